@@ -0,0 +1,72 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stacklok/toolhive-registry-server/internal/client"
+)
+
+var adminApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Create or update sources and registries from a YAML manifest",
+	Long: `Create or update the sources and registries declared in a YAML manifest. Sources
+and registries are written as in the configuration file of the server:
+
+  sources:
+    - name: internal
+      managed: {}
+  registries:
+    - name: default
+      sources: [internal]
+
+Sources are applied before registries, and applying stops at the first failure.
+Sources and registries missing from the manifest are left untouched.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runAdminApply,
+}
+
+func init() {
+	adminApplyCmd.Flags().StringP("file", "f", "", "Path to the manifest (YAML, - for standard input, required)")
+
+	if err := adminApplyCmd.MarkFlagRequired("file"); err != nil {
+		panic(err)
+	}
+}
+
+func runAdminApply(cmd *cobra.Command, _ []string) error {
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return fmt.Errorf("failed to get file flag: %w", err)
+	}
+	data, err := readInput(cmd, file)
+	if err != nil {
+		return err
+	}
+	manifest, err := client.ParseManifest(data)
+	if err != nil {
+		return err
+	}
+
+	c, err := newAdminClient(cmd)
+	if err != nil {
+		return err
+	}
+	results, applyErr := c.Apply(cmd.Context(), manifest)
+
+	err = printAdminOutput(cmd, results, []string{"KIND", "NAME", "ACTION"}, func(row func(...any)) {
+		for _, result := range results {
+			action := "updated"
+			if result.Created {
+				action = "created"
+			}
+			row(result.Kind, result.Name, action)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return applyErr
+}
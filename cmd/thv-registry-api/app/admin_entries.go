@@ -0,0 +1,214 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+var adminEntriesCmd = &cobra.Command{
+	Use:   "entries",
+	Short: "Manage the entries published to a registry server",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return cmd.Usage()
+	},
+}
+
+var adminEntriesPublishCmd = &cobra.Command{
+	Use:   "publish",
+	Short: "Publish an entry",
+	Long: `Publish a server, skill or plugin into the managed source. The file holds the
+JSON body of POST /v1/entries, e.g. {"server": {...}, "claims": {...}}, and is
+sent as is.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		file, err := cmd.Flags().GetString("file")
+		if err != nil {
+			return fmt.Errorf("failed to get file flag: %w", err)
+		}
+		request, err := readInput(cmd, file)
+		if err != nil {
+			return err
+		}
+
+		c, err := newAdminClient(cmd)
+		if err != nil {
+			return err
+		}
+		result, err := c.PublishEntry(cmd.Context(), request)
+		if err != nil {
+			return fmt.Errorf("failed to publish entry: %w", err)
+		}
+
+		if submission := result.Submission; submission != nil {
+			return printAdminOutput(cmd, submission, []string{"SUBMISSION", "TYPE", "NAME", "VERSION", "STATUS"},
+				func(row func(...any)) {
+					row(submission.ID, submission.EntryType, submission.Name, submission.Version, submission.Status)
+				})
+		}
+		return printAdminOutput(cmd, result.Entry, []string{"NAME", "VERSION", "STATUS"}, func(row func(...any)) {
+			var entry struct {
+				Namespace string `json:"namespace"`
+				Name      string `json:"name"`
+				Version   string `json:"version"`
+			}
+			_ = json.Unmarshal(result.Entry, &entry)
+			name := entry.Name
+			if entry.Namespace != "" {
+				name = entry.Namespace + "/" + entry.Name
+			}
+			row(name, entry.Version, "published")
+		})
+	},
+}
+
+var adminEntriesBulkCmd = &cobra.Command{
+	Use:   "bulk",
+	Short: "Publish the entries of an upstream registry document",
+	Long: `Publish the servers, skills and plugins of an upstream registry document into the
+managed source, as exported by GET /v1/sources/{name}/export. Claims are read
+from the io.github.stacklok/claims key of the _meta of each entry.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		file, err := cmd.Flags().GetString("file")
+		if err != nil {
+			return fmt.Errorf("failed to get file flag: %w", err)
+		}
+		atomic, err := cmd.Flags().GetBool("atomic")
+		if err != nil {
+			return fmt.Errorf("failed to get atomic flag: %w", err)
+		}
+		document, err := readInput(cmd, file)
+		if err != nil {
+			return err
+		}
+
+		c, err := newAdminClient(cmd)
+		if err != nil {
+			return err
+		}
+		result, publishErr := c.BulkPublishEntries(cmd.Context(), document, atomic)
+		if result == nil {
+			return fmt.Errorf("failed to publish entries: %w", publishErr)
+		}
+
+		header := []string{"TYPE", "NAME", "VERSION", "STATUS", "SUBMISSION", "ERROR"}
+		err = printAdminOutput(cmd, result, header, func(row func(...any)) {
+			for _, entry := range result.Results {
+				name := entry.Name
+				if entry.Namespace != "" {
+					name = entry.Namespace + "/" + entry.Name
+				}
+				row(entry.EntryType, name, entry.Version, entry.Status, entry.SubmissionID, entry.Error)
+			}
+		})
+		if err != nil {
+			return err
+		}
+		if publishErr != nil {
+			return fmt.Errorf("bulk publish rolled back: %w", publishErr)
+		}
+		return nil
+	},
+}
+
+var adminEntriesDeleteCmd = &cobra.Command{
+	Use:          "delete <type> <name> <version>",
+	Short:        "Delete a published version of an entry",
+	Long:         `Delete a published version of a server, skill or plugin. The type is server, skill or plugin.`,
+	Args:         cobra.ExactArgs(3),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := newAdminClient(cmd)
+		if err != nil {
+			return err
+		}
+		if err := c.DeleteEntryVersion(cmd.Context(), args[0], args[1], args[2]); err != nil {
+			return fmt.Errorf("failed to delete entry: %w", err)
+		}
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s %s %s deleted\n", args[0], args[1], args[2])
+		return nil
+	},
+}
+
+var adminEntriesClaimsCmd = &cobra.Command{
+	Use:          "claims <type> <name>",
+	Short:        "Show the claims of a published entry",
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := newAdminClient(cmd)
+		if err != nil {
+			return err
+		}
+		claims, err := c.GetEntryClaims(cmd.Context(), args[0], args[1])
+		if err != nil {
+			return fmt.Errorf("failed to get entry claims: %w", err)
+		}
+		return printAdminOutput(cmd, claims, []string{"CLAIM", "VALUE"}, func(row func(...any)) {
+			keys := make([]string, 0, len(claims))
+			for key := range claims {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				row(key, claims[key])
+			}
+		})
+	},
+}
+
+var adminEntriesSetClaimsCmd = &cobra.Command{
+	Use:          "set-claims <type> <name>",
+	Short:        "Replace the claims of a published entry",
+	Example:      `  thv-registry-api admin entries set-claims server com.example/weather --claims '{"org": "example"}'`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		claimsJSON, err := cmd.Flags().GetString("claims")
+		if err != nil {
+			return fmt.Errorf("failed to get claims flag: %w", err)
+		}
+		var claims map[string]any
+		if err := json.Unmarshal([]byte(claimsJSON), &claims); err != nil {
+			return fmt.Errorf("claims must be a JSON object: %w", err)
+		}
+
+		c, err := newAdminClient(cmd)
+		if err != nil {
+			return err
+		}
+		if err := c.UpdateEntryClaims(cmd.Context(), args[0], args[1], claims); err != nil {
+			return fmt.Errorf("failed to update entry claims: %w", err)
+		}
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Claims of %s %s updated\n", args[0], args[1])
+		return nil
+	},
+}
+
+func init() {
+	adminEntriesPublishCmd.Flags().StringP("file", "f", "", "Path to the publish request (JSON, - for standard input, required)")
+	adminEntriesBulkCmd.Flags().StringP("file", "f", "",
+		"Path to the upstream registry document (JSON, - for standard input, required)")
+	adminEntriesBulkCmd.Flags().Bool("atomic", false, "Publish all entries or none")
+	adminEntriesSetClaimsCmd.Flags().String("claims", "", "Claims to set, as a JSON object (required)")
+
+	for _, cmd := range []*cobra.Command{adminEntriesPublishCmd, adminEntriesBulkCmd} {
+		if err := cmd.MarkFlagRequired("file"); err != nil {
+			panic(err)
+		}
+	}
+	if err := adminEntriesSetClaimsCmd.MarkFlagRequired("claims"); err != nil {
+		panic(err)
+	}
+
+	adminEntriesCmd.AddCommand(adminEntriesPublishCmd)
+	adminEntriesCmd.AddCommand(adminEntriesBulkCmd)
+	adminEntriesCmd.AddCommand(adminEntriesDeleteCmd)
+	adminEntriesCmd.AddCommand(adminEntriesClaimsCmd)
+	adminEntriesCmd.AddCommand(adminEntriesSetClaimsCmd)
+}
@@ -0,0 +1,110 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+var adminRegistriesCmd = &cobra.Command{
+	Use:   "registries",
+	Short: "Manage the registries of a registry server",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return cmd.Usage()
+	},
+}
+
+var adminRegistriesListCmd = &cobra.Command{
+	Use:          "list",
+	Short:        "List registries",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		c, err := newAdminClient(cmd)
+		if err != nil {
+			return err
+		}
+		registries, err := c.ListRegistries(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("failed to list registries: %w", err)
+		}
+		return printRegistries(cmd, registries, registries)
+	},
+}
+
+var adminRegistriesGetCmd = &cobra.Command{
+	Use:          "get <name>",
+	Short:        "Show a registry",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := newAdminClient(cmd)
+		if err != nil {
+			return err
+		}
+		registry, err := c.GetRegistry(cmd.Context(), args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get registry: %w", err)
+		}
+		return printRegistries(cmd, registry, []service.RegistryInfo{*registry})
+	},
+}
+
+var adminRegistriesDeleteCmd = &cobra.Command{
+	Use:          "delete <name>",
+	Short:        "Delete a registry",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := newAdminClient(cmd)
+		if err != nil {
+			return err
+		}
+		if err := c.DeleteRegistry(cmd.Context(), args[0]); err != nil {
+			return fmt.Errorf("failed to delete registry: %w", err)
+		}
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Registry %s deleted\n", args[0])
+		return nil
+	},
+}
+
+var adminRegistriesEntriesCmd = &cobra.Command{
+	Use:          "entries <name>",
+	Short:        "List the entries of a registry",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := newAdminClient(cmd)
+		if err != nil {
+			return err
+		}
+		entries, err := c.ListRegistryEntries(cmd.Context(), args[0])
+		if err != nil {
+			return fmt.Errorf("failed to list registry entries: %w", err)
+		}
+		return printAdminOutput(cmd, entries, []string{"TYPE", "NAME", "VERSION", "SOURCE"}, func(row func(...any)) {
+			for _, entry := range entries {
+				row(entry.EntryType, entry.Name, entry.Version, entry.SourceName)
+			}
+		})
+	},
+}
+
+func init() {
+	adminRegistriesCmd.AddCommand(adminRegistriesListCmd)
+	adminRegistriesCmd.AddCommand(adminRegistriesGetCmd)
+	adminRegistriesCmd.AddCommand(adminRegistriesDeleteCmd)
+	adminRegistriesCmd.AddCommand(adminRegistriesEntriesCmd)
+}
+
+// printRegistries writes v as JSON, or the given registries as a table.
+func printRegistries(cmd *cobra.Command, v any, registries []service.RegistryInfo) error {
+	return printAdminOutput(cmd, v, []string{"NAME", "CREATED BY", "SOURCES"}, func(row func(...any)) {
+		for _, registry := range registries {
+			row(registry.Name, registry.CreationType, strings.Join(registry.Sources, ","))
+		}
+	})
+}
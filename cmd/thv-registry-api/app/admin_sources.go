@@ -0,0 +1,141 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+var adminSourcesCmd = &cobra.Command{
+	Use:   "sources",
+	Short: "Manage the sources of a registry server",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return cmd.Usage()
+	},
+}
+
+var adminSourcesListCmd = &cobra.Command{
+	Use:          "list",
+	Short:        "List sources",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		c, err := newAdminClient(cmd)
+		if err != nil {
+			return err
+		}
+		sources, err := c.ListSources(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("failed to list sources: %w", err)
+		}
+		return printSources(cmd, sources, sources)
+	},
+}
+
+var adminSourcesGetCmd = &cobra.Command{
+	Use:          "get <name>",
+	Short:        "Show a source",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := newAdminClient(cmd)
+		if err != nil {
+			return err
+		}
+		source, err := c.GetSource(cmd.Context(), args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get source: %w", err)
+		}
+		return printSources(cmd, source, []service.SourceInfo{*source})
+	},
+}
+
+var adminSourcesDeleteCmd = &cobra.Command{
+	Use:          "delete <name>",
+	Short:        "Delete a source",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := newAdminClient(cmd)
+		if err != nil {
+			return err
+		}
+		if err := c.DeleteSource(cmd.Context(), args[0]); err != nil {
+			return fmt.Errorf("failed to delete source: %w", err)
+		}
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Source %s deleted\n", args[0])
+		return nil
+	},
+}
+
+var adminSourcesEntriesCmd = &cobra.Command{
+	Use:          "entries <name>",
+	Short:        "List the entries of a source",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := newAdminClient(cmd)
+		if err != nil {
+			return err
+		}
+		entries, err := c.ListSourceEntries(cmd.Context(), args[0])
+		if err != nil {
+			return fmt.Errorf("failed to list source entries: %w", err)
+		}
+		return printAdminOutput(cmd, entries, []string{"TYPE", "NAME", "VERSIONS"}, func(row func(...any)) {
+			for _, entry := range entries {
+				row(entry.EntryType, entry.Name, len(entry.Versions))
+			}
+		})
+	},
+}
+
+var adminSourcesSyncCmd = &cobra.Command{
+	Use:          "sync <name>",
+	Short:        "Request a sync of a source",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := newAdminClient(cmd)
+		if err != nil {
+			return err
+		}
+		request, err := c.SyncSource(cmd.Context(), args[0])
+		if err != nil {
+			return fmt.Errorf("failed to request sync: %w", err)
+		}
+		return printAdminOutput(cmd, request, []string{"ID", "SOURCE", "STATUS", "REQUESTED"}, func(row func(...any)) {
+			row(request.ID, request.SourceName, request.Status, request.RequestedAt.Format(time.RFC3339))
+		})
+	},
+}
+
+func init() {
+	adminSourcesCmd.AddCommand(adminSourcesListCmd)
+	adminSourcesCmd.AddCommand(adminSourcesGetCmd)
+	adminSourcesCmd.AddCommand(adminSourcesDeleteCmd)
+	adminSourcesCmd.AddCommand(adminSourcesEntriesCmd)
+	adminSourcesCmd.AddCommand(adminSourcesSyncCmd)
+}
+
+// printSources writes v as JSON, or the given sources as a table.
+func printSources(cmd *cobra.Command, v any, sources []service.SourceInfo) error {
+	header := []string{"NAME", "TYPE", "CREATED BY", "PHASE", "SERVERS", "SKILLS", "PLUGINS", "LAST SYNC"}
+	return printAdminOutput(cmd, v, header, func(row func(...any)) {
+		for _, source := range sources {
+			var phase, lastSync string
+			var servers, skills, plugins int
+			if status := source.SyncStatus; status != nil {
+				phase = status.Phase
+				servers, skills, plugins = status.ServerCount, status.SkillCount, status.PluginCount
+				if status.LastSyncTime != nil {
+					lastSync = status.LastSyncTime.Format(time.RFC3339)
+				}
+			}
+			row(source.Name, source.SourceType, source.CreationType, phase, servers, skills, plugins, lastSync)
+		}
+	})
+}
@@ -0,0 +1,148 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/client"
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// The admin commands are package level commands sharing their flags, so the
+// test cases run sequentially and set every flag they depend on.
+func TestAdminCommands(t *testing.T) {
+	lastSync := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	sources := []service.SourceInfo{{
+		Name:         "upstream",
+		Type:         "REMOTE",
+		CreationType: service.CreationTypeCONFIG,
+		SourceType:   config.SourceTypeGit,
+		SyncStatus: &service.SourceSyncStatus{
+			Phase:        "complete",
+			LastSyncTime: &lastSync,
+			ServerCount:  3,
+			SkillCount:   1,
+		},
+	}}
+	registry := service.RegistryInfo{
+		Name:         "default",
+		CreationType: service.CreationTypeAPI,
+		Sources:      []string{"internal", "upstream"},
+	}
+	claims := map[string]any{"team": "platform", "org": "example"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/sources", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(service.SourceListResponse{Sources: sources})
+	})
+	mux.HandleFunc("GET /v1/registries/{name}", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(registry)
+	})
+	mux.HandleFunc("GET /v1/entries/{type}/{name}/claims", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/entries/server/com.example%2Fweather/claims", r.URL.EscapedPath())
+		_ = json.NewEncoder(w).Encode(map[string]any{"claims": claims})
+	})
+	mux.HandleFunc("PUT /v1/sources/{name}", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("PUT /v1/registries/{name}", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("secret\n"), 0o600))
+	manifestFile := filepath.Join(dir, "manifest.yaml")
+	require.NoError(t, os.WriteFile(manifestFile, []byte(`
+sources:
+  - name: internal
+    managed: {}
+registries:
+  - name: default
+    sources: [internal]
+`), 0o600))
+
+	applyResults := []client.ApplyResult{
+		{Kind: client.KindSource, Name: "internal", Created: true},
+		{Kind: client.KindRegistry, Name: "default", Created: false},
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantJSON any
+		// wantTable is the output of the command with -o table
+		wantTable string
+	}{
+		{
+			name:     "sources list",
+			args:     []string{"sources", "list"},
+			wantJSON: sources,
+			wantTable: "NAME       TYPE   CREATED BY   PHASE      SERVERS   SKILLS   PLUGINS   LAST SYNC\n" +
+				"upstream   git    CONFIG       complete   3         1        0         2026-10-01T12:00:00Z\n",
+		},
+		{
+			name:     "registries get",
+			args:     []string{"registries", "get", "default"},
+			wantJSON: registry,
+			wantTable: "NAME      CREATED BY   SOURCES\n" +
+				"default   API          internal,upstream\n",
+		},
+		{
+			name:     "entries claims",
+			args:     []string{"entries", "claims", "server", "com.example/weather"},
+			wantJSON: claims,
+			wantTable: "CLAIM   VALUE\n" +
+				"org     example\n" +
+				"team    platform\n",
+		},
+		{
+			name:     "apply",
+			args:     []string{"apply", "-f", manifestFile},
+			wantJSON: applyResults,
+			wantTable: "KIND       NAME       ACTION\n" +
+				"source     internal   created\n" +
+				"registry   default    updated\n",
+		},
+	}
+
+	for _, tt := range tests {
+		for _, output := range []string{outputTable, outputJSON} {
+			t.Run(tt.name+" "+output, func(t *testing.T) {
+				var out bytes.Buffer
+				adminCmd.SetOut(&out)
+				adminCmd.SetArgs(append(tt.args, "--server", server.URL, "--token-file", tokenFile, "-o", output))
+				t.Cleanup(func() {
+					adminCmd.SetOut(nil)
+					adminCmd.SetArgs(nil)
+				})
+
+				require.NoError(t, adminCmd.Execute())
+
+				if output == outputTable {
+					assert.Equal(t, tt.wantTable, out.String())
+					return
+				}
+				want, err := json.Marshal(tt.wantJSON)
+				require.NoError(t, err)
+				assert.JSONEq(t, string(want), out.String())
+			})
+		}
+	}
+}
@@ -40,6 +40,9 @@ func NewRootCmd() *cobra.Command {
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(migrateCmd)
 	rootCmd.AddCommand(primeDbCmd)
+	rootCmd.AddCommand(adminCmd)
+	rootCmd.AddCommand(validateCmd)
+	rootCmd.AddCommand(syncCmd)
 
 	return rootCmd
 }
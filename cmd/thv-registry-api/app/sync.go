@@ -0,0 +1,280 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stacklok/toolhive-registry-server/internal/app/storage"
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/sources"
+	"github.com/stacklok/toolhive-registry-server/internal/status"
+	"github.com/stacklok/toolhive-registry-server/internal/sync"
+	"github.com/stacklok/toolhive-registry-server/internal/sync/state"
+)
+
+// exitCodeChanges is the exit code of 'sync --dry-run --exit-code' when the
+// sync would change the stored data
+const exitCodeChanges = 2
+
+// ExitError is returned by commands that exit with a status code other than 1
+type ExitError struct {
+	Code    int
+	Message string
+}
+
+// Error implements the error interface.
+func (e *ExitError) Error() string {
+	return e.Message
+}
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Preview the sync of a source",
+	Long: `Preview the sync of a source defined in the configuration file. With --dry-run,
+the data of the source is fetched, validated and filtered as the server would,
+and the entry versions the sync would add, update and remove are compared with
+the data stored by the last sync of the source. The database is only read, but
+it must be reachable, even for a source that was never synced. A source whose
+stored entries have no digests recorded, such as one last synced by an older
+server, must be synced once by the server before it can be compared.
+
+With --exit-code, the command exits with status 2 when the sync would change
+the stored data, so that it can gate changes to the configuration in CI.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runSync,
+}
+
+func init() {
+	syncCmd.Flags().String("config", "", "Path to configuration file (YAML format, required)")
+	syncCmd.Flags().String("source", "", "Name of the source to sync (required)")
+	syncCmd.Flags().Bool("dry-run", false, "Report the changes the sync would make without storing them")
+	syncCmd.Flags().Bool("exit-code", false, "Exit with status 2 when the sync would change the stored data")
+	syncCmd.Flags().StringP("output", "o", outputTable, "Output format (table or json)")
+
+	for _, name := range []string{"config", "source"} {
+		if err := syncCmd.MarkFlagRequired(name); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// syncEntryCounts counts the entry versions of one type a sync would change
+type syncEntryCounts struct {
+	Added   int `json:"added"`
+	Updated int `json:"updated"`
+	Removed int `json:"removed"`
+}
+
+// syncEntryChange is an entry version a sync would change
+type syncEntryChange struct {
+	Change    string `json:"change"`
+	EntryType string `json:"entryType"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+}
+
+// syncDryRunOutput is the report of 'sync --dry-run'
+type syncDryRunOutput struct {
+	Source      string                     `json:"source"`
+	Hash        string                     `json:"hash"`
+	CommitSHA   string                     `json:"commitSha,omitempty"`
+	ServerCount int                        `json:"serverCount"`
+	SkillCount  int                        `json:"skillCount"`
+	PluginCount int                        `json:"pluginCount"`
+	Summary     map[string]syncEntryCounts `json:"summary"`
+	Changes     []syncEntryChange          `json:"changes"`
+}
+
+func runSync(cmd *cobra.Command, _ []string) error {
+	ctx := context.Background()
+
+	configPath, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return fmt.Errorf("failed to get config flag: %w", err)
+	}
+	sourceName, err := cmd.Flags().GetString("source")
+	if err != nil {
+		return fmt.Errorf("failed to get source flag: %w", err)
+	}
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("failed to get dry-run flag: %w", err)
+	}
+	exitCode, err := cmd.Flags().GetBool("exit-code")
+	if err != nil {
+		return fmt.Errorf("failed to get exit-code flag: %w", err)
+	}
+	output, err := adminOutput(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Sources are synced by the running server; use 'admin sources sync' to request one
+	if !dryRun {
+		return fmt.Errorf("only --dry-run is supported; use 'admin sources sync' to sync a source of a running server")
+	}
+
+	cfg, err := config.LoadConfig(config.WithConfigPath(configPath))
+	if err != nil {
+		return err
+	}
+	var source *config.SourceConfig
+	for i := range cfg.Sources {
+		if cfg.Sources[i].Name == sourceName {
+			source = &cfg.Sources[i]
+			break
+		}
+	}
+	if source == nil {
+		return fmt.Errorf("source %s is not defined in %s", sourceName, configPath)
+	}
+
+	previous, err := storedEntryDigests(ctx, cfg, sourceName)
+	if err != nil {
+		return err
+	}
+
+	preview, syncErr := sync.PreviewSync(ctx, sources.NewRegistryHandlerFactory(), source, previous)
+	if syncErr != nil {
+		return syncErr
+	}
+
+	return writeSyncDryRunReport(cmd, newSyncDryRunOutput(sourceName, preview), output, exitCode)
+}
+
+// writeSyncDryRunReport writes the report of a sync preview in the output
+// format. With exitCode, it returns an ExitError when the sync would change the
+// stored data.
+func writeSyncDryRunReport(cmd *cobra.Command, report *syncDryRunOutput, output string, exitCode bool) error {
+	if output == outputJSON {
+		if err := writeJSON(cmd.OutOrStdout(), report); err != nil {
+			return err
+		}
+	} else if err := writeSyncDryRunTable(cmd, report); err != nil {
+		return err
+	}
+
+	if exitCode && len(report.Changes) > 0 {
+		return &ExitError{
+			Code:    exitCodeChanges,
+			Message: fmt.Sprintf("sync of source %s would change %d entry versions", report.Source, len(report.Changes)),
+		}
+	}
+	return nil
+}
+
+// storedEntryDigests returns the entry digests stored by the last sync of the
+// source, or nil when the source has no entries stored. A source with entries
+// stored but no digests, such as one last synced before they were recorded,
+// cannot be compared: every entry would be reported as added.
+func storedEntryDigests(ctx context.Context, cfg *config.Config, sourceName string) (status.EntryDigests, error) {
+	factory, err := storage.NewDatabaseFactory(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer factory.Cleanup()
+
+	stateService, err := factory.CreateStateService(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create state service: %w", err)
+	}
+	digests, err := stateService.GetEntryDigests(ctx, sourceName)
+	if err != nil || digests != nil {
+		return digests, err
+	}
+
+	syncStatus, err := stateService.GetSyncStatus(ctx, sourceName)
+	if errors.Is(err, state.ErrRegistryNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sync status: %w", err)
+	}
+	return nil, checkUndigestedEntries(sourceName, syncStatus)
+}
+
+// checkUndigestedEntries returns an error when a source without stored entry
+// digests has entries stored.
+func checkUndigestedEntries(sourceName string, syncStatus *status.SyncStatus) error {
+	stored := syncStatus.ServerCount + syncStatus.SkillCount + syncStatus.PluginCount
+	if stored == 0 {
+		return nil
+	}
+	return fmt.Errorf("source %s has %d entry versions stored but no entry digests to compare them with; "+
+		"sync the source once with the server to record them", sourceName, stored)
+}
+
+// newSyncDryRunOutput returns the report of a sync preview.
+func newSyncDryRunOutput(sourceName string, preview *sync.Preview) *syncDryRunOutput {
+	counts := func(c status.EntryChanges) syncEntryCounts {
+		return syncEntryCounts{Added: c.Added, Updated: c.Updated, Removed: c.Removed}
+	}
+
+	report := &syncDryRunOutput{
+		Source:      sourceName,
+		Hash:        preview.Result.Hash,
+		CommitSHA:   preview.Result.CommitSHA,
+		ServerCount: preview.Result.ServerCount,
+		SkillCount:  preview.Result.SkillCount,
+		PluginCount: preview.Result.PluginCount,
+		Summary: map[string]syncEntryCounts{
+			"servers": counts(preview.Changes.Servers),
+			"skills":  counts(preview.Changes.Skills),
+			"plugins": counts(preview.Changes.Plugins),
+		},
+		Changes: make([]syncEntryChange, 0, len(preview.Changes.Entries)),
+	}
+	for _, entry := range preview.Changes.Entries {
+		change := "added"
+		switch entry.Type {
+		case status.EntryUpdated:
+			change = "updated"
+		case status.EntryDeleted:
+			change = "removed"
+		}
+		report.Changes = append(report.Changes, syncEntryChange{
+			Change:    change,
+			EntryType: entry.EntryType,
+			Namespace: entry.Namespace,
+			Name:      entry.Name,
+			Version:   entry.Version,
+		})
+	}
+	return report
+}
+
+// writeSyncDryRunTable writes the changes of a sync preview as a table,
+// followed by a summary line per entry type.
+func writeSyncDryRunTable(cmd *cobra.Command, report *syncDryRunOutput) error {
+	out := cmd.OutOrStdout()
+	if len(report.Changes) == 0 {
+		_, _ = fmt.Fprintf(out, "No changes: source %s is up to date\n", report.Source)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	writeRow(w, "CHANGE", "TYPE", "NAME", "VERSION")
+	for _, change := range report.Changes {
+		name := change.Name
+		if change.Namespace != "" {
+			name = change.Namespace + "/" + change.Name
+		}
+		writeRow(w, change.Change, change.EntryType, name, change.Version)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(out)
+	for _, entryType := range []string{"servers", "skills", "plugins"} {
+		c := report.Summary[entryType]
+		_, _ = fmt.Fprintf(out, "%s: %d added, %d updated, %d removed\n", entryType, c.Added, c.Updated, c.Removed)
+	}
+	return nil
+}
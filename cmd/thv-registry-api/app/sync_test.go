@@ -0,0 +1,162 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/status"
+	"github.com/stacklok/toolhive-registry-server/internal/sync"
+)
+
+func TestNewSyncDryRunOutput(t *testing.T) {
+	t.Parallel()
+
+	preview := &sync.Preview{
+		Result: &sync.Result{
+			Hash:        "abc123",
+			CommitSHA:   "0123456789abcdef",
+			ServerCount: 2,
+			SkillCount:  1,
+		},
+		Changes: status.SyncChanges{
+			Servers: status.EntryChanges{Added: 1, Updated: 1},
+			Skills:  status.EntryChanges{Removed: 1},
+			Entries: []status.EntryChange{
+				{Type: status.EntryCreated, EntryType: "server", Name: "io.github.acme/fetch", Version: "1.0.0"},
+				{Type: status.EntryUpdated, EntryType: "server", Name: "io.github.acme/search", Version: "2.1.0"},
+				{Type: status.EntryDeleted, EntryType: "skill", Namespace: "io.github.acme", Name: "review", Version: "0.3.0"},
+			},
+		},
+	}
+
+	report := newSyncDryRunOutput("upstream", preview)
+
+	assert.Equal(t, &syncDryRunOutput{
+		Source:      "upstream",
+		Hash:        "abc123",
+		CommitSHA:   "0123456789abcdef",
+		ServerCount: 2,
+		SkillCount:  1,
+		Summary: map[string]syncEntryCounts{
+			"servers": {Added: 1, Updated: 1},
+			"skills":  {Removed: 1},
+			"plugins": {},
+		},
+		Changes: []syncEntryChange{
+			{Change: "added", EntryType: "server", Name: "io.github.acme/fetch", Version: "1.0.0"},
+			{Change: "updated", EntryType: "server", Name: "io.github.acme/search", Version: "2.1.0"},
+			{Change: "removed", EntryType: "skill", Namespace: "io.github.acme", Name: "review", Version: "0.3.0"},
+		},
+	}, report)
+}
+
+func TestWriteSyncDryRunReport(t *testing.T) {
+	t.Parallel()
+
+	changed := &syncDryRunOutput{
+		Source: "upstream",
+		Hash:   "abc123",
+		Summary: map[string]syncEntryCounts{
+			"servers": {Added: 1},
+			"skills":  {Removed: 1},
+		},
+		Changes: []syncEntryChange{
+			{Change: "added", EntryType: "server", Name: "io.github.acme/fetch", Version: "1.0.0"},
+			{Change: "removed", EntryType: "skill", Namespace: "io.github.acme", Name: "review", Version: "0.3.0"},
+		},
+	}
+	unchanged := &syncDryRunOutput{
+		Source:  "upstream",
+		Hash:    "abc123",
+		Summary: map[string]syncEntryCounts{},
+		Changes: []syncEntryChange{},
+	}
+
+	tests := []struct {
+		name         string
+		report       *syncDryRunOutput
+		output       string
+		exitCode     bool
+		wantOutput   string
+		wantExitCode int
+	}{
+		{
+			name:   "table of changes",
+			report: changed,
+			output: outputTable,
+			wantOutput: "CHANGE    TYPE     NAME                    VERSION\n" +
+				"added     server   io.github.acme/fetch    1.0.0\n" +
+				"removed   skill    io.github.acme/review   0.3.0\n" +
+				"\n" +
+				"servers: 1 added, 0 updated, 0 removed\n" +
+				"skills: 0 added, 0 updated, 1 removed\n" +
+				"plugins: 0 added, 0 updated, 0 removed\n",
+		},
+		{
+			name:       "table without changes",
+			report:     unchanged,
+			output:     outputTable,
+			exitCode:   true,
+			wantOutput: "No changes: source upstream is up to date\n",
+		},
+		{
+			name:         "changes exit with status 2",
+			report:       changed,
+			output:       outputTable,
+			exitCode:     true,
+			wantExitCode: exitCodeChanges,
+		},
+		{
+			name:         "json changes exit with status 2",
+			report:       changed,
+			output:       outputJSON,
+			exitCode:     true,
+			wantExitCode: exitCodeChanges,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := &cobra.Command{}
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+
+			err := writeSyncDryRunReport(cmd, tt.report, tt.output, tt.exitCode)
+			if tt.wantExitCode != 0 {
+				var exitErr *ExitError
+				require.True(t, errors.As(err, &exitErr))
+				assert.Equal(t, tt.wantExitCode, exitErr.Code)
+				assert.Equal(t, "sync of source upstream would change 2 entry versions", exitErr.Message)
+			} else {
+				require.NoError(t, err)
+			}
+
+			if tt.output == outputJSON {
+				var decoded syncDryRunOutput
+				require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+				assert.Equal(t, *tt.report, decoded)
+			}
+			if tt.wantOutput != "" {
+				assert.Equal(t, tt.wantOutput, out.String())
+			}
+		})
+	}
+}
+
+func TestCheckUndigestedEntries(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, checkUndigestedEntries("upstream", &status.SyncStatus{}))
+
+	err := checkUndigestedEntries("upstream", &status.SyncStatus{ServerCount: 2, PluginCount: 1})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "source upstream has 3 entry versions stored but no entry digests")
+}
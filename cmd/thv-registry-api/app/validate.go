@@ -0,0 +1,101 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/filtering"
+	"github.com/stacklok/toolhive-registry-server/internal/notifications"
+	"github.com/stacklok/toolhive-registry-server/internal/signing"
+	"github.com/stacklok/toolhive-registry-server/internal/sources"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate a configuration file",
+	Long: `Validate a configuration file without starting the server or connecting to the
+database. This runs the validation 'serve' runs on startup, including the
+event types and secrets of the webhooks and the replication signing key, and
+checks the settings of every synced source the way its sync would, without
+fetching its data. Use 'sync --dry-run' to also fetch and filter the data of a source.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runValidate,
+}
+
+func init() {
+	validateCmd.Flags().String("config", "", "Path to configuration file (YAML format, required)")
+
+	if err := validateCmd.MarkFlagRequired("config"); err != nil {
+		panic(err)
+	}
+}
+
+func runValidate(cmd *cobra.Command, _ []string) error {
+	configPath, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return fmt.Errorf("failed to get config flag: %w", err)
+	}
+
+	cfg, err := config.LoadConfig(config.WithConfigPath(configPath))
+	if err != nil {
+		return err
+	}
+
+	factory := sources.NewRegistryHandlerFactory()
+	for i := range cfg.Sources {
+		source := &cfg.Sources[i]
+		if source.IsNonSyncedSource() {
+			continue
+		}
+		handler, err := factory.CreateHandler(source)
+		if err != nil {
+			return fmt.Errorf("source %s: %w", source.Name, err)
+		}
+		if err := handler.Validate(source); err != nil {
+			return fmt.Errorf("source %s: %w", source.Name, err)
+		}
+		if err := filtering.ValidateFilterConfig(source.Filter); err != nil {
+			return fmt.Errorf("source %s: invalid filter: %w", source.Name, err)
+		}
+	}
+
+	// 'serve' also rejects the webhooks and the replication signing key it cannot use
+	if _, err := notifications.NewOutbox(cfg); err != nil {
+		return err
+	}
+	if err := validateWebhookSecrets(cfg); err != nil {
+		return err
+	}
+	if cfg.Replication != nil {
+		signingKey, err := cfg.Replication.GetSigningKey()
+		if err != nil {
+			return err
+		}
+		if _, err := signing.NewSigner(signingKey); err != nil {
+			return fmt.Errorf("invalid replication signing key: %w", err)
+		}
+	}
+
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Configuration %s is valid: %d sources, %d registries\n",
+		configPath, len(cfg.Sources), len(cfg.Registries))
+	return nil
+}
+
+// validateWebhookSecrets reads the secret of every webhook, which the
+// notification dispatcher of 'serve' requires to be readable and not empty.
+func validateWebhookSecrets(cfg *config.Config) error {
+	webhooks := cfg.GetWebhooks()
+	for i := range webhooks {
+		secret, err := webhooks[i].GetSecret()
+		if err != nil {
+			return fmt.Errorf("webhook %s: %w", webhooks[i].Name, err)
+		}
+		if secret == "" {
+			return fmt.Errorf("webhook %s: secret is empty", webhooks[i].Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validateTestConfig is a configuration with a file source, to which the test
+// cases append their source filter and notifications
+const validateTestConfig = `sources:
+  - name: local-file
+    file:
+      path: ./data/registry.json
+    syncPolicy:
+      interval: "5m"
+%s
+registries:
+  - name: default
+    sources: ["local-file"]
+
+auth:
+  mode: anonymous
+
+database:
+  host: localhost
+  port: 5432
+  user: thv_user
+  database: toolhive_registry
+  sslMode: disable
+%s`
+
+func TestRunValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		filter        string
+		notifications string
+		secret        string
+		wantErr       string
+	}{
+		{
+			name: "valid configuration",
+		},
+		{
+			name: "valid filter and webhook",
+			filter: `    filter:
+      expressions:
+        include:
+          - 'entry.type == "server"'`,
+			notifications: `notifications:
+  webhooks:
+    - name: audit
+      url: https://hooks.example.com/registry
+      events: ["source.sync"]
+      secretFile: %s`,
+			secret: "s3cret",
+		},
+		{
+			name: "bad filter expression",
+			filter: `    filter:
+      expressions:
+        include:
+          - 'entry.type =='`,
+			wantErr: "invalid filter",
+		},
+		{
+			name: "bad webhook event",
+			notifications: `notifications:
+  webhooks:
+    - name: audit
+      url: https://hooks.example.com/registry
+      events: ["source.synced"]
+      secretFile: %s`,
+			secret:  "s3cret",
+			wantErr: `unsupported event type "source.synced"`,
+		},
+		{
+			name: "empty webhook secret",
+			notifications: `notifications:
+  webhooks:
+    - name: audit
+      url: https://hooks.example.com/registry
+      secretFile: %s`,
+			wantErr: "webhook audit: secret is empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			notifications := tt.notifications
+			if notifications != "" {
+				secretFile := filepath.Join(dir, "webhook-secret")
+				require.NoError(t, os.WriteFile(secretFile, []byte(tt.secret), 0o600))
+				notifications = fmt.Sprintf(notifications, secretFile)
+			}
+			configPath := filepath.Join(dir, "config.yaml")
+			require.NoError(t, os.WriteFile(configPath,
+				[]byte(fmt.Sprintf(validateTestConfig, tt.filter, notifications)), 0o600))
+
+			cmd := &cobra.Command{}
+			cmd.Flags().String("config", configPath, "")
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+
+			err := runValidate(cmd, nil)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf("Configuration %s is valid: 1 sources, 1 registries\n", configPath), out.String())
+		})
+	}
+}
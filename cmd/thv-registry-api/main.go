@@ -3,6 +3,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"os"
 	"strings"
@@ -89,6 +90,10 @@ func main() {
 	slog.Info("Starting ToolHive Registry API server")
 
 	if err := app.NewRootCmd().Execute(); err != nil {
+		var exitErr *app.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(1)
 	}
 }
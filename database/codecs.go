@@ -11,7 +11,7 @@ import (
 // enumArrayTypes are the custom PostgreSQL enum types whose array forms need a
 // codec registered on every pgx connection. pgx cannot infer how to encode Go
 // slices of these enums into PostgreSQL array types on its own.
-var enumArrayTypes = []string{"sync_status", "icon_theme", "creation_type"}
+var enumArrayTypes = []string{"sync_status", "icon_theme", "creation_type", "entry_type", "entry_change_type"}
 
 // RegisterEnumArrayCodecs registers pgx array codecs for the schema's custom
 // enum types. It is the AfterConnect hook used by both the production
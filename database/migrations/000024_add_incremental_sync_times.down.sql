@@ -0,0 +1,3 @@
+-- Remove incremental sync tracking columns from registry_sync table
+ALTER TABLE registry_sync DROP COLUMN last_full_sync_at;
+ALTER TABLE registry_sync DROP COLUMN last_success_at;
@@ -0,0 +1,5 @@
+-- Track the start of the last successful sync and the last full sync so API
+-- sources can fetch only servers updated since the previous sync and still run
+-- a periodic full reconciliation.
+ALTER TABLE registry_sync ADD COLUMN last_success_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE registry_sync ADD COLUMN last_full_sync_at TIMESTAMP WITH TIME ZONE;
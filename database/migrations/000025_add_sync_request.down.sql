@@ -0,0 +1,3 @@
+-- Remove manual sync request tracking
+DROP TABLE IF EXISTS sync_request;
+DROP TYPE IF EXISTS sync_request_status;
@@ -0,0 +1,25 @@
+-- Manual sync requests queued through the v1 API. The coordinator picks up
+-- sources with a PENDING request regardless of their sync schedule, and the
+-- request row records the outcome so callers can poll it by ID.
+CREATE TYPE sync_request_status AS ENUM (
+    'PENDING',
+    'IN_PROGRESS',
+    'COMPLETED',
+    'FAILED'
+);
+
+CREATE TABLE sync_request (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    source_id    UUID NOT NULL REFERENCES source(id) ON DELETE CASCADE,
+    status       sync_request_status NOT NULL DEFAULT 'PENDING',
+    error_msg    TEXT, -- Populated if status = 'FAILED'
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at   TIMESTAMP WITH TIME ZONE,
+    ended_at     TIMESTAMP WITH TIME ZONE
+);
+
+-- At most one PENDING request per source: repeated requests before the
+-- coordinator picks the source up collapse into the existing one.
+CREATE UNIQUE INDEX sync_request_pending_source_idx
+    ON sync_request(source_id) WHERE status = 'PENDING';
+CREATE INDEX sync_request_source_requested_at_idx ON sync_request(source_id, requested_at);
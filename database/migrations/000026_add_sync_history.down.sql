@@ -0,0 +1,3 @@
+-- Remove sync history tracking
+DROP TABLE IF EXISTS sync_entry_digests;
+DROP TABLE IF EXISTS sync_history;
@@ -0,0 +1,39 @@
+-- History of sync attempts per source. registry_sync only holds the latest
+-- state of a source, so every attempt is also appended here with its timing,
+-- reason, outcome and the number of entries it added, updated and removed.
+-- Rows older than the configured retention are pruned as new ones are added.
+CREATE TABLE sync_history (
+    id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    source_id        UUID NOT NULL REFERENCES source(id) ON DELETE CASCADE,
+    sync_status      sync_status NOT NULL,
+    reason           TEXT NOT NULL,
+    condition_reason TEXT, -- Populated if sync_status = 'FAILED'
+    error_msg        TEXT, -- Populated if sync_status = 'FAILED'
+    sync_hash        TEXT,
+    incremental      BOOLEAN NOT NULL DEFAULT FALSE,
+    server_count     BIGINT NOT NULL DEFAULT 0,
+    skill_count      BIGINT NOT NULL DEFAULT 0,
+    plugin_count     BIGINT NOT NULL DEFAULT 0,
+    servers_added    BIGINT NOT NULL DEFAULT 0,
+    servers_updated  BIGINT NOT NULL DEFAULT 0,
+    servers_removed  BIGINT NOT NULL DEFAULT 0,
+    skills_added     BIGINT NOT NULL DEFAULT 0,
+    skills_updated   BIGINT NOT NULL DEFAULT 0,
+    skills_removed   BIGINT NOT NULL DEFAULT 0,
+    plugins_added    BIGINT NOT NULL DEFAULT 0,
+    plugins_updated  BIGINT NOT NULL DEFAULT 0,
+    plugins_removed  BIGINT NOT NULL DEFAULT 0,
+    started_at       TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at         TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX sync_history_source_started_at_idx ON sync_history(source_id, started_at DESC, id DESC);
+
+-- Digest of every entry version stored by the last successful sync of a
+-- source, keyed by entry type, name and version. The next sync compares
+-- against it to count added, updated and removed entries.
+CREATE TABLE sync_entry_digests (
+    source_id  UUID PRIMARY KEY REFERENCES source(id) ON DELETE CASCADE,
+    digests    JSONB NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,2 @@
+-- Remove Git commit tracking column from registry_sync table
+ALTER TABLE registry_sync DROP COLUMN last_sync_commit;
@@ -0,0 +1,3 @@
+-- Track the commit the last successful sync of a Git source was read from, so
+-- later syncs can resolve the remote ref and skip the clone when it has not moved.
+ALTER TABLE registry_sync ADD COLUMN last_sync_commit TEXT;
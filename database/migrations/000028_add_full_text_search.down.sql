@@ -0,0 +1,5 @@
+-- Remove full-text search columns and their indexes
+DROP INDEX IF EXISTS mcp_server_tools_search_vector_idx;
+ALTER TABLE mcp_server DROP COLUMN IF EXISTS tools_search_vector;
+DROP INDEX IF EXISTS entry_version_search_vector_idx;
+ALTER TABLE entry_version DROP COLUMN IF EXISTS search_vector;
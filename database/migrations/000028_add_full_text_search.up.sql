@@ -0,0 +1,22 @@
+-- Full-text search over entry versions. The search vector weights the name
+-- over the title over the description. Names are split on their punctuation
+-- so that the words of "io.github.acme/weather-server" can be searched.
+-- The 'simple' configuration is used to match the prefix queries built from
+-- the search terms, without stemming.
+ALTER TABLE entry_version ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', translate(name, './-_@', '     ')), 'A') ||
+    setweight(to_tsvector('simple', COALESCE(title, '')), 'B') ||
+    setweight(to_tsvector('simple', COALESCE(description, '')), 'C')
+) STORED;
+
+CREATE INDEX entry_version_search_vector_idx ON entry_version USING GIN (search_vector);
+
+-- Tool names from the publisher-provided tool definitions of MCP servers,
+-- weighted below the name, title and description of the entry version.
+ALTER TABLE mcp_server ADD COLUMN tools_search_vector tsvector GENERATED ALWAYS AS (
+    setweight(jsonb_to_tsvector('simple', COALESCE(jsonb_path_query_array(server_meta,
+        '$."io.modelcontextprotocol.registry/publisher-provided".*.*.tool_definitions[*].name'),
+        '[]'::jsonb), '["string"]'), 'D')
+) STORED;
+
+CREATE INDEX mcp_server_tools_search_vector_idx ON mcp_server USING GIN (tools_search_vector);
@@ -0,0 +1,10 @@
+-- Remove lifecycle status columns from mcp_server table
+DROP INDEX IF EXISTS mcp_server_status_idx;
+
+ALTER TABLE mcp_server
+    DROP COLUMN status_changed_at,
+    DROP COLUMN replaced_by,
+    DROP COLUMN status_reason,
+    DROP COLUMN status;
+
+DROP TYPE IF EXISTS server_status;
@@ -0,0 +1,12 @@
+-- Lifecycle status of MCP server versions. Deprecated and yanked versions stay
+-- resolvable by their exact version, but are no longer listed nor pointed at
+-- as the latest version of their server.
+CREATE TYPE server_status AS ENUM ('ACTIVE', 'DEPRECATED', 'YANKED');
+
+ALTER TABLE mcp_server
+    ADD COLUMN status            server_status NOT NULL DEFAULT 'ACTIVE',
+    ADD COLUMN status_reason     TEXT,
+    ADD COLUMN replaced_by       TEXT,
+    ADD COLUMN status_changed_at TIMESTAMPTZ;
+
+CREATE INDEX mcp_server_status_idx ON mcp_server(status);
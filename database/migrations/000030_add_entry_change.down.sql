@@ -0,0 +1,4 @@
+-- Remove the entry change feed
+DROP TABLE IF EXISTS entry_change;
+
+DROP TYPE IF EXISTS entry_change_type;
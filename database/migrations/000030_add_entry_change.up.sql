@@ -0,0 +1,21 @@
+-- Change feed of the entry versions of every source. Rows are appended when a
+-- sync or the publish API creates, updates or deletes an entry version, and are
+-- read per registry in id order: the id is the resume token of the feed.
+CREATE TYPE entry_change_type AS ENUM ('CREATED', 'UPDATED', 'DELETED');
+
+CREATE TABLE entry_change (
+    id          BIGSERIAL PRIMARY KEY,
+    source_id   UUID NOT NULL REFERENCES source(id) ON DELETE CASCADE,
+    entry_type  entry_type NOT NULL,
+    namespace   TEXT,
+    name        TEXT NOT NULL,
+    version     TEXT NOT NULL,
+    change_type entry_change_type NOT NULL,
+    -- Claims of the entry when the change was made, so that deletions stay
+    -- filtered by the claims of the deleted entry
+    claims      JSONB,
+    changed_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX entry_change_source_id_idx ON entry_change(source_id, id);
+CREATE INDEX entry_change_changed_at_idx ON entry_change(changed_at);
@@ -0,0 +1,4 @@
+-- Remove the webhook delivery log
+DROP TABLE IF EXISTS webhook_delivery;
+
+DROP TYPE IF EXISTS webhook_delivery_status;
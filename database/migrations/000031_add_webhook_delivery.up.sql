@@ -0,0 +1,22 @@
+-- Delivery log of the outbound webhooks. A row is queued for every webhook
+-- notified of an event, in the transaction that caused the event, and is
+-- updated by the dispatcher on every delivery attempt.
+CREATE TYPE webhook_delivery_status AS ENUM ('PENDING', 'DELIVERED', 'FAILED');
+
+CREATE TABLE webhook_delivery (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    webhook         TEXT NOT NULL,
+    event_type      TEXT NOT NULL,
+    payload         JSONB NOT NULL,
+    status          webhook_delivery_status NOT NULL DEFAULT 'PENDING',
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    -- Outcome of the last attempt
+    response_status INTEGER,
+    last_error      TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    delivered_at    TIMESTAMPTZ
+);
+
+CREATE INDEX webhook_delivery_pending_idx ON webhook_delivery(next_attempt_at) WHERE status = 'PENDING';
+CREATE INDEX webhook_delivery_created_at_idx ON webhook_delivery(created_at, id);
@@ -0,0 +1,4 @@
+-- Remove the publish submissions
+DROP TABLE IF EXISTS entry_submission;
+
+DROP TYPE IF EXISTS submission_status;
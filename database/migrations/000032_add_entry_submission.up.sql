@@ -0,0 +1,27 @@
+-- Publish submissions awaiting review. When the managed source requires
+-- approval, a publish is stored here instead of in the source, and is only
+-- inserted into the source when a reviewer approves it.
+CREATE TYPE submission_status AS ENUM ('PENDING', 'APPROVED', 'REJECTED');
+
+CREATE TABLE entry_submission (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    source_id      UUID NOT NULL REFERENCES source(id) ON DELETE CASCADE,
+    entry_type     entry_type NOT NULL,
+    namespace      TEXT,
+    name           TEXT NOT NULL,
+    version        TEXT NOT NULL,
+    -- Published server, skill or plugin, as sent to POST /v1/entries
+    payload        JSONB NOT NULL,
+    claims         JSONB,
+    status         submission_status NOT NULL DEFAULT 'PENDING',
+    submitted_by   TEXT,
+    submitted_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    reviewed_by    TEXT,
+    review_comment TEXT,
+    reviewed_at    TIMESTAMPTZ
+);
+
+-- At most one pending submission per entry version
+CREATE UNIQUE INDEX entry_submission_pending_idx ON entry_submission(source_id, entry_type, name, version)
+    WHERE status = 'PENDING';
+CREATE INDEX entry_submission_submitted_at_idx ON entry_submission(status, submitted_at, id);
@@ -0,0 +1,3 @@
+ALTER TABLE entry_submission DROP COLUMN IF EXISTS signature;
+
+DROP TABLE IF EXISTS entry_signature;
@@ -0,0 +1,14 @@
+-- Verified signatures of the entry versions published into the managed source.
+-- A version has a row only if the signature attached to its publish was
+-- verified against a key trusted for its namespace.
+CREATE TABLE entry_signature (
+    version_id  UUID PRIMARY KEY REFERENCES entry_version(id) ON DELETE CASCADE,
+    format      TEXT NOT NULL,
+    key_id      TEXT NOT NULL,
+    signature   TEXT NOT NULL,
+    verified_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Verified signature of a publish held for review, carried over to the entry
+-- version when the submission is approved.
+ALTER TABLE entry_submission ADD COLUMN signature JSONB;
@@ -0,0 +1,2 @@
+-- Remove the namespace reservations
+DROP TABLE IF EXISTS namespace;
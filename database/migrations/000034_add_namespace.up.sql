@@ -0,0 +1,10 @@
+-- Namespaces reserved for their owners. Only callers covering the claims of
+-- the most specific namespace owning an entry may publish, delete or update
+-- the claims of that entry. A namespace without claims is reserved for
+-- super-admins.
+CREATE TABLE namespace (
+    name       TEXT PRIMARY KEY,
+    claims     JSONB,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
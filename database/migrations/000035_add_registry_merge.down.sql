@@ -0,0 +1,2 @@
+-- Remove the merge strategies of registries
+ALTER TABLE registry DROP COLUMN IF EXISTS merge;
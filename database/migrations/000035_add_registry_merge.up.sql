@@ -0,0 +1,4 @@
+-- Merge strategies of a registry, selecting per field how entries provided by
+-- several of its sources are merged at read time. NULL means first-wins for
+-- every field: the entry is served from the source with the lowest position.
+ALTER TABLE registry ADD COLUMN merge JSONB;
@@ -0,0 +1,2 @@
+-- Remove the registry overlays
+DROP TABLE IF EXISTS registry_overlay;
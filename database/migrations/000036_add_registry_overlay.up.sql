@@ -0,0 +1,15 @@
+-- Overlays annotating the servers of the sources of a registry without owning
+-- them: extra _meta, tags, icons and a display name merged into every version of
+-- the server at read time. They are keyed by server name rather than by entry,
+-- so that they are kept across the syncs of the underlying sources.
+CREATE TABLE registry_overlay (
+    registry_id UUID NOT NULL REFERENCES registry(id) ON DELETE CASCADE,
+    name        TEXT NOT NULL,
+    title       TEXT,
+    tags        TEXT[],
+    icons       JSONB,
+    meta        JSONB,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (registry_id, name)
+);
@@ -0,0 +1,9 @@
+-- Results of the scan of entry versions by the scanners of the entry policy.
+-- A version has a row only if it was scanned when it was synced or published;
+-- blocked versions are never stored.
+CREATE TABLE entry_scan (
+    version_id UUID PRIMARY KEY REFERENCES entry_version(id) ON DELETE CASCADE,
+    verdict    TEXT NOT NULL,
+    findings   JSONB,
+    scanned_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,13 @@
+-- Digests the tags of the OCI packages of entry versions resolved to, when
+-- digest pinning is configured. A tag resolving to another digest than at the
+-- previous sync overwrites digest, keeps the digest it replaced in
+-- previous_digest and is flagged as drifted by drifted_at.
+CREATE TABLE package_digest (
+    version_id      UUID NOT NULL REFERENCES entry_version(id) ON DELETE CASCADE,
+    identifier      TEXT NOT NULL,
+    digest          TEXT NOT NULL,
+    previous_digest TEXT,
+    resolved_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    drifted_at      TIMESTAMPTZ,
+    PRIMARY KEY (version_id, identifier)
+);
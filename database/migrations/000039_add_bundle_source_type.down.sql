@@ -0,0 +1,6 @@
+DELETE FROM registry_source WHERE source_id IN (SELECT id FROM source WHERE source_type = 'bundle');
+DELETE FROM source WHERE source_type = 'bundle';
+
+ALTER TABLE source DROP CONSTRAINT source_source_type_check;
+ALTER TABLE source ADD CONSTRAINT source_source_type_check
+    CHECK (source_type IN ('git', 'api', 'file', 'managed', 'kubernetes'));
@@ -0,0 +1,4 @@
+-- Allow sources importing the signed bundles exported by another registry server
+ALTER TABLE source DROP CONSTRAINT source_source_type_check;
+ALTER TABLE source ADD CONSTRAINT source_source_type_check
+    CHECK (source_type IN ('git', 'api', 'file', 'bundle', 'managed', 'kubernetes'));
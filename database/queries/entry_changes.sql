@@ -0,0 +1,118 @@
+-- name: LockEntryChanges :exec
+-- Serializes the transactions that record entry changes until they commit, so
+-- that changes become visible in id order and readers never skip one.
+SELECT pg_advisory_xact_lock(hashtext('entry_change'));
+
+-- name: InsertEntryChange :exec
+-- Records a change of an entry version. The claims of the entry, or of its
+-- source when the entry has none, are copied into the change.
+INSERT INTO entry_change (
+    source_id,
+    entry_type,
+    namespace,
+    name,
+    version,
+    change_type,
+    claims
+)
+SELECT s.id,
+       sqlc.arg(entry_type)::entry_type,
+       sqlc.narg(namespace)::text,
+       sqlc.arg(name)::text,
+       sqlc.arg(version)::text,
+       sqlc.arg(change_type)::entry_change_type,
+       COALESCE(e.claims, s.claims)
+  FROM source s
+  LEFT JOIN registry_entry e
+    ON e.source_id = s.id
+   AND e.entry_type = sqlc.arg(entry_type)::entry_type
+   AND e.name = sqlc.arg(name)::text
+ WHERE s.id = sqlc.arg(source_id)::uuid;
+
+-- name: InsertEntryVersionChanges :exec
+-- Records an update of every version of an entry, such as a change of its claims.
+INSERT INTO entry_change (
+    source_id,
+    entry_type,
+    namespace,
+    name,
+    version,
+    change_type,
+    claims
+)
+SELECT e.source_id,
+       e.entry_type,
+       COALESCE(sk.namespace, p.namespace),
+       e.name,
+       v.version,
+       'UPDATED'::entry_change_type,
+       COALESCE(e.claims, s.claims)
+  FROM registry_entry e
+  JOIN source s ON s.id = e.source_id
+  JOIN entry_version v ON v.entry_id = e.id
+  LEFT JOIN skill sk ON sk.version_id = v.id
+  LEFT JOIN plugin p ON p.version_id = v.id
+ WHERE e.id = sqlc.arg(entry_id)
+ ORDER BY v.version;
+
+-- name: InsertSyncEntryChanges :exec
+-- Records the entry versions changed by a sync of the named source, in the order given.
+INSERT INTO entry_change (
+    source_id,
+    entry_type,
+    namespace,
+    name,
+    version,
+    change_type,
+    claims
+)
+SELECT s.id,
+       c.entry_type,
+       NULLIF(c.namespace, ''),
+       c.name,
+       c.version,
+       c.change_type,
+       COALESCE(e.claims, s.claims)
+  FROM source s
+ CROSS JOIN unnest(
+           sqlc.arg(entry_types)::entry_type[],
+           sqlc.arg(namespaces)::text[],
+           sqlc.arg(names)::text[],
+           sqlc.arg(versions)::text[],
+           sqlc.arg(change_types)::entry_change_type[]
+       ) WITH ORDINALITY AS c(entry_type, namespace, name, version, change_type, ord)
+  LEFT JOIN registry_entry e
+    ON e.source_id = s.id
+   AND e.entry_type = c.entry_type
+   AND e.name = c.name
+ WHERE s.name = sqlc.arg(source_name)
+ ORDER BY c.ord;
+
+-- name: ListRegistryEntryChanges :many
+-- Lists the changes of the entries of the sources of a registry, in id order,
+-- starting AFTER the given id.
+SELECT c.id,
+       c.entry_type,
+       c.namespace,
+       c.name,
+       c.version,
+       c.change_type,
+       c.claims,
+       c.changed_at
+  FROM entry_change c
+  JOIN registry_source rs ON rs.source_id = c.source_id
+ WHERE rs.registry_id = sqlc.arg(registry_id)::uuid
+   AND c.id > sqlc.arg(after_id)::bigint
+ ORDER BY c.id
+ LIMIT sqlc.arg(size)::bigint;
+
+-- name: GetEntryChangeBounds :one
+-- Returns the ids of the oldest and newest retained changes, or zeros when
+-- no change is retained.
+SELECT COALESCE(MIN(id), 0)::bigint AS oldest_id,
+       COALESCE(MAX(id), 0)::bigint AS newest_id
+  FROM entry_change;
+
+-- name: DeleteEntryChangesBefore :exec
+DELETE FROM entry_change
+WHERE changed_at < sqlc.arg(before);
@@ -0,0 +1,64 @@
+-- name: UpsertEntryScan :exec
+-- Records the scan of a published entry version.
+INSERT INTO entry_scan (
+    version_id,
+    verdict,
+    findings
+) VALUES (
+    sqlc.arg(version_id),
+    sqlc.arg(verdict),
+    sqlc.narg(findings)
+)
+ON CONFLICT (version_id) DO UPDATE SET
+    verdict = EXCLUDED.verdict,
+    findings = EXCLUDED.findings,
+    scanned_at = NOW();
+
+-- name: UpsertSourceEntryScans :exec
+-- Records the scans of the entry versions of a sync. Versions are matched by
+-- entry type, name and version among the entries of the source; findings are
+-- JSON documents, empty when the version has none.
+INSERT INTO entry_scan (
+    version_id,
+    verdict,
+    findings
+)
+SELECT v.id,
+       scans.verdict,
+       NULLIF(scans.findings, '')::jsonb
+  FROM unnest(
+           sqlc.arg(entry_types)::text[],
+           sqlc.arg(names)::text[],
+           sqlc.arg(versions)::text[],
+           sqlc.arg(verdicts)::text[],
+           sqlc.arg(findings)::text[]
+       ) AS scans(entry_type, name, version, verdict, findings)
+  JOIN registry_entry e ON e.source_id = sqlc.arg(source_id)
+                       AND e.entry_type = scans.entry_type::entry_type
+                       AND e.name = scans.name
+  JOIN entry_version v ON v.entry_id = e.id AND v.version = scans.version
+ON CONFLICT (version_id) DO UPDATE SET
+    verdict = EXCLUDED.verdict,
+    findings = EXCLUDED.findings,
+    scanned_at = NOW();
+
+-- name: DeleteSourceEntryScans :exec
+-- Deletes the scans of the entry versions of a source, before a full sync
+-- records the scans of its entries.
+DELETE FROM entry_scan
+ WHERE version_id IN (
+       SELECT v.id
+         FROM entry_version v
+         JOIN registry_entry e ON v.entry_id = e.id
+        WHERE e.source_id = sqlc.arg(source_id)
+ );
+
+-- name: ListEntryScans :many
+-- Returns the scans of the given entry versions. Versions stored without a
+-- scan have no row.
+SELECT version_id,
+       verdict,
+       findings,
+       scanned_at
+  FROM entry_scan
+ WHERE version_id = ANY(sqlc.slice(version_ids)::UUID[]);
@@ -0,0 +1,24 @@
+-- name: InsertEntrySignature :exec
+-- Records the verified signature of a published entry version.
+INSERT INTO entry_signature (
+    version_id,
+    format,
+    key_id,
+    signature
+) VALUES (
+    sqlc.arg(version_id),
+    sqlc.arg(format),
+    sqlc.arg(key_id),
+    sqlc.arg(signature)
+);
+
+-- name: ListEntrySignatures :many
+-- Returns the verified signatures of the given entry versions. Versions
+-- published without a verified signature have no row.
+SELECT version_id,
+       format,
+       key_id,
+       signature,
+       verified_at
+  FROM entry_signature
+ WHERE version_id = ANY(sqlc.slice(version_ids)::UUID[]);
@@ -0,0 +1,133 @@
+-- name: InsertEntrySubmission :one
+-- Stores a publish awaiting review. Fails with a unique violation when the same
+-- entry version is already pending review.
+INSERT INTO entry_submission (
+    source_id,
+    entry_type,
+    namespace,
+    name,
+    version,
+    payload,
+    claims,
+    submitted_by,
+    signature
+) VALUES (
+    sqlc.arg(source_id),
+    sqlc.arg(entry_type),
+    sqlc.narg(namespace),
+    sqlc.arg(name),
+    sqlc.arg(version),
+    sqlc.arg(payload),
+    sqlc.narg(claims),
+    sqlc.narg(submitted_by),
+    sqlc.narg(signature)
+)
+RETURNING id,
+          source_id,
+          entry_type,
+          namespace,
+          name,
+          version,
+          payload,
+          claims,
+          status,
+          submitted_by,
+          submitted_at,
+          reviewed_by,
+          review_comment,
+          reviewed_at,
+          signature;
+
+-- name: ListEntrySubmissions :many
+-- Cursor-based pagination using (submitted_at, id) compound cursor, oldest first.
+-- When cursor is provided, results start AFTER the specified tuple.
+SELECT id,
+       source_id,
+       entry_type,
+       namespace,
+       name,
+       version,
+       payload,
+       claims,
+       status,
+       submitted_by,
+       submitted_at,
+       reviewed_by,
+       review_comment,
+       reviewed_at,
+       signature
+  FROM entry_submission
+ WHERE (sqlc.narg(status)::submission_status IS NULL OR status = sqlc.narg(status)::submission_status)
+   AND (sqlc.narg(entry_type)::entry_type IS NULL OR entry_type = sqlc.narg(entry_type)::entry_type)
+   AND (
+       sqlc.narg(cursor_submitted_at)::timestamp with time zone IS NULL
+       OR (submitted_at, id) > (sqlc.narg(cursor_submitted_at)::timestamp with time zone, sqlc.narg(cursor_id)::uuid)
+   )
+ ORDER BY submitted_at ASC, id ASC
+ LIMIT sqlc.arg(size)::bigint;
+
+-- name: GetEntrySubmission :one
+-- Reads a submission without locking it, before the transaction of its review.
+SELECT id,
+       source_id,
+       entry_type,
+       namespace,
+       name,
+       version,
+       payload,
+       claims,
+       status,
+       submitted_by,
+       submitted_at,
+       reviewed_by,
+       review_comment,
+       reviewed_at,
+       signature
+  FROM entry_submission
+ WHERE id = sqlc.arg(id);
+
+-- name: GetEntrySubmissionForUpdate :one
+-- Locks a submission for the duration of its review.
+SELECT id,
+       source_id,
+       entry_type,
+       namespace,
+       name,
+       version,
+       payload,
+       claims,
+       status,
+       submitted_by,
+       submitted_at,
+       reviewed_by,
+       review_comment,
+       reviewed_at,
+       signature
+  FROM entry_submission
+ WHERE id = sqlc.arg(id)
+   FOR UPDATE;
+
+-- name: UpdateEntrySubmissionReview :one
+-- Records the decision of a reviewer on a pending submission.
+UPDATE entry_submission
+   SET status = sqlc.arg(status)::submission_status,
+       reviewed_by = sqlc.narg(reviewed_by)::text,
+       review_comment = sqlc.narg(review_comment)::text,
+       reviewed_at = NOW()
+ WHERE id = sqlc.arg(id)
+   AND status = 'PENDING'
+RETURNING id,
+          source_id,
+          entry_type,
+          namespace,
+          name,
+          version,
+          payload,
+          claims,
+          status,
+          submitted_by,
+          submitted_at,
+          reviewed_by,
+          review_comment,
+          reviewed_at,
+          signature;
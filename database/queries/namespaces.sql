@@ -0,0 +1,46 @@
+-- name: ListNamespaces :many
+SELECT name, claims, created_at, updated_at
+FROM namespace
+ORDER BY name;
+
+-- name: GetNamespace :one
+SELECT name, claims, created_at, updated_at
+FROM namespace
+WHERE name = sqlc.arg(name);
+
+-- name: GetOwningNamespace :one
+-- Get the most specific namespace owning an entry namespace: the namespace
+-- itself, or the closest parent reverse-DNS namespace.
+SELECT name, claims, created_at, updated_at
+FROM namespace
+WHERE name = sqlc.arg(entry_namespace)::text
+   OR sqlc.arg(entry_namespace)::text LIKE name || '.%'
+ORDER BY length(name) DESC
+LIMIT 1;
+
+-- name: ListEntryNamespaces :many
+-- List the namespaces of the versions of a skill or plugin entry.
+SELECT s.namespace
+FROM skill s
+JOIN entry_version ev ON ev.id = s.version_id
+WHERE ev.entry_id = sqlc.arg(entry_id)
+UNION
+SELECT p.namespace
+FROM plugin p
+JOIN entry_version ev ON ev.id = p.version_id
+WHERE ev.entry_id = sqlc.arg(entry_id)
+ORDER BY namespace;
+
+-- name: InsertNamespace :one
+INSERT INTO namespace (name, claims)
+VALUES (sqlc.arg(name), sqlc.narg(claims))
+RETURNING name, claims, created_at, updated_at;
+
+-- name: UpdateNamespace :one
+UPDATE namespace
+SET claims = sqlc.narg(claims), updated_at = NOW()
+WHERE name = sqlc.arg(name)
+RETURNING name, claims, created_at, updated_at;
+
+-- name: DeleteNamespace :execrows
+DELETE FROM namespace WHERE name = sqlc.arg(name);
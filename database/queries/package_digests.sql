@@ -0,0 +1,56 @@
+-- name: UpsertSourcePackageDigests :many
+-- Records the digests the OCI packages of entry versions of a source resolved
+-- to. Versions are matched by entry type, name and version among the entries
+-- of the source. A package resolving to another digest than the stored one is
+-- flagged as drifted; the drifted packages are returned.
+WITH upserted AS (
+    INSERT INTO package_digest (
+        version_id,
+        identifier,
+        digest
+    )
+    SELECT v.id,
+           digests.identifier,
+           digests.digest
+      FROM unnest(
+               sqlc.arg(entry_types)::text[],
+               sqlc.arg(names)::text[],
+               sqlc.arg(versions)::text[],
+               sqlc.arg(identifiers)::text[],
+               sqlc.arg(digests)::text[]
+           ) AS digests(entry_type, name, version, identifier, digest)
+      JOIN registry_entry e ON e.source_id = sqlc.arg(source_id)
+                           AND e.entry_type = digests.entry_type::entry_type
+                           AND e.name = digests.name
+      JOIN entry_version v ON v.entry_id = e.id AND v.version = digests.version
+    ON CONFLICT (version_id, identifier) DO UPDATE SET
+        previous_digest = CASE WHEN package_digest.digest <> EXCLUDED.digest
+                               THEN package_digest.digest
+                               ELSE package_digest.previous_digest END,
+        drifted_at = CASE WHEN package_digest.digest <> EXCLUDED.digest
+                          THEN NOW()
+                          ELSE package_digest.drifted_at END,
+        digest = EXCLUDED.digest,
+        resolved_at = NOW()
+    RETURNING package_digest.identifier,
+              package_digest.digest,
+              package_digest.previous_digest,
+              package_digest.drifted_at
+)
+SELECT identifier,
+       digest,
+       previous_digest
+  FROM upserted
+ WHERE drifted_at = NOW();
+
+-- name: ListPackageDigests :many
+-- Returns the resolved digests of the OCI packages of the given entry versions.
+SELECT version_id,
+       identifier,
+       digest,
+       previous_digest,
+       resolved_at,
+       drifted_at
+  FROM package_digest
+ WHERE version_id = ANY(sqlc.slice(version_ids)::UUID[])
+ ORDER BY version_id, identifier;
@@ -32,11 +32,6 @@ SELECT src.source_type AS registry_type,
                           AND rs.registry_id = sqlc.arg(registry_id)::uuid
  WHERE (sqlc.narg(namespace)::text IS NULL OR p.namespace = sqlc.narg(namespace)::text)
    AND (sqlc.narg(name)::text IS NULL OR e.name = sqlc.narg(name)::text)
-   AND (sqlc.narg(search)::text IS NULL OR (
-       LOWER(e.name) LIKE LOWER('%' || sqlc.narg(search)::text || '%')
-       OR LOWER(v.title) LIKE LOWER('%' || sqlc.narg(search)::text || '%')
-       OR LOWER(v.description) LIKE LOWER('%' || sqlc.narg(search)::text || '%')
-   ))
    AND (sqlc.narg(updated_since)::timestamp with time zone IS NULL OR v.updated_at > sqlc.narg(updated_since)::timestamp with time zone)
    AND (
        sqlc.narg(cursor_name)::text IS NULL
@@ -45,6 +40,74 @@ SELECT src.source_type AS registry_type,
  ORDER BY v.name ASC, v.version ASC, rs.position ASC
  LIMIT sqlc.arg(size)::bigint;
 
+-- name: SearchPlugins :many
+-- Full-text search over the name, title and description of plugins, ordered by relevance.
+-- The search parameter is a tsquery in the 'simple' configuration.
+-- All versions of a plugin share the relevance of its best matching row, so they stay
+-- adjacent in the same (name, version, position) order as ListPlugins.
+-- When cursor is provided, results start AFTER the specified (name, version) tuple
+-- in relevance order.
+WITH matches AS (
+    SELECT src.source_type AS registry_type,
+           p.version_id,
+           e.name,
+           v.version,
+           (l.latest_version_id IS NOT NULL)::boolean AS is_latest,
+           v.created_at,
+           v.updated_at,
+           v.description,
+           v.title,
+           p.namespace,
+           p.status,
+           p.license,
+           p.repository,
+           p.icons,
+           p.metadata,
+           p.extension_meta,
+           e.claims,
+           COALESCE(rs.position, 32767)::integer AS position,
+           MAX(ts_rank(v.search_vector, to_tsquery('simple', sqlc.arg(search)::text)))
+               OVER (PARTITION BY e.name) AS relevance
+      FROM plugin p
+      JOIN entry_version v ON p.version_id = v.id
+      JOIN registry_entry e ON v.entry_id = e.id
+      JOIN source src ON e.source_id = src.id
+      LEFT JOIN latest_entry_version l ON v.id = l.latest_version_id
+      JOIN registry_source rs ON rs.source_id = e.source_id
+                              AND rs.registry_id = sqlc.arg(registry_id)::uuid
+     WHERE v.search_vector @@ to_tsquery('simple', sqlc.arg(search)::text)
+       AND (sqlc.narg(namespace)::text IS NULL OR p.namespace = sqlc.narg(namespace)::text)
+       AND (sqlc.narg(name)::text IS NULL OR e.name = sqlc.narg(name)::text)
+       AND (sqlc.narg(updated_since)::timestamp with time zone IS NULL OR v.updated_at > sqlc.narg(updated_since)::timestamp with time zone)
+), cursor_match AS (
+    SELECT relevance FROM matches WHERE name = sqlc.narg(cursor_name)::text LIMIT 1
+)
+SELECT registry_type,
+       version_id,
+       name,
+       version,
+       is_latest,
+       created_at,
+       updated_at,
+       description,
+       title,
+       namespace,
+       status,
+       license,
+       repository,
+       icons,
+       metadata,
+       extension_meta,
+       claims,
+       position
+  FROM matches
+ WHERE sqlc.narg(cursor_name)::text IS NULL
+    OR relevance < (SELECT relevance FROM cursor_match)
+    OR (relevance = (SELECT relevance FROM cursor_match)
+        AND (name, version) > (sqlc.narg(cursor_name)::text, sqlc.narg(cursor_version)::text))
+ ORDER BY relevance DESC, name ASC, version ASC, position ASC
+ LIMIT sqlc.arg(size)::bigint;
+
 -- name: GetPluginVersion :many
 -- Despite the name, this query returns multiple rows. The actual number of
 -- records is bounded by the number of sources that provide the same name and
@@ -1,22 +1,22 @@
 -- Queries for the new lightweight registry table and registry_source junction.
 
 -- name: ListRegistries :many
-SELECT id, name, claims, creation_type, created_at, updated_at
+SELECT id, name, claims, creation_type, created_at, updated_at, merge
 FROM registry
 WHERE (sqlc.narg(cursor)::text IS NULL OR name > sqlc.narg(cursor))
 ORDER BY name
 LIMIT sqlc.arg(size)::bigint;
 
 -- name: GetRegistryByName :one
-SELECT id, name, claims, creation_type, created_at, updated_at
+SELECT id, name, claims, creation_type, created_at, updated_at, merge
 FROM registry WHERE name = sqlc.arg(name);
 
 -- name: UpsertRegistry :one
 -- Insert or update a registry. The creation_type is passed as a parameter.
 -- Business logic in Go guards against cross-type overwrites.
-INSERT INTO registry (name, claims, creation_type, created_at, updated_at)
-VALUES (sqlc.arg(name), sqlc.narg(claims), sqlc.arg(creation_type), sqlc.arg(created_at), sqlc.arg(updated_at))
-ON CONFLICT (name) DO UPDATE SET claims = EXCLUDED.claims, updated_at = EXCLUDED.updated_at
+INSERT INTO registry (name, claims, creation_type, created_at, updated_at, merge)
+VALUES (sqlc.arg(name), sqlc.narg(claims), sqlc.arg(creation_type), sqlc.arg(created_at), sqlc.arg(updated_at), sqlc.narg(merge))
+ON CONFLICT (name) DO UPDATE SET claims = EXCLUDED.claims, updated_at = EXCLUDED.updated_at, merge = EXCLUDED.merge
 RETURNING *;
 
 -- name: DeleteRegistry :execrows
@@ -96,6 +96,8 @@ SELECT e.entry_type,
  ORDER BY v.name ASC, v.version ASC;
 
 -- name: ListEntriesByRegistry :many
+-- Lists the entry versions of every source of a registry with their scan, flagging the fields of
+-- server versions that a later source can override under the merge strategies of the registry.
 SELECT e.entry_type,
        e.name,
        v.version,
@@ -104,14 +106,84 @@ SELECT e.entry_type,
        v.created_at,
        v.updated_at,
        src.name AS source_name,
-       rs.position
+       rs.position,
+       (s.server_meta IS NOT NULL)::boolean AS has_meta,
+       EXISTS (SELECT 1 FROM mcp_server_icon i WHERE i.server_id = v.id)::boolean AS has_icons,
+       sc.verdict AS scan_verdict,
+       sc.findings AS scan_findings,
+       sc.scanned_at
   FROM registry_source rs
   JOIN source src ON rs.source_id = src.id
   JOIN registry_entry e ON e.source_id = rs.source_id
   JOIN entry_version v ON v.entry_id = e.id
+  LEFT JOIN mcp_server s ON s.version_id = v.id
+  LEFT JOIN entry_scan sc ON sc.version_id = v.id
  WHERE rs.registry_id = sqlc.arg(registry_id)
  ORDER BY v.name ASC, v.version ASC, rs.position ASC;
 
+-- name: ListRegistryEntryVersions :many
+-- Lists the versions of the entries of a registry with the position of their source,
+-- to select the source providing the highest version of each entry.
+SELECT e.entry_type,
+       e.name,
+       v.version,
+       rs.position
+  FROM registry_source rs
+  JOIN registry_entry e ON e.source_id = rs.source_id
+  JOIN entry_version v ON v.entry_id = e.id
+ WHERE rs.registry_id = sqlc.arg(registry_id)::uuid
+   AND (sqlc.narg(entry_type)::entry_type IS NULL OR e.entry_type = sqlc.narg(entry_type)::entry_type)
+   AND (sqlc.narg(name)::text IS NULL OR e.name = sqlc.narg(name)::text)
+ ORDER BY rs.position ASC, v.version ASC;
+
+-- name: SearchEntries :many
+-- Full-text search over the latest versions of the servers, skills and plugins of a registry,
+-- ordered by relevance. The search parameter is a tsquery in the 'simple' configuration.
+-- An entry provided by several sources returns one row per source, adjacent in position order.
+-- When cursor is provided, results start AFTER the specified (relevance, entry_type, name)
+-- tuple, so that pagination goes on past a cursor entry that changed or was removed.
+WITH matches AS (
+    SELECT e.entry_type,
+           e.name,
+           v.version,
+           v.title,
+           v.description,
+           COALESCE(sk.namespace, p.namespace) AS namespace,
+           v.updated_at,
+           e.claims,
+           rs.position,
+           MAX(ts_rank(v.search_vector || COALESCE(s.tools_search_vector, ''::tsvector),
+                       to_tsquery('simple', sqlc.arg(search)::text)))
+               OVER (PARTITION BY e.entry_type, e.name) AS relevance
+      FROM registry_source rs
+      JOIN registry_entry e ON e.source_id = rs.source_id
+      JOIN entry_version v ON v.entry_id = e.id
+      JOIN latest_entry_version l ON v.id = l.latest_version_id
+      LEFT JOIN mcp_server s ON s.version_id = v.id
+      LEFT JOIN skill sk ON sk.version_id = v.id
+      LEFT JOIN plugin p ON p.version_id = v.id
+     WHERE rs.registry_id = sqlc.arg(registry_id)::uuid
+       AND (v.search_vector @@ to_tsquery('simple', sqlc.arg(search)::text)
+            OR s.tools_search_vector @@ to_tsquery('simple', sqlc.arg(search)::text))
+)
+SELECT entry_type,
+       name,
+       version,
+       title,
+       description,
+       namespace,
+       updated_at,
+       claims,
+       position,
+       relevance::real AS relevance
+  FROM matches
+ WHERE sqlc.narg(cursor_name)::text IS NULL
+    OR relevance < sqlc.narg(cursor_relevance)::real
+    OR (relevance = sqlc.narg(cursor_relevance)::real
+        AND (entry_type, name) > (sqlc.narg(cursor_type)::entry_type, sqlc.narg(cursor_name)::text))
+ ORDER BY relevance DESC, entry_type ASC, name ASC, position ASC
+ LIMIT sqlc.arg(size)::bigint;
+
 -- name: UpdateRegistryEntryClaims :execrows
 UPDATE registry_entry
    SET claims = sqlc.narg(claims),
@@ -0,0 +1,47 @@
+-- name: ListRegistryOverlays :many
+SELECT registry_id, name, title, tags, icons, meta, created_at, updated_at
+FROM registry_overlay
+WHERE registry_id = sqlc.arg(registry_id)
+ORDER BY name;
+
+-- name: ListRegistryOverlaysByNames :many
+-- List the overlays of the given servers of a registry, to merge them into the
+-- server versions the registry serves.
+SELECT registry_id, name, title, tags, icons, meta, created_at, updated_at
+FROM registry_overlay
+WHERE registry_id = sqlc.arg(registry_id)
+  AND name = ANY(sqlc.arg(names)::text[]);
+
+-- name: GetRegistryOverlay :one
+SELECT registry_id, name, title, tags, icons, meta, created_at, updated_at
+FROM registry_overlay
+WHERE registry_id = sqlc.arg(registry_id)
+  AND name = sqlc.arg(name);
+
+-- name: InsertRegistryOverlay :one
+INSERT INTO registry_overlay (registry_id, name, title, tags, icons, meta)
+VALUES (
+    sqlc.arg(registry_id),
+    sqlc.arg(name),
+    sqlc.narg(title),
+    sqlc.narg(tags),
+    sqlc.narg(icons),
+    sqlc.narg(meta)
+)
+RETURNING registry_id, name, title, tags, icons, meta, created_at, updated_at;
+
+-- name: UpdateRegistryOverlay :one
+UPDATE registry_overlay
+SET title = sqlc.narg(title),
+    tags = sqlc.narg(tags),
+    icons = sqlc.narg(icons),
+    meta = sqlc.narg(meta),
+    updated_at = NOW()
+WHERE registry_id = sqlc.arg(registry_id)
+  AND name = sqlc.arg(name)
+RETURNING registry_id, name, title, tags, icons, meta, created_at, updated_at;
+
+-- name: DeleteRegistryOverlay :execrows
+DELETE FROM registry_overlay
+WHERE registry_id = sqlc.arg(registry_id)
+  AND name = sqlc.arg(name);
@@ -4,6 +4,7 @@
 -- When cursor is provided, results start AFTER the specified (name, version) tuple.
 -- Returns position from registry_source for source priority ordering.
 -- When name is provided, results are filtered to versions of that specific server.
+-- Deprecated and yanked versions are skipped unless include_inactive is set.
 SELECT src.source_type as registry_type,
        v.id,
        e.name,
@@ -20,6 +21,10 @@ SELECT src.source_type as registry_type,
        s.repository_id,
        s.repository_subfolder,
        s.repository_type,
+       s.status,
+       s.status_reason,
+       s.replaced_by,
+       s.status_changed_at,
        e.claims,
        -- Sources not linked to the requested registry have no position; default to max int16
        -- so they sort after all explicitly positioned sources (lower position = higher priority).
@@ -32,11 +37,6 @@ SELECT src.source_type as registry_type,
   JOIN registry_source rs ON rs.source_id = e.source_id
                           AND rs.registry_id = sqlc.arg(registry_id)::uuid
  WHERE (sqlc.narg(name)::text IS NULL OR e.name = sqlc.narg(name)::text)
-   AND (sqlc.narg(search)::text IS NULL OR (
-       LOWER(e.name) LIKE LOWER('%' || sqlc.narg(search)::text || '%')
-       OR LOWER(v.title) LIKE LOWER('%' || sqlc.narg(search)::text || '%')
-       OR LOWER(v.description) LIKE LOWER('%' || sqlc.narg(search)::text || '%')
-   ))
    -- Filter by updated_since if provided
    AND (sqlc.narg(updated_since)::timestamp with time zone IS NULL OR v.updated_at > sqlc.narg(updated_since)::timestamp with time zone)
    -- Compound cursor comparison: (name, version) > (cursor_name, cursor_version)
@@ -50,9 +50,94 @@ SELECT src.source_type as registry_type,
        v.version = sqlc.narg(version)::text OR
        (sqlc.narg(version)::text = 'latest' AND l.latest_version_id = v.id)
    )
+   -- Deprecated and yanked versions are only listed when asked for
+   AND (sqlc.arg(include_inactive)::boolean OR s.status = 'ACTIVE')
  ORDER BY v.name ASC, v.version ASC, rs.position ASC
  LIMIT sqlc.arg(size)::bigint;
 
+-- name: SearchServers :many
+-- Full-text search over the name, title, description and tool names of servers,
+-- ordered by relevance. The search parameter is a tsquery in the 'simple' configuration.
+-- All versions of a server share the relevance of its best matching row, so they stay
+-- adjacent in the same (name, version, position) order as ListServers.
+-- When cursor is provided, results start AFTER the specified (name, version) tuple
+-- in relevance order.
+WITH matches AS (
+    SELECT src.source_type as registry_type,
+           v.id,
+           e.name,
+           v.version,
+           (l.latest_version_id IS NOT NULL)::boolean AS is_latest,
+           v.created_at,
+           v.updated_at,
+           v.description,
+           v.title,
+           s.website,
+           s.upstream_meta,
+           s.server_meta,
+           s.repository_url,
+           s.repository_id,
+           s.repository_subfolder,
+           s.repository_type,
+           s.status,
+           s.status_reason,
+           s.replaced_by,
+           s.status_changed_at,
+           e.claims,
+           COALESCE(rs.position, 32767)::integer AS position,
+           MAX(ts_rank(v.search_vector || s.tools_search_vector,
+                       to_tsquery('simple', sqlc.arg(search)::text)))
+               OVER (PARTITION BY e.name) AS relevance
+      FROM mcp_server s
+      JOIN entry_version v ON s.version_id = v.id
+      JOIN registry_entry e ON v.entry_id = e.id
+      JOIN source src ON e.source_id = src.id
+      LEFT JOIN latest_entry_version l ON v.id = l.latest_version_id
+      JOIN registry_source rs ON rs.source_id = e.source_id
+                              AND rs.registry_id = sqlc.arg(registry_id)::uuid
+     WHERE (v.search_vector @@ to_tsquery('simple', sqlc.arg(search)::text)
+            OR s.tools_search_vector @@ to_tsquery('simple', sqlc.arg(search)::text))
+       AND (sqlc.narg(name)::text IS NULL OR e.name = sqlc.narg(name)::text)
+       AND (sqlc.narg(updated_since)::timestamp with time zone IS NULL OR v.updated_at > sqlc.narg(updated_since)::timestamp with time zone)
+       AND (
+           sqlc.narg(version)::text IS NULL OR
+           v.version = sqlc.narg(version)::text OR
+           (sqlc.narg(version)::text = 'latest' AND l.latest_version_id = v.id)
+       )
+       AND (sqlc.arg(include_inactive)::boolean OR s.status = 'ACTIVE')
+), cursor_match AS (
+    SELECT relevance FROM matches WHERE name = sqlc.narg(cursor_name)::text LIMIT 1
+)
+SELECT registry_type,
+       id,
+       name,
+       version,
+       is_latest,
+       created_at,
+       updated_at,
+       description,
+       title,
+       website,
+       upstream_meta,
+       server_meta,
+       repository_url,
+       repository_id,
+       repository_subfolder,
+       repository_type,
+       status,
+       status_reason,
+       replaced_by,
+       status_changed_at,
+       claims,
+       position
+  FROM matches
+ WHERE sqlc.narg(cursor_name)::text IS NULL
+    OR relevance < (SELECT relevance FROM cursor_match)
+    OR (relevance = (SELECT relevance FROM cursor_match)
+        AND (name, version) > (sqlc.narg(cursor_name)::text, sqlc.narg(cursor_version)::text))
+ ORDER BY relevance DESC, name ASC, version ASC, position ASC
+ LIMIT sqlc.arg(size)::bigint;
+
 -- name: GetServerVersion :many
 -- Despite the name, this query returns multiple rows. The actual number of
 -- records is bounded by the number of sources that provide the same name and
@@ -75,6 +160,10 @@ SELECT src.source_type as registry_type,
        s.repository_id,
        s.repository_subfolder,
        s.repository_type,
+       s.status,
+       s.status_reason,
+       s.replaced_by,
+       s.status_changed_at,
        e.claims,
        rs.source_id,
        -- Sources not linked to the requested registry have no position; default to max int16
@@ -121,6 +210,10 @@ SELECT src.source_type as registry_type,
        s.repository_id,
        s.repository_subfolder,
        s.repository_type,
+       s.status,
+       s.status_reason,
+       s.replaced_by,
+       s.status_changed_at,
        e.claims,
        0::integer AS position
   FROM mcp_server s
@@ -161,6 +254,34 @@ SELECT r.server_id,
  WHERE s.version_id = ANY(sqlc.slice(version_ids)::UUID[])
  ORDER BY r.transport, r.transport_url;
 
+-- name: ListServerIcons :many
+SELECT i.server_id,
+       i.source_uri,
+       i.mime_type,
+       i.theme
+  FROM mcp_server_icon i
+ WHERE i.server_id = ANY(sqlc.slice(version_ids)::UUID[])
+ ORDER BY i.source_uri, i.theme;
+
+-- name: ListServerFieldOverrides :many
+-- Lists the versions of the given servers provided by the sources of a registry, in
+-- position order, with the fields a later source can override on the version served
+-- from an earlier one under the merge strategies of the registry.
+SELECT v.id,
+       e.name,
+       v.version,
+       v.description,
+       s.server_meta,
+       e.claims,
+       rs.position
+  FROM mcp_server s
+  JOIN entry_version v ON s.version_id = v.id
+  JOIN registry_entry e ON v.entry_id = e.id
+  JOIN registry_source rs ON rs.source_id = e.source_id
+                          AND rs.registry_id = sqlc.arg(registry_id)::uuid
+ WHERE e.name = ANY(sqlc.arg(names)::text[])
+ ORDER BY e.name ASC, v.version ASC, rs.position ASC;
+
 -- name: InsertServerVersion :one
 INSERT INTO mcp_server (
     version_id,
@@ -183,6 +304,34 @@ INSERT INTO mcp_server (
 )
 RETURNING version_id;
 
+-- name: UpdateServerVersionStatus :execrows
+-- Sets the lifecycle status of a server version, along with the reason for it
+-- and the version replacing it.
+UPDATE mcp_server s
+   SET status = sqlc.arg(status),
+       status_reason = sqlc.narg(status_reason),
+       replaced_by = sqlc.narg(replaced_by),
+       status_changed_at = sqlc.arg(status_changed_at)
+  FROM entry_version v
+ WHERE s.version_id = v.id
+   AND v.entry_id = sqlc.arg(entry_id)
+   AND v.version = sqlc.arg(version);
+
+-- name: ListActiveServerVersions :many
+-- Lists the versions of a server that may be pointed at as its latest version.
+SELECT v.id, v.version
+  FROM entry_version v
+  JOIN mcp_server s ON s.version_id = v.id
+ WHERE v.entry_id = sqlc.arg(entry_id)
+   AND s.status = 'ACTIVE'
+ ORDER BY v.version ASC;
+
+-- name: DeleteLatestServerVersion :exec
+-- Removes the latest version pointer of a server that has no active version left.
+DELETE FROM latest_entry_version
+ WHERE source_id = sqlc.arg(source_id)
+   AND name = sqlc.arg(name);
+
 -- name: UpsertLatestServerVersion :one
 INSERT INTO latest_entry_version (
     source_id,
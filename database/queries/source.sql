@@ -198,5 +198,5 @@ WHERE name = ANY(sqlc.arg(names)::text[])
   AND creation_type = 'API';
 
 -- name: GetManagedSources :many
-SELECT id, name, source_type, creation_type, claims, created_at, updated_at
+SELECT id, name, source_type, creation_type, source_config, claims, created_at, updated_at
 FROM source WHERE source_type = 'managed';
@@ -10,7 +10,10 @@ SELECT id,
        last_applied_filter_hash,
        server_count,
        skill_count,
-       plugin_count
+       plugin_count,
+       last_success_at,
+       last_full_sync_at,
+       last_sync_commit
 FROM registry_sync
 WHERE id = sqlc.arg(id);
 
@@ -46,7 +49,10 @@ SELECT rs.id,
        rs.last_applied_filter_hash,
        rs.server_count,
        rs.skill_count,
-       rs.plugin_count
+       rs.plugin_count,
+       rs.last_success_at,
+       rs.last_full_sync_at,
+       rs.last_sync_commit
 FROM registry_sync rs
 INNER JOIN source s ON rs.source_id = s.id
 WHERE s.name = sqlc.arg(name);
@@ -65,6 +71,9 @@ SELECT s.name,
        rs.server_count,
        rs.skill_count,
        rs.plugin_count,
+       rs.last_success_at,
+       rs.last_full_sync_at,
+       rs.last_sync_commit,
        s.sync_schedule::interval AS sync_schedule
 FROM registry_sync rs
 INNER JOIN source s ON rs.source_id = s.id
@@ -82,7 +91,10 @@ INSERT INTO registry_sync (
     last_applied_filter_hash,
     server_count,
     skill_count,
-    plugin_count
+    plugin_count,
+    last_success_at,
+    last_full_sync_at,
+    last_sync_commit
 ) VALUES (
     (SELECT id FROM source WHERE name = sqlc.arg(name)),
     sqlc.arg(sync_status),
@@ -94,7 +106,10 @@ INSERT INTO registry_sync (
     sqlc.narg(last_applied_filter_hash),
     sqlc.arg(server_count),
     sqlc.arg(skill_count),
-    sqlc.arg(plugin_count)
+    sqlc.arg(plugin_count),
+    sqlc.narg(last_success_at),
+    sqlc.narg(last_full_sync_at),
+    sqlc.narg(last_sync_commit)
 )
 ON CONFLICT (source_id) DO UPDATE SET
     sync_status = EXCLUDED.sync_status,
@@ -102,11 +117,16 @@ ON CONFLICT (source_id) DO UPDATE SET
     started_at = EXCLUDED.started_at,
     ended_at = EXCLUDED.ended_at,
     attempt_count = EXCLUDED.attempt_count,
-    last_sync_hash = EXCLUDED.last_sync_hash,
     last_applied_filter_hash = EXCLUDED.last_applied_filter_hash,
     server_count = EXCLUDED.server_count,
     skill_count = EXCLUDED.skill_count,
-    plugin_count = EXCLUDED.plugin_count;
+    plugin_count = EXCLUDED.plugin_count,
+    last_sync_commit = EXCLUDED.last_sync_commit,
+    -- Failed attempts don't carry these values; keep the previous ones. The hash
+    -- identifies the stored data, such as the last imported bundle.
+    last_sync_hash = COALESCE(EXCLUDED.last_sync_hash, registry_sync.last_sync_hash),
+    last_success_at = COALESCE(EXCLUDED.last_success_at, registry_sync.last_success_at),
+    last_full_sync_at = COALESCE(EXCLUDED.last_full_sync_at, registry_sync.last_full_sync_at);
 
 -- name: InitializeSourceSync :exec
 INSERT INTO registry_sync (
@@ -132,6 +152,13 @@ SELECT
     unnest(sqlc.arg(error_msgs)::text[])
 ON CONFLICT (source_id) DO NOTHING;
 
+-- name: ClearSourceSyncCommits :exec
+-- Forget the synced commit of sources whose configuration may have changed, so
+-- that their next sync fetches the data instead of only checking the remote commit
+UPDATE registry_sync
+SET last_sync_commit = NULL
+WHERE source_id = ANY(sqlc.arg(source_ids)::uuid[]);
+
 -- name: ListSourceSyncsByLastUpdate :many
 SELECT s.name,
        rs.id,
@@ -146,13 +173,32 @@ SELECT s.name,
        rs.server_count,
        rs.skill_count,
        rs.plugin_count,
-       s.sync_schedule::interval AS sync_schedule
+       rs.last_success_at,
+       rs.last_full_sync_at,
+       rs.last_sync_commit,
+       s.sync_schedule::interval AS sync_schedule,
+       EXISTS (
+           SELECT 1 FROM sync_request sr
+           WHERE sr.source_id = s.id AND sr.status = 'PENDING'
+       ) AS manual_sync_requested
 FROM registry_sync rs
 INNER JOIN source s ON rs.source_id = s.id
 WHERE s.syncable = true
   AND (rs.ended_at IS NULL
-       OR rs.ended_at + s.sync_schedule::interval <= now())
-ORDER BY rs.ended_at ASC NULLS FIRST, s.name ASC
+       OR rs.ended_at + s.sync_schedule::interval <= now()
+       -- Manual sync requests bypass the sync schedule
+       OR EXISTS (
+           SELECT 1 FROM sync_request sr
+           WHERE sr.source_id = s.id AND sr.status = 'PENDING'
+       ))
+  -- Sources the caller passed over earlier in its transaction
+  AND s.name <> ALL(COALESCE(sqlc.arg(skipped_names)::text[], '{}'))
+-- Sources already being synced by another worker are only considered once
+-- nothing else is due, so that they do not hold up the queue
+ORDER BY manual_sync_requested DESC,
+         rs.sync_status = 'IN_PROGRESS' ASC,
+         rs.ended_at ASC NULLS FIRST,
+         s.name ASC
 FOR UPDATE OF rs SKIP LOCKED
 LIMIT 1;
 
@@ -161,3 +207,154 @@ UPDATE registry_sync
 SET sync_status = sqlc.arg(sync_status),
     started_at = sqlc.arg(started_at)
 WHERE source_id = (SELECT id FROM source WHERE name = sqlc.arg(name));
+
+-- name: InsertSyncRequest :one
+-- Queue a manual sync for a syncable source. A PENDING request for the same
+-- source is reused so repeated calls return the same request.
+INSERT INTO sync_request (source_id)
+SELECT s.id FROM source s
+WHERE s.name = sqlc.arg(name) AND s.syncable = true
+ON CONFLICT (source_id) WHERE status = 'PENDING'
+DO UPDATE SET requested_at = sync_request.requested_at
+RETURNING id,
+          source_id,
+          status,
+          error_msg,
+          requested_at,
+          started_at,
+          ended_at;
+
+-- name: GetSyncRequest :one
+SELECT sr.id,
+       sr.source_id,
+       sr.status,
+       sr.error_msg,
+       sr.requested_at,
+       sr.started_at,
+       sr.ended_at
+FROM sync_request sr
+INNER JOIN source s ON sr.source_id = s.id
+WHERE sr.id = sqlc.arg(id)
+  AND s.name = sqlc.arg(name);
+
+-- name: StartSyncRequests :exec
+UPDATE sync_request
+SET status = 'IN_PROGRESS',
+    started_at = sqlc.arg(started_at)
+WHERE source_id = (SELECT id FROM source WHERE name = sqlc.arg(name))
+  AND status = 'PENDING';
+
+-- name: FinishSyncRequests :exec
+UPDATE sync_request
+SET status = sqlc.arg(status),
+    error_msg = sqlc.narg(error_msg),
+    ended_at = sqlc.arg(ended_at)
+WHERE source_id = (SELECT id FROM source WHERE name = sqlc.arg(name))
+  AND status = 'IN_PROGRESS';
+
+-- name: InsertSyncHistory :exec
+INSERT INTO sync_history (
+    source_id,
+    sync_status,
+    reason,
+    condition_reason,
+    error_msg,
+    sync_hash,
+    incremental,
+    server_count,
+    skill_count,
+    plugin_count,
+    servers_added,
+    servers_updated,
+    servers_removed,
+    skills_added,
+    skills_updated,
+    skills_removed,
+    plugins_added,
+    plugins_updated,
+    plugins_removed,
+    started_at,
+    ended_at
+) VALUES (
+    (SELECT id FROM source WHERE name = sqlc.arg(name)),
+    sqlc.arg(sync_status),
+    sqlc.arg(reason),
+    sqlc.narg(condition_reason),
+    sqlc.narg(error_msg),
+    sqlc.narg(sync_hash),
+    sqlc.arg(incremental),
+    sqlc.arg(server_count),
+    sqlc.arg(skill_count),
+    sqlc.arg(plugin_count),
+    sqlc.arg(servers_added),
+    sqlc.arg(servers_updated),
+    sqlc.arg(servers_removed),
+    sqlc.arg(skills_added),
+    sqlc.arg(skills_updated),
+    sqlc.arg(skills_removed),
+    sqlc.arg(plugins_added),
+    sqlc.arg(plugins_updated),
+    sqlc.arg(plugins_removed),
+    sqlc.arg(started_at),
+    sqlc.arg(ended_at)
+);
+
+-- name: ListSyncHistory :many
+-- Cursor-based pagination using (started_at, id) compound cursor, newest first.
+-- When cursor is provided, results start AFTER the specified tuple.
+SELECT sh.id,
+       sh.source_id,
+       sh.sync_status,
+       sh.reason,
+       sh.condition_reason,
+       sh.error_msg,
+       sh.sync_hash,
+       sh.incremental,
+       sh.server_count,
+       sh.skill_count,
+       sh.plugin_count,
+       sh.servers_added,
+       sh.servers_updated,
+       sh.servers_removed,
+       sh.skills_added,
+       sh.skills_updated,
+       sh.skills_removed,
+       sh.plugins_added,
+       sh.plugins_updated,
+       sh.plugins_removed,
+       sh.started_at,
+       sh.ended_at
+FROM sync_history sh
+INNER JOIN source s ON sh.source_id = s.id
+WHERE s.name = sqlc.arg(name)
+  AND (
+      sqlc.narg(cursor_started_at)::timestamp with time zone IS NULL
+      OR (sh.started_at, sh.id) < (sqlc.narg(cursor_started_at)::timestamp with time zone, sqlc.narg(cursor_id)::uuid)
+  )
+ORDER BY sh.started_at DESC, sh.id DESC
+LIMIT sqlc.arg(size)::bigint;
+
+-- name: DeleteSyncHistoryBefore :exec
+DELETE FROM sync_history
+WHERE source_id = (SELECT id FROM source WHERE name = sqlc.arg(name))
+  AND started_at < sqlc.arg(before);
+
+-- name: GetSourceEntryDigests :one
+SELECT d.digests
+FROM sync_entry_digests d
+INNER JOIN source s ON d.source_id = s.id
+WHERE s.name = sqlc.arg(name);
+
+-- name: UpsertSourceEntryDigests :exec
+INSERT INTO sync_entry_digests (
+    source_id,
+    digests,
+    updated_at
+) VALUES (
+    (SELECT id FROM source WHERE name = sqlc.arg(name)),
+    sqlc.arg(digests),
+    sqlc.arg(updated_at)
+)
+ON CONFLICT (source_id) DO UPDATE SET
+    digests = EXCLUDED.digests,
+    updated_at = EXCLUDED.updated_at;
@@ -0,0 +1,70 @@
+-- name: InsertWebhookDeliveries :exec
+-- Queues the delivery of an event to each of the given webhooks.
+INSERT INTO webhook_delivery (
+    webhook,
+    event_type,
+    payload
+)
+SELECT w.webhook,
+       sqlc.arg(event_type)::text,
+       sqlc.arg(payload)::jsonb
+  FROM unnest(sqlc.arg(webhooks)::text[]) AS w(webhook);
+
+-- name: ClaimWebhookDeliveries :many
+-- Claims the pending deliveries that are due. Their next attempt is pushed back
+-- to the end of the lease, so that other instances skip them while they are
+-- delivered and retry them if this instance stops before recording the outcome.
+UPDATE webhook_delivery d
+   SET next_attempt_at = sqlc.arg(leased_until)::timestamptz
+ WHERE d.id IN (
+       SELECT p.id
+         FROM webhook_delivery p
+        WHERE p.status = 'PENDING'
+          AND p.next_attempt_at <= NOW()
+        ORDER BY p.next_attempt_at
+        LIMIT sqlc.arg(size)::bigint
+          FOR UPDATE SKIP LOCKED
+ )
+RETURNING d.id, d.webhook, d.event_type, d.payload, d.attempts;
+
+-- name: UpdateWebhookDeliveryAttempt :exec
+-- Records the outcome of a delivery attempt.
+UPDATE webhook_delivery
+   SET status = sqlc.arg(status)::webhook_delivery_status,
+       attempts = attempts + 1,
+       response_status = sqlc.narg(response_status)::integer,
+       last_error = sqlc.narg(last_error)::text,
+       next_attempt_at = sqlc.arg(next_attempt_at)::timestamptz,
+       delivered_at = sqlc.narg(delivered_at)::timestamptz
+ WHERE id = sqlc.arg(id)::uuid;
+
+-- name: ListWebhookDeliveries :many
+-- Cursor-based pagination using (created_at, id) compound cursor, newest first.
+-- When cursor is provided, results start AFTER the specified tuple.
+SELECT id,
+       webhook,
+       event_type,
+       payload,
+       status,
+       attempts,
+       response_status,
+       last_error,
+       next_attempt_at,
+       created_at,
+       delivered_at
+  FROM webhook_delivery
+ WHERE (sqlc.narg(webhook)::text IS NULL OR webhook = sqlc.narg(webhook)::text)
+   AND (sqlc.narg(status)::webhook_delivery_status IS NULL OR status = sqlc.narg(status)::webhook_delivery_status)
+   AND (
+       sqlc.narg(cursor_created_at)::timestamp with time zone IS NULL
+       OR (created_at, id) < (sqlc.narg(cursor_created_at)::timestamp with time zone, sqlc.narg(cursor_id)::uuid)
+   )
+ ORDER BY created_at DESC, id DESC
+ LIMIT sqlc.arg(size)::bigint;
+
+-- name: DeleteWebhookDeliveriesBefore :exec
+-- Prunes the deliveries that are no longer pending and were queued before the
+-- retention period.
+DELETE FROM webhook_delivery
+ WHERE status <> 'PENDING'
+   AND created_at < sqlc.arg(before);
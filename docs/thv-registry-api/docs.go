@@ -15,6 +15,9 @@ const docTemplate = `{
                         "description": "Endpoint is the base API URL (without path)\nThe registry handler will append the appropriate paths for the MCP Registry API v0.1:\n  - /v0.1/servers - List all servers\n  - /v0.1/servers/{name}/versions - List server versions\n  - /v0.1/servers/{name}/versions/{version} - Get specific version\nExample: \"http://my-registry-api.default.svc.cluster.local/registry\"",
                         "type": "string"
                     },
+                    "incremental": {
+                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_config.APIIncrementalConfig"
+                    },
                     "timeout": {
                         "description": "Timeout is the per-request timeout for HTTP requests to the API endpoint\nAccepts a Go duration string (e.g., \"30s\", \"1m\"); must be \u003e 0 and \u003c= 5m\nDefaults to 10s if not specified\nUseful for public or occasionally-slow upstreams where the default is too aggressive",
                         "type": "string"
@@ -22,6 +25,65 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "github_com_stacklok_toolhive-registry-server_internal_config.APIIncrementalConfig": {
+                "description": "Incremental enables incremental syncs that only fetch servers updated since\nthe last successful sync (via the upstream updated_since query parameter)\nand merge them into the stored data instead of replacing it",
+                "properties": {
+                    "enabled": {
+                        "description": "Enabled turns on incremental syncs for the source",
+                        "type": "boolean"
+                    },
+                    "fullSyncInterval": {
+                        "description": "FullSyncInterval is how often a full reconciliation sync runs in place of an\nincremental one, so that entries dropped by the filter or missed upstream are removed\nAccepts a Go duration string (e.g., \"12h\", \"24h\"); defaults to 24h if not specified",
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "github_com_stacklok_toolhive-registry-server_internal_config.BundleConfig": {
+                "description": "Signed bundle import source",
+                "properties": {
+                    "path": {
+                        "description": "Path is the path to a bundle file, or to a directory of bundle files, on the\nlocal filesystem. Mutually exclusive with URL - exactly one must be specified",
+                        "type": "string"
+                    },
+                    "publicKeys": {
+                        "description": "PublicKeys are the PEM encoded public keys (ECDSA, Ed25519 or RSA) trusted\nto sign the imported bundles. Bundles signed by another key are refused.",
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "timeout": {
+                        "description": "Timeout is the timeout for HTTP requests when using URL\nDefaults to 30s if not specified",
+                        "type": "string"
+                    },
+                    "url": {
+                        "description": "URL is the HTTP/HTTPS URL to fetch a full bundle from\nMutually exclusive with Path - exactly one must be specified",
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "github_com_stacklok_toolhive-registry-server_internal_config.ExpressionFilterConfig": {
+                "properties": {
+                    "exclude": {
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "include": {
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    }
+                },
+                "type": "object"
+            },
             "github_com_stacklok_toolhive-registry-server_internal_config.FileConfig": {
                 "description": "Local file or URL source",
                 "properties": {
@@ -47,6 +109,9 @@ const docTemplate = `{
             "github_com_stacklok_toolhive-registry-server_internal_config.FilterConfig": {
                 "description": "Filtering rules",
                 "properties": {
+                    "expressions": {
+                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_config.ExpressionFilterConfig"
+                    },
                     "names": {
                         "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_config.NameFilterConfig"
                     },
@@ -59,10 +124,16 @@ const docTemplate = `{
             "github_com_stacklok_toolhive-registry-server_internal_config.GitAuthConfig": {
                 "description": "Auth contains optional authentication for private repositories",
                 "properties": {
+                    "githubApp": {
+                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_config.GitHubAppAuthConfig"
+                    },
                     "passwordFile": {
                         "description": "PasswordFile is the path to a file containing the Git password/token\nMust be an absolute path; whitespace is trimmed from the content",
                         "type": "string"
                     },
+                    "ssh": {
+                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_config.GitSSHAuthConfig"
+                    },
                     "username": {
                         "description": "Username is the Git username for HTTP Basic authentication",
                         "type": "string"
@@ -85,7 +156,7 @@ const docTemplate = `{
                         "type": "string"
                     },
                     "path": {
-                        "description": "Path is the path to the registry file within the repository",
+                        "description": "Path is the path to the registry file within the repository. It may also name a\ndirectory or a glob pattern, whose matching files are merged into a single registry.",
                         "type": "string"
                     },
                     "repository": {
@@ -95,6 +166,63 @@ const docTemplate = `{
                     "tag": {
                         "description": "Tag is the Git tag to use (mutually exclusive with Branch and Commit)",
                         "type": "string"
+                    },
+                    "webhook": {
+                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_config.GitWebhookConfig"
+                    }
+                },
+                "type": "object"
+            },
+            "github_com_stacklok_toolhive-registry-server_internal_config.GitHubAppAuthConfig": {
+                "description": "GitHubApp configures authentication with GitHub App installation tokens",
+                "properties": {
+                    "apiUrl": {
+                        "description": "APIURL is the base URL of the GitHub API, for GitHub Enterprise Server (e.g., https://github.example.com/api/v3)\nDefaults to https://api.github.com",
+                        "type": "string"
+                    },
+                    "appId": {
+                        "description": "AppID is the ID of the GitHub App",
+                        "type": "integer"
+                    },
+                    "installationId": {
+                        "description": "InstallationID is the ID of the installation of the GitHub App that has access to the repository",
+                        "type": "integer"
+                    },
+                    "privateKeyFile": {
+                        "description": "PrivateKeyFile is the path to a file containing the PEM encoded private key of the GitHub App\nMust be an absolute path",
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "github_com_stacklok_toolhive-registry-server_internal_config.GitSSHAuthConfig": {
+                "description": "SSH configures SSH private key authentication (e.g., deploy keys)",
+                "properties": {
+                    "knownHostsFile": {
+                        "description": "KnownHostsFile is the path to a known_hosts file used to verify the host key of the server\nMust be an absolute path",
+                        "type": "string"
+                    },
+                    "passphraseFile": {
+                        "description": "PassphraseFile is the path to a file containing the passphrase of the private key\nMust be an absolute path; whitespace is trimmed from the content",
+                        "type": "string"
+                    },
+                    "privateKeyFile": {
+                        "description": "PrivateKeyFile is the path to a file containing the PEM encoded private key\nMust be an absolute path",
+                        "type": "string"
+                    },
+                    "user": {
+                        "description": "User is the SSH user name. Defaults to \"git\"",
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "github_com_stacklok_toolhive-registry-server_internal_config.GitWebhookConfig": {
+                "description": "Webhook enables push webhooks that trigger an immediate sync of the source",
+                "properties": {
+                    "secretFile": {
+                        "description": "SecretFile is the path to a file containing the webhook secret\nMust be an absolute path; whitespace is trimmed from the content",
+                        "type": "string"
                     }
                 },
                 "type": "object"
@@ -105,8 +233,56 @@ const docTemplate = `{
             },
             "github_com_stacklok_toolhive-registry-server_internal_config.ManagedConfig": {
                 "description": "Managed registry (no sync)",
+                "properties": {
+                    "requireApproval": {
+                        "description": "RequireApproval holds publishes as pending submissions, invisible to\nconsumers until a user with the reviewEntries role approves them",
+                        "type": "boolean"
+                    },
+                    "signing": {
+                        "description": "Signing lists the keys trusted to sign the entries published under\nnamespace prefixes. Publishes may attach a signature over the entry,\nverified against the keys of the longest matching prefix.",
+                        "items": {
+                            "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_config.SigningConfig"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    }
+                },
+                "type": "object"
+            },
+            "github_com_stacklok_toolhive-registry-server_internal_config.MergeConfig": {
+                "description": "Merge strategies, first-wins when unset",
+                "properties": {
+                    "description": {
+                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_config.MergeStrategy"
+                    },
+                    "icons": {
+                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_config.MergeStrategy"
+                    },
+                    "meta": {
+                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_config.MergeStrategy"
+                    },
+                    "versions": {
+                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_config.MergeStrategy"
+                    }
+                },
                 "type": "object"
             },
+            "github_com_stacklok_toolhive-registry-server_internal_config.MergeStrategy": {
+                "description": "Description selects the source of the description of a server version",
+                "enum": [
+                    "first-wins",
+                    "highest-version",
+                    "union",
+                    "overlay"
+                ],
+                "type": "string",
+                "x-enum-varnames": [
+                    "MergeFirstWins",
+                    "MergeHighestVersion",
+                    "MergeUnion",
+                    "MergeOverlay"
+                ]
+            },
             "github_com_stacklok_toolhive-registry-server_internal_config.NameFilterConfig": {
                 "properties": {
                     "exclude": {
@@ -126,13 +302,35 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "github_com_stacklok_toolhive-registry-server_internal_config.SigningConfig": {
+                "properties": {
+                    "namespace": {
+                        "description": "Namespace is the prefix of the names of the entries the keys are trusted\nfor, such as \"com.example/\". It covers the names continuing it after a\n\".\" or \"/\", so \"com.example\" does not cover \"com.examplecorp/weather\".\nSkill and plugin names are prefixed with their namespace, e.g.\n\"io.github.acme/calendar\".",
+                        "type": "string"
+                    },
+                    "publicKeys": {
+                        "description": "PublicKeys are the PEM encoded public keys (ECDSA, Ed25519 or RSA)\ntrusted to sign the entries of the namespace",
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "requireSignature": {
+                        "description": "RequireSignature rejects the publishes into the namespace without a\nvalid signature",
+                        "type": "boolean"
+                    }
+                },
+                "type": "object"
+            },
             "github_com_stacklok_toolhive-registry-server_internal_config.SourceType": {
-                "description": "git, api, file, managed, kubernetes",
+                "description": "git, api, file, bundle, managed, kubernetes",
                 "enum": [
                     "git",
                     "api",
                     "file",
                     "managed",
+                    "bundle",
                     "kubernetes"
                 ],
                 "type": "string",
@@ -141,6 +339,7 @@ const docTemplate = `{
                     "SourceTypeAPI",
                     "SourceTypeFile",
                     "SourceTypeManaged",
+                    "SourceTypeBundle",
                     "SourceTypeKubernetes"
                 ]
             },
@@ -172,6 +371,57 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "github_com_stacklok_toolhive-registry-server_internal_service.BulkEntryResult": {
+                "properties": {
+                    "entryType": {
+                        "type": "string"
+                    },
+                    "error": {
+                        "type": "string"
+                    },
+                    "name": {
+                        "type": "string"
+                    },
+                    "namespace": {
+                        "type": "string"
+                    },
+                    "status": {
+                        "enum": [
+                            "published",
+                            "pending_review",
+                            "failed",
+                            "skipped"
+                        ],
+                        "type": "string"
+                    },
+                    "submissionId": {
+                        "type": "string"
+                    },
+                    "version": {
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "github_com_stacklok_toolhive-registry-server_internal_service.BulkPublishResult": {
+                "properties": {
+                    "atomic": {
+                        "type": "boolean"
+                    },
+                    "committed": {
+                        "description": "Committed is false when an atomic bulk publish was rolled back",
+                        "type": "boolean"
+                    },
+                    "results": {
+                        "items": {
+                            "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.BulkEntryResult"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    }
+                },
+                "type": "object"
+            },
             "github_com_stacklok_toolhive-registry-server_internal_service.CreationType": {
                 "description": "API or CONFIG",
                 "enum": [
@@ -184,81 +434,104 @@ const docTemplate = `{
                     "CreationTypeCONFIG"
                 ]
             },
-            "github_com_stacklok_toolhive-registry-server_internal_service.EntryVersionInfo": {
+            "github_com_stacklok_toolhive-registry-server_internal_service.EntryChangesInfo": {
                 "properties": {
-                    "createdAt": {
-                        "type": "string"
+                    "added": {
+                        "type": "integer"
                     },
-                    "description": {
-                        "type": "string"
+                    "removed": {
+                        "type": "integer"
                     },
-                    "title": {
+                    "updated": {
+                        "type": "integer"
+                    }
+                },
+                "type": "object"
+            },
+            "github_com_stacklok_toolhive-registry-server_internal_service.EntryScanResult": {
+                "properties": {
+                    "findings": {
+                        "items": {
+                            "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.ScanFinding"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "scannedAt": {
                         "type": "string"
                     },
-                    "updatedAt": {
+                    "verdict": {
+                        "enum": [
+                            "allow",
+                            "annotate"
+                        ],
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "github_com_stacklok_toolhive-registry-server_internal_service.EntrySignature": {
+                "description": "Signature is an optional signature over the JSON encoding of the server,\nskill or plugin, exactly as sent in this request",
+                "properties": {
+                    "format": {
+                        "description": "Format is jws, for a JWS with detached payload in compact serialization,\nor cosign, for the base64 encoded output of cosign sign-blob",
+                        "enum": [
+                            "jws",
+                            "cosign"
+                        ],
                         "type": "string"
                     },
-                    "version": {
+                    "value": {
+                        "description": "Value is the signature",
                         "type": "string"
                     }
                 },
                 "type": "object"
             },
-            "github_com_stacklok_toolhive-registry-server_internal_service.Plugin": {
+            "github_com_stacklok_toolhive-registry-server_internal_service.EntrySubmission": {
                 "properties": {
-                    "_meta": {
+                    "claims": {
                         "additionalProperties": {},
                         "type": "object"
                     },
-                    "createdAt": {
-                        "type": "string"
+                    "entry": {
+                        "description": "Published server, skill or plugin",
+                        "type": "object"
                     },
-                    "description": {
+                    "entryType": {
+                        "description": "EntryTypeServer, EntryTypeSkill, or EntryTypePlugin",
                         "type": "string"
                     },
-                    "icons": {
-                        "items": {
-                            "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.PluginIcon"
-                        },
-                        "type": "array",
-                        "uniqueItems": false
-                    },
                     "id": {
                         "type": "string"
                     },
-                    "isLatest": {
-                        "type": "boolean"
-                    },
-                    "license": {
+                    "name": {
                         "type": "string"
                     },
-                    "metadata": {
-                        "additionalProperties": {},
-                        "type": "object"
+                    "namespace": {
+                        "description": "skills and plugins only",
+                        "type": "string"
                     },
-                    "name": {
+                    "reviewComment": {
                         "type": "string"
                     },
-                    "namespace": {
+                    "reviewedAt": {
                         "type": "string"
                     },
-                    "packages": {
-                        "items": {
-                            "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.PluginPackage"
-                        },
-                        "type": "array",
-                        "uniqueItems": false
+                    "reviewedBy": {
+                        "type": "string"
                     },
-                    "repository": {
-                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.PluginRepository"
+                    "signature": {
+                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.SignatureVerification"
                     },
                     "status": {
+                        "description": "pending, approved, rejected",
                         "type": "string"
                     },
-                    "title": {
+                    "submittedAt": {
                         "type": "string"
                     },
-                    "updatedAt": {
+                    "submittedBy": {
                         "type": "string"
                     },
                     "version": {
@@ -267,44 +540,171 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
-            "github_com_stacklok_toolhive-registry-server_internal_service.PluginIcon": {
+            "github_com_stacklok_toolhive-registry-server_internal_service.EntrySubmissionListResponse": {
                 "properties": {
-                    "label": {
-                        "type": "string"
-                    },
-                    "size": {
-                        "type": "string"
-                    },
-                    "src": {
+                    "nextCursor": {
+                        "description": "NextCursor is the cursor to use for fetching the next page of results.\nEmpty string indicates no more results are available.",
                         "type": "string"
                     },
-                    "type": {
-                        "type": "string"
+                    "submissions": {
+                        "items": {
+                            "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.EntrySubmission"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
                     }
                 },
                 "type": "object"
             },
-            "github_com_stacklok_toolhive-registry-server_internal_service.PluginPackage": {
+            "github_com_stacklok_toolhive-registry-server_internal_service.EntryVersionInfo": {
                 "properties": {
-                    "commit": {
-                        "type": "string"
-                    },
-                    "digest": {
+                    "createdAt": {
                         "type": "string"
                     },
-                    "identifier": {
+                    "description": {
                         "type": "string"
                     },
-                    "mediaType": {
+                    "title": {
                         "type": "string"
                     },
-                    "ref": {
+                    "updatedAt": {
                         "type": "string"
                     },
-                    "registryType": {
+                    "version": {
                         "type": "string"
-                    },
-                    "subfolder": {
+                    }
+                },
+                "type": "object"
+            },
+            "github_com_stacklok_toolhive-registry-server_internal_service.NamespaceInfo": {
+                "properties": {
+                    "claims": {
+                        "additionalProperties": {},
+                        "description": "Claims a caller must cover to publish, delete or update the claims of the\nentries of the namespace. Without claims, only super-admins may.",
+                        "type": "object"
+                    },
+                    "createdAt": {
+                        "type": "string"
+                    },
+                    "name": {
+                        "type": "string"
+                    },
+                    "updatedAt": {
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "github_com_stacklok_toolhive-registry-server_internal_service.NamespaceRequest": {
+                "properties": {
+                    "claims": {
+                        "additionalProperties": {},
+                        "type": "object"
+                    }
+                },
+                "type": "object"
+            },
+            "github_com_stacklok_toolhive-registry-server_internal_service.Plugin": {
+                "properties": {
+                    "_meta": {
+                        "additionalProperties": {},
+                        "type": "object"
+                    },
+                    "createdAt": {
+                        "type": "string"
+                    },
+                    "description": {
+                        "type": "string"
+                    },
+                    "icons": {
+                        "items": {
+                            "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.PluginIcon"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "id": {
+                        "type": "string"
+                    },
+                    "isLatest": {
+                        "type": "boolean"
+                    },
+                    "license": {
+                        "type": "string"
+                    },
+                    "metadata": {
+                        "additionalProperties": {},
+                        "type": "object"
+                    },
+                    "name": {
+                        "type": "string"
+                    },
+                    "namespace": {
+                        "type": "string"
+                    },
+                    "packages": {
+                        "items": {
+                            "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.PluginPackage"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "repository": {
+                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.PluginRepository"
+                    },
+                    "status": {
+                        "type": "string"
+                    },
+                    "title": {
+                        "type": "string"
+                    },
+                    "updatedAt": {
+                        "type": "string"
+                    },
+                    "version": {
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "github_com_stacklok_toolhive-registry-server_internal_service.PluginIcon": {
+                "properties": {
+                    "label": {
+                        "type": "string"
+                    },
+                    "size": {
+                        "type": "string"
+                    },
+                    "src": {
+                        "type": "string"
+                    },
+                    "type": {
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "github_com_stacklok_toolhive-registry-server_internal_service.PluginPackage": {
+                "properties": {
+                    "commit": {
+                        "type": "string"
+                    },
+                    "digest": {
+                        "type": "string"
+                    },
+                    "identifier": {
+                        "type": "string"
+                    },
+                    "mediaType": {
+                        "type": "string"
+                    },
+                    "ref": {
+                        "type": "string"
+                    },
+                    "registryType": {
+                        "type": "string"
+                    },
+                    "subfolder": {
                         "type": "string"
                     },
                     "url": {
@@ -331,6 +731,9 @@ const docTemplate = `{
                         "description": "Authorization claims",
                         "type": "object"
                     },
+                    "merge": {
+                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_config.MergeConfig"
+                    },
                     "sources": {
                         "description": "ordered list of source names",
                         "items": {
@@ -365,12 +768,21 @@ const docTemplate = `{
                     "entryType": {
                         "type": "string"
                     },
+                    "fieldSources": {
+                        "additionalProperties": {
+                            "type": "string"
+                        },
+                        "type": "object"
+                    },
                     "name": {
                         "type": "string"
                     },
                     "position": {
                         "type": "integer"
                     },
+                    "scan": {
+                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.EntryScanResult"
+                    },
                     "sourceName": {
                         "type": "string"
                     },
@@ -398,6 +810,9 @@ const docTemplate = `{
                     "creationType": {
                         "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.CreationType"
                     },
+                    "merge": {
+                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_config.MergeConfig"
+                    },
                     "name": {
                         "type": "string"
                     },
@@ -414,6 +829,108 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "github_com_stacklok_toolhive-registry-server_internal_service.ScanFinding": {
+                "properties": {
+                    "id": {
+                        "type": "string"
+                    },
+                    "message": {
+                        "type": "string"
+                    },
+                    "scanner": {
+                        "description": "Scanner is the name of the scanner, as configured in the entry policy",
+                        "type": "string"
+                    },
+                    "severity": {
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "github_com_stacklok_toolhive-registry-server_internal_service.ServerOverlayInfo": {
+                "properties": {
+                    "createdAt": {
+                        "type": "string"
+                    },
+                    "icons": {
+                        "description": "Icons replace the icons of the server",
+                        "items": {
+                            "$ref": "#/components/schemas/model.Icon"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "meta": {
+                        "additionalProperties": {},
+                        "description": "Meta is reported under OverlayMetaKey",
+                        "type": "object"
+                    },
+                    "name": {
+                        "type": "string"
+                    },
+                    "tags": {
+                        "description": "Tags are reported under OverlayMetaKey",
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "title": {
+                        "description": "Title overrides the display name of the server",
+                        "type": "string"
+                    },
+                    "updatedAt": {
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "github_com_stacklok_toolhive-registry-server_internal_service.ServerOverlayRequest": {
+                "properties": {
+                    "icons": {
+                        "items": {
+                            "$ref": "#/components/schemas/model.Icon"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "meta": {
+                        "additionalProperties": {},
+                        "type": "object"
+                    },
+                    "tags": {
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "title": {
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "github_com_stacklok_toolhive-registry-server_internal_service.SignatureVerification": {
+                "description": "Signature is the verified signature attached to the publish, if any",
+                "properties": {
+                    "format": {
+                        "type": "string"
+                    },
+                    "keyId": {
+                        "description": "sha256 digest of the DER encoded public key",
+                        "type": "string"
+                    },
+                    "verified": {
+                        "type": "boolean"
+                    },
+                    "verifiedAt": {
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
             "github_com_stacklok_toolhive-registry-server_internal_service.Skill": {
                 "properties": {
                     "_meta": {
@@ -549,6 +1066,9 @@ const docTemplate = `{
                     "api": {
                         "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_config.APIConfig"
                     },
+                    "bundle": {
+                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_config.BundleConfig"
+                    },
                     "claims": {
                         "additionalProperties": {},
                         "description": "Authorization claims",
@@ -663,49 +1183,229 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
-            "github_com_stacklok_toolhive-registry-server_internal_service.SourceSyncStatus": {
+            "github_com_stacklok_toolhive-registry-server_internal_service.SourceSyncInfo": {
                 "properties": {
-                    "attemptCount": {
-                        "description": "Number of sync attempts",
-                        "type": "integer"
+                    "changes": {
+                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.SyncChangesInfo"
                     },
-                    "lastAttempt": {
-                        "description": "Last sync attempt",
+                    "conditionReason": {
+                        "description": "Failure classification",
                         "type": "string"
                     },
-                    "lastSyncTime": {
-                        "description": "Last completed sync attempt",
+                    "endedAt": {
                         "type": "string"
                     },
-                    "message": {
-                        "description": "Status or error message",
+                    "hash": {
+                        "description": "Hash of the synced data",
                         "type": "string"
                     },
-                    "phase": {
-                        "description": "complete, syncing, failed",
+                    "id": {
+                        "type": "string"
+                    },
+                    "incremental": {
+                        "description": "Only changed entries were fetched",
+                        "type": "boolean"
+                    },
+                    "message": {
+                        "description": "Error message of a failed sync",
                         "type": "string"
                     },
                     "pluginCount": {
-                        "description": "Number of plugins in registry",
+                        "description": "Number of plugins after the sync",
                         "type": "integer"
                     },
+                    "reason": {
+                        "description": "Why the sync was triggered",
+                        "type": "string"
+                    },
                     "serverCount": {
-                        "description": "Number of servers in registry",
+                        "description": "Number of servers after the sync",
                         "type": "integer"
                     },
                     "skillCount": {
-                        "description": "Number of skills in registry",
+                        "description": "Number of skills after the sync",
                         "type": "integer"
+                    },
+                    "startedAt": {
+                        "type": "string"
+                    },
+                    "status": {
+                        "description": "complete, failed",
+                        "type": "string"
                     }
                 },
                 "type": "object"
             },
-            "internal_api_v1.entryClaimsResponse": {
+            "github_com_stacklok_toolhive-registry-server_internal_service.SourceSyncListResponse": {
                 "properties": {
-                    "claims": {
-                        "additionalProperties": {},
-                        "type": "object"
-                    }
+                    "nextCursor": {
+                        "description": "NextCursor is the cursor to use for fetching the next page of results.\nEmpty string indicates no more results are available.",
+                        "type": "string"
+                    },
+                    "syncs": {
+                        "items": {
+                            "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.SourceSyncInfo"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    }
+                },
+                "type": "object"
+            },
+            "github_com_stacklok_toolhive-registry-server_internal_service.SourceSyncStatus": {
+                "properties": {
+                    "attemptCount": {
+                        "description": "Number of sync attempts",
+                        "type": "integer"
+                    },
+                    "commitSha": {
+                        "description": "Git commit of the last successful sync",
+                        "type": "string"
+                    },
+                    "lastAttempt": {
+                        "description": "Last sync attempt",
+                        "type": "string"
+                    },
+                    "lastSyncTime": {
+                        "description": "Last completed sync attempt",
+                        "type": "string"
+                    },
+                    "message": {
+                        "description": "Status or error message",
+                        "type": "string"
+                    },
+                    "phase": {
+                        "description": "complete, syncing, failed",
+                        "type": "string"
+                    },
+                    "pluginCount": {
+                        "description": "Number of plugins in registry",
+                        "type": "integer"
+                    },
+                    "serverCount": {
+                        "description": "Number of servers in registry",
+                        "type": "integer"
+                    },
+                    "skillCount": {
+                        "description": "Number of skills in registry",
+                        "type": "integer"
+                    }
+                },
+                "type": "object"
+            },
+            "github_com_stacklok_toolhive-registry-server_internal_service.SyncChangesInfo": {
+                "description": "Entry versions changed by the sync",
+                "properties": {
+                    "plugins": {
+                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.EntryChangesInfo"
+                    },
+                    "servers": {
+                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.EntryChangesInfo"
+                    },
+                    "skills": {
+                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.EntryChangesInfo"
+                    }
+                },
+                "type": "object"
+            },
+            "github_com_stacklok_toolhive-registry-server_internal_service.SyncRequestInfo": {
+                "properties": {
+                    "endedAt": {
+                        "description": "When the sync finished",
+                        "type": "string"
+                    },
+                    "id": {
+                        "type": "string"
+                    },
+                    "message": {
+                        "description": "Error message of a failed sync",
+                        "type": "string"
+                    },
+                    "requestedAt": {
+                        "description": "When the sync was requested",
+                        "type": "string"
+                    },
+                    "sourceName": {
+                        "type": "string"
+                    },
+                    "startedAt": {
+                        "description": "When the coordinator picked up the request",
+                        "type": "string"
+                    },
+                    "status": {
+                        "description": "pending, syncing, complete, failed",
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "github_com_stacklok_toolhive-registry-server_internal_service.WebhookDeliveryInfo": {
+                "properties": {
+                    "attempts": {
+                        "description": "Number of attempts made so far",
+                        "type": "integer"
+                    },
+                    "createdAt": {
+                        "description": "When the event was queued",
+                        "type": "string"
+                    },
+                    "deliveredAt": {
+                        "type": "string"
+                    },
+                    "eventType": {
+                        "type": "string"
+                    },
+                    "id": {
+                        "type": "string"
+                    },
+                    "lastError": {
+                        "description": "Error of the last failed attempt",
+                        "type": "string"
+                    },
+                    "nextAttemptAt": {
+                        "description": "When a pending delivery is attempted next",
+                        "type": "string"
+                    },
+                    "payload": {
+                        "description": "Body of the delivery",
+                        "type": "object"
+                    },
+                    "responseStatus": {
+                        "description": "HTTP status of the last attempt",
+                        "type": "integer"
+                    },
+                    "status": {
+                        "description": "pending, delivered, failed",
+                        "type": "string"
+                    },
+                    "webhook": {
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "github_com_stacklok_toolhive-registry-server_internal_service.WebhookDeliveryListResponse": {
+                "properties": {
+                    "deliveries": {
+                        "items": {
+                            "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.WebhookDeliveryInfo"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "nextCursor": {
+                        "description": "NextCursor is the cursor to use for fetching the next page of results.\nEmpty string indicates no more results are available.",
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "internal_api_v1.entryClaimsResponse": {
+                "properties": {
+                    "claims": {
+                        "additionalProperties": {},
+                        "type": "object"
+                    }
                 },
                 "type": "object"
             },
@@ -724,6 +1424,30 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "internal_api_v1.namespaceListResponse": {
+                "properties": {
+                    "namespaces": {
+                        "items": {
+                            "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.NamespaceInfo"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    }
+                },
+                "type": "object"
+            },
+            "internal_api_v1.overlayListResponse": {
+                "properties": {
+                    "overlays": {
+                        "items": {
+                            "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.ServerOverlayInfo"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    }
+                },
+                "type": "object"
+            },
             "internal_api_v1.publishEntryRequest": {
                 "properties": {
                     "claims": {
@@ -736,6 +1460,9 @@ const docTemplate = `{
                     "server": {
                         "$ref": "#/components/schemas/v0.ServerJSON"
                     },
+                    "signature": {
+                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.EntrySignature"
+                    },
                     "skill": {
                         "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.Skill"
                     }
@@ -754,6 +1481,15 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "internal_api_v1.reviewSubmissionRequest": {
+                "properties": {
+                    "comment": {
+                        "description": "Comment is recorded with the decision. It is required to reject a submission.",
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
             "internal_api_v1.updateEntryClaimsRequest": {
                 "properties": {
                     "claims": {
@@ -763,33 +1499,96 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
-            "internal_api_x_plugins.PluginListMetadata": {
+            "internal_api_v1.updateEntryVersionStatusRequest": {
+                "properties": {
+                    "reason": {
+                        "description": "Reason explains why the version was deprecated or yanked",
+                        "type": "string"
+                    },
+                    "replacedBy": {
+                        "description": "ReplacedBy is the version to use instead of a deprecated or yanked one",
+                        "type": "string"
+                    },
+                    "status": {
+                        "description": "Status is one of \"active\", \"deprecated\", or \"yanked\"",
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "internal_api_webhooks.Response": {
+                "properties": {
+                    "message": {
+                        "description": "why the delivery was ignored",
+                        "type": "string"
+                    },
+                    "status": {
+                        "description": "queued or ignored",
+                        "type": "string"
+                    },
+                    "syncRequest": {
+                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.SyncRequestInfo"
+                    }
+                },
+                "type": "object"
+            },
+            "internal_api_x_changes.Change": {
+                "properties": {
+                    "changeType": {
+                        "description": "created, updated or deleted",
+                        "type": "string"
+                    },
+                    "changedAt": {
+                        "type": "string"
+                    },
+                    "name": {
+                        "type": "string"
+                    },
+                    "namespace": {
+                        "description": "skills and plugins only",
+                        "type": "string"
+                    },
+                    "token": {
+                        "description": "resume after this change",
+                        "type": "string"
+                    },
+                    "type": {
+                        "description": "server, skill or plugin",
+                        "type": "string"
+                    },
+                    "version": {
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "internal_api_x_changes.Metadata": {
                 "properties": {
                     "count": {
                         "type": "integer"
                     },
-                    "nextCursor": {
+                    "nextToken": {
                         "type": "string"
                     }
                 },
                 "type": "object"
             },
-            "internal_api_x_plugins.PluginListResponse": {
+            "internal_api_x_changes.Response": {
                 "properties": {
-                    "metadata": {
-                        "$ref": "#/components/schemas/internal_api_x_plugins.PluginListMetadata"
-                    },
-                    "plugins": {
+                    "changes": {
                         "items": {
-                            "$ref": "#/components/schemas/registry.Plugin"
+                            "$ref": "#/components/schemas/internal_api_x_changes.Change"
                         },
                         "type": "array",
                         "uniqueItems": false
+                    },
+                    "metadata": {
+                        "$ref": "#/components/schemas/internal_api_x_changes.Metadata"
                     }
                 },
                 "type": "object"
             },
-            "internal_api_x_skills.SkillListMetadata": {
+            "internal_api_x_plugins.PluginListMetadata": {
                 "properties": {
                     "count": {
                         "type": "integer"
@@ -800,14 +1599,14 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
-            "internal_api_x_skills.SkillListResponse": {
+            "internal_api_x_plugins.PluginListResponse": {
                 "properties": {
                     "metadata": {
-                        "$ref": "#/components/schemas/internal_api_x_skills.SkillListMetadata"
+                        "$ref": "#/components/schemas/internal_api_x_plugins.PluginListMetadata"
                     },
-                    "skills": {
+                    "plugins": {
                         "items": {
-                            "$ref": "#/components/schemas/registry.Skill"
+                            "$ref": "#/components/schemas/registry.Plugin"
                         },
                         "type": "array",
                         "uniqueItems": false
@@ -815,53 +1614,133 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
-            "model.Argument": {
+            "internal_api_x_search.Hit": {
                 "properties": {
-                    "choices": {
-                        "items": {
-                            "type": "string"
-                        },
-                        "type": "array",
-                        "uniqueItems": false
-                    },
-                    "default": {
-                        "type": "string"
-                    },
                     "description": {
                         "type": "string"
                     },
-                    "format": {
-                        "$ref": "#/components/schemas/model.Format"
-                    },
-                    "isRepeated": {
-                        "type": "boolean"
-                    },
-                    "isRequired": {
-                        "type": "boolean"
-                    },
-                    "isSecret": {
-                        "type": "boolean"
-                    },
                     "name": {
-                        "example": "--port",
                         "type": "string"
                     },
-                    "placeholder": {
+                    "namespace": {
+                        "description": "skills and plugins only",
                         "type": "string"
                     },
-                    "type": {
-                        "$ref": "#/components/schemas/model.ArgumentType"
+                    "title": {
+                        "type": "string"
                     },
-                    "value": {
+                    "type": {
+                        "description": "server, skill or plugin",
                         "type": "string"
                     },
-                    "valueHint": {
-                        "example": "file_path",
+                    "updatedAt": {
                         "type": "string"
                     },
-                    "variables": {
-                        "additionalProperties": {
-                            "$ref": "#/components/schemas/model.Input"
+                    "version": {
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "internal_api_x_search.Metadata": {
+                "properties": {
+                    "count": {
+                        "type": "integer"
+                    },
+                    "nextCursor": {
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "internal_api_x_search.Response": {
+                "properties": {
+                    "metadata": {
+                        "$ref": "#/components/schemas/internal_api_x_search.Metadata"
+                    },
+                    "results": {
+                        "items": {
+                            "$ref": "#/components/schemas/internal_api_x_search.Hit"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    }
+                },
+                "type": "object"
+            },
+            "internal_api_x_skills.SkillListMetadata": {
+                "properties": {
+                    "count": {
+                        "type": "integer"
+                    },
+                    "nextCursor": {
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "internal_api_x_skills.SkillListResponse": {
+                "properties": {
+                    "metadata": {
+                        "$ref": "#/components/schemas/internal_api_x_skills.SkillListMetadata"
+                    },
+                    "skills": {
+                        "items": {
+                            "$ref": "#/components/schemas/registry.Skill"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    }
+                },
+                "type": "object"
+            },
+            "model.Argument": {
+                "properties": {
+                    "choices": {
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "default": {
+                        "type": "string"
+                    },
+                    "description": {
+                        "type": "string"
+                    },
+                    "format": {
+                        "$ref": "#/components/schemas/model.Format"
+                    },
+                    "isRepeated": {
+                        "type": "boolean"
+                    },
+                    "isRequired": {
+                        "type": "boolean"
+                    },
+                    "isSecret": {
+                        "type": "boolean"
+                    },
+                    "name": {
+                        "example": "--port",
+                        "type": "string"
+                    },
+                    "placeholder": {
+                        "type": "string"
+                    },
+                    "type": {
+                        "$ref": "#/components/schemas/model.ArgumentType"
+                    },
+                    "value": {
+                        "type": "string"
+                    },
+                    "valueHint": {
+                        "example": "file_path",
+                        "type": "string"
+                    },
+                    "variables": {
+                        "additionalProperties": {
+                            "$ref": "#/components/schemas/model.Input"
                         },
                         "type": "object"
                     }
@@ -1321,7 +2200,7 @@ const docTemplate = `{
                 "type": "object"
             },
             "registry.SkillRepository": {
-                "description": "Repository is the source repository of the skill.",
+                "description": "Repository is the source repository of the plugin.",
                 "properties": {
                     "type": {
                         "description": "Type is the type of the repository.",
@@ -1334,6 +2213,65 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "registry.UpstreamData": {
+                "description": "Data contains the actual registry content",
+                "properties": {
+                    "plugins": {
+                        "description": "Plugins contains the plugin definitions",
+                        "items": {
+                            "$ref": "#/components/schemas/registry.Plugin"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "servers": {
+                        "description": "Servers contains the server definitions in upstream MCP format",
+                        "items": {
+                            "$ref": "#/components/schemas/v0.ServerJSON"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "skills": {
+                        "description": "Skills contains the skill definitions",
+                        "items": {
+                            "$ref": "#/components/schemas/registry.Skill"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    }
+                },
+                "type": "object"
+            },
+            "registry.UpstreamMeta": {
+                "description": "Meta contains registry metadata",
+                "properties": {
+                    "last_updated": {
+                        "description": "LastUpdated is the timestamp when registry was last updated in RFC3339 format",
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "registry.UpstreamRegistry": {
+                "properties": {
+                    "$schema": {
+                        "description": "Schema is the JSON schema URL for validation",
+                        "type": "string"
+                    },
+                    "data": {
+                        "$ref": "#/components/schemas/registry.UpstreamData"
+                    },
+                    "meta": {
+                        "$ref": "#/components/schemas/registry.UpstreamMeta"
+                    },
+                    "version": {
+                        "description": "Version is the schema version (e.g., \"1.0.0\")",
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
             "v0.Metadata": {
                 "properties": {
                     "count": {
@@ -1545,7 +2483,7 @@ const docTemplate = `{
         },
         "/registry/{registryName}/v0.1/servers": {
             "get": {
-                "description": "Get a list of available servers from a specific registry",
+                "description": "Get a list of available servers from a specific registry. Deprecated and yanked versions are not listed.",
                 "parameters": [
                     {
                         "description": "Registry name",
@@ -1573,7 +2511,7 @@ const docTemplate = `{
                         }
                     },
                     {
-                        "description": "Search servers by name (substring match)",
+                        "description": "Full-text search on name, title, description and tool names, ordered by relevance",
                         "in": "query",
                         "name": "search",
                         "schema": {
@@ -1611,22 +2549,2431 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Bad request"
-                    },
-                    "401": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Registry not found"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "List servers in specific registry",
+                "tags": [
+                    "registry"
+                ]
+            }
+        },
+        "/registry/{registryName}/v0.1/servers/{serverName}/versions": {
+            "get": {
+                "description": "Returns all available versions for a specific MCP server from a specific registry,\nincluding deprecated and yanked ones.",
+                "parameters": [
+                    {
+                        "description": "Registry name",
+                        "in": "path",
+                        "name": "registryName",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "URL-encoded server name (e.g., \\",
+                        "in": "path",
+                        "name": "serverName",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/v0.ServerListResponse"
+                                }
+                            }
+                        },
+                        "description": "A list of all versions for the server"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Server not found"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "List all versions of an MCP server in specific registry",
+                "tags": [
+                    "registry"
+                ]
+            }
+        },
+        "/registry/{registryName}/v0.1/servers/{serverName}/versions/{version}": {
+            "get": {
+                "description": "Returns detailed information about a specific version of an MCP server from a specific registry.\nUse the special version ` + "`" + `latest` + "`" + ` to get the latest version that is neither deprecated nor yanked.",
+                "parameters": [
+                    {
+                        "description": "Registry name",
+                        "in": "path",
+                        "name": "registryName",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "URL-encoded server name (e.g., \\",
+                        "in": "path",
+                        "name": "serverName",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "URL-encoded version to retrieve (e.g., \\",
+                        "in": "path",
+                        "name": "version",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/v0.ServerResponse"
+                                }
+                            }
+                        },
+                        "description": "Detailed server information"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Server or version not found"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Get specific MCP server version in specific registry",
+                "tags": [
+                    "registry"
+                ]
+            }
+        },
+        "/registry/{registryName}/v0.1/x/dev.toolhive/changes": {
+            "get": {
+                "description": "Lists the server, skill and plugin versions of a registry that were created, updated or\ndeleted after a change token, oldest first. Without a token, changes are listed from the\noldest retained one. Pass the returned nextToken as since to resume; changes are retained\nas long as the sync history.",
+                "parameters": [
+                    {
+                        "description": "Registry name",
+                        "in": "path",
+                        "name": "registryName",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Change token to resume after",
+                        "in": "query",
+                        "name": "since",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Max changes (default 100, max 1000)",
+                        "in": "query",
+                        "name": "limit",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/internal_api_x_changes.Response"
+                                }
+                            }
+                        },
+                        "description": "Changes after the token"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Insufficient claims"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Registry not found"
+                    },
+                    "410": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Changes after the token are no longer retained"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "List registry changes",
+                "tags": [
+                    "changes"
+                ]
+            }
+        },
+        "/registry/{registryName}/v0.1/x/dev.toolhive/events": {
+            "get": {
+                "description": "Server-sent event stream of the server, skill and plugin versions of a registry that are\ncreated, updated or deleted. Each event is named after its change type, carries a Change as\ndata and the change token as id. The stream resumes after the Last-Event-ID header or the\nsince parameter, and otherwise starts with the changes made after the connection.",
+                "parameters": [
+                    {
+                        "description": "Registry name",
+                        "in": "path",
+                        "name": "registryName",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Change token to resume after",
+                        "in": "query",
+                        "name": "since",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Change token to resume after, set by reconnecting clients",
+                        "in": "header",
+                        "name": "Last-Event-ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "text/event-stream": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/internal_api_x_changes.Change"
+                                }
+                            }
+                        },
+                        "description": "Stream of change events"
+                    },
+                    "400": {
+                        "content": {
+                            "text/event-stream": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "403": {
+                        "content": {
+                            "text/event-stream": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Insufficient claims"
+                    },
+                    "404": {
+                        "content": {
+                            "text/event-stream": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Registry not found"
+                    },
+                    "410": {
+                        "content": {
+                            "text/event-stream": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Changes after the token are no longer retained"
+                    },
+                    "500": {
+                        "content": {
+                            "text/event-stream": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Stream registry changes",
+                "tags": [
+                    "changes"
+                ]
+            }
+        },
+        "/registry/{registryName}/v0.1/x/dev.toolhive/plugins": {
+            "get": {
+                "description": "List plugins in a registry (paginated, latest versions).",
+                "parameters": [
+                    {
+                        "description": "Registry name",
+                        "in": "path",
+                        "name": "registryName",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Full-text search on name, title and description, ordered by relevance",
+                        "in": "query",
+                        "name": "search",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Filter by status (comma-separated, e.g. active,deprecated)",
+                        "in": "query",
+                        "name": "status",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Max results (default 50, max 100)",
+                        "in": "query",
+                        "name": "limit",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Pagination cursor",
+                        "in": "query",
+                        "name": "cursor",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/internal_api_x_plugins.PluginListResponse"
+                                }
+                            }
+                        },
+                        "description": "List of plugins"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "List plugins in registry",
+                "tags": [
+                    "plugins"
+                ]
+            }
+        },
+        "/registry/{registryName}/v0.1/x/dev.toolhive/plugins/{namespace}/{name}": {
+            "get": {
+                "description": "Get the latest version of a plugin by namespace and name.",
+                "parameters": [
+                    {
+                        "description": "Registry name",
+                        "in": "path",
+                        "name": "registryName",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Plugin namespace (reverse-DNS)",
+                        "in": "path",
+                        "name": "namespace",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Plugin name",
+                        "in": "path",
+                        "name": "name",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/registry.Plugin"
+                                }
+                            }
+                        },
+                        "description": "Plugin details"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Plugin not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Get latest plugin version",
+                "tags": [
+                    "plugins"
+                ]
+            }
+        },
+        "/registry/{registryName}/v0.1/x/dev.toolhive/plugins/{namespace}/{name}/versions": {
+            "get": {
+                "description": "List all versions of a plugin.",
+                "parameters": [
+                    {
+                        "description": "Registry name",
+                        "in": "path",
+                        "name": "registryName",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Plugin namespace (reverse-DNS)",
+                        "in": "path",
+                        "name": "namespace",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Plugin name",
+                        "in": "path",
+                        "name": "name",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/internal_api_x_plugins.PluginListResponse"
+                                }
+                            }
+                        },
+                        "description": "List of plugin versions"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Plugin not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "List plugin versions",
+                "tags": [
+                    "plugins"
+                ]
+            }
+        },
+        "/registry/{registryName}/v0.1/x/dev.toolhive/plugins/{namespace}/{name}/versions/{version}": {
+            "get": {
+                "description": "Get a specific version of a plugin.",
+                "parameters": [
+                    {
+                        "description": "Registry name",
+                        "in": "path",
+                        "name": "registryName",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Plugin namespace (reverse-DNS)",
+                        "in": "path",
+                        "name": "namespace",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Plugin name",
+                        "in": "path",
+                        "name": "name",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Plugin version",
+                        "in": "path",
+                        "name": "version",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/registry.Plugin"
+                                }
+                            }
+                        },
+                        "description": "Plugin details"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Plugin or version not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Get specific plugin version",
+                "tags": [
+                    "plugins"
+                ]
+            }
+        },
+        "/registry/{registryName}/v0.1/x/dev.toolhive/search": {
+            "get": {
+                "description": "Full-text search over the servers, skills and plugins of a registry. Results are the\nlatest versions of the matching entries, ordered by relevance across all entry types.",
+                "parameters": [
+                    {
+                        "description": "Registry name",
+                        "in": "path",
+                        "name": "registryName",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Search terms, matched against names, titles, descriptions and server tool names",
+                        "in": "query",
+                        "name": "search",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Max results (default 50, max 100)",
+                        "in": "query",
+                        "name": "limit",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Pagination cursor",
+                        "in": "query",
+                        "name": "cursor",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/internal_api_x_search.Response"
+                                }
+                            }
+                        },
+                        "description": "Matching entries"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Insufficient claims"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Registry not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Search registry entries",
+                "tags": [
+                    "search"
+                ]
+            }
+        },
+        "/registry/{registryName}/v0.1/x/dev.toolhive/skills": {
+            "get": {
+                "description": "List skills in a registry (paginated, latest versions).",
+                "parameters": [
+                    {
+                        "description": "Registry name",
+                        "in": "path",
+                        "name": "registryName",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Full-text search on name, title and description, ordered by relevance",
+                        "in": "query",
+                        "name": "search",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Filter by status (comma-separated, e.g. active,deprecated)",
+                        "in": "query",
+                        "name": "status",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Max results (default 50, max 100)",
+                        "in": "query",
+                        "name": "limit",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Pagination cursor",
+                        "in": "query",
+                        "name": "cursor",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/internal_api_x_skills.SkillListResponse"
+                                }
+                            }
+                        },
+                        "description": "List of skills"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "List skills in registry",
+                "tags": [
+                    "skills"
+                ]
+            }
+        },
+        "/registry/{registryName}/v0.1/x/dev.toolhive/skills/{namespace}/{name}": {
+            "get": {
+                "description": "Get the latest version of a skill by namespace and name.",
+                "parameters": [
+                    {
+                        "description": "Registry name",
+                        "in": "path",
+                        "name": "registryName",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Skill namespace (reverse-DNS)",
+                        "in": "path",
+                        "name": "namespace",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Skill name",
+                        "in": "path",
+                        "name": "name",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/registry.Skill"
+                                }
+                            }
+                        },
+                        "description": "Skill details"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Skill not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Get latest skill version",
+                "tags": [
+                    "skills"
+                ]
+            }
+        },
+        "/registry/{registryName}/v0.1/x/dev.toolhive/skills/{namespace}/{name}/versions": {
+            "get": {
+                "description": "List all versions of a skill.",
+                "parameters": [
+                    {
+                        "description": "Registry name",
+                        "in": "path",
+                        "name": "registryName",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Skill namespace (reverse-DNS)",
+                        "in": "path",
+                        "name": "namespace",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Skill name",
+                        "in": "path",
+                        "name": "name",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/internal_api_x_skills.SkillListResponse"
+                                }
+                            }
+                        },
+                        "description": "List of skill versions"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Skill not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "List skill versions",
+                "tags": [
+                    "skills"
+                ]
+            }
+        },
+        "/registry/{registryName}/v0.1/x/dev.toolhive/skills/{namespace}/{name}/versions/{version}": {
+            "get": {
+                "description": "Get a specific version of a skill.",
+                "parameters": [
+                    {
+                        "description": "Registry name",
+                        "in": "path",
+                        "name": "registryName",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Skill namespace (reverse-DNS)",
+                        "in": "path",
+                        "name": "namespace",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Skill name",
+                        "in": "path",
+                        "name": "name",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Skill version",
+                        "in": "path",
+                        "name": "version",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/registry.Skill"
+                                }
+                            }
+                        },
+                        "description": "Skill details"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Skill or version not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Get specific skill version",
+                "tags": [
+                    "skills"
+                ]
+            }
+        },
+        "/v1/entries": {
+            "post": {
+                "description": "Publish a new server, skill, or plugin entry. Exactly one of 'server', 'skill', or 'plugin' must be provided.\nAn optional signature over the entry is verified against the keys trusted for its namespace.",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/internal_api_v1.publishEntryRequest",
+                                        "summary": "request",
+                                        "description": "Entry to publish (server, skill, or plugin)"
+                                    }
+                                ]
+                            }
+                        }
+                    },
+                    "description": "Entry to publish (server, skill, or plugin)",
+                    "required": true
+                },
+                "responses": {
+                    "201": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Published entry (server or skill)"
+                    },
+                    "202": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.EntrySubmission"
+                                }
+                            }
+                        },
+                        "description": "Submission held for review, when the managed source requires approval"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
+                    },
+                    "409": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Conflict"
+                    },
+                    "422": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Entry blocked by the entry policy"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Publish entry",
+                "tags": [
+                    "v1"
+                ]
+            }
+        },
+        "/v1/entries/{type}/{name}/claims": {
+            "get": {
+                "description": "Get the claims for an API-published entry name within the managed source.\nClaims are stored at the entry-name level and are shared by every version of that name.\nSynced-source entries (git/api/file/kubernetes) are out of scope: their claims come from\nupstream (the source manifest or the ` + "`" + `toolhive.stacklok.dev/authz-claims` + "`" + ` annotation) and\nare surfaced through the ` + "`" + `/v1/sources/{name}/entries` + "`" + ` and ` + "`" + `/v1/registries/{name}/entries` + "`" + ` lists.",
+                "parameters": [
+                    {
+                        "description": "Entry Type (server or skill)",
+                        "in": "path",
+                        "name": "type",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Entry Name",
+                        "in": "path",
+                        "name": "name",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/internal_api_v1.entryClaimsResponse"
+                                }
+                            }
+                        },
+                        "description": "Entry claims"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Get entry claims",
+                "tags": [
+                    "v1"
+                ]
+            },
+            "put": {
+                "description": "Update claims for a published entry name",
+                "parameters": [
+                    {
+                        "description": "Entry Type (server or skill)",
+                        "in": "path",
+                        "name": "type",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Entry Name",
+                        "in": "path",
+                        "name": "name",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/internal_api_v1.updateEntryClaimsRequest",
+                                        "summary": "request",
+                                        "description": "Claims to set"
+                                    }
+                                ]
+                            }
+                        }
+                    },
+                    "description": "Claims to set",
+                    "required": true
+                },
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Update entry claims",
+                "tags": [
+                    "v1"
+                ]
+            }
+        },
+        "/v1/entries/{type}/{name}/versions/{version}": {
+            "delete": {
+                "description": "Delete a published entry version",
+                "parameters": [
+                    {
+                        "description": "Entry Type (server, skill, or plugin)",
+                        "in": "path",
+                        "name": "type",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Entry Name",
+                        "in": "path",
+                        "name": "name",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Version",
+                        "in": "path",
+                        "name": "version",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Delete published entry",
+                "tags": [
+                    "v1"
+                ]
+            }
+        },
+        "/v1/entries/{type}/{name}/versions/{version}/status": {
+            "put": {
+                "description": "Deprecate or yank a published server version, or make it active again. Deprecated and\nyanked versions stay resolvable by their exact version, but are no longer listed nor\nresolved as the latest version. Only server entries are supported.",
+                "parameters": [
+                    {
+                        "description": "Entry Type (server)",
+                        "in": "path",
+                        "name": "type",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Entry Name",
+                        "in": "path",
+                        "name": "name",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Version",
+                        "in": "path",
+                        "name": "version",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/internal_api_v1.updateEntryVersionStatusRequest",
+                                        "summary": "request",
+                                        "description": "Status to set"
+                                    }
+                                ]
+                            }
+                        }
+                    },
+                    "description": "Status to set",
+                    "required": true
+                },
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Update entry version status",
+                "tags": [
+                    "v1"
+                ]
+            }
+        },
+        "/v1/entries:bulk": {
+            "post": {
+                "description": "Publish the servers, skills and plugins of an upstream registry document into the managed source.\nThe claims of each entry are read from its _meta under io.github.stacklok/claims.\nEntries are published one by one, the failures of some not preventing the others, unless atomic is\nset: then either all entries are published or, when one fails, none.\nBulk publishes carry no signatures: the entries of namespaces requiring them fail.\nThe document is limited to 32 MiB and 1000 entry versions.",
+                "parameters": [
+                    {
+                        "description": "Publish all entries or none",
+                        "in": "query",
+                        "name": "atomic",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/registry.UpstreamRegistry",
+                                        "summary": "request",
+                                        "description": "Upstream registry document"
+                                    }
+                                ]
+                            }
+                        }
+                    },
+                    "description": "Upstream registry document",
+                    "required": true
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.BulkPublishResult"
+                                }
+                            }
+                        },
+                        "description": "Outcome of each entry"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
+                    },
+                    "413": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Document too large"
+                    },
+                    "422": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.BulkPublishResult"
+                                }
+                            }
+                        },
+                        "description": "Atomic bulk publish rolled back"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Bulk publish entries",
+                "tags": [
+                    "v1"
+                ]
+            }
+        },
+        "/v1/me": {
+            "get": {
+                "description": "Returns the authenticated caller's identity and roles",
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/internal_api_v1.meResponse"
+                                }
+                            }
+                        },
+                        "description": "Caller identity and roles"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Unauthorized"
+                    }
+                },
+                "summary": "Get current user info",
+                "tags": [
+                    "v1"
+                ]
+            }
+        },
+        "/v1/namespaces": {
+            "get": {
+                "description": "List the namespaces reserved for their owners",
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/internal_api_v1.namespaceListResponse"
+                                }
+                            }
+                        },
+                        "description": "Namespaces list"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "List namespaces",
+                "tags": [
+                    "v1"
+                ]
+            }
+        },
+        "/v1/namespaces/{name}": {
+            "delete": {
+                "description": "Release a reserved namespace. Its entries are kept.",
+                "parameters": [
+                    {
+                        "description": "Namespace (reverse-DNS)",
+                        "in": "path",
+                        "name": "name",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Namespace released"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Namespace not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Release namespace",
+                "tags": [
+                    "v1"
+                ]
+            },
+            "get": {
+                "description": "Get a reserved namespace by name",
+                "parameters": [
+                    {
+                        "description": "Namespace (reverse-DNS)",
+                        "in": "path",
+                        "name": "name",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.NamespaceInfo"
+                                }
+                            }
+                        },
+                        "description": "Namespace details"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Namespace not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Get namespace",
+                "tags": [
+                    "v1"
+                ]
+            },
+            "put": {
+                "description": "Reserve a namespace for the callers covering its claims, or update the claims of a reserved one.\nWithout claims, only super-admins may publish into the namespace.",
+                "parameters": [
+                    {
+                        "description": "Namespace (reverse-DNS)",
+                        "in": "path",
+                        "name": "name",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.NamespaceRequest",
+                                        "summary": "request",
+                                        "description": "Namespace owners"
+                                    }
+                                ]
+                            }
+                        }
+                    },
+                    "description": "Namespace owners",
+                    "required": true
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.NamespaceInfo"
+                                }
+                            }
+                        },
+                        "description": "Namespace updated"
+                    },
+                    "201": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.NamespaceInfo"
+                                }
+                            }
+                        },
+                        "description": "Namespace reserved"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Reserve or update namespace",
+                "tags": [
+                    "v1"
+                ]
+            }
+        },
+        "/v1/notifications/deliveries": {
+            "get": {
+                "description": "List the deliveries of events to the outbound webhooks, newest first (paginated).\nDeliveries older than the configured delivery retention are pruned.",
+                "parameters": [
+                    {
+                        "description": "Webhook name",
+                        "in": "query",
+                        "name": "webhook",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Delivery status (pending, delivered or failed)",
+                        "in": "query",
+                        "name": "status",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Max results (default 30, max 1000)",
+                        "in": "query",
+                        "name": "limit",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Pagination cursor",
+                        "in": "query",
+                        "name": "cursor",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.WebhookDeliveryListResponse"
+                                }
+                            }
+                        },
+                        "description": "Webhook deliveries"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "List webhook deliveries",
+                "tags": [
+                    "v1"
+                ]
+            }
+        },
+        "/v1/registries": {
+            "get": {
+                "description": "List all registries",
+                "responses": {
+                    "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "additionalProperties": {
-                                        "type": "string"
-                                    },
-                                    "type": "object"
+                                    "$ref": "#/components/schemas/internal_api_v1.registryListResponse"
                                 }
                             }
                         },
-                        "description": "Unauthorized"
+                        "description": "Registries list"
                     },
-                    "404": {
+                    "500": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -1637,37 +4984,23 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Registry not found"
+                        "description": "Internal server error"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "List servers in specific registry",
+                "summary": "List registries",
                 "tags": [
-                    "registry"
+                    "v1"
                 ]
             }
         },
-        "/registry/{registryName}/v0.1/servers/{serverName}/versions": {
-            "get": {
-                "description": "Returns all available versions for a specific MCP server from a specific registry",
+        "/v1/registries/{name}": {
+            "delete": {
+                "description": "Delete a registry by name",
                 "parameters": [
                     {
-                        "description": "Registry name",
-                        "in": "path",
-                        "name": "registryName",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "URL-encoded server name (e.g., \\",
+                        "description": "Registry Name",
                         "in": "path",
-                        "name": "serverName",
+                        "name": "name",
                         "required": true,
                         "schema": {
                             "type": "string"
@@ -1675,17 +5008,23 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "200": {
+                    "204": {
+                        "description": "Registry deleted"
+                    },
+                    "400": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/v0.ServerListResponse"
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
                                 }
                             }
                         },
-                        "description": "A list of all versions for the server"
+                        "description": "Bad request"
                     },
-                    "400": {
+                    "403": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -1696,9 +5035,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Bad request"
+                        "description": "Cannot modify config-created registry"
                     },
-                    "401": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -1709,9 +5048,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Unauthorized"
+                        "description": "Registry not found"
                     },
-                    "404": {
+                    "500": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -1722,46 +5061,21 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Server not found"
+                        "description": "Internal server error"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "List all versions of an MCP server in specific registry",
+                "summary": "Delete registry",
                 "tags": [
-                    "registry"
+                    "v1"
                 ]
-            }
-        },
-        "/registry/{registryName}/v0.1/servers/{serverName}/versions/{version}": {
+            },
             "get": {
-                "description": "Returns detailed information about a specific version of an MCP server from a specific registry.\nUse the special version ` + "`" + `latest` + "`" + ` to get the latest version.",
+                "description": "Get a registry by name",
                 "parameters": [
                     {
-                        "description": "Registry name",
-                        "in": "path",
-                        "name": "registryName",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "URL-encoded server name (e.g., \\",
-                        "in": "path",
-                        "name": "serverName",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "URL-encoded version to retrieve (e.g., \\",
+                        "description": "Registry Name",
                         "in": "path",
-                        "name": "version",
+                        "name": "name",
                         "required": true,
                         "schema": {
                             "type": "string"
@@ -1773,11 +5087,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/v0.ServerResponse"
+                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.RegistryInfo"
                                 }
                             }
                         },
-                        "description": "Detailed server information"
+                        "description": "Registry details"
                     },
                     "400": {
                         "content": {
@@ -1792,7 +5106,7 @@ const docTemplate = `{
                         },
                         "description": "Bad request"
                     },
-                    "401": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -1803,9 +5117,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Unauthorized"
+                        "description": "Registry not found"
                     },
-                    "404": {
+                    "500": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -1816,76 +5130,67 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Server or version not found"
+                        "description": "Internal server error"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Get specific MCP server version in specific registry",
+                "summary": "Get registry",
                 "tags": [
-                    "registry"
+                    "v1"
                 ]
-            }
-        },
-        "/registry/{registryName}/v0.1/x/dev.toolhive/plugins": {
-            "get": {
-                "description": "List plugins in a registry (paginated, latest versions).",
+            },
+            "put": {
+                "description": "Create a new registry or update an existing one",
                 "parameters": [
                     {
-                        "description": "Registry name",
+                        "description": "Registry Name",
                         "in": "path",
-                        "name": "registryName",
+                        "name": "name",
                         "required": true,
                         "schema": {
                             "type": "string"
                         }
-                    },
-                    {
-                        "description": "Filter by name/description substring",
-                        "in": "query",
-                        "name": "search",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Filter by status (comma-separated, e.g. active,deprecated)",
-                        "in": "query",
-                        "name": "status",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Max results (default 50, max 100)",
-                        "in": "query",
-                        "name": "limit",
-                        "schema": {
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "Pagination cursor",
-                        "in": "query",
-                        "name": "cursor",
-                        "schema": {
-                            "type": "string"
-                        }
                     }
                 ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.RegistryCreateRequest",
+                                        "summary": "request",
+                                        "description": "Registry configuration"
+                                    }
+                                ]
+                            }
+                        }
+                    },
+                    "description": "Registry configuration",
+                    "required": true
+                },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/internal_api_x_plugins.PluginListResponse"
+                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.RegistryInfo"
                                 }
                             }
                         },
-                        "description": "List of plugins"
+                        "description": "Registry updated"
+                    },
+                    "201": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.RegistryInfo"
+                                }
+                            }
+                        },
+                        "description": "Registry created"
                     },
                     "400": {
                         "content": {
@@ -1900,6 +5205,19 @@ const docTemplate = `{
                         },
                         "description": "Bad request"
                     },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Cannot modify config-created registry"
+                    },
                     "500": {
                         "content": {
                             "application/json": {
@@ -1914,44 +5232,29 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "List plugins in registry",
+                "summary": "Create or update registry",
                 "tags": [
-                    "plugins"
+                    "v1"
                 ]
             }
         },
-        "/registry/{registryName}/v0.1/x/dev.toolhive/plugins/{namespace}/{name}": {
+        "/v1/registries/{name}/bundle": {
             "get": {
-                "description": "Get the latest version of a plugin by namespace and name.",
+                "description": "Export the server, skill and plugin versions a registry serves, with the claims of their entries,\nas a bundle file signed with the replication signing key, to be imported by a bundle source.\nThe ID of the bundle is a change token of the registry: with since set to the ID of a previous\nbundle, a delta bundle is exported holding the versions changed after it and the versions no\nlonger served.",
                 "parameters": [
                     {
-                        "description": "Registry name",
-                        "in": "path",
-                        "name": "registryName",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Plugin namespace (reverse-DNS)",
+                        "description": "Registry Name",
                         "in": "path",
-                        "name": "namespace",
+                        "name": "name",
                         "required": true,
                         "schema": {
                             "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Plugin name",
-                        "in": "path",
-                        "name": "name",
-                        "required": true,
+                        }
+                    },
+                    {
+                        "description": "ID of the bundle to export a delta bundle since",
+                        "in": "query",
+                        "name": "since",
                         "schema": {
                             "type": "string"
                         }
@@ -1962,11 +5265,12 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/registry.Plugin"
+                                    "additionalProperties": {},
+                                    "type": "object"
                                 }
                             }
                         },
-                        "description": "Plugin details"
+                        "description": "Signed bundle file"
                     },
                     "400": {
                         "content": {
@@ -1981,6 +5285,19 @@ const docTemplate = `{
                         },
                         "description": "Bad request"
                     },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Insufficient claims"
+                    },
                     "404": {
                         "content": {
                             "application/json": {
@@ -1992,7 +5309,20 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Plugin not found"
+                        "description": "Registry not found"
+                    },
+                    "410": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Changes after since are no longer retained"
                     },
                     "500": {
                         "content": {
@@ -2006,43 +5336,33 @@ const docTemplate = `{
                             }
                         },
                         "description": "Internal server error"
+                    },
+                    "501": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Replication is not configured"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Get latest plugin version",
+                "summary": "Export registry bundle",
                 "tags": [
-                    "plugins"
+                    "v1"
                 ]
             }
         },
-        "/registry/{registryName}/v0.1/x/dev.toolhive/plugins/{namespace}/{name}/versions": {
+        "/v1/registries/{name}/entries": {
             "get": {
-                "description": "List all versions of a plugin.",
+                "description": "List all entries for a registry",
                 "parameters": [
                     {
-                        "description": "Registry name",
-                        "in": "path",
-                        "name": "registryName",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Plugin namespace (reverse-DNS)",
-                        "in": "path",
-                        "name": "namespace",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Plugin name",
+                        "description": "Registry Name",
                         "in": "path",
                         "name": "name",
                         "required": true,
@@ -2056,11 +5376,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/internal_api_x_plugins.PluginListResponse"
+                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.RegistryEntriesResponse"
                                 }
                             }
                         },
-                        "description": "List of plugin versions"
+                        "description": "Registry entries"
                     },
                     "400": {
                         "content": {
@@ -2086,7 +5406,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Plugin not found"
+                        "description": "Registry not found"
                     },
                     "500": {
                         "content": {
@@ -2102,56 +5422,24 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "List plugin versions",
+                "summary": "List registry entries",
                 "tags": [
-                    "plugins"
+                    "v1"
                 ]
             }
         },
-        "/registry/{registryName}/v0.1/x/dev.toolhive/plugins/{namespace}/{name}/versions/{version}": {
+        "/v1/registries/{name}/overlays": {
             "get": {
-                "description": "Get a specific version of a plugin.",
+                "description": "List the overlays attached to servers in a registry",
                 "parameters": [
                     {
-                        "description": "Registry name",
-                        "in": "path",
-                        "name": "registryName",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Plugin namespace (reverse-DNS)",
-                        "in": "path",
-                        "name": "namespace",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Plugin name",
+                        "description": "Registry Name",
                         "in": "path",
                         "name": "name",
                         "required": true,
                         "schema": {
                             "type": "string"
                         }
-                    },
-                    {
-                        "description": "Plugin version",
-                        "in": "path",
-                        "name": "version",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
                     }
                 ],
                 "responses": {
@@ -2159,11 +5447,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/registry.Plugin"
+                                    "$ref": "#/components/schemas/internal_api_v1.overlayListResponse"
                                 }
                             }
                         },
-                        "description": "Plugin details"
+                        "description": "Overlays list"
                     },
                     "400": {
                         "content": {
@@ -2178,6 +5466,19 @@ const docTemplate = `{
                         },
                         "description": "Bad request"
                     },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
+                    },
                     "404": {
                         "content": {
                             "application/json": {
@@ -2189,7 +5490,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Plugin or version not found"
+                        "description": "Registry not found"
                     },
                     "500": {
                         "content": {
@@ -2205,75 +5506,53 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Get specific plugin version",
+                "summary": "List server overlays",
                 "tags": [
-                    "plugins"
+                    "v1"
                 ]
             }
         },
-        "/registry/{registryName}/v0.1/x/dev.toolhive/skills": {
-            "get": {
-                "description": "List skills in a registry (paginated, latest versions).",
+        "/v1/registries/{name}/overlays/{serverName}": {
+            "delete": {
+                "description": "Remove the overlay attached to a server in a registry",
                 "parameters": [
                     {
-                        "description": "Registry name",
+                        "description": "Registry Name",
                         "in": "path",
-                        "name": "registryName",
+                        "name": "name",
                         "required": true,
                         "schema": {
                             "type": "string"
                         }
                     },
                     {
-                        "description": "Filter by name/description substring",
-                        "in": "query",
-                        "name": "search",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Filter by status (comma-separated, e.g. active,deprecated)",
-                        "in": "query",
-                        "name": "status",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Max results (default 50, max 100)",
-                        "in": "query",
-                        "name": "limit",
-                        "schema": {
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "Pagination cursor",
-                        "in": "query",
-                        "name": "cursor",
+                        "description": "URL-encoded server name (e.g., \\",
+                        "in": "path",
+                        "name": "serverName",
+                        "required": true,
                         "schema": {
                             "type": "string"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
+                    "204": {
+                        "description": "Overlay deleted"
+                    },
+                    "400": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/internal_api_x_skills.SkillListResponse"
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
                                 }
                             }
                         },
-                        "description": "List of skills"
+                        "description": "Bad request"
                     },
-                    "400": {
+                    "403": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -2284,7 +5563,20 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Bad request"
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Registry or overlay not found"
                     },
                     "500": {
                         "content": {
@@ -2300,43 +5592,27 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "List skills in registry",
+                "summary": "Delete server overlay",
                 "tags": [
-                    "skills"
+                    "v1"
                 ]
-            }
-        },
-        "/registry/{registryName}/v0.1/x/dev.toolhive/skills/{namespace}/{name}": {
+            },
             "get": {
-                "description": "Get the latest version of a skill by namespace and name.",
+                "description": "Get the overlay attached to a server in a registry",
                 "parameters": [
                     {
-                        "description": "Registry name",
-                        "in": "path",
-                        "name": "registryName",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Skill namespace (reverse-DNS)",
+                        "description": "Registry Name",
                         "in": "path",
-                        "name": "namespace",
+                        "name": "name",
                         "required": true,
                         "schema": {
                             "type": "string"
                         }
                     },
                     {
-                        "description": "Skill name",
+                        "description": "URL-encoded server name (e.g., \\",
                         "in": "path",
-                        "name": "name",
+                        "name": "serverName",
                         "required": true,
                         "schema": {
                             "type": "string"
@@ -2348,11 +5624,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/registry.Skill"
+                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.ServerOverlayInfo"
                                 }
                             }
                         },
-                        "description": "Skill details"
+                        "description": "Overlay details"
                     },
                     "400": {
                         "content": {
@@ -2365,7 +5641,20 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Bad request"
+                        "description": "Bad request"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
                     },
                     "404": {
                         "content": {
@@ -2378,7 +5667,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Skill not found"
+                        "description": "Registry or overlay not found"
                     },
                     "500": {
                         "content": {
@@ -2394,59 +5683,73 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Get latest skill version",
+                "summary": "Get server overlay",
                 "tags": [
-                    "skills"
+                    "v1"
                 ]
-            }
-        },
-        "/registry/{registryName}/v0.1/x/dev.toolhive/skills/{namespace}/{name}/versions": {
-            "get": {
-                "description": "List all versions of a skill.",
+            },
+            "put": {
+                "description": "Attach an overlay to a server in a registry, or replace the existing one.\nThe overlay applies to every version of the server the registry serves and is kept across syncs.",
                 "parameters": [
                     {
-                        "description": "Registry name",
-                        "in": "path",
-                        "name": "registryName",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Skill namespace (reverse-DNS)",
+                        "description": "Registry Name",
                         "in": "path",
-                        "name": "namespace",
+                        "name": "name",
                         "required": true,
                         "schema": {
                             "type": "string"
                         }
                     },
                     {
-                        "description": "Skill name",
+                        "description": "URL-encoded server name (e.g., \\",
                         "in": "path",
-                        "name": "name",
+                        "name": "serverName",
                         "required": true,
                         "schema": {
                             "type": "string"
                         }
                     }
                 ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.ServerOverlayRequest",
+                                        "summary": "request",
+                                        "description": "Server overlay"
+                                    }
+                                ]
+                            }
+                        }
+                    },
+                    "description": "Server overlay",
+                    "required": true
+                },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/internal_api_x_skills.SkillListResponse"
+                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.ServerOverlayInfo"
                                 }
                             }
                         },
-                        "description": "List of skill versions"
+                        "description": "Overlay replaced"
+                    },
+                    "201": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.ServerOverlayInfo"
+                                }
+                            }
+                        },
+                        "description": "Overlay created"
                     },
                     "400": {
                         "content": {
@@ -2461,6 +5764,19 @@ const docTemplate = `{
                         },
                         "description": "Bad request"
                     },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
+                    },
                     "404": {
                         "content": {
                             "application/json": {
@@ -2472,7 +5788,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Skill not found"
+                        "description": "Registry not found"
                     },
                     "500": {
                         "content": {
@@ -2488,70 +5804,27 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "List skill versions",
+                "summary": "Create or replace server overlay",
                 "tags": [
-                    "skills"
+                    "v1"
                 ]
             }
         },
-        "/registry/{registryName}/v0.1/x/dev.toolhive/skills/{namespace}/{name}/versions/{version}": {
+        "/v1/sources": {
             "get": {
-                "description": "Get a specific version of a skill.",
-                "parameters": [
-                    {
-                        "description": "Registry name",
-                        "in": "path",
-                        "name": "registryName",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Skill namespace (reverse-DNS)",
-                        "in": "path",
-                        "name": "namespace",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Skill name",
-                        "in": "path",
-                        "name": "name",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Skill version",
-                        "in": "path",
-                        "name": "version",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                ],
+                "description": "List all sources",
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/registry.Skill"
+                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.SourceListResponse"
                                 }
                             }
                         },
-                        "description": "Skill details"
+                        "description": "Sources list"
                     },
-                    "400": {
+                    "500": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -2562,9 +5835,34 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Bad request"
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "List sources",
+                "tags": [
+                    "v1"
+                ]
+            }
+        },
+        "/v1/sources/{name}": {
+            "delete": {
+                "description": "Delete a source by name",
+                "parameters": [
+                    {
+                        "description": "Source Name",
+                        "in": "path",
+                        "name": "name",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Source deleted"
                     },
-                    "404": {
+                    "400": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -2575,9 +5873,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Skill or version not found"
+                        "description": "Bad request"
                     },
-                    "500": {
+                    "403": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -2588,55 +5886,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
-                    }
-                },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Get specific skill version",
-                "tags": [
-                    "skills"
-                ]
-            }
-        },
-        "/v1/entries": {
-            "post": {
-                "description": "Publish a new server, skill, or plugin entry. Exactly one of 'server', 'skill', or 'plugin' must be provided.",
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "oneOf": [
-                                    {
-                                        "type": "object"
-                                    },
-                                    {
-                                        "$ref": "#/components/schemas/internal_api_v1.publishEntryRequest",
-                                        "summary": "request",
-                                        "description": "Entry to publish (server, skill, or plugin)"
-                                    }
-                                ]
-                            }
-                        }
-                    },
-                    "description": "Entry to publish (server, skill, or plugin)",
-                    "required": true
-                },
-                "responses": {
-                    "201": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "type": "object"
-                                }
-                            }
-                        },
-                        "description": "Published entry (server or skill)"
+                        "description": "Cannot modify config-created source"
                     },
-                    "400": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -2647,7 +5899,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Bad request"
+                        "description": "Source not found"
                     },
                     "409": {
                         "content": {
@@ -2660,7 +5912,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Conflict"
+                        "description": "Source in use"
                     },
                     "500": {
                         "content": {
@@ -2676,27 +5928,16 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Publish entry",
+                "summary": "Delete source",
                 "tags": [
                     "v1"
                 ]
-            }
-        },
-        "/v1/entries/{type}/{name}/claims": {
+            },
             "get": {
-                "description": "Get the claims for an API-published entry name within the managed source.\nClaims are stored at the entry-name level and are shared by every version of that name.\nSynced-source entries (git/api/file/kubernetes) are out of scope: their claims come from\nupstream (the source manifest or the ` + "`" + `toolhive.stacklok.dev/authz-claims` + "`" + ` annotation) and\nare surfaced through the ` + "`" + `/v1/sources/{name}/entries` + "`" + ` and ` + "`" + `/v1/registries/{name}/entries` + "`" + ` lists.",
+                "description": "Get a source by name",
                 "parameters": [
                     {
-                        "description": "Entry Type (server or skill)",
-                        "in": "path",
-                        "name": "type",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Entry Name",
+                        "description": "Source Name",
                         "in": "path",
                         "name": "name",
                         "required": true,
@@ -2710,11 +5951,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/internal_api_v1.entryClaimsResponse"
+                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.SourceInfo"
                                 }
                             }
                         },
-                        "description": "Entry claims"
+                        "description": "Source details"
                     },
                     "400": {
                         "content": {
@@ -2729,19 +5970,6 @@ const docTemplate = `{
                         },
                         "description": "Bad request"
                     },
-                    "403": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "additionalProperties": {
-                                        "type": "string"
-                                    },
-                                    "type": "object"
-                                }
-                            }
-                        },
-                        "description": "Forbidden"
-                    },
                     "404": {
                         "content": {
                             "application/json": {
@@ -2753,7 +5981,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Not found"
+                        "description": "Source not found"
                     },
                     "500": {
                         "content": {
@@ -2769,25 +5997,16 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Get entry claims",
+                "summary": "Get source",
                 "tags": [
                     "v1"
                 ]
             },
             "put": {
-                "description": "Update claims for a published entry name",
+                "description": "Create a new source or update an existing one",
                 "parameters": [
                     {
-                        "description": "Entry Type (server or skill)",
-                        "in": "path",
-                        "name": "type",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Entry Name",
+                        "description": "Source Name",
                         "in": "path",
                         "name": "name",
                         "required": true,
@@ -2805,20 +6024,37 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/internal_api_v1.updateEntryClaimsRequest",
+                                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.SourceCreateRequest",
                                         "summary": "request",
-                                        "description": "Claims to set"
+                                        "description": "Source configuration"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Claims to set",
+                    "description": "Source configuration",
                     "required": true
                 },
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.SourceInfo"
+                                }
+                            }
+                        },
+                        "description": "Source updated"
+                    },
+                    "201": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.SourceInfo"
+                                }
+                            }
+                        },
+                        "description": "Source created"
                     },
                     "400": {
                         "content": {
@@ -2844,9 +6080,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Forbidden"
+                        "description": "Cannot modify config-created source"
                     },
-                    "404": {
+                    "409": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -2857,7 +6093,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Not found"
+                        "description": "Managed source limit reached"
                     },
                     "500": {
                         "content": {
@@ -2873,75 +6109,38 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Update entry claims",
+                "summary": "Create or update source",
                 "tags": [
                     "v1"
                 ]
             }
         },
-        "/v1/entries/{type}/{name}/versions/{version}": {
-            "delete": {
-                "description": "Delete a published entry version",
+        "/v1/sources/{name}/entries": {
+            "get": {
+                "description": "List all entries for a source",
                 "parameters": [
                     {
-                        "description": "Entry Type (server, skill, or plugin)",
-                        "in": "path",
-                        "name": "type",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Entry Name",
+                        "description": "Source Name",
                         "in": "path",
                         "name": "name",
                         "required": true,
                         "schema": {
                             "type": "string"
                         }
-                    },
-                    {
-                        "description": "Version",
-                        "in": "path",
-                        "name": "version",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "additionalProperties": {
-                                        "type": "string"
-                                    },
-                                    "type": "object"
-                                }
-                            }
-                        },
-                        "description": "Bad request"
-                    },
-                    "404": {
+                    "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "additionalProperties": {
-                                        "type": "string"
-                                    },
-                                    "type": "object"
+                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.SourceEntriesResponse"
                                 }
                             }
                         },
-                        "description": "Not found"
+                        "description": "Source entries"
                     },
-                    "500": {
+                    "400": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -2952,30 +6151,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
-                    }
-                },
-                "summary": "Delete published entry",
-                "tags": [
-                    "v1"
-                ]
-            }
-        },
-        "/v1/me": {
-            "get": {
-                "description": "Returns the authenticated caller's identity and roles",
-                "responses": {
-                    "200": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/internal_api_v1.meResponse"
-                                }
-                            }
-                        },
-                        "description": "Caller identity and roles"
+                        "description": "Bad request"
                     },
-                    "401": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -2986,28 +6164,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Unauthorized"
-                    }
-                },
-                "summary": "Get current user info",
-                "tags": [
-                    "v1"
-                ]
-            }
-        },
-        "/v1/registries": {
-            "get": {
-                "description": "List all registries",
-                "responses": {
-                    "200": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/internal_api_v1.registryListResponse"
-                                }
-                            }
-                        },
-                        "description": "Registries list"
+                        "description": "Source not found"
                     },
                     "500": {
                         "content": {
@@ -3023,18 +6180,18 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "List registries",
+                "summary": "List source entries",
                 "tags": [
                     "v1"
                 ]
             }
         },
-        "/v1/registries/{name}": {
-            "delete": {
-                "description": "Delete a registry by name",
+        "/v1/sources/{name}/export": {
+            "get": {
+                "description": "Stream every entry version of a source as an upstream registry document. The claims of each entry\nare carried in its _meta under io.github.stacklok/claims, so that the document can be imported back\nwith POST /v1/entries:bulk.",
                 "parameters": [
                     {
-                        "description": "Registry Name",
+                        "description": "Source Name",
                         "in": "path",
                         "name": "name",
                         "required": true,
@@ -3044,23 +6201,17 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Registry deleted"
-                    },
-                    "400": {
+                    "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "additionalProperties": {
-                                        "type": "string"
-                                    },
-                                    "type": "object"
+                                    "$ref": "#/components/schemas/registry.UpstreamRegistry"
                                 }
                             }
                         },
-                        "description": "Bad request"
+                        "description": "Upstream registry document"
                     },
-                    "403": {
+                    "400": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -3071,7 +6222,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Cannot modify config-created registry"
+                        "description": "Bad request"
                     },
                     "404": {
                         "content": {
@@ -3084,7 +6235,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Registry not found"
+                        "description": "Source not found"
                     },
                     "500": {
                         "content": {
@@ -3100,16 +6251,18 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Delete registry",
+                "summary": "Export source",
                 "tags": [
                     "v1"
                 ]
-            },
-            "get": {
-                "description": "Get a registry by name",
+            }
+        },
+        "/v1/sources/{name}/sync": {
+            "post": {
+                "description": "Queue a manual sync for a source. The sync runs on the next coordinator\npoll regardless of the sync schedule. Poll the returned sync request by ID\nto see the outcome. A request that is still pending is returned as is.",
                 "parameters": [
                     {
-                        "description": "Registry Name",
+                        "description": "Source Name",
                         "in": "path",
                         "name": "name",
                         "required": true,
@@ -3119,15 +6272,15 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "200": {
+                    "202": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.RegistryInfo"
+                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.SyncRequestInfo"
                                 }
                             }
                         },
-                        "description": "Registry details"
+                        "description": "Sync requested"
                     },
                     "400": {
                         "content": {
@@ -3140,7 +6293,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Bad request"
+                        "description": "Bad request or source is not syncable"
                     },
                     "404": {
                         "content": {
@@ -3153,7 +6306,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Registry not found"
+                        "description": "Source not found"
                     },
                     "500": {
                         "content": {
@@ -3169,64 +6322,45 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Get registry",
+                "summary": "Request source sync",
                 "tags": [
                     "v1"
                 ]
-            },
-            "put": {
-                "description": "Create a new registry or update an existing one",
+            }
+        },
+        "/v1/sources/{name}/sync/{id}": {
+            "get": {
+                "description": "Get the status of a manual sync request for a source",
                 "parameters": [
                     {
-                        "description": "Registry Name",
+                        "description": "Source Name",
                         "in": "path",
                         "name": "name",
                         "required": true,
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    {
+                        "description": "Sync request ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 ],
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "oneOf": [
-                                    {
-                                        "type": "object"
-                                    },
-                                    {
-                                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.RegistryCreateRequest",
-                                        "summary": "request",
-                                        "description": "Registry configuration"
-                                    }
-                                ]
-                            }
-                        }
-                    },
-                    "description": "Registry configuration",
-                    "required": true
-                },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.RegistryInfo"
-                                }
-                            }
-                        },
-                        "description": "Registry updated"
-                    },
-                    "201": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.RegistryInfo"
+                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.SyncRequestInfo"
                                 }
                             }
                         },
-                        "description": "Registry created"
+                        "description": "Sync request"
                     },
                     "400": {
                         "content": {
@@ -3241,7 +6375,7 @@ const docTemplate = `{
                         },
                         "description": "Bad request"
                     },
-                    "403": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -3252,7 +6386,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Cannot modify config-created registry"
+                        "description": "Source or sync request not found"
                     },
                     "500": {
                         "content": {
@@ -3268,24 +6402,40 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Create or update registry",
+                "summary": "Get source sync request",
                 "tags": [
                     "v1"
                 ]
             }
         },
-        "/v1/registries/{name}/entries": {
+        "/v1/sources/{name}/syncs": {
             "get": {
-                "description": "List all entries for a registry",
+                "description": "List the recorded sync attempts of a source, newest first (paginated).\nAttempts older than the configured sync history retention are pruned.",
                 "parameters": [
                     {
-                        "description": "Registry Name",
+                        "description": "Source Name",
                         "in": "path",
                         "name": "name",
                         "required": true,
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    {
+                        "description": "Max results (default 30, max 1000)",
+                        "in": "query",
+                        "name": "limit",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Pagination cursor",
+                        "in": "query",
+                        "name": "cursor",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 ],
                 "responses": {
@@ -3293,11 +6443,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.RegistryEntriesResponse"
+                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.SourceSyncListResponse"
                                 }
                             }
                         },
-                        "description": "Registry entries"
+                        "description": "Sync history"
                     },
                     "400": {
                         "content": {
@@ -3323,7 +6473,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Registry not found"
+                        "description": "Source not found"
                     },
                     "500": {
                         "content": {
@@ -3339,25 +6489,85 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "List registry entries",
+                "summary": "List source sync history",
                 "tags": [
                     "v1"
                 ]
             }
         },
-        "/v1/sources": {
+        "/v1/submissions": {
             "get": {
-                "description": "List all sources",
+                "description": "List the publishes held for review by the managed source, oldest first (paginated).\nOnly pending submissions are listed unless another status is requested.",
+                "parameters": [
+                    {
+                        "description": "Submission status (pending, approved or rejected; default pending)",
+                        "in": "query",
+                        "name": "status",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Entry type (server, skill or plugin)",
+                        "in": "query",
+                        "name": "type",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Max results (default 30, max 1000)",
+                        "in": "query",
+                        "name": "limit",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Pagination cursor",
+                        "in": "query",
+                        "name": "cursor",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.SourceListResponse"
+                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.EntrySubmissionListResponse"
                                 }
                             }
                         },
-                        "description": "Sources list"
+                        "description": "Entry submissions"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
                     },
                     "500": {
                         "content": {
@@ -3373,57 +6583,57 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "List sources",
+                "summary": "List entry submissions",
                 "tags": [
                     "v1"
                 ]
             }
         },
-        "/v1/sources/{name}": {
-            "delete": {
-                "description": "Delete a source by name",
+        "/v1/submissions/{id}/approve": {
+            "post": {
+                "description": "Approve a pending submission, publishing its entry into the managed source",
                 "parameters": [
                     {
-                        "description": "Source Name",
+                        "description": "Submission ID",
                         "in": "path",
-                        "name": "name",
+                        "name": "id",
                         "required": true,
                         "schema": {
                             "type": "string"
                         }
                     }
                 ],
-                "responses": {
-                    "204": {
-                        "description": "Source deleted"
-                    },
-                    "400": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "additionalProperties": {
-                                        "type": "string"
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
                                     },
-                                    "type": "object"
-                                }
+                                    {
+                                        "$ref": "#/components/schemas/internal_api_v1.reviewSubmissionRequest",
+                                        "summary": "request",
+                                        "description": "Review comment"
+                                    }
+                                ]
                             }
-                        },
-                        "description": "Bad request"
+                        }
                     },
-                    "403": {
+                    "description": "Review comment"
+                },
+                "responses": {
+                    "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "additionalProperties": {
-                                        "type": "string"
-                                    },
-                                    "type": "object"
+                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.EntrySubmission"
                                 }
                             }
                         },
-                        "description": "Cannot modify config-created source"
+                        "description": "Approved submission"
                     },
-                    "404": {
+                    "400": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -3434,9 +6644,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Source not found"
+                        "description": "Bad request"
                     },
-                    "409": {
+                    "403": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -3447,9 +6657,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Source in use"
+                        "description": "Forbidden"
                     },
-                    "500": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -3460,39 +6670,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
-                    }
-                },
-                "summary": "Delete source",
-                "tags": [
-                    "v1"
-                ]
-            },
-            "get": {
-                "description": "Get a source by name",
-                "parameters": [
-                    {
-                        "description": "Source Name",
-                        "in": "path",
-                        "name": "name",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.SourceInfo"
-                                }
-                            }
-                        },
-                        "description": "Source details"
+                        "description": "Submission not found"
                     },
-                    "400": {
+                    "409": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -3503,9 +6683,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Bad request"
+                        "description": "Conflict"
                     },
-                    "404": {
+                    "422": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -3516,7 +6696,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Source not found"
+                        "description": "Entry blocked by the entry policy"
                     },
                     "500": {
                         "content": {
@@ -3532,18 +6712,20 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Get source",
+                "summary": "Approve entry submission",
                 "tags": [
                     "v1"
                 ]
-            },
-            "put": {
-                "description": "Create a new source or update an existing one",
+            }
+        },
+        "/v1/submissions/{id}/reject": {
+            "post": {
+                "description": "Reject a pending submission with a comment. Its entry is never published.",
                 "parameters": [
                     {
-                        "description": "Source Name",
+                        "description": "Submission ID",
                         "in": "path",
-                        "name": "name",
+                        "name": "id",
                         "required": true,
                         "schema": {
                             "type": "string"
@@ -3559,15 +6741,15 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.SourceCreateRequest",
+                                        "$ref": "#/components/schemas/internal_api_v1.reviewSubmissionRequest",
                                         "summary": "request",
-                                        "description": "Source configuration"
+                                        "description": "Review comment"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Source configuration",
+                    "description": "Review comment",
                     "required": true
                 },
                 "responses": {
@@ -3575,23 +6757,26 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.SourceInfo"
+                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.EntrySubmission"
                                 }
                             }
                         },
-                        "description": "Source updated"
+                        "description": "Rejected submission"
                     },
-                    "201": {
+                    "400": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.SourceInfo"
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
                                 }
                             }
                         },
-                        "description": "Source created"
+                        "description": "Bad request"
                     },
-                    "400": {
+                    "403": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -3602,9 +6787,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Bad request"
+                        "description": "Forbidden"
                     },
-                    "403": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -3615,7 +6800,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Cannot modify config-created source"
+                        "description": "Submission not found"
                     },
                     "409": {
                         "content": {
@@ -3628,7 +6813,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Managed source limit reached"
+                        "description": "Conflict"
                     },
                     "500": {
                         "content": {
@@ -3644,15 +6829,15 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Create or update source",
+                "summary": "Reject entry submission",
                 "tags": [
                     "v1"
                 ]
             }
         },
-        "/v1/sources/{name}/entries": {
-            "get": {
-                "description": "List all entries for a source",
+        "/webhooks/git/{name}": {
+            "post": {
+                "description": "Receives GitHub, GitLab and Gitea push events for a Git source with a configured webhook.\nGitHub and Gitea deliveries must carry an HMAC-SHA256 signature of the body, GitLab deliveries\nthe secret token. A push to the branch or tag the source syncs from queues an immediate sync;\nother events and references are acknowledged and ignored.",
                 "parameters": [
                     {
                         "description": "Source Name",
@@ -3662,18 +6847,61 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    {
+                        "description": "GitHub signature (sha256=\u003chex\u003e)",
+                        "in": "header",
+                        "name": "X-Hub-Signature-256",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Gitea signature (hex)",
+                        "in": "header",
+                        "name": "X-Gitea-Signature",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "GitLab secret token",
+                        "in": "header",
+                        "name": "X-Gitlab-Token",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "type": "object"
+                            }
+                        }
+                    }
+                },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.SourceEntriesResponse"
+                                    "$ref": "#/components/schemas/internal_api_webhooks.Response"
                                 }
                             }
                         },
-                        "description": "Source entries"
+                        "description": "Delivery ignored"
+                    },
+                    "202": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/internal_api_webhooks.Response"
+                                }
+                            }
+                        },
+                        "description": "Sync queued"
                     },
                     "400": {
                         "content": {
@@ -3688,6 +6916,19 @@ const docTemplate = `{
                         },
                         "description": "Bad request"
                     },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Missing or invalid signature"
+                    },
                     "404": {
                         "content": {
                             "application/json": {
@@ -3699,7 +6940,20 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Source not found"
+                        "description": "Source not found or webhook not enabled"
+                    },
+                    "413": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    },
+                                    "type": "object"
+                                }
+                            }
+                        },
+                        "description": "Payload too large"
                     },
                     "500": {
                         "content": {
@@ -3715,9 +6969,9 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "List source entries",
+                "summary": "Git push webhook",
                 "tags": [
-                    "v1"
+                    "webhooks"
                 ]
             }
         }
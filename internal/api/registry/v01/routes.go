@@ -13,7 +13,9 @@ import (
 	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 
 	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/api/x/changes"
 	"github.com/stacklok/toolhive-registry-server/internal/api/x/plugins"
+	xsearch "github.com/stacklok/toolhive-registry-server/internal/api/x/search"
 	"github.com/stacklok/toolhive-registry-server/internal/api/x/skills"
 	auditmw "github.com/stacklok/toolhive-registry-server/internal/audit"
 	"github.com/stacklok/toolhive-registry-server/internal/auth"
@@ -40,6 +42,9 @@ func Router(svc service.RegistryService) http.Handler {
 	r.Mount("/{registryName}/v0.1", registryRouter(routes))
 	r.Mount("/{registryName}/v0.1/x/dev.toolhive/skills", skills.Router(svc))
 	r.Mount("/{registryName}/v0.1/x/dev.toolhive/plugins", plugins.Router(svc))
+	r.Mount("/{registryName}/v0.1/x/dev.toolhive/search", xsearch.Router(svc))
+	r.Mount("/{registryName}/v0.1/x/dev.toolhive/changes", changes.Router(svc))
+	r.Mount("/{registryName}/v0.1/x/dev.toolhive/events", changes.EventsRouter(svc))
 
 	return r
 }
@@ -141,10 +146,7 @@ func (routes *Routes) handleListServers(w http.ResponseWriter, r *http.Request,
 
 	serverResponses := make([]upstreamv0.ServerResponse, len(listResult.Servers))
 	for i, server := range listResult.Servers {
-		serverResponses[i] = upstreamv0.ServerResponse{
-			Server: *server,
-			Meta:   upstreamv0.ResponseMeta{},
-		}
+		serverResponses[i] = *server
 	}
 
 	result := upstreamv0.ServerListResponse{
@@ -161,13 +163,13 @@ func (routes *Routes) handleListServers(w http.ResponseWriter, r *http.Request,
 // listServersWithRegistryName handles GET /{registryName}/v0.1/servers
 //
 // @Summary		List servers in specific registry
-// @Description	Get a list of available servers from a specific registry
+// @Description	Get a list of available servers from a specific registry. Deprecated and yanked versions are not listed.
 // @Tags		registry
 // @Produce		json
 // @Param		registryName	path	string	true	"Registry name"
 // @Param		cursor			query	string	false	"Pagination cursor for retrieving next set of results"
 // @Param		limit			query	int		false	"Maximum number of items to return"
-// @Param		search			query	string	false	"Search servers by name (substring match)"
+// @Param		search			query	string	false	"Full-text search on name, title, description and tool names, ordered by relevance"
 // @Param		updated_since	query	time	false	"Filter servers updated since timestamp (RFC3339 datetime)"
 // @Param		version			query	string	false	"Filter by version ('latest' for latest version, or an exact version like '1.2.3')"
 // @Success		200		{object}	upstreamv0.ServerListResponse
@@ -229,10 +231,7 @@ func (routes *Routes) handleListVersions(w http.ResponseWriter, r *http.Request,
 
 	serverResponses := make([]upstreamv0.ServerResponse, len(versions))
 	for i, version := range versions {
-		serverResponses[i] = upstreamv0.ServerResponse{
-			Server: *version,
-			Meta:   upstreamv0.ResponseMeta{},
-		}
+		serverResponses[i] = *version
 	}
 
 	result := upstreamv0.ServerListResponse{
@@ -249,7 +248,8 @@ func (routes *Routes) handleListVersions(w http.ResponseWriter, r *http.Request,
 // listVersionsWithRegistryName handles GET /{registryName}/v0.1/servers/{serverName}/versions
 //
 // @Summary		List all versions of an MCP server in specific registry
-// @Description	Returns all available versions for a specific MCP server from a specific registry
+// @Description	Returns all available versions for a specific MCP server from a specific registry,
+// @Description	including deprecated and yanked ones.
 // @Tags		registry
 // @Produce		json
 // @Param		registryName	path	string	true	"Registry name"
@@ -309,18 +309,14 @@ func (routes *Routes) handleGetVersion(w http.ResponseWriter, r *http.Request, r
 		return
 	}
 
-	serverResponse := upstreamv0.ServerResponse{
-		Server: *server,
-		Meta:   upstreamv0.ResponseMeta{},
-	}
-	common.WriteJSONResponse(w, serverResponse, http.StatusOK)
+	common.WriteJSONResponse(w, server, http.StatusOK)
 }
 
 // getVersionWithRegistryName handles GET /{registryName}/v0.1/servers/{serverName}/versions/{version}
 //
 // @Summary		Get specific MCP server version in specific registry
 // @Description	Returns detailed information about a specific version of an MCP server from a specific registry.
-// @Description	Use the special version `latest` to get the latest version.
+// @Description	Use the special version `latest` to get the latest version that is neither deprecated nor yanked.
 // @Tags		registry
 // @Produce		json
 // @Param		registryName	path		string	true	"Registry name"
@@ -32,7 +32,7 @@ func TestListServers(t *testing.T) {
 			path: "/foo/v0.1/servers",
 			setupMocks: func(m *mocks.MockRegistryService) {
 				m.EXPECT().ListServers(gomock.Any(), gomock.Any()).Return(&service.ListServersResult{
-					Servers:    []*upstreamv0.ServerJSON{},
+					Servers:    []*upstreamv0.ServerResponse{},
 					NextCursor: "",
 				}, nil).AnyTimes()
 			},
@@ -46,7 +46,7 @@ func TestListServers(t *testing.T) {
 			path: "/foo/v0.1/servers?cursor=abc123",
 			setupMocks: func(m *mocks.MockRegistryService) {
 				m.EXPECT().ListServers(gomock.Any(), gomock.Any()).Return(&service.ListServersResult{
-					Servers:    []*upstreamv0.ServerJSON{},
+					Servers:    []*upstreamv0.ServerResponse{},
 					NextCursor: "",
 				}, nil).AnyTimes()
 			},
@@ -60,7 +60,7 @@ func TestListServers(t *testing.T) {
 			path: "/foo/v0.1/servers?limit=10",
 			setupMocks: func(m *mocks.MockRegistryService) {
 				m.EXPECT().ListServers(gomock.Any(), gomock.Any()).Return(&service.ListServersResult{
-					Servers:    []*upstreamv0.ServerJSON{},
+					Servers:    []*upstreamv0.ServerResponse{},
 					NextCursor: "",
 				}, nil).AnyTimes()
 			},
@@ -74,7 +74,7 @@ func TestListServers(t *testing.T) {
 			path: "/foo/v0.1/servers?search=test",
 			setupMocks: func(m *mocks.MockRegistryService) {
 				m.EXPECT().ListServers(gomock.Any(), gomock.Any()).Return(&service.ListServersResult{
-					Servers:    []*upstreamv0.ServerJSON{},
+					Servers:    []*upstreamv0.ServerResponse{},
 					NextCursor: "",
 				}, nil).AnyTimes()
 			},
@@ -88,7 +88,7 @@ func TestListServers(t *testing.T) {
 			path: "/foo/v0.1/servers?updated_since=2025-01-01T00:00:00Z",
 			setupMocks: func(m *mocks.MockRegistryService) {
 				m.EXPECT().ListServers(gomock.Any(), gomock.Any()).Return(&service.ListServersResult{
-					Servers:    []*upstreamv0.ServerJSON{},
+					Servers:    []*upstreamv0.ServerResponse{},
 					NextCursor: "",
 				}, nil).AnyTimes()
 			},
@@ -102,7 +102,7 @@ func TestListServers(t *testing.T) {
 			path: "/foo/v0.1/servers?version=latest",
 			setupMocks: func(m *mocks.MockRegistryService) {
 				m.EXPECT().ListServers(gomock.Any(), gomock.Any()).Return(&service.ListServersResult{
-					Servers:    []*upstreamv0.ServerJSON{},
+					Servers:    []*upstreamv0.ServerResponse{},
 					NextCursor: "",
 				}, nil).AnyTimes()
 			},
@@ -197,7 +197,7 @@ func TestListVersions(t *testing.T) {
 			name: "list versions with registry name - valid server name",
 			path: "/foo/v0.1/servers/com.example%2Ftest-server/versions",
 			setupMocks: func(m *mocks.MockRegistryService) {
-				m.EXPECT().ListServerVersions(gomock.Any(), gomock.Any()).Return([]*upstreamv0.ServerJSON{}, nil).AnyTimes()
+				m.EXPECT().ListServerVersions(gomock.Any(), gomock.Any()).Return([]*upstreamv0.ServerResponse{}, nil).AnyTimes()
 			},
 			setupRouter: func(mockSvc *mocks.MockRegistryService) http.Handler {
 				return Router(mockSvc)
@@ -290,7 +290,7 @@ func TestGetVersion(t *testing.T) {
 			name: "get version with registry name - valid server and version",
 			path: "/foo/v0.1/servers/com.example%2Ftest-server/versions/1.0.0",
 			setupMocks: func(m *mocks.MockRegistryService) {
-				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&upstreamv0.ServerJSON{}, nil).AnyTimes()
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&upstreamv0.ServerResponse{}, nil).AnyTimes()
 			},
 			setupRouter: func(mockSvc *mocks.MockRegistryService) http.Handler {
 				return Router(mockSvc)
@@ -301,7 +301,7 @@ func TestGetVersion(t *testing.T) {
 			name: "get version with registry name - latest",
 			path: "/foo/v0.1/servers/com.example%2Ftest-server/versions/latest",
 			setupMocks: func(m *mocks.MockRegistryService) {
-				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&upstreamv0.ServerJSON{}, nil).AnyTimes()
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&upstreamv0.ServerResponse{}, nil).AnyTimes()
 			},
 			setupRouter: func(mockSvc *mocks.MockRegistryService) http.Handler {
 				return Router(mockSvc)
@@ -414,7 +414,7 @@ func TestURLEncodingInRoutes(t *testing.T) {
 			description: "Should decode test%2Fserver to test/server and pass to service",
 			setupMocks: func(m *mocks.MockRegistryService) {
 				m.EXPECT().ListServerVersions(gomock.Any(), gomock.Any()).
-					Return([]*upstreamv0.ServerJSON{}, nil)
+					Return([]*upstreamv0.ServerResponse{}, nil)
 			},
 			wantStatus: http.StatusOK,
 		},
@@ -482,7 +482,7 @@ func TestURLEncodingInRoutes(t *testing.T) {
 			description: "Should decode server name properly",
 			setupMocks: func(m *mocks.MockRegistryService) {
 				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).
-					Return(&upstreamv0.ServerJSON{}, nil)
+					Return(&upstreamv0.ServerResponse{}, nil)
 			},
 			wantStatus: http.StatusOK,
 		},
@@ -492,7 +492,7 @@ func TestURLEncodingInRoutes(t *testing.T) {
 			description: "Should decode version properly",
 			setupMocks: func(m *mocks.MockRegistryService) {
 				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).
-					Return(&upstreamv0.ServerJSON{}, nil)
+					Return(&upstreamv0.ServerResponse{}, nil)
 			},
 			wantStatus: http.StatusOK,
 		},
@@ -502,7 +502,7 @@ func TestURLEncodingInRoutes(t *testing.T) {
 			description: "Should decode version with @ symbol",
 			setupMocks: func(m *mocks.MockRegistryService) {
 				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).
-					Return(&upstreamv0.ServerJSON{}, nil)
+					Return(&upstreamv0.ServerResponse{}, nil)
 			},
 			wantStatus: http.StatusOK,
 		},
@@ -546,7 +546,7 @@ func TestURLEncodingInRoutes(t *testing.T) {
 			description: "Should decode registry name properly",
 			setupMocks: func(m *mocks.MockRegistryService) {
 				m.EXPECT().ListServerVersions(gomock.Any(), gomock.Any()).
-					Return([]*upstreamv0.ServerJSON{}, nil)
+					Return([]*upstreamv0.ServerResponse{}, nil)
 			},
 			wantStatus: http.StatusOK,
 		},
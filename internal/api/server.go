@@ -15,9 +15,11 @@ import (
 	_ "github.com/stacklok/toolhive-registry-server/docs/thv-registry-api"
 	v01 "github.com/stacklok/toolhive-registry-server/internal/api/registry/v01"
 	apiv1 "github.com/stacklok/toolhive-registry-server/internal/api/v1"
+	"github.com/stacklok/toolhive-registry-server/internal/api/webhooks"
 	"github.com/stacklok/toolhive-registry-server/internal/auth"
 	"github.com/stacklok/toolhive-registry-server/internal/config"
 	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/signing"
 	"github.com/stacklok/toolhive-registry-server/internal/versions"
 )
 
@@ -31,6 +33,8 @@ type serverConfig struct {
 	middlewares     []func(http.Handler) http.Handler
 	authInfoHandler http.Handler
 	authConfig      *config.AuthConfig
+	syncTrigger     webhooks.SyncTrigger
+	bundleSigner    *signing.Signer
 }
 
 // WithMiddlewares adds middleware to the server
@@ -56,6 +60,21 @@ func WithAuthConfig(authCfg *config.AuthConfig) ServerOption {
 	}
 }
 
+// WithSyncTrigger sets the trigger that webhooks use to start queued syncs
+// without waiting for the next polling tick of the sync coordinator
+func WithSyncTrigger(trigger webhooks.SyncTrigger) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.syncTrigger = trigger
+	}
+}
+
+// WithBundleSigner sets the signer of the bundles exported for replication
+func WithBundleSigner(signer *signing.Signer) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.bundleSigner = signer
+	}
+}
+
 // NewServer creates and configures the HTTP router with the given service and options
 func NewServer(svc service.RegistryService, opts ...ServerOption) *chi.Mux {
 	// Initialize configuration with defaults
@@ -85,7 +104,10 @@ func NewServer(svc service.RegistryService, opts ...ServerOption) *chi.Mux {
 
 	// Mount MCP Registry API v0.1 routes
 	r.Mount("/registry", v01.Router(svc))
-	r.Mount("/v1", apiv1.Router(svc, cfg.authConfig))
+	r.Mount("/v1", apiv1.Router(svc, cfg.authConfig, apiv1.WithBundleSigner(cfg.bundleSigner)))
+
+	// Mount webhook routes, which are authenticated by signature instead of JWT
+	r.Mount("/webhooks", webhooks.Router(svc, cfg.syncTrigger))
 
 	return r
 }
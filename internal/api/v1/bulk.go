@@ -0,0 +1,257 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	thvregistry "github.com/stacklok/toolhive-core/registry/types"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/auth"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/sources"
+)
+
+const (
+	// maxBulkBodySize is the maximum size of the body of a bulk publish
+	maxBulkBodySize = 32 << 20
+	// maxBulkEntries is the maximum number of entry versions of a bulk publish
+	maxBulkEntries = 1000
+)
+
+// bulkPublishEntries handles POST /v1/entries:bulk
+//
+// @Summary		Bulk publish entries
+// @Description	Publish the servers, skills and plugins of an upstream registry document into the managed source.
+// @Description	The claims of each entry are read from its _meta under io.github.stacklok/claims.
+// @Description	Entries are published one by one, the failures of some not preventing the others, unless atomic is
+// @Description	set: then either all entries are published or, when one fails, none.
+// @Description	Bulk publishes carry no signatures: the entries of namespaces requiring them fail.
+// @Description	The document is limited to 32 MiB and 1000 entry versions.
+// @Tags		v1
+// @Accept		json
+// @Produce		json
+// @Param		atomic	query		bool						false	"Publish all entries or none"
+// @Param		request	body		thvregistry.UpstreamRegistry	true	"Upstream registry document"
+// @Success		200		{object}	service.BulkPublishResult	"Outcome of each entry"
+// @Failure		400		{object}	map[string]string			"Bad request"
+// @Failure		403		{object}	map[string]string			"Forbidden"
+// @Failure		413		{object}	map[string]string			"Document too large"
+// @Failure		422		{object}	service.BulkPublishResult	"Atomic bulk publish rolled back"
+// @Failure		500		{object}	map[string]string			"Internal server error"
+// @Router		/v1/entries:bulk [post]
+func (routes *Routes) bulkPublishEntries(w http.ResponseWriter, r *http.Request) {
+	var opts []service.Option
+	if atomicStr := r.URL.Query().Get("atomic"); atomicStr != "" {
+		atomic, err := strconv.ParseBool(atomicStr)
+		if err != nil {
+			common.WriteErrorResponse(w, "invalid atomic parameter: must be a boolean", http.StatusBadRequest)
+			return
+		}
+		if atomic {
+			opts = append(opts, service.WithAtomic())
+		}
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBulkBodySize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			common.WriteErrorResponse(w, "registry document too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		common.WriteErrorResponse(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+	reg, err := sources.NewRegistryDataValidator().ValidateData(body)
+	if err != nil {
+		common.WriteErrorResponse(w, "invalid registry data: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	entries, err := service.PortableEntriesFromUpstream(reg)
+	if err != nil {
+		common.WriteErrorResponse(w, "invalid registry data: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(entries) > maxBulkEntries {
+		common.WriteErrorResponse(w,
+			fmt.Sprintf("too many entries: %d, a bulk publish takes at most %d", len(entries), maxBulkEntries),
+			http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if routes.authzEnabled {
+		for i := range entries {
+			if len(entries[i].Claims) == 0 {
+				common.WriteErrorResponse(w,
+					fmt.Sprintf("claims are required when authorization is enabled: %s has none",
+						bulkEntryLabel(&entries[i])),
+					http.StatusBadRequest)
+				return
+			}
+		}
+	}
+
+	// Extract JWT claims for authorization (subset validation)
+	if jwtClaims := auth.ClaimsFromContext(r.Context()); jwtClaims != nil {
+		opts = append(opts, service.WithJWTClaims(map[string]any(jwtClaims)))
+	}
+
+	result, err := routes.service.BulkPublishEntries(r.Context(), entries, opts...)
+	if err != nil {
+		writePublishError(w, r, err)
+		return
+	}
+
+	status := http.StatusOK
+	if !result.Committed {
+		status = http.StatusUnprocessableEntity
+	}
+	for i := range result.Results {
+		if result.Results[i].Err != nil {
+			_, result.Results[i].Error = publishErrorStatus(r, result.Results[i].Err)
+		}
+	}
+
+	common.WriteJSONResponse(w, result, status)
+}
+
+// bulkEntryLabel names an entry of a bulk publish in error messages.
+func bulkEntryLabel(entry *service.PortableEntry) string {
+	switch {
+	case entry.Server != nil:
+		return fmt.Sprintf("server %s %s", entry.Server.Name, entry.Server.Version)
+	case entry.Skill != nil:
+		return fmt.Sprintf("skill %s/%s %s", entry.Skill.Namespace, entry.Skill.Name, entry.Skill.Version)
+	default:
+		return fmt.Sprintf("plugin %s/%s %s", entry.Plugin.Namespace, entry.Plugin.Name, entry.Plugin.Version)
+	}
+}
+
+// exportSource handles GET /v1/sources/{name}/export
+//
+// @Summary		Export source
+// @Description	Stream every entry version of a source as an upstream registry document. The claims of each entry
+// @Description	are carried in its _meta under io.github.stacklok/claims, so that the document can be imported back
+// @Description	with POST /v1/entries:bulk.
+// @Tags		v1
+// @Produce		json
+// @Param		name	path		string						true	"Source Name"
+// @Success		200		{object}	thvregistry.UpstreamRegistry	"Upstream registry document"
+// @Failure		400		{object}	map[string]string			"Bad request"
+// @Failure		404		{object}	map[string]string			"Source not found"
+// @Failure		500		{object}	map[string]string			"Internal server error"
+// @Router		/v1/sources/{name}/export [get]
+func (routes *Routes) exportSource(w http.ResponseWriter, r *http.Request) {
+	name, err := common.GetAndValidateURLParam(r, "name")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// The response starts with the first entry, so that errors raised before it
+	// are still answered with an error status
+	export := &exportWriter{w: w, started: func() {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+	}}
+	err = routes.service.ExportSource(r.Context(), name, export.writeEntry)
+	if err == nil {
+		err = export.close()
+	}
+	if err != nil {
+		if !export.writing() {
+			writeSourceError(w, err)
+			return
+		}
+		// Too late for an error status: the document is left truncated
+		slog.ErrorContext(r.Context(), "failed to export source", "source", name, "error", err)
+	}
+}
+
+// exportWriter streams entries as an upstream registry document. Entries must
+// be written servers first, then skills, then plugins.
+type exportWriter struct {
+	w       io.Writer
+	started func()
+	// section is the data array being written, empty before the document starts
+	section string
+	// count is the number of entries written in section
+	count int
+}
+
+// writing reports whether the document has started.
+func (e *exportWriter) writing() bool {
+	return e.section != ""
+}
+
+// writeEntry writes an entry into the array of its type.
+func (e *exportWriter) writeEntry(entry *service.PortableEntry) error {
+	var section string
+	var value any
+	switch {
+	case entry.Server != nil:
+		section, value = "servers", entry.UpstreamServer()
+	case entry.Skill != nil:
+		section, value = "skills", entry.UpstreamSkill()
+	default:
+		section, value = "plugins", entry.UpstreamPlugin()
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to serialize %s: %w", bulkEntryLabel(entry), err)
+	}
+
+	if err := e.start(); err != nil {
+		return err
+	}
+	if section != e.section {
+		if _, err := fmt.Fprintf(e.w, `],%q:[`, section); err != nil {
+			return err
+		}
+		e.section = section
+		e.count = 0
+	}
+	if e.count > 0 {
+		if _, err := io.WriteString(e.w, ","); err != nil {
+			return err
+		}
+	}
+	e.count++
+	_, err = e.w.Write(data)
+	return err
+}
+
+// close ends the document.
+func (e *exportWriter) close() error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(e.w, "]}}")
+	return err
+}
+
+// start writes the head of the document, up to the servers array, unless
+// already written.
+func (e *exportWriter) start() error {
+	if e.writing() {
+		return nil
+	}
+	meta, err := json.Marshal(thvregistry.UpstreamMeta{LastUpdated: time.Now().UTC().Format(time.RFC3339)})
+	if err != nil {
+		return err
+	}
+	e.started()
+	e.section = "servers"
+	_, err = fmt.Fprintf(e.w, `{"$schema":%q,"version":%q,"meta":%s,"data":{"servers":[`,
+		registry.UpstreamRegistrySchemaURL, registry.UpstreamRegistryVersion, meta)
+	return err
+}
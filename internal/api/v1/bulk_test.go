@@ -0,0 +1,314 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+	"github.com/stacklok/toolhive-registry-server/internal/sources"
+)
+
+const bulkRegistryJSON = `{
+	"version": "1.0.0",
+	"meta": {"last_updated": "2025-01-01T00:00:00Z"},
+	"data": {
+		"servers": [{
+			"name": "com.example/weather",
+			"description": "Weather data",
+			"version": "1.0.0",
+			"_meta": {
+				"io.modelcontextprotocol.registry/publisher-provided": {
+					"io.github.stacklok/claims": {"org": "example"}
+				}
+			}
+		}],
+		"skills": [{
+			"namespace": "com.example",
+			"name": "forecast",
+			"description": "Forecasts",
+			"version": "1.0.0"
+		}]
+	}
+}`
+
+// bulkRegistryJSONWithServers returns a registry document with count servers
+func bulkRegistryJSONWithServers(count int) string {
+	servers := make([]string, count)
+	for i := range servers {
+		servers[i] = fmt.Sprintf(`{"name": "com.example/server-%d", "description": "Server", "version": "1.0.0"}`, i)
+	}
+	return `{"version": "1.0.0", "meta": {"last_updated": "2025-01-01T00:00:00Z"}, "data": {"servers": [` +
+		strings.Join(servers, ",") + `]}}`
+}
+
+func TestBulkPublishEntries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		query       string
+		body        string
+		setupMock   func(*mocks.MockRegistryService)
+		wantStatus  int
+		wantError   string
+		wantResults []service.BulkEntryResult
+	}{
+		{
+			name: "publishes each entry",
+			body: bulkRegistryJSON,
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().BulkPublishEntries(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, entries []service.PortableEntry, opts ...service.Option) (
+						*service.BulkPublishResult, error,
+					) {
+						options := &service.BulkPublishEntriesOptions{}
+						for _, opt := range opts {
+							require.NoError(t, opt(options))
+						}
+						assert.False(t, options.Atomic)
+
+						require.Len(t, entries, 2)
+						assert.Equal(t, "com.example/weather", entries[0].Server.Name)
+						assert.Equal(t, map[string]any{"org": "example"}, entries[0].Claims)
+						assert.Empty(t, entries[0].Server.Meta.PublisherProvided)
+						assert.Equal(t, "forecast", entries[1].Skill.Name)
+						assert.Nil(t, entries[1].Claims)
+
+						return &service.BulkPublishResult{
+							Committed: true,
+							Results: []service.BulkEntryResult{
+								{EntryType: service.EntryTypeServer, Name: "com.example/weather", Version: "1.0.0",
+									Status: service.BulkEntryPublished},
+								{EntryType: service.EntryTypeSkill, Namespace: "com.example", Name: "forecast",
+									Version: "1.0.0", Status: service.BulkEntryFailed,
+									Err: fmt.Errorf("%w: forecast 1.0.0", service.ErrVersionAlreadyExists)},
+							},
+						}, nil
+					})
+			},
+			wantStatus: http.StatusOK,
+			wantResults: []service.BulkEntryResult{
+				{EntryType: service.EntryTypeServer, Name: "com.example/weather", Version: "1.0.0",
+					Status: service.BulkEntryPublished},
+				{EntryType: service.EntryTypeSkill, Namespace: "com.example", Name: "forecast", Version: "1.0.0",
+					Status: service.BulkEntryFailed, Error: "version already exists: forecast 1.0.0"},
+			},
+		},
+		{
+			name:  "atomic rollback",
+			query: "?atomic=true",
+			body:  bulkRegistryJSON,
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().BulkPublishEntries(gomock.Any(), gomock.Len(2), gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ []service.PortableEntry, opts ...service.Option) (
+						*service.BulkPublishResult, error,
+					) {
+						options := &service.BulkPublishEntriesOptions{}
+						for _, opt := range opts {
+							require.NoError(t, opt(options))
+						}
+						assert.True(t, options.Atomic)
+
+						return &service.BulkPublishResult{
+							Atomic: true,
+							Results: []service.BulkEntryResult{
+								{EntryType: service.EntryTypeServer, Name: "com.example/weather", Version: "1.0.0",
+									Status: service.BulkEntrySkipped},
+								{EntryType: service.EntryTypeSkill, Namespace: "com.example", Name: "forecast",
+									Version: "1.0.0", Status: service.BulkEntryFailed,
+									Err: errors.New("connection reset")},
+							},
+						}, nil
+					})
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantResults: []service.BulkEntryResult{
+				{EntryType: service.EntryTypeServer, Name: "com.example/weather", Version: "1.0.0",
+					Status: service.BulkEntrySkipped},
+				{EntryType: service.EntryTypeSkill, Namespace: "com.example", Name: "forecast", Version: "1.0.0",
+					Status: service.BulkEntryFailed, Error: "failed to publish entry"},
+			},
+		},
+		{
+			name:       "invalid atomic parameter",
+			query:      "?atomic=maybe",
+			body:       bulkRegistryJSON,
+			setupMock:  func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid atomic parameter",
+		},
+		{
+			name:       "invalid registry data",
+			body:       `{"version": "1.0.0", "data": {"servers": []}}`,
+			setupMock:  func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid registry data",
+		},
+		{
+			name: "claims that are not an object",
+			body: `{"version": "1.0.0", "meta": {"last_updated": "2025-01-01T00:00:00Z"}, "data": {"servers": [{
+				"name": "com.example/weather", "description": "Weather data", "version": "1.0.0",
+				"_meta": {"io.modelcontextprotocol.registry/publisher-provided": {"io.github.stacklok/claims": "org"}}
+			}]}}`,
+			setupMock:  func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+			wantError:  "io.github.stacklok/claims must be an object",
+		},
+		{
+			name:       "document too large",
+			body:       `{"version": "` + strings.Repeat("1", maxBulkBodySize) + `"}`,
+			setupMock:  func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantError:  "registry document too large",
+		},
+		{
+			name:       "too many entries",
+			body:       bulkRegistryJSONWithServers(maxBulkEntries + 1),
+			setupMock:  func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantError:  "too many entries: 1001, a bulk publish takes at most 1000",
+		},
+		{
+			name: "no managed source",
+			body: bulkRegistryJSON,
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().BulkPublishEntries(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, service.ErrNoManagedSource)
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantError:  "no managed source available for publishing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			tt.setupMock(mockSvc)
+
+			router := Router(mockSvc, nil)
+			req, err := http.NewRequest(http.MethodPost, "/entries:bulk"+tt.query, bytes.NewBufferString(tt.body))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantError != "" {
+				var response map[string]string
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Contains(t, response["error"], tt.wantError)
+			}
+			if tt.wantResults != nil {
+				var response service.BulkPublishResult
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tt.wantResults, response.Results)
+			}
+		})
+	}
+}
+
+func TestExportSource(t *testing.T) {
+	t.Parallel()
+
+	server := &upstreamv0.ServerJSON{
+		Schema:      "https://static.modelcontextprotocol.io/schemas/2025-12-11/server.schema.json",
+		Name:        "com.example/weather",
+		Description: "Weather data",
+		Version:     "1.0.0",
+	}
+	skill := &service.Skill{Namespace: "com.example", Name: "forecast", Description: "Forecasts", Version: "1.0.0"}
+	plugin := &service.Plugin{Namespace: "com.example", Name: "radar", Description: "Radar", Version: "1.0.0"}
+	claims := map[string]any{"org": "example"}
+
+	t.Run("streams an upstream registry document", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockSvc := mocks.NewMockRegistryService(ctrl)
+		mockSvc.EXPECT().ExportSource(gomock.Any(), "upstream", gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, emit func(*service.PortableEntry) error) error {
+				for _, entry := range []*service.PortableEntry{
+					{Server: server, Claims: claims},
+					{Server: server},
+					{Skill: skill, Claims: claims},
+					{Plugin: plugin},
+				} {
+					if err := emit(entry); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/sources/upstream/export", nil)
+		require.NoError(t, err)
+		Router(mockSvc, nil).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+		reg, err := sources.NewRegistryDataValidator().ValidateData(rr.Body.Bytes())
+		require.NoError(t, err)
+		require.Len(t, reg.Data.Servers, 2)
+		require.Len(t, reg.Data.Skills, 1)
+		require.Len(t, reg.Data.Plugins, 1)
+
+		entries, err := service.PortableEntriesFromUpstream(reg)
+		require.NoError(t, err)
+		require.Len(t, entries, 4)
+		assert.Equal(t, claims, entries[0].Claims)
+		assert.Nil(t, entries[1].Claims)
+		assert.Equal(t, claims, entries[2].Claims)
+		assert.Equal(t, "forecast", entries[2].Skill.Name)
+		assert.Equal(t, "radar", entries[3].Plugin.Name)
+	})
+
+	t.Run("source without entries", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockSvc := mocks.NewMockRegistryService(ctrl)
+		mockSvc.EXPECT().ExportSource(gomock.Any(), "empty", gomock.Any()).Return(nil)
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/sources/empty/export", nil)
+		require.NoError(t, err)
+		Router(mockSvc, nil).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var doc map[string]any
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &doc))
+		assert.Equal(t, map[string]any{"servers": []any{}}, doc["data"])
+	})
+
+	t.Run("unknown source", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockSvc := mocks.NewMockRegistryService(ctrl)
+		mockSvc.EXPECT().ExportSource(gomock.Any(), "unknown", gomock.Any()).
+			Return(fmt.Errorf("%w: unknown", service.ErrSourceNotFound))
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/sources/unknown/export", nil)
+		require.NoError(t, err)
+		Router(mockSvc, nil).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
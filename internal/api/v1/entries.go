@@ -3,6 +3,7 @@ package v1
 import (
 	"encoding/json"
 	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 
@@ -20,26 +21,46 @@ type publishEntryRequest struct {
 	Server *upstreamv0.ServerJSON `json:"server,omitempty"`
 	Skill  *service.Skill         `json:"skill,omitempty"`
 	Plugin *service.Plugin        `json:"plugin,omitempty"`
+	// Signature is an optional signature over the JSON encoding of the server,
+	// skill or plugin, exactly as sent in this request
+	Signature *service.EntrySignature `json:"signature,omitempty"`
+}
+
+// publishedEntryPayloads holds the JSON encoding of the entry of a publish
+// request, as sent by the publisher, over which its signature is made.
+type publishedEntryPayloads struct {
+	Server json.RawMessage `json:"server"`
+	Skill  json.RawMessage `json:"skill"`
+	Plugin json.RawMessage `json:"plugin"`
 }
 
 // publishEntry handles POST /v1/entries
 //
 // @Summary		Publish entry
 // @Description	Publish a new server, skill, or plugin entry. Exactly one of 'server', 'skill', or 'plugin' must be provided.
+// @Description	An optional signature over the entry is verified against the keys trusted for its namespace.
 // @Tags		v1
 // @Accept		json
 // @Produce		json
 // @Param		request	body		publishEntryRequest	true	"Entry to publish (server, skill, or plugin)"
 // @Success		201	{object}	interface{}	"Published entry (server or skill)"
+// @Success		202	{object}	service.EntrySubmission	"Submission held for review, when the managed source requires approval"
 // @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		403	{object}	map[string]string	"Forbidden"
 // @Failure		409	{object}	map[string]string	"Conflict"
+// @Failure		422	{object}	map[string]string	"Entry blocked by the entry policy"
 // @Failure		500	{object}	map[string]string	"Internal server error"
 // @Router		/v1/entries [post]
 //
 //nolint:gocyclo // complexity driven by three-way entry type dispatch
 func (routes *Routes) publishEntry(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		common.WriteErrorResponse(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
 	var req publishEntryRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := json.Unmarshal(body, &req); err != nil {
 		common.WriteErrorResponse(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -63,13 +84,31 @@ func (routes *Routes) publishEntry(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Extract JWT claims for authorization (subset validation)
-	var jwtOpts []service.Option
+	var commonOpts []service.Option
 	if jwtClaims := auth.ClaimsFromContext(r.Context()); jwtClaims != nil {
-		jwtOpts = append(jwtOpts, service.WithJWTClaims(map[string]any(jwtClaims)))
+		commonOpts = append(commonOpts, service.WithJWTClaims(map[string]any(jwtClaims)))
+	}
+
+	// The signature is verified over the entry exactly as sent
+	if req.Signature != nil {
+		var payloads publishedEntryPayloads
+		if err := json.Unmarshal(body, &payloads); err != nil {
+			common.WriteErrorResponse(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		switch {
+		case hasServer:
+			req.Signature.Payload = payloads.Server
+		case hasSkill:
+			req.Signature.Payload = payloads.Skill
+		default:
+			req.Signature.Payload = payloads.Plugin
+		}
+		commonOpts = append(commonOpts, service.WithSignature(req.Signature))
 	}
 
 	if req.Server != nil {
-		opts := append([]service.Option{service.WithServerData(req.Server)}, jwtOpts...)
+		opts := append([]service.Option{service.WithServerData(req.Server)}, commonOpts...)
 		if req.Claims != nil {
 			opts = append(opts, service.WithClaims(req.Claims))
 		}
@@ -83,7 +122,7 @@ func (routes *Routes) publishEntry(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if req.Skill != nil {
-		opts := append([]service.Option{}, jwtOpts...)
+		opts := append([]service.Option{}, commonOpts...)
 		if req.Claims != nil {
 			opts = append(opts, service.WithClaims(req.Claims))
 		}
@@ -97,7 +136,7 @@ func (routes *Routes) publishEntry(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if req.Plugin != nil {
-		opts := append([]service.Option{}, jwtOpts...)
+		opts := append([]service.Option{}, commonOpts...)
 		if req.Claims != nil {
 			opts = append(opts, service.WithClaims(req.Claims))
 		}
@@ -111,30 +150,45 @@ func (routes *Routes) publishEntry(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// writePublishError maps service-layer publish errors to HTTP responses.
+// writePublishError maps service-layer publish errors to HTTP responses. A
+// publish held for review is answered with 202 and the submission.
 func writePublishError(w http.ResponseWriter, r *http.Request, err error) {
-	if errors.Is(err, service.ErrInvalidServerName) {
-		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+	var pending *service.PendingReviewError
+	if errors.As(err, &pending) {
+		common.WriteJSONResponse(w, pending.Submission, http.StatusAccepted)
 		return
 	}
-	if errors.Is(err, service.ErrClaimsInsufficient) {
-		common.WriteErrorResponse(w, err.Error(), http.StatusForbidden)
-		return
+	status, message := publishErrorStatus(r, err)
+	common.WriteErrorResponse(w, message, status)
+}
+
+// publishErrorStatus maps a service-layer publish error to an HTTP status and
+// the message reported to the publisher.
+func publishErrorStatus(r *http.Request, err error) (int, string) {
+	if errors.Is(err, service.ErrInvalidServerName) ||
+		errors.Is(err, service.ErrSignatureRequired) ||
+		errors.Is(err, service.ErrInvalidSignature) ||
+		errors.Is(err, service.ErrDigestPinningRequired) {
+		return http.StatusBadRequest, err.Error()
+	}
+	if errors.Is(err, service.ErrClaimsInsufficient) ||
+		errors.Is(err, service.ErrNamespaceNotOwned) {
+		return http.StatusForbidden, err.Error()
 	}
 	if errors.Is(err, service.ErrVersionAlreadyExists) {
-		common.WriteErrorResponse(w, err.Error(), http.StatusConflict)
-		return
+		return http.StatusConflict, err.Error()
 	}
 	if errors.Is(err, service.ErrClaimsMismatch) {
-		common.WriteErrorResponse(w, err.Error(), http.StatusConflict)
-		return
+		return http.StatusConflict, err.Error()
+	}
+	if errors.Is(err, service.ErrEntryBlocked) {
+		return http.StatusUnprocessableEntity, err.Error()
 	}
 	if errors.Is(err, service.ErrNoManagedSource) {
-		common.WriteErrorResponse(w, "no managed source available for publishing", http.StatusInternalServerError)
-		return
+		return http.StatusInternalServerError, "no managed source available for publishing"
 	}
 	slog.ErrorContext(r.Context(), "failed to publish entry", "error", err)
-	common.WriteErrorResponse(w, "failed to publish entry", http.StatusInternalServerError)
+	return http.StatusInternalServerError, "failed to publish entry"
 }
 
 // deletePublishedEntry handles DELETE /v1/entries/{type}/{name}/versions/{version}
@@ -148,6 +202,7 @@ func writePublishError(w http.ResponseWriter, r *http.Request, err error) {
 // @Param		version	path	string	true	"Version"
 // @Success		204	"No Content"
 // @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		403	{object}	map[string]string	"Forbidden"
 // @Failure		404	{object}	map[string]string	"Not found"
 // @Failure		500	{object}	map[string]string	"Internal server error"
 // @Router		/v1/entries/{type}/{name}/versions/{version} [delete]
@@ -189,7 +244,8 @@ func (routes *Routes) deletePublishedEntry(w http.ResponseWriter, r *http.Reques
 	}
 
 	if err != nil {
-		if errors.Is(err, service.ErrClaimsInsufficient) {
+		if errors.Is(err, service.ErrClaimsInsufficient) ||
+			errors.Is(err, service.ErrNamespaceNotOwned) {
 			common.WriteErrorResponse(w, err.Error(), http.StatusForbidden)
 			return
 		}
@@ -209,6 +265,100 @@ func (routes *Routes) deletePublishedEntry(w http.ResponseWriter, r *http.Reques
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// updateEntryVersionStatusRequest is the request body for updating the status of an entry version.
+type updateEntryVersionStatusRequest struct {
+	// Status is one of "active", "deprecated", or "yanked"
+	Status string `json:"status"`
+	// Reason explains why the version was deprecated or yanked
+	Reason string `json:"reason,omitempty"`
+	// ReplacedBy is the version to use instead of a deprecated or yanked one
+	ReplacedBy string `json:"replacedBy,omitempty"`
+}
+
+// updateEntryVersionStatus handles PUT /v1/entries/{type}/{name}/versions/{version}/status
+//
+// @Summary		Update entry version status
+// @Description	Deprecate or yank a published server version, or make it active again. Deprecated and
+// @Description	yanked versions stay resolvable by their exact version, but are no longer listed nor
+// @Description	resolved as the latest version. Only server entries are supported.
+// @Tags		v1
+// @Accept		json
+// @Produce		json
+// @Param		type	path	string	true	"Entry Type (server)"
+// @Param		name	path	string	true	"Entry Name"
+// @Param		version	path	string	true	"Version"
+// @Param		request	body	updateEntryVersionStatusRequest	true	"Status to set"
+// @Success		204	"No Content"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		403	{object}	map[string]string	"Forbidden"
+// @Failure		404	{object}	map[string]string	"Not found"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Router		/v1/entries/{type}/{name}/versions/{version}/status [put]
+func (routes *Routes) updateEntryVersionStatus(w http.ResponseWriter, r *http.Request) {
+	entryType, err := common.GetAndValidateURLParam(r, "type")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if entryType != service.EntryTypeServer {
+		common.WriteErrorResponse(w, "unsupported entry type: status can only be set on 'server' entries", http.StatusBadRequest)
+		return
+	}
+
+	name, err := common.GetAndValidateURLParam(r, "name")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	version, err := common.GetAndValidateURLParam(r, "version")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req updateEntryVersionStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteErrorResponse(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	opts := []service.Option{
+		service.WithName(name),
+		service.WithVersion(version),
+		service.WithServerStatus(req.Status),
+		service.WithStatusReason(req.Reason),
+		service.WithReplacedBy(req.ReplacedBy),
+	}
+	if jwtClaims := auth.ClaimsFromContext(r.Context()); jwtClaims != nil {
+		opts = append(opts, service.WithJWTClaims(map[string]any(jwtClaims)))
+	}
+
+	if err := routes.service.UpdateServerVersionStatus(r.Context(), opts...); err != nil {
+		if errors.Is(err, service.ErrInvalidServerStatus) {
+			common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, service.ErrClaimsInsufficient) {
+			common.WriteErrorResponse(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, service.ErrNotFound) {
+			common.WriteErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, service.ErrNoManagedSource) {
+			common.WriteErrorResponse(w, "no managed source available for updating status", http.StatusInternalServerError)
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to update entry version status", "error", err, "type", entryType)
+		common.WriteErrorResponse(w, "failed to update entry version status", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // entryClaimsResponse is the response body for fetching or returning entry claims.
 type entryClaimsResponse struct {
 	Claims map[string]any `json:"claims"`
@@ -350,7 +500,8 @@ func (routes *Routes) updateEntryClaims(w http.ResponseWriter, r *http.Request)
 			common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		if errors.Is(err, service.ErrClaimsInsufficient) {
+		if errors.Is(err, service.ErrClaimsInsufficient) ||
+			errors.Is(err, service.ErrNamespaceNotOwned) {
 			common.WriteErrorResponse(w, err.Error(), http.StatusForbidden)
 			return
 		}
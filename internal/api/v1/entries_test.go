@@ -2,6 +2,7 @@ package v1
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -91,6 +92,48 @@ func TestPublishEntry(t *testing.T) {
 			wantStatus: http.StatusConflict,
 			wantError:  "version already exists",
 		},
+		{
+			name: "entry blocked by the entry policy",
+			body: mustMarshal(publishEntryRequest{
+				Server: &upstreamv0.ServerJSON{Name: "test/server", Version: "1.0.0"},
+			}),
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().PublishServerVersion(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("%w: test/server 1.0.0: osv: malware", service.ErrEntryBlocked))
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantError:  "entry blocked by the entry policy",
+		},
+		{
+			name: "publish of unpinned packages returns 400",
+			body: mustMarshal(publishEntryRequest{
+				Server: &upstreamv0.ServerJSON{Name: "test/server", Version: "1.0.0"},
+			}),
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().PublishServerVersion(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("%w: package is not pinned to a digest: test/server 1.0.0: ghcr.io/test/server:1.0.0",
+						service.ErrDigestPinningRequired))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantError:  "digest pinning required",
+		},
+		{
+			name: "publish held for review returns 202",
+			body: mustMarshal(publishEntryRequest{
+				Server: &upstreamv0.ServerJSON{Name: "test/server", Version: "1.0.0"},
+			}),
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().PublishServerVersion(gomock.Any(), gomock.Any()).
+					Return(nil, &service.PendingReviewError{Submission: &service.EntrySubmission{
+						ID:        "0b6f1a52-6a4e-4a53-9d0c-5c1e2f9f7a10",
+						EntryType: service.EntryTypeServer,
+						Name:      "test/server",
+						Version:   "1.0.0",
+						Status:    service.SubmissionStatusPending,
+					}})
+			},
+			wantStatus: http.StatusAccepted,
+		},
 		{
 			name: "no managed source",
 			body: mustMarshal(publishEntryRequest{
@@ -115,6 +158,18 @@ func TestPublishEntry(t *testing.T) {
 			wantStatus: http.StatusBadRequest,
 			wantError:  "invalid server name",
 		},
+		{
+			name: "namespace reserved by another publisher returns 403",
+			body: mustMarshal(publishEntryRequest{
+				Server: &upstreamv0.ServerJSON{Name: "com.bigcorp/server", Version: "1.0.0"},
+			}),
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().PublishServerVersion(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("%w: com.bigcorp is reserved by com.bigcorp", service.ErrNamespaceNotOwned))
+			},
+			wantStatus: http.StatusForbidden,
+			wantError:  "namespace is not owned by the caller",
+		},
 		{
 			name: "generic service error",
 			body: mustMarshal(publishEntryRequest{
@@ -294,6 +349,77 @@ func TestPublishEntryClaimsRequired(t *testing.T) {
 	}
 }
 
+func TestPublishEntrySignature(t *testing.T) {
+	t.Parallel()
+
+	// The signature covers the entry exactly as sent, whitespace included
+	const skillJSON = `{ "namespace": "io.test",  "name": "my-skill", "version": "1.0.0" }`
+	body := []byte(`{"skill": ` + skillJSON + `, "signature": {"format": "jws", "value": "eyJhbGciOiJFUzI1NiJ9..c2ln"}}`)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{
+			name:       "verified signature",
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "invalid signature returns 400",
+			err:        fmt.Errorf("%w: io.test/my-skill", service.ErrInvalidSignature),
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid signature",
+		},
+		{
+			name:       "missing signature returns 400",
+			err:        fmt.Errorf("%w: io.test/my-skill", service.ErrSignatureRequired),
+			wantStatus: http.StatusBadRequest,
+			wantError:  "signature is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			mockSvc.EXPECT().PublishSkill(gomock.Any(), gomock.Any(), gomock.Len(1)).DoAndReturn(
+				func(_ context.Context, skill *service.Skill, opts ...service.Option) (*service.Skill, error) {
+					options := &service.PublishSkillOptions{}
+					for _, opt := range opts {
+						require.NoError(t, opt(options))
+					}
+					require.NotNil(t, options.Signature)
+					assert.Equal(t, "jws", options.Signature.Format)
+					assert.Equal(t, skillJSON, string(options.Signature.Payload))
+
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return skill, nil
+				})
+
+			router := Router(mockSvc, nil)
+			req, err := http.NewRequest("POST", "/entries", bytes.NewReader(body))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantError != "" {
+				var response map[string]string
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Contains(t, response["error"], tt.wantError)
+			}
+		})
+	}
+}
+
 func TestDeletePublishedEntry(t *testing.T) {
 	t.Parallel()
 
@@ -398,6 +524,118 @@ func TestDeletePublishedEntry(t *testing.T) {
 	}
 }
 
+func TestUpdateEntryVersionStatus(t *testing.T) {
+	t.Parallel()
+
+	// name, version, status, reason and replacement options
+	anyStatusOpts := []any{gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()}
+
+	tests := []struct {
+		name       string
+		path       string
+		body       []byte
+		setupMock  func(*mocks.MockRegistryService)
+		wantStatus int
+		wantError  string
+	}{
+		{
+			name: "success - deprecate server version",
+			path: "/entries/server/test%2Fserver/versions/1.0.0/status",
+			body: mustMarshal(map[string]any{"status": "deprecated", "reason": "CVE-2026-0001", "replacedBy": "1.0.1"}),
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().UpdateServerVersionStatus(gomock.Any(), anyStatusOpts...).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "unsupported entry type",
+			path:       "/entries/skill/test%2Fskill/versions/1.0.0/status",
+			body:       mustMarshal(map[string]any{"status": "deprecated"}),
+			setupMock:  func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+			wantError:  "unsupported entry type",
+		},
+		{
+			name:       "invalid JSON body",
+			path:       "/entries/server/test%2Fserver/versions/1.0.0/status",
+			body:       []byte("not-json"),
+			setupMock:  func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid request body",
+		},
+		{
+			name: "invalid status",
+			path: "/entries/server/test%2Fserver/versions/1.0.0/status",
+			body: mustMarshal(map[string]any{"status": "archived"}),
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().UpdateServerVersionStatus(gomock.Any(), anyStatusOpts...).
+					Return(fmt.Errorf("invalid option: %w", service.ErrInvalidServerStatus))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid server status",
+		},
+		{
+			name: "version not found",
+			path: "/entries/server/test%2Fserver/versions/1.0.0/status",
+			body: mustMarshal(map[string]any{"status": "yanked"}),
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().UpdateServerVersionStatus(gomock.Any(), anyStatusOpts...).Return(service.ErrNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "claims insufficient",
+			path: "/entries/server/test%2Fserver/versions/1.0.0/status",
+			body: mustMarshal(map[string]any{"status": "yanked"}),
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().UpdateServerVersionStatus(gomock.Any(), anyStatusOpts...).Return(service.ErrClaimsInsufficient)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "generic service error",
+			path: "/entries/server/test%2Fserver/versions/1.0.0/status",
+			body: mustMarshal(map[string]any{"status": "yanked"}),
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().UpdateServerVersionStatus(gomock.Any(), anyStatusOpts...).Return(fmt.Errorf("unexpected error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantError:  "failed to update entry version status",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			tt.setupMock(mockSvc)
+
+			router := Router(mockSvc, nil)
+			req, err := http.NewRequest(http.MethodPut, tt.path, bytes.NewReader(tt.body))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+
+			if tt.wantError != "" {
+				var response map[string]string
+				err = json.Unmarshal(rr.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Contains(t, response["error"], tt.wantError)
+			}
+
+			if tt.wantStatus == http.StatusNoContent {
+				assert.Empty(t, rr.Body.Bytes())
+			}
+		})
+	}
+}
+
 func TestUpdateEntryClaims(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,158 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// namespaceListResponse is the JSON envelope for listing namespaces.
+type namespaceListResponse struct {
+	Namespaces []service.NamespaceInfo `json:"namespaces"`
+}
+
+// listNamespaces handles GET /v1/namespaces
+//
+// @Summary		List namespaces
+// @Description	List the namespaces reserved for their owners
+// @Tags		v1
+// @Produce		json
+// @Success		200	{object}	namespaceListResponse	"Namespaces list"
+// @Failure		403	{object}	map[string]string		"Forbidden"
+// @Failure		500	{object}	map[string]string		"Internal server error"
+// @Router		/v1/namespaces [get]
+func (routes *Routes) listNamespaces(w http.ResponseWriter, r *http.Request) {
+	namespaces, err := routes.service.ListNamespaces(r.Context())
+	if err != nil {
+		writeNamespaceError(w, r, err)
+		return
+	}
+
+	common.WriteJSONResponse(w, namespaceListResponse{Namespaces: namespaces}, http.StatusOK)
+}
+
+// getNamespace handles GET /v1/namespaces/{name}
+//
+// @Summary		Get namespace
+// @Description	Get a reserved namespace by name
+// @Tags		v1
+// @Produce		json
+// @Param		name	path		string					true	"Namespace (reverse-DNS)"
+// @Success		200		{object}	service.NamespaceInfo	"Namespace details"
+// @Failure		400		{object}	map[string]string		"Bad request"
+// @Failure		403		{object}	map[string]string		"Forbidden"
+// @Failure		404		{object}	map[string]string		"Namespace not found"
+// @Failure		500		{object}	map[string]string		"Internal server error"
+// @Router		/v1/namespaces/{name} [get]
+func (routes *Routes) getNamespace(w http.ResponseWriter, r *http.Request) {
+	name, err := common.GetAndValidateURLParam(r, "name")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	namespace, err := routes.service.GetNamespace(r.Context(), name)
+	if err != nil {
+		writeNamespaceError(w, r, err)
+		return
+	}
+
+	common.WriteJSONResponse(w, namespace, http.StatusOK)
+}
+
+// upsertNamespace handles PUT /v1/namespaces/{name}
+//
+// @Summary		Reserve or update namespace
+// @Description	Reserve a namespace for the callers covering its claims, or update the claims of a reserved one.
+// @Description	Without claims, only super-admins may publish into the namespace.
+// @Tags		v1
+// @Accept		json
+// @Produce		json
+// @Param		name	path		string						true	"Namespace (reverse-DNS)"
+// @Param		request	body		service.NamespaceRequest	true	"Namespace owners"
+// @Success		200		{object}	service.NamespaceInfo		"Namespace updated"
+// @Success		201		{object}	service.NamespaceInfo		"Namespace reserved"
+// @Failure		400		{object}	map[string]string			"Bad request"
+// @Failure		403		{object}	map[string]string			"Forbidden"
+// @Failure		500		{object}	map[string]string			"Internal server error"
+// @Router		/v1/namespaces/{name} [put]
+func (routes *Routes) upsertNamespace(w http.ResponseWriter, r *http.Request) {
+	name, err := common.GetAndValidateURLParam(r, "name")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req service.NamespaceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteErrorResponse(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Try create first
+	namespace, err := routes.service.CreateNamespace(r.Context(), name, &req)
+	if err == nil {
+		common.WriteJSONResponse(w, namespace, http.StatusCreated)
+		return
+	}
+
+	// If it already exists, try update
+	if errors.Is(err, service.ErrNamespaceAlreadyExists) {
+		namespace, err = routes.service.UpdateNamespace(r.Context(), name, &req)
+		if err != nil {
+			writeNamespaceError(w, r, err)
+			return
+		}
+		common.WriteJSONResponse(w, namespace, http.StatusOK)
+		return
+	}
+
+	writeNamespaceError(w, r, err)
+}
+
+// deleteNamespace handles DELETE /v1/namespaces/{name}
+//
+// @Summary		Release namespace
+// @Description	Release a reserved namespace. Its entries are kept.
+// @Tags		v1
+// @Produce		json
+// @Param		name	path	string	true	"Namespace (reverse-DNS)"
+// @Success		204	"Namespace released"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		403	{object}	map[string]string	"Forbidden"
+// @Failure		404	{object}	map[string]string	"Namespace not found"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Router		/v1/namespaces/{name} [delete]
+func (routes *Routes) deleteNamespace(w http.ResponseWriter, r *http.Request) {
+	name, err := common.GetAndValidateURLParam(r, "name")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := routes.service.DeleteNamespace(r.Context(), name); err != nil {
+		writeNamespaceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeNamespaceError maps service-layer namespace errors to HTTP responses.
+func writeNamespaceError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrNamespaceNotFound):
+		common.WriteErrorResponse(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, service.ErrInvalidNamespace):
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrNamespaceAlreadyExists):
+		common.WriteErrorResponse(w, err.Error(), http.StatusConflict)
+	default:
+		slog.ErrorContext(r.Context(), "unexpected namespace error", "error", err)
+		common.WriteErrorResponse(w, "internal server error", http.StatusInternalServerError)
+	}
+}
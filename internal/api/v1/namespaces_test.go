@@ -0,0 +1,160 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+func TestNamespaceRoutes(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	namespace := &service.NamespaceInfo{
+		Name:      "com.example",
+		Claims:    map[string]any{"org": "example"},
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+	body := mustMarshal(service.NamespaceRequest{Claims: map[string]any{"org": "example"}})
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       []byte
+		setupMock  func(*mocks.MockRegistryService)
+		wantStatus int
+		wantError  string
+	}{
+		{
+			name:   "list namespaces",
+			method: http.MethodGet,
+			path:   "/namespaces",
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListNamespaces(gomock.Any()).Return([]service.NamespaceInfo{*namespace}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "get namespace",
+			method: http.MethodGet,
+			path:   "/namespaces/com.example",
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetNamespace(gomock.Any(), "com.example").Return(namespace, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "get unknown namespace",
+			method: http.MethodGet,
+			path:   "/namespaces/com.unknown",
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetNamespace(gomock.Any(), "com.unknown").
+					Return(nil, fmt.Errorf("%w: com.unknown", service.ErrNamespaceNotFound))
+			},
+			wantStatus: http.StatusNotFound,
+			wantError:  "namespace not found",
+		},
+		{
+			name:   "reserve namespace",
+			method: http.MethodPut,
+			path:   "/namespaces/com.example",
+			body:   body,
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().CreateNamespace(gomock.Any(), "com.example",
+					&service.NamespaceRequest{Claims: map[string]any{"org": "example"}}).Return(namespace, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:   "update reserved namespace",
+			method: http.MethodPut,
+			path:   "/namespaces/com.example",
+			body:   body,
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().CreateNamespace(gomock.Any(), "com.example", gomock.Any()).
+					Return(nil, fmt.Errorf("%w: com.example", service.ErrNamespaceAlreadyExists))
+				m.EXPECT().UpdateNamespace(gomock.Any(), "com.example", gomock.Any()).Return(namespace, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "invalid namespace",
+			method: http.MethodPut,
+			path:   "/namespaces/com.example.",
+			body:   body,
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().CreateNamespace(gomock.Any(), "com.example.", gomock.Any()).
+					Return(nil, fmt.Errorf("%w: namespace 'com.example.' is invalid", service.ErrInvalidNamespace))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid namespace",
+		},
+		{
+			name:       "invalid request body",
+			method:     http.MethodPut,
+			path:       "/namespaces/com.example",
+			body:       []byte("not-json"),
+			setupMock:  func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid request body",
+		},
+		{
+			name:   "release namespace",
+			method: http.MethodDelete,
+			path:   "/namespaces/com.example",
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().DeleteNamespace(gomock.Any(), "com.example").Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:   "release unknown namespace",
+			method: http.MethodDelete,
+			path:   "/namespaces/com.unknown",
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().DeleteNamespace(gomock.Any(), "com.unknown").
+					Return(fmt.Errorf("%w: com.unknown", service.ErrNamespaceNotFound))
+			},
+			wantStatus: http.StatusNotFound,
+			wantError:  "namespace not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			tt.setupMock(mockSvc)
+
+			router := Router(mockSvc, nil)
+			req, err := http.NewRequest(tt.method, tt.path, bytes.NewReader(tt.body))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantError != "" {
+				var response map[string]string
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Contains(t, response["error"], tt.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// listWebhookDeliveries handles GET /v1/notifications/deliveries
+//
+// @Summary		List webhook deliveries
+// @Description	List the deliveries of events to the outbound webhooks, newest first (paginated).
+// @Description	Deliveries older than the configured delivery retention are pruned.
+// @Tags		v1
+// @Produce		json
+// @Param		webhook	query		string	false	"Webhook name"
+// @Param		status	query		string	false	"Delivery status (pending, delivered or failed)"
+// @Param		limit	query		int		false	"Max results (default 30, max 1000)"
+// @Param		cursor	query		string	false	"Pagination cursor"
+// @Success		200		{object}	service.WebhookDeliveryListResponse	"Webhook deliveries"
+// @Failure		400		{object}	map[string]string					"Bad request"
+// @Failure		403		{object}	map[string]string					"Forbidden"
+// @Failure		500		{object}	map[string]string					"Internal server error"
+// @Router		/v1/notifications/deliveries [get]
+func (routes *Routes) listWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var opts []service.Option
+	if webhook := query.Get("webhook"); webhook != "" {
+		opts = append(opts, service.WithWebhook(webhook))
+	}
+	if status := query.Get("status"); status != "" {
+		opts = append(opts, service.WithDeliveryStatus(status))
+	}
+	if cursor := query.Get("cursor"); cursor != "" {
+		opts = append(opts, service.WithCursor(cursor))
+	}
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			common.WriteErrorResponse(w, "Invalid limit parameter: must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		opts = append(opts, service.WithLimit(limit))
+	}
+
+	deliveries, err := routes.service.ListWebhookDeliveries(r.Context(), opts...)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidDeliveryStatus), errors.Is(err, service.ErrInvalidCursor):
+			common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		default:
+			slog.Error("failed to list webhook deliveries", "error", err)
+			common.WriteErrorResponse(w, "failed to list webhook deliveries", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	common.WriteJSONResponse(w, deliveries, http.StatusOK)
+}
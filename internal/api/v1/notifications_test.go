@@ -0,0 +1,137 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+func TestListWebhookDeliveries(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockSvc := mocks.NewMockRegistryService(ctrl)
+	mockSvc.EXPECT().ListWebhookDeliveries(gomock.Any(), gomock.Len(4)).DoAndReturn(
+		func(_ context.Context, opts ...service.Option) (*service.WebhookDeliveryListResponse, error) {
+			options := &service.ListWebhookDeliveriesOptions{}
+			for _, opt := range opts {
+				require.NoError(t, opt(options))
+			}
+			assert.Equal(t, service.ListWebhookDeliveriesOptions{
+				Webhook: "catalog",
+				Status:  service.DeliveryStatusFailed,
+				Cursor:  "abc",
+				Limit:   1,
+			}, *options)
+
+			return &service.WebhookDeliveryListResponse{
+				Deliveries: []service.WebhookDeliveryInfo{
+					{
+						ID:             "0b6f1a52-6a4e-4a53-9d0c-5c1e2f9f7a10",
+						Webhook:        "catalog",
+						EventType:      "entry.publish",
+						Status:         service.DeliveryStatusFailed,
+						Attempts:       8,
+						ResponseStatus: http.StatusInternalServerError,
+						LastError:      "unexpected status 500",
+						CreatedAt:      createdAt,
+						Payload:        json.RawMessage(`{"type":"entry.publish"}`),
+					},
+				},
+				NextCursor: "next",
+			}, nil
+		})
+
+	router := Router(mockSvc, nil)
+	req, err := http.NewRequest("GET", "/notifications/deliveries?webhook=catalog&status=failed&limit=1&cursor=abc", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp service.WebhookDeliveryListResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Len(t, resp.Deliveries, 1)
+	assert.Equal(t, "unexpected status 500", resp.Deliveries[0].LastError)
+	assert.JSONEq(t, `{"type":"entry.publish"}`, string(resp.Deliveries[0].Payload))
+	assert.Equal(t, "next", resp.NextCursor)
+}
+
+func TestListWebhookDeliveriesErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		path           string
+		setupMock      func(*mocks.MockRegistryService)
+		expectedStatus int
+	}{
+		{
+			name:           "invalid limit",
+			path:           "/notifications/deliveries?limit=abc",
+			setupMock:      func(_ *mocks.MockRegistryService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid status",
+			path: "/notifications/deliveries?status=lost",
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListWebhookDeliveries(gomock.Any(), gomock.Any()).
+					Return(nil, service.ErrInvalidDeliveryStatus)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid cursor",
+			path: "/notifications/deliveries?cursor=bogus",
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListWebhookDeliveries(gomock.Any(), gomock.Any()).
+					Return(nil, service.ErrInvalidCursor)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service error",
+			path: "/notifications/deliveries",
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListWebhookDeliveries(gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			tt.setupMock(mockSvc)
+
+			router := Router(mockSvc, nil)
+			req, err := http.NewRequest("GET", tt.path, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// overlayListResponse is the JSON envelope for listing server overlays.
+type overlayListResponse struct {
+	Overlays []service.ServerOverlayInfo `json:"overlays"`
+}
+
+// listServerOverlays handles GET /v1/registries/{name}/overlays
+//
+// @Summary		List server overlays
+// @Description	List the overlays attached to servers in a registry
+// @Tags		v1
+// @Produce		json
+// @Param		name	path		string				true	"Registry Name"
+// @Success		200		{object}	overlayListResponse	"Overlays list"
+// @Failure		400		{object}	map[string]string	"Bad request"
+// @Failure		403		{object}	map[string]string	"Forbidden"
+// @Failure		404		{object}	map[string]string	"Registry not found"
+// @Failure		500		{object}	map[string]string	"Internal server error"
+// @Router		/v1/registries/{name}/overlays [get]
+func (routes *Routes) listServerOverlays(w http.ResponseWriter, r *http.Request) {
+	registryName, err := common.GetAndValidateURLParam(r, "name")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	overlays, err := routes.service.ListServerOverlays(r.Context(), registryName)
+	if err != nil {
+		writeOverlayError(w, err)
+		return
+	}
+
+	common.WriteJSONResponse(w, overlayListResponse{Overlays: overlays}, http.StatusOK)
+}
+
+// getServerOverlay handles GET /v1/registries/{name}/overlays/{serverName}
+//
+// @Summary		Get server overlay
+// @Description	Get the overlay attached to a server in a registry
+// @Tags		v1
+// @Produce		json
+// @Param		name		path		string						true	"Registry Name"
+// @Param		serverName	path		string						true	"URL-encoded server name (e.g., \"com.example%2Fmy-server\")"
+// @Success		200			{object}	service.ServerOverlayInfo	"Overlay details"
+// @Failure		400			{object}	map[string]string			"Bad request"
+// @Failure		403			{object}	map[string]string			"Forbidden"
+// @Failure		404			{object}	map[string]string			"Registry or overlay not found"
+// @Failure		500			{object}	map[string]string			"Internal server error"
+// @Router		/v1/registries/{name}/overlays/{serverName} [get]
+func (routes *Routes) getServerOverlay(w http.ResponseWriter, r *http.Request) {
+	registryName, serverName, ok := overlayParams(w, r)
+	if !ok {
+		return
+	}
+
+	overlay, err := routes.service.GetServerOverlay(r.Context(), registryName, serverName)
+	if err != nil {
+		writeOverlayError(w, err)
+		return
+	}
+
+	common.WriteJSONResponse(w, overlay, http.StatusOK)
+}
+
+// upsertServerOverlay handles PUT /v1/registries/{name}/overlays/{serverName}
+//
+// @Summary		Create or replace server overlay
+// @Description	Attach an overlay to a server in a registry, or replace the existing one.
+// @Description	The overlay applies to every version of the server the registry serves and is kept across syncs.
+// @Tags		v1
+// @Accept		json
+// @Produce		json
+// @Param		name		path		string							true	"Registry Name"
+// @Param		serverName	path		string							true	"URL-encoded server name (e.g., \"com.example%2Fmy-server\")"
+// @Param		request		body		service.ServerOverlayRequest	true	"Server overlay"
+// @Success		200			{object}	service.ServerOverlayInfo		"Overlay replaced"
+// @Success		201			{object}	service.ServerOverlayInfo		"Overlay created"
+// @Failure		400			{object}	map[string]string				"Bad request"
+// @Failure		403			{object}	map[string]string				"Forbidden"
+// @Failure		404			{object}	map[string]string				"Registry not found"
+// @Failure		500			{object}	map[string]string				"Internal server error"
+// @Router		/v1/registries/{name}/overlays/{serverName} [put]
+func (routes *Routes) upsertServerOverlay(w http.ResponseWriter, r *http.Request) {
+	registryName, serverName, ok := overlayParams(w, r)
+	if !ok {
+		return
+	}
+
+	var req service.ServerOverlayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteErrorResponse(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Try create first
+	overlay, err := routes.service.CreateServerOverlay(r.Context(), registryName, serverName, &req)
+	if err == nil {
+		common.WriteJSONResponse(w, overlay, http.StatusCreated)
+		return
+	}
+
+	// If it already exists, try update
+	if errors.Is(err, service.ErrOverlayAlreadyExists) {
+		overlay, err = routes.service.UpdateServerOverlay(r.Context(), registryName, serverName, &req)
+		if err != nil {
+			writeOverlayError(w, err)
+			return
+		}
+		common.WriteJSONResponse(w, overlay, http.StatusOK)
+		return
+	}
+
+	writeOverlayError(w, err)
+}
+
+// deleteServerOverlay handles DELETE /v1/registries/{name}/overlays/{serverName}
+//
+// @Summary		Delete server overlay
+// @Description	Remove the overlay attached to a server in a registry
+// @Tags		v1
+// @Produce		json
+// @Param		name		path	string	true	"Registry Name"
+// @Param		serverName	path	string	true	"URL-encoded server name (e.g., \"com.example%2Fmy-server\")"
+// @Success		204	"Overlay deleted"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		403	{object}	map[string]string	"Forbidden"
+// @Failure		404	{object}	map[string]string	"Registry or overlay not found"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Router		/v1/registries/{name}/overlays/{serverName} [delete]
+func (routes *Routes) deleteServerOverlay(w http.ResponseWriter, r *http.Request) {
+	registryName, serverName, ok := overlayParams(w, r)
+	if !ok {
+		return
+	}
+
+	if err := routes.service.DeleteServerOverlay(r.Context(), registryName, serverName); err != nil {
+		writeOverlayError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// overlayParams reads the registry and server names of an overlay route,
+// writing a 400 response when either is invalid.
+func overlayParams(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	registryName, err := common.GetAndValidateURLParam(r, "name")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return "", "", false
+	}
+	serverName, err := common.GetAndValidateServerNameParam(r, "serverName")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return "", "", false
+	}
+	return registryName, serverName, true
+}
+
+// writeOverlayError maps service-layer overlay errors to HTTP responses,
+// deferring to writeRegistryError for errors about the registry itself.
+func writeOverlayError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrOverlayNotFound):
+		common.WriteErrorResponse(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, service.ErrInvalidOverlay):
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrOverlayAlreadyExists):
+		common.WriteErrorResponse(w, err.Error(), http.StatusConflict)
+	default:
+		writeRegistryError(w, err)
+	}
+}
@@ -0,0 +1,192 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+func TestOverlayRoutes(t *testing.T) {
+	t.Parallel()
+
+	const serverName = "com.example/server"
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	overlay := &service.ServerOverlayInfo{
+		Name:      serverName,
+		Title:     "Example Server",
+		Tags:      []string{"approved"},
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+	request := &service.ServerOverlayRequest{Title: "Example Server", Tags: []string{"approved"}}
+	body := mustMarshal(request)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       []byte
+		setupMock  func(*mocks.MockRegistryService)
+		wantStatus int
+		wantError  string
+	}{
+		{
+			name:   "list overlays",
+			method: http.MethodGet,
+			path:   "/registries/internal/overlays",
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListServerOverlays(gomock.Any(), "internal").Return([]service.ServerOverlayInfo{*overlay}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "list overlays of unknown registry",
+			method: http.MethodGet,
+			path:   "/registries/unknown/overlays",
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListServerOverlays(gomock.Any(), "unknown").
+					Return(nil, fmt.Errorf("%w: unknown", service.ErrRegistryNotFound))
+			},
+			wantStatus: http.StatusNotFound,
+			wantError:  "registry not found",
+		},
+		{
+			name:   "get overlay",
+			method: http.MethodGet,
+			path:   "/registries/internal/overlays/com.example%2Fserver",
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerOverlay(gomock.Any(), "internal", serverName).Return(overlay, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "get unknown overlay",
+			method: http.MethodGet,
+			path:   "/registries/internal/overlays/com.example%2Funknown",
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerOverlay(gomock.Any(), "internal", "com.example/unknown").
+					Return(nil, fmt.Errorf("%w: com.example/unknown", service.ErrOverlayNotFound))
+			},
+			wantStatus: http.StatusNotFound,
+			wantError:  "overlay not found",
+		},
+		{
+			name:       "invalid server name",
+			method:     http.MethodGet,
+			path:       "/registries/internal/overlays/server",
+			setupMock:  func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid server name format",
+		},
+		{
+			name:   "create overlay",
+			method: http.MethodPut,
+			path:   "/registries/internal/overlays/com.example%2Fserver",
+			body:   body,
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().CreateServerOverlay(gomock.Any(), "internal", serverName, request).Return(overlay, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:   "replace overlay",
+			method: http.MethodPut,
+			path:   "/registries/internal/overlays/com.example%2Fserver",
+			body:   body,
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().CreateServerOverlay(gomock.Any(), "internal", serverName, gomock.Any()).
+					Return(nil, fmt.Errorf("%w: %s", service.ErrOverlayAlreadyExists, serverName))
+				m.EXPECT().UpdateServerOverlay(gomock.Any(), "internal", serverName, gomock.Any()).Return(overlay, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "invalid overlay",
+			method: http.MethodPut,
+			path:   "/registries/internal/overlays/com.example%2Fserver",
+			body:   body,
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().CreateServerOverlay(gomock.Any(), "internal", serverName, gomock.Any()).
+					Return(nil, fmt.Errorf("%w: tags[0] cannot be empty", service.ErrInvalidOverlay))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid overlay",
+		},
+		{
+			name:   "overlay on registry outside caller claims",
+			method: http.MethodPut,
+			path:   "/registries/internal/overlays/com.example%2Fserver",
+			body:   body,
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().CreateServerOverlay(gomock.Any(), "internal", serverName, gomock.Any()).
+					Return(nil, service.ErrClaimsInsufficient)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "invalid request body",
+			method:     http.MethodPut,
+			path:       "/registries/internal/overlays/com.example%2Fserver",
+			body:       []byte("not-json"),
+			setupMock:  func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid request body",
+		},
+		{
+			name:   "delete overlay",
+			method: http.MethodDelete,
+			path:   "/registries/internal/overlays/com.example%2Fserver",
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().DeleteServerOverlay(gomock.Any(), "internal", serverName).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:   "delete unknown overlay",
+			method: http.MethodDelete,
+			path:   "/registries/internal/overlays/com.example%2Fserver",
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().DeleteServerOverlay(gomock.Any(), "internal", serverName).
+					Return(fmt.Errorf("%w: %s", service.ErrOverlayNotFound, serverName))
+			},
+			wantStatus: http.StatusNotFound,
+			wantError:  "overlay not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			tt.setupMock(mockSvc)
+
+			router := Router(mockSvc, nil)
+			req, err := http.NewRequest(tt.method, tt.path, bytes.NewReader(tt.body))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantError != "" {
+				var response map[string]string
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Contains(t, response["error"], tt.wantError)
+			}
+		})
+	}
+}
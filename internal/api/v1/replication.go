@@ -0,0 +1,117 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/replication"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// exportRegistryBundle handles GET /v1/registries/{name}/bundle
+//
+// @Summary		Export registry bundle
+// @Description	Export the server, skill and plugin versions a registry serves, with the claims of their entries,
+// @Description	as a bundle file signed with the replication signing key, to be imported by a bundle source.
+// @Description	The ID of the bundle is a change token of the registry: with since set to the ID of a previous
+// @Description	bundle, a delta bundle is exported holding the versions changed after it and the versions no
+// @Description	longer served.
+// @Tags		v1
+// @Produce		json
+// @Param		name	path		string				true	"Registry Name"
+// @Param		since	query		string				false	"ID of the bundle to export a delta bundle since"
+// @Success		200		{object}	map[string]any		"Signed bundle file"
+// @Failure		400		{object}	map[string]string	"Bad request"
+// @Failure		403		{object}	map[string]string	"Insufficient claims"
+// @Failure		404		{object}	map[string]string	"Registry not found"
+// @Failure		410		{object}	map[string]string	"Changes after since are no longer retained"
+// @Failure		500		{object}	map[string]string	"Internal server error"
+// @Failure		501		{object}	map[string]string	"Replication is not configured"
+// @Router		/v1/registries/{name}/bundle [get]
+func (routes *Routes) exportRegistryBundle(w http.ResponseWriter, r *http.Request) {
+	name, err := common.GetAndValidateURLParam(r, "name")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if routes.bundleSigner == nil {
+		common.WriteErrorResponse(w, "replication is not configured", http.StatusNotImplemented)
+		return
+	}
+	since := strings.TrimSpace(r.URL.Query().Get("since"))
+
+	export, err := routes.service.ExportRegistry(r.Context(), name, since)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidChangeToken):
+			common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, service.ErrChangeTokenExpired):
+			common.WriteErrorResponse(w, err.Error(), http.StatusGone)
+		default:
+			writeRegistryError(w, err)
+		}
+		return
+	}
+
+	bundle := newBundle(name, since, export)
+	data, err := replication.Seal(bundle, routes.bundleSigner)
+	if err != nil {
+		writeRegistryError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%s.json"`, name, bundle.ID))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// newBundle builds the bundle of a registry export. The claims of the entries
+// are carried once per entry rather than in the _meta of their versions.
+func newBundle(registryName, since string, export *service.RegistryExport) *replication.Bundle {
+	bundle := &replication.Bundle{
+		Version:   replication.FormatVersion,
+		ID:        export.ChangeToken,
+		BaseID:    since,
+		Registry:  registryName,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	withClaims := make(map[replication.EntryRef]bool)
+	for i := range export.Entries {
+		entry := export.Entries[i]
+		claims := entry.Claims
+		entry.Claims = nil
+
+		ref := replication.EntryRef{Type: entry.EntryType()}
+		switch {
+		case entry.Server != nil:
+			ref.Name = entry.Server.Name
+			bundle.Servers = append(bundle.Servers, *entry.Server)
+		case entry.Skill != nil:
+			ref.Name = entry.Skill.Name
+			bundle.Skills = append(bundle.Skills, *entry.UpstreamSkill())
+		default:
+			ref.Name = entry.Plugin.Name
+			bundle.Plugins = append(bundle.Plugins, *entry.UpstreamPlugin())
+		}
+
+		if len(claims) > 0 && !withClaims[ref] {
+			withClaims[ref] = true
+			bundle.Claims = append(bundle.Claims, replication.EntryClaims{Type: ref.Type, Name: ref.Name, Claims: claims})
+		}
+	}
+
+	for _, change := range export.Deleted {
+		bundle.Deleted = append(bundle.Deleted, replication.EntryRef{
+			Type:    change.EntryType,
+			Name:    change.Name,
+			Version: change.Version,
+		})
+	}
+	return bundle
+}
@@ -0,0 +1,167 @@
+package v1
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/replication"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+	"github.com/stacklok/toolhive-registry-server/internal/signing"
+)
+
+func TestExportRegistryBundle(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	signer, err := signing.NewSigner(string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})))
+	require.NoError(t, err)
+	der, err = x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	verifier, err := replication.NewVerifier([]string{string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))})
+	require.NoError(t, err)
+
+	export := &service.RegistryExport{
+		ChangeToken: "42",
+		Entries: []service.PortableEntry{
+			{
+				Server: &upstreamv0.ServerJSON{Name: "com.example/server", Version: "1.0.0"},
+				Claims: map[string]any{"org": "acme"},
+			},
+			{
+				Server: &upstreamv0.ServerJSON{Name: "com.example/server", Version: "2.0.0"},
+				Claims: map[string]any{"org": "acme"},
+			},
+			{
+				Skill: &service.Skill{Namespace: "io.example", Name: "calendar", Version: "1.0.0"},
+			},
+		},
+		Deleted: []*service.EntryChange{
+			{Token: "40", Type: service.ChangeTypeDeleted, EntryType: service.EntryTypePlugin, Name: "deploy", Version: "1.0.0"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		signer     *signing.Signer
+		setupMock  func(*mocks.MockRegistryService)
+		wantStatus int
+		wantBundle func(t *testing.T, bundle *replication.Bundle)
+	}{
+		{
+			name:   "full bundle",
+			path:   "/registries/central/bundle",
+			signer: signer,
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ExportRegistry(gomock.Any(), "central", "").Return(export, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBundle: func(t *testing.T, bundle *replication.Bundle) {
+				t.Helper()
+				assert.Equal(t, "42", bundle.ID)
+				assert.False(t, bundle.IsDelta())
+				assert.Equal(t, "central", bundle.Registry)
+				assert.Len(t, bundle.Servers, 2)
+				require.Len(t, bundle.Skills, 1)
+				assert.Nil(t, bundle.Skills[0].Meta)
+				assert.Equal(t, []replication.EntryClaims{
+					{Type: replication.EntryTypeServer, Name: "com.example/server", Claims: map[string]any{"org": "acme"}},
+				}, bundle.Claims)
+			},
+		},
+		{
+			name:   "delta bundle",
+			path:   "/registries/central/bundle?since=30",
+			signer: signer,
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ExportRegistry(gomock.Any(), "central", "30").Return(export, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBundle: func(t *testing.T, bundle *replication.Bundle) {
+				t.Helper()
+				assert.Equal(t, "30", bundle.BaseID)
+				assert.Equal(t, []replication.EntryRef{
+					{Type: replication.EntryTypePlugin, Name: "deploy", Version: "1.0.0"},
+				}, bundle.Deleted)
+			},
+		},
+		{
+			name:       "replication not configured",
+			path:       "/registries/central/bundle",
+			setupMock:  func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusNotImplemented,
+		},
+		{
+			name:   "unknown registry",
+			path:   "/registries/unknown/bundle",
+			signer: signer,
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ExportRegistry(gomock.Any(), "unknown", "").
+					Return(nil, fmt.Errorf("%w: unknown", service.ErrRegistryNotFound))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "invalid since",
+			path:   "/registries/central/bundle?since=abc",
+			signer: signer,
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ExportRegistry(gomock.Any(), "central", "abc").
+					Return(nil, fmt.Errorf("%w: abc", service.ErrInvalidChangeToken))
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "expired since",
+			path:   "/registries/central/bundle?since=1",
+			signer: signer,
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ExportRegistry(gomock.Any(), "central", "1").
+					Return(nil, fmt.Errorf("%w: changes after 1 are no longer retained", service.ErrChangeTokenExpired))
+			},
+			wantStatus: http.StatusGone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			tt.setupMock(mockSvc)
+
+			router := Router(mockSvc, nil, WithBundleSigner(tt.signer))
+			req, err := http.NewRequest(http.MethodGet, tt.path, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantBundle != nil {
+				assert.Contains(t, rr.Header().Get("Content-Disposition"), "central-42.json")
+				bundle, err := replication.Open(rr.Body.Bytes(), verifier)
+				require.NoError(t, err)
+				tt.wantBundle(t, bundle)
+			}
+		})
+	}
+}
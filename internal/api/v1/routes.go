@@ -10,12 +10,14 @@ import (
 	"github.com/stacklok/toolhive-registry-server/internal/auth"
 	"github.com/stacklok/toolhive-registry-server/internal/config"
 	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/signing"
 )
 
 // Routes handles HTTP requests for API v1 endpoints.
 type Routes struct {
 	service      service.RegistryService
 	authzEnabled bool
+	bundleSigner *signing.Signer
 }
 
 // NewRoutes creates a new Routes instance with the given service.
@@ -29,17 +31,31 @@ func NewRoutes(svc service.RegistryService, authzEnabled bool) *Routes {
 	}
 }
 
+// RouterOption configures the routes of the v1 router
+type RouterOption func(*Routes)
+
+// WithBundleSigner sets the signer of the bundles exported for replication.
+// Without it, bundle exports are refused.
+func WithBundleSigner(signer *signing.Signer) RouterOption {
+	return func(routes *Routes) {
+		routes.bundleSigner = signer
+	}
+}
+
 // Router creates and configures the HTTP router for API v1 endpoints.
 // authCfg is the full authentication configuration; its Authz subtree drives
 // role checks and publish-time claim requirements. A nil authCfg means no
 // auth is configured (development).
-func Router(svc service.RegistryService, authCfg *config.AuthConfig) http.Handler {
+func Router(svc service.RegistryService, authCfg *config.AuthConfig, opts ...RouterOption) http.Handler {
 	var authzCfg *config.AuthzConfig
 	if authCfg != nil {
 		authzCfg = authCfg.Authz
 	}
 	authzEnabled := authzCfg != nil
 	routes := NewRoutes(svc, authzEnabled)
+	for _, opt := range opts {
+		opt(routes)
+	}
 
 	r := chi.NewRouter()
 
@@ -61,6 +77,16 @@ func Router(svc service.RegistryService, authCfg *config.AuthConfig) http.Handle
 		r.Get("/sources/{name}/entries",
 			auditmw.Audited(auditmw.EventSourceEntriesList, auditmw.ResourceTypeSource, "name",
 				routes.listSourceEntries))
+		r.Get("/sources/{name}/export",
+			auditmw.Audited(auditmw.EventSourceExport, auditmw.ResourceTypeSource, "name", routes.exportSource))
+		r.Post("/sources/{name}/sync",
+			auditmw.Audited(auditmw.EventSourceSync, auditmw.ResourceTypeSource, "name", routes.requestSourceSync))
+		r.Get("/sources/{name}/sync/{id}",
+			auditmw.Audited(auditmw.EventSourceSyncRead, auditmw.ResourceTypeSource, "name",
+				routes.getSourceSyncRequest))
+		r.Get("/sources/{name}/syncs",
+			auditmw.Audited(auditmw.EventSourceSyncsList, auditmw.ResourceTypeSource, "name",
+				routes.listSourceSyncs))
 	})
 
 	// Registry read endpoints — authenticated only (no role requirement).
@@ -77,12 +103,27 @@ func Router(svc service.RegistryService, authCfg *config.AuthConfig) http.Handle
 		r.Get("/registries/{name}/entries",
 			auditmw.Audited(auditmw.EventRegistryEntriesList, auditmw.ResourceTypeRegistry, "name",
 				routes.listRegistryEntries))
+		r.Get("/registries/{name}/bundle",
+			auditmw.Audited(auditmw.EventRegistryExport, auditmw.ResourceTypeRegistry, "name",
+				routes.exportRegistryBundle))
 		r.Put("/registries/{name}",
 			auditmw.AuditedUpsert(auditmw.EventRegistryCreate, auditmw.EventRegistryUpdate,
 				auditmw.ResourceTypeRegistry, "name", routes.upsertRegistry))
 		r.Delete("/registries/{name}",
 			auditmw.Audited(auditmw.EventRegistryDelete, auditmw.ResourceTypeRegistry, "name",
 				routes.deleteRegistry))
+		r.Get("/registries/{name}/overlays",
+			auditmw.Audited(auditmw.EventOverlayList, auditmw.ResourceTypeRegistry, "name",
+				routes.listServerOverlays))
+		r.Get("/registries/{name}/overlays/{serverName}",
+			auditmw.Audited(auditmw.EventOverlayRead, auditmw.ResourceTypeRegistry, "name",
+				routes.getServerOverlay))
+		r.Put("/registries/{name}/overlays/{serverName}",
+			auditmw.AuditedUpsert(auditmw.EventOverlayCreate, auditmw.EventOverlayUpdate,
+				auditmw.ResourceTypeRegistry, "name", routes.upsertServerOverlay))
+		r.Delete("/registries/{name}/overlays/{serverName}",
+			auditmw.Audited(auditmw.EventOverlayDelete, auditmw.ResourceTypeRegistry, "name",
+				routes.deleteServerOverlay))
 	})
 
 	// Entry endpoints — require manageEntries role
@@ -90,13 +131,49 @@ func Router(svc service.RegistryService, authCfg *config.AuthConfig) http.Handle
 		r.Use(auth.RequireRole(auth.RoleManageEntries, authzCfg))
 		r.Post("/entries",
 			auditmw.Audited(auditmw.EventEntryPublish, auditmw.ResourceTypeEntry, "", routes.publishEntry))
+		r.Post("/entries:bulk",
+			auditmw.Audited(auditmw.EventEntryBulkPublish, auditmw.ResourceTypeEntry, "", routes.bulkPublishEntries))
 		r.Delete("/entries/{type}/{name}/versions/{version}",
 			auditmw.AuditedEntry(auditmw.EventEntryDelete, routes.deletePublishedEntry))
+		r.Put("/entries/{type}/{name}/versions/{version}/status",
+			auditmw.AuditedEntry(auditmw.EventEntryStatus, routes.updateEntryVersionStatus))
 		r.Get("/entries/{type}/{name}/claims",
 			auditmw.AuditedEntry(auditmw.EventEntryClaimsRead, routes.getEntryClaims))
 		r.Put("/entries/{type}/{name}/claims",
 			auditmw.AuditedEntry(auditmw.EventEntryClaims, routes.updateEntryClaims))
 	})
 
+	// Submission review endpoints — require reviewEntries role
+	r.Group(func(r chi.Router) {
+		r.Use(auth.RequireRole(auth.RoleReviewEntries, authzCfg))
+		r.Get("/submissions",
+			auditmw.Audited(auditmw.EventSubmissionList, auditmw.ResourceTypeSubmission, "",
+				routes.listEntrySubmissions))
+		r.Post("/submissions/{id}/approve",
+			auditmw.Audited(auditmw.EventSubmissionApprove, auditmw.ResourceTypeSubmission, "id",
+				routes.approveEntrySubmission))
+		r.Post("/submissions/{id}/reject",
+			auditmw.Audited(auditmw.EventSubmissionReject, auditmw.ResourceTypeSubmission, "id",
+				routes.rejectEntrySubmission))
+	})
+
+	// Webhook delivery log and namespace reservations — require superAdmin role
+	r.Group(func(r chi.Router) {
+		r.Use(auth.RequireRole(auth.RoleSuperAdmin, authzCfg))
+		r.Get("/notifications/deliveries",
+			auditmw.Audited(auditmw.EventWebhookDeliveryList, auditmw.ResourceTypeWebhook, "",
+				routes.listWebhookDeliveries))
+		r.Get("/namespaces",
+			auditmw.Audited(auditmw.EventNamespaceList, auditmw.ResourceTypeNamespace, "", routes.listNamespaces))
+		r.Get("/namespaces/{name}",
+			auditmw.Audited(auditmw.EventNamespaceRead, auditmw.ResourceTypeNamespace, "name", routes.getNamespace))
+		r.Put("/namespaces/{name}",
+			auditmw.AuditedUpsert(auditmw.EventNamespaceCreate, auditmw.EventNamespaceUpdate,
+				auditmw.ResourceTypeNamespace, "name", routes.upsertNamespace))
+		r.Delete("/namespaces/{name}",
+			auditmw.Audited(auditmw.EventNamespaceDelete, auditmw.ResourceTypeNamespace, "name",
+				routes.deleteNamespace))
+	})
+
 	return r
 }
@@ -221,6 +221,216 @@ func TestDeleteSourceInUse(t *testing.T) {
 	assert.Equal(t, http.StatusConflict, rr.Code)
 }
 
+func TestRequestSourceSync(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	requestedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockSvc := mocks.NewMockRegistryService(ctrl)
+	mockSvc.EXPECT().RequestSourceSync(gomock.Any(), "my-source").Return(&service.SyncRequestInfo{
+		ID:          "5b2f3c1e-8a4d-4f6b-9c7e-2d1a0b3c4d5e",
+		SourceName:  "my-source",
+		Status:      "pending",
+		RequestedAt: requestedAt,
+	}, nil)
+
+	router := Router(mockSvc, nil)
+	req, err := http.NewRequest("POST", "/sources/my-source/sync", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusAccepted, rr.Code)
+	var resp service.SyncRequestInfo
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, "5b2f3c1e-8a4d-4f6b-9c7e-2d1a0b3c4d5e", resp.ID)
+	assert.Equal(t, "pending", resp.Status)
+}
+
+func TestRequestSourceSyncErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "source not found", err: service.ErrSourceNotFound, wantStatus: http.StatusNotFound},
+		{name: "source not syncable", err: service.ErrSourceNotSyncable, wantStatus: http.StatusBadRequest},
+		{name: "unexpected error", err: errors.New("boom"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			mockSvc.EXPECT().RequestSourceSync(gomock.Any(), "my-source").Return(nil, tt.err)
+
+			router := Router(mockSvc, nil)
+			req, err := http.NewRequest("POST", "/sources/my-source/sync", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
+
+func TestGetSourceSyncRequest(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	endedAt := time.Date(2025, 1, 1, 0, 5, 0, 0, time.UTC)
+	mockSvc := mocks.NewMockRegistryService(ctrl)
+	mockSvc.EXPECT().GetSourceSyncRequest(gomock.Any(), "my-source", "abc").Return(&service.SyncRequestInfo{
+		ID:         "abc",
+		SourceName: "my-source",
+		Status:     "complete",
+		EndedAt:    &endedAt,
+	}, nil)
+
+	router := Router(mockSvc, nil)
+	req, err := http.NewRequest("GET", "/sources/my-source/sync/abc", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp service.SyncRequestInfo
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, "complete", resp.Status)
+}
+
+func TestGetSourceSyncRequestNotFound(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockSvc := mocks.NewMockRegistryService(ctrl)
+	mockSvc.EXPECT().GetSourceSyncRequest(gomock.Any(), "my-source", "missing").
+		Return(nil, service.ErrSyncRequestNotFound)
+
+	router := Router(mockSvc, nil)
+	req, err := http.NewRequest("GET", "/sources/my-source/sync/missing", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestListSourceSyncs(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	startedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockSvc := mocks.NewMockRegistryService(ctrl)
+	mockSvc.EXPECT().ListSourceSyncs(gomock.Any(), "my-source", gomock.Len(2)).Return(&service.SourceSyncListResponse{
+		Syncs: []service.SourceSyncInfo{
+			{
+				ID:          "abc",
+				Status:      "complete",
+				Reason:      "source-data-changed",
+				ServerCount: 3,
+				Changes: service.SyncChangesInfo{
+					Servers: service.EntryChangesInfo{Added: 1, Updated: 2},
+				},
+				StartedAt: startedAt,
+				EndedAt:   startedAt.Add(time.Minute),
+			},
+		},
+		NextCursor: "next",
+	}, nil)
+
+	router := Router(mockSvc, nil)
+	req, err := http.NewRequest("GET", "/sources/my-source/syncs?limit=1&cursor=abc", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp service.SourceSyncListResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Len(t, resp.Syncs, 1)
+	assert.Equal(t, "source-data-changed", resp.Syncs[0].Reason)
+	assert.Equal(t, 2, resp.Syncs[0].Changes.Servers.Updated)
+	assert.Equal(t, "next", resp.NextCursor)
+}
+
+func TestListSourceSyncsErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		path           string
+		setupMock      func(*mocks.MockRegistryService)
+		expectedStatus int
+	}{
+		{
+			name:           "invalid limit",
+			path:           "/sources/my-source/syncs?limit=abc",
+			setupMock:      func(_ *mocks.MockRegistryService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "zero limit",
+			path:           "/sources/my-source/syncs?limit=0",
+			setupMock:      func(_ *mocks.MockRegistryService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid cursor",
+			path: "/sources/my-source/syncs?cursor=bogus",
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListSourceSyncs(gomock.Any(), "my-source", gomock.Any()).
+					Return(nil, service.ErrInvalidCursor)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "source not found",
+			path: "/sources/missing/syncs",
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListSourceSyncs(gomock.Any(), "missing").
+					Return(nil, service.ErrSourceNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			tt.setupMock(mockSvc)
+
+			router := Router(mockSvc, nil)
+			req, err := http.NewRequest("GET", tt.path, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
+
 func TestListRegistries(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
@@ -432,6 +642,20 @@ func TestV1URLParamValidation(t *testing.T) {
 			wantStatus: http.StatusBadRequest,
 			wantError:  "name cannot be empty",
 		},
+		{
+			name:       "request source sync - empty name",
+			method:     "POST",
+			path:       "/sources/%20/sync",
+			wantStatus: http.StatusBadRequest,
+			wantError:  "name cannot be empty",
+		},
+		{
+			name:       "get source sync request - empty id",
+			method:     "GET",
+			path:       "/sources/my-source/sync/%20",
+			wantStatus: http.StatusBadRequest,
+			wantError:  "id cannot be empty",
+		},
 		// Source name validation - whitespace
 		{
 			name:       "get source - whitespace in name",
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/stacklok/toolhive-registry-server/internal/api/common"
 	"github.com/stacklok/toolhive-registry-server/internal/service"
@@ -165,6 +166,115 @@ func (routes *Routes) listSourceEntries(w http.ResponseWriter, r *http.Request)
 	common.WriteJSONResponse(w, service.SourceEntriesResponse{Entries: entries}, http.StatusOK)
 }
 
+// requestSourceSync handles POST /v1/sources/{name}/sync
+//
+// @Summary		Request source sync
+// @Description	Queue a manual sync for a source. The sync runs on the next coordinator
+// @Description	poll regardless of the sync schedule. Poll the returned sync request by ID
+// @Description	to see the outcome. A request that is still pending is returned as is.
+// @Tags		v1
+// @Produce		json
+// @Param		name	path		string					true	"Source Name"
+// @Success		202		{object}	service.SyncRequestInfo	"Sync requested"
+// @Failure		400		{object}	map[string]string		"Bad request or source is not syncable"
+// @Failure		404		{object}	map[string]string		"Source not found"
+// @Failure		500		{object}	map[string]string		"Internal server error"
+// @Router		/v1/sources/{name}/sync [post]
+func (routes *Routes) requestSourceSync(w http.ResponseWriter, r *http.Request) {
+	name, err := common.GetAndValidateURLParam(r, "name")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	request, err := routes.service.RequestSourceSync(r.Context(), name)
+	if err != nil {
+		writeSourceError(w, err)
+		return
+	}
+
+	common.WriteJSONResponse(w, request, http.StatusAccepted)
+}
+
+// getSourceSyncRequest handles GET /v1/sources/{name}/sync/{id}
+//
+// @Summary		Get source sync request
+// @Description	Get the status of a manual sync request for a source
+// @Tags		v1
+// @Produce		json
+// @Param		name	path		string					true	"Source Name"
+// @Param		id		path		string					true	"Sync request ID"
+// @Success		200		{object}	service.SyncRequestInfo	"Sync request"
+// @Failure		400		{object}	map[string]string		"Bad request"
+// @Failure		404		{object}	map[string]string		"Source or sync request not found"
+// @Failure		500		{object}	map[string]string		"Internal server error"
+// @Router		/v1/sources/{name}/sync/{id} [get]
+func (routes *Routes) getSourceSyncRequest(w http.ResponseWriter, r *http.Request) {
+	name, err := common.GetAndValidateURLParam(r, "name")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	id, err := common.GetAndValidateURLParam(r, "id")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	request, err := routes.service.GetSourceSyncRequest(r.Context(), name, id)
+	if err != nil {
+		writeSourceError(w, err)
+		return
+	}
+
+	common.WriteJSONResponse(w, request, http.StatusOK)
+}
+
+// listSourceSyncs handles GET /v1/sources/{name}/syncs
+//
+// @Summary		List source sync history
+// @Description	List the recorded sync attempts of a source, newest first (paginated).
+// @Description	Attempts older than the configured sync history retention are pruned.
+// @Tags		v1
+// @Produce		json
+// @Param		name	path		string	true	"Source Name"
+// @Param		limit	query		int		false	"Max results (default 30, max 1000)"
+// @Param		cursor	query		string	false	"Pagination cursor"
+// @Success		200		{object}	service.SourceSyncListResponse	"Sync history"
+// @Failure		400		{object}	map[string]string				"Bad request"
+// @Failure		404		{object}	map[string]string				"Source not found"
+// @Failure		500		{object}	map[string]string				"Internal server error"
+// @Router		/v1/sources/{name}/syncs [get]
+func (routes *Routes) listSourceSyncs(w http.ResponseWriter, r *http.Request) {
+	name, err := common.GetAndValidateURLParam(r, "name")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+	var opts []service.Option
+	if cursor := query.Get("cursor"); cursor != "" {
+		opts = append(opts, service.WithCursor(cursor))
+	}
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			common.WriteErrorResponse(w, "Invalid limit parameter: must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		opts = append(opts, service.WithLimit(limit))
+	}
+
+	syncs, err := routes.service.ListSourceSyncs(r.Context(), name, opts...)
+	if err != nil {
+		writeSourceError(w, err)
+		return
+	}
+
+	common.WriteJSONResponse(w, syncs, http.StatusOK)
+}
+
 // writeSourceError maps service-layer source errors to HTTP responses.
 func writeSourceError(w http.ResponseWriter, err error) {
 	switch {
@@ -182,6 +292,12 @@ func writeSourceError(w http.ResponseWriter, err error) {
 		common.WriteErrorResponse(w, err.Error(), http.StatusConflict)
 	case errors.Is(err, service.ErrManagedSourceLimitReached):
 		common.WriteErrorResponse(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, service.ErrSourceNotSyncable):
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrSyncRequestNotFound):
+		common.WriteErrorResponse(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, service.ErrInvalidCursor):
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
 	default:
 		slog.Error("unexpected source error", "error", err)
 		common.WriteErrorResponse(w, "internal server error", http.StatusInternalServerError)
@@ -0,0 +1,195 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/auth"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// reviewSubmissionRequest is the request body for approving or rejecting a submission.
+type reviewSubmissionRequest struct {
+	// Comment is recorded with the decision. It is required to reject a submission.
+	Comment string `json:"comment,omitempty"`
+}
+
+// listEntrySubmissions handles GET /v1/submissions
+//
+// @Summary		List entry submissions
+// @Description	List the publishes held for review by the managed source, oldest first (paginated).
+// @Description	Only pending submissions are listed unless another status is requested.
+// @Tags		v1
+// @Produce		json
+// @Param		status	query		string	false	"Submission status (pending, approved or rejected; default pending)"
+// @Param		type	query		string	false	"Entry type (server, skill or plugin)"
+// @Param		limit	query		int		false	"Max results (default 30, max 1000)"
+// @Param		cursor	query		string	false	"Pagination cursor"
+// @Success		200		{object}	service.EntrySubmissionListResponse	"Entry submissions"
+// @Failure		400		{object}	map[string]string					"Bad request"
+// @Failure		403		{object}	map[string]string					"Forbidden"
+// @Failure		500		{object}	map[string]string					"Internal server error"
+// @Router		/v1/submissions [get]
+func (routes *Routes) listEntrySubmissions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	status := query.Get("status")
+	if status == "" {
+		status = service.SubmissionStatusPending
+	}
+	opts := []service.Option{service.WithSubmissionStatus(status)}
+	if entryType := query.Get("type"); entryType != "" {
+		opts = append(opts, service.WithEntryType(entryType))
+	}
+	if cursor := query.Get("cursor"); cursor != "" {
+		opts = append(opts, service.WithCursor(cursor))
+	}
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			common.WriteErrorResponse(w, "Invalid limit parameter: must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		opts = append(opts, service.WithLimit(limit))
+	}
+	if jwtClaims := auth.ClaimsFromContext(r.Context()); jwtClaims != nil {
+		opts = append(opts, service.WithJWTClaims(map[string]any(jwtClaims)))
+	}
+
+	submissions, err := routes.service.ListEntrySubmissions(r.Context(), opts...)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidSubmissionStatus),
+			errors.Is(err, service.ErrInvalidEntryType),
+			errors.Is(err, service.ErrInvalidCursor):
+			common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		default:
+			slog.ErrorContext(r.Context(), "failed to list entry submissions", "error", err)
+			common.WriteErrorResponse(w, "failed to list entry submissions", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	common.WriteJSONResponse(w, submissions, http.StatusOK)
+}
+
+// approveEntrySubmission handles POST /v1/submissions/{id}/approve
+//
+// @Summary		Approve entry submission
+// @Description	Approve a pending submission, publishing its entry into the managed source
+// @Tags		v1
+// @Accept		json
+// @Produce		json
+// @Param		id		path		string					true	"Submission ID"
+// @Param		request	body		reviewSubmissionRequest	false	"Review comment"
+// @Success		200		{object}	service.EntrySubmission	"Approved submission"
+// @Failure		400		{object}	map[string]string		"Bad request"
+// @Failure		403		{object}	map[string]string		"Forbidden"
+// @Failure		404		{object}	map[string]string		"Submission not found"
+// @Failure		409		{object}	map[string]string		"Conflict"
+// @Failure		422		{object}	map[string]string		"Entry blocked by the entry policy"
+// @Failure		500		{object}	map[string]string		"Internal server error"
+// @Router		/v1/submissions/{id}/approve [post]
+func (routes *Routes) approveEntrySubmission(w http.ResponseWriter, r *http.Request) {
+	id, req, ok := decodeReviewRequest(w, r)
+	if !ok {
+		return
+	}
+
+	submission, err := routes.service.ApproveEntrySubmission(r.Context(), id, reviewOptions(r, req)...)
+	if err != nil {
+		writeReviewError(w, r, err)
+		return
+	}
+	common.WriteJSONResponse(w, submission, http.StatusOK)
+}
+
+// rejectEntrySubmission handles POST /v1/submissions/{id}/reject
+//
+// @Summary		Reject entry submission
+// @Description	Reject a pending submission with a comment. Its entry is never published.
+// @Tags		v1
+// @Accept		json
+// @Produce		json
+// @Param		id		path		string					true	"Submission ID"
+// @Param		request	body		reviewSubmissionRequest	true	"Review comment"
+// @Success		200		{object}	service.EntrySubmission	"Rejected submission"
+// @Failure		400		{object}	map[string]string		"Bad request"
+// @Failure		403		{object}	map[string]string		"Forbidden"
+// @Failure		404		{object}	map[string]string		"Submission not found"
+// @Failure		409		{object}	map[string]string		"Conflict"
+// @Failure		500		{object}	map[string]string		"Internal server error"
+// @Router		/v1/submissions/{id}/reject [post]
+func (routes *Routes) rejectEntrySubmission(w http.ResponseWriter, r *http.Request) {
+	id, req, ok := decodeReviewRequest(w, r)
+	if !ok {
+		return
+	}
+	if req.Comment == "" {
+		common.WriteErrorResponse(w, "comment is required to reject a submission", http.StatusBadRequest)
+		return
+	}
+
+	submission, err := routes.service.RejectEntrySubmission(r.Context(), id, reviewOptions(r, req)...)
+	if err != nil {
+		writeReviewError(w, r, err)
+		return
+	}
+	common.WriteJSONResponse(w, submission, http.StatusOK)
+}
+
+// decodeReviewRequest reads the submission ID and the optional body of a review
+// request. It writes an error response and returns false when they are invalid.
+func decodeReviewRequest(w http.ResponseWriter, r *http.Request) (string, *reviewSubmissionRequest, bool) {
+	id, err := common.GetAndValidateURLParam(r, "id")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return "", nil, false
+	}
+
+	var req reviewSubmissionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		common.WriteErrorResponse(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+		return "", nil, false
+	}
+	return id, &req, true
+}
+
+// reviewOptions returns the service options of a review request.
+func reviewOptions(r *http.Request, req *reviewSubmissionRequest) []service.Option {
+	var opts []service.Option
+	if req.Comment != "" {
+		opts = append(opts, service.WithReviewComment(req.Comment))
+	}
+	if jwtClaims := auth.ClaimsFromContext(r.Context()); jwtClaims != nil {
+		opts = append(opts, service.WithJWTClaims(map[string]any(jwtClaims)))
+	}
+	return opts
+}
+
+// writeReviewError maps service-layer review errors to HTTP responses.
+func writeReviewError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrDigestPinningRequired):
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrSubmissionNotFound):
+		common.WriteErrorResponse(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, service.ErrClaimsInsufficient), errors.Is(err, service.ErrSubmissionSelfReview):
+		common.WriteErrorResponse(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, service.ErrSubmissionNotPending),
+		errors.Is(err, service.ErrVersionAlreadyExists),
+		errors.Is(err, service.ErrClaimsMismatch):
+		common.WriteErrorResponse(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, service.ErrEntryBlocked):
+		common.WriteErrorResponse(w, err.Error(), http.StatusUnprocessableEntity)
+	case errors.Is(err, service.ErrNoManagedSource):
+		common.WriteErrorResponse(w, "no managed source available for publishing", http.StatusInternalServerError)
+	default:
+		slog.ErrorContext(r.Context(), "failed to review entry submission", "error", err)
+		common.WriteErrorResponse(w, "failed to review entry submission", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,257 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+const testSubmissionID = "0b6f1a52-6a4e-4a53-9d0c-5c1e2f9f7a10"
+
+func TestListEntrySubmissions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		path           string
+		setupMock      func(*mocks.MockRegistryService)
+		expectedStatus int
+	}{
+		{
+			name: "pending submissions by default",
+			path: "/submissions",
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListEntrySubmissions(gomock.Any(), gomock.Len(1)).DoAndReturn(
+					func(_ context.Context, opts ...service.Option) (*service.EntrySubmissionListResponse, error) {
+						options := &service.ListEntrySubmissionsOptions{}
+						for _, opt := range opts {
+							require.NoError(t, opt(options))
+						}
+						assert.Equal(t, service.SubmissionStatusPending, options.Status)
+
+						return &service.EntrySubmissionListResponse{
+							Submissions: []service.EntrySubmission{
+								{
+									ID:          testSubmissionID,
+									EntryType:   service.EntryTypeServer,
+									Name:        "com.example/weather",
+									Version:     "1.0.0",
+									Status:      service.SubmissionStatusPending,
+									Entry:       json.RawMessage(`{"name":"com.example/weather"}`),
+									SubmittedBy: "alice",
+									SubmittedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+								},
+							},
+						}, nil
+					})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "filters and pagination",
+			path: "/submissions?status=rejected&type=skill&limit=5&cursor=abc",
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListEntrySubmissions(gomock.Any(), gomock.Len(4)).DoAndReturn(
+					func(_ context.Context, opts ...service.Option) (*service.EntrySubmissionListResponse, error) {
+						options := &service.ListEntrySubmissionsOptions{}
+						for _, opt := range opts {
+							require.NoError(t, opt(options))
+						}
+						assert.Equal(t, service.ListEntrySubmissionsOptions{
+							Status:    service.SubmissionStatusRejected,
+							EntryType: service.EntryTypeSkill,
+							Cursor:    "abc",
+							Limit:     5,
+						}, *options)
+						return &service.EntrySubmissionListResponse{Submissions: []service.EntrySubmission{}}, nil
+					})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid limit",
+			path:           "/submissions?limit=0",
+			setupMock:      func(_ *mocks.MockRegistryService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid status",
+			path: "/submissions?status=lost",
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListEntrySubmissions(gomock.Any(), gomock.Any()).
+					Return(nil, service.ErrInvalidSubmissionStatus)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service error",
+			path: "/submissions",
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListEntrySubmissions(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			tt.setupMock(mockSvc)
+
+			router := Router(mockSvc, nil)
+			req, err := http.NewRequest("GET", tt.path, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
+
+func TestReviewEntrySubmission(t *testing.T) {
+	t.Parallel()
+
+	reviewed := func(status string) *service.EntrySubmission {
+		return &service.EntrySubmission{
+			ID:            testSubmissionID,
+			EntryType:     service.EntryTypeServer,
+			Name:          "com.example/weather",
+			Version:       "1.0.0",
+			Status:        status,
+			ReviewedBy:    "bob",
+			ReviewComment: "reviewed",
+		}
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		setupMock      func(*mocks.MockRegistryService)
+		expectedStatus int
+		wantStatus     string
+	}{
+		{
+			name: "approve without comment",
+			path: "/submissions/" + testSubmissionID + "/approve",
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ApproveEntrySubmission(gomock.Any(), testSubmissionID).
+					Return(reviewed(service.SubmissionStatusApproved), nil)
+			},
+			expectedStatus: http.StatusOK,
+			wantStatus:     service.SubmissionStatusApproved,
+		},
+		{
+			name: "reject with comment",
+			path: "/submissions/" + testSubmissionID + "/reject",
+			body: `{"comment": "missing license"}`,
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().RejectEntrySubmission(gomock.Any(), testSubmissionID, gomock.Len(1)).DoAndReturn(
+					func(_ context.Context, _ string, opts ...service.Option) (*service.EntrySubmission, error) {
+						options := &service.ReviewEntrySubmissionOptions{}
+						for _, opt := range opts {
+							require.NoError(t, opt(options))
+						}
+						assert.Equal(t, "missing license", options.Comment)
+						return reviewed(service.SubmissionStatusRejected), nil
+					})
+			},
+			expectedStatus: http.StatusOK,
+			wantStatus:     service.SubmissionStatusRejected,
+		},
+		{
+			name:           "reject without comment",
+			path:           "/submissions/" + testSubmissionID + "/reject",
+			setupMock:      func(_ *mocks.MockRegistryService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid body",
+			path:           "/submissions/" + testSubmissionID + "/approve",
+			body:           "not-json",
+			setupMock:      func(_ *mocks.MockRegistryService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "submission not found",
+			path: "/submissions/" + testSubmissionID + "/approve",
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ApproveEntrySubmission(gomock.Any(), testSubmissionID).
+					Return(nil, service.ErrSubmissionNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "submission already reviewed",
+			path: "/submissions/" + testSubmissionID + "/approve",
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ApproveEntrySubmission(gomock.Any(), testSubmissionID).
+					Return(nil, service.ErrSubmissionNotPending)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "self review",
+			path: "/submissions/" + testSubmissionID + "/approve",
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ApproveEntrySubmission(gomock.Any(), testSubmissionID).
+					Return(nil, service.ErrSubmissionSelfReview)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "service error",
+			path: "/submissions/" + testSubmissionID + "/reject",
+			body: `{"comment": "no"}`,
+			setupMock: func(m *mocks.MockRegistryService) {
+				m.EXPECT().RejectEntrySubmission(gomock.Any(), testSubmissionID, gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			tt.setupMock(mockSvc)
+
+			router := Router(mockSvc, nil)
+			req, err := http.NewRequest("POST", tt.path, bytes.NewBufferString(tt.body))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.wantStatus != "" {
+				var submission service.EntrySubmission
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &submission))
+				assert.Equal(t, tt.wantStatus, submission.Status)
+			}
+		})
+	}
+}
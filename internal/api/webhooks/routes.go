@@ -0,0 +1,178 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	auditmw "github.com/stacklok/toolhive-registry-server/internal/audit"
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// maxPayloadSize is the maximum size of a webhook delivery body
+const maxPayloadSize = 5 << 20
+
+// SyncTrigger starts queued syncs without waiting for the next polling tick
+type SyncTrigger interface {
+	Wake()
+}
+
+// Router returns an HTTP handler for the webhook routes. These routes are public:
+// deliveries are authenticated by their signature instead of a JWT. trigger may
+// be nil, in which case queued syncs start on the next polling tick.
+func Router(svc service.RegistryService, trigger SyncTrigger) http.Handler {
+	r := chi.NewRouter()
+	routes := &Routes{service: svc, trigger: trigger}
+
+	r.Post("/git/{name}", auditmw.Audited(auditmw.EventSourceWebhook, auditmw.ResourceTypeSource, "name", routes.gitPush))
+
+	return r
+}
+
+// Routes holds dependencies for webhook handlers.
+type Routes struct {
+	service service.RegistryService
+	trigger SyncTrigger
+}
+
+// gitPush handles POST /webhooks/git/{name}
+//
+// @Summary		Git push webhook
+// @Description	Receives GitHub, GitLab and Gitea push events for a Git source with a configured webhook.
+// @Description	GitHub and Gitea deliveries must carry an HMAC-SHA256 signature of the body, GitLab deliveries
+// @Description	the secret token. A push to the branch or tag the source syncs from queues an immediate sync;
+// @Description	other events and references are acknowledged and ignored.
+// @Tags		webhooks
+// @Accept		json
+// @Produce		json
+// @Param		name				path		string	true	"Source Name"
+// @Param		X-Hub-Signature-256	header		string	false	"GitHub signature (sha256=<hex>)"
+// @Param		X-Gitea-Signature	header		string	false	"Gitea signature (hex)"
+// @Param		X-Gitlab-Token		header		string	false	"GitLab secret token"
+// @Success		200					{object}	Response			"Delivery ignored"
+// @Success		202					{object}	Response			"Sync queued"
+// @Failure		400					{object}	map[string]string	"Bad request"
+// @Failure		401					{object}	map[string]string	"Missing or invalid signature"
+// @Failure		404					{object}	map[string]string	"Source not found or webhook not enabled"
+// @Failure		413					{object}	map[string]string	"Payload too large"
+// @Failure		500					{object}	map[string]string	"Internal server error"
+// @Router		/webhooks/git/{name} [post]
+func (routes *Routes) gitPush(w http.ResponseWriter, r *http.Request) {
+	name, err := common.GetAndValidateURLParam(r, "name")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	d, ok := detectDelivery(r.Header)
+	if !ok {
+		common.WriteErrorResponse(w, "unsupported webhook delivery: expected a GitHub, GitLab or Gitea event",
+			http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			common.WriteErrorResponse(w, "webhook payload too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		common.WriteErrorResponse(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	gitCfg, err := routes.webhookSource(r, name)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	secret, err := gitCfg.Webhook.GetSecret()
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to read webhook secret", "source", name, "error", err)
+		common.WriteErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if !d.verify(secret, body) {
+		slog.WarnContext(r.Context(), "Rejected webhook delivery with a missing or invalid signature",
+			"source", name,
+			"provider", d.provider,
+			"event", d.event,
+			"remote_addr", r.RemoteAddr)
+		common.WriteErrorResponse(w, "missing or invalid webhook signature", http.StatusUnauthorized)
+		return
+	}
+
+	if !d.isPush() {
+		common.WriteJSONResponse(w, Response{
+			Status:  StatusIgnored,
+			Message: fmt.Sprintf("%s event is not a push", d.event),
+		}, http.StatusOK)
+		return
+	}
+
+	var event pushEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		common.WriteErrorResponse(w, "invalid push payload", http.StatusBadRequest)
+		return
+	}
+	if !gitCfg.MatchesRef(event.Ref, event.defaultBranch()) {
+		common.WriteJSONResponse(w, Response{
+			Status:  StatusIgnored,
+			Message: fmt.Sprintf("%s is not synced by the source", event.Ref),
+		}, http.StatusOK)
+		return
+	}
+
+	request, err := routes.service.RequestSourceSync(r.Context(), name)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	if routes.trigger != nil {
+		routes.trigger.Wake()
+	}
+
+	slog.InfoContext(r.Context(), "Webhook queued source sync",
+		"source", name,
+		"provider", d.provider,
+		"ref", event.Ref,
+		"sync_request_id", request.ID)
+
+	common.WriteJSONResponse(w, Response{Status: StatusQueued, SyncRequest: request}, http.StatusAccepted)
+}
+
+// webhookSource returns the configuration of a Git source with a webhook.
+// Sources that are not Git sources or have no webhook are reported as not found.
+func (routes *Routes) webhookSource(r *http.Request, name string) (*config.GitConfig, error) {
+	source, err := routes.service.GetSourceByName(r.Context(), name)
+	if err != nil {
+		return nil, err
+	}
+	gitCfg, ok := source.SourceConfig.(*config.GitConfig)
+	if !ok || gitCfg.Webhook == nil {
+		return nil, fmt.Errorf("%w: webhook is not enabled for source %s", service.ErrSourceNotFound, name)
+	}
+	return gitCfg, nil
+}
+
+// writeServiceError maps service-layer errors to HTTP responses.
+func writeServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrSourceNotFound):
+		common.WriteErrorResponse(w, "source not found or webhook not enabled", http.StatusNotFound)
+	case errors.Is(err, service.ErrSourceNotSyncable):
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+	default:
+		slog.ErrorContext(r.Context(), "unexpected error", "error", err)
+		common.WriteErrorResponse(w, "internal server error", http.StatusInternalServerError)
+	}
+}
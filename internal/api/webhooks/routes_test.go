@@ -0,0 +1,246 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+const testSecret = "webhook-secret"
+
+// countingTrigger counts how often the sync coordinator was woken up
+type countingTrigger struct {
+	wakes atomic.Int32
+}
+
+func (c *countingTrigger) Wake() {
+	c.wakes.Add(1)
+}
+
+// webhookRouter returns a router that mounts the webhook routes under /webhooks
+func webhookRouter(svc service.RegistryService, trigger SyncTrigger) http.Handler {
+	r := chi.NewRouter()
+	r.Mount("/webhooks", Router(svc, trigger))
+	return r
+}
+
+// sign returns the hex encoded HMAC-SHA256 of body with the test secret
+func sign(body string) string {
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	_, _ = mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestGitPush(t *testing.T) {
+	t.Parallel()
+
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(secretFile, []byte(testSecret+"\n"), 0600))
+
+	gitSource := &service.SourceInfo{
+		Name: "catalog",
+		SourceConfig: &config.GitConfig{
+			Repository: "https://github.com/acme/catalog.git",
+			Branch:     "main",
+			Webhook:    &config.GitWebhookConfig{SecretFile: secretFile},
+		},
+	}
+	defaultBranchSource := &service.SourceInfo{
+		Name: "catalog",
+		SourceConfig: &config.GitConfig{
+			Repository: "https://gitlab.com/acme/catalog.git",
+			Webhook:    &config.GitWebhookConfig{SecretFile: secretFile},
+		},
+	}
+
+	mainPush := `{"ref":"refs/heads/main","repository":{"default_branch":"main"}}`
+	devPush := `{"ref":"refs/heads/dev","repository":{"default_branch":"main"}}`
+	gitlabPush := `{"ref":"refs/heads/trunk","project":{"default_branch":"trunk"}}`
+
+	expectSource := func(source *service.SourceInfo) func(m *mocks.MockRegistryService) {
+		return func(m *mocks.MockRegistryService) {
+			m.EXPECT().GetSourceByName(gomock.Any(), "catalog").Return(source, nil)
+		}
+	}
+	expectSync := func(source *service.SourceInfo) func(m *mocks.MockRegistryService) {
+		return func(m *mocks.MockRegistryService) {
+			expectSource(source)(m)
+			m.EXPECT().RequestSourceSync(gomock.Any(), "catalog").
+				Return(&service.SyncRequestInfo{ID: "req-1", SourceName: "catalog", Status: "pending"}, nil)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		headers    map[string]string
+		body       string
+		setupMocks func(m *mocks.MockRegistryService)
+		wantStatus int
+		wantError  string
+		wantResult string
+		wantWakes  int32
+	}{
+		{
+			name:       "signed github push to the synced branch queues a sync",
+			headers:    map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": "sha256=" + sign(mainPush)},
+			body:       mainPush,
+			setupMocks: expectSync(gitSource),
+			wantStatus: http.StatusAccepted,
+			wantResult: StatusQueued,
+			wantWakes:  1,
+		},
+		{
+			name:       "signed gitea push queues a sync",
+			headers:    map[string]string{"X-Gitea-Event": "push", "X-Gitea-Signature": sign(mainPush)},
+			body:       mainPush,
+			setupMocks: expectSync(gitSource),
+			wantStatus: http.StatusAccepted,
+			wantResult: StatusQueued,
+			wantWakes:  1,
+		},
+		{
+			name:       "gitlab push to the default branch queues a sync",
+			headers:    map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": testSecret},
+			body:       gitlabPush,
+			setupMocks: expectSync(defaultBranchSource),
+			wantStatus: http.StatusAccepted,
+			wantResult: StatusQueued,
+			wantWakes:  1,
+		},
+		{
+			name:       "push to another branch is ignored",
+			headers:    map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": "sha256=" + sign(devPush)},
+			body:       devPush,
+			setupMocks: expectSource(gitSource),
+			wantStatus: http.StatusOK,
+			wantResult: StatusIgnored,
+		},
+		{
+			name:       "ping is ignored",
+			headers:    map[string]string{"X-GitHub-Event": "ping", "X-Hub-Signature-256": "sha256=" + sign(`{}`)},
+			body:       `{}`,
+			setupMocks: expectSource(gitSource),
+			wantStatus: http.StatusOK,
+			wantResult: StatusIgnored,
+		},
+		{
+			name:       "invalid signature is rejected",
+			headers:    map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": "sha256=" + sign(devPush)},
+			body:       mainPush,
+			setupMocks: expectSource(gitSource),
+			wantStatus: http.StatusUnauthorized,
+			wantError:  "missing or invalid webhook signature",
+		},
+		{
+			name:       "unsigned delivery is rejected",
+			headers:    map[string]string{"X-GitHub-Event": "push"},
+			body:       mainPush,
+			setupMocks: expectSource(gitSource),
+			wantStatus: http.StatusUnauthorized,
+			wantError:  "missing or invalid webhook signature",
+		},
+		{
+			name:       "wrong gitlab token is rejected",
+			headers:    map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": "guess"},
+			body:       gitlabPush,
+			setupMocks: expectSource(defaultBranchSource),
+			wantStatus: http.StatusUnauthorized,
+			wantError:  "missing or invalid webhook signature",
+		},
+		{
+			name:       "unknown provider returns 400",
+			body:       mainPush,
+			wantStatus: http.StatusBadRequest,
+			wantError:  "unsupported webhook delivery: expected a GitHub, GitLab or Gitea event",
+		},
+		{
+			name:    "source without webhook returns 404",
+			headers: map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": "sha256=" + sign(mainPush)},
+			body:    mainPush,
+			setupMocks: expectSource(&service.SourceInfo{
+				Name:         "catalog",
+				SourceConfig: &config.GitConfig{Repository: "https://github.com/acme/catalog.git"},
+			}),
+			wantStatus: http.StatusNotFound,
+			wantError:  "source not found or webhook not enabled",
+		},
+		{
+			name:    "unknown source returns 404",
+			headers: map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": "sha256=" + sign(mainPush)},
+			body:    mainPush,
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetSourceByName(gomock.Any(), "catalog").
+					Return(nil, fmt.Errorf("%w: catalog", service.ErrSourceNotFound))
+			},
+			wantStatus: http.StatusNotFound,
+			wantError:  "source not found or webhook not enabled",
+		},
+		{
+			name:    "queueing failure returns 500",
+			headers: map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": "sha256=" + sign(mainPush)},
+			body:    mainPush,
+			setupMocks: func(m *mocks.MockRegistryService) {
+				expectSource(gitSource)(m)
+				m.EXPECT().RequestSourceSync(gomock.Any(), "catalog").Return(nil, fmt.Errorf("database error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockSvc)
+			}
+			trigger := &countingTrigger{}
+			router := webhookRouter(mockSvc, trigger)
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/git/catalog", strings.NewReader(tt.body))
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code, "status code")
+			if tt.wantError != "" {
+				var body map[string]string
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+				assert.Equal(t, tt.wantError, body["error"], "error message")
+			}
+			if tt.wantResult != "" {
+				var resp Response
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+				assert.Equal(t, tt.wantResult, resp.Status)
+				if tt.wantResult == StatusQueued {
+					require.NotNil(t, resp.SyncRequest)
+					assert.Equal(t, "req-1", resp.SyncRequest.ID)
+				}
+			}
+			assert.Equal(t, tt.wantWakes, trigger.wakes.Load(), "coordinator wakeups")
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// Provider identifies the Git hosting service that sent a webhook delivery.
+type Provider string
+
+// Supported webhook providers.
+const (
+	ProviderGitHub Provider = "github"
+	ProviderGitLab Provider = "gitlab"
+	ProviderGitea  Provider = "gitea"
+)
+
+// delivery is a webhook delivery whose provider was detected from its headers.
+type delivery struct {
+	provider  Provider
+	event     string
+	signature string
+}
+
+// detectDelivery detects the provider of a delivery from its headers. It returns
+// false if the delivery does not come from a supported provider.
+func detectDelivery(header http.Header) (*delivery, bool) {
+	// Gitea also sends GitHub headers for compatibility, so it is checked first
+	if event := header.Get("X-Gitea-Event"); event != "" {
+		return &delivery{
+			provider:  ProviderGitea,
+			event:     event,
+			signature: header.Get("X-Gitea-Signature"),
+		}, true
+	}
+	if event := header.Get("X-GitHub-Event"); event != "" {
+		return &delivery{
+			provider:  ProviderGitHub,
+			event:     event,
+			signature: header.Get("X-Hub-Signature-256"),
+		}, true
+	}
+	if event := header.Get("X-Gitlab-Event"); event != "" {
+		return &delivery{
+			provider:  ProviderGitLab,
+			event:     event,
+			signature: header.Get("X-Gitlab-Token"),
+		}, true
+	}
+	return nil, false
+}
+
+// isPush reports whether the delivery is a branch or tag push event.
+func (d *delivery) isPush() bool {
+	if d.provider == ProviderGitLab {
+		return d.event == "Push Hook" || d.event == "Tag Push Hook"
+	}
+	return d.event == "push"
+}
+
+// verify checks the signature of the delivery body against the shared secret.
+// Unsigned deliveries and empty secrets never verify.
+func (d *delivery) verify(secret string, body []byte) bool {
+	if d.signature == "" || secret == "" {
+		return false
+	}
+	switch d.provider {
+	case ProviderGitHub:
+		signature, ok := strings.CutPrefix(d.signature, "sha256=")
+		return ok && validHMAC(signature, secret, body)
+	case ProviderGitea:
+		return validHMAC(d.signature, secret, body)
+	case ProviderGitLab:
+		// GitLab sends the secret token itself rather than a signature of the body
+		return subtle.ConstantTimeCompare([]byte(d.signature), []byte(secret)) == 1
+	default:
+		return false
+	}
+}
+
+// validHMAC reports whether signature is the hex encoded HMAC-SHA256 of body
+func validHMAC(signature, secret string, body []byte) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
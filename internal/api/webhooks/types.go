@@ -0,0 +1,39 @@
+// Package webhooks provides the handlers for Git push webhooks, which queue an
+// immediate sync of the Git source a push was made to.
+package webhooks
+
+import "github.com/stacklok/toolhive-registry-server/internal/service"
+
+// Delivery statuses reported in webhook responses.
+const (
+	StatusQueued  = "queued"
+	StatusIgnored = "ignored"
+)
+
+// Response is the response for POST /webhooks/git/{name}.
+type Response struct {
+	Status      string                   `json:"status"`            // queued or ignored
+	Message     string                   `json:"message,omitempty"` // why the delivery was ignored
+	SyncRequest *service.SyncRequestInfo `json:"syncRequest,omitempty"`
+}
+
+// pushEvent holds the fields of GitHub, GitLab and Gitea push payloads that are
+// needed to match a push with the source configuration.
+type pushEvent struct {
+	Ref        string         `json:"ref"`
+	Repository pushRepository `json:"repository"` // GitHub and Gitea
+	Project    pushRepository `json:"project"`    // GitLab
+}
+
+// pushRepository is the repository a push was made to.
+type pushRepository struct {
+	DefaultBranch string `json:"default_branch"`
+}
+
+// defaultBranch returns the default branch of the repository a push was made to.
+func (e *pushEvent) defaultBranch() string {
+	if e.Repository.DefaultBranch != "" {
+		return e.Repository.DefaultBranch
+	}
+	return e.Project.DefaultBranch
+}
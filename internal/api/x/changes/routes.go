@@ -0,0 +1,334 @@
+// Package changes provides API types and handlers for the dev.toolhive/changes
+// and dev.toolhive/events extension endpoints, which report the servers, skills
+// and plugins of a registry that were created, updated or deleted.
+package changes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	auditmw "github.com/stacklok/toolhive-registry-server/internal/audit"
+	"github.com/stacklok/toolhive-registry-server/internal/auth"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+
+	// pollInterval is how often the event stream checks for new changes
+	pollInterval = 2 * time.Second
+	// heartbeatInterval is how often the event stream sends a comment to keep
+	// idle connections open through proxies
+	heartbeatInterval = 30 * time.Second
+)
+
+// Router returns an HTTP handler for the dev.toolhive/changes extension routes.
+func Router(svc service.RegistryService) http.Handler {
+	r := chi.NewRouter()
+	routes := newRoutes(svc)
+
+	r.Get("/", auditmw.AuditedSearch(auditmw.EventEntryChangesList, routes.listChanges))
+
+	return r
+}
+
+// EventsRouter returns an HTTP handler for the dev.toolhive/events extension routes.
+func EventsRouter(svc service.RegistryService) http.Handler {
+	r := chi.NewRouter()
+	routes := newRoutes(svc)
+
+	r.Get("/", auditmw.AuditedSearch(auditmw.EventEntryChangesStream, routes.streamEvents))
+
+	return r
+}
+
+// Routes holds dependencies for change feed extension handlers.
+type Routes struct {
+	service           service.RegistryService
+	pollInterval      time.Duration
+	heartbeatInterval time.Duration
+}
+
+func newRoutes(svc service.RegistryService) *Routes {
+	return &Routes{
+		service:           svc,
+		pollInterval:      pollInterval,
+		heartbeatInterval: heartbeatInterval,
+	}
+}
+
+// listChanges handles GET /registry/{registryName}/v0.1/x/dev.toolhive/changes
+//
+// @Summary		List registry changes
+// @Description	Lists the server, skill and plugin versions of a registry that were created, updated or
+// @Description	deleted after a change token, oldest first. Without a token, changes are listed from the
+// @Description	oldest retained one. Pass the returned nextToken as since to resume; changes are retained
+// @Description	as long as the sync history.
+// @Tags		changes
+// @Produce		json
+// @Param		registryName	path		string	true	"Registry name"
+// @Param		since		query		string	false	"Change token to resume after"
+// @Param		limit		query		int		false	"Max changes (default 100, max 1000)"
+// @Success		200			{object}	Response			"Changes after the token"
+// @Failure		400			{object}	map[string]string	"Bad request"
+// @Failure		403			{object}	map[string]string	"Insufficient claims"
+// @Failure		404			{object}	map[string]string	"Registry not found"
+// @Failure		410			{object}	map[string]string	"Changes after the token are no longer retained"
+// @Failure		500			{object}	map[string]string	"Internal server error"
+// @Security	BearerAuth
+// @Router		/registry/{registryName}/v0.1/x/dev.toolhive/changes [get]
+func (routes *Routes) listChanges(w http.ResponseWriter, r *http.Request) {
+	registryName, err := common.GetAndValidateURLParam(r, "registryName")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	query, err := parseQuery(r)
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	opts := []service.Option{
+		service.WithRegistryName(registryName),
+		service.WithLimit(query.Limit),
+	}
+	if query.Since != "" {
+		opts = append(opts, service.WithSince(query.Since))
+	}
+	if jwtClaims := auth.ClaimsFromContext(r.Context()); jwtClaims != nil {
+		opts = append(opts, service.WithClaims(map[string]any(jwtClaims)))
+	}
+
+	result, err := routes.service.ListEntryChanges(r.Context(), opts...)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	resp := Response{
+		Changes: serviceChangesToResponse(result.Changes),
+		Metadata: Metadata{
+			Count:     len(result.Changes),
+			NextToken: result.NextToken,
+		},
+	}
+
+	common.WriteJSONResponse(w, resp, http.StatusOK)
+}
+
+// streamEvents handles GET /registry/{registryName}/v0.1/x/dev.toolhive/events
+//
+// @Summary		Stream registry changes
+// @Description	Server-sent event stream of the server, skill and plugin versions of a registry that are
+// @Description	created, updated or deleted. Each event is named after its change type, carries a Change as
+// @Description	data and the change token as id. The stream resumes after the Last-Event-ID header or the
+// @Description	since parameter, and otherwise starts with the changes made after the connection.
+// @Tags		changes
+// @Produce		text/event-stream
+// @Param		registryName	path		string	true	"Registry name"
+// @Param		since		query		string	false	"Change token to resume after"
+// @Param		Last-Event-ID	header		string	false	"Change token to resume after, set by reconnecting clients"
+// @Success		200			{object}	Change				"Stream of change events"
+// @Failure		400			{object}	map[string]string	"Bad request"
+// @Failure		403			{object}	map[string]string	"Insufficient claims"
+// @Failure		404			{object}	map[string]string	"Registry not found"
+// @Failure		410			{object}	map[string]string	"Changes after the token are no longer retained"
+// @Failure		500			{object}	map[string]string	"Internal server error"
+// @Security	BearerAuth
+// @Router		/registry/{registryName}/v0.1/x/dev.toolhive/events [get]
+func (routes *Routes) streamEvents(w http.ResponseWriter, r *http.Request) {
+	registryName, err := common.GetAndValidateURLParam(r, "registryName")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	since := strings.TrimSpace(r.Header.Get("Last-Event-ID"))
+	if since == "" {
+		since = strings.TrimSpace(r.URL.Query().Get("since"))
+	}
+
+	baseOpts := []service.Option{
+		service.WithRegistryName(registryName),
+		service.WithLimit(maxLimit),
+	}
+	if jwtClaims := auth.ClaimsFromContext(r.Context()); jwtClaims != nil {
+		baseOpts = append(baseOpts, service.WithClaims(map[string]any(jwtClaims)))
+	}
+	listChanges := func(position service.Option) (*service.EntryChangesResult, error) {
+		return routes.service.ListEntryChanges(r.Context(), append(baseOpts, position)...)
+	}
+
+	// The first page is read before the stream starts, so that an unknown registry
+	// or an invalid token is reported with an error status
+	position := service.WithFromLatest()
+	if since != "" {
+		position = service.WithSince(since)
+	}
+	result, err := listChanges(position)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	// Events are written for as long as the client stays connected
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		slog.WarnContext(r.Context(), "Failed to clear the write deadline of the event stream", "error", err)
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	poll := time.NewTicker(routes.pollInterval)
+	defer poll.Stop()
+	heartbeat := time.NewTicker(routes.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		if err := writeEvents(w, result.Changes); err != nil {
+			return
+		}
+		_ = rc.Flush()
+		token := result.NextToken
+
+		// Drain full pages right away, then wait for the next poll
+		if len(result.Changes) < maxLimit {
+			if !waitForPoll(w, rc, r, poll, heartbeat) {
+				return
+			}
+		}
+
+		result, err = listChanges(service.WithSince(token))
+		if err != nil {
+			if r.Context().Err() != nil {
+				return
+			}
+			writeStreamError(w, r, err)
+			_ = rc.Flush()
+			return
+		}
+	}
+}
+
+// waitForPoll waits for the next poll of the event stream, sending heartbeats in
+// the meantime. It returns false when the client disconnected.
+func waitForPoll(w io.Writer, rc *http.ResponseController, r *http.Request, poll, heartbeat *time.Ticker) bool {
+	if r.Context().Err() != nil {
+		return false
+	}
+	for {
+		select {
+		case <-r.Context().Done():
+			return false
+		case <-poll.C:
+			return true
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return false
+			}
+			_ = rc.Flush()
+		}
+	}
+}
+
+// writeEvents writes changes as server-sent events named after their change type.
+func writeEvents(w io.Writer, changes []*service.EntryChange) error {
+	for _, change := range serviceChangesToResponse(changes) {
+		data, err := json.Marshal(change)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", change.Token, change.ChangeType, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeStreamError reports an error that ended an event stream as an error event.
+func writeStreamError(w io.Writer, r *http.Request, err error) {
+	message := "internal server error"
+	switch {
+	case errors.Is(err, service.ErrChangeTokenExpired):
+		message = "changes after the token are no longer retained"
+	case errors.Is(err, service.ErrClaimsInsufficient):
+		message = "forbidden: insufficient claims for registry"
+	case errors.Is(err, service.ErrRegistryNotFound):
+		message = "registry not found"
+	default:
+		slog.ErrorContext(r.Context(), "unexpected error", "error", err)
+	}
+	data, _ := json.Marshal(map[string]string{"error": message})
+	_, _ = fmt.Fprintf(w, "event: error\ndata: %s\n\n", data)
+}
+
+// parseQuery parses and validates changes query parameters.
+func parseQuery(r *http.Request) (*Query, error) {
+	q := r.URL.Query()
+	query := &Query{
+		Since: strings.TrimSpace(q.Get("since")),
+		Limit: defaultLimit,
+	}
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid limit parameter: must be an integer")
+		}
+		if limit < 1 || limit > maxLimit {
+			return nil, fmt.Errorf("invalid limit parameter: must be between 1 and %d", maxLimit)
+		}
+		query.Limit = limit
+	}
+
+	return query, nil
+}
+
+// writeServiceError maps service-layer errors to HTTP responses.
+func writeServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidChangeToken):
+		common.WriteErrorResponse(w, "invalid since parameter", http.StatusBadRequest)
+	case errors.Is(err, service.ErrChangeTokenExpired):
+		common.WriteErrorResponse(w, "changes after the token are no longer retained", http.StatusGone)
+	case errors.Is(err, service.ErrClaimsInsufficient):
+		common.WriteErrorResponse(w, "forbidden: insufficient claims for registry", http.StatusForbidden)
+	case errors.Is(err, service.ErrRegistryNotFound):
+		common.WriteErrorResponse(w, "registry not found", http.StatusNotFound)
+	default:
+		slog.ErrorContext(r.Context(), "unexpected error", "error", err)
+		common.WriteErrorResponse(w, "internal server error", http.StatusInternalServerError)
+	}
+}
+
+// serviceChangesToResponse maps a slice of service.EntryChange to changes.
+func serviceChangesToResponse(changes []*service.EntryChange) []Change {
+	result := make([]Change, len(changes))
+	for i, c := range changes {
+		result[i] = Change{
+			Token:      c.Token,
+			ChangeType: c.Type,
+			Type:       c.EntryType,
+			Namespace:  c.Namespace,
+			Name:       c.Name,
+			Version:    c.Version,
+			ChangedAt:  c.ChangedAt,
+		}
+	}
+	return result
+}
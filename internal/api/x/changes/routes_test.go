@@ -0,0 +1,333 @@
+package changes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+// changesRouterWithRegistryMount returns a router that mounts the change feed routes
+// under /{registryName}/v0.1/x/dev.toolhive so URL param registryName is set.
+func changesRouterWithRegistryMount(routes *Routes) http.Handler {
+	r := chi.NewRouter()
+	r.Get("/{registryName}/v0.1/x/dev.toolhive/changes", routes.listChanges)
+	r.Get("/{registryName}/v0.1/x/dev.toolhive/events", routes.streamEvents)
+	return r
+}
+
+// applyListEntryChangesOptions applies service.Option functions to a ListEntryChangesOptions
+// struct so tests can inspect which options were passed by the handler.
+func applyListEntryChangesOptions(t *testing.T, opts []service.Option) *service.ListEntryChangesOptions {
+	t.Helper()
+	result := &service.ListEntryChangesOptions{}
+	for _, opt := range opts {
+		require.NoError(t, opt(result))
+	}
+	return result
+}
+
+func TestListChanges(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		path       string
+		setupMocks func(m *mocks.MockRegistryService)
+		wantStatus int
+		wantError  string
+	}{
+		{
+			name: "changes are returned",
+			path: "/myreg/v0.1/x/dev.toolhive/changes",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListEntryChanges(gomock.Any(), gomock.Any()).
+					Return(&service.EntryChangesResult{
+						Changes: []*service.EntryChange{
+							{Token: "7", Type: service.ChangeTypeDeleted, EntryType: service.EntryTypeServer, Name: "io.github.example/fetch"},
+						},
+						NextToken: "7",
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid limit returns 400",
+			path:       "/myreg/v0.1/x/dev.toolhive/changes?limit=0",
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid limit parameter: must be between 1 and 1000",
+		},
+		{
+			name: "invalid token returns 400",
+			path: "/myreg/v0.1/x/dev.toolhive/changes?since=bogus",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListEntryChanges(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("%w: bogus", service.ErrInvalidChangeToken))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid since parameter",
+		},
+		{
+			name: "expired token returns 410",
+			path: "/myreg/v0.1/x/dev.toolhive/changes?since=1",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListEntryChanges(gomock.Any(), gomock.Any()).
+					Return(nil, service.ErrChangeTokenExpired)
+			},
+			wantStatus: http.StatusGone,
+			wantError:  "changes after the token are no longer retained",
+		},
+		{
+			name: "registry not found returns 404",
+			path: "/myreg/v0.1/x/dev.toolhive/changes",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListEntryChanges(gomock.Any(), gomock.Any()).
+					Return(nil, service.ErrRegistryNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantError:  "registry not found",
+		},
+		{
+			name: "insufficient claims returns 403",
+			path: "/myreg/v0.1/x/dev.toolhive/changes",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListEntryChanges(gomock.Any(), gomock.Any()).
+					Return(nil, service.ErrClaimsInsufficient)
+			},
+			wantStatus: http.StatusForbidden,
+			wantError:  "forbidden: insufficient claims for registry",
+		},
+		{
+			name: "service error returns 500",
+			path: "/myreg/v0.1/x/dev.toolhive/changes",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListEntryChanges(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("database error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockSvc)
+			}
+			router := changesRouterWithRegistryMount(newRoutes(mockSvc))
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+			assert.Equal(t, tt.wantStatus, rr.Code, "status code")
+			if tt.wantError != "" {
+				var body map[string]string
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+				assert.Equal(t, tt.wantError, body["error"], "error message")
+			}
+		})
+	}
+}
+
+func TestListChangesOptionsAndResponse(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	mockSvc := mocks.NewMockRegistryService(ctrl)
+
+	mockSvc.EXPECT().ListEntryChanges(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, opts ...service.Option) (*service.EntryChangesResult, error) {
+			resolved := applyListEntryChangesOptions(t, opts)
+			assert.Equal(t, "myreg", resolved.RegistryName)
+			assert.Equal(t, "41", resolved.Since)
+			assert.Equal(t, 10, resolved.Limit)
+			assert.False(t, resolved.FromLatest)
+			return &service.EntryChangesResult{
+				Changes: []*service.EntryChange{
+					{Token: "42", Type: service.ChangeTypeCreated, EntryType: service.EntryTypeSkill,
+						Namespace: "io.github.example", Name: "pdf", Version: "1.0.0"},
+					{Token: "44", Type: service.ChangeTypeDeleted, EntryType: service.EntryTypeServer,
+						Name: "io.github.example/fetch", Version: "1.0.0"},
+				},
+				NextToken: "45",
+			}, nil
+		})
+
+	router := changesRouterWithRegistryMount(newRoutes(mockSvc))
+	req := httptest.NewRequest(http.MethodGet, "/myreg/v0.1/x/dev.toolhive/changes?since=41&limit=10", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp Response
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Len(t, resp.Changes, 2)
+	assert.Equal(t, "created", resp.Changes[0].ChangeType)
+	assert.Equal(t, "skill", resp.Changes[0].Type)
+	assert.Equal(t, "io.github.example", resp.Changes[0].Namespace)
+	assert.Equal(t, "deleted", resp.Changes[1].ChangeType)
+	assert.Equal(t, "server", resp.Changes[1].Type)
+	assert.Equal(t, 2, resp.Metadata.Count)
+	assert.Equal(t, "45", resp.Metadata.NextToken)
+}
+
+func TestStreamEvents(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	mockSvc := mocks.NewMockRegistryService(ctrl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	gomock.InOrder(
+		// The stream resumes after Last-Event-ID
+		mockSvc.EXPECT().ListEntryChanges(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, opts ...service.Option) (*service.EntryChangesResult, error) {
+				resolved := applyListEntryChangesOptions(t, opts)
+				assert.Equal(t, "myreg", resolved.RegistryName)
+				assert.Equal(t, "5", resolved.Since)
+				return &service.EntryChangesResult{
+					Changes: []*service.EntryChange{
+						{Token: "6", Type: service.ChangeTypeCreated, EntryType: service.EntryTypeServer,
+							Name: "io.github.example/fetch", Version: "1.0.0"},
+					},
+					NextToken: "6",
+				}, nil
+			}),
+		// The next poll resumes after the last change sent
+		mockSvc.EXPECT().ListEntryChanges(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, opts ...service.Option) (*service.EntryChangesResult, error) {
+				assert.Equal(t, "6", applyListEntryChangesOptions(t, opts).Since)
+				return &service.EntryChangesResult{
+					Changes: []*service.EntryChange{
+						{Token: "9", Type: service.ChangeTypeDeleted, EntryType: service.EntryTypeServer,
+							Name: "io.github.example/fetch", Version: "1.0.0"},
+					},
+					NextToken: "9",
+				}, nil
+			}),
+		// The client disconnects
+		mockSvc.EXPECT().ListEntryChanges(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, opts ...service.Option) (*service.EntryChangesResult, error) {
+				assert.Equal(t, "9", applyListEntryChangesOptions(t, opts).Since)
+				cancel()
+				return &service.EntryChangesResult{Changes: []*service.EntryChange{}, NextToken: "9"}, nil
+			}),
+	)
+
+	routes := newRoutes(mockSvc)
+	routes.pollInterval = time.Millisecond
+	router := changesRouterWithRegistryMount(routes)
+
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/myreg/v0.1/x/dev.toolhive/events", nil)
+	req.Header.Set("Last-Event-ID", "5")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/event-stream", rr.Header().Get("Content-Type"))
+	assert.Equal(t,
+		"id: 6\nevent: created\ndata: "+
+			`{"token":"6","changeType":"created","type":"server","name":"io.github.example/fetch",`+
+			`"version":"1.0.0","changedAt":"0001-01-01T00:00:00Z"}`+"\n\n"+
+			"id: 9\nevent: deleted\ndata: "+
+			`{"token":"9","changeType":"deleted","type":"server","name":"io.github.example/fetch",`+
+			`"version":"1.0.0","changedAt":"0001-01-01T00:00:00Z"}`+"\n\n",
+		rr.Body.String())
+}
+
+func TestStreamEvents_StartsFromLatest(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	mockSvc := mocks.NewMockRegistryService(ctrl)
+
+	gomock.InOrder(
+		mockSvc.EXPECT().ListEntryChanges(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, opts ...service.Option) (*service.EntryChangesResult, error) {
+				assert.True(t, applyListEntryChangesOptions(t, opts).FromLatest)
+				return &service.EntryChangesResult{Changes: []*service.EntryChange{}, NextToken: "12"}, nil
+			}),
+		mockSvc.EXPECT().ListEntryChanges(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, opts ...service.Option) (*service.EntryChangesResult, error) {
+				assert.Equal(t, "12", applyListEntryChangesOptions(t, opts).Since)
+				return nil, fmt.Errorf("database error")
+			}),
+	)
+
+	routes := newRoutes(mockSvc)
+	routes.pollInterval = time.Millisecond
+	router := changesRouterWithRegistryMount(routes)
+
+	req := httptest.NewRequest(http.MethodGet, "/myreg/v0.1/x/dev.toolhive/events", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "event: error\ndata: {\"error\":\"internal server error\"}\n\n", rr.Body.String())
+}
+
+func TestStreamEvents_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{
+			name:       "invalid token returns 400",
+			err:        service.ErrInvalidChangeToken,
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid since parameter",
+		},
+		{
+			name:       "expired token returns 410",
+			err:        service.ErrChangeTokenExpired,
+			wantStatus: http.StatusGone,
+			wantError:  "changes after the token are no longer retained",
+		},
+		{
+			name:       "registry not found returns 404",
+			err:        service.ErrRegistryNotFound,
+			wantStatus: http.StatusNotFound,
+			wantError:  "registry not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			mockSvc.EXPECT().ListEntryChanges(gomock.Any(), gomock.Any()).Return(nil, tt.err)
+			router := changesRouterWithRegistryMount(newRoutes(mockSvc))
+
+			req := httptest.NewRequest(http.MethodGet, "/myreg/v0.1/x/dev.toolhive/events?since=3", nil)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code, "status code")
+			var body map[string]string
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+			assert.Equal(t, tt.wantError, body["error"], "error message")
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Package changes provides API types and handlers for the dev.toolhive/changes
+// and dev.toolhive/events extension endpoints, which report the servers, skills
+// and plugins of a registry that were created, updated or deleted.
+package changes
+
+import "time"
+
+// Query holds parsed query parameters for GET /changes.
+type Query struct {
+	Since string
+	Limit int // default 100, max 1000
+}
+
+// Change is a change of a server, skill or plugin version. It is an item of
+// changes responses and the data of the events of the event stream.
+type Change struct {
+	Token      string    `json:"token"`               // resume after this change
+	ChangeType string    `json:"changeType"`          // created, updated or deleted
+	Type       string    `json:"type"`                // server, skill or plugin
+	Namespace  string    `json:"namespace,omitempty"` // skills and plugins only
+	Name       string    `json:"name"`
+	Version    string    `json:"version"`
+	ChangedAt  time.Time `json:"changedAt"`
+}
+
+// Metadata is the metadata object in changes responses.
+type Metadata struct {
+	Count     int    `json:"count"`
+	NextToken string `json:"nextToken"`
+}
+
+// Response is the response for GET /changes.
+type Response struct {
+	Changes  []Change `json:"changes"`
+	Metadata Metadata `json:"metadata"`
+}
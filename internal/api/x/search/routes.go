@@ -0,0 +1,158 @@
+// Package search provides API types and handlers for the dev.toolhive/search
+// extension endpoint, which searches servers, skills and plugins at once.
+package search
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	auditmw "github.com/stacklok/toolhive-registry-server/internal/audit"
+	"github.com/stacklok/toolhive-registry-server/internal/auth"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 100
+)
+
+// Router returns an HTTP handler for the dev.toolhive/search extension routes.
+func Router(svc service.RegistryService) http.Handler {
+	r := chi.NewRouter()
+	routes := &Routes{service: svc}
+
+	r.Get("/", auditmw.AuditedSearch(auditmw.EventEntrySearch, routes.search))
+
+	return r
+}
+
+// Routes holds dependencies for search extension handlers.
+type Routes struct {
+	service service.RegistryService
+}
+
+// search handles GET /registry/{registryName}/v0.1/x/dev.toolhive/search
+//
+// @Summary		Search registry entries
+// @Description	Full-text search over the servers, skills and plugins of a registry. Results are the
+// @Description	latest versions of the matching entries, ordered by relevance across all entry types.
+// @Tags		search
+// @Produce		json
+// @Param		registryName	path		string	true	"Registry name"
+// @Param		search		query		string	true	"Search terms, matched against names, titles, descriptions and server tool names"
+// @Param		limit		query		int		false	"Max results (default 50, max 100)"
+// @Param		cursor		query		string	false	"Pagination cursor"
+// @Success		200			{object}	Response			"Matching entries"
+// @Failure		400			{object}	map[string]string	"Bad request"
+// @Failure		403			{object}	map[string]string	"Insufficient claims"
+// @Failure		404			{object}	map[string]string	"Registry not found"
+// @Failure		500			{object}	map[string]string	"Internal server error"
+// @Security	BearerAuth
+// @Router		/registry/{registryName}/v0.1/x/dev.toolhive/search [get]
+func (routes *Routes) search(w http.ResponseWriter, r *http.Request) {
+	registryName, err := common.GetAndValidateURLParam(r, "registryName")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	query, err := parseQuery(r)
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	opts := []service.Option{
+		service.WithRegistryName(registryName),
+		service.WithSearch(query.Search),
+		service.WithLimit(query.Limit),
+	}
+	if query.Cursor != "" {
+		opts = append(opts, service.WithCursor(query.Cursor))
+	}
+	if jwtClaims := auth.ClaimsFromContext(r.Context()); jwtClaims != nil {
+		opts = append(opts, service.WithClaims(map[string]any(jwtClaims)))
+	}
+
+	result, err := routes.service.SearchEntries(r.Context(), opts...)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	resp := Response{
+		Results: serviceHitsToResponse(result.Hits),
+		Metadata: Metadata{
+			Count:      len(result.Hits),
+			NextCursor: result.NextCursor,
+		},
+	}
+
+	common.WriteJSONResponse(w, resp, http.StatusOK)
+}
+
+// parseQuery parses and validates search query parameters.
+func parseQuery(r *http.Request) (*Query, error) {
+	q := r.URL.Query()
+	query := &Query{
+		Search: strings.TrimSpace(q.Get("search")),
+		Cursor: strings.TrimSpace(q.Get("cursor")),
+		Limit:  defaultLimit,
+	}
+
+	if query.Search == "" {
+		return nil, fmt.Errorf("search parameter is required")
+	}
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid limit parameter: must be an integer")
+		}
+		if limit < 1 || limit > maxLimit {
+			return nil, fmt.Errorf("invalid limit parameter: must be between 1 and %d", maxLimit)
+		}
+		query.Limit = limit
+	}
+
+	return query, nil
+}
+
+// writeServiceError maps service-layer errors to HTTP responses.
+func writeServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidCursor):
+		common.WriteErrorResponse(w, "invalid cursor parameter", http.StatusBadRequest)
+	case errors.Is(err, service.ErrClaimsInsufficient):
+		common.WriteErrorResponse(w, "forbidden: insufficient claims for registry", http.StatusForbidden)
+	case errors.Is(err, service.ErrRegistryNotFound):
+		common.WriteErrorResponse(w, "registry not found", http.StatusNotFound)
+	default:
+		slog.ErrorContext(r.Context(), "unexpected error", "error", err)
+		common.WriteErrorResponse(w, "internal server error", http.StatusInternalServerError)
+	}
+}
+
+// serviceHitsToResponse maps a slice of service.SearchHit to search results.
+func serviceHitsToResponse(hits []*service.SearchHit) []Hit {
+	result := make([]Hit, len(hits))
+	for i, h := range hits {
+		result[i] = Hit{
+			Type:        h.EntryType,
+			Namespace:   h.Namespace,
+			Name:        h.Name,
+			Version:     h.Version,
+			Title:       h.Title,
+			Description: h.Description,
+			UpdatedAt:   h.UpdatedAt,
+		}
+	}
+	return result
+}
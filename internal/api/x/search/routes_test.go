@@ -0,0 +1,188 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+// searchRouterWithRegistryMount returns a router that mounts search under
+// /{registryName}/v0.1/x/dev.toolhive/search so URL param registryName is set.
+func searchRouterWithRegistryMount(svc service.RegistryService) http.Handler {
+	r := chi.NewRouter()
+	r.Mount("/{registryName}/v0.1/x/dev.toolhive/search", Router(svc))
+	return r
+}
+
+// applySearchEntriesOptions applies service.Option functions to a SearchEntriesOptions
+// struct so tests can inspect which options were passed by the handler.
+func applySearchEntriesOptions(t *testing.T, opts []service.Option) *service.SearchEntriesOptions {
+	t.Helper()
+	result := &service.SearchEntriesOptions{}
+	for _, opt := range opts {
+		require.NoError(t, opt(result))
+	}
+	return result
+}
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		path       string
+		setupMocks func(m *mocks.MockRegistryService)
+		wantStatus int
+		wantError  string
+	}{
+		{
+			name: "valid query returns 200",
+			path: "/myreg/v0.1/x/dev.toolhive/search?search=pdf",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().SearchEntries(gomock.Any(), gomock.Any()).
+					Return(&service.SearchEntriesResult{
+						Hits: []*service.SearchHit{
+							{EntryType: service.EntryTypeSkill, Namespace: "io.github.stacklok", Name: "pdf-processor", Version: "1.0.0"},
+						},
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing search returns 400",
+			path:       "/myreg/v0.1/x/dev.toolhive/search?search=%20",
+			wantStatus: http.StatusBadRequest,
+			wantError:  "search parameter is required",
+		},
+		{
+			name:       "invalid limit returns 400",
+			path:       "/myreg/v0.1/x/dev.toolhive/search?search=pdf&limit=notanint",
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid limit parameter: must be an integer",
+		},
+		{
+			name:       "limit over max returns 400",
+			path:       "/myreg/v0.1/x/dev.toolhive/search?search=pdf&limit=101",
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid limit parameter: must be between 1 and 100",
+		},
+		{
+			name: "invalid cursor returns 400",
+			path: "/myreg/v0.1/x/dev.toolhive/search?search=pdf&cursor=bogus",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().SearchEntries(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("%w: bad encoding", service.ErrInvalidCursor))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid cursor parameter",
+		},
+		{
+			name: "registry not found returns 404",
+			path: "/myreg/v0.1/x/dev.toolhive/search?search=pdf",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().SearchEntries(gomock.Any(), gomock.Any()).
+					Return(nil, service.ErrRegistryNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantError:  "registry not found",
+		},
+		{
+			name: "insufficient claims returns 403",
+			path: "/myreg/v0.1/x/dev.toolhive/search?search=pdf",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().SearchEntries(gomock.Any(), gomock.Any()).
+					Return(nil, service.ErrClaimsInsufficient)
+			},
+			wantStatus: http.StatusForbidden,
+			wantError:  "forbidden: insufficient claims for registry",
+		},
+		{
+			name: "service error returns 500",
+			path: "/myreg/v0.1/x/dev.toolhive/search?search=pdf",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().SearchEntries(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("database error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "empty registry name returns 400",
+			path:       "/%20/v0.1/x/dev.toolhive/search?search=pdf",
+			wantStatus: http.StatusBadRequest,
+			wantError:  "registryName cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockSvc)
+			}
+			router := searchRouterWithRegistryMount(mockSvc)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+			assert.Equal(t, tt.wantStatus, rr.Code, "status code")
+			if tt.wantError != "" {
+				var body map[string]string
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+				assert.Equal(t, tt.wantError, body["error"], "error message")
+			}
+		})
+	}
+}
+
+func TestSearchOptionsAndResponse(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	mockSvc := mocks.NewMockRegistryService(ctrl)
+
+	mockSvc.EXPECT().SearchEntries(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, opts ...service.Option) (*service.SearchEntriesResult, error) {
+			resolved := applySearchEntriesOptions(t, opts)
+			assert.Equal(t, "myreg", resolved.RegistryName)
+			assert.Equal(t, "fetch web", resolved.Search)
+			assert.Equal(t, 10, resolved.Limit)
+			require.NotNil(t, resolved.Cursor)
+			assert.Equal(t, "abc", *resolved.Cursor)
+			return &service.SearchEntriesResult{
+				Hits: []*service.SearchHit{
+					{EntryType: service.EntryTypeServer, Name: "io.github.example/fetch", Version: "1.0.0"},
+					{EntryType: service.EntryTypePlugin, Namespace: "io.github.example", Name: "web", Version: "2.0.0"},
+				},
+				NextCursor: "next",
+			}, nil
+		})
+
+	router := searchRouterWithRegistryMount(mockSvc)
+	req := httptest.NewRequest(http.MethodGet, "/myreg/v0.1/x/dev.toolhive/search?search=fetch+web&limit=10&cursor=abc", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp Response
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Len(t, resp.Results, 2)
+	assert.Equal(t, "server", resp.Results[0].Type)
+	assert.Equal(t, "io.github.example/fetch", resp.Results[0].Name)
+	assert.Equal(t, "plugin", resp.Results[1].Type)
+	assert.Equal(t, "io.github.example", resp.Results[1].Namespace)
+	assert.Equal(t, 2, resp.Metadata.Count)
+	assert.Equal(t, "next", resp.Metadata.NextCursor)
+}
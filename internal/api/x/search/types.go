@@ -0,0 +1,36 @@
+// Package search provides API types and handlers for the dev.toolhive/search
+// extension endpoint, which searches servers, skills and plugins at once.
+package search
+
+import "time"
+
+// Query holds parsed query parameters for GET /search.
+type Query struct {
+	Search string
+	Limit  int // default 50, max 100
+	Cursor string
+}
+
+// Hit is a single result in search responses. It describes the latest
+// version of a server, skill or plugin.
+type Hit struct {
+	Type        string    `json:"type"`                // server, skill or plugin
+	Namespace   string    `json:"namespace,omitempty"` // skills and plugins only
+	Name        string    `json:"name"`
+	Version     string    `json:"version"`
+	Title       string    `json:"title,omitempty"`
+	Description string    `json:"description,omitempty"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// Metadata is the metadata object in search responses.
+type Metadata struct {
+	Count      int    `json:"count"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// Response is the response for GET /search.
+type Response struct {
+	Results  []Hit    `json:"results"`
+	Metadata Metadata `json:"metadata"`
+}
@@ -49,7 +49,7 @@ type Routes struct {
 // @Tags		skills
 // @Produce		json
 // @Param		registryName	path		string	true	"Registry name"
-// @Param		search		query		string	false	"Filter by name/description substring"
+// @Param		search		query		string	false	"Full-text search on name, title and description, ordered by relevance"
 // @Param		status		query		string	false	"Filter by status (comma-separated, e.g. active,deprecated)"
 // @Param		limit		query		int		false	"Max results (default 50, max 100)"
 // @Param		cursor		query		string	false	"Pagination cursor"
@@ -35,6 +35,11 @@ func (app *RegistryApp) Start() error {
 		}
 	}()
 
+	// Start webhook notification dispatcher in background
+	if app.components.NotificationDispatcher != nil {
+		go app.components.NotificationDispatcher.Start(app.ctx)
+	}
+
 	// Start internal HTTP server in background
 	go func() {
 		slog.Info("Internal server listening", "address", app.internalHTTPServer.Addr)
@@ -51,6 +51,8 @@ func (m *mockCoordinator) Stop() error {
 	return m.stopErr
 }
 
+func (*mockCoordinator) Wake() {}
+
 func (m *mockCoordinator) wasStartCalled() bool {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -15,12 +15,17 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/stacklok/toolhive-registry-server/internal/api"
+	"github.com/stacklok/toolhive-registry-server/internal/api/webhooks"
 	"github.com/stacklok/toolhive-registry-server/internal/app/storage"
 	auditmw "github.com/stacklok/toolhive-registry-server/internal/audit"
 	"github.com/stacklok/toolhive-registry-server/internal/auth"
 	"github.com/stacklok/toolhive-registry-server/internal/config"
 	"github.com/stacklok/toolhive-registry-server/internal/kubernetes"
+	"github.com/stacklok/toolhive-registry-server/internal/notifications"
+	"github.com/stacklok/toolhive-registry-server/internal/pinning"
+	"github.com/stacklok/toolhive-registry-server/internal/scanning"
 	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/signing"
 	"github.com/stacklok/toolhive-registry-server/internal/sources"
 	pkgsync "github.com/stacklok/toolhive-registry-server/internal/sync"
 	"github.com/stacklok/toolhive-registry-server/internal/sync/coordinator"
@@ -37,8 +42,13 @@ const (
 	defaultIdleTimeout         = 60 * time.Second
 )
 
-// defaultPublicPaths are paths that never require authentication
-var defaultPublicPaths = []string{"/openapi.json", "/.well-known"}
+// defaultPublicPaths are paths that never require authentication.
+// Webhook deliveries are authenticated by their signature instead.
+var defaultPublicPaths = []string{"/openapi.json", "/.well-known", "/webhooks"}
+
+// eventStreamPathSuffix ends the paths of the registry event streams, which stay
+// open until the client disconnects and are therefore exempt from the request timeout.
+const eventStreamPathSuffix = "/x/dev.toolhive/events"
 
 // RegistryAppOptions is a function that configures the registry app builder
 type RegistryAppOptions func(*registryAppConfig) error
@@ -66,6 +76,9 @@ type registryAppConfig struct {
 	authMiddleware  func(http.Handler) http.Handler
 	authInfoHandler http.Handler
 
+	// syncTrigger wakes up the sync coordinator when a webhook queues a sync
+	syncTrigger webhooks.SyncTrigger
+
 	// Telemetry components
 	meterProvider  metric.MeterProvider
 	tracerProvider trace.TracerProvider
@@ -79,6 +92,10 @@ type registryMetricsReaderFactory interface {
 	CreateRegistryMetricsReader(ctx context.Context) (telemetry.RegistryMetricReader, error)
 }
 
+type notificationDispatcherFactory interface {
+	CreateNotificationDispatcher(ctx context.Context) (*notifications.Dispatcher, error)
+}
+
 func baseConfig(opts ...RegistryAppOptions) (*registryAppConfig, error) {
 	cfg := &registryAppConfig{
 		address:         defaultHTTPAddress,
@@ -127,6 +144,7 @@ func NewRegistryApp(
 	if err != nil {
 		return nil, fmt.Errorf("failed to build sync components: %w", err)
 	}
+	cfg.syncTrigger = syncCoordinator
 
 	// Build service components using factory
 	registryService, err := buildServiceComponents(ctx, cfg)
@@ -134,6 +152,12 @@ func NewRegistryApp(
 		return nil, fmt.Errorf("failed to build service components: %w", err)
 	}
 
+	// Build webhook notification dispatcher, if webhooks are configured
+	notificationDispatcher, err := buildNotificationDispatcher(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build notification dispatcher: %w", err)
+	}
+
 	// Build auth middleware (if not injected)
 	if cfg.authMiddleware == nil {
 		var authErr error
@@ -180,8 +204,9 @@ func NewRegistryApp(
 	return &RegistryApp{
 		config: cfg.config,
 		components: &AppComponents{
-			SyncCoordinator: syncCoordinator,
-			RegistryService: registryService,
+			SyncCoordinator:        syncCoordinator,
+			RegistryService:        registryService,
+			NotificationDispatcher: notificationDispatcher,
 		},
 		httpServer:         httpServer,
 		internalHTTPServer: internalHTTPServer,
@@ -349,9 +374,16 @@ func buildSyncComponents(
 			return nil, fmt.Errorf("failed to create sync writer: %w", err)
 		}
 
+		entryPolicy, err := scanning.NewPipeline(b.config.EntryPolicy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create entry policy: %w", err)
+		}
+
 		b.syncManager = pkgsync.NewDefaultSyncManager(
 			b.registryHandlerFactory,
 			syncWriter,
+			pkgsync.WithEntryPolicy(entryPolicy),
+			pkgsync.WithDigestPinning(pinning.NewPinner(b.config)),
 		)
 
 		// Setup Kubernetes reconciler if any registry uses Kubernetes source
@@ -431,6 +463,30 @@ func buildServiceComponents(
 	return svc, nil
 }
 
+// buildNotificationDispatcher builds the dispatcher delivering events to the
+// outbound webhooks. It returns nil when no webhook is configured.
+func buildNotificationDispatcher(
+	ctx context.Context,
+	b *registryAppConfig,
+) (*notifications.Dispatcher, error) {
+	if len(b.config.GetWebhooks()) == 0 {
+		return nil, nil
+	}
+
+	dispatcherFactory, ok := b.storageFactory.(notificationDispatcherFactory)
+	if !ok {
+		slog.Warn("Webhook notifications disabled: storage factory does not provide a notification dispatcher")
+		return nil, nil
+	}
+
+	dispatcher, err := dispatcherFactory.CreateNotificationDispatcher(ctx)
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("Webhook notifications enabled", "webhooks", len(b.config.GetWebhooks()))
+	return dispatcher, nil
+}
+
 // buildHTTPServer builds the HTTP server with router and middleware
 //
 //nolint:unparam // we prefer having a similar interface
@@ -453,7 +509,7 @@ func buildHTTPServer(
 			// r.RemoteAddr as the direct peer address; the raw X-Forwarded-For
 			// header is still preserved for forensics in audit events.
 			middleware.Recoverer,
-			middleware.Timeout(b.requestTimeout),
+			requestTimeoutMiddleware(b.requestTimeout),
 			api.LoggingMiddleware,
 			securityHeadersMiddleware,
 		}
@@ -510,6 +566,21 @@ func buildHTTPServer(
 		api.WithAuthInfoHandler(b.authInfoHandler),
 		api.WithAuthConfig(authCfg),
 	}
+	if b.syncTrigger != nil {
+		serverOpts = append(serverOpts, api.WithSyncTrigger(b.syncTrigger))
+	}
+	if b.config != nil && b.config.Replication != nil {
+		signingKey, err := b.config.Replication.GetSigningKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read replication signing key: %w", err)
+		}
+		signer, err := signing.NewSigner(signingKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid replication signing key: %w", err)
+		}
+		serverOpts = append(serverOpts, api.WithBundleSigner(signer))
+		slog.Info("Registry bundle export enabled", "keyId", signer.KeyID())
+	}
 	// Create router with middlewares
 	router := api.NewServer(svc, serverOpts...)
 
@@ -646,6 +717,22 @@ func setupKubernetesReconciler(ctx context.Context, cfg *config.Config, syncWrit
 	return nil
 }
 
+// requestTimeoutMiddleware cancels the context of requests that run longer than
+// timeout, except for the event streams.
+func requestTimeoutMiddleware(timeout time.Duration) func(http.Handler) http.Handler {
+	withTimeout := middleware.Timeout(timeout)
+	return func(next http.Handler) http.Handler {
+		timed := withTimeout(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(strings.TrimSuffix(r.URL.Path, "/"), eventStreamPathSuffix) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			timed.ServeHTTP(w, r)
+		})
+	}
+}
+
 // securityHeadersMiddleware sets baseline security response headers on every request.
 func securityHeadersMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -1074,3 +1074,31 @@ func TestSecurityHeadersInDefaultMiddleware(t *testing.T) {
 	assert.Equal(t, "nosniff", rr.Header().Get("X-Content-Type-Options"))
 	assert.Equal(t, "same-origin", rr.Header().Get("Cross-Origin-Resource-Policy"))
 }
+
+func TestRequestTimeoutMiddleware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		path         string
+		wantDeadline bool
+	}{
+		{name: "regular request", path: "/registry/default/v0.1/servers", wantDeadline: true},
+		{name: "event stream", path: "/registry/default/v0.1/x/dev.toolhive/events", wantDeadline: false},
+		{name: "event stream with trailing slash", path: "/registry/default/v0.1/x/dev.toolhive/events/", wantDeadline: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var hasDeadline bool
+			handler := requestTimeoutMiddleware(time.Minute)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				_, hasDeadline = r.Context().Deadline()
+			}))
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.wantDeadline, hasDeadline)
+		})
+	}
+}
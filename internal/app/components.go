@@ -1,6 +1,7 @@
 package app
 
 import (
+	"github.com/stacklok/toolhive-registry-server/internal/notifications"
 	"github.com/stacklok/toolhive-registry-server/internal/service"
 	"github.com/stacklok/toolhive-registry-server/internal/sync/coordinator"
 )
@@ -14,4 +15,8 @@ type AppComponents struct {
 
 	// RegistryService provides registry business logic
 	RegistryService service.RegistryService
+
+	// NotificationDispatcher delivers events to the outbound webhooks. It is nil
+	// when no webhook is configured.
+	NotificationDispatcher *notifications.Dispatcher
 }
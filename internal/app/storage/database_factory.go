@@ -11,6 +11,10 @@ import (
 
 	schemadb "github.com/stacklok/toolhive-registry-server/database"
 	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/notifications"
+	"github.com/stacklok/toolhive-registry-server/internal/pinning"
+	"github.com/stacklok/toolhive-registry-server/internal/scanning"
 	"github.com/stacklok/toolhive-registry-server/internal/service"
 	database "github.com/stacklok/toolhive-registry-server/internal/service/db"
 	"github.com/stacklok/toolhive-registry-server/internal/sync/state"
@@ -120,9 +124,33 @@ func (d *DatabaseFactory) CreateRegistryService(_ context.Context) (service.Regi
 		opts = append(opts, database.WithSkipAuthz())
 	}
 
+	// Queue entry events for the outbound webhooks, if any
+	outbox, err := notifications.NewOutbox(d.config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure webhook notifications: %w", err)
+	}
+	opts = append(opts, database.WithOutbox(outbox))
+
+	// Scan the published entries with the entry policy, if any
+	entryPolicy, err := scanning.NewPipeline(d.config.EntryPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create entry policy: %w", err)
+	}
+	opts = append(opts, database.WithEntryPolicy(entryPolicy))
+
+	// Pin the OCI packages of the published entries to digests, if configured
+	opts = append(opts, database.WithDigestPinning(pinning.NewPinner(d.config)))
+
 	return database.New(opts...)
 }
 
+// CreateNotificationDispatcher creates the dispatcher delivering the events
+// queued in the database to the configured webhooks.
+func (d *DatabaseFactory) CreateNotificationDispatcher(_ context.Context) (*notifications.Dispatcher, error) {
+	slog.Debug("Creating webhook notification dispatcher")
+	return notifications.NewDispatcher(sqlc.New(d.pool), d.config)
+}
+
 // Cleanup releases resources held by the database factory.
 // This closes the database connection pool and any active connections.
 func (d *DatabaseFactory) Cleanup() {
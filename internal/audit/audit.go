@@ -15,13 +15,16 @@ const ComponentRegistryAPI = "toolhive-registry-api"
 
 // Target field values for resource types.
 const (
-	ResourceTypeSource   = "source"
-	ResourceTypeRegistry = "registry"
-	ResourceTypeEntry    = "entry"
-	ResourceTypeUser     = "user"
-	ResourceTypeServer   = "server"
-	ResourceTypeSkill    = "skill"
-	ResourceTypePlugin   = "plugin"
+	ResourceTypeSource     = "source"
+	ResourceTypeRegistry   = "registry"
+	ResourceTypeEntry      = "entry"
+	ResourceTypeUser       = "user"
+	ResourceTypeServer     = "server"
+	ResourceTypeSkill      = "skill"
+	ResourceTypePlugin     = "plugin"
+	ResourceTypeWebhook    = "webhook"
+	ResourceTypeSubmission = "submission"
+	ResourceTypeNamespace  = "namespace"
 )
 
 // Target field keys.
@@ -59,17 +62,36 @@ const (
 	EventPluginVersionRead  = "plugin.version.read"
 )
 
+// Event types for the cross-type search and change feed extension APIs.
+const (
+	EventEntrySearch        = "entry.search"
+	EventEntryChangesList   = "entry.changes.list"
+	EventEntryChangesStream = "entry.changes.stream"
+)
+
 // Event types for audit logging — write operations.
 const (
-	EventSourceCreate   = "source.create"
-	EventSourceUpdate   = "source.update"
-	EventSourceDelete   = "source.delete"
-	EventRegistryCreate = "registry.create"
-	EventRegistryUpdate = "registry.update"
-	EventRegistryDelete = "registry.delete"
-	EventEntryPublish   = "entry.publish"
-	EventEntryDelete    = "entry.delete"
-	EventEntryClaims    = "entry.claims.update"
+	EventSourceCreate      = "source.create"
+	EventSourceUpdate      = "source.update"
+	EventSourceDelete      = "source.delete"
+	EventSourceSync        = "source.sync"
+	EventSourceWebhook     = "source.webhook"
+	EventRegistryCreate    = "registry.create"
+	EventRegistryUpdate    = "registry.update"
+	EventRegistryDelete    = "registry.delete"
+	EventEntryPublish      = "entry.publish"
+	EventEntryBulkPublish  = "entry.bulk.publish"
+	EventEntryDelete       = "entry.delete"
+	EventEntryClaims       = "entry.claims.update"
+	EventEntryStatus       = "entry.status.update"
+	EventSubmissionApprove = "submission.approve"
+	EventSubmissionReject  = "submission.reject"
+	EventNamespaceCreate   = "namespace.create"
+	EventNamespaceUpdate   = "namespace.update"
+	EventNamespaceDelete   = "namespace.delete"
+	EventOverlayCreate     = "registry.overlay.create"
+	EventOverlayUpdate     = "registry.overlay.update"
+	EventOverlayDelete     = "registry.overlay.delete"
 )
 
 // Event types for audit logging — read operations.
@@ -77,11 +99,21 @@ const (
 	EventSourceList          = "source.list"
 	EventSourceRead          = "source.read"
 	EventSourceEntriesList   = "source.entries.list"
+	EventSourceExport        = "source.export"
+	EventSourceSyncRead      = "source.sync.read"
+	EventSourceSyncsList     = "source.syncs.list"
 	EventRegistryList        = "registry.list"
 	EventRegistryRead        = "registry.read"
 	EventRegistryEntriesList = "registry.entries.list"
+	EventRegistryExport      = "registry.export"
 	EventEntryClaimsRead     = "entry.claims.read"
 	EventUserInfo            = "user.info"
+	EventWebhookDeliveryList = "webhook.deliveries.list"
+	EventSubmissionList      = "submission.list"
+	EventNamespaceList       = "namespace.list"
+	EventNamespaceRead       = "namespace.read"
+	EventOverlayList         = "registry.overlay.list"
+	EventOverlayRead         = "registry.overlay.read"
 )
 
 // Event types for audit logging — security events.
@@ -215,3 +215,23 @@ func AuditedPlugin(eventType string, h http.HandlerFunc) http.HandlerFunc {
 		h(w, r)
 	}
 }
+
+// AuditedSearch wraps handlers on .../search, .../changes and .../events paths,
+// which span every entry type. Reads the "registryName" param.
+func AuditedSearch(eventType string, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		target := map[string]string{
+			targetFieldMethod:       r.Method,
+			targetFieldPath:         r.URL.Path,
+			targetFieldResourceType: ResourceTypeEntry,
+		}
+		if registryName := chi.URLParam(r, "registryName"); registryName != "" {
+			target[targetFieldRegistryName] = registryName
+		}
+		setRouteInfo(r.Context(), &RouteInfo{
+			EventType: eventType,
+			Target:    target,
+		})
+		h(w, r)
+	}
+}
@@ -18,6 +18,8 @@ const (
 	RoleManageRegistries Role = "manageRegistries"
 	// RoleManageEntries grants access to entry management operations.
 	RoleManageEntries Role = "manageEntries"
+	// RoleReviewEntries grants access to the review of publish submissions.
+	RoleReviewEntries Role = "reviewEntries"
 )
 
 // ResolveRoles returns all roles the user has based on JWT claims and authz config.
@@ -42,6 +44,9 @@ func ResolveRoles(claims jwt.MapClaims, authzCfg *config.AuthzConfig) []Role {
 	if matchesRoleRules(claims, authzCfg.Roles.ManageEntries) {
 		roles = append(roles, RoleManageEntries)
 	}
+	if matchesRoleRules(claims, authzCfg.Roles.ReviewEntries) {
+		roles = append(roles, RoleReviewEntries)
+	}
 
 	return roles
 }
@@ -49,7 +54,7 @@ func ResolveRoles(claims jwt.MapClaims, authzCfg *config.AuthzConfig) []Role {
 // AllRoles returns every role defined in the system.
 // Used when no authz config is provided — authenticated users implicitly hold all permissions.
 func AllRoles() []Role {
-	return []Role{RoleSuperAdmin, RoleManageSources, RoleManageRegistries, RoleManageEntries}
+	return []Role{RoleSuperAdmin, RoleManageSources, RoleManageRegistries, RoleManageEntries, RoleReviewEntries}
 }
 
 // HasRole checks if the resolved roles contain the specified role.
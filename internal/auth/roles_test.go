@@ -117,6 +117,17 @@ func TestResolveRoles(t *testing.T) {
 			},
 			expected: []Role{RoleManageEntries},
 		},
+		{
+			name:   "reviewEntries role granted when config matches",
+			claims: jwt.MapClaims{"groups": []any{"security"}},
+			authzCfg: &config.AuthzConfig{
+				Roles: config.RolesConfig{
+					ManageEntries: []map[string]any{{"groups": "publishers"}},
+					ReviewEntries: []map[string]any{{"groups": "security"}},
+				},
+			},
+			expected: []Role{RoleReviewEntries},
+		},
 		{
 			name:   "superAdmin role granted when config matches",
 			claims: jwt.MapClaims{"email": "root@example.com"},
@@ -253,7 +264,8 @@ func TestAllRoles(t *testing.T) {
 	assert.Contains(t, roles, RoleManageSources)
 	assert.Contains(t, roles, RoleManageRegistries)
 	assert.Contains(t, roles, RoleManageEntries)
-	assert.Len(t, roles, 4, "AllRoles must stay in sync with the Role constants")
+	assert.Contains(t, roles, RoleReviewEntries)
+	assert.Len(t, roles, 5, "AllRoles must stay in sync with the Role constants")
 }
 
 // TestMatchesClaimValue exercises the matchesClaimValue logic through
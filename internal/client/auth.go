@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"golang.org/x/oauth2"
+
+	"github.com/stacklok/toolhive-registry-server/internal/httpclient"
+)
+
+// DeviceFlowConfig configures the OAuth 2.0 device authorization grant
+// (RFC 8628) used to obtain a bearer token interactively.
+type DeviceFlowConfig struct {
+	// Issuer is the URL of the authorization server. When empty, the first
+	// authorization server advertised by the registry server is used.
+	Issuer string
+	// ClientID is the OAuth client ID registered for the device flow
+	ClientID string
+	// Scopes are the scopes requested for the token
+	Scopes []string
+}
+
+// TokenSourceFromFile returns a token source serving the bearer token read
+// from the given file.
+func TokenSourceFromFile(path string) (oauth2.TokenSource, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is provided by the operator
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return nil, fmt.Errorf("token file %s is empty", path)
+	}
+	return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token, TokenType: "Bearer"}), nil
+}
+
+// DeviceFlowTokenSource runs the device authorization grant against the
+// authorization server of the registry server at serverURL, printing the
+// verification URL and user code to out, and returns a token source serving
+// the obtained token.
+func DeviceFlowTokenSource(
+	ctx context.Context, serverURL string, cfg DeviceFlowConfig, out io.Writer,
+) (oauth2.TokenSource, error) {
+	if cfg.ClientID == "" {
+		return nil, fmt.Errorf("a client ID is required for the device flow")
+	}
+
+	httpClient := &http.Client{Timeout: httpclient.DefaultTimeout}
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, httpClient)
+
+	issuer := cfg.Issuer
+	if issuer == "" {
+		var resource struct {
+			AuthorizationServers []string `json:"authorization_servers"`
+		}
+		metadataURL := strings.TrimSuffix(serverURL, "/") + "/.well-known/oauth-protected-resource"
+		if err := getJSON(ctx, httpClient, metadataURL, &resource); err != nil {
+			return nil, fmt.Errorf("failed to discover the authorization server: %w", err)
+		}
+		if len(resource.AuthorizationServers) == 0 {
+			return nil, fmt.Errorf("the registry server does not advertise an authorization server")
+		}
+		issuer = resource.AuthorizationServers[0]
+	}
+
+	var discovery struct {
+		TokenEndpoint               string `json:"token_endpoint"`
+		DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+	}
+	discoveryURL := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	if err := getJSON(ctx, httpClient, discoveryURL, &discovery); err != nil {
+		return nil, fmt.Errorf("failed to discover the endpoints of %s: %w", issuer, err)
+	}
+	if discovery.DeviceAuthorizationEndpoint == "" {
+		return nil, fmt.Errorf("authorization server %s does not support the device authorization grant", issuer)
+	}
+
+	oauthCfg := &oauth2.Config{
+		ClientID: cfg.ClientID,
+		Scopes:   cfg.Scopes,
+		Endpoint: oauth2.Endpoint{
+			TokenURL:      discovery.TokenEndpoint,
+			DeviceAuthURL: discovery.DeviceAuthorizationEndpoint,
+		},
+	}
+
+	deviceAuth, err := oauthCfg.DeviceAuth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start the device flow: %w", err)
+	}
+	if deviceAuth.VerificationURIComplete != "" {
+		_, _ = fmt.Fprintf(out, "To authenticate, open %s\n", deviceAuth.VerificationURIComplete)
+	} else {
+		_, _ = fmt.Fprintf(out, "To authenticate, open %s and enter the code %s\n",
+			deviceAuth.VerificationURI, deviceAuth.UserCode)
+	}
+
+	token, err := oauthCfg.DeviceAccessToken(ctx, deviceAuth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete the device flow: %w", err)
+	}
+	return oauthCfg.TokenSource(ctx, token), nil
+}
+
+// getJSON fetches a JSON document into out.
+func getJSON(ctx context.Context, httpClient *http.Client, target string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", httpclient.UserAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", target, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch %s: status %d", target, resp.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, httpclient.MaxResponseSize)).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", target, err)
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenSourceFromFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	tests := []struct {
+		name      string
+		content   *string
+		wantToken string
+		wantErr   string
+	}{
+		{
+			name:      "token is trimmed",
+			content:   ptr("  secret\n"),
+			wantToken: "secret",
+		},
+		{
+			name:    "empty file",
+			content: ptr(" \n"),
+			wantErr: "is empty",
+		},
+		{
+			name:    "missing file",
+			wantErr: "failed to read token file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(dir, tt.name)
+			if tt.content != nil {
+				require.NoError(t, os.WriteFile(path, []byte(*tt.content), 0o600))
+			}
+
+			ts, err := TokenSourceFromFile(path)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			token, err := ts.Token()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantToken, token.AccessToken)
+			assert.Equal(t, "Bearer", token.TokenType)
+		})
+	}
+}
+
+func TestDeviceFlowTokenSource(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		// authorizationServers are advertised by the registry server; the
+		// authorization server itself is added when nil
+		authorizationServers []string
+		noDeviceEndpoint     bool
+		issuer               bool
+		clientID             string
+		wantErr              string
+	}{
+		{
+			name:     "discovered from the registry server",
+			clientID: "thv-cli",
+		},
+		{
+			name:     "configured issuer",
+			issuer:   true,
+			clientID: "thv-cli",
+		},
+		{
+			name:    "client ID is required",
+			wantErr: "a client ID is required",
+		},
+		{
+			name:                 "no authorization server advertised",
+			authorizationServers: []string{},
+			clientID:             "thv-cli",
+			wantErr:              "does not advertise an authorization server",
+		},
+		{
+			name:             "no device endpoint",
+			noDeviceEndpoint: true,
+			clientID:         "thv-cli",
+			wantErr:          "does not support the device authorization grant",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var server *httptest.Server
+			var resourceRequests atomic.Int32
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /.well-known/oauth-protected-resource", func(w http.ResponseWriter, _ *http.Request) {
+				resourceRequests.Add(1)
+				servers := tt.authorizationServers
+				if servers == nil {
+					servers = []string{server.URL + "/auth"}
+				}
+				_ = json.NewEncoder(w).Encode(map[string]any{"authorization_servers": servers})
+			})
+			mux.HandleFunc("GET /auth/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+				discovery := map[string]any{"token_endpoint": server.URL + "/auth/token"}
+				if !tt.noDeviceEndpoint {
+					discovery["device_authorization_endpoint"] = server.URL + "/auth/device"
+				}
+				_ = json.NewEncoder(w).Encode(discovery)
+			})
+			mux.HandleFunc("POST /auth/device", func(w http.ResponseWriter, r *http.Request) {
+				require.NoError(t, r.ParseForm())
+				assert.Equal(t, "registry", r.Form.Get("scope"))
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"device_code":      "device-code",
+					"user_code":        "ABCD-EFGH",
+					"verification_uri": server.URL + "/auth/verify",
+					"expires_in":       60,
+					"interval":         1,
+				})
+			})
+			mux.HandleFunc("POST /auth/token", func(w http.ResponseWriter, r *http.Request) {
+				require.NoError(t, r.ParseForm())
+				assert.Equal(t, "device-code", r.Form.Get("device_code"))
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"access_token": "device-token",
+					"token_type":   "Bearer",
+				})
+			})
+			server = httptest.NewServer(mux)
+			t.Cleanup(server.Close)
+
+			cfg := DeviceFlowConfig{ClientID: tt.clientID, Scopes: []string{"registry"}}
+			if tt.issuer {
+				cfg.Issuer = server.URL + "/auth/"
+			}
+
+			var out bytes.Buffer
+			ts, err := DeviceFlowTokenSource(context.Background(), server.URL+"/", cfg, &out)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "To authenticate, open "+server.URL+"/auth/verify and enter the code ABCD-EFGH\n", out.String())
+
+			token, err := ts.Token()
+			require.NoError(t, err)
+			assert.Equal(t, "device-token", token.AccessToken)
+
+			// The registry server is not asked when the issuer is configured
+			wantResourceRequests := int32(1)
+			if tt.issuer {
+				wantResourceRequests = 0
+			}
+			assert.Equal(t, wantResourceRequests, resourceRequests.Load())
+		})
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
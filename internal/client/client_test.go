@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("secret\n"), 0o600))
+	ts, err := TokenSourceFromFile(tokenFile)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/sources/{name}", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		if r.PathValue("name") != "upstream" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": "source not found"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(service.SourceInfo{Name: "upstream", Type: "REMOTE"})
+	})
+	mux.HandleFunc("GET /v1/entries/{type}/{name}/claims", func(w http.ResponseWriter, r *http.Request) {
+		// Names containing a slash are sent as a single segment
+		assert.Equal(t, "/v1/entries/server/com.example%2Fweather/claims", r.URL.EscapedPath())
+		_, _ = w.Write([]byte(`{"claims": {"org": "example"}}`))
+	})
+	mux.HandleFunc("POST /v1/entries:bulk", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.URL.Query().Get("atomic"))
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_ = json.NewEncoder(w).Encode(service.BulkPublishResult{
+			Atomic:  true,
+			Results: []service.BulkEntryResult{{Name: "com.example/weather", Status: service.BulkEntryFailed}},
+		})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	c, err := New(server.URL+"/", WithTokenSource(ts))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	source, err := c.GetSource(ctx, "upstream")
+	require.NoError(t, err)
+	assert.Equal(t, "upstream", source.Name)
+
+	_, err = c.GetSource(ctx, "unknown")
+	require.ErrorContains(t, err, "source not found")
+	assert.True(t, IsNotFound(err))
+
+	claims, err := c.GetEntryClaims(ctx, "server", "com.example/weather")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"org": "example"}, claims)
+
+	// A rolled back atomic bulk publish returns its results along with the error
+	result, err := c.BulkPublishEntries(ctx, []byte(`{}`), true)
+	require.Error(t, err)
+	require.NotNil(t, result)
+	assert.False(t, result.Committed)
+	assert.Equal(t, service.BulkEntryFailed, result.Results[0].Status)
+
+	_, err = New("ftp://example.com")
+	require.Error(t, err)
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	manifest, err := ParseManifest([]byte(`
+sources:
+  - name: internal
+    managed: {}
+    claims:
+      org: example
+  - name: upstream
+    git:
+      repository: https://github.com/example/registry.git
+      branch: main
+      path: registry.json
+    syncPolicy:
+      interval: 30m
+registries:
+  - name: default
+    sources: [internal, upstream]
+`))
+	require.NoError(t, err)
+
+	var requests []string
+	existing := map[string]bool{"/v1/sources/upstream": true}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		var body map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if r.URL.Path == "/v1/sources/upstream" {
+			assert.Equal(t, "30m", body["syncPolicy"].(map[string]any)["interval"])
+		}
+		if !existing[r.URL.Path] {
+			w.WriteHeader(http.StatusCreated)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := New(server.URL)
+	require.NoError(t, err)
+
+	results, err := c.Apply(context.Background(), manifest)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"PUT /v1/sources/internal", "PUT /v1/sources/upstream", "PUT /v1/registries/default",
+	}, requests)
+	assert.Equal(t, []ApplyResult{
+		{Kind: KindSource, Name: "internal", Created: true},
+		{Kind: KindSource, Name: "upstream", Created: false},
+		{Kind: KindRegistry, Name: "default", Created: true},
+	}, results)
+}
+
+func TestParseManifest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{name: "empty", data: ``, wantErr: "declares no sources or registries"},
+		{name: "unknown field", data: "sources:\n  - name: a\n    managd: {}\n", wantErr: "field managd not found"},
+		{name: "unnamed source", data: "sources:\n  - managed: {}\n", wantErr: "source 0: name is required"},
+		{name: "duplicate registry", data: "registries:\n  - name: a\n  - name: a\n", wantErr: "declared more than once"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := ParseManifest([]byte(tt.data))
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
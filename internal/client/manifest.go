@@ -0,0 +1,128 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// Manifest kinds
+const (
+	// KindSource is the kind of a source applied from a manifest
+	KindSource = "source"
+	// KindRegistry is the kind of a registry applied from a manifest
+	KindRegistry = "registry"
+)
+
+// Manifest declares sources and registries to create or update on a running
+// registry server. Sources and registries are written as in the
+// configuration file of the server.
+type Manifest struct {
+	Sources    []config.SourceConfig   `yaml:"sources,omitempty"`
+	Registries []config.RegistryConfig `yaml:"registries,omitempty"`
+}
+
+// ApplyResult is the outcome of applying a source or registry of a manifest.
+type ApplyResult struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Created bool   `json:"created"`
+}
+
+// ParseManifest parses a YAML manifest, rejecting unknown fields.
+func ParseManifest(data []byte) (*Manifest, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var manifest Manifest
+	if err := decoder.Decode(&manifest); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if err := manifest.validate(); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// validate checks that every source and registry is named once.
+func (m *Manifest) validate() error {
+	if len(m.Sources) == 0 && len(m.Registries) == 0 {
+		return fmt.Errorf("manifest declares no sources or registries")
+	}
+
+	sources := make(map[string]bool, len(m.Sources))
+	for i := range m.Sources {
+		name := m.Sources[i].Name
+		if name == "" {
+			return fmt.Errorf("source %d: name is required", i)
+		}
+		if sources[name] {
+			return fmt.Errorf("source %s: declared more than once", name)
+		}
+		sources[name] = true
+	}
+
+	registries := make(map[string]bool, len(m.Registries))
+	for i := range m.Registries {
+		name := m.Registries[i].Name
+		if name == "" {
+			return fmt.Errorf("registry %d: name is required", i)
+		}
+		if registries[name] {
+			return fmt.Errorf("registry %s: declared more than once", name)
+		}
+		registries[name] = true
+	}
+	return nil
+}
+
+// Apply creates or updates the sources of the manifest, then its registries,
+// stopping at the first failure. It returns the results of the sources and
+// registries applied so far.
+func (c *Client) Apply(ctx context.Context, m *Manifest) ([]ApplyResult, error) {
+	results := make([]ApplyResult, 0, len(m.Sources)+len(m.Registries))
+
+	for i := range m.Sources {
+		source := &m.Sources[i]
+		_, created, err := c.PutSource(ctx, source.Name, sourceCreateRequest(source))
+		if err != nil {
+			return results, fmt.Errorf("failed to apply source %s: %w", source.Name, err)
+		}
+		results = append(results, ApplyResult{Kind: KindSource, Name: source.Name, Created: created})
+	}
+
+	for i := range m.Registries {
+		registry := &m.Registries[i]
+		req := &service.RegistryCreateRequest{Sources: registry.Sources, Claims: registry.Claims, Merge: registry.Merge}
+		_, created, err := c.PutRegistry(ctx, registry.Name, req)
+		if err != nil {
+			return results, fmt.Errorf("failed to apply registry %s: %w", registry.Name, err)
+		}
+		results = append(results, ApplyResult{Kind: KindRegistry, Name: registry.Name, Created: created})
+	}
+
+	return results, nil
+}
+
+// sourceCreateRequest converts a source declared as in the configuration file
+// to the body of PUT /v1/sources/{name}.
+func sourceCreateRequest(source *config.SourceConfig) *service.SourceCreateRequest {
+	return &service.SourceCreateRequest{
+		Git:        source.Git,
+		API:        source.API,
+		File:       source.File,
+		Bundle:     source.Bundle,
+		Managed:    source.Managed,
+		Kubernetes: source.Kubernetes,
+		SyncPolicy: source.SyncPolicy,
+		Filter:     source.Filter,
+		Claims:     source.Claims,
+	}
+}
@@ -0,0 +1,193 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// PublishResult is the outcome of publishing an entry.
+type PublishResult struct {
+	// Entry is the published server, skill or plugin, when it was published
+	// right away
+	Entry json.RawMessage
+	// Submission holds the entry for review, when the managed source requires
+	// approval
+	Submission *service.EntrySubmission
+}
+
+// ListSources returns the sources of the server.
+func (c *Client) ListSources(ctx context.Context) ([]service.SourceInfo, error) {
+	var resp service.SourceListResponse
+	if _, err := c.do(ctx, http.MethodGet, "/v1/sources", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Sources, nil
+}
+
+// GetSource returns the source with the given name.
+func (c *Client) GetSource(ctx context.Context, name string) (*service.SourceInfo, error) {
+	var source service.SourceInfo
+	if _, err := c.do(ctx, http.MethodGet, escapedPath("v1", "sources", name), nil, nil, &source); err != nil {
+		return nil, err
+	}
+	return &source, nil
+}
+
+// PutSource creates or updates the source with the given name. It returns
+// whether the source was created.
+func (c *Client) PutSource(
+	ctx context.Context, name string, req *service.SourceCreateRequest,
+) (*service.SourceInfo, bool, error) {
+	var source service.SourceInfo
+	status, err := c.do(ctx, http.MethodPut, escapedPath("v1", "sources", name), nil, req, &source)
+	if err != nil {
+		return nil, false, err
+	}
+	return &source, status == http.StatusCreated, nil
+}
+
+// DeleteSource deletes the source with the given name.
+func (c *Client) DeleteSource(ctx context.Context, name string) error {
+	_, err := c.do(ctx, http.MethodDelete, escapedPath("v1", "sources", name), nil, nil, nil)
+	return err
+}
+
+// ListSourceEntries returns the entries of the source with the given name.
+func (c *Client) ListSourceEntries(ctx context.Context, name string) ([]service.SourceEntryInfo, error) {
+	var resp service.SourceEntriesResponse
+	if _, err := c.do(ctx, http.MethodGet, escapedPath("v1", "sources", name, "entries"), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Entries, nil
+}
+
+// SyncSource requests a sync of the source with the given name.
+func (c *Client) SyncSource(ctx context.Context, name string) (*service.SyncRequestInfo, error) {
+	var request service.SyncRequestInfo
+	if _, err := c.do(ctx, http.MethodPost, escapedPath("v1", "sources", name, "sync"), nil, nil, &request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// ListRegistries returns the registries of the server.
+func (c *Client) ListRegistries(ctx context.Context) ([]service.RegistryInfo, error) {
+	var resp struct {
+		Registries []service.RegistryInfo `json:"registries"`
+	}
+	if _, err := c.do(ctx, http.MethodGet, "/v1/registries", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Registries, nil
+}
+
+// GetRegistry returns the registry with the given name.
+func (c *Client) GetRegistry(ctx context.Context, name string) (*service.RegistryInfo, error) {
+	var registry service.RegistryInfo
+	if _, err := c.do(ctx, http.MethodGet, escapedPath("v1", "registries", name), nil, nil, &registry); err != nil {
+		return nil, err
+	}
+	return &registry, nil
+}
+
+// PutRegistry creates or updates the registry with the given name. It
+// returns whether the registry was created.
+func (c *Client) PutRegistry(
+	ctx context.Context, name string, req *service.RegistryCreateRequest,
+) (*service.RegistryInfo, bool, error) {
+	var registry service.RegistryInfo
+	status, err := c.do(ctx, http.MethodPut, escapedPath("v1", "registries", name), nil, req, &registry)
+	if err != nil {
+		return nil, false, err
+	}
+	return &registry, status == http.StatusCreated, nil
+}
+
+// DeleteRegistry deletes the registry with the given name.
+func (c *Client) DeleteRegistry(ctx context.Context, name string) error {
+	_, err := c.do(ctx, http.MethodDelete, escapedPath("v1", "registries", name), nil, nil, nil)
+	return err
+}
+
+// ListRegistryEntries returns the entries of the registry with the given name.
+func (c *Client) ListRegistryEntries(ctx context.Context, name string) ([]service.RegistryEntryInfo, error) {
+	var resp service.RegistryEntriesResponse
+	if _, err := c.do(ctx, http.MethodGet, escapedPath("v1", "registries", name, "entries"), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Entries, nil
+}
+
+// PublishEntry publishes an entry into the managed source. request is the
+// JSON body of POST /v1/entries, sent as is so that its signature, if any,
+// still covers the entry.
+func (c *Client) PublishEntry(ctx context.Context, request []byte) (*PublishResult, error) {
+	status, data, err := c.send(ctx, http.MethodPost, "/v1/entries", nil, request)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusAccepted {
+		var submission service.EntrySubmission
+		if err := json.Unmarshal(data, &submission); err != nil {
+			return nil, fmt.Errorf("failed to decode submission: %w", err)
+		}
+		return &PublishResult{Submission: &submission}, nil
+	}
+	return &PublishResult{Entry: data}, nil
+}
+
+// BulkPublishEntries publishes the entries of an upstream registry document
+// into the managed source. The result is returned along with the error of an
+// atomic bulk publish that was rolled back.
+func (c *Client) BulkPublishEntries(ctx context.Context, document []byte, atomic bool) (*service.BulkPublishResult, error) {
+	query := url.Values{}
+	if atomic {
+		query.Set("atomic", strconv.FormatBool(atomic))
+	}
+	status, data, err := c.send(ctx, http.MethodPost, "/v1/entries:bulk", query, document)
+	if err != nil && status != http.StatusUnprocessableEntity {
+		return nil, err
+	}
+	var result service.BulkPublishResult
+	if jsonErr := json.Unmarshal(data, &result); jsonErr != nil {
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to decode bulk publish result: %w", jsonErr)
+	}
+	return &result, err
+}
+
+// DeleteEntryVersion deletes a published version of an entry.
+func (c *Client) DeleteEntryVersion(ctx context.Context, entryType, name, version string) error {
+	_, err := c.do(ctx, http.MethodDelete, escapedPath("v1", "entries", entryType, name, "versions", version),
+		nil, nil, nil)
+	return err
+}
+
+// GetEntryClaims returns the claims of a published entry.
+func (c *Client) GetEntryClaims(ctx context.Context, entryType, name string) (map[string]any, error) {
+	var resp struct {
+		Claims map[string]any `json:"claims"`
+	}
+	if _, err := c.do(ctx, http.MethodGet, escapedPath("v1", "entries", entryType, name, "claims"),
+		nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Claims, nil
+}
+
+// UpdateEntryClaims replaces the claims of a published entry.
+func (c *Client) UpdateEntryClaims(ctx context.Context, entryType, name string, claims map[string]any) error {
+	body := struct {
+		Claims map[string]any `json:"claims"`
+	}{Claims: claims}
+	_, err := c.do(ctx, http.MethodPut, escapedPath("v1", "entries", entryType, name, "claims"), nil, body, nil)
+	return err
+}
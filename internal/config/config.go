@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -18,6 +19,7 @@ import (
 	"github.com/stacklok/toolhive-core/postgres"
 
 	"github.com/stacklok/toolhive-registry-server/internal/db"
+	"github.com/stacklok/toolhive-registry-server/internal/signing"
 	"github.com/stacklok/toolhive-registry-server/internal/telemetry"
 )
 
@@ -38,6 +40,10 @@ const (
 	// Managed registries do not sync from external sources
 	SourceTypeManaged SourceType = "managed"
 
+	// SourceTypeBundle is the type for registry data imported from signed bundles
+	// exported by another registry server
+	SourceTypeBundle SourceType = "bundle"
+
 	// SourceTypeKubernetes is the type for registries that query Kubernetes deployments
 	// Kubernetes registries discover MCP servers from running Kubernetes resources
 	SourceTypeKubernetes SourceType = "kubernetes"
@@ -133,6 +139,237 @@ func (a *AuditConfig) GetMaxDataSize() int {
 	return a.MaxDataSize
 }
 
+// DefaultSyncHistoryRetention is how long sync history records are kept by default.
+const DefaultSyncHistoryRetention = 30 * 24 * time.Hour
+
+// DefaultSyncConcurrency is the default number of sources synced in parallel by each instance.
+const DefaultSyncConcurrency = 4
+
+// SyncConfig defines settings shared by the sync of all sources
+type SyncConfig struct {
+	// HistoryRetention is how long the history of sync attempts is kept per source.
+	// Accepts a Go duration string (e.g., "168h", "720h"); defaults to 720h (30 days).
+	HistoryRetention string `yaml:"historyRetention,omitempty"`
+
+	// Concurrency is the maximum number of sources synced in parallel by each
+	// server instance. Defaults to 4.
+	Concurrency int `yaml:"concurrency,omitempty"`
+}
+
+// DefaultWebhookTimeout is the default timeout of a webhook delivery attempt.
+const DefaultWebhookTimeout = 10 * time.Second
+
+// DefaultWebhookMaxAttempts is the default number of attempts of a webhook delivery.
+const DefaultWebhookMaxAttempts = 8
+
+// DefaultNotificationDeliveryRetention is how long webhook deliveries are kept by default.
+const DefaultNotificationDeliveryRetention = 30 * 24 * time.Hour
+
+// NotificationsConfig defines the outbound webhooks notified of catalog and sync events
+type NotificationsConfig struct {
+	// Webhooks are the endpoints that receive event notifications
+	Webhooks []WebhookConfig `yaml:"webhooks,omitempty"`
+
+	// DeliveryRetention is how long the delivery log of the webhooks is kept.
+	// Accepts a Go duration string (e.g., "168h", "720h"); defaults to 720h (30 days).
+	DeliveryRetention string `yaml:"deliveryRetention,omitempty"`
+}
+
+// WebhookConfig defines an outbound webhook. Events are delivered as JSON POST
+// requests signed with the shared secret, and failed deliveries are retried with
+// exponential backoff.
+type WebhookConfig struct {
+	// Name identifies the webhook in the delivery log
+	Name string `yaml:"name"`
+
+	// URL is the HTTP or HTTPS endpoint that receives the deliveries
+	URL string `yaml:"url"`
+
+	// Events lists the event types delivered to the webhook, named after the
+	// audit events (e.g., "source.sync", "entry.publish").
+	// When empty (default), all event types are delivered.
+	Events []string `yaml:"events,omitempty"`
+
+	// SecretFile is the path to a file containing the secret that signs deliveries
+	// Must be an absolute path; whitespace is trimmed from the content
+	SecretFile string `yaml:"secretFile"`
+
+	// Timeout is the timeout of a delivery attempt (e.g., "10s"). Defaults to 10s.
+	Timeout string `yaml:"timeout,omitempty"`
+
+	// MaxAttempts is the number of attempts after which a delivery is given up.
+	// Defaults to 8.
+	MaxAttempts int `yaml:"maxAttempts,omitempty"`
+}
+
+// GetSecret reads the webhook secret from SecretFile using the secure file reader.
+func (w *WebhookConfig) GetSecret() (string, error) {
+	secret, err := readSecretFromFile(w.SecretFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read webhook secret: %w", err)
+	}
+	return secret, nil
+}
+
+// GetTimeout returns the configured delivery timeout, or DefaultWebhookTimeout
+// if it is not set or cannot be parsed.
+func (w *WebhookConfig) GetTimeout() time.Duration {
+	if w.Timeout == "" {
+		return DefaultWebhookTimeout
+	}
+	d, err := time.ParseDuration(w.Timeout)
+	if err != nil || d <= 0 {
+		return DefaultWebhookTimeout
+	}
+	return d
+}
+
+// GetMaxAttempts returns the configured number of delivery attempts,
+// or DefaultWebhookMaxAttempts if it is not set.
+func (w *WebhookConfig) GetMaxAttempts() int {
+	if w.MaxAttempts <= 0 {
+		return DefaultWebhookMaxAttempts
+	}
+	return w.MaxAttempts
+}
+
+// DefaultScannerTimeout is the default timeout of the scan of an entry by a scanner.
+const DefaultScannerTimeout = 30 * time.Second
+
+// EntryPolicyConfig defines the scanners that check the entries of the sources
+// before they are stored, after filtering for syncs and for every publish into
+// the managed source
+type EntryPolicyConfig struct {
+	// Sources lists the names of the sources the policy applies to.
+	// When empty (default), it applies to every source.
+	Sources []string `yaml:"sources,omitempty"`
+
+	// Scanners are invoked in order with every entry version. The entry is
+	// blocked if any scanner blocks it.
+	Scanners []ScannerConfig `yaml:"scanners"`
+}
+
+// ScannerConfig defines an external scanner. The scanner receives the entry as a
+// JSON document and answers with a verdict: allow, annotate or block, and the
+// findings supporting it. Exactly one of Command and URL must be set.
+type ScannerConfig struct {
+	// Name identifies the scanner in the findings
+	Name string `yaml:"name"`
+
+	// Command is the local command run for every entry, as the path of the
+	// executable followed by its arguments. The entry is written to its standard
+	// input and the verdict read from its standard output.
+	Command []string `yaml:"command,omitempty"`
+
+	// URL is the HTTP or HTTPS endpoint the entry is POSTed to. The verdict is
+	// read from the response body.
+	URL string `yaml:"url,omitempty"`
+
+	// Timeout is the timeout of the scan of an entry (e.g., "30s"). Defaults to 30s.
+	Timeout string `yaml:"timeout,omitempty"`
+
+	// FailClosed blocks the entries the scanner fails to scan. By default, the
+	// failure is recorded as a finding and the entry is kept.
+	FailClosed bool `yaml:"failClosed,omitempty"`
+}
+
+// DefaultDigestResolutionTimeout is the default timeout of the resolution of the
+// tag of an OCI package to a digest.
+const DefaultDigestResolutionTimeout = 10 * time.Second
+
+// DefaultDigestPinningHosts are the hosts contacted to resolve tags when
+// digestPinning.allowedHosts is not set: the public OCI registries and the
+// token services they authenticate with.
+var DefaultDigestPinningHosts = []string{
+	"docker.io",
+	"registry-1.docker.io",
+	"auth.docker.io",
+	"ghcr.io",
+	"quay.io",
+	"gcr.io",
+	"registry.k8s.io",
+	"public.ecr.aws",
+	"mcr.microsoft.com",
+}
+
+// DigestPinningConfig defines the resolution of the tags of the OCI packages of
+// the entries to digests, after filtering for syncs and for every publish into
+// the managed source. Resolved digests are stored with the entry versions, and
+// a tag resolving to another digest than at the previous sync is flagged as
+// drifted.
+type DigestPinningConfig struct {
+	// Sources lists the names of the sources whose OCI packages are resolved.
+	// When empty (default), the packages of every source are resolved.
+	Sources []string `yaml:"sources,omitempty"`
+
+	// PlainHTTPRegistries lists the hosts of the OCI registries reached over
+	// plain HTTP instead of HTTPS, such as a local registry (e.g., "localhost:5000")
+	PlainHTTPRegistries []string `yaml:"plainHTTPRegistries,omitempty"`
+
+	// AllowedHosts lists the hosts, optionally with a port, contacted to resolve
+	// tags: the OCI registries and the token services they authenticate with.
+	// The tags of the packages of other registries are left unresolved. When
+	// empty (default), DefaultDigestPinningHosts. The PlainHTTPRegistries are
+	// always allowed.
+	AllowedHosts []string `yaml:"allowedHosts,omitempty"`
+
+	// Timeout is the timeout of the resolution of a tag (e.g., "10s"). Defaults to 10s.
+	Timeout string `yaml:"timeout,omitempty"`
+}
+
+// GetTimeout returns the configured resolution timeout, or
+// DefaultDigestResolutionTimeout if it is not set or cannot be parsed.
+func (d *DigestPinningConfig) GetTimeout() time.Duration {
+	if d.Timeout == "" {
+		return DefaultDigestResolutionTimeout
+	}
+	timeout, err := time.ParseDuration(d.Timeout)
+	if err != nil || timeout <= 0 {
+		return DefaultDigestResolutionTimeout
+	}
+	return timeout
+}
+
+// GetAllowedHosts returns the hosts contacted to resolve tags, including the
+// plain HTTP registries.
+func (d *DigestPinningConfig) GetAllowedHosts() []string {
+	hosts := d.AllowedHosts
+	if len(hosts) == 0 {
+		hosts = DefaultDigestPinningHosts
+	}
+	return append(slices.Clone(hosts), d.PlainHTTPRegistries...)
+}
+
+// GetTimeout returns the configured scan timeout, or DefaultScannerTimeout
+// if it is not set or cannot be parsed.
+func (s *ScannerConfig) GetTimeout() time.Duration {
+	if s.Timeout == "" {
+		return DefaultScannerTimeout
+	}
+	d, err := time.ParseDuration(s.Timeout)
+	if err != nil || d <= 0 {
+		return DefaultScannerTimeout
+	}
+	return d
+}
+
+// ReplicationConfig defines the export of the entries served by the registries
+// as signed bundles, imported by the bundle sources of downstream servers
+type ReplicationConfig struct {
+	// SigningKeyFile is the path to a file containing the PEM encoded private key
+	// (ECDSA, Ed25519 or RSA) signing the exported bundles. Must be an absolute path.
+	SigningKeyFile string `yaml:"signingKeyFile"`
+}
+
+// GetSigningKey reads the bundle signing key from SigningKeyFile using the secure file reader.
+func (r *ReplicationConfig) GetSigningKey() (string, error) {
+	key, err := readSecretFromFile(r.SigningKeyFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read replication signing key: %w", err)
+	}
+	return key, nil
+}
+
 // Config represents the root configuration structure
 type Config struct {
 	Sources    []SourceConfig    `yaml:"sources"`
@@ -141,6 +378,18 @@ type Config struct {
 	Auth       *AuthConfig       `yaml:"auth,omitempty"`
 	Telemetry  *telemetry.Config `yaml:"telemetry,omitempty"`
 	Audit      *AuditConfig      `yaml:"audit,omitempty"`
+	Sync       *SyncConfig       `yaml:"sync,omitempty"`
+
+	Notifications *NotificationsConfig `yaml:"notifications,omitempty"`
+
+	// EntryPolicy defines the scanners that check entries before they are stored
+	EntryPolicy *EntryPolicyConfig `yaml:"entryPolicy,omitempty"`
+
+	// DigestPinning defines the resolution of the tags of OCI packages to digests
+	DigestPinning *DigestPinningConfig `yaml:"digestPinning,omitempty"`
+
+	// Replication defines the export of the registries as signed bundles
+	Replication *ReplicationConfig `yaml:"replication,omitempty"`
 
 	// insecureAllowHTTP allows HTTP URLs for OAuth issuer URLs (development only)
 	// Can be set via THV_REGISTRY_INSECURE_URL environment variable
@@ -172,6 +421,7 @@ type SourceConfig struct {
 	Git        *GitConfig        `yaml:"git,omitempty"`
 	API        *APIConfig        `yaml:"api,omitempty"`
 	File       *FileConfig       `yaml:"file,omitempty"`
+	Bundle     *BundleConfig     `yaml:"bundle,omitempty"`
 	Managed    *ManagedConfig    `yaml:"managed,omitempty"`
 	Kubernetes *KubernetesConfig `yaml:"kubernetes,omitempty"`
 
@@ -199,6 +449,125 @@ type RegistryConfig struct {
 
 	// Sources is an ordered list of source names that feed this registry
 	Sources []string `yaml:"sources"`
+
+	// Merge defines how entries provided by several sources are merged
+	// (optional, defaults to first-wins for every field)
+	Merge *MergeConfig `yaml:"merge,omitempty"`
+
+	// RequireDigestPinning refuses the entry versions of the sources of this
+	// registry whose OCI packages are not pinned to a digest, either by the
+	// entry or by the resolution of their tag (optional, defaults to false).
+	// It is only read from the configuration file: registries created through
+	// the API cannot require digest pinning.
+	RequireDigestPinning bool `yaml:"requireDigestPinning,omitempty"`
+}
+
+// MergeStrategy selects how a registry merges a field of an entry provided by several of its sources
+type MergeStrategy string
+
+const (
+	// MergeFirstWins serves the field from the first source in the list providing the entry
+	MergeFirstWins MergeStrategy = "first-wins"
+
+	// MergeHighestVersion serves the versions of the source providing the highest
+	// semantic version of the entry
+	MergeHighestVersion MergeStrategy = "highest-version"
+
+	// MergeUnion serves the versions of the entry provided by all the sources; a version
+	// provided by several sources is served from the first one in the list
+	MergeUnion MergeStrategy = "union"
+
+	// MergeOverlay lets the last source in the list providing the same version of the
+	// entry override the field of the version served from an earlier source
+	MergeOverlay MergeStrategy = "overlay"
+)
+
+// Fields of a server version that a later source may overlay
+const (
+	// MergeFieldMeta is the publisher-provided _meta of a server version
+	MergeFieldMeta = "meta"
+
+	// MergeFieldIcons is the icons of a server version
+	MergeFieldIcons = "icons"
+
+	// MergeFieldDescription is the description of a server version
+	MergeFieldDescription = "description"
+)
+
+// MergeConfig defines the merge strategy of each field of the entries of a registry.
+// Versions accepts first-wins, highest-version or union; Meta, Icons and Description
+// accept first-wins or overlay. Overlays apply to MCP servers.
+type MergeConfig struct {
+	// Versions selects the versions served for an entry
+	Versions MergeStrategy `yaml:"versions,omitempty" json:"versions,omitempty"`
+
+	// Meta selects the source of the publisher-provided _meta of a server version
+	Meta MergeStrategy `yaml:"meta,omitempty" json:"meta,omitempty"`
+
+	// Icons selects the source of the icons of a server version
+	Icons MergeStrategy `yaml:"icons,omitempty" json:"icons,omitempty"`
+
+	// Description selects the source of the description of a server version
+	Description MergeStrategy `yaml:"description,omitempty" json:"description,omitempty"`
+}
+
+// GetVersions returns the strategy selecting the versions of an entry, defaulting to first-wins
+func (m *MergeConfig) GetVersions() MergeStrategy {
+	if m == nil || m.Versions == "" {
+		return MergeFirstWins
+	}
+	return m.Versions
+}
+
+// Overlays returns the fields a later source may override, in a stable order
+func (m *MergeConfig) Overlays() []string {
+	var fields []string
+	for _, field := range m.fieldStrategies() {
+		if field.strategy == MergeOverlay {
+			fields = append(fields, field.name)
+		}
+	}
+	return fields
+}
+
+// Validate checks that each field of the merge configuration uses a strategy it supports
+func (m *MergeConfig) Validate() error {
+	if m == nil {
+		return nil
+	}
+	switch m.Versions {
+	case "", MergeFirstWins, MergeHighestVersion, MergeUnion:
+	default:
+		return fmt.Errorf("versions: unsupported strategy '%s' (supported: %s, %s, %s)",
+			m.Versions, MergeFirstWins, MergeHighestVersion, MergeUnion)
+	}
+	for _, field := range m.fieldStrategies() {
+		switch field.strategy {
+		case "", MergeFirstWins, MergeOverlay:
+		default:
+			return fmt.Errorf("%s: unsupported strategy '%s' (supported: %s, %s)",
+				field.name, field.strategy, MergeFirstWins, MergeOverlay)
+		}
+	}
+	return nil
+}
+
+// mergeFieldStrategy is the strategy of a field that a later source may overlay
+type mergeFieldStrategy struct {
+	name     string
+	strategy MergeStrategy
+}
+
+// fieldStrategies returns the strategies of the fields that a later source may overlay
+func (m *MergeConfig) fieldStrategies() []mergeFieldStrategy {
+	if m == nil {
+		return nil
+	}
+	return []mergeFieldStrategy{
+		{name: MergeFieldMeta, strategy: m.Meta},
+		{name: MergeFieldIcons, strategy: m.Icons},
+		{name: MergeFieldDescription, strategy: m.Description},
+	}
 }
 
 // GitConfig defines Git source settings
@@ -215,14 +584,66 @@ type GitConfig struct {
 	// Commit is the Git commit SHA to use (mutually exclusive with Branch and Tag)
 	Commit string `yaml:"commit,omitempty" json:"commit,omitempty"`
 
-	// Path is the path to the registry file within the repository
+	// Path is the path to the registry file within the repository. It may also name a
+	// directory or a glob pattern, whose matching files are merged into a single registry.
 	Path string `yaml:"path,omitempty" json:"path,omitempty"`
 
 	// Auth contains optional authentication for private repositories
 	Auth *GitAuthConfig `yaml:"auth,omitempty" json:"auth,omitempty"`
+
+	// Webhook enables push webhooks that trigger an immediate sync of the source
+	Webhook *GitWebhookConfig `yaml:"webhook,omitempty" json:"webhook,omitempty"`
+}
+
+// MatchesRef reports whether a pushed Git reference (e.g., refs/heads/main) is the
+// branch or tag the source syncs from. defaultBranch is the default branch of the
+// repository, which is synced when neither Branch, Tag nor Commit is set. Sources
+// pinned to a commit never match.
+func (g *GitConfig) MatchesRef(ref, defaultBranch string) bool {
+	switch {
+	case g.Commit != "":
+		return false
+	case g.Tag != "":
+		return ref == "refs/tags/"+g.Tag
+	case g.Branch != "":
+		return ref == "refs/heads/"+g.Branch
+	default:
+		return defaultBranch != "" && ref == "refs/heads/"+defaultBranch
+	}
 }
 
-// GitAuthConfig defines authentication settings for Git repositories
+// GitWebhookConfig defines the push webhook of a Git source. GitHub, GitLab and
+// Gitea deliveries are sent to /webhooks/git/{sourceName} and must be signed
+// with the shared secret.
+type GitWebhookConfig struct {
+	// SecretFile is the path to a file containing the webhook secret
+	// Must be an absolute path; whitespace is trimmed from the content
+	SecretFile string `yaml:"secretFile" json:"secretFile"`
+}
+
+// GetSecret reads the webhook secret from SecretFile using the secure file reader.
+func (w *GitWebhookConfig) GetSecret() (string, error) {
+	secret, err := readSecretFromFile(w.SecretFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read git webhook secret: %w", err)
+	}
+	return secret, nil
+}
+
+// Validate validates the GitWebhookConfig.
+// It checks that secretFile is an absolute path that exists and is readable.
+func (w *GitWebhookConfig) Validate() error {
+	if w == nil {
+		return nil
+	}
+	if w.SecretFile == "" {
+		return fmt.Errorf("git.webhook.secretFile is required")
+	}
+	return validateGitAuthFile(w.SecretFile, "git.webhook.secretFile")
+}
+
+// GitAuthConfig defines authentication settings for Git repositories.
+// Exactly one of HTTP Basic (username and passwordFile), SSH, or GitHubApp may be used.
 type GitAuthConfig struct {
 	// Username is the Git username for HTTP Basic authentication
 	Username string `yaml:"username,omitempty" json:"username,omitempty"`
@@ -230,6 +651,98 @@ type GitAuthConfig struct {
 	// PasswordFile is the path to a file containing the Git password/token
 	// Must be an absolute path; whitespace is trimmed from the content
 	PasswordFile string `yaml:"passwordFile,omitempty" json:"passwordFile,omitempty"`
+
+	// SSH configures SSH private key authentication (e.g., deploy keys)
+	SSH *GitSSHAuthConfig `yaml:"ssh,omitempty" json:"ssh,omitempty"`
+
+	// GitHubApp configures authentication with GitHub App installation tokens
+	GitHubApp *GitHubAppAuthConfig `yaml:"githubApp,omitempty" json:"githubApp,omitempty"`
+}
+
+// DefaultGitSSHUser is the SSH user used when GitSSHAuthConfig.User is not set
+const DefaultGitSSHUser = "git"
+
+// GitSSHAuthConfig defines SSH private key authentication for Git repositories
+type GitSSHAuthConfig struct {
+	// User is the SSH user name. Defaults to "git"
+	User string `yaml:"user,omitempty" json:"user,omitempty"`
+
+	// PrivateKeyFile is the path to a file containing the PEM encoded private key
+	// Must be an absolute path
+	PrivateKeyFile string `yaml:"privateKeyFile" json:"privateKeyFile"`
+
+	// PassphraseFile is the path to a file containing the passphrase of the private key
+	// Must be an absolute path; whitespace is trimmed from the content
+	PassphraseFile string `yaml:"passphraseFile,omitempty" json:"passphraseFile,omitempty"`
+
+	// KnownHostsFile is the path to a known_hosts file used to verify the host key of the server
+	// Must be an absolute path
+	KnownHostsFile string `yaml:"knownHostsFile" json:"knownHostsFile"`
+}
+
+// GetUser returns the SSH user, or DefaultGitSSHUser if it is not set
+func (a *GitSSHAuthConfig) GetUser() string {
+	if a == nil || a.User == "" {
+		return DefaultGitSSHUser
+	}
+	return a.User
+}
+
+// GetPrivateKey reads the private key from PrivateKeyFile using the secure file reader.
+func (a *GitSSHAuthConfig) GetPrivateKey() ([]byte, error) {
+	key, err := readSecretFromFile(a.PrivateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git ssh private key: %w", err)
+	}
+	return []byte(key), nil
+}
+
+// GetPassphrase reads the private key passphrase from PassphraseFile using the secure file reader.
+// Returns empty string if PassphraseFile is empty.
+func (a *GitSSHAuthConfig) GetPassphrase() (string, error) {
+	passphrase, err := readSecretFromFile(a.PassphraseFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read git ssh passphrase: %w", err)
+	}
+	return passphrase, nil
+}
+
+// DefaultGitHubAPIURL is the GitHub API URL used when GitHubAppAuthConfig.APIURL is not set
+const DefaultGitHubAPIURL = "https://api.github.com"
+
+// GitHubAppAuthConfig defines GitHub App authentication for Git repositories.
+// Installation tokens are minted from the app's private key and refreshed before they expire.
+type GitHubAppAuthConfig struct {
+	// AppID is the ID of the GitHub App
+	AppID int64 `yaml:"appId" json:"appId"`
+
+	// InstallationID is the ID of the installation of the GitHub App that has access to the repository
+	InstallationID int64 `yaml:"installationId" json:"installationId"`
+
+	// PrivateKeyFile is the path to a file containing the PEM encoded private key of the GitHub App
+	// Must be an absolute path
+	PrivateKeyFile string `yaml:"privateKeyFile" json:"privateKeyFile"`
+
+	// APIURL is the base URL of the GitHub API, for GitHub Enterprise Server (e.g., https://github.example.com/api/v3)
+	// Defaults to https://api.github.com
+	APIURL string `yaml:"apiUrl,omitempty" json:"apiUrl,omitempty"`
+}
+
+// GetAPIURL returns the GitHub API URL, or DefaultGitHubAPIURL if it is not set
+func (a *GitHubAppAuthConfig) GetAPIURL() string {
+	if a == nil || a.APIURL == "" {
+		return DefaultGitHubAPIURL
+	}
+	return strings.TrimSuffix(a.APIURL, "/")
+}
+
+// GetPrivateKey reads the GitHub App private key from PrivateKeyFile using the secure file reader.
+func (a *GitHubAppAuthConfig) GetPrivateKey() ([]byte, error) {
+	key, err := readSecretFromFile(a.PrivateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read github app private key: %w", err)
+	}
+	return []byte(key), nil
 }
 
 // GetPassword reads the password from PasswordFile using the secure file reader.
@@ -247,8 +760,9 @@ func (a *GitAuthConfig) GetPassword() (string, error) {
 }
 
 // Validate validates the GitAuthConfig.
-// It checks that both username and passwordFile are specified together,
-// that passwordFile is an absolute path, and that the file exists and is readable.
+// It checks that only one authentication method is configured, that both
+// username and passwordFile are specified together, and that all referenced
+// files are absolute paths that exist and are readable.
 func (a *GitAuthConfig) Validate() error {
 	if a == nil {
 		return nil
@@ -257,23 +771,116 @@ func (a *GitAuthConfig) Validate() error {
 	hasUsername := a.Username != ""
 	hasPasswordFile := a.PasswordFile != ""
 
+	methods := 0
+	if hasUsername || hasPasswordFile {
+		methods++
+	}
+	if a.SSH != nil {
+		methods++
+	}
+	if a.GitHubApp != nil {
+		methods++
+	}
+	if methods > 1 {
+		return fmt.Errorf("only one of git.auth.username/passwordFile, git.auth.ssh, or git.auth.githubApp may be specified")
+	}
+
+	if a.SSH != nil {
+		return a.SSH.Validate()
+	}
+	if a.GitHubApp != nil {
+		return a.GitHubApp.Validate()
+	}
+
 	// Both must be set together, or neither
 	if hasUsername != hasPasswordFile {
 		return fmt.Errorf("git.auth.username and git.auth.passwordFile must both be specified")
 	}
 
 	if hasPasswordFile {
-		// Must be absolute path
-		if !filepath.IsAbs(a.PasswordFile) {
-			return fmt.Errorf("git.auth.passwordFile must be an absolute path")
-		}
+		return validateGitAuthFile(a.PasswordFile, "git.auth.passwordFile")
+	}
+
+	return nil
+}
 
-		// Verify the file exists and is readable
-		if _, err := os.Stat(a.PasswordFile); err != nil {
-			return fmt.Errorf("git.auth.passwordFile is not accessible: %w", err)
+// ValidateRepository checks that the configured authentication method can be
+// used with the repository URL: SSH keys only work with SSH URLs, and GitHub App
+// installation tokens are only sent over HTTPS.
+func (a *GitAuthConfig) ValidateRepository(repository string) error {
+	if a == nil {
+		return nil
+	}
+	isHTTP := strings.HasPrefix(repository, "https://") || strings.HasPrefix(repository, "http://")
+	if a.SSH != nil && isHTTP {
+		return fmt.Errorf("git.auth.ssh requires an SSH repository URL")
+	}
+	if a.GitHubApp != nil && !strings.HasPrefix(repository, "https://") {
+		return fmt.Errorf("git.auth.githubApp requires an HTTPS repository URL")
+	}
+	return nil
+}
+
+// Validate validates the GitSSHAuthConfig.
+// It checks that privateKeyFile and knownHostsFile are specified, and that all
+// referenced files are absolute paths that exist and are readable.
+func (a *GitSSHAuthConfig) Validate() error {
+	if a.PrivateKeyFile == "" {
+		return fmt.Errorf("git.auth.ssh.privateKeyFile is required")
+	}
+	if err := validateGitAuthFile(a.PrivateKeyFile, "git.auth.ssh.privateKeyFile"); err != nil {
+		return err
+	}
+	// Host keys are always verified, there is no insecure fallback
+	if a.KnownHostsFile == "" {
+		return fmt.Errorf("git.auth.ssh.knownHostsFile is required")
+	}
+	if err := validateGitAuthFile(a.KnownHostsFile, "git.auth.ssh.knownHostsFile"); err != nil {
+		return err
+	}
+	if a.PassphraseFile != "" {
+		return validateGitAuthFile(a.PassphraseFile, "git.auth.ssh.passphraseFile")
+	}
+	return nil
+}
+
+// Validate validates the GitHubAppAuthConfig.
+// It checks that the app and installation IDs are set, that privateKeyFile is an
+// absolute path that exists and is readable, and that apiUrl is a valid URL.
+func (a *GitHubAppAuthConfig) Validate() error {
+	if a.AppID <= 0 {
+		return fmt.Errorf("git.auth.githubApp.appId is required")
+	}
+	if a.InstallationID <= 0 {
+		return fmt.Errorf("git.auth.githubApp.installationId is required")
+	}
+	if a.PrivateKeyFile == "" {
+		return fmt.Errorf("git.auth.githubApp.privateKeyFile is required")
+	}
+	if err := validateGitAuthFile(a.PrivateKeyFile, "git.auth.githubApp.privateKeyFile"); err != nil {
+		return err
+	}
+	if a.APIURL != "" {
+		u, err := url.Parse(a.APIURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("git.auth.githubApp.apiUrl must be a valid HTTP or HTTPS URL")
 		}
 	}
+	return nil
+}
+
+// validateGitAuthFile checks that a Git credential file is an absolute path
+// that exists and is readable. field is the config path used in errors.
+func validateGitAuthFile(path, field string) error {
+	// Must be absolute path
+	if !filepath.IsAbs(path) {
+		return fmt.Errorf("%s must be an absolute path", field)
+	}
 
+	// Verify the file exists and is readable
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("%s is not accessible: %w", field, err)
+	}
 	return nil
 }
 
@@ -292,6 +899,45 @@ type APIConfig struct {
 	// Defaults to 10s if not specified
 	// Useful for public or occasionally-slow upstreams where the default is too aggressive
 	Timeout string `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+
+	// Incremental enables incremental syncs that only fetch servers updated since
+	// the last successful sync (via the upstream updated_since query parameter)
+	// and merge them into the stored data instead of replacing it
+	Incremental *APIIncrementalConfig `yaml:"incremental,omitempty" json:"incremental,omitempty"`
+}
+
+// DefaultFullSyncInterval is the default interval between full reconciliation
+// syncs for API sources with incremental sync enabled.
+const DefaultFullSyncInterval = 24 * time.Hour
+
+// APIIncrementalConfig defines incremental sync settings for API sources
+type APIIncrementalConfig struct {
+	// Enabled turns on incremental syncs for the source
+	Enabled bool `yaml:"enabled" json:"enabled"`
+
+	// FullSyncInterval is how often a full reconciliation sync runs in place of an
+	// incremental one, so that entries dropped by the filter or missed upstream are removed
+	// Accepts a Go duration string (e.g., "12h", "24h"); defaults to 24h if not specified
+	FullSyncInterval string `yaml:"fullSyncInterval,omitempty" json:"fullSyncInterval,omitempty"`
+}
+
+// IsIncrementalEnabled returns true when incremental sync is enabled for the API source.
+func (a *APIConfig) IsIncrementalEnabled() bool {
+	return a != nil && a.Incremental != nil && a.Incremental.Enabled
+}
+
+// GetFullSyncInterval returns the configured full reconciliation interval,
+// or DefaultFullSyncInterval if it is not set or cannot be parsed.
+// Invalid values are rejected at config load (see ValidateAPIIncremental).
+func (a *APIIncrementalConfig) GetFullSyncInterval() time.Duration {
+	if a == nil || a.FullSyncInterval == "" {
+		return DefaultFullSyncInterval
+	}
+	d, err := time.ParseDuration(a.FullSyncInterval)
+	if err != nil || d <= 0 {
+		return DefaultFullSyncInterval
+	}
+	return d
 }
 
 // FileConfig defines file source configuration
@@ -318,11 +964,128 @@ type FileConfig struct {
 	Timeout string `yaml:"timeout,omitempty" json:"timeout,omitempty"`
 }
 
+// BundleConfig defines bundle source configuration. A bundle source imports the
+// signed bundles exported by a registry of another server, such as across an
+// air gap. Path may name a directory holding a full bundle followed by the delta
+// bundles exported since, which are applied in order.
+type BundleConfig struct {
+	// Path is the path to a bundle file, or to a directory of bundle files, on the
+	// local filesystem. Mutually exclusive with URL - exactly one must be specified
+	Path string `yaml:"path,omitempty" json:"path,omitempty"`
+
+	// URL is the HTTP/HTTPS URL to fetch a full bundle from
+	// Mutually exclusive with Path - exactly one must be specified
+	URL string `yaml:"url,omitempty" json:"url,omitempty"`
+
+	// Timeout is the timeout for HTTP requests when using URL
+	// Defaults to 30s if not specified
+	Timeout string `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+
+	// PublicKeys are the PEM encoded public keys (ECDSA, Ed25519 or RSA) trusted
+	// to sign the imported bundles. Bundles signed by another key are refused.
+	PublicKeys []string `yaml:"publicKeys" json:"publicKeys"`
+}
+
+// Validate validates the bundle source configuration. Exactly one of path or
+// url must be set, and at least one supported public key must be trusted.
+func (b *BundleConfig) Validate() error {
+	hasPath := b.Path != ""
+	hasURL := b.URL != ""
+
+	if !hasPath && !hasURL {
+		return fmt.Errorf("bundle.path or bundle.url is required")
+	}
+	if hasPath && hasURL {
+		return fmt.Errorf("bundle.path and bundle.url are mutually exclusive")
+	}
+
+	if hasURL {
+		if err := validateSourceURL(b.URL, "bundle.url"); err != nil {
+			return err
+		}
+	}
+	if b.Timeout != "" {
+		if !hasURL {
+			return fmt.Errorf("bundle.timeout is only applicable when bundle.url is specified")
+		}
+		if _, err := time.ParseDuration(b.Timeout); err != nil {
+			return fmt.Errorf("bundle.timeout must be a valid duration (e.g., '30s', '1m'): %w", err)
+		}
+	}
+
+	if len(b.PublicKeys) == 0 {
+		return fmt.Errorf("bundle.publicKeys must not be empty")
+	}
+	for i, key := range b.PublicKeys {
+		if err := signing.ValidatePublicKey(key); err != nil {
+			return fmt.Errorf("bundle.publicKeys[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
 // ManagedConfig defines configuration for managed registries
 // Managed registries are directly manipulated via API and do not sync from external sources
-// Note: Initially empty, may be used as a placeholder for future configuration options
 type ManagedConfig struct {
-	// Future fields can be added here as needed
+	// RequireApproval holds publishes as pending submissions, invisible to
+	// consumers until a user with the reviewEntries role approves them
+	RequireApproval bool `yaml:"requireApproval,omitempty" json:"requireApproval,omitempty"`
+
+	// Signing lists the keys trusted to sign the entries published under
+	// namespace prefixes. Publishes may attach a signature over the entry,
+	// verified against the keys of the longest matching prefix.
+	Signing []SigningConfig `yaml:"signing,omitempty" json:"signing,omitempty"`
+}
+
+// Validate validates the managed source configuration. Every signing namespace
+// must be a namespace prefix, matched at a "." or "/" boundary, and list at
+// least one supported public key.
+func (m *ManagedConfig) Validate() error {
+	if m == nil {
+		return nil
+	}
+	namespaces := make(map[string]bool, len(m.Signing))
+	for i, signingCfg := range m.Signing {
+		if signingCfg.Namespace == "" {
+			return fmt.Errorf("managed.signing[%d].namespace is required", i)
+		}
+		if err := signing.ValidateNamespace(signingCfg.Namespace); err != nil {
+			return fmt.Errorf("managed.signing[%d].namespace: %w", i, err)
+		}
+		if namespaces[signingCfg.Namespace] {
+			return fmt.Errorf("managed.signing[%d]: duplicate namespace '%s'", i, signingCfg.Namespace)
+		}
+		namespaces[signingCfg.Namespace] = true
+
+		if len(signingCfg.PublicKeys) == 0 {
+			return fmt.Errorf("managed.signing[%d].publicKeys must not be empty", i)
+		}
+		for j, key := range signingCfg.PublicKeys {
+			if err := signing.ValidatePublicKey(key); err != nil {
+				return fmt.Errorf("managed.signing[%d].publicKeys[%d]: %w", i, j, err)
+			}
+		}
+	}
+	return nil
+}
+
+// SigningConfig defines the keys trusted to sign the entries published under a
+// namespace prefix
+type SigningConfig struct {
+	// Namespace is the prefix of the names of the entries the keys are trusted
+	// for, such as "com.example/". It covers the names continuing it after a
+	// "." or "/", so "com.example" does not cover "com.examplecorp/weather".
+	// Skill and plugin names are prefixed with their namespace, e.g.
+	// "io.github.acme/calendar".
+	Namespace string `yaml:"namespace" json:"namespace"`
+
+	// PublicKeys are the PEM encoded public keys (ECDSA, Ed25519 or RSA)
+	// trusted to sign the entries of the namespace
+	PublicKeys []string `yaml:"publicKeys,omitempty" json:"publicKeys,omitempty"`
+
+	// RequireSignature rejects the publishes into the namespace without a
+	// valid signature
+	RequireSignature bool `yaml:"requireSignature,omitempty" json:"requireSignature,omitempty"`
 }
 
 // KubernetesConfig defines configuration for Kubernetes-based registries.
@@ -342,8 +1105,9 @@ type SyncPolicyConfig struct {
 
 // FilterConfig defines filtering rules for registry entries
 type FilterConfig struct {
-	Names *NameFilterConfig `yaml:"names,omitempty" json:"names,omitempty"`
-	Tags  *TagFilterConfig  `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Names       *NameFilterConfig       `yaml:"names,omitempty" json:"names,omitempty"`
+	Tags        *TagFilterConfig        `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Expressions *ExpressionFilterConfig `yaml:"expressions,omitempty" json:"expressions,omitempty"`
 }
 
 // NameFilterConfig defines name-based filtering
@@ -358,6 +1122,13 @@ type TagFilterConfig struct {
 	Exclude []string `yaml:"exclude,omitempty" json:"exclude,omitempty"`
 }
 
+// ExpressionFilterConfig defines filtering with CEL expressions evaluated
+// against each server, skill and plugin
+type ExpressionFilterConfig struct {
+	Include []string `yaml:"include,omitempty" json:"include,omitempty"`
+	Exclude []string `yaml:"exclude,omitempty" json:"exclude,omitempty"`
+}
+
 // AuthMode represents the authentication mode
 type AuthMode string
 
@@ -384,6 +1155,7 @@ type RolesConfig struct {
 	ManageSources    []map[string]any `yaml:"manageSources,omitempty"`
 	ManageRegistries []map[string]any `yaml:"manageRegistries,omitempty"`
 	ManageEntries    []map[string]any `yaml:"manageEntries,omitempty"`
+	ReviewEntries    []map[string]any `yaml:"reviewEntries,omitempty"`
 }
 
 // AuthConfig defines authentication configuration for the registry server
@@ -854,6 +1626,51 @@ func (c *Config) IsAuditEnabled() bool {
 	return c != nil && c.Audit != nil && c.Audit.Enabled
 }
 
+// GetSyncHistoryRetention returns the configured sync history retention,
+// or DefaultSyncHistoryRetention if it is not set or cannot be parsed.
+// Invalid values are rejected at config load (see validateSync).
+func (c *Config) GetSyncHistoryRetention() time.Duration {
+	if c == nil || c.Sync == nil || c.Sync.HistoryRetention == "" {
+		return DefaultSyncHistoryRetention
+	}
+	d, err := time.ParseDuration(c.Sync.HistoryRetention)
+	if err != nil || d <= 0 {
+		return DefaultSyncHistoryRetention
+	}
+	return d
+}
+
+// GetSyncConcurrency returns the configured number of parallel sync workers,
+// or DefaultSyncConcurrency if it is not set.
+func (c *Config) GetSyncConcurrency() int {
+	if c == nil || c.Sync == nil || c.Sync.Concurrency <= 0 {
+		return DefaultSyncConcurrency
+	}
+	return c.Sync.Concurrency
+}
+
+// GetWebhooks returns the configured outbound webhooks.
+func (c *Config) GetWebhooks() []WebhookConfig {
+	if c == nil || c.Notifications == nil {
+		return nil
+	}
+	return c.Notifications.Webhooks
+}
+
+// GetNotificationDeliveryRetention returns the configured webhook delivery retention,
+// or DefaultNotificationDeliveryRetention if it is not set or cannot be parsed.
+// Invalid values are rejected at config load (see validateNotifications).
+func (c *Config) GetNotificationDeliveryRetention() time.Duration {
+	if c == nil || c.Notifications == nil || c.Notifications.DeliveryRetention == "" {
+		return DefaultNotificationDeliveryRetention
+	}
+	d, err := time.ParseDuration(c.Notifications.DeliveryRetention)
+	if err != nil || d <= 0 {
+		return DefaultNotificationDeliveryRetention
+	}
+	return d
+}
+
 // IsCompressionEnabled returns true when HTTP response compression is enabled
 // via the THV_REGISTRY_COMPRESS_RESPONSE environment variable.
 func (c *Config) IsCompressionEnabled() bool {
@@ -920,6 +1737,21 @@ func (c *Config) validate() error {
 		return err
 	}
 
+	// Validate entry policy if present
+	if err := c.validateEntryPolicy(sourceNames); err != nil {
+		return err
+	}
+
+	// Validate digest pinning if present
+	if err := c.validateDigestPinning(sourceNames); err != nil {
+		return err
+	}
+
+	// Validate replication if present
+	if err := c.validateReplication(); err != nil {
+		return err
+	}
+
 	// Validate storage configuration
 	if err := c.validateStorageConfig(); err != nil {
 		return err
@@ -930,6 +1762,16 @@ func (c *Config) validate() error {
 		return err
 	}
 
+	// Validate sync configuration if present
+	if err := c.validateSync(); err != nil {
+		return err
+	}
+
+	// Validate notifications configuration if present
+	if err := c.validateNotifications(); err != nil {
+		return err
+	}
+
 	// Validate auth configuration if present
 	return c.validateAuth()
 }
@@ -987,6 +1829,10 @@ func (c *Config) validateRegistries(sourceNames map[string]bool) error {
 		if err := validateClaims(reg.Claims, fmt.Sprintf("registries[%d] (%s)", i, reg.Name)); err != nil {
 			return err
 		}
+
+		if err := reg.Merge.Validate(); err != nil {
+			return fmt.Errorf("registries[%d] (%s): merge.%w", i, reg.Name, err)
+		}
 	}
 	return nil
 }
@@ -1038,6 +1884,9 @@ func validateSourceTypeCount(src *SourceConfig, prefix string) error {
 	if src.File != nil {
 		configCount++
 	}
+	if src.Bundle != nil {
+		configCount++
+	}
 	if src.Managed != nil {
 		configCount++
 	}
@@ -1046,10 +1895,10 @@ func validateSourceTypeCount(src *SourceConfig, prefix string) error {
 	}
 
 	if configCount == 0 {
-		return fmt.Errorf("%s: one of git, api, file, managed, or kubernetes configuration must be specified", prefix)
+		return fmt.Errorf("%s: one of git, api, file, bundle, managed, or kubernetes configuration must be specified", prefix)
 	}
 	if configCount > 1 {
-		return fmt.Errorf("%s: only one of git, api, file, managed, or kubernetes configuration may be specified", prefix)
+		return fmt.Errorf("%s: only one of git, api, file, bundle, managed, or kubernetes configuration may be specified", prefix)
 	}
 
 	return nil
@@ -1069,6 +1918,18 @@ func validateSourceSpecificConfig(src *SourceConfig, prefix string) error {
 		return validateFileConfig(src.File, prefix)
 	}
 
+	if src.Bundle != nil {
+		if err := src.Bundle.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", prefix, err)
+		}
+	}
+
+	if src.Managed != nil {
+		if err := src.Managed.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", prefix, err)
+		}
+	}
+
 	return nil
 }
 
@@ -1091,6 +1952,13 @@ func validateGitConfig(git *GitConfig, prefix string) error {
 		if err := git.Auth.Validate(); err != nil {
 			return fmt.Errorf("%s: %w", prefix, err)
 		}
+		if err := git.Auth.ValidateRepository(git.Repository); err != nil {
+			return fmt.Errorf("%s: %w", prefix, err)
+		}
+	}
+
+	if err := git.Webhook.Validate(); err != nil {
+		return fmt.Errorf("%s: %w", prefix, err)
 	}
 	return nil
 }
@@ -1131,6 +1999,27 @@ func validateAPIConfig(api *APIConfig, prefix string) error {
 		return fmt.Errorf("%s: %w", prefix, err)
 	}
 
+	if err := ValidateAPIIncremental(api.Incremental); err != nil {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+
+	return nil
+}
+
+// ValidateAPIIncremental validates an optional api.incremental block. A nil block
+// or an empty fullSyncInterval is valid. Otherwise fullSyncInterval must be a Go
+// duration greater than zero. It is shared by the config-load and API validation paths.
+func ValidateAPIIncremental(incremental *APIIncrementalConfig) error {
+	if incremental == nil || incremental.FullSyncInterval == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(incremental.FullSyncInterval)
+	if err != nil {
+		return fmt.Errorf("api.incremental.fullSyncInterval must be a valid duration (e.g., '12h', '24h'): %w", err)
+	}
+	if d <= 0 {
+		return fmt.Errorf("api.incremental.fullSyncInterval must be greater than zero")
+	}
 	return nil
 }
 
@@ -1166,18 +2055,23 @@ func validateFileConfig(file *FileConfig, prefix string) error {
 
 // validateFileURL validates the URL for file sources
 func validateFileURL(rawURL string, prefix string) error {
+	return validateSourceURL(rawURL, prefix+": file.url")
+}
+
+// validateSourceURL validates the URL a source fetches its data from
+func validateSourceURL(rawURL string, field string) error {
 	parsedURL, err := url.Parse(rawURL)
 	if err != nil {
-		return fmt.Errorf("%s: file.url is invalid: %w", prefix, err)
+		return fmt.Errorf("%s is invalid: %w", field, err)
 	}
 
 	if !parsedURL.IsAbs() || parsedURL.Host == "" {
-		return fmt.Errorf("%s: file.url must be an absolute URL with host", prefix)
+		return fmt.Errorf("%s must be an absolute URL with host", field)
 	}
 
 	// Only allow HTTP and HTTPS schemes
 	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
-		return fmt.Errorf("%s: file.url must use http or https scheme", prefix)
+		return fmt.Errorf("%s must use http or https scheme", field)
 	}
 
 	return nil
@@ -1254,6 +2148,9 @@ func (s *SourceConfig) GetType() SourceType {
 	if s.File != nil {
 		return SourceTypeFile
 	}
+	if s.Bundle != nil {
+		return SourceTypeBundle
+	}
 	if s.Managed != nil {
 		return SourceTypeManaged
 	}
@@ -1300,6 +2197,216 @@ func (c *Config) validateAudit() error {
 	return nil
 }
 
+// validateSync validates the sync configuration if present.
+func (c *Config) validateSync() error {
+	if c.Sync == nil {
+		return nil
+	}
+	if c.Sync.Concurrency < 0 {
+		return fmt.Errorf("sync.concurrency must not be negative")
+	}
+	if c.Sync.HistoryRetention == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(c.Sync.HistoryRetention)
+	if err != nil {
+		return fmt.Errorf("sync.historyRetention must be a valid duration (e.g., '168h', '720h'): %w", err)
+	}
+	if d <= 0 {
+		return fmt.Errorf("sync.historyRetention must be greater than zero")
+	}
+	return nil
+}
+
+// validateNotifications validates the outbound webhooks configuration if present.
+func (c *Config) validateNotifications() error {
+	if c.Notifications == nil {
+		return nil
+	}
+	if c.Notifications.DeliveryRetention != "" {
+		d, err := time.ParseDuration(c.Notifications.DeliveryRetention)
+		if err != nil {
+			return fmt.Errorf("notifications.deliveryRetention must be a valid duration (e.g., '168h', '720h'): %w", err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("notifications.deliveryRetention must be greater than zero")
+		}
+	}
+
+	names := make(map[string]bool, len(c.Notifications.Webhooks))
+	for i := range c.Notifications.Webhooks {
+		webhook := &c.Notifications.Webhooks[i]
+		prefix := fmt.Sprintf("notifications.webhooks[%d]", i)
+		if !IsValidDNSSubdomain(webhook.Name) {
+			return fmt.Errorf("%s: name must be a valid DNS subdomain", prefix)
+		}
+		if names[webhook.Name] {
+			return fmt.Errorf("%s: duplicate webhook name '%s'", prefix, webhook.Name)
+		}
+		names[webhook.Name] = true
+
+		if err := validateWebhookConfig(webhook, prefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateEntryPolicy validates the entry policy configuration if present.
+func (c *Config) validateEntryPolicy(sourceNames map[string]bool) error {
+	if c.EntryPolicy == nil {
+		return nil
+	}
+	for _, name := range c.EntryPolicy.Sources {
+		if !sourceNames[name] {
+			return fmt.Errorf("entryPolicy: references unknown source '%s'", name)
+		}
+	}
+	if len(c.EntryPolicy.Scanners) == 0 {
+		return fmt.Errorf("entryPolicy: at least one scanner is required")
+	}
+
+	names := make(map[string]bool, len(c.EntryPolicy.Scanners))
+	for i := range c.EntryPolicy.Scanners {
+		scanner := &c.EntryPolicy.Scanners[i]
+		prefix := fmt.Sprintf("entryPolicy.scanners[%d]", i)
+		if !IsValidDNSSubdomain(scanner.Name) {
+			return fmt.Errorf("%s: name must be a valid DNS subdomain", prefix)
+		}
+		if names[scanner.Name] {
+			return fmt.Errorf("%s: duplicate scanner name '%s'", prefix, scanner.Name)
+		}
+		names[scanner.Name] = true
+
+		if err := validateScannerConfig(scanner, prefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateDigestPinning validates the digest pinning configuration if present.
+func (c *Config) validateDigestPinning(sourceNames map[string]bool) error {
+	if c.DigestPinning == nil {
+		return nil
+	}
+	for _, name := range c.DigestPinning.Sources {
+		if !sourceNames[name] {
+			return fmt.Errorf("digestPinning: references unknown source '%s'", name)
+		}
+	}
+	for i, host := range c.DigestPinning.PlainHTTPRegistries {
+		if host == "" || strings.ContainsAny(host, "/ ") {
+			return fmt.Errorf("digestPinning.plainHTTPRegistries[%d]: must be a host, optionally with a port", i)
+		}
+	}
+	for i, host := range c.DigestPinning.AllowedHosts {
+		if host == "" || strings.ContainsAny(host, "/ ") {
+			return fmt.Errorf("digestPinning.allowedHosts[%d]: must be a host, optionally with a port", i)
+		}
+	}
+	if c.DigestPinning.Timeout != "" {
+		d, err := time.ParseDuration(c.DigestPinning.Timeout)
+		if err != nil {
+			return fmt.Errorf("digestPinning: timeout must be a valid duration (e.g., '10s', '1m'): %w", err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("digestPinning: timeout must be greater than zero")
+		}
+	}
+	return nil
+}
+
+// validateReplication validates the replication configuration if present.
+func (c *Config) validateReplication() error {
+	if c.Replication == nil {
+		return nil
+	}
+	if c.Replication.SigningKeyFile == "" {
+		return fmt.Errorf("replication.signingKeyFile is required")
+	}
+	if !filepath.IsAbs(c.Replication.SigningKeyFile) {
+		return fmt.Errorf("replication.signingKeyFile must be an absolute path")
+	}
+	return nil
+}
+
+// validateScannerConfig validates a single scanner configuration
+func validateScannerConfig(scanner *ScannerConfig, prefix string) error {
+	switch {
+	case len(scanner.Command) > 0 && scanner.URL != "":
+		return fmt.Errorf("%s: command and url are mutually exclusive", prefix)
+	case len(scanner.Command) > 0:
+		if !filepath.IsAbs(scanner.Command[0]) {
+			return fmt.Errorf("%s: command must start with the absolute path of the executable", prefix)
+		}
+	case scanner.URL != "":
+		parsedURL, err := url.Parse(scanner.URL)
+		if err != nil {
+			return fmt.Errorf("%s: url is invalid: %w", prefix, err)
+		}
+		if !parsedURL.IsAbs() || parsedURL.Host == "" {
+			return fmt.Errorf("%s: url must be an absolute URL with host", prefix)
+		}
+		if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+			return fmt.Errorf("%s: url must use http or https scheme", prefix)
+		}
+	default:
+		return fmt.Errorf("%s: either command or url is required", prefix)
+	}
+
+	if scanner.Timeout != "" {
+		d, err := time.ParseDuration(scanner.Timeout)
+		if err != nil {
+			return fmt.Errorf("%s: timeout must be a valid duration (e.g., '30s', '1m'): %w", prefix, err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("%s: timeout must be greater than zero", prefix)
+		}
+	}
+	return nil
+}
+
+// validateWebhookConfig validates a single outbound webhook configuration
+func validateWebhookConfig(webhook *WebhookConfig, prefix string) error {
+	parsedURL, err := url.Parse(webhook.URL)
+	if err != nil {
+		return fmt.Errorf("%s: url is invalid: %w", prefix, err)
+	}
+	if !parsedURL.IsAbs() || parsedURL.Host == "" {
+		return fmt.Errorf("%s: url must be an absolute URL with host", prefix)
+	}
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return fmt.Errorf("%s: url must use http or https scheme", prefix)
+	}
+
+	if webhook.SecretFile == "" {
+		return fmt.Errorf("%s: secretFile is required", prefix)
+	}
+	if err := validateGitAuthFile(webhook.SecretFile, prefix+": secretFile"); err != nil {
+		return err
+	}
+
+	for _, event := range webhook.Events {
+		if event == "" {
+			return fmt.Errorf("%s: events must not contain empty event types", prefix)
+		}
+	}
+	if webhook.Timeout != "" {
+		d, err := time.ParseDuration(webhook.Timeout)
+		if err != nil {
+			return fmt.Errorf("%s: timeout must be a valid duration (e.g., '10s', '1m'): %w", prefix, err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("%s: timeout must be greater than zero", prefix)
+		}
+	}
+	if webhook.MaxAttempts < 0 {
+		return fmt.Errorf("%s: maxAttempts must not be negative", prefix)
+	}
+	return nil
+}
+
 func (c *Config) validateAuth() error {
 	if c.Auth == nil {
 		return errors.New("auth configuration is required")
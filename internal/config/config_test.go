@@ -1,6 +1,11 @@
 package config
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"os"
 	"path/filepath"
 	"testing"
@@ -438,7 +443,7 @@ func TestConfigValidate(t *testing.T) {
 				},
 			},
 			wantErr: true,
-			errMsg:  "one of git, api, file, managed, or kubernetes configuration must be specified",
+			errMsg:  "one of git, api, file, bundle, managed, or kubernetes configuration must be specified",
 		},
 		{
 			name: "missing_file_path_or_url",
@@ -658,6 +663,64 @@ func TestConfigValidate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "api.timeout must not exceed",
 		},
+		{
+			name: "valid_api_incremental",
+			config: &Config{
+				Sources: []SourceConfig{
+					{
+						Name: "test-registry",
+						API: &APIConfig{
+							Endpoint: "https://registry.modelcontextprotocol.io",
+							Incremental: &APIIncrementalConfig{
+								Enabled:          true,
+								FullSyncInterval: "12h",
+							},
+						},
+						SyncPolicy: &SyncPolicyConfig{
+							Interval: "1h",
+						},
+					},
+				},
+				Registries: []RegistryConfig{
+					{Name: "default", Sources: []string{"test-registry"}},
+				},
+				Database: &DatabaseConfig{
+					Host:     "localhost",
+					Port:     5432,
+					User:     "testuser",
+					Database: "testdb",
+				},
+				Auth: &AuthConfig{
+					Mode: AuthModeAnonymous,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid_api_incremental_full_sync_interval",
+			config: &Config{
+				Sources: []SourceConfig{
+					{
+						Name: "test-registry",
+						API: &APIConfig{
+							Endpoint: "https://registry.modelcontextprotocol.io",
+							Incremental: &APIIncrementalConfig{
+								Enabled:          true,
+								FullSyncInterval: "0s",
+							},
+						},
+						SyncPolicy: &SyncPolicyConfig{
+							Interval: "1h",
+						},
+					},
+				},
+				Auth: &AuthConfig{
+					Mode: AuthModeAnonymous,
+				},
+			},
+			wantErr: true,
+			errMsg:  "api.incremental.fullSyncInterval must be greater than zero",
+		},
 		{
 			name: "invalid_file_url_scheme",
 			config: &Config{
@@ -873,6 +936,52 @@ func TestConfigValidate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "at most one managed source is allowed",
 		},
+		{
+			name: "valid_registry_merge",
+			config: &Config{
+				Sources: []SourceConfig{
+					{Name: "managed", Managed: &ManagedConfig{}},
+				},
+				Registries: []RegistryConfig{
+					{
+						Name:    "default",
+						Sources: []string{"managed"},
+						Merge:   &MergeConfig{Versions: MergeUnion, Meta: MergeOverlay, Description: MergeFirstWins},
+					},
+				},
+				Database: &DatabaseConfig{Host: "localhost", Port: 5432, User: "testuser", Database: "testdb"},
+				Auth:     &AuthConfig{Mode: AuthModeAnonymous},
+			},
+			wantErr: false,
+		},
+		{
+			name: "registry_merge_unsupported_versions_strategy",
+			config: &Config{
+				Sources: []SourceConfig{
+					{Name: "managed", Managed: &ManagedConfig{}},
+				},
+				Registries: []RegistryConfig{
+					{Name: "default", Sources: []string{"managed"}, Merge: &MergeConfig{Versions: MergeOverlay}},
+				},
+				Auth: &AuthConfig{Mode: AuthModeAnonymous},
+			},
+			wantErr: true,
+			errMsg:  "merge.versions: unsupported strategy 'overlay'",
+		},
+		{
+			name: "registry_merge_unsupported_field_strategy",
+			config: &Config{
+				Sources: []SourceConfig{
+					{Name: "managed", Managed: &ManagedConfig{}},
+				},
+				Registries: []RegistryConfig{
+					{Name: "default", Sources: []string{"managed"}, Merge: &MergeConfig{Icons: MergeUnion}},
+				},
+				Auth: &AuthConfig{Mode: AuthModeAnonymous},
+			},
+			wantErr: true,
+			errMsg:  "merge.icons: unsupported strategy 'union'",
+		},
 		{
 			name: "multiple_source_types_specified",
 			config: &Config{
@@ -895,7 +1004,7 @@ func TestConfigValidate(t *testing.T) {
 				},
 			},
 			wantErr: true,
-			errMsg:  "only one of git, api, file, managed, or kubernetes configuration may be specified",
+			errMsg:  "only one of git, api, file, bundle, managed, or kubernetes configuration may be specified",
 		},
 		{
 			name: "valid_managed_registry_no_sync_policy",
@@ -1080,7 +1189,7 @@ func TestConfigValidate(t *testing.T) {
 				},
 			},
 			wantErr: true,
-			errMsg:  "only one of git, api, file, managed, or kubernetes configuration may be specified",
+			errMsg:  "only one of git, api, file, bundle, managed, or kubernetes configuration may be specified",
 		},
 		{
 			name: "managed_and_git_source_specified",
@@ -1099,7 +1208,7 @@ func TestConfigValidate(t *testing.T) {
 				},
 			},
 			wantErr: true,
-			errMsg:  "only one of git, api, file, managed, or kubernetes configuration may be specified",
+			errMsg:  "only one of git, api, file, bundle, managed, or kubernetes configuration may be specified",
 		},
 		{
 			name: "managed_and_api_source_specified",
@@ -1118,7 +1227,7 @@ func TestConfigValidate(t *testing.T) {
 				},
 			},
 			wantErr: true,
-			errMsg:  "only one of git, api, file, managed, or kubernetes configuration may be specified",
+			errMsg:  "only one of git, api, file, bundle, managed, or kubernetes configuration may be specified",
 		},
 		{
 			name: "mixed_managed_and_synced_registries",
@@ -2175,6 +2284,16 @@ func TestRegistryConfig_GetType(t *testing.T) {
 			},
 			expectedType: SourceTypeFile,
 		},
+		{
+			name: "bundle type",
+			registryConf: &SourceConfig{
+				Name: "bundle-registry",
+				Bundle: &BundleConfig{
+					Path: "/data/bundles",
+				},
+			},
+			expectedType: SourceTypeBundle,
+		},
 		{
 			name: "managed type",
 			registryConf: &SourceConfig{
@@ -2963,115 +3082,533 @@ func TestConfigValidateGitAuth(t *testing.T) {
 	}
 }
 
-func TestDatabaseConfigGetMaxMetaSize(t *testing.T) {
+// TestGitAuthConfigValidateMethods tests validation of the SSH and GitHub App auth methods
+func TestGitAuthConfigValidateMethods(t *testing.T) {
 	t.Parallel()
 
+	tmpDir := t.TempDir()
+	keyFile := filepath.Join(tmpDir, "key.pem")
+	require.NoError(t, os.WriteFile(keyFile, []byte("key"), 0600))
+	knownHostsFile := filepath.Join(tmpDir, "known_hosts")
+	require.NoError(t, os.WriteFile(knownHostsFile, []byte(""), 0600))
+	passwordFile := filepath.Join(tmpDir, "password.txt")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("secret"), 0600))
+
 	tests := []struct {
-		name     string
-		dbConfig *DatabaseConfig
-		want     int
+		name   string
+		auth   *GitAuthConfig
+		errMsg string
 	}{
 		{
-			name:     "nil DatabaseConfig returns default",
-			dbConfig: nil,
-			want:     DefaultMaxMetaSize,
+			name: "valid ssh auth",
+			auth: &GitAuthConfig{
+				SSH: &GitSSHAuthConfig{PrivateKeyFile: keyFile, KnownHostsFile: knownHostsFile},
+			},
 		},
 		{
-			name:     "MaxMetaSize not set returns default",
-			dbConfig: &DatabaseConfig{},
-			want:     DefaultMaxMetaSize,
+			name: "ssh auth without privateKeyFile",
+			auth: &GitAuthConfig{
+				SSH: &GitSSHAuthConfig{KnownHostsFile: knownHostsFile},
+			},
+			errMsg: "git.auth.ssh.privateKeyFile is required",
 		},
 		{
-			name: "MaxMetaSize set to custom value",
-			dbConfig: &DatabaseConfig{
-				MaxMetaSize: ptr.Int(4096),
+			name: "ssh auth without knownHostsFile",
+			auth: &GitAuthConfig{
+				SSH: &GitSSHAuthConfig{PrivateKeyFile: keyFile},
 			},
-			want: 4096,
+			errMsg: "git.auth.ssh.knownHostsFile is required",
+		},
+		{
+			name: "ssh auth with relative passphraseFile",
+			auth: &GitAuthConfig{
+				SSH: &GitSSHAuthConfig{
+					PrivateKeyFile: keyFile,
+					KnownHostsFile: knownHostsFile,
+					PassphraseFile: "passphrase.txt",
+				},
+			},
+			errMsg: "git.auth.ssh.passphraseFile must be an absolute path",
+		},
+		{
+			name: "ssh auth with missing privateKeyFile",
+			auth: &GitAuthConfig{
+				SSH: &GitSSHAuthConfig{
+					PrivateKeyFile: filepath.Join(tmpDir, "missing.pem"),
+					KnownHostsFile: knownHostsFile,
+				},
+			},
+			errMsg: "git.auth.ssh.privateKeyFile is not accessible",
+		},
+		{
+			name: "valid github app auth",
+			auth: &GitAuthConfig{
+				GitHubApp: &GitHubAppAuthConfig{
+					AppID:          1234,
+					InstallationID: 42,
+					PrivateKeyFile: keyFile,
+					APIURL:         "https://github.example.com/api/v3",
+				},
+			},
+		},
+		{
+			name: "github app auth without appId",
+			auth: &GitAuthConfig{
+				GitHubApp: &GitHubAppAuthConfig{InstallationID: 42, PrivateKeyFile: keyFile},
+			},
+			errMsg: "git.auth.githubApp.appId is required",
+		},
+		{
+			name: "github app auth without installationId",
+			auth: &GitAuthConfig{
+				GitHubApp: &GitHubAppAuthConfig{AppID: 1234, PrivateKeyFile: keyFile},
+			},
+			errMsg: "git.auth.githubApp.installationId is required",
+		},
+		{
+			name: "github app auth without privateKeyFile",
+			auth: &GitAuthConfig{
+				GitHubApp: &GitHubAppAuthConfig{AppID: 1234, InstallationID: 42},
+			},
+			errMsg: "git.auth.githubApp.privateKeyFile is required",
+		},
+		{
+			name: "github app auth with invalid apiUrl",
+			auth: &GitAuthConfig{
+				GitHubApp: &GitHubAppAuthConfig{
+					AppID:          1234,
+					InstallationID: 42,
+					PrivateKeyFile: keyFile,
+					APIURL:         "ftp://github.example.com",
+				},
+			},
+			errMsg: "git.auth.githubApp.apiUrl must be a valid HTTP or HTTPS URL",
+		},
+		{
+			name: "multiple auth methods",
+			auth: &GitAuthConfig{
+				Username:     "testuser",
+				PasswordFile: passwordFile,
+				SSH:          &GitSSHAuthConfig{PrivateKeyFile: keyFile, KnownHostsFile: knownHostsFile},
+			},
+			errMsg: "only one of git.auth.username/passwordFile, git.auth.ssh, or git.auth.githubApp may be specified",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got := tt.dbConfig.GetMaxMetaSize()
-			assert.Equal(t, tt.want, got)
+			err := tt.auth.Validate()
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				require.NoError(t, err)
+			}
 		})
 	}
 }
 
-func TestDatabaseConfigToCorePostgresConfig(t *testing.T) {
+// TestGitAuthConfigValidateRepository tests that auth methods match the repository URL scheme
+func TestGitAuthConfigValidateRepository(t *testing.T) {
 	t.Parallel()
 
-	t.Run("maps connection and pool fields", func(t *testing.T) {
-		t.Parallel()
-		maxIdle := int32(3)
-		dbConfig := &DatabaseConfig{
-			Host:              "db.example.com",
-			Port:              5433,
-			User:              "appuser",
-			Password:          "s3cret",
-			MigrationUser:     "migratoruser",
-			MigrationPassword: "migpass",
-			Database:          "production",
-			SSLMode:           "verify-full",
-			MaxOpenConns:      10,
-			MaxIdleConns:      maxIdle,
-			ConnMaxLifetime:   "1h30m",
-		}
-
-		got, err := dbConfig.ToCorePostgresConfig()
-		require.NoError(t, err)
-		require.NotNil(t, got)
+	tests := []struct {
+		name       string
+		auth       *GitAuthConfig
+		repository string
+		errMsg     string
+	}{
+		{
+			name:       "no auth",
+			auth:       nil,
+			repository: "https://github.com/example/repo.git",
+		},
+		{
+			name:       "ssh auth with scp-like URL",
+			auth:       &GitAuthConfig{SSH: &GitSSHAuthConfig{}},
+			repository: "git@github.com:example/repo.git",
+		},
+		{
+			name:       "ssh auth with ssh URL",
+			auth:       &GitAuthConfig{SSH: &GitSSHAuthConfig{}},
+			repository: "ssh://git@github.com/example/repo.git",
+		},
+		{
+			name:       "ssh auth with https URL",
+			auth:       &GitAuthConfig{SSH: &GitSSHAuthConfig{}},
+			repository: "https://github.com/example/repo.git",
+			errMsg:     "git.auth.ssh requires an SSH repository URL",
+		},
+		{
+			name:       "github app auth with https URL",
+			auth:       &GitAuthConfig{GitHubApp: &GitHubAppAuthConfig{}},
+			repository: "https://github.com/example/repo.git",
+		},
+		{
+			name:       "github app auth with ssh URL",
+			auth:       &GitAuthConfig{GitHubApp: &GitHubAppAuthConfig{}},
+			repository: "git@github.com:example/repo.git",
+			errMsg:     "git.auth.githubApp requires an HTTPS repository URL",
+		},
+	}
 
-		assert.Equal(t, "db.example.com", got.Host)
-		assert.Equal(t, 5433, got.Port)
-		assert.Equal(t, "appuser", got.User)
-		assert.Equal(t, "s3cret", got.Password)
-		assert.Equal(t, "migratoruser", got.MigrationUser)
-		assert.Equal(t, "migpass", got.MigrationPassword)
-		assert.Equal(t, "production", got.Database)
-		assert.Equal(t, "verify-full", got.SSLMode)
-		assert.Equal(t, int32(10), got.MaxOpenConns)
-		// maxIdleConns maps onto pgxpool's MinConns floor.
-		assert.Equal(t, maxIdle, got.MinConns)
-		assert.Equal(t, 90*time.Minute, got.ConnMaxLifetime)
-		assert.Nil(t, got.DynamicAuth)
-	})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.auth.ValidateRepository(tt.repository)
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
 
-	t.Run("passes through dynamic auth", func(t *testing.T) {
-		t.Parallel()
-		dbConfig := &DatabaseConfig{
-			Host:     "localhost",
-			Port:     5432,
-			User:     "appuser",
-			Database: "testdb",
-			DynamicAuth: &DynamicAuthConfig{
-				AWSRDSIAM: &DynamicAuthAWSRDSIAM{Region: "us-east-1"},
-			},
-		}
+// TestGitWebhookConfigValidate tests the validation of Git webhook secrets
+func TestGitWebhookConfigValidate(t *testing.T) {
+	t.Parallel()
 
-		got, err := dbConfig.ToCorePostgresConfig()
-		require.NoError(t, err)
-		require.NotNil(t, got.DynamicAuth)
-		require.NotNil(t, got.DynamicAuth.AWSRDSIAM)
-		assert.Equal(t, "us-east-1", got.DynamicAuth.AWSRDSIAM.Region)
-	})
+	secretFile := filepath.Join(t.TempDir(), "webhook-secret")
+	require.NoError(t, os.WriteFile(secretFile, []byte("s3cret\n"), 0600))
 
-	t.Run("rejects malformed connMaxLifetime", func(t *testing.T) {
-		t.Parallel()
-		dbConfig := &DatabaseConfig{
-			Host:            "localhost",
-			Port:            5432,
-			User:            "appuser",
-			Database:        "testdb",
-			ConnMaxLifetime: "not-a-duration",
-		}
+	tests := []struct {
+		name    string
+		webhook *GitWebhookConfig
+		errMsg  string
+	}{
+		{
+			name:    "no webhook",
+			webhook: nil,
+		},
+		{
+			name:    "valid secret file",
+			webhook: &GitWebhookConfig{SecretFile: secretFile},
+		},
+		{
+			name:    "missing secret file",
+			webhook: &GitWebhookConfig{},
+			errMsg:  "git.webhook.secretFile is required",
+		},
+		{
+			name:    "relative secret file",
+			webhook: &GitWebhookConfig{SecretFile: "webhook-secret"},
+			errMsg:  "git.webhook.secretFile must be an absolute path",
+		},
+	}
 
-		got, err := dbConfig.ToCorePostgresConfig()
-		require.Error(t, err)
-		assert.Nil(t, got)
-		assert.Contains(t, err.Error(), "connMaxLifetime")
-	})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.webhook.Validate()
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	secret, err := (&GitWebhookConfig{SecretFile: secretFile}).GetSecret()
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", secret)
+}
+
+func TestManagedConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	tests := []struct {
+		name    string
+		managed *ManagedConfig
+		errMsg  string
+	}{
+		{
+			name:    "no signing",
+			managed: &ManagedConfig{RequireApproval: true},
+		},
+		{
+			name: "valid signing",
+			managed: &ManagedConfig{Signing: []SigningConfig{
+				{Namespace: "com.example/", PublicKeys: []string{publicKey}, RequireSignature: true},
+			}},
+		},
+		{
+			name: "missing namespace",
+			managed: &ManagedConfig{Signing: []SigningConfig{
+				{PublicKeys: []string{publicKey}},
+			}},
+			errMsg: "managed.signing[0].namespace is required",
+		},
+		{
+			name: "invalid namespace",
+			managed: &ManagedConfig{Signing: []SigningConfig{
+				{Namespace: "com.example/secure-*", PublicKeys: []string{publicKey}},
+			}},
+			errMsg: "managed.signing[0].namespace: invalid namespace",
+		},
+		{
+			name: "duplicate namespace",
+			managed: &ManagedConfig{Signing: []SigningConfig{
+				{Namespace: "com.example/", PublicKeys: []string{publicKey}},
+				{Namespace: "com.example/", PublicKeys: []string{publicKey}},
+			}},
+			errMsg: "managed.signing[1]: duplicate namespace 'com.example/'",
+		},
+		{
+			name: "no public keys",
+			managed: &ManagedConfig{Signing: []SigningConfig{
+				{Namespace: "com.example/", RequireSignature: true},
+			}},
+			errMsg: "managed.signing[0].publicKeys must not be empty",
+		},
+		{
+			name: "invalid public key",
+			managed: &ManagedConfig{Signing: []SigningConfig{
+				{Namespace: "com.example/", PublicKeys: []string{"not a key"}},
+			}},
+			errMsg: "managed.signing[0].publicKeys[0]: not a PEM encoded key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.managed.Validate()
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBundleConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	tests := []struct {
+		name   string
+		bundle *BundleConfig
+		errMsg string
+	}{
+		{
+			name:   "valid path",
+			bundle: &BundleConfig{Path: "/data/bundles", PublicKeys: []string{publicKey}},
+		},
+		{
+			name:   "valid url",
+			bundle: &BundleConfig{URL: "https://central.example.com/bundle.json", Timeout: "1m", PublicKeys: []string{publicKey}},
+		},
+		{
+			name:   "no path or url",
+			bundle: &BundleConfig{PublicKeys: []string{publicKey}},
+			errMsg: "bundle.path or bundle.url is required",
+		},
+		{
+			name:   "path and url",
+			bundle: &BundleConfig{Path: "/data/bundles", URL: "https://central.example.com/bundle.json"},
+			errMsg: "bundle.path and bundle.url are mutually exclusive",
+		},
+		{
+			name:   "invalid url scheme",
+			bundle: &BundleConfig{URL: "ftp://central.example.com/bundle.json", PublicKeys: []string{publicKey}},
+			errMsg: "bundle.url must use http or https scheme",
+		},
+		{
+			name:   "timeout without url",
+			bundle: &BundleConfig{Path: "/data/bundles", Timeout: "1m", PublicKeys: []string{publicKey}},
+			errMsg: "bundle.timeout is only applicable when bundle.url is specified",
+		},
+		{
+			name:   "no public keys",
+			bundle: &BundleConfig{Path: "/data/bundles"},
+			errMsg: "bundle.publicKeys must not be empty",
+		},
+		{
+			name:   "invalid public key",
+			bundle: &BundleConfig{Path: "/data/bundles", PublicKeys: []string{"not a key"}},
+			errMsg: "bundle.publicKeys[0]: not a PEM encoded key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.bundle.Validate()
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestGitConfigMatchesRef tests matching pushed references with the synced branch or tag
+func TestGitConfigMatchesRef(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		git           GitConfig
+		ref           string
+		defaultBranch string
+		want          bool
+	}{
+		{name: "branch", git: GitConfig{Branch: "main"}, ref: "refs/heads/main", want: true},
+		{name: "other branch", git: GitConfig{Branch: "main"}, ref: "refs/heads/dev"},
+		{name: "tag with branch name", git: GitConfig{Branch: "main"}, ref: "refs/tags/main"},
+		{name: "tag", git: GitConfig{Tag: "v1"}, ref: "refs/tags/v1", want: true},
+		{name: "commit", git: GitConfig{Commit: "abc123"}, ref: "refs/heads/main", defaultBranch: "main"},
+		{name: "default branch", git: GitConfig{}, ref: "refs/heads/main", defaultBranch: "main", want: true},
+		{name: "unknown default branch", git: GitConfig{}, ref: "refs/heads/main"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.git.MatchesRef(tt.ref, tt.defaultBranch))
+		})
+	}
+}
+
+// TestGitAuthConfigDefaults tests the defaults of the SSH user and GitHub API URL
+func TestGitAuthConfigDefaults(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, DefaultGitHubAPIURL, (&GitHubAppAuthConfig{}).GetAPIURL())
+	assert.Equal(t, "https://github.example.com/api/v3",
+		(&GitHubAppAuthConfig{APIURL: "https://github.example.com/api/v3/"}).GetAPIURL())
+	assert.Equal(t, DefaultGitSSHUser, (&GitSSHAuthConfig{}).GetUser())
+	assert.Equal(t, "deploy", (&GitSSHAuthConfig{User: "deploy"}).GetUser())
+}
+
+func TestDatabaseConfigGetMaxMetaSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		dbConfig *DatabaseConfig
+		want     int
+	}{
+		{
+			name:     "nil DatabaseConfig returns default",
+			dbConfig: nil,
+			want:     DefaultMaxMetaSize,
+		},
+		{
+			name:     "MaxMetaSize not set returns default",
+			dbConfig: &DatabaseConfig{},
+			want:     DefaultMaxMetaSize,
+		},
+		{
+			name: "MaxMetaSize set to custom value",
+			dbConfig: &DatabaseConfig{
+				MaxMetaSize: ptr.Int(4096),
+			},
+			want: 4096,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.dbConfig.GetMaxMetaSize()
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDatabaseConfigToCorePostgresConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("maps connection and pool fields", func(t *testing.T) {
+		t.Parallel()
+		maxIdle := int32(3)
+		dbConfig := &DatabaseConfig{
+			Host:              "db.example.com",
+			Port:              5433,
+			User:              "appuser",
+			Password:          "s3cret",
+			MigrationUser:     "migratoruser",
+			MigrationPassword: "migpass",
+			Database:          "production",
+			SSLMode:           "verify-full",
+			MaxOpenConns:      10,
+			MaxIdleConns:      maxIdle,
+			ConnMaxLifetime:   "1h30m",
+		}
+
+		got, err := dbConfig.ToCorePostgresConfig()
+		require.NoError(t, err)
+		require.NotNil(t, got)
+
+		assert.Equal(t, "db.example.com", got.Host)
+		assert.Equal(t, 5433, got.Port)
+		assert.Equal(t, "appuser", got.User)
+		assert.Equal(t, "s3cret", got.Password)
+		assert.Equal(t, "migratoruser", got.MigrationUser)
+		assert.Equal(t, "migpass", got.MigrationPassword)
+		assert.Equal(t, "production", got.Database)
+		assert.Equal(t, "verify-full", got.SSLMode)
+		assert.Equal(t, int32(10), got.MaxOpenConns)
+		// maxIdleConns maps onto pgxpool's MinConns floor.
+		assert.Equal(t, maxIdle, got.MinConns)
+		assert.Equal(t, 90*time.Minute, got.ConnMaxLifetime)
+		assert.Nil(t, got.DynamicAuth)
+	})
+
+	t.Run("passes through dynamic auth", func(t *testing.T) {
+		t.Parallel()
+		dbConfig := &DatabaseConfig{
+			Host:     "localhost",
+			Port:     5432,
+			User:     "appuser",
+			Database: "testdb",
+			DynamicAuth: &DynamicAuthConfig{
+				AWSRDSIAM: &DynamicAuthAWSRDSIAM{Region: "us-east-1"},
+			},
+		}
+
+		got, err := dbConfig.ToCorePostgresConfig()
+		require.NoError(t, err)
+		require.NotNil(t, got.DynamicAuth)
+		require.NotNil(t, got.DynamicAuth.AWSRDSIAM)
+		assert.Equal(t, "us-east-1", got.DynamicAuth.AWSRDSIAM.Region)
+	})
+
+	t.Run("rejects malformed connMaxLifetime", func(t *testing.T) {
+		t.Parallel()
+		dbConfig := &DatabaseConfig{
+			Host:            "localhost",
+			Port:            5432,
+			User:            "appuser",
+			Database:        "testdb",
+			ConnMaxLifetime: "not-a-duration",
+		}
+
+		got, err := dbConfig.ToCorePostgresConfig()
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "connMaxLifetime")
+	})
 
 	t.Run("produces a config that builds the expected connection strings", func(t *testing.T) {
 		t.Parallel()
@@ -3172,3 +3709,500 @@ func TestValidateStorageConfigRejectsPasswordWithDynamicAuth(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigGetSyncHistoryRetention(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config *Config
+		want   time.Duration
+	}{
+		{
+			name:   "nil Config returns default",
+			config: nil,
+			want:   DefaultSyncHistoryRetention,
+		},
+		{
+			name:   "Sync not set returns default",
+			config: &Config{},
+			want:   DefaultSyncHistoryRetention,
+		},
+		{
+			name:   "HistoryRetention set to custom value",
+			config: &Config{Sync: &SyncConfig{HistoryRetention: "168h"}},
+			want:   168 * time.Hour,
+		},
+		{
+			name:   "invalid HistoryRetention returns default",
+			config: &Config{Sync: &SyncConfig{HistoryRetention: "forever"}},
+			want:   DefaultSyncHistoryRetention,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.config.GetSyncHistoryRetention())
+		})
+	}
+}
+
+func TestConfigGetSyncConcurrency(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config *Config
+		want   int
+	}{
+		{
+			name:   "nil Config returns default",
+			config: nil,
+			want:   DefaultSyncConcurrency,
+		},
+		{
+			name:   "Sync not set returns default",
+			config: &Config{},
+			want:   DefaultSyncConcurrency,
+		},
+		{
+			name:   "Concurrency set to custom value",
+			config: &Config{Sync: &SyncConfig{Concurrency: 10}},
+			want:   10,
+		},
+		{
+			name:   "zero Concurrency returns default",
+			config: &Config{Sync: &SyncConfig{Concurrency: 0}},
+			want:   DefaultSyncConcurrency,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.config.GetSyncConcurrency())
+		})
+	}
+}
+
+func TestValidateSync(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		sync      *SyncConfig
+		wantErr   bool
+		errSubstr string
+	}{
+		{name: "nil sync config", sync: nil},
+		{name: "empty retention", sync: &SyncConfig{}},
+		{name: "valid retention", sync: &SyncConfig{HistoryRetention: "720h"}},
+		{
+			name:      "invalid duration",
+			sync:      &SyncConfig{HistoryRetention: "forever"},
+			wantErr:   true,
+			errSubstr: "sync.historyRetention must be a valid duration",
+		},
+		{
+			name:      "zero duration",
+			sync:      &SyncConfig{HistoryRetention: "0s"},
+			wantErr:   true,
+			errSubstr: "sync.historyRetention must be greater than zero",
+		},
+		{name: "valid concurrency", sync: &SyncConfig{Concurrency: 8}},
+		{
+			name:      "negative concurrency",
+			sync:      &SyncConfig{Concurrency: -1},
+			wantErr:   true,
+			errSubstr: "sync.concurrency must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{Sync: tt.sync}
+			err := cfg.validateSync()
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errSubstr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateNotifications(t *testing.T) {
+	t.Parallel()
+
+	secretFile := filepath.Join(t.TempDir(), "webhook-secret")
+	require.NoError(t, os.WriteFile(secretFile, []byte("s3cret\n"), 0600))
+
+	validWebhook := func(name string) WebhookConfig {
+		return WebhookConfig{Name: name, URL: "https://hooks.example.com/registry", SecretFile: secretFile}
+	}
+	withWebhook := func(mutate func(*WebhookConfig)) *NotificationsConfig {
+		webhook := validWebhook("catalog")
+		mutate(&webhook)
+		return &NotificationsConfig{Webhooks: []WebhookConfig{webhook}}
+	}
+
+	tests := []struct {
+		name          string
+		notifications *NotificationsConfig
+		errSubstr     string
+	}{
+		{name: "nil notifications config", notifications: nil},
+		{
+			name: "valid webhooks",
+			notifications: &NotificationsConfig{
+				Webhooks:          []WebhookConfig{validWebhook("catalog"), validWebhook("audit-sink")},
+				DeliveryRetention: "168h",
+			},
+		},
+		{
+			name: "valid events, timeout and attempts",
+			notifications: withWebhook(func(w *WebhookConfig) {
+				w.Events = []string{"source.sync", "entry.publish"}
+				w.Timeout = "30s"
+				w.MaxAttempts = 3
+			}),
+		},
+		{
+			name:          "invalid retention",
+			notifications: &NotificationsConfig{DeliveryRetention: "forever"},
+			errSubstr:     "notifications.deliveryRetention must be a valid duration",
+		},
+		{
+			name:          "zero retention",
+			notifications: &NotificationsConfig{DeliveryRetention: "0s"},
+			errSubstr:     "notifications.deliveryRetention must be greater than zero",
+		},
+		{
+			name:          "invalid name",
+			notifications: withWebhook(func(w *WebhookConfig) { w.Name = "Catalog Hook" }),
+			errSubstr:     "notifications.webhooks[0]: name must be a valid DNS subdomain",
+		},
+		{
+			name: "duplicate name",
+			notifications: &NotificationsConfig{
+				Webhooks: []WebhookConfig{validWebhook("catalog"), validWebhook("catalog")},
+			},
+			errSubstr: "notifications.webhooks[1]: duplicate webhook name 'catalog'",
+		},
+		{
+			name:          "relative url",
+			notifications: withWebhook(func(w *WebhookConfig) { w.URL = "/registry" }),
+			errSubstr:     "url must be an absolute URL with host",
+		},
+		{
+			name:          "unsupported scheme",
+			notifications: withWebhook(func(w *WebhookConfig) { w.URL = "ftp://hooks.example.com" }),
+			errSubstr:     "url must use http or https scheme",
+		},
+		{
+			name:          "missing secret file",
+			notifications: withWebhook(func(w *WebhookConfig) { w.SecretFile = "" }),
+			errSubstr:     "secretFile is required",
+		},
+		{
+			name:          "relative secret file",
+			notifications: withWebhook(func(w *WebhookConfig) { w.SecretFile = "webhook-secret" }),
+			errSubstr:     "secretFile must be an absolute path",
+		},
+		{
+			name:          "empty event type",
+			notifications: withWebhook(func(w *WebhookConfig) { w.Events = []string{""} }),
+			errSubstr:     "events must not contain empty event types",
+		},
+		{
+			name:          "invalid timeout",
+			notifications: withWebhook(func(w *WebhookConfig) { w.Timeout = "soon" }),
+			errSubstr:     "timeout must be a valid duration",
+		},
+		{
+			name:          "negative max attempts",
+			notifications: withWebhook(func(w *WebhookConfig) { w.MaxAttempts = -1 }),
+			errSubstr:     "maxAttempts must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{Notifications: tt.notifications}
+			err := cfg.validateNotifications()
+			if tt.errSubstr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errSubstr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestWebhookConfigDefaults(t *testing.T) {
+	t.Parallel()
+
+	secretFile := filepath.Join(t.TempDir(), "webhook-secret")
+	require.NoError(t, os.WriteFile(secretFile, []byte("s3cret\n"), 0600))
+
+	webhook := &WebhookConfig{SecretFile: secretFile}
+	assert.Equal(t, DefaultWebhookTimeout, webhook.GetTimeout())
+	assert.Equal(t, DefaultWebhookMaxAttempts, webhook.GetMaxAttempts())
+	secret, err := webhook.GetSecret()
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", secret)
+
+	webhook = &WebhookConfig{Timeout: "30s", MaxAttempts: 3}
+	assert.Equal(t, 30*time.Second, webhook.GetTimeout())
+	assert.Equal(t, 3, webhook.GetMaxAttempts())
+
+	var cfg *Config
+	assert.Empty(t, cfg.GetWebhooks())
+	assert.Equal(t, DefaultNotificationDeliveryRetention, cfg.GetNotificationDeliveryRetention())
+	cfg = &Config{Notifications: &NotificationsConfig{DeliveryRetention: "24h"}}
+	assert.Equal(t, 24*time.Hour, cfg.GetNotificationDeliveryRetention())
+}
+
+func TestValidateEntryPolicy(t *testing.T) {
+	t.Parallel()
+
+	sourceNames := map[string]bool{"upstream": true, "internal": true}
+	withScanner := func(mutate func(*ScannerConfig)) *EntryPolicyConfig {
+		scanner := ScannerConfig{Name: "osv", URL: "https://scanner.example.com/scan"}
+		mutate(&scanner)
+		return &EntryPolicyConfig{Scanners: []ScannerConfig{scanner}}
+	}
+
+	tests := []struct {
+		name      string
+		policy    *EntryPolicyConfig
+		errSubstr string
+	}{
+		{name: "nil entry policy", policy: nil},
+		{
+			name: "valid scanners",
+			policy: &EntryPolicyConfig{
+				Sources: []string{"upstream"},
+				Scanners: []ScannerConfig{
+					{Name: "osv", URL: "https://scanner.example.com/scan", Timeout: "1m"},
+					{Name: "license", Command: []string{"/usr/local/bin/license-check", "--json"}, FailClosed: true},
+				},
+			},
+		},
+		{
+			name:      "unknown source",
+			policy:    &EntryPolicyConfig{Sources: []string{"unknown"}, Scanners: withScanner(func(*ScannerConfig) {}).Scanners},
+			errSubstr: "entryPolicy: references unknown source 'unknown'",
+		},
+		{
+			name:      "no scanners",
+			policy:    &EntryPolicyConfig{},
+			errSubstr: "entryPolicy: at least one scanner is required",
+		},
+		{
+			name:      "invalid name",
+			policy:    withScanner(func(s *ScannerConfig) { s.Name = "OSV Scanner" }),
+			errSubstr: "entryPolicy.scanners[0]: name must be a valid DNS subdomain",
+		},
+		{
+			name: "duplicate name",
+			policy: &EntryPolicyConfig{Scanners: []ScannerConfig{
+				{Name: "osv", URL: "https://scanner.example.com/scan"},
+				{Name: "osv", Command: []string{"/usr/local/bin/osv-scanner"}},
+			}},
+			errSubstr: "entryPolicy.scanners[1]: duplicate scanner name 'osv'",
+		},
+		{
+			name:      "missing command and url",
+			policy:    withScanner(func(s *ScannerConfig) { s.URL = "" }),
+			errSubstr: "either command or url is required",
+		},
+		{
+			name:      "command and url",
+			policy:    withScanner(func(s *ScannerConfig) { s.Command = []string{"/usr/local/bin/osv-scanner"} }),
+			errSubstr: "command and url are mutually exclusive",
+		},
+		{
+			name: "relative command",
+			policy: withScanner(func(s *ScannerConfig) {
+				s.URL = ""
+				s.Command = []string{"osv-scanner"}
+			}),
+			errSubstr: "command must start with the absolute path of the executable",
+		},
+		{
+			name:      "unsupported scheme",
+			policy:    withScanner(func(s *ScannerConfig) { s.URL = "ftp://scanner.example.com" }),
+			errSubstr: "url must use http or https scheme",
+		},
+		{
+			name:      "invalid timeout",
+			policy:    withScanner(func(s *ScannerConfig) { s.Timeout = "soon" }),
+			errSubstr: "timeout must be a valid duration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{EntryPolicy: tt.policy}
+			err := cfg.validateEntryPolicy(sourceNames)
+			if tt.errSubstr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errSubstr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	assert.Equal(t, DefaultScannerTimeout, (&ScannerConfig{}).GetTimeout())
+	assert.Equal(t, time.Minute, (&ScannerConfig{Timeout: "1m"}).GetTimeout())
+}
+
+func TestValidateDigestPinning(t *testing.T) {
+	t.Parallel()
+
+	sourceNames := map[string]bool{"upstream": true}
+
+	tests := []struct {
+		name      string
+		pinning   *DigestPinningConfig
+		errSubstr string
+	}{
+		{name: "nil digest pinning", pinning: nil},
+		{name: "empty digest pinning", pinning: &DigestPinningConfig{}},
+		{
+			name: "valid digest pinning",
+			pinning: &DigestPinningConfig{
+				Sources:             []string{"upstream"},
+				PlainHTTPRegistries: []string{"localhost:5000"},
+				Timeout:             "5s",
+			},
+		},
+		{
+			name:      "unknown source",
+			pinning:   &DigestPinningConfig{Sources: []string{"unknown"}},
+			errSubstr: "digestPinning: references unknown source 'unknown'",
+		},
+		{
+			name:      "plain HTTP registry with path",
+			pinning:   &DigestPinningConfig{PlainHTTPRegistries: []string{"localhost:5000/v2"}},
+			errSubstr: "digestPinning.plainHTTPRegistries[0]: must be a host",
+		},
+		{
+			name:      "allowed host with scheme",
+			pinning:   &DigestPinningConfig{AllowedHosts: []string{"https://ghcr.io"}},
+			errSubstr: "digestPinning.allowedHosts[0]: must be a host",
+		},
+		{
+			name:      "invalid timeout",
+			pinning:   &DigestPinningConfig{Timeout: "soon"},
+			errSubstr: "timeout must be a valid duration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{DigestPinning: tt.pinning}
+			err := cfg.validateDigestPinning(sourceNames)
+			if tt.errSubstr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errSubstr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	assert.Equal(t, DefaultDigestResolutionTimeout, (&DigestPinningConfig{}).GetTimeout())
+	assert.Equal(t, 5*time.Second, (&DigestPinningConfig{Timeout: "5s"}).GetTimeout())
+	assert.Equal(t, DefaultDigestPinningHosts, (&DigestPinningConfig{}).GetAllowedHosts())
+	assert.Equal(t, []string{"ghcr.io", "localhost:5000"},
+		(&DigestPinningConfig{AllowedHosts: []string{"ghcr.io"}, PlainHTTPRegistries: []string{"localhost:5000"}}).GetAllowedHosts())
+}
+
+func TestValidateReplication(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		replication *ReplicationConfig
+		errSubstr   string
+	}{
+		{name: "nil replication", replication: nil},
+		{name: "valid replication", replication: &ReplicationConfig{SigningKeyFile: "/secrets/bundle-key.pem"}},
+		{
+			name:        "missing signing key file",
+			replication: &ReplicationConfig{},
+			errSubstr:   "replication.signingKeyFile is required",
+		},
+		{
+			name:        "relative signing key file",
+			replication: &ReplicationConfig{SigningKeyFile: "bundle-key.pem"},
+			errSubstr:   "replication.signingKeyFile must be an absolute path",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{Replication: tt.replication}
+			err := cfg.validateReplication()
+			if tt.errSubstr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errSubstr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMergeConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		merge            *MergeConfig
+		expectedVersions MergeStrategy
+		expectedOverlays []string
+	}{
+		{
+			name:             "nil config defaults to first-wins",
+			merge:            nil,
+			expectedVersions: MergeFirstWins,
+		},
+		{
+			name:             "empty config defaults to first-wins",
+			merge:            &MergeConfig{},
+			expectedVersions: MergeFirstWins,
+		},
+		{
+			name: "overlay fields in stable order",
+			merge: &MergeConfig{
+				Versions:    MergeHighestVersion,
+				Meta:        MergeOverlay,
+				Icons:       MergeFirstWins,
+				Description: MergeOverlay,
+			},
+			expectedVersions: MergeHighestVersion,
+			expectedOverlays: []string{MergeFieldMeta, MergeFieldDescription},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expectedVersions, tt.merge.GetVersions())
+			assert.Equal(t, tt.expectedOverlays, tt.merge.Overlays())
+			assert.NoError(t, tt.merge.Validate())
+		})
+	}
+}
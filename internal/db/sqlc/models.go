@@ -55,6 +55,49 @@ func (ns NullCreationType) Value() (driver.Value, error) {
 	return string(ns.CreationType), nil
 }
 
+type EntryChangeType string
+
+const (
+	EntryChangeTypeCREATED EntryChangeType = "CREATED"
+	EntryChangeTypeUPDATED EntryChangeType = "UPDATED"
+	EntryChangeTypeDELETED EntryChangeType = "DELETED"
+)
+
+func (e *EntryChangeType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EntryChangeType(s)
+	case string:
+		*e = EntryChangeType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EntryChangeType: %T", src)
+	}
+	return nil
+}
+
+type NullEntryChangeType struct {
+	EntryChangeType EntryChangeType `json:"entry_change_type"`
+	Valid           bool            `json:"valid"` // Valid is true if EntryChangeType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEntryChangeType) Scan(value interface{}) error {
+	if value == nil {
+		ns.EntryChangeType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EntryChangeType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEntryChangeType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EntryChangeType), nil
+}
+
 type EntryType string
 
 const (
@@ -183,6 +226,49 @@ func (ns NullPluginStatus) Value() (driver.Value, error) {
 	return string(ns.PluginStatus), nil
 }
 
+type ServerStatus string
+
+const (
+	ServerStatusACTIVE     ServerStatus = "ACTIVE"
+	ServerStatusDEPRECATED ServerStatus = "DEPRECATED"
+	ServerStatusYANKED     ServerStatus = "YANKED"
+)
+
+func (e *ServerStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ServerStatus(s)
+	case string:
+		*e = ServerStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ServerStatus: %T", src)
+	}
+	return nil
+}
+
+type NullServerStatus struct {
+	ServerStatus ServerStatus `json:"server_status"`
+	Valid        bool         `json:"valid"` // Valid is true if ServerStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullServerStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ServerStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ServerStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullServerStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ServerStatus), nil
+}
+
 type SkillStatus string
 
 const (
@@ -226,6 +312,93 @@ func (ns NullSkillStatus) Value() (driver.Value, error) {
 	return string(ns.SkillStatus), nil
 }
 
+type SubmissionStatus string
+
+const (
+	SubmissionStatusPENDING  SubmissionStatus = "PENDING"
+	SubmissionStatusAPPROVED SubmissionStatus = "APPROVED"
+	SubmissionStatusREJECTED SubmissionStatus = "REJECTED"
+)
+
+func (e *SubmissionStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SubmissionStatus(s)
+	case string:
+		*e = SubmissionStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SubmissionStatus: %T", src)
+	}
+	return nil
+}
+
+type NullSubmissionStatus struct {
+	SubmissionStatus SubmissionStatus `json:"submission_status"`
+	Valid            bool             `json:"valid"` // Valid is true if SubmissionStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSubmissionStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.SubmissionStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SubmissionStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSubmissionStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SubmissionStatus), nil
+}
+
+type SyncRequestStatus string
+
+const (
+	SyncRequestStatusPENDING    SyncRequestStatus = "PENDING"
+	SyncRequestStatusINPROGRESS SyncRequestStatus = "IN_PROGRESS"
+	SyncRequestStatusCOMPLETED  SyncRequestStatus = "COMPLETED"
+	SyncRequestStatusFAILED     SyncRequestStatus = "FAILED"
+)
+
+func (e *SyncRequestStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SyncRequestStatus(s)
+	case string:
+		*e = SyncRequestStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SyncRequestStatus: %T", src)
+	}
+	return nil
+}
+
+type NullSyncRequestStatus struct {
+	SyncRequestStatus SyncRequestStatus `json:"sync_request_status"`
+	Valid             bool              `json:"valid"` // Valid is true if SyncRequestStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSyncRequestStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.SyncRequestStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SyncRequestStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSyncRequestStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SyncRequestStatus), nil
+}
+
 type SyncStatus string
 
 const (
@@ -269,15 +442,104 @@ func (ns NullSyncStatus) Value() (driver.Value, error) {
 	return string(ns.SyncStatus), nil
 }
 
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPENDING   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryStatusDELIVERED WebhookDeliveryStatus = "DELIVERED"
+	WebhookDeliveryStatusFAILED    WebhookDeliveryStatus = "FAILED"
+)
+
+func (e *WebhookDeliveryStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WebhookDeliveryStatus(s)
+	case string:
+		*e = WebhookDeliveryStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WebhookDeliveryStatus: %T", src)
+	}
+	return nil
+}
+
+type NullWebhookDeliveryStatus struct {
+	WebhookDeliveryStatus WebhookDeliveryStatus `json:"webhook_delivery_status"`
+	Valid                 bool                  `json:"valid"` // Valid is true if WebhookDeliveryStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWebhookDeliveryStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.WebhookDeliveryStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WebhookDeliveryStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWebhookDeliveryStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WebhookDeliveryStatus), nil
+}
+
+type EntryChange struct {
+	ID         int64           `json:"id"`
+	SourceID   uuid.UUID       `json:"source_id"`
+	EntryType  EntryType       `json:"entry_type"`
+	Namespace  *string         `json:"namespace"`
+	Name       string          `json:"name"`
+	Version    string          `json:"version"`
+	ChangeType EntryChangeType `json:"change_type"`
+	Claims     []byte          `json:"claims"`
+	ChangedAt  time.Time       `json:"changed_at"`
+}
+
+type EntryScan struct {
+	VersionID uuid.UUID `json:"version_id"`
+	Verdict   string    `json:"verdict"`
+	Findings  []byte    `json:"findings"`
+	ScannedAt time.Time `json:"scanned_at"`
+}
+
+type EntrySignature struct {
+	VersionID  uuid.UUID `json:"version_id"`
+	Format     string    `json:"format"`
+	KeyID      string    `json:"key_id"`
+	Signature  string    `json:"signature"`
+	VerifiedAt time.Time `json:"verified_at"`
+}
+
+type EntrySubmission struct {
+	ID            uuid.UUID        `json:"id"`
+	SourceID      uuid.UUID        `json:"source_id"`
+	EntryType     EntryType        `json:"entry_type"`
+	Namespace     *string          `json:"namespace"`
+	Name          string           `json:"name"`
+	Version       string           `json:"version"`
+	Payload       []byte           `json:"payload"`
+	Claims        []byte           `json:"claims"`
+	Status        SubmissionStatus `json:"status"`
+	SubmittedBy   *string          `json:"submitted_by"`
+	SubmittedAt   time.Time        `json:"submitted_at"`
+	ReviewedBy    *string          `json:"reviewed_by"`
+	ReviewComment *string          `json:"review_comment"`
+	ReviewedAt    *time.Time       `json:"reviewed_at"`
+	Signature     []byte           `json:"signature"`
+}
+
 type EntryVersion struct {
-	ID          uuid.UUID  `json:"id"`
-	EntryID     uuid.UUID  `json:"entry_id"`
-	Version     string     `json:"version"`
-	Title       *string    `json:"title"`
-	Description *string    `json:"description"`
-	CreatedAt   *time.Time `json:"created_at"`
-	UpdatedAt   *time.Time `json:"updated_at"`
-	Name        string     `json:"name"`
+	ID           uuid.UUID   `json:"id"`
+	EntryID      uuid.UUID   `json:"entry_id"`
+	Version      string      `json:"version"`
+	Title        *string     `json:"title"`
+	Description  *string     `json:"description"`
+	CreatedAt    *time.Time  `json:"created_at"`
+	UpdatedAt    *time.Time  `json:"updated_at"`
+	Name         string      `json:"name"`
+	SearchVector interface{} `json:"search_vector"`
 }
 
 type LatestEntryVersion struct {
@@ -288,14 +550,19 @@ type LatestEntryVersion struct {
 }
 
 type McpServer struct {
-	Website             *string   `json:"website"`
-	UpstreamMeta        []byte    `json:"upstream_meta"`
-	ServerMeta          []byte    `json:"server_meta"`
-	RepositoryUrl       *string   `json:"repository_url"`
-	RepositoryID        *string   `json:"repository_id"`
-	RepositorySubfolder *string   `json:"repository_subfolder"`
-	RepositoryType      *string   `json:"repository_type"`
-	VersionID           uuid.UUID `json:"version_id"`
+	Website             *string      `json:"website"`
+	UpstreamMeta        []byte       `json:"upstream_meta"`
+	ServerMeta          []byte       `json:"server_meta"`
+	RepositoryUrl       *string      `json:"repository_url"`
+	RepositoryID        *string      `json:"repository_id"`
+	RepositorySubfolder *string      `json:"repository_subfolder"`
+	RepositoryType      *string      `json:"repository_type"`
+	VersionID           uuid.UUID    `json:"version_id"`
+	ToolsSearchVector   interface{}  `json:"tools_search_vector"`
+	Status              ServerStatus `json:"status"`
+	StatusReason        *string      `json:"status_reason"`
+	ReplacedBy          *string      `json:"replaced_by"`
+	StatusChangedAt     *time.Time   `json:"status_changed_at"`
 }
 
 type McpServerIcon struct {
@@ -328,6 +595,22 @@ type McpServerRemote struct {
 	TransportHeaders []byte    `json:"transport_headers"`
 }
 
+type Namespace struct {
+	Name      string    `json:"name"`
+	Claims    []byte    `json:"claims"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type PackageDigest struct {
+	VersionID      uuid.UUID  `json:"version_id"`
+	Identifier     string     `json:"identifier"`
+	Digest         string     `json:"digest"`
+	PreviousDigest *string    `json:"previous_digest"`
+	ResolvedAt     time.Time  `json:"resolved_at"`
+	DriftedAt      *time.Time `json:"drifted_at"`
+}
+
 type Plugin struct {
 	VersionID     uuid.UUID    `json:"version_id"`
 	Namespace     string       `json:"namespace"`
@@ -363,6 +646,7 @@ type Registry struct {
 	CreationType CreationType `json:"creation_type"`
 	CreatedAt    *time.Time   `json:"created_at"`
 	UpdatedAt    *time.Time   `json:"updated_at"`
+	Merge        []byte       `json:"merge"`
 }
 
 type RegistryEntry struct {
@@ -375,6 +659,17 @@ type RegistryEntry struct {
 	Claims    []byte     `json:"claims"`
 }
 
+type RegistryOverlay struct {
+	RegistryID uuid.UUID `json:"registry_id"`
+	Name       string    `json:"name"`
+	Title      *string   `json:"title"`
+	Tags       []string  `json:"tags"`
+	Icons      []byte    `json:"icons"`
+	Meta       []byte    `json:"meta"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
 type RegistrySource struct {
 	RegistryID uuid.UUID  `json:"registry_id"`
 	SourceID   uuid.UUID  `json:"source_id"`
@@ -395,6 +690,9 @@ type RegistrySync struct {
 	ServerCount           int64      `json:"server_count"`
 	SkillCount            int64      `json:"skill_count"`
 	PluginCount           int64      `json:"plugin_count"`
+	LastSuccessAt         *time.Time `json:"last_success_at"`
+	LastFullSyncAt        *time.Time `json:"last_full_sync_at"`
+	LastSyncCommit        *string    `json:"last_sync_commit"`
 }
 
 type Skill struct {
@@ -441,6 +739,47 @@ type Source struct {
 	Claims       []byte           `json:"claims"`
 }
 
+type SyncEntryDigest struct {
+	SourceID  uuid.UUID `json:"source_id"`
+	Digests   []byte    `json:"digests"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type SyncHistory struct {
+	ID              uuid.UUID  `json:"id"`
+	SourceID        uuid.UUID  `json:"source_id"`
+	SyncStatus      SyncStatus `json:"sync_status"`
+	Reason          string     `json:"reason"`
+	ConditionReason *string    `json:"condition_reason"`
+	ErrorMsg        *string    `json:"error_msg"`
+	SyncHash        *string    `json:"sync_hash"`
+	Incremental     bool       `json:"incremental"`
+	ServerCount     int64      `json:"server_count"`
+	SkillCount      int64      `json:"skill_count"`
+	PluginCount     int64      `json:"plugin_count"`
+	ServersAdded    int64      `json:"servers_added"`
+	ServersUpdated  int64      `json:"servers_updated"`
+	ServersRemoved  int64      `json:"servers_removed"`
+	SkillsAdded     int64      `json:"skills_added"`
+	SkillsUpdated   int64      `json:"skills_updated"`
+	SkillsRemoved   int64      `json:"skills_removed"`
+	PluginsAdded    int64      `json:"plugins_added"`
+	PluginsUpdated  int64      `json:"plugins_updated"`
+	PluginsRemoved  int64      `json:"plugins_removed"`
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         time.Time  `json:"ended_at"`
+}
+
+type SyncRequest struct {
+	ID          uuid.UUID         `json:"id"`
+	SourceID    uuid.UUID         `json:"source_id"`
+	Status      SyncRequestStatus `json:"status"`
+	ErrorMsg    *string           `json:"error_msg"`
+	RequestedAt time.Time         `json:"requested_at"`
+	StartedAt   *time.Time        `json:"started_at"`
+	EndedAt     *time.Time        `json:"ended_at"`
+}
+
 type TempEntryVersion struct {
 	ID          uuid.UUID  `json:"id"`
 	EntryID     uuid.UUID  `json:"entry_id"`
@@ -502,3 +841,17 @@ type TempRegistryEntry struct {
 	CreatedAt *time.Time `json:"created_at"`
 	UpdatedAt *time.Time `json:"updated_at"`
 }
+
+type WebhookDelivery struct {
+	ID             uuid.UUID             `json:"id"`
+	Webhook        string                `json:"webhook"`
+	EventType      string                `json:"event_type"`
+	Payload        []byte                `json:"payload"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int32                 `json:"attempts"`
+	ResponseStatus *int32                `json:"response_status"`
+	LastError      *string               `json:"last_error"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at"`
+	CreatedAt      time.Time             `json:"created_at"`
+	DeliveredAt    *time.Time            `json:"delivered_at"`
+}
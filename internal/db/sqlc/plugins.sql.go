@@ -542,25 +542,19 @@ SELECT src.source_type AS registry_type,
                           AND rs.registry_id = $1::uuid
  WHERE ($2::text IS NULL OR p.namespace = $2::text)
    AND ($3::text IS NULL OR e.name = $3::text)
-   AND ($4::text IS NULL OR (
-       LOWER(e.name) LIKE LOWER('%' || $4::text || '%')
-       OR LOWER(v.title) LIKE LOWER('%' || $4::text || '%')
-       OR LOWER(v.description) LIKE LOWER('%' || $4::text || '%')
-   ))
-   AND ($5::timestamp with time zone IS NULL OR v.updated_at > $5::timestamp with time zone)
+   AND ($4::timestamp with time zone IS NULL OR v.updated_at > $4::timestamp with time zone)
    AND (
-       $6::text IS NULL
-       OR (v.name, v.version) > ($6::text, $7::text)
+       $5::text IS NULL
+       OR (v.name, v.version) > ($5::text, $6::text)
    )
  ORDER BY v.name ASC, v.version ASC, rs.position ASC
- LIMIT $8::bigint
+ LIMIT $7::bigint
 `
 
 type ListPluginsParams struct {
 	RegistryID    uuid.UUID  `json:"registry_id"`
 	Namespace     *string    `json:"namespace"`
 	Name          *string    `json:"name"`
-	Search        *string    `json:"search"`
 	UpdatedSince  *time.Time `json:"updated_since"`
 	CursorName    *string    `json:"cursor_name"`
 	CursorVersion *string    `json:"cursor_version"`
@@ -597,7 +591,6 @@ func (q *Queries) ListPlugins(ctx context.Context, arg ListPluginsParams) ([]Lis
 		arg.RegistryID,
 		arg.Namespace,
 		arg.Name,
-		arg.Search,
 		arg.UpdatedSince,
 		arg.CursorName,
 		arg.CursorVersion,
@@ -640,6 +633,155 @@ func (q *Queries) ListPlugins(ctx context.Context, arg ListPluginsParams) ([]Lis
 	return items, nil
 }
 
+const searchPlugins = `-- name: SearchPlugins :many
+WITH matches AS (
+    SELECT src.source_type AS registry_type,
+           p.version_id,
+           e.name,
+           v.version,
+           (l.latest_version_id IS NOT NULL)::boolean AS is_latest,
+           v.created_at,
+           v.updated_at,
+           v.description,
+           v.title,
+           p.namespace,
+           p.status,
+           p.license,
+           p.repository,
+           p.icons,
+           p.metadata,
+           p.extension_meta,
+           e.claims,
+           COALESCE(rs.position, 32767)::integer AS position,
+           MAX(ts_rank(v.search_vector, to_tsquery('simple', $1::text)))
+               OVER (PARTITION BY e.name) AS relevance
+      FROM plugin p
+      JOIN entry_version v ON p.version_id = v.id
+      JOIN registry_entry e ON v.entry_id = e.id
+      JOIN source src ON e.source_id = src.id
+      LEFT JOIN latest_entry_version l ON v.id = l.latest_version_id
+      JOIN registry_source rs ON rs.source_id = e.source_id
+                              AND rs.registry_id = $2::uuid
+     WHERE v.search_vector @@ to_tsquery('simple', $1::text)
+       AND ($3::text IS NULL OR p.namespace = $3::text)
+       AND ($4::text IS NULL OR e.name = $4::text)
+       AND ($5::timestamp with time zone IS NULL OR v.updated_at > $5::timestamp with time zone)
+), cursor_match AS (
+    SELECT relevance FROM matches WHERE name = $6::text LIMIT 1
+)
+SELECT registry_type,
+       version_id,
+       name,
+       version,
+       is_latest,
+       created_at,
+       updated_at,
+       description,
+       title,
+       namespace,
+       status,
+       license,
+       repository,
+       icons,
+       metadata,
+       extension_meta,
+       claims,
+       position
+  FROM matches
+ WHERE $6::text IS NULL
+    OR relevance < (SELECT relevance FROM cursor_match)
+    OR (relevance = (SELECT relevance FROM cursor_match)
+        AND (name, version) > ($6::text, $7::text))
+ ORDER BY relevance DESC, name ASC, version ASC, position ASC
+ LIMIT $8::bigint
+`
+
+type SearchPluginsParams struct {
+	Search        string     `json:"search"`
+	RegistryID    uuid.UUID  `json:"registry_id"`
+	Namespace     *string    `json:"namespace"`
+	Name          *string    `json:"name"`
+	UpdatedSince  *time.Time `json:"updated_since"`
+	CursorName    *string    `json:"cursor_name"`
+	CursorVersion *string    `json:"cursor_version"`
+	Size          int64      `json:"size"`
+}
+
+type SearchPluginsRow struct {
+	RegistryType  string       `json:"registry_type"`
+	VersionID     uuid.UUID    `json:"version_id"`
+	Name          string       `json:"name"`
+	Version       string       `json:"version"`
+	IsLatest      bool         `json:"is_latest"`
+	CreatedAt     *time.Time   `json:"created_at"`
+	UpdatedAt     *time.Time   `json:"updated_at"`
+	Description   *string      `json:"description"`
+	Title         *string      `json:"title"`
+	Namespace     string       `json:"namespace"`
+	Status        PluginStatus `json:"status"`
+	License       *string      `json:"license"`
+	Repository    []byte       `json:"repository"`
+	Icons         []byte       `json:"icons"`
+	Metadata      []byte       `json:"metadata"`
+	ExtensionMeta []byte       `json:"extension_meta"`
+	Claims        []byte       `json:"claims"`
+	Position      int32        `json:"position"`
+}
+
+// Full-text search over the name, title and description of plugins, ordered by relevance.
+// The search parameter is a tsquery in the 'simple' configuration.
+// All versions of a plugin share the relevance of its best matching row, so they stay
+// adjacent in the same (name, version, position) order as ListPlugins.
+// When cursor is provided, results start AFTER the specified (name, version) tuple
+// in relevance order.
+func (q *Queries) SearchPlugins(ctx context.Context, arg SearchPluginsParams) ([]SearchPluginsRow, error) {
+	rows, err := q.db.Query(ctx, searchPlugins,
+		arg.Search,
+		arg.RegistryID,
+		arg.Namespace,
+		arg.Name,
+		arg.UpdatedSince,
+		arg.CursorName,
+		arg.CursorVersion,
+		arg.Size,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchPluginsRow{}
+	for rows.Next() {
+		var i SearchPluginsRow
+		if err := rows.Scan(
+			&i.RegistryType,
+			&i.VersionID,
+			&i.Name,
+			&i.Version,
+			&i.IsLatest,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Description,
+			&i.Title,
+			&i.Namespace,
+			&i.Status,
+			&i.License,
+			&i.Repository,
+			&i.Icons,
+			&i.Metadata,
+			&i.ExtensionMeta,
+			&i.Claims,
+			&i.Position,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const upsertLatestPluginVersion = `-- name: UpsertLatestPluginVersion :one
 INSERT INTO latest_entry_version (
     source_id,
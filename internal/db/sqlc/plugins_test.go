@@ -958,31 +958,66 @@ func TestListPlugins(t *testing.T) {
 			},
 		},
 		{
-			name: "list plugins with search",
+			name: "search plugins ordered by relevance",
 			//nolint:thelper // We want to see these lines in the test output
 			setupFunc: func(t *testing.T, queries *Queries, regID uuid.UUID) {
-				entryID1 := createPluginEntry(t, queries, regID, "code-review", testPluginVersion,
-					ptr.String("Automated code review tool"), ptr.String("Code Review"))
+				entryID1 := createPluginEntry(t, queries, regID, "style-checker", testPluginVersion,
+					ptr.String("Checks the style of code"), ptr.String("Style Checker"))
 				insertPlugin(t, queries, entryID1, "test-namespace")
 
-				entryID2 := createPluginEntry(t, queries, regID, "test-runner", testPluginVersion,
-					ptr.String("Run unit tests"), ptr.String("Test Runner"))
+				entryID2 := createPluginEntry(t, queries, regID, "code-review", testPluginVersion,
+					ptr.String("Automated review tool"), ptr.String("Code Review"))
 				insertPlugin(t, queries, entryID2, "test-namespace")
+
+				entryID3 := createPluginEntry(t, queries, regID, "test-runner", testPluginVersion,
+					ptr.String("Run unit tests"), ptr.String("Test Runner"))
+				insertPlugin(t, queries, entryID3, "test-namespace")
 			},
 			//nolint:thelper // We want to see these lines in the test output
 			scenarioFunc: func(t *testing.T, queries *Queries, _ uuid.UUID) {
-				search := "code"
-				plugins, err := queries.ListPlugins(
+				registryID := getRegistryID(t, queries, "test-registry")
+				plugins, err := queries.SearchPlugins(
 					context.Background(),
-					ListPluginsParams{
-						RegistryID: getRegistryID(t, queries, "test-registry"),
-						Search:     &search,
+					SearchPluginsParams{
+						RegistryID: registryID,
+						Search:     "cod:*",
 						Size:       10,
 					},
 				)
 				require.NoError(t, err)
-				require.Len(t, plugins, 1)
+				require.Len(t, plugins, 2)
+				// A match in the name ranks above a match in the description
 				assert.Equal(t, "code-review", plugins[0].Name)
+				assert.Equal(t, "style-checker", plugins[1].Name)
+
+				// The cursor continues in relevance order
+				cursorName, cursorVersion := plugins[0].Name, plugins[0].Version
+				next, err := queries.SearchPlugins(
+					context.Background(),
+					SearchPluginsParams{
+						RegistryID:    registryID,
+						Search:        "cod:*",
+						CursorName:    &cursorName,
+						CursorVersion: &cursorVersion,
+						Size:          10,
+					},
+				)
+				require.NoError(t, err)
+				require.Len(t, next, 1)
+				assert.Equal(t, "style-checker", next[0].Name)
+
+				// Every word must match
+				plugins, err = queries.SearchPlugins(
+					context.Background(),
+					SearchPluginsParams{
+						RegistryID: registryID,
+						Search:     "unit:* & test:*",
+						Size:       10,
+					},
+				)
+				require.NoError(t, err)
+				require.Len(t, plugins, 1)
+				assert.Equal(t, "test-runner", plugins[0].Name)
 			},
 		},
 		{
@@ -6,6 +6,7 @@ package sqlc
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -14,6 +15,13 @@ type Querier interface {
 	BulkInitializeSourceSyncs(ctx context.Context, arg BulkInitializeSourceSyncsParams) error
 	// Bulk insert or update CONFIG sources (only updates existing CONFIG sources)
 	BulkUpsertConfigSources(ctx context.Context, arg BulkUpsertConfigSourcesParams) ([]BulkUpsertConfigSourcesRow, error)
+	// Claims the pending deliveries that are due. Their next attempt is pushed back
+	// to the end of the lease, so that other instances skip them while they are
+	// delivered and retry them if this instance stops before recording the outcome.
+	ClaimWebhookDeliveries(ctx context.Context, arg ClaimWebhookDeliveriesParams) ([]ClaimWebhookDeliveriesRow, error)
+	// Forget the synced commit of sources whose configuration may have changed, so
+	// that their next sync fetches the data instead of only checking the remote commit
+	ClearSourceSyncCommits(ctx context.Context, sourceIds []uuid.UUID) error
 	CountEntryVersions(ctx context.Context, entryID uuid.UUID) (int64, error)
 	// Count how many registries reference a given source (via registry_source junction).
 	CountRegistriesBySourceID(ctx context.Context, sourceID uuid.UUID) (int64, error)
@@ -37,11 +45,15 @@ type Querier interface {
 	DeleteConfigRegistriesNotInList(ctx context.Context, keepNames []string) error
 	// Delete CONFIG sources not in the provided list (for config file sync)
 	DeleteConfigSourcesNotInList(ctx context.Context, ids []uuid.UUID) error
+	DeleteEntryChangesBefore(ctx context.Context, before time.Time) error
 	DeleteEntryVersion(ctx context.Context, arg DeleteEntryVersionParams) (int64, error)
+	// Removes the latest version pointer of a server that has no active version left.
+	DeleteLatestServerVersion(ctx context.Context, arg DeleteLatestServerVersionParams) error
 	DeleteOrphanedEntryVersions(ctx context.Context, arg DeleteOrphanedEntryVersionsParams) error
 	DeleteOrphanedIcons(ctx context.Context, serverIds []uuid.UUID) error
 	DeleteOrphanedPackages(ctx context.Context, serverIds []uuid.UUID) error
 	DeleteOrphanedRemotes(ctx context.Context, serverIds []uuid.UUID) error
+	DeleteNamespace(ctx context.Context, name string) (int64, error)
 	DeletePluginGitPackagesByPluginId(ctx context.Context, pluginID uuid.UUID) error
 	DeletePluginOciPackagesByPluginId(ctx context.Context, pluginID uuid.UUID) error
 	DeletePluginsByRegistry(ctx context.Context, sourceID uuid.UUID) error
@@ -49,6 +61,7 @@ type Querier interface {
 	DeleteRegistry(ctx context.Context, name string) (int64, error)
 	DeleteRegistryEntry(ctx context.Context, arg DeleteRegistryEntryParams) (int64, error)
 	DeleteRegistryEntryByID(ctx context.Context, id uuid.UUID) (int64, error)
+	DeleteRegistryOverlay(ctx context.Context, arg DeleteRegistryOverlayParams) (int64, error)
 	DeleteServerIconsByServerId(ctx context.Context, serverID uuid.UUID) error
 	DeleteServerPackagesByServerId(ctx context.Context, serverID uuid.UUID) error
 	DeleteServerRemotesByServerId(ctx context.Context, serverID uuid.UUID) error
@@ -58,11 +71,30 @@ type Querier interface {
 	DeleteSkillsByRegistry(ctx context.Context, sourceID uuid.UUID) error
 	// Delete a source by name. Go callers guard against deleting wrong creation_type.
 	DeleteSource(ctx context.Context, name string) (int64, error)
+	// Deletes the scans of the entry versions of a source, before a full sync
+	// records the scans of its entries.
+	DeleteSourceEntryScans(ctx context.Context, sourceID uuid.UUID) error
+	DeleteSyncHistoryBefore(ctx context.Context, arg DeleteSyncHistoryBeforeParams) error
+	// Prunes the deliveries that are no longer pending and were queued before the
+	// retention period.
+	DeleteWebhookDeliveriesBefore(ctx context.Context, before time.Time) error
 	DropTempEntryVersionTable(ctx context.Context) error
 	DropTempRegistryEntryTable(ctx context.Context) error
+	FinishSyncRequests(ctx context.Context, arg FinishSyncRequestsParams) error
 	GetAPISourcesByNames(ctx context.Context, names []string) ([]GetAPISourcesByNamesRow, error)
+	// Returns the ids of the oldest and newest retained changes, or zeros when
+	// no change is retained.
+	GetEntryChangeBounds(ctx context.Context) (GetEntryChangeBoundsRow, error)
+	// Reads a submission without locking it, before the transaction of its review.
+	GetEntrySubmission(ctx context.Context, id uuid.UUID) (EntrySubmission, error)
+	// Locks a submission for the duration of its review.
+	GetEntrySubmissionForUpdate(ctx context.Context, id uuid.UUID) (EntrySubmission, error)
 	GetLatestEntryVersion(ctx context.Context, arg GetLatestEntryVersionParams) (string, error)
 	GetManagedSources(ctx context.Context) ([]GetManagedSourcesRow, error)
+	GetNamespace(ctx context.Context, name string) (Namespace, error)
+	// Get the most specific namespace owning an entry namespace: the namespace
+	// itself, or the closest parent reverse-DNS namespace.
+	GetOwningNamespace(ctx context.Context, entryNamespace string) (Namespace, error)
 	// Despite the name, this query returns multiple rows. The actual number of
 	// records is bounded by the number of sources that provide the same name and
 	// version, which we currently don't expect to be more than a few.
@@ -74,6 +106,7 @@ type Querier interface {
 	GetPluginVersionBySourceName(ctx context.Context, arg GetPluginVersionBySourceNameParams) (GetPluginVersionBySourceNameRow, error)
 	GetRegistryByName(ctx context.Context, name string) (Registry, error)
 	GetRegistryEntryByName(ctx context.Context, arg GetRegistryEntryByNameParams) (GetRegistryEntryByNameRow, error)
+	GetRegistryOverlay(ctx context.Context, arg GetRegistryOverlayParams) (RegistryOverlay, error)
 	GetServerIDsByRegistryNameVersion(ctx context.Context, sourceID uuid.UUID) ([]GetServerIDsByRegistryNameVersionRow, error)
 	// Despite the name, this query returns multiple rows. The actual number of
 	// records is bounded by the number of sources that provide the same name and
@@ -95,15 +128,29 @@ type Querier interface {
 	GetSkillVersionBySourceName(ctx context.Context, arg GetSkillVersionBySourceNameParams) (GetSkillVersionBySourceNameRow, error)
 	GetSource(ctx context.Context, id uuid.UUID) (GetSourceRow, error)
 	GetSourceByName(ctx context.Context, name string) (GetSourceByNameRow, error)
+	GetSourceEntryDigests(ctx context.Context, name string) ([]byte, error)
 	GetSourceSync(ctx context.Context, id uuid.UUID) (RegistrySync, error)
 	GetSourceSyncByName(ctx context.Context, name string) (RegistrySync, error)
+	GetSyncRequest(ctx context.Context, arg GetSyncRequestParams) (SyncRequest, error)
 	InitializeSourceSync(ctx context.Context, arg InitializeSourceSyncParams) error
+	// Records a change of an entry version. The claims of the entry, or of its
+	// source when the entry has none, are copied into the change.
+	InsertEntryChange(ctx context.Context, arg InsertEntryChangeParams) error
+	// Records the verified signature of a published entry version.
+	InsertEntrySignature(ctx context.Context, arg InsertEntrySignatureParams) error
+	// Stores a publish awaiting review. Fails with a unique violation when the same
+	// entry version is already pending review.
+	InsertEntrySubmission(ctx context.Context, arg InsertEntrySubmissionParams) (EntrySubmission, error)
 	InsertEntryVersion(ctx context.Context, arg InsertEntryVersionParams) (uuid.UUID, error)
+	// Records an update of every version of an entry, such as a change of its claims.
+	InsertEntryVersionChanges(ctx context.Context, entryID uuid.UUID) error
+	InsertNamespace(ctx context.Context, arg InsertNamespaceParams) (Namespace, error)
 	InsertPluginGitPackage(ctx context.Context, arg InsertPluginGitPackageParams) error
 	InsertPluginOciPackage(ctx context.Context, arg InsertPluginOciPackageParams) error
 	InsertPluginVersion(ctx context.Context, arg InsertPluginVersionParams) (uuid.UUID, error)
 	InsertPluginVersionForSync(ctx context.Context, arg InsertPluginVersionForSyncParams) (uuid.UUID, error)
 	InsertRegistryEntry(ctx context.Context, arg InsertRegistryEntryParams) (uuid.UUID, error)
+	InsertRegistryOverlay(ctx context.Context, arg InsertRegistryOverlayParams) (RegistryOverlay, error)
 	InsertServerIcon(ctx context.Context, arg InsertServerIconParams) error
 	// TODO: this seems unused
 	InsertServerPackage(ctx context.Context, arg InsertServerPackageParams) error
@@ -120,11 +167,37 @@ type Querier interface {
 	// Insert a new source with full configuration. creation_type is passed as a parameter.
 	InsertSource(ctx context.Context, arg InsertSourceParams) (Source, error)
 	InsertSourceSync(ctx context.Context, arg InsertSourceSyncParams) (uuid.UUID, error)
+	// Records the entry versions changed by a sync of the named source, in the order given.
+	InsertSyncEntryChanges(ctx context.Context, arg InsertSyncEntryChangesParams) error
+	InsertSyncHistory(ctx context.Context, arg InsertSyncHistoryParams) error
+	// Queue a manual sync for a syncable source. A PENDING request for the same
+	// source is reused so repeated calls return the same request.
+	InsertSyncRequest(ctx context.Context, name string) (SyncRequest, error)
+	// Queues the delivery of an event to each of the given webhooks.
+	InsertWebhookDeliveries(ctx context.Context, arg InsertWebhookDeliveriesParams) error
 	LinkRegistrySource(ctx context.Context, arg LinkRegistrySourceParams) error
+	// Lists the versions of a server that may be pointed at as its latest version.
+	ListActiveServerVersions(ctx context.Context, entryID uuid.UUID) ([]ListActiveServerVersionsRow, error)
 	ListAllSourceNames(ctx context.Context) ([]string, error)
+	// Lists the entry versions of every source of a registry with their scan, flagging the fields of
+	// server versions that a later source can override under the merge strategies of the registry.
 	ListEntriesByRegistry(ctx context.Context, registryID uuid.UUID) ([]ListEntriesByRegistryRow, error)
 	ListEntriesBySource(ctx context.Context, sourceID uuid.UUID) ([]ListEntriesBySourceRow, error)
+	// List the namespaces of the versions of a skill or plugin entry.
+	ListEntryNamespaces(ctx context.Context, entryID uuid.UUID) ([]string, error)
+	// Returns the scans of the given entry versions. Versions stored without a
+	// scan have no row.
+	ListEntryScans(ctx context.Context, versionIds []uuid.UUID) ([]EntryScan, error)
+	// Returns the verified signatures of the given entry versions. Versions
+	// published without a verified signature have no row.
+	ListEntrySignatures(ctx context.Context, versionIds []uuid.UUID) ([]EntrySignature, error)
+	// Cursor-based pagination using (submitted_at, id) compound cursor, oldest first.
+	// When cursor is provided, results start AFTER the specified tuple.
+	ListEntrySubmissions(ctx context.Context, arg ListEntrySubmissionsParams) ([]EntrySubmission, error)
 	ListEntryVersions(ctx context.Context, entryID uuid.UUID) ([]ListEntryVersionsRow, error)
+	ListNamespaces(ctx context.Context) ([]Namespace, error)
+	// Returns the resolved digests of the OCI packages of the given entry versions.
+	ListPackageDigests(ctx context.Context, versionIds []uuid.UUID) ([]PackageDigest, error)
 	ListPluginGitPackages(ctx context.Context, versionIds []uuid.UUID) ([]PluginGitPackage, error)
 	ListPluginOciPackages(ctx context.Context, versionIds []uuid.UUID) ([]PluginOciPackage, error)
 	// Cursor-based pagination using (name, version) compound cursor.
@@ -134,7 +207,22 @@ type Querier interface {
 	ListPlugins(ctx context.Context, arg ListPluginsParams) ([]ListPluginsRow, error)
 	// Queries for the new lightweight registry table and registry_source junction.
 	ListRegistries(ctx context.Context, arg ListRegistriesParams) ([]Registry, error)
+	// Lists the changes of the entries of the sources of a registry, in id order,
+	// starting AFTER the given id.
+	ListRegistryEntryChanges(ctx context.Context, arg ListRegistryEntryChangesParams) ([]ListRegistryEntryChangesRow, error)
+	// Lists the versions of the entries of a registry with the position of their source,
+	// to select the source providing the highest version of each entry.
+	ListRegistryEntryVersions(ctx context.Context, arg ListRegistryEntryVersionsParams) ([]ListRegistryEntryVersionsRow, error)
+	ListRegistryOverlays(ctx context.Context, registryID uuid.UUID) ([]RegistryOverlay, error)
+	// List the overlays of the given servers of a registry, to merge them into the
+	// server versions the registry serves.
+	ListRegistryOverlaysByNames(ctx context.Context, arg ListRegistryOverlaysByNamesParams) ([]RegistryOverlay, error)
 	ListRegistrySources(ctx context.Context, registryID uuid.UUID) ([]ListRegistrySourcesRow, error)
+	// Lists the versions of the given servers provided by the sources of a registry, in
+	// position order, with the fields a later source can override on the version served
+	// from an earlier one under the merge strategies of the registry.
+	ListServerFieldOverrides(ctx context.Context, arg ListServerFieldOverridesParams) ([]ListServerFieldOverridesRow, error)
+	ListServerIcons(ctx context.Context, versionIds []uuid.UUID) ([]McpServerIcon, error)
 	ListServerPackages(ctx context.Context, versionIds []uuid.UUID) ([]ListServerPackagesRow, error)
 	ListServerRemotes(ctx context.Context, versionIds []uuid.UUID) ([]McpServerRemote, error)
 	// Cursor-based pagination using (name, version) compound cursor.
@@ -142,6 +230,7 @@ type Querier interface {
 	// When cursor is provided, results start AFTER the specified (name, version) tuple.
 	// Returns position from registry_source for source priority ordering.
 	// When name is provided, results are filtered to versions of that specific server.
+	// Deprecated and yanked versions are skipped unless include_inactive is set.
 	ListServers(ctx context.Context, arg ListServersParams) ([]ListServersRow, error)
 	ListSkillGitPackages(ctx context.Context, versionIds []uuid.UUID) ([]SkillGitPackage, error)
 	ListSkillOciPackages(ctx context.Context, versionIds []uuid.UUID) ([]SkillOciPackage, error)
@@ -151,18 +240,66 @@ type Querier interface {
 	// Returns position from registry_source for source priority ordering.
 	ListSkills(ctx context.Context, arg ListSkillsParams) ([]ListSkillsRow, error)
 	ListSourceSyncs(ctx context.Context) ([]ListSourceSyncsRow, error)
-	ListSourceSyncsByLastUpdate(ctx context.Context) ([]ListSourceSyncsByLastUpdateRow, error)
+	ListSourceSyncsByLastUpdate(ctx context.Context, skippedNames []string) ([]ListSourceSyncsByLastUpdateRow, error)
 	ListSources(ctx context.Context, arg ListSourcesParams) ([]ListSourcesRow, error)
+	// Cursor-based pagination using (started_at, id) compound cursor, newest first.
+	// When cursor is provided, results start AFTER the specified tuple.
+	ListSyncHistory(ctx context.Context, arg ListSyncHistoryParams) ([]SyncHistory, error)
+	// Cursor-based pagination using (created_at, id) compound cursor, newest first.
+	// When cursor is provided, results start AFTER the specified tuple.
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	// Serializes the transactions that record entry changes until they commit, so
+	// that changes become visible in id order and readers never skip one.
+	LockEntryChanges(ctx context.Context) error
 	// Update all registry entries for a source to match the source's current claims.
 	// Used during initialization to fix drift when source claims change without data change.
 	PropagateSourceClaimsToEntries(ctx context.Context, arg PropagateSourceClaimsToEntriesParams) error
+	// Full-text search over the latest versions of the servers, skills and plugins of a registry,
+	// ordered by relevance. The search parameter is a tsquery in the 'simple' configuration.
+	// An entry provided by several sources returns one row per source, adjacent in position order.
+	// When cursor is provided, results start AFTER the specified (relevance, entry_type, name)
+	// tuple, so that pagination goes on past a cursor entry that changed or was removed.
+	SearchEntries(ctx context.Context, arg SearchEntriesParams) ([]SearchEntriesRow, error)
+	// Full-text search over the name, title and description of plugins, ordered by relevance.
+	// The search parameter is a tsquery in the 'simple' configuration.
+	// All versions of a plugin share the relevance of its best matching row, so they stay
+	// adjacent in the same (name, version, position) order as ListPlugins.
+	// When cursor is provided, results start AFTER the specified (name, version) tuple
+	// in relevance order.
+	SearchPlugins(ctx context.Context, arg SearchPluginsParams) ([]SearchPluginsRow, error)
+	// Full-text search over the name, title, description and tool names of servers,
+	// ordered by relevance. The search parameter is a tsquery in the 'simple' configuration.
+	// All versions of a server share the relevance of its best matching row, so they stay
+	// adjacent in the same (name, version, position) order as ListServers.
+	// When cursor is provided, results start AFTER the specified (name, version) tuple
+	// in relevance order.
+	SearchServers(ctx context.Context, arg SearchServersParams) ([]SearchServersRow, error)
+	// Full-text search over the name, title and description of skills, ordered by relevance.
+	// The search parameter is a tsquery in the 'simple' configuration.
+	// All versions of a skill share the relevance of its best matching row, so they stay
+	// adjacent in the same (name, version, position) order as ListSkills.
+	// When cursor is provided, results start AFTER the specified (name, version) tuple
+	// in relevance order.
+	SearchSkills(ctx context.Context, arg SearchSkillsParams) ([]SearchSkillsRow, error)
+	StartSyncRequests(ctx context.Context, arg StartSyncRequestsParams) error
 	UnlinkAllRegistrySources(ctx context.Context, registryID uuid.UUID) error
 	UnlinkRegistrySource(ctx context.Context, arg UnlinkRegistrySourceParams) error
+	// Records the decision of a reviewer on a pending submission.
+	UpdateEntrySubmissionReview(ctx context.Context, arg UpdateEntrySubmissionReviewParams) (EntrySubmission, error)
+	UpdateNamespace(ctx context.Context, arg UpdateNamespaceParams) (Namespace, error)
 	UpdateRegistryEntryClaims(ctx context.Context, arg UpdateRegistryEntryClaimsParams) (int64, error)
+	UpdateRegistryOverlay(ctx context.Context, arg UpdateRegistryOverlayParams) (RegistryOverlay, error)
+	// Sets the lifecycle status of a server version, along with the reason for it
+	// and the version replacing it.
+	UpdateServerVersionStatus(ctx context.Context, arg UpdateServerVersionStatusParams) (int64, error)
 	// Update an existing source. Go callers guard against modifying wrong creation_type.
 	UpdateSource(ctx context.Context, arg UpdateSourceParams) (Source, error)
 	UpdateSourceSync(ctx context.Context, arg UpdateSourceSyncParams) error
 	UpdateSourceSyncStatusByName(ctx context.Context, arg UpdateSourceSyncStatusByNameParams) error
+	// Records the outcome of a delivery attempt.
+	UpdateWebhookDeliveryAttempt(ctx context.Context, arg UpdateWebhookDeliveryAttemptParams) error
+	// Records the scan of a published entry version.
+	UpsertEntryScan(ctx context.Context, arg UpsertEntryScanParams) error
 	UpsertEntryVersionsFromTemp(ctx context.Context) ([]UpsertEntryVersionsFromTempRow, error)
 	UpsertIconsFromTemp(ctx context.Context) error
 	UpsertLatestPluginVersion(ctx context.Context, arg UpsertLatestPluginVersionParams) (uuid.UUID, error)
@@ -184,6 +321,16 @@ type Querier interface {
 	// Insert or update a source. The creation_type is passed as a parameter.
 	// Business logic in Go guards against cross-type overwrites.
 	UpsertSource(ctx context.Context, arg UpsertSourceParams) (uuid.UUID, error)
+	UpsertSourceEntryDigests(ctx context.Context, arg UpsertSourceEntryDigestsParams) error
+	// Records the scans of the entry versions of a sync. Versions are matched by
+	// entry type, name and version among the entries of the source; findings are
+	// JSON documents, empty when the version has none.
+	UpsertSourceEntryScans(ctx context.Context, arg UpsertSourceEntryScansParams) error
+	// Records the digests the OCI packages of entry versions of a source resolved
+	// to. Versions are matched by entry type, name and version among the entries
+	// of the source. A package resolving to another digest than the stored one is
+	// flagged as drifted; the drifted packages are returned.
+	UpsertSourcePackageDigests(ctx context.Context, arg UpsertSourcePackageDigestsParams) ([]UpsertSourcePackageDigestsRow, error)
 	UpsertSourceSyncByName(ctx context.Context, arg UpsertSourceSyncByNameParams) error
 }
 
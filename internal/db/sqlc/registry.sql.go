@@ -51,7 +51,7 @@ func (q *Queries) DeleteRegistry(ctx context.Context, name string) (int64, error
 }
 
 const getRegistryByName = `-- name: GetRegistryByName :one
-SELECT id, name, claims, creation_type, created_at, updated_at
+SELECT id, name, claims, creation_type, created_at, updated_at, merge
 FROM registry WHERE name = $1
 `
 
@@ -65,6 +65,7 @@ func (q *Queries) GetRegistryByName(ctx context.Context, name string) (Registry,
 		&i.CreationType,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Merge,
 	)
 	return i, err
 }
@@ -88,7 +89,7 @@ func (q *Queries) LinkRegistrySource(ctx context.Context, arg LinkRegistrySource
 
 const listRegistries = `-- name: ListRegistries :many
 
-SELECT id, name, claims, creation_type, created_at, updated_at
+SELECT id, name, claims, creation_type, created_at, updated_at, merge
 FROM registry
 WHERE ($1::text IS NULL OR name > $1)
 ORDER BY name
@@ -117,6 +118,7 @@ func (q *Queries) ListRegistries(ctx context.Context, arg ListRegistriesParams)
 			&i.CreationType,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Merge,
 		); err != nil {
 			return nil, err
 		}
@@ -186,10 +188,10 @@ func (q *Queries) UnlinkRegistrySource(ctx context.Context, arg UnlinkRegistrySo
 }
 
 const upsertRegistry = `-- name: UpsertRegistry :one
-INSERT INTO registry (name, claims, creation_type, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5)
-ON CONFLICT (name) DO UPDATE SET claims = EXCLUDED.claims, updated_at = EXCLUDED.updated_at
-RETURNING id, name, claims, creation_type, created_at, updated_at
+INSERT INTO registry (name, claims, creation_type, created_at, updated_at, merge)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (name) DO UPDATE SET claims = EXCLUDED.claims, updated_at = EXCLUDED.updated_at, merge = EXCLUDED.merge
+RETURNING id, name, claims, creation_type, created_at, updated_at, merge
 `
 
 type UpsertRegistryParams struct {
@@ -198,6 +200,7 @@ type UpsertRegistryParams struct {
 	CreationType CreationType `json:"creation_type"`
 	CreatedAt    *time.Time   `json:"created_at"`
 	UpdatedAt    *time.Time   `json:"updated_at"`
+	Merge        []byte       `json:"merge"`
 }
 
 // Insert or update a registry. The creation_type is passed as a parameter.
@@ -209,6 +212,7 @@ func (q *Queries) UpsertRegistry(ctx context.Context, arg UpsertRegistryParams)
 		arg.CreationType,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.Merge,
 	)
 	var i Registry
 	err := row.Scan(
@@ -218,6 +222,7 @@ func (q *Queries) UpsertRegistry(ctx context.Context, arg UpsertRegistryParams)
 		&i.CreationType,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Merge,
 	)
 	return i, err
 }
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const countEntryVersions = `-- name: CountEntryVersions :one
@@ -217,27 +218,41 @@ SELECT e.entry_type,
        v.created_at,
        v.updated_at,
        src.name AS source_name,
-       rs.position
+       rs.position,
+       (s.server_meta IS NOT NULL)::boolean AS has_meta,
+       EXISTS (SELECT 1 FROM mcp_server_icon i WHERE i.server_id = v.id)::boolean AS has_icons,
+       sc.verdict AS scan_verdict,
+       sc.findings AS scan_findings,
+       sc.scanned_at
   FROM registry_source rs
   JOIN source src ON rs.source_id = src.id
   JOIN registry_entry e ON e.source_id = rs.source_id
   JOIN entry_version v ON v.entry_id = e.id
+  LEFT JOIN mcp_server s ON s.version_id = v.id
+  LEFT JOIN entry_scan sc ON sc.version_id = v.id
  WHERE rs.registry_id = $1
  ORDER BY v.name ASC, v.version ASC, rs.position ASC
 `
 
 type ListEntriesByRegistryRow struct {
-	EntryType   EntryType  `json:"entry_type"`
-	Name        string     `json:"name"`
-	Version     string     `json:"version"`
-	Title       *string    `json:"title"`
-	Description *string    `json:"description"`
-	CreatedAt   *time.Time `json:"created_at"`
-	UpdatedAt   *time.Time `json:"updated_at"`
-	SourceName  string     `json:"source_name"`
-	Position    int32      `json:"position"`
+	EntryType    EntryType  `json:"entry_type"`
+	Name         string     `json:"name"`
+	Version      string     `json:"version"`
+	Title        *string    `json:"title"`
+	Description  *string    `json:"description"`
+	CreatedAt    *time.Time `json:"created_at"`
+	UpdatedAt    *time.Time `json:"updated_at"`
+	SourceName   string     `json:"source_name"`
+	Position     int32      `json:"position"`
+	HasMeta      bool       `json:"has_meta"`
+	HasIcons     bool       `json:"has_icons"`
+	ScanVerdict  *string    `json:"scan_verdict"`
+	ScanFindings []byte     `json:"scan_findings"`
+	ScannedAt    *time.Time `json:"scanned_at"`
 }
 
+// Lists the entry versions of every source of a registry with their scan, flagging the fields of
+// server versions that a later source can override under the merge strategies of the registry.
 func (q *Queries) ListEntriesByRegistry(ctx context.Context, registryID uuid.UUID) ([]ListEntriesByRegistryRow, error) {
 	rows, err := q.db.Query(ctx, listEntriesByRegistry, registryID)
 	if err != nil {
@@ -257,6 +272,11 @@ func (q *Queries) ListEntriesByRegistry(ctx context.Context, registryID uuid.UUI
 			&i.UpdatedAt,
 			&i.SourceName,
 			&i.Position,
+			&i.HasMeta,
+			&i.HasIcons,
+			&i.ScanVerdict,
+			&i.ScanFindings,
+			&i.ScannedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -355,6 +375,60 @@ func (q *Queries) ListEntryVersions(ctx context.Context, entryID uuid.UUID) ([]L
 	return items, nil
 }
 
+const listRegistryEntryVersions = `-- name: ListRegistryEntryVersions :many
+SELECT e.entry_type,
+       e.name,
+       v.version,
+       rs.position
+  FROM registry_source rs
+  JOIN registry_entry e ON e.source_id = rs.source_id
+  JOIN entry_version v ON v.entry_id = e.id
+ WHERE rs.registry_id = $1::uuid
+   AND ($2::entry_type IS NULL OR e.entry_type = $2::entry_type)
+   AND ($3::text IS NULL OR e.name = $3::text)
+ ORDER BY rs.position ASC, v.version ASC
+`
+
+type ListRegistryEntryVersionsParams struct {
+	RegistryID uuid.UUID     `json:"registry_id"`
+	EntryType  NullEntryType `json:"entry_type"`
+	Name       *string       `json:"name"`
+}
+
+type ListRegistryEntryVersionsRow struct {
+	EntryType EntryType `json:"entry_type"`
+	Name      string    `json:"name"`
+	Version   string    `json:"version"`
+	Position  int32     `json:"position"`
+}
+
+// Lists the versions of the entries of a registry with the position of their source,
+// to select the source providing the highest version of each entry.
+func (q *Queries) ListRegistryEntryVersions(ctx context.Context, arg ListRegistryEntryVersionsParams) ([]ListRegistryEntryVersionsRow, error) {
+	rows, err := q.db.Query(ctx, listRegistryEntryVersions, arg.RegistryID, arg.EntryType, arg.Name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListRegistryEntryVersionsRow{}
+	for rows.Next() {
+		var i ListRegistryEntryVersionsRow
+		if err := rows.Scan(
+			&i.EntryType,
+			&i.Name,
+			&i.Version,
+			&i.Position,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const propagateSourceClaimsToEntries = `-- name: PropagateSourceClaimsToEntries :exec
 UPDATE registry_entry
    SET claims = $1,
@@ -375,6 +449,115 @@ func (q *Queries) PropagateSourceClaimsToEntries(ctx context.Context, arg Propag
 	return err
 }
 
+const searchEntries = `-- name: SearchEntries :many
+WITH matches AS (
+    SELECT e.entry_type,
+           e.name,
+           v.version,
+           v.title,
+           v.description,
+           COALESCE(sk.namespace, p.namespace) AS namespace,
+           v.updated_at,
+           e.claims,
+           rs.position,
+           MAX(ts_rank(v.search_vector || COALESCE(s.tools_search_vector, ''::tsvector),
+                       to_tsquery('simple', $1::text)))
+               OVER (PARTITION BY e.entry_type, e.name) AS relevance
+      FROM registry_source rs
+      JOIN registry_entry e ON e.source_id = rs.source_id
+      JOIN entry_version v ON v.entry_id = e.id
+      JOIN latest_entry_version l ON v.id = l.latest_version_id
+      LEFT JOIN mcp_server s ON s.version_id = v.id
+      LEFT JOIN skill sk ON sk.version_id = v.id
+      LEFT JOIN plugin p ON p.version_id = v.id
+     WHERE rs.registry_id = $2::uuid
+       AND (v.search_vector @@ to_tsquery('simple', $1::text)
+            OR s.tools_search_vector @@ to_tsquery('simple', $1::text))
+)
+SELECT entry_type,
+       name,
+       version,
+       title,
+       description,
+       namespace,
+       updated_at,
+       claims,
+       position,
+       relevance::real AS relevance
+  FROM matches
+ WHERE $3::text IS NULL
+    OR relevance < $4::real
+    OR (relevance = $4::real
+        AND (entry_type, name) > ($5::entry_type, $3::text))
+ ORDER BY relevance DESC, entry_type ASC, name ASC, position ASC
+ LIMIT $6::bigint
+`
+
+type SearchEntriesParams struct {
+	Search          string        `json:"search"`
+	RegistryID      uuid.UUID     `json:"registry_id"`
+	CursorName      *string       `json:"cursor_name"`
+	CursorRelevance pgtype.Float4 `json:"cursor_relevance"`
+	CursorType      NullEntryType `json:"cursor_type"`
+	Size            int64         `json:"size"`
+}
+
+type SearchEntriesRow struct {
+	EntryType   EntryType  `json:"entry_type"`
+	Name        string     `json:"name"`
+	Version     string     `json:"version"`
+	Title       *string    `json:"title"`
+	Description *string    `json:"description"`
+	Namespace   *string    `json:"namespace"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+	Claims      []byte     `json:"claims"`
+	Position    int32      `json:"position"`
+	Relevance   float32    `json:"relevance"`
+}
+
+// Full-text search over the latest versions of the servers, skills and plugins of a registry,
+// ordered by relevance. The search parameter is a tsquery in the 'simple' configuration.
+// An entry provided by several sources returns one row per source, adjacent in position order.
+// When cursor is provided, results start AFTER the specified (relevance, entry_type, name)
+// tuple, so that pagination goes on past a cursor entry that changed or was removed.
+func (q *Queries) SearchEntries(ctx context.Context, arg SearchEntriesParams) ([]SearchEntriesRow, error) {
+	rows, err := q.db.Query(ctx, searchEntries,
+		arg.Search,
+		arg.RegistryID,
+		arg.CursorName,
+		arg.CursorRelevance,
+		arg.CursorType,
+		arg.Size,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchEntriesRow{}
+	for rows.Next() {
+		var i SearchEntriesRow
+		if err := rows.Scan(
+			&i.EntryType,
+			&i.Name,
+			&i.Version,
+			&i.Title,
+			&i.Description,
+			&i.Namespace,
+			&i.UpdatedAt,
+			&i.Claims,
+			&i.Position,
+			&i.Relevance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateRegistryEntryClaims = `-- name: UpdateRegistryEntryClaims :execrows
 UPDATE registry_entry
    SET claims = $1,
@@ -135,6 +135,10 @@ SELECT src.source_type as registry_type,
        s.repository_id,
        s.repository_subfolder,
        s.repository_type,
+       s.status,
+       s.status_reason,
+       s.replaced_by,
+       s.status_changed_at,
        e.claims,
        rs.source_id,
        -- Sources not linked to the requested registry have no position; default to max int16
@@ -174,25 +178,29 @@ type GetServerVersionParams struct {
 }
 
 type GetServerVersionRow struct {
-	RegistryType        string     `json:"registry_type"`
-	ID                  uuid.UUID  `json:"id"`
-	Name                string     `json:"name"`
-	Version             string     `json:"version"`
-	IsLatest            bool       `json:"is_latest"`
-	CreatedAt           *time.Time `json:"created_at"`
-	UpdatedAt           *time.Time `json:"updated_at"`
-	Description         *string    `json:"description"`
-	Title               *string    `json:"title"`
-	Website             *string    `json:"website"`
-	UpstreamMeta        []byte     `json:"upstream_meta"`
-	ServerMeta          []byte     `json:"server_meta"`
-	RepositoryUrl       *string    `json:"repository_url"`
-	RepositoryID        *string    `json:"repository_id"`
-	RepositorySubfolder *string    `json:"repository_subfolder"`
-	RepositoryType      *string    `json:"repository_type"`
-	Claims              []byte     `json:"claims"`
-	SourceID            uuid.UUID  `json:"source_id"`
-	Position            int32      `json:"position"`
+	RegistryType        string       `json:"registry_type"`
+	ID                  uuid.UUID    `json:"id"`
+	Name                string       `json:"name"`
+	Version             string       `json:"version"`
+	IsLatest            bool         `json:"is_latest"`
+	CreatedAt           *time.Time   `json:"created_at"`
+	UpdatedAt           *time.Time   `json:"updated_at"`
+	Description         *string      `json:"description"`
+	Title               *string      `json:"title"`
+	Website             *string      `json:"website"`
+	UpstreamMeta        []byte       `json:"upstream_meta"`
+	ServerMeta          []byte       `json:"server_meta"`
+	RepositoryUrl       *string      `json:"repository_url"`
+	RepositoryID        *string      `json:"repository_id"`
+	RepositorySubfolder *string      `json:"repository_subfolder"`
+	RepositoryType      *string      `json:"repository_type"`
+	Status              ServerStatus `json:"status"`
+	StatusReason        *string      `json:"status_reason"`
+	ReplacedBy          *string      `json:"replaced_by"`
+	StatusChangedAt     *time.Time   `json:"status_changed_at"`
+	Claims              []byte       `json:"claims"`
+	SourceID            uuid.UUID    `json:"source_id"`
+	Position            int32        `json:"position"`
 }
 
 // Despite the name, this query returns multiple rows. The actual number of
@@ -234,6 +242,10 @@ func (q *Queries) GetServerVersion(ctx context.Context, arg GetServerVersionPara
 			&i.RepositoryID,
 			&i.RepositorySubfolder,
 			&i.RepositoryType,
+			&i.Status,
+			&i.StatusReason,
+			&i.ReplacedBy,
+			&i.StatusChangedAt,
 			&i.Claims,
 			&i.SourceID,
 			&i.Position,
@@ -265,6 +277,10 @@ SELECT src.source_type as registry_type,
        s.repository_id,
        s.repository_subfolder,
        s.repository_type,
+       s.status,
+       s.status_reason,
+       s.replaced_by,
+       s.status_changed_at,
        e.claims,
        0::integer AS position
   FROM mcp_server s
@@ -284,24 +300,28 @@ type GetServerVersionBySourceNameParams struct {
 }
 
 type GetServerVersionBySourceNameRow struct {
-	RegistryType        string     `json:"registry_type"`
-	ID                  uuid.UUID  `json:"id"`
-	Name                string     `json:"name"`
-	Version             string     `json:"version"`
-	IsLatest            bool       `json:"is_latest"`
-	CreatedAt           *time.Time `json:"created_at"`
-	UpdatedAt           *time.Time `json:"updated_at"`
-	Description         *string    `json:"description"`
-	Title               *string    `json:"title"`
-	Website             *string    `json:"website"`
-	UpstreamMeta        []byte     `json:"upstream_meta"`
-	ServerMeta          []byte     `json:"server_meta"`
-	RepositoryUrl       *string    `json:"repository_url"`
-	RepositoryID        *string    `json:"repository_id"`
-	RepositorySubfolder *string    `json:"repository_subfolder"`
-	RepositoryType      *string    `json:"repository_type"`
-	Claims              []byte     `json:"claims"`
-	Position            int32      `json:"position"`
+	RegistryType        string       `json:"registry_type"`
+	ID                  uuid.UUID    `json:"id"`
+	Name                string       `json:"name"`
+	Version             string       `json:"version"`
+	IsLatest            bool         `json:"is_latest"`
+	CreatedAt           *time.Time   `json:"created_at"`
+	UpdatedAt           *time.Time   `json:"updated_at"`
+	Description         *string      `json:"description"`
+	Title               *string      `json:"title"`
+	Website             *string      `json:"website"`
+	UpstreamMeta        []byte       `json:"upstream_meta"`
+	ServerMeta          []byte       `json:"server_meta"`
+	RepositoryUrl       *string      `json:"repository_url"`
+	RepositoryID        *string      `json:"repository_id"`
+	RepositorySubfolder *string      `json:"repository_subfolder"`
+	RepositoryType      *string      `json:"repository_type"`
+	Status              ServerStatus `json:"status"`
+	StatusReason        *string      `json:"status_reason"`
+	ReplacedBy          *string      `json:"replaced_by"`
+	StatusChangedAt     *time.Time   `json:"status_changed_at"`
+	Claims              []byte       `json:"claims"`
+	Position            int32        `json:"position"`
 }
 
 // Source-scoped variant of GetServerVersion used by the publish fetch-back path.
@@ -326,6 +346,10 @@ func (q *Queries) GetServerVersionBySourceName(ctx context.Context, arg GetServe
 		&i.RepositoryID,
 		&i.RepositorySubfolder,
 		&i.RepositoryType,
+		&i.Status,
+		&i.StatusReason,
+		&i.ReplacedBy,
+		&i.StatusChangedAt,
 		&i.Claims,
 		&i.Position,
 	)
@@ -562,6 +586,41 @@ func (q *Queries) InsertServerVersionForSync(ctx context.Context, arg InsertServ
 	return version_id, err
 }
 
+const listServerIcons = `-- name: ListServerIcons :many
+SELECT i.server_id,
+       i.source_uri,
+       i.mime_type,
+       i.theme
+  FROM mcp_server_icon i
+ WHERE i.server_id = ANY($1::UUID[])
+ ORDER BY i.source_uri, i.theme
+`
+
+func (q *Queries) ListServerIcons(ctx context.Context, versionIds []uuid.UUID) ([]McpServerIcon, error) {
+	rows, err := q.db.Query(ctx, listServerIcons, versionIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []McpServerIcon{}
+	for rows.Next() {
+		var i McpServerIcon
+		if err := rows.Scan(
+			&i.ServerID,
+			&i.SourceUri,
+			&i.MimeType,
+			&i.Theme,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listServerPackages = `-- name: ListServerPackages :many
 SELECT p.server_id,
        p.registry_type,
@@ -668,6 +727,69 @@ func (q *Queries) ListServerRemotes(ctx context.Context, versionIds []uuid.UUID)
 	return items, nil
 }
 
+const listServerFieldOverrides = `-- name: ListServerFieldOverrides :many
+SELECT v.id,
+       e.name,
+       v.version,
+       v.description,
+       s.server_meta,
+       e.claims,
+       rs.position
+  FROM mcp_server s
+  JOIN entry_version v ON s.version_id = v.id
+  JOIN registry_entry e ON v.entry_id = e.id
+  JOIN registry_source rs ON rs.source_id = e.source_id
+                          AND rs.registry_id = $1::uuid
+ WHERE e.name = ANY($2::text[])
+ ORDER BY e.name ASC, v.version ASC, rs.position ASC
+`
+
+type ListServerFieldOverridesParams struct {
+	RegistryID uuid.UUID `json:"registry_id"`
+	Names      []string  `json:"names"`
+}
+
+type ListServerFieldOverridesRow struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Version     string    `json:"version"`
+	Description *string   `json:"description"`
+	ServerMeta  []byte    `json:"server_meta"`
+	Claims      []byte    `json:"claims"`
+	Position    int32     `json:"position"`
+}
+
+// Lists the versions of the given servers provided by the sources of a registry, in
+// position order, with the fields a later source can override on the version served
+// from an earlier one under the merge strategies of the registry.
+func (q *Queries) ListServerFieldOverrides(ctx context.Context, arg ListServerFieldOverridesParams) ([]ListServerFieldOverridesRow, error) {
+	rows, err := q.db.Query(ctx, listServerFieldOverrides, arg.RegistryID, arg.Names)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListServerFieldOverridesRow{}
+	for rows.Next() {
+		var i ListServerFieldOverridesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Version,
+			&i.Description,
+			&i.ServerMeta,
+			&i.Claims,
+			&i.Position,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listServers = `-- name: ListServers :many
 SELECT src.source_type as registry_type,
        v.id,
@@ -685,6 +807,10 @@ SELECT src.source_type as registry_type,
        s.repository_id,
        s.repository_subfolder,
        s.repository_type,
+       s.status,
+       s.status_reason,
+       s.replaced_by,
+       s.status_changed_at,
        e.claims,
        -- Sources not linked to the requested registry have no position; default to max int16
        -- so they sort after all explicitly positioned sources (lower position = higher priority).
@@ -697,58 +823,59 @@ SELECT src.source_type as registry_type,
   JOIN registry_source rs ON rs.source_id = e.source_id
                           AND rs.registry_id = $1::uuid
  WHERE ($2::text IS NULL OR e.name = $2::text)
-   AND ($3::text IS NULL OR (
-       LOWER(e.name) LIKE LOWER('%' || $3::text || '%')
-       OR LOWER(v.title) LIKE LOWER('%' || $3::text || '%')
-       OR LOWER(v.description) LIKE LOWER('%' || $3::text || '%')
-   ))
    -- Filter by updated_since if provided
-   AND ($4::timestamp with time zone IS NULL OR v.updated_at > $4::timestamp with time zone)
+   AND ($3::timestamp with time zone IS NULL OR v.updated_at > $3::timestamp with time zone)
    -- Compound cursor comparison: (name, version) > (cursor_name, cursor_version)
    -- This ensures deterministic pagination even when timestamps are identical
    AND (
-       $5::text IS NULL
-       OR (v.name, v.version) > ($5::text, $6::text)
+       $4::text IS NULL
+       OR (v.name, v.version) > ($4::text, $5::text)
    )
    AND (
-       $7::text IS NULL OR
-       v.version = $7::text OR
-       ($7::text = 'latest' AND l.latest_version_id = v.id)
+       $6::text IS NULL OR
+       v.version = $6::text OR
+       ($6::text = 'latest' AND l.latest_version_id = v.id)
    )
+   -- Deprecated and yanked versions are only listed when asked for
+   AND ($7::boolean OR s.status = 'ACTIVE')
  ORDER BY v.name ASC, v.version ASC, rs.position ASC
  LIMIT $8::bigint
 `
 
 type ListServersParams struct {
-	RegistryID    uuid.UUID  `json:"registry_id"`
-	Name          *string    `json:"name"`
-	Search        *string    `json:"search"`
-	UpdatedSince  *time.Time `json:"updated_since"`
-	CursorName    *string    `json:"cursor_name"`
-	CursorVersion *string    `json:"cursor_version"`
-	Version       *string    `json:"version"`
-	Size          int64      `json:"size"`
+	RegistryID      uuid.UUID  `json:"registry_id"`
+	Name            *string    `json:"name"`
+	UpdatedSince    *time.Time `json:"updated_since"`
+	CursorName      *string    `json:"cursor_name"`
+	CursorVersion   *string    `json:"cursor_version"`
+	Version         *string    `json:"version"`
+	IncludeInactive bool       `json:"include_inactive"`
+	Size            int64      `json:"size"`
 }
 
 type ListServersRow struct {
-	RegistryType        string     `json:"registry_type"`
-	ID                  uuid.UUID  `json:"id"`
-	Name                string     `json:"name"`
-	Version             string     `json:"version"`
-	IsLatest            bool       `json:"is_latest"`
-	CreatedAt           *time.Time `json:"created_at"`
-	UpdatedAt           *time.Time `json:"updated_at"`
-	Description         *string    `json:"description"`
-	Title               *string    `json:"title"`
-	Website             *string    `json:"website"`
-	UpstreamMeta        []byte     `json:"upstream_meta"`
-	ServerMeta          []byte     `json:"server_meta"`
-	RepositoryUrl       *string    `json:"repository_url"`
-	RepositoryID        *string    `json:"repository_id"`
-	RepositorySubfolder *string    `json:"repository_subfolder"`
-	RepositoryType      *string    `json:"repository_type"`
-	Claims              []byte     `json:"claims"`
-	Position            int32      `json:"position"`
+	RegistryType        string       `json:"registry_type"`
+	ID                  uuid.UUID    `json:"id"`
+	Name                string       `json:"name"`
+	Version             string       `json:"version"`
+	IsLatest            bool         `json:"is_latest"`
+	CreatedAt           *time.Time   `json:"created_at"`
+	UpdatedAt           *time.Time   `json:"updated_at"`
+	Description         *string      `json:"description"`
+	Title               *string      `json:"title"`
+	Website             *string      `json:"website"`
+	UpstreamMeta        []byte       `json:"upstream_meta"`
+	ServerMeta          []byte       `json:"server_meta"`
+	RepositoryUrl       *string      `json:"repository_url"`
+	RepositoryID        *string      `json:"repository_id"`
+	RepositorySubfolder *string      `json:"repository_subfolder"`
+	RepositoryType      *string      `json:"repository_type"`
+	Status              ServerStatus `json:"status"`
+	StatusReason        *string      `json:"status_reason"`
+	ReplacedBy          *string      `json:"replaced_by"`
+	StatusChangedAt     *time.Time   `json:"status_changed_at"`
+	Claims              []byte       `json:"claims"`
+	Position            int32        `json:"position"`
 }
 
 // Cursor-based pagination using (name, version) compound cursor.
@@ -756,15 +883,16 @@ type ListServersRow struct {
 // When cursor is provided, results start AFTER the specified (name, version) tuple.
 // Returns position from registry_source for source priority ordering.
 // When name is provided, results are filtered to versions of that specific server.
+// Deprecated and yanked versions are skipped unless include_inactive is set.
 func (q *Queries) ListServers(ctx context.Context, arg ListServersParams) ([]ListServersRow, error) {
 	rows, err := q.db.Query(ctx, listServers,
 		arg.RegistryID,
 		arg.Name,
-		arg.Search,
 		arg.UpdatedSince,
 		arg.CursorName,
 		arg.CursorVersion,
 		arg.Version,
+		arg.IncludeInactive,
 		arg.Size,
 	)
 	if err != nil {
@@ -791,6 +919,10 @@ func (q *Queries) ListServers(ctx context.Context, arg ListServersParams) ([]Lis
 			&i.RepositoryID,
 			&i.RepositorySubfolder,
 			&i.RepositoryType,
+			&i.Status,
+			&i.StatusReason,
+			&i.ReplacedBy,
+			&i.StatusChangedAt,
 			&i.Claims,
 			&i.Position,
 		); err != nil {
@@ -804,6 +936,270 @@ func (q *Queries) ListServers(ctx context.Context, arg ListServersParams) ([]Lis
 	return items, nil
 }
 
+const searchServers = `-- name: SearchServers :many
+WITH matches AS (
+    SELECT src.source_type as registry_type,
+           v.id,
+           e.name,
+           v.version,
+           (l.latest_version_id IS NOT NULL)::boolean AS is_latest,
+           v.created_at,
+           v.updated_at,
+           v.description,
+           v.title,
+           s.website,
+           s.upstream_meta,
+           s.server_meta,
+           s.repository_url,
+           s.repository_id,
+           s.repository_subfolder,
+           s.repository_type,
+           s.status,
+           s.status_reason,
+           s.replaced_by,
+           s.status_changed_at,
+           e.claims,
+           COALESCE(rs.position, 32767)::integer AS position,
+           MAX(ts_rank(v.search_vector || s.tools_search_vector,
+                       to_tsquery('simple', $1::text)))
+               OVER (PARTITION BY e.name) AS relevance
+      FROM mcp_server s
+      JOIN entry_version v ON s.version_id = v.id
+      JOIN registry_entry e ON v.entry_id = e.id
+      JOIN source src ON e.source_id = src.id
+      LEFT JOIN latest_entry_version l ON v.id = l.latest_version_id
+      JOIN registry_source rs ON rs.source_id = e.source_id
+                              AND rs.registry_id = $2::uuid
+     WHERE (v.search_vector @@ to_tsquery('simple', $1::text)
+            OR s.tools_search_vector @@ to_tsquery('simple', $1::text))
+       AND ($3::text IS NULL OR e.name = $3::text)
+       AND ($4::timestamp with time zone IS NULL OR v.updated_at > $4::timestamp with time zone)
+       AND (
+           $5::text IS NULL OR
+           v.version = $5::text OR
+           ($5::text = 'latest' AND l.latest_version_id = v.id)
+       )
+       AND ($6::boolean OR s.status = 'ACTIVE')
+), cursor_match AS (
+    SELECT relevance FROM matches WHERE name = $7::text LIMIT 1
+)
+SELECT registry_type,
+       id,
+       name,
+       version,
+       is_latest,
+       created_at,
+       updated_at,
+       description,
+       title,
+       website,
+       upstream_meta,
+       server_meta,
+       repository_url,
+       repository_id,
+       repository_subfolder,
+       repository_type,
+       status,
+       status_reason,
+       replaced_by,
+       status_changed_at,
+       claims,
+       position
+  FROM matches
+ WHERE $7::text IS NULL
+    OR relevance < (SELECT relevance FROM cursor_match)
+    OR (relevance = (SELECT relevance FROM cursor_match)
+        AND (name, version) > ($7::text, $8::text))
+ ORDER BY relevance DESC, name ASC, version ASC, position ASC
+ LIMIT $9::bigint
+`
+
+type SearchServersParams struct {
+	Search          string     `json:"search"`
+	RegistryID      uuid.UUID  `json:"registry_id"`
+	Name            *string    `json:"name"`
+	UpdatedSince    *time.Time `json:"updated_since"`
+	Version         *string    `json:"version"`
+	IncludeInactive bool       `json:"include_inactive"`
+	CursorName      *string    `json:"cursor_name"`
+	CursorVersion   *string    `json:"cursor_version"`
+	Size            int64      `json:"size"`
+}
+
+type SearchServersRow struct {
+	RegistryType        string       `json:"registry_type"`
+	ID                  uuid.UUID    `json:"id"`
+	Name                string       `json:"name"`
+	Version             string       `json:"version"`
+	IsLatest            bool         `json:"is_latest"`
+	CreatedAt           *time.Time   `json:"created_at"`
+	UpdatedAt           *time.Time   `json:"updated_at"`
+	Description         *string      `json:"description"`
+	Title               *string      `json:"title"`
+	Website             *string      `json:"website"`
+	UpstreamMeta        []byte       `json:"upstream_meta"`
+	ServerMeta          []byte       `json:"server_meta"`
+	RepositoryUrl       *string      `json:"repository_url"`
+	RepositoryID        *string      `json:"repository_id"`
+	RepositorySubfolder *string      `json:"repository_subfolder"`
+	RepositoryType      *string      `json:"repository_type"`
+	Status              ServerStatus `json:"status"`
+	StatusReason        *string      `json:"status_reason"`
+	ReplacedBy          *string      `json:"replaced_by"`
+	StatusChangedAt     *time.Time   `json:"status_changed_at"`
+	Claims              []byte       `json:"claims"`
+	Position            int32        `json:"position"`
+}
+
+// Full-text search over the name, title, description and tool names of servers,
+// ordered by relevance. The search parameter is a tsquery in the 'simple' configuration.
+// All versions of a server share the relevance of its best matching row, so they stay
+// adjacent in the same (name, version, position) order as ListServers.
+// When cursor is provided, results start AFTER the specified (name, version) tuple
+// in relevance order.
+func (q *Queries) SearchServers(ctx context.Context, arg SearchServersParams) ([]SearchServersRow, error) {
+	rows, err := q.db.Query(ctx, searchServers,
+		arg.Search,
+		arg.RegistryID,
+		arg.Name,
+		arg.UpdatedSince,
+		arg.Version,
+		arg.IncludeInactive,
+		arg.CursorName,
+		arg.CursorVersion,
+		arg.Size,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchServersRow{}
+	for rows.Next() {
+		var i SearchServersRow
+		if err := rows.Scan(
+			&i.RegistryType,
+			&i.ID,
+			&i.Name,
+			&i.Version,
+			&i.IsLatest,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Description,
+			&i.Title,
+			&i.Website,
+			&i.UpstreamMeta,
+			&i.ServerMeta,
+			&i.RepositoryUrl,
+			&i.RepositoryID,
+			&i.RepositorySubfolder,
+			&i.RepositoryType,
+			&i.Status,
+			&i.StatusReason,
+			&i.ReplacedBy,
+			&i.StatusChangedAt,
+			&i.Claims,
+			&i.Position,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateServerVersionStatus = `-- name: UpdateServerVersionStatus :execrows
+UPDATE mcp_server s
+   SET status = $1,
+       status_reason = $2,
+       replaced_by = $3,
+       status_changed_at = $4
+  FROM entry_version v
+ WHERE s.version_id = v.id
+   AND v.entry_id = $5
+   AND v.version = $6
+`
+
+type UpdateServerVersionStatusParams struct {
+	Status          ServerStatus `json:"status"`
+	StatusReason    *string      `json:"status_reason"`
+	ReplacedBy      *string      `json:"replaced_by"`
+	StatusChangedAt *time.Time   `json:"status_changed_at"`
+	EntryID         uuid.UUID    `json:"entry_id"`
+	Version         string       `json:"version"`
+}
+
+// Sets the lifecycle status of a server version, along with the reason for it
+// and the version replacing it.
+func (q *Queries) UpdateServerVersionStatus(ctx context.Context, arg UpdateServerVersionStatusParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateServerVersionStatus,
+		arg.Status,
+		arg.StatusReason,
+		arg.ReplacedBy,
+		arg.StatusChangedAt,
+		arg.EntryID,
+		arg.Version,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listActiveServerVersions = `-- name: ListActiveServerVersions :many
+SELECT v.id, v.version
+  FROM entry_version v
+  JOIN mcp_server s ON s.version_id = v.id
+ WHERE v.entry_id = $1
+   AND s.status = 'ACTIVE'
+ ORDER BY v.version ASC
+`
+
+type ListActiveServerVersionsRow struct {
+	ID      uuid.UUID `json:"id"`
+	Version string    `json:"version"`
+}
+
+// Lists the versions of a server that may be pointed at as its latest version.
+func (q *Queries) ListActiveServerVersions(ctx context.Context, entryID uuid.UUID) ([]ListActiveServerVersionsRow, error) {
+	rows, err := q.db.Query(ctx, listActiveServerVersions, entryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListActiveServerVersionsRow{}
+	for rows.Next() {
+		var i ListActiveServerVersionsRow
+		if err := rows.Scan(&i.ID, &i.Version); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteLatestServerVersion = `-- name: DeleteLatestServerVersion :exec
+DELETE FROM latest_entry_version
+ WHERE source_id = $1
+   AND name = $2
+`
+
+type DeleteLatestServerVersionParams struct {
+	SourceID uuid.UUID `json:"source_id"`
+	Name     string    `json:"name"`
+}
+
+// Removes the latest version pointer of a server that has no active version left.
+func (q *Queries) DeleteLatestServerVersion(ctx context.Context, arg DeleteLatestServerVersionParams) error {
+	_, err := q.db.Exec(ctx, deleteLatestServerVersion, arg.SourceID, arg.Name)
+	return err
+}
+
 const upsertLatestServerVersion = `-- name: UpsertLatestServerVersion :one
 INSERT INTO latest_entry_version (
     source_id,
@@ -976,31 +976,66 @@ func TestListSkills(t *testing.T) {
 			},
 		},
 		{
-			name: "list skills with search",
+			name: "search skills ordered by relevance",
 			//nolint:thelper // We want to see these lines in the test output
 			setupFunc: func(t *testing.T, queries *Queries, regID uuid.UUID) {
-				entryID1 := createSkillEntry(t, queries, regID, "code-review", testSkillVersion,
-					ptr.String("Automated code review tool"), ptr.String("Code Review"))
+				entryID1 := createSkillEntry(t, queries, regID, "style-checker", testSkillVersion,
+					ptr.String("Checks the style of code"), ptr.String("Style Checker"))
 				insertSkill(t, queries, entryID1, "test-namespace")
 
-				entryID2 := createSkillEntry(t, queries, regID, "test-runner", testSkillVersion,
-					ptr.String("Run unit tests"), ptr.String("Test Runner"))
+				entryID2 := createSkillEntry(t, queries, regID, "code-review", testSkillVersion,
+					ptr.String("Automated review tool"), ptr.String("Code Review"))
 				insertSkill(t, queries, entryID2, "test-namespace")
+
+				entryID3 := createSkillEntry(t, queries, regID, "test-runner", testSkillVersion,
+					ptr.String("Run unit tests"), ptr.String("Test Runner"))
+				insertSkill(t, queries, entryID3, "test-namespace")
 			},
 			//nolint:thelper // We want to see these lines in the test output
 			scenarioFunc: func(t *testing.T, queries *Queries, _ uuid.UUID) {
-				search := "code"
-				skills, err := queries.ListSkills(
+				registryID := getRegistryID(t, queries, "test-registry")
+				skills, err := queries.SearchSkills(
 					context.Background(),
-					ListSkillsParams{
-						RegistryID: getRegistryID(t, queries, "test-registry"),
-						Search:     &search,
+					SearchSkillsParams{
+						RegistryID: registryID,
+						Search:     "cod:*",
 						Size:       10,
 					},
 				)
 				require.NoError(t, err)
-				require.Len(t, skills, 1)
+				require.Len(t, skills, 2)
+				// A match in the name ranks above a match in the description
 				assert.Equal(t, "code-review", skills[0].Name)
+				assert.Equal(t, "style-checker", skills[1].Name)
+
+				// The cursor continues in relevance order
+				cursorName, cursorVersion := skills[0].Name, skills[0].Version
+				next, err := queries.SearchSkills(
+					context.Background(),
+					SearchSkillsParams{
+						RegistryID:    registryID,
+						Search:        "cod:*",
+						CursorName:    &cursorName,
+						CursorVersion: &cursorVersion,
+						Size:          10,
+					},
+				)
+				require.NoError(t, err)
+				require.Len(t, next, 1)
+				assert.Equal(t, "style-checker", next[0].Name)
+
+				// Every word must match
+				skills, err = queries.SearchSkills(
+					context.Background(),
+					SearchSkillsParams{
+						RegistryID: registryID,
+						Search:     "unit:* & test:*",
+						Size:       10,
+					},
+				)
+				require.NoError(t, err)
+				require.Len(t, skills, 1)
+				assert.Equal(t, "test-runner", skills[0].Name)
 			},
 		},
 		{
@@ -184,7 +184,7 @@ func (q *Queries) GetAPISourcesByNames(ctx context.Context, names []string) ([]G
 }
 
 const getManagedSources = `-- name: GetManagedSources :many
-SELECT id, name, source_type, creation_type, claims, created_at, updated_at
+SELECT id, name, source_type, creation_type, source_config, claims, created_at, updated_at
 FROM source WHERE source_type = 'managed'
 `
 
@@ -193,6 +193,7 @@ type GetManagedSourcesRow struct {
 	Name         string       `json:"name"`
 	SourceType   string       `json:"source_type"`
 	CreationType CreationType `json:"creation_type"`
+	SourceConfig []byte       `json:"source_config"`
 	Claims       []byte       `json:"claims"`
 	CreatedAt    *time.Time   `json:"created_at"`
 	UpdatedAt    *time.Time   `json:"updated_at"`
@@ -212,6 +213,7 @@ func (q *Queries) GetManagedSources(ctx context.Context) ([]GetManagedSourcesRow
 			&i.Name,
 			&i.SourceType,
 			&i.CreationType,
+			&i.SourceConfig,
 			&i.Claims,
 			&i.CreatedAt,
 			&i.UpdatedAt,
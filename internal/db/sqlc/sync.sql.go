@@ -37,6 +37,75 @@ func (q *Queries) BulkInitializeSourceSyncs(ctx context.Context, arg BulkInitial
 	return err
 }
 
+const clearSourceSyncCommits = `-- name: ClearSourceSyncCommits :exec
+UPDATE registry_sync
+SET last_sync_commit = NULL
+WHERE source_id = ANY($1::uuid[])
+`
+
+// Forget the synced commit of sources whose configuration may have changed, so
+// that their next sync fetches the data instead of only checking the remote commit
+func (q *Queries) ClearSourceSyncCommits(ctx context.Context, sourceIds []uuid.UUID) error {
+	_, err := q.db.Exec(ctx, clearSourceSyncCommits, sourceIds)
+	return err
+}
+
+const deleteSyncHistoryBefore = `-- name: DeleteSyncHistoryBefore :exec
+DELETE FROM sync_history
+WHERE source_id = (SELECT id FROM source WHERE name = $1)
+  AND started_at < $2
+`
+
+type DeleteSyncHistoryBeforeParams struct {
+	Name   string    `json:"name"`
+	Before time.Time `json:"before"`
+}
+
+func (q *Queries) DeleteSyncHistoryBefore(ctx context.Context, arg DeleteSyncHistoryBeforeParams) error {
+	_, err := q.db.Exec(ctx, deleteSyncHistoryBefore, arg.Name, arg.Before)
+	return err
+}
+
+const finishSyncRequests = `-- name: FinishSyncRequests :exec
+UPDATE sync_request
+SET status = $1,
+    error_msg = $2,
+    ended_at = $3
+WHERE source_id = (SELECT id FROM source WHERE name = $4)
+  AND status = 'IN_PROGRESS'
+`
+
+type FinishSyncRequestsParams struct {
+	Status   SyncRequestStatus `json:"status"`
+	ErrorMsg *string           `json:"error_msg"`
+	EndedAt  *time.Time        `json:"ended_at"`
+	Name     string            `json:"name"`
+}
+
+func (q *Queries) FinishSyncRequests(ctx context.Context, arg FinishSyncRequestsParams) error {
+	_, err := q.db.Exec(ctx, finishSyncRequests,
+		arg.Status,
+		arg.ErrorMsg,
+		arg.EndedAt,
+		arg.Name,
+	)
+	return err
+}
+
+const getSourceEntryDigests = `-- name: GetSourceEntryDigests :one
+SELECT d.digests
+FROM sync_entry_digests d
+INNER JOIN source s ON d.source_id = s.id
+WHERE s.name = $1
+`
+
+func (q *Queries) GetSourceEntryDigests(ctx context.Context, name string) ([]byte, error) {
+	row := q.db.QueryRow(ctx, getSourceEntryDigests, name)
+	var digests []byte
+	err := row.Scan(&digests)
+	return digests, err
+}
+
 const getSourceSync = `-- name: GetSourceSync :one
 SELECT id,
        source_id,
@@ -49,7 +118,10 @@ SELECT id,
        last_applied_filter_hash,
        server_count,
        skill_count,
-       plugin_count
+       plugin_count,
+       last_success_at,
+       last_full_sync_at,
+       last_sync_commit
 FROM registry_sync
 WHERE id = $1
 `
@@ -70,6 +142,9 @@ func (q *Queries) GetSourceSync(ctx context.Context, id uuid.UUID) (RegistrySync
 		&i.ServerCount,
 		&i.SkillCount,
 		&i.PluginCount,
+		&i.LastSuccessAt,
+		&i.LastFullSyncAt,
+		&i.LastSyncCommit,
 	)
 	return i, err
 }
@@ -86,7 +161,10 @@ SELECT rs.id,
        rs.last_applied_filter_hash,
        rs.server_count,
        rs.skill_count,
-       rs.plugin_count
+       rs.plugin_count,
+       rs.last_success_at,
+       rs.last_full_sync_at,
+       rs.last_sync_commit
 FROM registry_sync rs
 INNER JOIN source s ON rs.source_id = s.id
 WHERE s.name = $1
@@ -108,6 +186,43 @@ func (q *Queries) GetSourceSyncByName(ctx context.Context, name string) (Registr
 		&i.ServerCount,
 		&i.SkillCount,
 		&i.PluginCount,
+		&i.LastSuccessAt,
+		&i.LastFullSyncAt,
+		&i.LastSyncCommit,
+	)
+	return i, err
+}
+
+const getSyncRequest = `-- name: GetSyncRequest :one
+SELECT sr.id,
+       sr.source_id,
+       sr.status,
+       sr.error_msg,
+       sr.requested_at,
+       sr.started_at,
+       sr.ended_at
+FROM sync_request sr
+INNER JOIN source s ON sr.source_id = s.id
+WHERE sr.id = $1
+  AND s.name = $2
+`
+
+type GetSyncRequestParams struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+func (q *Queries) GetSyncRequest(ctx context.Context, arg GetSyncRequestParams) (SyncRequest, error) {
+	row := q.db.QueryRow(ctx, getSyncRequest, arg.ID, arg.Name)
+	var i SyncRequest
+	err := row.Scan(
+		&i.ID,
+		&i.SourceID,
+		&i.Status,
+		&i.ErrorMsg,
+		&i.RequestedAt,
+		&i.StartedAt,
+		&i.EndedAt,
 	)
 	return i, err
 }
@@ -169,6 +284,137 @@ func (q *Queries) InsertSourceSync(ctx context.Context, arg InsertSourceSyncPara
 	return id, err
 }
 
+const insertSyncHistory = `-- name: InsertSyncHistory :exec
+INSERT INTO sync_history (
+    source_id,
+    sync_status,
+    reason,
+    condition_reason,
+    error_msg,
+    sync_hash,
+    incremental,
+    server_count,
+    skill_count,
+    plugin_count,
+    servers_added,
+    servers_updated,
+    servers_removed,
+    skills_added,
+    skills_updated,
+    skills_removed,
+    plugins_added,
+    plugins_updated,
+    plugins_removed,
+    started_at,
+    ended_at
+) VALUES (
+    (SELECT id FROM source WHERE name = $1),
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9,
+    $10,
+    $11,
+    $12,
+    $13,
+    $14,
+    $15,
+    $16,
+    $17,
+    $18,
+    $19,
+    $20,
+    $21
+)
+`
+
+type InsertSyncHistoryParams struct {
+	Name            string     `json:"name"`
+	SyncStatus      SyncStatus `json:"sync_status"`
+	Reason          string     `json:"reason"`
+	ConditionReason *string    `json:"condition_reason"`
+	ErrorMsg        *string    `json:"error_msg"`
+	SyncHash        *string    `json:"sync_hash"`
+	Incremental     bool       `json:"incremental"`
+	ServerCount     int64      `json:"server_count"`
+	SkillCount      int64      `json:"skill_count"`
+	PluginCount     int64      `json:"plugin_count"`
+	ServersAdded    int64      `json:"servers_added"`
+	ServersUpdated  int64      `json:"servers_updated"`
+	ServersRemoved  int64      `json:"servers_removed"`
+	SkillsAdded     int64      `json:"skills_added"`
+	SkillsUpdated   int64      `json:"skills_updated"`
+	SkillsRemoved   int64      `json:"skills_removed"`
+	PluginsAdded    int64      `json:"plugins_added"`
+	PluginsUpdated  int64      `json:"plugins_updated"`
+	PluginsRemoved  int64      `json:"plugins_removed"`
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         time.Time  `json:"ended_at"`
+}
+
+func (q *Queries) InsertSyncHistory(ctx context.Context, arg InsertSyncHistoryParams) error {
+	_, err := q.db.Exec(ctx, insertSyncHistory,
+		arg.Name,
+		arg.SyncStatus,
+		arg.Reason,
+		arg.ConditionReason,
+		arg.ErrorMsg,
+		arg.SyncHash,
+		arg.Incremental,
+		arg.ServerCount,
+		arg.SkillCount,
+		arg.PluginCount,
+		arg.ServersAdded,
+		arg.ServersUpdated,
+		arg.ServersRemoved,
+		arg.SkillsAdded,
+		arg.SkillsUpdated,
+		arg.SkillsRemoved,
+		arg.PluginsAdded,
+		arg.PluginsUpdated,
+		arg.PluginsRemoved,
+		arg.StartedAt,
+		arg.EndedAt,
+	)
+	return err
+}
+
+const insertSyncRequest = `-- name: InsertSyncRequest :one
+INSERT INTO sync_request (source_id)
+SELECT s.id FROM source s
+WHERE s.name = $1 AND s.syncable = true
+ON CONFLICT (source_id) WHERE status = 'PENDING'
+DO UPDATE SET requested_at = sync_request.requested_at
+RETURNING id,
+          source_id,
+          status,
+          error_msg,
+          requested_at,
+          started_at,
+          ended_at
+`
+
+// Queue a manual sync for a syncable source. A PENDING request for the same
+// source is reused so repeated calls return the same request.
+func (q *Queries) InsertSyncRequest(ctx context.Context, name string) (SyncRequest, error) {
+	row := q.db.QueryRow(ctx, insertSyncRequest, name)
+	var i SyncRequest
+	err := row.Scan(
+		&i.ID,
+		&i.SourceID,
+		&i.Status,
+		&i.ErrorMsg,
+		&i.RequestedAt,
+		&i.StartedAt,
+		&i.EndedAt,
+	)
+	return i, err
+}
+
 const listSourceSyncs = `-- name: ListSourceSyncs :many
 SELECT s.name,
        rs.id,
@@ -183,6 +429,9 @@ SELECT s.name,
        rs.server_count,
        rs.skill_count,
        rs.plugin_count,
+       rs.last_success_at,
+       rs.last_full_sync_at,
+       rs.last_sync_commit,
        s.sync_schedule::interval AS sync_schedule
 FROM registry_sync rs
 INNER JOIN source s ON rs.source_id = s.id
@@ -203,6 +452,9 @@ type ListSourceSyncsRow struct {
 	ServerCount           int64            `json:"server_count"`
 	SkillCount            int64            `json:"skill_count"`
 	PluginCount           int64            `json:"plugin_count"`
+	LastSuccessAt         *time.Time       `json:"last_success_at"`
+	LastFullSyncAt        *time.Time       `json:"last_full_sync_at"`
+	LastSyncCommit        *string          `json:"last_sync_commit"`
 	SyncSchedule          pgtypes.Interval `json:"sync_schedule"`
 }
 
@@ -229,6 +481,9 @@ func (q *Queries) ListSourceSyncs(ctx context.Context) ([]ListSourceSyncsRow, er
 			&i.ServerCount,
 			&i.SkillCount,
 			&i.PluginCount,
+			&i.LastSuccessAt,
+			&i.LastFullSyncAt,
+			&i.LastSyncCommit,
 			&i.SyncSchedule,
 		); err != nil {
 			return nil, err
@@ -255,13 +510,32 @@ SELECT s.name,
        rs.server_count,
        rs.skill_count,
        rs.plugin_count,
-       s.sync_schedule::interval AS sync_schedule
+       rs.last_success_at,
+       rs.last_full_sync_at,
+       rs.last_sync_commit,
+       s.sync_schedule::interval AS sync_schedule,
+       EXISTS (
+           SELECT 1 FROM sync_request sr
+           WHERE sr.source_id = s.id AND sr.status = 'PENDING'
+       ) AS manual_sync_requested
 FROM registry_sync rs
 INNER JOIN source s ON rs.source_id = s.id
 WHERE s.syncable = true
   AND (rs.ended_at IS NULL
-       OR rs.ended_at + s.sync_schedule::interval <= now())
-ORDER BY rs.ended_at ASC NULLS FIRST, s.name ASC
+       OR rs.ended_at + s.sync_schedule::interval <= now()
+       -- Manual sync requests bypass the sync schedule
+       OR EXISTS (
+           SELECT 1 FROM sync_request sr
+           WHERE sr.source_id = s.id AND sr.status = 'PENDING'
+       ))
+  -- Sources the caller passed over earlier in its transaction
+  AND s.name <> ALL(COALESCE($1::text[], '{}'))
+-- Sources already being synced by another worker are only considered once
+-- nothing else is due, so that they do not hold up the queue
+ORDER BY manual_sync_requested DESC,
+         rs.sync_status = 'IN_PROGRESS' ASC,
+         rs.ended_at ASC NULLS FIRST,
+         s.name ASC
 FOR UPDATE OF rs SKIP LOCKED
 LIMIT 1
 `
@@ -280,11 +554,15 @@ type ListSourceSyncsByLastUpdateRow struct {
 	ServerCount           int64            `json:"server_count"`
 	SkillCount            int64            `json:"skill_count"`
 	PluginCount           int64            `json:"plugin_count"`
+	LastSuccessAt         *time.Time       `json:"last_success_at"`
+	LastFullSyncAt        *time.Time       `json:"last_full_sync_at"`
+	LastSyncCommit        *string          `json:"last_sync_commit"`
 	SyncSchedule          pgtypes.Interval `json:"sync_schedule"`
+	ManualSyncRequested   bool             `json:"manual_sync_requested"`
 }
 
-func (q *Queries) ListSourceSyncsByLastUpdate(ctx context.Context) ([]ListSourceSyncsByLastUpdateRow, error) {
-	rows, err := q.db.Query(ctx, listSourceSyncsByLastUpdate)
+func (q *Queries) ListSourceSyncsByLastUpdate(ctx context.Context, skippedNames []string) ([]ListSourceSyncsByLastUpdateRow, error) {
+	rows, err := q.db.Query(ctx, listSourceSyncsByLastUpdate, skippedNames)
 	if err != nil {
 		return nil, err
 	}
@@ -306,7 +584,11 @@ func (q *Queries) ListSourceSyncsByLastUpdate(ctx context.Context) ([]ListSource
 			&i.ServerCount,
 			&i.SkillCount,
 			&i.PluginCount,
+			&i.LastSuccessAt,
+			&i.LastFullSyncAt,
+			&i.LastSyncCommit,
 			&i.SyncSchedule,
+			&i.ManualSyncRequested,
 		); err != nil {
 			return nil, err
 		}
@@ -318,6 +600,115 @@ func (q *Queries) ListSourceSyncsByLastUpdate(ctx context.Context) ([]ListSource
 	return items, nil
 }
 
+const listSyncHistory = `-- name: ListSyncHistory :many
+SELECT sh.id,
+       sh.source_id,
+       sh.sync_status,
+       sh.reason,
+       sh.condition_reason,
+       sh.error_msg,
+       sh.sync_hash,
+       sh.incremental,
+       sh.server_count,
+       sh.skill_count,
+       sh.plugin_count,
+       sh.servers_added,
+       sh.servers_updated,
+       sh.servers_removed,
+       sh.skills_added,
+       sh.skills_updated,
+       sh.skills_removed,
+       sh.plugins_added,
+       sh.plugins_updated,
+       sh.plugins_removed,
+       sh.started_at,
+       sh.ended_at
+FROM sync_history sh
+INNER JOIN source s ON sh.source_id = s.id
+WHERE s.name = $1
+  AND (
+      $2::timestamp with time zone IS NULL
+      OR (sh.started_at, sh.id) < ($2::timestamp with time zone, $3::uuid)
+  )
+ORDER BY sh.started_at DESC, sh.id DESC
+LIMIT $4::bigint
+`
+
+type ListSyncHistoryParams struct {
+	Name            string     `json:"name"`
+	CursorStartedAt *time.Time `json:"cursor_started_at"`
+	CursorID        *uuid.UUID `json:"cursor_id"`
+	Size            int64      `json:"size"`
+}
+
+// Cursor-based pagination using (started_at, id) compound cursor, newest first.
+// When cursor is provided, results start AFTER the specified tuple.
+func (q *Queries) ListSyncHistory(ctx context.Context, arg ListSyncHistoryParams) ([]SyncHistory, error) {
+	rows, err := q.db.Query(ctx, listSyncHistory,
+		arg.Name,
+		arg.CursorStartedAt,
+		arg.CursorID,
+		arg.Size,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SyncHistory{}
+	for rows.Next() {
+		var i SyncHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.SourceID,
+			&i.SyncStatus,
+			&i.Reason,
+			&i.ConditionReason,
+			&i.ErrorMsg,
+			&i.SyncHash,
+			&i.Incremental,
+			&i.ServerCount,
+			&i.SkillCount,
+			&i.PluginCount,
+			&i.ServersAdded,
+			&i.ServersUpdated,
+			&i.ServersRemoved,
+			&i.SkillsAdded,
+			&i.SkillsUpdated,
+			&i.SkillsRemoved,
+			&i.PluginsAdded,
+			&i.PluginsUpdated,
+			&i.PluginsRemoved,
+			&i.StartedAt,
+			&i.EndedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const startSyncRequests = `-- name: StartSyncRequests :exec
+UPDATE sync_request
+SET status = 'IN_PROGRESS',
+    started_at = $1
+WHERE source_id = (SELECT id FROM source WHERE name = $2)
+  AND status = 'PENDING'
+`
+
+type StartSyncRequestsParams struct {
+	StartedAt *time.Time `json:"started_at"`
+	Name      string     `json:"name"`
+}
+
+func (q *Queries) StartSyncRequests(ctx context.Context, arg StartSyncRequestsParams) error {
+	_, err := q.db.Exec(ctx, startSyncRequests, arg.StartedAt, arg.Name)
+	return err
+}
+
 const updateSourceSync = `-- name: UpdateSourceSync :exec
 UPDATE registry_sync SET
     sync_status = $1,
@@ -361,6 +752,32 @@ func (q *Queries) UpdateSourceSyncStatusByName(ctx context.Context, arg UpdateSo
 	return err
 }
 
+const upsertSourceEntryDigests = `-- name: UpsertSourceEntryDigests :exec
+INSERT INTO sync_entry_digests (
+    source_id,
+    digests,
+    updated_at
+) VALUES (
+    (SELECT id FROM source WHERE name = $1),
+    $2,
+    $3
+)
+ON CONFLICT (source_id) DO UPDATE SET
+    digests = EXCLUDED.digests,
+    updated_at = EXCLUDED.updated_at
+`
+
+type UpsertSourceEntryDigestsParams struct {
+	Name      string    `json:"name"`
+	Digests   []byte    `json:"digests"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (q *Queries) UpsertSourceEntryDigests(ctx context.Context, arg UpsertSourceEntryDigestsParams) error {
+	_, err := q.db.Exec(ctx, upsertSourceEntryDigests, arg.Name, arg.Digests, arg.UpdatedAt)
+	return err
+}
+
 const upsertSourceSyncByName = `-- name: UpsertSourceSyncByName :exec
 INSERT INTO registry_sync (
     source_id,
@@ -373,7 +790,10 @@ INSERT INTO registry_sync (
     last_applied_filter_hash,
     server_count,
     skill_count,
-    plugin_count
+    plugin_count,
+    last_success_at,
+    last_full_sync_at,
+    last_sync_commit
 ) VALUES (
     (SELECT id FROM source WHERE name = $1),
     $2,
@@ -385,7 +805,10 @@ INSERT INTO registry_sync (
     $8,
     $9,
     $10,
-    $11
+    $11,
+    $12,
+    $13,
+    $14
 )
 ON CONFLICT (source_id) DO UPDATE SET
     sync_status = EXCLUDED.sync_status,
@@ -393,11 +816,16 @@ ON CONFLICT (source_id) DO UPDATE SET
     started_at = EXCLUDED.started_at,
     ended_at = EXCLUDED.ended_at,
     attempt_count = EXCLUDED.attempt_count,
-    last_sync_hash = EXCLUDED.last_sync_hash,
     last_applied_filter_hash = EXCLUDED.last_applied_filter_hash,
     server_count = EXCLUDED.server_count,
     skill_count = EXCLUDED.skill_count,
-    plugin_count = EXCLUDED.plugin_count
+    plugin_count = EXCLUDED.plugin_count,
+    last_sync_commit = EXCLUDED.last_sync_commit,
+    -- Failed attempts don't carry these values; keep the previous ones. The hash
+    -- identifies the stored data, such as the last imported bundle.
+    last_sync_hash = COALESCE(EXCLUDED.last_sync_hash, registry_sync.last_sync_hash),
+    last_success_at = COALESCE(EXCLUDED.last_success_at, registry_sync.last_success_at),
+    last_full_sync_at = COALESCE(EXCLUDED.last_full_sync_at, registry_sync.last_full_sync_at)
 `
 
 type UpsertSourceSyncByNameParams struct {
@@ -412,6 +840,9 @@ type UpsertSourceSyncByNameParams struct {
 	ServerCount           int64      `json:"server_count"`
 	SkillCount            int64      `json:"skill_count"`
 	PluginCount           int64      `json:"plugin_count"`
+	LastSuccessAt         *time.Time `json:"last_success_at"`
+	LastFullSyncAt        *time.Time `json:"last_full_sync_at"`
+	LastSyncCommit        *string    `json:"last_sync_commit"`
 }
 
 func (q *Queries) UpsertSourceSyncByName(ctx context.Context, arg UpsertSourceSyncByNameParams) error {
@@ -427,6 +858,9 @@ func (q *Queries) UpsertSourceSyncByName(ctx context.Context, arg UpsertSourceSy
 		arg.ServerCount,
 		arg.SkillCount,
 		arg.PluginCount,
+		arg.LastSuccessAt,
+		arg.LastFullSyncAt,
+		arg.LastSyncCommit,
 	)
 	return err
 }
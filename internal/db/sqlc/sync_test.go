@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/stacklok/toolhive-registry-server/database"
+	"github.com/stacklok/toolhive-registry-server/internal/db/pgtypes"
 )
 
 func TestGetSourceSync(t *testing.T) {
@@ -538,3 +539,384 @@ func TestUpdateSourceSync(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncRequests(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		setupFunc    func(t *testing.T, queries *Queries)
+		scenarioFunc func(t *testing.T, queries *Queries)
+	}{
+		{
+			name: "pending request is reused",
+			//nolint:thelper // We want to see these lines in the test output
+			setupFunc: func(t *testing.T, queries *Queries) {
+				createSyncRequestTestSource(t, queries, "git-source", true)
+			},
+			//nolint:thelper // We want to see these lines in the test output
+			scenarioFunc: func(t *testing.T, queries *Queries) {
+				first, err := queries.InsertSyncRequest(context.Background(), "git-source")
+				require.NoError(t, err)
+				require.Equal(t, SyncRequestStatusPENDING, first.Status)
+
+				second, err := queries.InsertSyncRequest(context.Background(), "git-source")
+				require.NoError(t, err)
+				require.Equal(t, first.ID, second.ID)
+			},
+		},
+		{
+			name: "non-syncable source",
+			//nolint:thelper // We want to see these lines in the test output
+			setupFunc: func(t *testing.T, queries *Queries) {
+				createSyncRequestTestSource(t, queries, "managed-source", false)
+			},
+			//nolint:thelper // We want to see these lines in the test output
+			scenarioFunc: func(t *testing.T, queries *Queries) {
+				_, err := queries.InsertSyncRequest(context.Background(), "managed-source")
+				require.ErrorIs(t, err, sql.ErrNoRows)
+			},
+		},
+		{
+			name: "pending request bypasses the sync schedule",
+			//nolint:thelper // We want to see these lines in the test output
+			setupFunc: func(t *testing.T, queries *Queries) {
+				createSyncRequestTestSource(t, queries, "git-source", true)
+				endedAt := time.Now().UTC()
+				err := queries.UpsertSourceSyncByName(context.Background(), UpsertSourceSyncByNameParams{
+					Name:       "git-source",
+					SyncStatus: SyncStatusCOMPLETED,
+					EndedAt:    &endedAt,
+				})
+				require.NoError(t, err)
+			},
+			//nolint:thelper // We want to see these lines in the test output
+			scenarioFunc: func(t *testing.T, queries *Queries) {
+				rows, err := queries.ListSourceSyncsByLastUpdate(context.Background(), nil)
+				require.NoError(t, err)
+				require.Empty(t, rows)
+
+				_, err = queries.InsertSyncRequest(context.Background(), "git-source")
+				require.NoError(t, err)
+
+				rows, err = queries.ListSourceSyncsByLastUpdate(context.Background(), nil)
+				require.NoError(t, err)
+				require.Len(t, rows, 1)
+				require.Equal(t, "git-source", rows[0].Name)
+				require.True(t, rows[0].ManualSyncRequested)
+			},
+		},
+		{
+			name: "start and finish requests",
+			//nolint:thelper // We want to see these lines in the test output
+			setupFunc: func(t *testing.T, queries *Queries) {
+				createSyncRequestTestSource(t, queries, "git-source", true)
+			},
+			//nolint:thelper // We want to see these lines in the test output
+			scenarioFunc: func(t *testing.T, queries *Queries) {
+				req, err := queries.InsertSyncRequest(context.Background(), "git-source")
+				require.NoError(t, err)
+
+				now := time.Now().UTC()
+				err = queries.StartSyncRequests(context.Background(), StartSyncRequestsParams{
+					Name:      "git-source",
+					StartedAt: &now,
+				})
+				require.NoError(t, err)
+
+				got, err := queries.GetSyncRequest(context.Background(), GetSyncRequestParams{
+					ID:   req.ID,
+					Name: "git-source",
+				})
+				require.NoError(t, err)
+				require.Equal(t, SyncRequestStatusINPROGRESS, got.Status)
+				require.NotNil(t, got.StartedAt)
+
+				err = queries.FinishSyncRequests(context.Background(), FinishSyncRequestsParams{
+					Name:    "git-source",
+					Status:  SyncRequestStatusCOMPLETED,
+					EndedAt: &now,
+				})
+				require.NoError(t, err)
+
+				got, err = queries.GetSyncRequest(context.Background(), GetSyncRequestParams{
+					ID:   req.ID,
+					Name: "git-source",
+				})
+				require.NoError(t, err)
+				require.Equal(t, SyncRequestStatusCOMPLETED, got.Status)
+				require.NotNil(t, got.EndedAt)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, cleanupFunc := database.SetupTestDB(t)
+			t.Cleanup(cleanupFunc)
+			queries := New(db)
+			require.NotNil(t, queries)
+
+			tc.setupFunc(t, queries)
+			tc.scenarioFunc(t, queries)
+		})
+	}
+}
+
+func TestListSourceSyncsByLastUpdate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		setupFunc    func(t *testing.T, queries *Queries)
+		scenarioFunc func(t *testing.T, queries *Queries)
+	}{
+		{
+			name: "in-progress source is listed after other due sources",
+			//nolint:thelper // We want to see these lines in the test output
+			setupFunc: func(t *testing.T, queries *Queries) {
+				createSyncRequestTestSource(t, queries, "alpha", true)
+				createSyncRequestTestSource(t, queries, "beta", true)
+
+				startedAt := time.Now().UTC()
+				err := queries.UpsertSourceSyncByName(context.Background(), UpsertSourceSyncByNameParams{
+					Name:       "alpha",
+					SyncStatus: SyncStatusINPROGRESS,
+					StartedAt:  &startedAt,
+				})
+				require.NoError(t, err)
+				err = queries.UpsertSourceSyncByName(context.Background(), UpsertSourceSyncByNameParams{
+					Name:       "beta",
+					SyncStatus: SyncStatusFAILED,
+				})
+				require.NoError(t, err)
+			},
+			//nolint:thelper // We want to see these lines in the test output
+			scenarioFunc: func(t *testing.T, queries *Queries) {
+				rows, err := queries.ListSourceSyncsByLastUpdate(context.Background(), nil)
+				require.NoError(t, err)
+				require.Len(t, rows, 1)
+				require.Equal(t, "beta", rows[0].Name)
+			},
+		},
+		{
+			name: "in-progress source is listed when nothing else is due",
+			//nolint:thelper // We want to see these lines in the test output
+			setupFunc: func(t *testing.T, queries *Queries) {
+				createSyncRequestTestSource(t, queries, "alpha", true)
+
+				startedAt := time.Now().UTC()
+				err := queries.UpsertSourceSyncByName(context.Background(), UpsertSourceSyncByNameParams{
+					Name:       "alpha",
+					SyncStatus: SyncStatusINPROGRESS,
+					StartedAt:  &startedAt,
+				})
+				require.NoError(t, err)
+			},
+			//nolint:thelper // We want to see these lines in the test output
+			scenarioFunc: func(t *testing.T, queries *Queries) {
+				rows, err := queries.ListSourceSyncsByLastUpdate(context.Background(), nil)
+				require.NoError(t, err)
+				require.Len(t, rows, 1)
+				require.Equal(t, "alpha", rows[0].Name)
+				require.Equal(t, SyncStatusINPROGRESS, rows[0].SyncStatus)
+			},
+		},
+		{
+			name: "skipped sources are passed over",
+			//nolint:thelper // We want to see these lines in the test output
+			setupFunc: func(t *testing.T, queries *Queries) {
+				for _, name := range []string{"alpha", "beta"} {
+					createSyncRequestTestSource(t, queries, name, true)
+					err := queries.UpsertSourceSyncByName(context.Background(), UpsertSourceSyncByNameParams{
+						Name:       name,
+						SyncStatus: SyncStatusFAILED,
+					})
+					require.NoError(t, err)
+				}
+			},
+			//nolint:thelper // We want to see these lines in the test output
+			scenarioFunc: func(t *testing.T, queries *Queries) {
+				rows, err := queries.ListSourceSyncsByLastUpdate(context.Background(), nil)
+				require.NoError(t, err)
+				require.Len(t, rows, 1)
+				require.Equal(t, "alpha", rows[0].Name)
+
+				rows, err = queries.ListSourceSyncsByLastUpdate(context.Background(), []string{"alpha"})
+				require.NoError(t, err)
+				require.Len(t, rows, 1)
+				require.Equal(t, "beta", rows[0].Name)
+
+				rows, err = queries.ListSourceSyncsByLastUpdate(context.Background(), []string{"alpha", "beta"})
+				require.NoError(t, err)
+				require.Empty(t, rows)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, cleanupFunc := database.SetupTestDB(t)
+			t.Cleanup(cleanupFunc)
+			queries := New(db)
+			require.NotNil(t, queries)
+
+			tc.setupFunc(t, queries)
+			tc.scenarioFunc(t, queries)
+		})
+	}
+}
+
+func createSyncRequestTestSource(t *testing.T, queries *Queries, name string, syncable bool) {
+	t.Helper()
+
+	sourceType := "git"
+	if !syncable {
+		sourceType = "managed"
+	}
+	_, err := queries.UpsertSource(
+		context.Background(),
+		UpsertSourceParams{
+			CreationType: CreationTypeCONFIG,
+			Name:         name,
+			SourceType:   sourceType,
+			SyncSchedule: pgtypes.NewInterval(time.Hour),
+			Syncable:     syncable,
+		},
+	)
+	require.NoError(t, err)
+}
+
+func TestSyncHistory(t *testing.T) {
+	t.Parallel()
+
+	base := time.Now().UTC().Truncate(time.Second)
+
+	testCases := []struct {
+		name         string
+		setupFunc    func(t *testing.T, queries *Queries)
+		scenarioFunc func(t *testing.T, queries *Queries)
+	}{
+		{
+			name: "list with cursor pagination",
+			//nolint:thelper // We want to see these lines in the test output
+			setupFunc: func(t *testing.T, queries *Queries) {
+				createSyncRequestTestSource(t, queries, "git-source", true)
+				for i := range 3 {
+					startedAt := base.Add(time.Duration(i) * time.Minute)
+					err := queries.InsertSyncHistory(context.Background(), InsertSyncHistoryParams{
+						Name:         "git-source",
+						SyncStatus:   SyncStatusCOMPLETED,
+						Reason:       "source-data-changed",
+						ServerCount:  int64(i),
+						ServersAdded: int64(i),
+						StartedAt:    startedAt,
+						EndedAt:      startedAt.Add(time.Second),
+					})
+					require.NoError(t, err)
+				}
+			},
+			//nolint:thelper // We want to see these lines in the test output
+			scenarioFunc: func(t *testing.T, queries *Queries) {
+				page, err := queries.ListSyncHistory(context.Background(), ListSyncHistoryParams{
+					Name: "git-source",
+					Size: 2,
+				})
+				require.NoError(t, err)
+				require.Len(t, page, 2)
+				require.Equal(t, int64(2), page[0].ServersAdded)
+				require.Equal(t, int64(1), page[1].ServersAdded)
+
+				last := page[1]
+				page, err = queries.ListSyncHistory(context.Background(), ListSyncHistoryParams{
+					Name:            "git-source",
+					CursorStartedAt: &last.StartedAt,
+					CursorID:        &last.ID,
+					Size:            2,
+				})
+				require.NoError(t, err)
+				require.Len(t, page, 1)
+				require.Equal(t, int64(0), page[0].ServersAdded)
+			},
+		},
+		{
+			name: "prune before retention cutoff",
+			//nolint:thelper // We want to see these lines in the test output
+			setupFunc: func(t *testing.T, queries *Queries) {
+				createSyncRequestTestSource(t, queries, "git-source", true)
+				for _, startedAt := range []time.Time{base.Add(-48 * time.Hour), base} {
+					err := queries.InsertSyncHistory(context.Background(), InsertSyncHistoryParams{
+						Name:            "git-source",
+						SyncStatus:      SyncStatusFAILED,
+						Reason:          "registry-not-ready",
+						ConditionReason: ptr.String("FetchFailed"),
+						ErrorMsg:        ptr.String("fetch failed"),
+						StartedAt:       startedAt,
+						EndedAt:         startedAt,
+					})
+					require.NoError(t, err)
+				}
+			},
+			//nolint:thelper // We want to see these lines in the test output
+			scenarioFunc: func(t *testing.T, queries *Queries) {
+				err := queries.DeleteSyncHistoryBefore(context.Background(), DeleteSyncHistoryBeforeParams{
+					Name:   "git-source",
+					Before: base.Add(-24 * time.Hour),
+				})
+				require.NoError(t, err)
+
+				rows, err := queries.ListSyncHistory(context.Background(), ListSyncHistoryParams{
+					Name: "git-source",
+					Size: 10,
+				})
+				require.NoError(t, err)
+				require.Len(t, rows, 1)
+				require.True(t, rows[0].StartedAt.Equal(base))
+				require.Equal(t, "FetchFailed", *rows[0].ConditionReason)
+			},
+		},
+		{
+			name: "entry digests upsert",
+			//nolint:thelper // We want to see these lines in the test output
+			setupFunc: func(t *testing.T, queries *Queries) {
+				createSyncRequestTestSource(t, queries, "git-source", true)
+			},
+			//nolint:thelper // We want to see these lines in the test output
+			scenarioFunc: func(t *testing.T, queries *Queries) {
+				_, err := queries.GetSourceEntryDigests(context.Background(), "git-source")
+				require.ErrorIs(t, err, sql.ErrNoRows)
+
+				for _, digests := range []string{`{"server/a@1":"x"}`, `{"server/a@1":"y"}`} {
+					err = queries.UpsertSourceEntryDigests(context.Background(), UpsertSourceEntryDigestsParams{
+						Name:      "git-source",
+						Digests:   []byte(digests),
+						UpdatedAt: base,
+					})
+					require.NoError(t, err)
+				}
+
+				got, err := queries.GetSourceEntryDigests(context.Background(), "git-source")
+				require.NoError(t, err)
+				require.JSONEq(t, `{"server/a@1":"y"}`, string(got))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, cleanupFunc := database.SetupTestDB(t)
+			t.Cleanup(cleanupFunc)
+			queries := New(db)
+			require.NotNil(t, queries)
+
+			tc.setupFunc(t, queries)
+			tc.scenarioFunc(t, queries)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package sqlc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/database"
+)
+
+func TestWebhookDeliveries(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		setupFunc    func(t *testing.T, queries *Queries)
+		scenarioFunc func(t *testing.T, queries *Queries)
+	}{
+		{
+			name: "claim and record attempts",
+			//nolint:thelper // We want to see these lines in the test output
+			setupFunc: func(t *testing.T, queries *Queries) {
+				err := queries.InsertWebhookDeliveries(context.Background(), InsertWebhookDeliveriesParams{
+					EventType: "entry.publish",
+					Payload:   []byte(`{"type":"entry.publish"}`),
+					Webhooks:  []string{"catalog", "audit-sink"},
+				})
+				require.NoError(t, err)
+			},
+			//nolint:thelper // We want to see these lines in the test output
+			scenarioFunc: func(t *testing.T, queries *Queries) {
+				ctx := context.Background()
+				leasedUntil := time.Now().Add(time.Minute)
+				claimed, err := queries.ClaimWebhookDeliveries(ctx, ClaimWebhookDeliveriesParams{
+					LeasedUntil: leasedUntil,
+					Size:        10,
+				})
+				require.NoError(t, err)
+				require.Len(t, claimed, 2)
+				require.Equal(t, "entry.publish", claimed[0].EventType)
+				require.JSONEq(t, `{"type":"entry.publish"}`, string(claimed[0].Payload))
+				require.Zero(t, claimed[0].Attempts)
+
+				// Leased deliveries are not claimed again
+				again, err := queries.ClaimWebhookDeliveries(ctx, ClaimWebhookDeliveriesParams{
+					LeasedUntil: leasedUntil,
+					Size:        10,
+				})
+				require.NoError(t, err)
+				require.Empty(t, again)
+
+				now := time.Now()
+				require.NoError(t, queries.UpdateWebhookDeliveryAttempt(ctx, UpdateWebhookDeliveryAttemptParams{
+					ID:             claimed[0].ID,
+					Status:         WebhookDeliveryStatusDELIVERED,
+					ResponseStatus: ptr.Int32(204),
+					NextAttemptAt:  now,
+					DeliveredAt:    &now,
+				}))
+				// A failed attempt due now is claimed again
+				require.NoError(t, queries.UpdateWebhookDeliveryAttempt(ctx, UpdateWebhookDeliveryAttemptParams{
+					ID:            claimed[1].ID,
+					Status:        WebhookDeliveryStatusPENDING,
+					LastError:     ptr.String("connection refused"),
+					NextAttemptAt: now.Add(-time.Second),
+				}))
+
+				retried, err := queries.ClaimWebhookDeliveries(ctx, ClaimWebhookDeliveriesParams{
+					LeasedUntil: leasedUntil,
+					Size:        10,
+				})
+				require.NoError(t, err)
+				require.Len(t, retried, 1)
+				require.Equal(t, claimed[1].ID, retried[0].ID)
+				require.Equal(t, int32(1), retried[0].Attempts)
+
+				delivered, err := queries.ListWebhookDeliveries(ctx, ListWebhookDeliveriesParams{
+					Status: NullWebhookDeliveryStatus{WebhookDeliveryStatus: WebhookDeliveryStatusDELIVERED, Valid: true},
+					Size:   10,
+				})
+				require.NoError(t, err)
+				require.Len(t, delivered, 1)
+				require.Equal(t, claimed[0].Webhook, delivered[0].Webhook)
+				require.Equal(t, int32(204), *delivered[0].ResponseStatus)
+				require.NotNil(t, delivered[0].DeliveredAt)
+			},
+		},
+		{
+			name: "list with filter and cursor pagination",
+			//nolint:thelper // We want to see these lines in the test output
+			setupFunc: func(t *testing.T, queries *Queries) {
+				for _, eventType := range []string{"source.sync", "entry.publish", "entry.delete"} {
+					err := queries.InsertWebhookDeliveries(context.Background(), InsertWebhookDeliveriesParams{
+						EventType: eventType,
+						Payload:   []byte(`{}`),
+						Webhooks:  []string{"catalog", "audit-sink"},
+					})
+					require.NoError(t, err)
+				}
+			},
+			//nolint:thelper // We want to see these lines in the test output
+			scenarioFunc: func(t *testing.T, queries *Queries) {
+				ctx := context.Background()
+				page, err := queries.ListWebhookDeliveries(ctx, ListWebhookDeliveriesParams{
+					Webhook: ptr.String("catalog"),
+					Size:    2,
+				})
+				require.NoError(t, err)
+				require.Len(t, page, 2)
+				require.Equal(t, "entry.delete", page[0].EventType)
+				require.Equal(t, "entry.publish", page[1].EventType)
+				require.Equal(t, WebhookDeliveryStatusPENDING, page[0].Status)
+
+				last := page[1]
+				page, err = queries.ListWebhookDeliveries(ctx, ListWebhookDeliveriesParams{
+					Webhook:         ptr.String("catalog"),
+					CursorCreatedAt: &last.CreatedAt,
+					CursorID:        &last.ID,
+					Size:            2,
+				})
+				require.NoError(t, err)
+				require.Len(t, page, 1)
+				require.Equal(t, "source.sync", page[0].EventType)
+				require.Equal(t, "catalog", page[0].Webhook)
+			},
+		},
+		{
+			name: "prune only deliveries that are no longer pending",
+			//nolint:thelper // We want to see these lines in the test output
+			setupFunc: func(t *testing.T, queries *Queries) {
+				err := queries.InsertWebhookDeliveries(context.Background(), InsertWebhookDeliveriesParams{
+					EventType: "source.sync",
+					Payload:   []byte(`{}`),
+					Webhooks:  []string{"catalog", "audit-sink"},
+				})
+				require.NoError(t, err)
+			},
+			//nolint:thelper // We want to see these lines in the test output
+			scenarioFunc: func(t *testing.T, queries *Queries) {
+				ctx := context.Background()
+				claimed, err := queries.ClaimWebhookDeliveries(ctx, ClaimWebhookDeliveriesParams{
+					LeasedUntil: time.Now().Add(time.Minute),
+					Size:        1,
+				})
+				require.NoError(t, err)
+				require.Len(t, claimed, 1)
+				require.NoError(t, queries.UpdateWebhookDeliveryAttempt(ctx, UpdateWebhookDeliveryAttemptParams{
+					ID:            claimed[0].ID,
+					Status:        WebhookDeliveryStatusFAILED,
+					LastError:     ptr.String("unexpected status 500"),
+					NextAttemptAt: time.Now(),
+				}))
+
+				require.NoError(t, queries.DeleteWebhookDeliveriesBefore(ctx, time.Now().Add(time.Minute)))
+
+				rows, err := queries.ListWebhookDeliveries(ctx, ListWebhookDeliveriesParams{Size: 10})
+				require.NoError(t, err)
+				require.Len(t, rows, 1)
+				require.Equal(t, WebhookDeliveryStatusPENDING, rows[0].Status)
+				require.NotEqual(t, claimed[0].ID, rows[0].ID)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, cleanupFunc := database.SetupTestDB(t)
+			t.Cleanup(cleanupFunc)
+			queries := New(db)
+			require.NotNil(t, queries)
+
+			tc.setupFunc(t, queries)
+			tc.scenarioFunc(t, queries)
+		})
+	}
+}
@@ -1,17 +1,18 @@
 // Package filtering provides server filtering capabilities for registry data.
 //
 // This package implements a comprehensive filtering system that allows the
-// registry API to selectively include or exclude servers based on name patterns
-// and tags. The filtering system supports both include and exclude rules with
-// exclude taking precedence over include.
+// registry API to selectively include or exclude servers based on name patterns,
+// tags and CEL expressions. The filtering system supports both include and
+// exclude rules with exclude taking precedence over include.
 //
 // # Architecture
 //
-// The filtering system consists of three main components:
+// The filtering system consists of four main components:
 //
 //   - NameFilter: Handles server name filtering using glob patterns
 //   - TagFilter: Handles tag-based filtering using exact string matching
-//   - FilterService: Coordinates both name and tag filtering
+//   - ExpressionFilter: Handles filtering with CEL expressions
+//   - FilterService: Coordinates name, tag and expression filtering
 //
 // # Name Filtering
 //
@@ -28,9 +29,22 @@
 // included if any of its tags match any include tag, and excluded if any of
 // its tags match any exclude tag.
 //
+// # Expression Filtering
+//
+// Expression filtering evaluates CEL expressions against the "entry" variable,
+// a map that describes servers, skills and plugins alike: type, name,
+// namespace, version, status, license, tags, packages, transports, remotes and
+// meta (the _meta object of the entry). Examples:
+//
+//   - entry.packages.all(p, p.registryType == "oci")
+//   - "stdio" in entry.transports
+//   - entry.remotes.all(r, r.host.endsWith(".example.com"))
+//
+// An expression that fails to evaluate against an entry excludes it.
+//
 // # Filtering Logic
 //
-// Both name and tag filters follow the same precedence rules:
+// Name, tag and expression filters follow the same precedence rules:
 //
 //  1. If exclude patterns/tags are specified and match -> exclude (precedence)
 //  2. If include patterns/tags are specified and match -> include
@@ -38,8 +52,9 @@
 //  4. If only exclude patterns/tags specified and no match -> include
 //  5. If no filters specified -> include (default behavior)
 //
-// For a server to be included in the final registry, it must pass BOTH
-// name and tag filtering (logical AND).
+// For a server to be included in the final registry, it must pass name, tag
+// and expression filtering (logical AND). Skills and plugins must pass name and
+// expression filtering.
 //
 // # Usage Example
 //
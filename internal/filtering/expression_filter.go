@@ -0,0 +1,316 @@
+package filtering
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	toolhivetypes "github.com/stacklok/toolhive-core/registry/types"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+)
+
+// expressionVariable is the name of the variable holding the entry in filter expressions
+const expressionVariable = "entry"
+
+// Entry types exposed to filter expressions as entry.type
+const (
+	entryTypeServer = "server"
+	entryTypeSkill  = "skill"
+	entryTypePlugin = "plugin"
+)
+
+// ExpressionFilter handles filtering with CEL expressions evaluated against each entry
+type ExpressionFilter interface {
+	// Validate compiles the include/exclude expressions, returning an error for the first invalid one
+	Validate(include, exclude []string) error
+
+	// ShouldInclude determines if an entry should be included based on include/exclude expressions
+	// Returns (shouldInclude bool, reason string)
+	ShouldInclude(entry map[string]any, include, exclude []string) (bool, string)
+}
+
+// defaultExpressionFilter implements expression filtering with CEL, caching compiled programs
+type defaultExpressionFilter struct {
+	env *cel.Env
+
+	mu       sync.Mutex
+	programs map[string]cel.Program
+}
+
+var _ ExpressionFilter = (*defaultExpressionFilter)(nil)
+
+// NewDefaultExpressionFilter creates a new defaultExpressionFilter
+func NewDefaultExpressionFilter() ExpressionFilter {
+	env, err := cel.NewEnv(
+		cel.Variable(expressionVariable, cel.MapType(cel.StringType, cel.DynType)),
+		ext.Strings(),
+	)
+	if err != nil {
+		// The environment is static, so this only fails on a programming error
+		panic(fmt.Sprintf("failed to create filter expression environment: %v", err))
+	}
+	return &defaultExpressionFilter{
+		env:      env,
+		programs: make(map[string]cel.Program),
+	}
+}
+
+// Validate compiles the include/exclude expressions, returning an error for the first invalid one
+func (f *defaultExpressionFilter) Validate(include, exclude []string) error {
+	for _, expr := range append(append([]string{}, include...), exclude...) {
+		if _, err := f.program(expr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ShouldInclude determines if an entry should be included based on include/exclude expressions
+//
+// Logic:
+// 1. If any exclude expression is true -> exclude (exclude takes precedence)
+// 2. If include expressions are specified and any is true -> include
+// 3. If include expressions are specified and none is true -> exclude
+// 4. If only exclude expressions are specified and none is true -> include
+// 5. If no expressions are specified -> include (default behavior)
+//
+// An expression that cannot be evaluated against the entry, for example
+// because it reads a key the entry does not have, excludes the entry.
+func (f *defaultExpressionFilter) ShouldInclude(entry map[string]any, include, exclude []string) (bool, string) {
+	for _, expr := range exclude {
+		matched, err := f.eval(expr, entry)
+		if err != nil {
+			return false, err.Error()
+		}
+		if matched {
+			return false, fmt.Sprintf("excluded by expression '%s'", expr)
+		}
+	}
+
+	if len(include) > 0 {
+		for _, expr := range include {
+			matched, err := f.eval(expr, entry)
+			if err != nil {
+				return false, err.Error()
+			}
+			if matched {
+				return true, fmt.Sprintf("included by expression '%s'", expr)
+			}
+		}
+		return false, fmt.Sprintf("no matching expression in include list %v", include)
+	}
+
+	if len(exclude) > 0 {
+		return true, fmt.Sprintf("no matching expression in exclude list %v", exclude)
+	}
+	return true, "no expression filters specified"
+}
+
+// eval evaluates an expression against an entry
+func (f *defaultExpressionFilter) eval(expr string, entry map[string]any) (bool, error) {
+	prg, err := f.program(expr)
+	if err != nil {
+		return false, err
+	}
+	out, _, err := prg.Eval(map[string]any{expressionVariable: entry})
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate expression '%s': %w", expr, err)
+	}
+	matched, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression '%s' evaluated to %v, not a bool", expr, out.Value())
+	}
+	return matched, nil
+}
+
+// program returns the compiled program of an expression
+func (f *defaultExpressionFilter) program(expr string) (cel.Program, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if prg, ok := f.programs[expr]; ok {
+		return prg, nil
+	}
+
+	ast, issues := f.env.Compile(expr)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("invalid expression '%s': %w", expr, issues.Err())
+	}
+	if !ast.OutputType().IsAssignableType(cel.BoolType) {
+		return nil, fmt.Errorf("invalid expression '%s': must evaluate to a bool, not %s", expr, ast.OutputType())
+	}
+	prg, err := f.env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression '%s': %w", expr, err)
+	}
+	f.programs[expr] = prg
+	return prg, nil
+}
+
+// ValidateFilterConfig checks that the expressions of a filter configuration compile
+func ValidateFilterConfig(filter *config.FilterConfig) error {
+	if filter == nil || filter.Expressions == nil {
+		return nil
+	}
+	return NewDefaultExpressionFilter().Validate(filter.Expressions.Include, filter.Expressions.Exclude)
+}
+
+// serverExpressionEntry returns the representation of a server in filter expressions
+func serverExpressionEntry(server *upstreamv0.ServerJSON) map[string]any {
+	namespace, _, _ := strings.Cut(server.Name, "/")
+
+	packages := make([]any, 0, len(server.Packages))
+	transports := make([]string, 0, len(server.Packages)+len(server.Remotes))
+	for _, pkg := range server.Packages {
+		packages = append(packages, map[string]any{
+			"registryType": pkg.RegistryType,
+			"identifier":   pkg.Identifier,
+			"version":      pkg.Version,
+			"transport":    pkg.Transport.Type,
+		})
+		transports = appendUnique(transports, pkg.Transport.Type)
+	}
+
+	remotes := make([]any, 0, len(server.Remotes))
+	for _, remote := range server.Remotes {
+		remotes = append(remotes, map[string]any{
+			"type": remote.Type,
+			"url":  remote.URL,
+			"host": urlHost(remote.URL),
+		})
+		transports = appendUnique(transports, remote.Type)
+	}
+
+	return map[string]any{
+		"type":        entryTypeServer,
+		"name":        server.Name,
+		"namespace":   namespace,
+		"version":     server.Version,
+		"title":       server.Title,
+		"description": server.Description,
+		"status":      extensionString(server, "status"),
+		"license":     extensionString(server, "license"),
+		"tags":        registry.ExtractTags(server),
+		"packages":    packages,
+		"transports":  transports,
+		"remotes":     remotes,
+		"meta":        toJSONMap(server.Meta),
+	}
+}
+
+// skillExpressionEntry returns the representation of a skill in filter expressions
+func skillExpressionEntry(skill *toolhivetypes.Skill) map[string]any {
+	return skillLikeExpressionEntry(entryTypeSkill, skill.Namespace, skill.Name, skill.Version, skill.Title,
+		skill.Description, skill.Status, skill.License, skill.Packages, skill.Meta)
+}
+
+// pluginExpressionEntry returns the representation of a plugin in filter expressions
+func pluginExpressionEntry(plugin *toolhivetypes.Plugin) map[string]any {
+	return skillLikeExpressionEntry(entryTypePlugin, plugin.Namespace, plugin.Name, plugin.Version, plugin.Title,
+		plugin.Description, plugin.Status, plugin.License, plugin.Packages, plugin.Meta)
+}
+
+// skillLikeExpressionEntry returns the representation of a skill or plugin in filter expressions.
+// Skills and plugins have no transports or remotes; these are empty so that
+// expressions written for servers evaluate without errors.
+func skillLikeExpressionEntry(
+	entryType, namespace, name, version, title, description, status, license string,
+	skillPackages []toolhivetypes.SkillPackage,
+	meta map[string]any,
+) map[string]any {
+	packages := make([]any, 0, len(skillPackages))
+	for _, pkg := range skillPackages {
+		identifier := pkg.Identifier
+		if identifier == "" {
+			identifier = pkg.URL
+		}
+		packages = append(packages, map[string]any{
+			"registryType": pkg.RegistryType,
+			"identifier":   identifier,
+			"version":      "",
+			"transport":    "",
+		})
+	}
+	if meta == nil {
+		meta = map[string]any{}
+	}
+
+	return map[string]any{
+		"type":        entryType,
+		"name":        namespace + "/" + name,
+		"namespace":   namespace,
+		"version":     version,
+		"title":       title,
+		"description": description,
+		"status":      status,
+		"license":     license,
+		"tags":        []string{},
+		"packages":    packages,
+		"transports":  []string{},
+		"remotes":     []any{},
+		"meta":        meta,
+	}
+}
+
+// extensionString returns the first string value of a key in the
+// publisher-provided extensions of a server, where ExtractTags finds tags
+func extensionString(server *upstreamv0.ServerJSON, key string) string {
+	if server.Meta == nil {
+		return ""
+	}
+	for _, publisher := range server.Meta.PublisherProvided {
+		publisherMap, ok := publisher.(map[string]any)
+		if !ok {
+			continue
+		}
+		for _, extensions := range publisherMap {
+			extensionsMap, ok := extensions.(map[string]any)
+			if !ok {
+				continue
+			}
+			if value, ok := extensionsMap[key].(string); ok && value != "" {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+// toJSONMap converts a value to its generic JSON representation, so that
+// expressions see the same keys as the JSON of the entry
+func toJSONMap(v any) map[string]any {
+	result := map[string]any{}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return result
+	}
+	if err := json.Unmarshal(data, &result); err != nil || result == nil {
+		return map[string]any{}
+	}
+	return result
+}
+
+// urlHost returns the host name of a URL, or an empty string when it cannot be parsed
+func urlHost(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
+
+// appendUnique appends a non-empty value to a list unless it is already present
+func appendUnique(values []string, value string) []string {
+	if value == "" || slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
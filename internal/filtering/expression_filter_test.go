@@ -0,0 +1,202 @@
+package filtering
+
+import (
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	toolhivetypes "github.com/stacklok/toolhive-core/registry/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+)
+
+func TestDefaultExpressionFilter_ShouldInclude(t *testing.T) {
+	t.Parallel()
+
+	filter := NewDefaultExpressionFilter()
+
+	server := registry.NewTestServer("io.test/remote-server",
+		registry.WithOCIPackage("test/server:latest"),
+		registry.WithTags("database"),
+		registry.WithToolHiveMetadata("status", "Deprecated"),
+		registry.WithToolHiveMetadata("license", "MIT"),
+	)
+	server.Remotes = []model.Transport{{Type: "streamable-http", URL: "https://mcp.example.com/mcp"}}
+	entry := serverExpressionEntry(&server)
+
+	tests := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		expected bool
+	}{
+		{
+			name:     "no expressions",
+			expected: true,
+		},
+		{
+			name:     "include by package type",
+			include:  []string{`entry.packages.all(p, p.registryType == "oci")`},
+			expected: true,
+		},
+		{
+			name:     "exclude by transport",
+			exclude:  []string{`"stdio" in entry.transports`},
+			expected: false,
+		},
+		{
+			name:     "include by remote host",
+			include:  []string{`entry.remotes.all(r, r.host.endsWith(".example.com"))`},
+			expected: true,
+		},
+		{
+			name:     "include by license",
+			include:  []string{`entry.license in ["Apache-2.0"]`},
+			expected: false,
+		},
+		{
+			name:     "exclude by status",
+			exclude:  []string{`entry.status.lowerAscii() == "deprecated"`},
+			expected: false,
+		},
+		{
+			name:     "include by meta path",
+			include:  []string{`entry.meta["io.modelcontextprotocol.registry/publisher-provided"].provider.toolhive.license == "MIT"`},
+			expected: true,
+		},
+		{
+			name:     "include any of several expressions",
+			include:  []string{`entry.type == "skill"`, `"database" in entry.tags`},
+			expected: true,
+		},
+		{
+			name:     "exclude takes precedence over include",
+			include:  []string{`entry.type == "server"`},
+			exclude:  []string{`entry.namespace == "io.test"`},
+			expected: false,
+		},
+		{
+			name:     "evaluation error excludes the entry",
+			include:  []string{`entry.meta.missing.key == "value"`},
+			expected: false,
+		},
+		{
+			name:     "has guards a missing key",
+			exclude:  []string{`has(entry.meta.missing) && entry.meta.missing.key == "value"`},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, reason := filter.ShouldInclude(entry, tt.include, tt.exclude)
+			assert.Equal(t, tt.expected, result, reason)
+			assert.NotEmpty(t, reason)
+		})
+	}
+}
+
+func TestDefaultExpressionFilter_Validate(t *testing.T) {
+	t.Parallel()
+
+	filter := NewDefaultExpressionFilter()
+
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+		wantErr string
+	}{
+		{
+			name:    "valid expressions",
+			include: []string{`entry.type == "server"`},
+			exclude: []string{`entry.meta.flag`},
+		},
+		{
+			name:    "syntax error",
+			include: []string{`entry.type ==`},
+			wantErr: "invalid expression",
+		},
+		{
+			name:    "unknown variable",
+			exclude: []string{`server.name == "x"`},
+			wantErr: "undeclared reference",
+		},
+		{
+			name:    "non bool expression",
+			include: []string{`entry.name + "x"`},
+			wantErr: "must evaluate to a bool",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := filter.Validate(tt.include, tt.exclude)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateFilterConfig(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, ValidateFilterConfig(nil))
+	assert.NoError(t, ValidateFilterConfig(&config.FilterConfig{}))
+	assert.NoError(t, ValidateFilterConfig(&config.FilterConfig{
+		Expressions: &config.ExpressionFilterConfig{Include: []string{`entry.type == "server"`}},
+	}))
+	assert.Error(t, ValidateFilterConfig(&config.FilterConfig{
+		Expressions: &config.ExpressionFilterConfig{Exclude: []string{`entry.type ==`}},
+	}))
+}
+
+func TestExpressionEntries(t *testing.T) {
+	t.Parallel()
+
+	server := upstreamv0.ServerJSON{
+		Name:    "io.test/server",
+		Version: "1.0.0",
+		Packages: []model.Package{
+			{RegistryType: "npm", Identifier: "@test/server", Transport: model.Transport{Type: "stdio"}},
+			{RegistryType: "oci", Identifier: "test/server:1.0.0", Transport: model.Transport{Type: "stdio"}},
+		},
+		Remotes: []model.Transport{{Type: "sse", URL: "https://mcp.example.com:8443/sse"}},
+	}
+	entry := serverExpressionEntry(&server)
+	assert.Equal(t, "server", entry["type"])
+	assert.Equal(t, "io.test", entry["namespace"])
+	assert.Equal(t, []string{"stdio", "sse"}, entry["transports"])
+	assert.Equal(t, "mcp.example.com", entry["remotes"].([]any)[0].(map[string]any)["host"])
+	assert.Equal(t, map[string]any{}, entry["meta"])
+
+	skill := toolhivetypes.Skill{
+		Namespace: "io.test",
+		Name:      "skill",
+		Version:   "1.0.0",
+		Status:    "active",
+		License:   "MIT",
+		Packages:  []toolhivetypes.SkillPackage{{RegistryType: "git", URL: "https://github.com/test/skill"}},
+	}
+	entry = skillExpressionEntry(&skill)
+	assert.Equal(t, "skill", entry["type"])
+	assert.Equal(t, "io.test/skill", entry["name"])
+	assert.Equal(t, "active", entry["status"])
+	assert.Equal(t, "https://github.com/test/skill", entry["packages"].([]any)[0].(map[string]any)["identifier"])
+
+	plugin := toolhivetypes.Plugin{Namespace: "io.test", Name: "plugin", Meta: map[string]any{"team": "platform"}}
+	entry = pluginExpressionEntry(&plugin)
+	assert.Equal(t, "plugin", entry["type"])
+	assert.Equal(t, map[string]any{"team": "platform"}, entry["meta"])
+}
@@ -13,7 +13,7 @@ import (
 	"github.com/stacklok/toolhive-registry-server/internal/registry"
 )
 
-// FilterService coordinates name and tag filtering to apply registry filters
+// FilterService coordinates name, tag and expression filtering to apply registry filters
 type FilterService interface {
 	// ApplyFilters filters the registry based on filter configuration
 	ApplyFilters(
@@ -23,25 +23,28 @@ type FilterService interface {
 	) (*toolhivetypes.UpstreamRegistry, error)
 }
 
-// defaultFilterService implements filtering coordination using name and tag filters
+// defaultFilterService implements filtering coordination using name, tag and expression filters
 type defaultFilterService struct {
-	nameFilter NameFilter
-	tagFilter  TagFilter
+	nameFilter       NameFilter
+	tagFilter        TagFilter
+	expressionFilter ExpressionFilter
 }
 
 // NewDefaultFilterService creates a new defaultFilterService with default filter implementations
 func NewDefaultFilterService() FilterService {
 	return &defaultFilterService{
-		nameFilter: NewDefaultNameFilter(),
-		tagFilter:  NewDefaultTagFilter(),
+		nameFilter:       NewDefaultNameFilter(),
+		tagFilter:        NewDefaultTagFilter(),
+		expressionFilter: NewDefaultExpressionFilter(),
 	}
 }
 
-// NewFilterService creates a new defaultFilterService with custom filter implementations
+// NewFilterService creates a new defaultFilterService with custom name and tag filter implementations
 func NewFilterService(nameFilter NameFilter, tagFilter TagFilter) FilterService {
 	return &defaultFilterService{
-		nameFilter: nameFilter,
-		tagFilter:  tagFilter,
+		nameFilter:       nameFilter,
+		tagFilter:        tagFilter,
+		expressionFilter: NewDefaultExpressionFilter(),
 	}
 }
 
@@ -50,9 +53,10 @@ func NewFilterService(nameFilter NameFilter, tagFilter TagFilter) FilterService
 // The filtering process:
 // 1. If no filter is specified, return the original registry unchanged
 // 2. Create a new registry with the same metadata but empty server maps
-// 3. For each server (both container and remote), apply name and tag filtering
-// 4. Only include servers that pass both name and tag filters
-// 5. Return the filtered registry
+// 3. For each server (both container and remote), apply name, tag and expression filtering
+// 4. Only include servers that pass all filters
+// 5. Apply name and expression filtering to skills and plugins
+// 6. Return the filtered registry
 func (s *defaultFilterService) ApplyFilters(
 	_ context.Context,
 	reg *toolhivetypes.UpstreamRegistry,
@@ -81,7 +85,7 @@ func (s *defaultFilterService) ApplyFilters(
 	}
 
 	// Extract filter criteria
-	var nameInclude, nameExclude, tagInclude, tagExclude []string
+	var nameInclude, nameExclude, tagInclude, tagExclude, exprInclude, exprExclude []string
 	if filter.Names != nil {
 		nameInclude = filter.Names.Include
 		nameExclude = filter.Names.Exclude
@@ -90,6 +94,16 @@ func (s *defaultFilterService) ApplyFilters(
 		tagInclude = filter.Tags.Include
 		tagExclude = filter.Tags.Exclude
 	}
+	if filter.Expressions != nil {
+		exprInclude = filter.Expressions.Include
+		exprExclude = filter.Expressions.Exclude
+	}
+
+	// Compile the expressions before filtering so that an invalid one fails the
+	// sync instead of excluding every entry
+	if err := s.expressionFilter.Validate(exprInclude, exprExclude); err != nil {
+		return nil, fmt.Errorf("invalid filter expression: %w", err)
+	}
 
 	includedCount := 0
 	excludedCount := 0
@@ -106,6 +120,9 @@ func (s *defaultFilterService) ApplyFilters(
 			tagInclude,
 			tagExclude,
 		)
+		if included && (len(exprInclude) > 0 || len(exprExclude) > 0) {
+			included, reason = s.shouldIncludeWithExpressions(serverExpressionEntry(&server), reason, exprInclude, exprExclude)
+		}
 		if included {
 			filteredRegistry.Data.Servers = append(filteredRegistry.Data.Servers, server)
 			includedCount++
@@ -128,6 +145,9 @@ func (s *defaultFilterService) ApplyFilters(
 	for _, skill := range reg.Data.Skills {
 		skillName := skill.Namespace + "/" + skill.Name
 		included, reason := s.nameFilter.ShouldInclude(skillName, nameInclude, nameExclude)
+		if included && (len(exprInclude) > 0 || len(exprExclude) > 0) {
+			included, reason = s.shouldIncludeWithExpressions(skillExpressionEntry(&skill), reason, exprInclude, exprExclude)
+		}
 		if included {
 			filteredRegistry.Data.Skills = append(filteredRegistry.Data.Skills, skill)
 			skillIncluded++
@@ -148,6 +168,9 @@ func (s *defaultFilterService) ApplyFilters(
 	for _, plugin := range reg.Data.Plugins {
 		pluginName := plugin.Namespace + "/" + plugin.Name
 		included, reason := s.nameFilter.ShouldInclude(pluginName, nameInclude, nameExclude)
+		if included && (len(exprInclude) > 0 || len(exprExclude) > 0) {
+			included, reason = s.shouldIncludeWithExpressions(pluginExpressionEntry(&plugin), reason, exprInclude, exprExclude)
+		}
 		if included {
 			filteredRegistry.Data.Plugins = append(filteredRegistry.Data.Plugins, plugin)
 			pluginIncluded++
@@ -209,3 +232,16 @@ func (s *defaultFilterService) shouldIncludeServerWithReason(
 
 	return true, "passed all filters: " + strings.Join(inclusionReasons, " AND ")
 }
+
+// shouldIncludeWithExpressions applies expression filtering to an entry that passed the other filters,
+// combining the reason of the other filters with that of the expressions
+func (s *defaultFilterService) shouldIncludeWithExpressions(
+	entry map[string]any,
+	reason string,
+	exprInclude, exprExclude []string) (bool, string) {
+	included, exprReason := s.expressionFilter.ShouldInclude(entry, exprInclude, exprExclude)
+	if !included {
+		return false, fmt.Sprintf("expression filter: %s", exprReason)
+	}
+	return true, fmt.Sprintf("%s AND expression filter: %s", reason, exprReason)
+}
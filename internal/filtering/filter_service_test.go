@@ -577,3 +577,58 @@ func TestDefaultFilterService_ApplyFilters_PluginsPassWithNoFilter(t *testing.T)
 	require.Len(t, result.Data.Plugins, 1)
 	assert.Equal(t, "pdf-generator", result.Data.Plugins[0].Name)
 }
+
+func TestDefaultFilterService_ApplyFilters_ExpressionFiltering(t *testing.T) {
+	t.Parallel()
+
+	service := NewDefaultFilterService()
+	ctx := context.Background()
+
+	originalRegistry := registry.NewTestUpstreamRegistry(
+		registry.WithServers(
+			registry.NewTestServer("oci-server",
+				registry.WithNamespace("io.test/"),
+				registry.WithOCIPackage("oci-server:latest"),
+			),
+			registry.NewTestServer("http-server",
+				registry.WithNamespace("io.test/"),
+				registry.WithHTTPPackage("https://example.com"),
+			),
+			registry.NewTestServer("deprecated-server",
+				registry.WithNamespace("io.test/"),
+				registry.WithOCIPackage("deprecated-server:latest"),
+				registry.WithToolHiveMetadata("status", "Deprecated"),
+			),
+		),
+	)
+	originalRegistry.Data.Skills = []toolhivetypes.Skill{
+		{Namespace: "io.test", Name: "active-skill", Version: "1.0.0", Status: "active"},
+		{Namespace: "io.test", Name: "deprecated-skill", Version: "1.0.0", Status: "deprecated"},
+	}
+	originalRegistry.Data.Plugins = []toolhivetypes.Plugin{
+		{Namespace: "io.test", Name: "plugin", Version: "1.0.0", Status: "deprecated"},
+	}
+
+	// Servers must be OCI only; deprecated entries of any type are excluded
+	filter := &config.FilterConfig{
+		Expressions: &config.ExpressionFilterConfig{
+			Include: []string{`entry.type != "server" || entry.packages.all(p, p.registryType == "oci")`},
+			Exclude: []string{`entry.status.lowerAscii() == "deprecated"`},
+		},
+	}
+
+	result, err := service.ApplyFilters(ctx, originalRegistry, filter)
+	require.NoError(t, err)
+
+	assertContainsServerNames(t, result.Data.Servers, []string{"oci-server"})
+	require.Len(t, result.Data.Skills, 1)
+	assert.Equal(t, "active-skill", result.Data.Skills[0].Name)
+	assert.Empty(t, result.Data.Plugins)
+
+	// Invalid expressions fail the filtering instead of excluding every entry
+	_, err = service.ApplyFilters(ctx, originalRegistry, &config.FilterConfig{
+		Expressions: &config.ExpressionFilterConfig{Include: []string{`entry.type ==`}},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid filter expression")
+}
@@ -0,0 +1,64 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+)
+
+// authMethod builds the go-git authentication method for the given credentials.
+// It returns nil if no credentials are configured.
+func authMethod(ctx context.Context, auth *AuthConfig) (transport.AuthMethod, error) {
+	if auth == nil {
+		return nil, nil
+	}
+
+	switch {
+	case auth.SSH != nil:
+		return sshAuthMethod(auth.SSH)
+	case auth.TokenSource != nil:
+		token, err := auth.TokenSource.Token(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get git access token: %w", err)
+		}
+		slog.Debug("Using Git HTTP token authentication", "username", auth.TokenSource.Username())
+		return &githttp.BasicAuth{
+			Username: auth.TokenSource.Username(),
+			Password: token,
+		}, nil
+	case auth.Username != "":
+		slog.Debug("Using Git HTTP Basic authentication", "username", auth.Username)
+		return &githttp.BasicAuth{
+			Username: auth.Username,
+			Password: auth.Password,
+		}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// sshAuthMethod builds the SSH public key authentication method. The server host
+// key is always verified against the configured known_hosts file.
+func sshAuthMethod(auth *SSHAuthConfig) (transport.AuthMethod, error) {
+	if auth.KnownHostsFile == "" {
+		return nil, fmt.Errorf("a known_hosts file is required for SSH authentication")
+	}
+
+	publicKeys, err := gitssh.NewPublicKeys(auth.User, auth.PrivateKey, auth.Passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SSH private key: %w", err)
+	}
+
+	hostKeyCallback, err := gitssh.NewKnownHostsCallback(auth.KnownHostsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load known_hosts file: %w", err)
+	}
+	publicKeys.HostKeyCallback = hostKeyCallback
+
+	slog.Debug("Using Git SSH public key authentication", "user", auth.User)
+	return publicKeys, nil
+}
@@ -0,0 +1,157 @@
+package git
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+)
+
+// staticTokenSource is a TokenSource returning a fixed token or error
+type staticTokenSource struct {
+	token string
+	err   error
+}
+
+func (s *staticTokenSource) Token(context.Context) (string, error) {
+	return s.token, s.err
+}
+
+func (*staticTokenSource) Username() string {
+	return "x-access-token"
+}
+
+func generateTestSSHKey(t *testing.T) []byte {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate SSH key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal SSH key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func TestAuthMethod(t *testing.T) {
+	t.Parallel()
+
+	sshKey := generateTestSSHKey(t)
+	knownHosts := filepath.Join(t.TempDir(), "known_hosts")
+	if err := os.WriteFile(knownHosts, nil, 0600); err != nil {
+		t.Fatalf("failed to write known_hosts: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		auth         *AuthConfig
+		expectErr    bool
+		expectMethod func(t *testing.T, method any)
+	}{
+		{
+			name: "no authentication",
+			auth: nil,
+			expectMethod: func(t *testing.T, method any) {
+				t.Helper()
+				if method != nil {
+					t.Errorf("Expected no auth method, got %T", method)
+				}
+			},
+		},
+		{
+			name: "basic authentication",
+			auth: &AuthConfig{Username: "user", Password: "secret"},
+			expectMethod: func(t *testing.T, method any) {
+				t.Helper()
+				basic, ok := method.(*githttp.BasicAuth)
+				if !ok || basic.Username != "user" || basic.Password != "secret" {
+					t.Errorf("Expected basic auth for user, got %#v", method)
+				}
+			},
+		},
+		{
+			name: "token source takes precedence over basic authentication",
+			auth: &AuthConfig{
+				Username:    "user",
+				Password:    "secret",
+				TokenSource: &staticTokenSource{token: "ghs_token"},
+			},
+			expectMethod: func(t *testing.T, method any) {
+				t.Helper()
+				basic, ok := method.(*githttp.BasicAuth)
+				if !ok || basic.Username != "x-access-token" || basic.Password != "ghs_token" {
+					t.Errorf("Expected basic auth with the minted token, got %#v", method)
+				}
+			},
+		},
+		{
+			name:      "token source error",
+			auth:      &AuthConfig{TokenSource: &staticTokenSource{err: errors.New("boom")}},
+			expectErr: true,
+		},
+		{
+			name: "ssh authentication",
+			auth: &AuthConfig{
+				SSH: &SSHAuthConfig{User: "git", PrivateKey: sshKey, KnownHostsFile: knownHosts},
+			},
+			expectMethod: func(t *testing.T, method any) {
+				t.Helper()
+				keys, ok := method.(*gitssh.PublicKeys)
+				if !ok || keys.User != "git" || keys.HostKeyCallback == nil {
+					t.Errorf("Expected ssh public keys with host key verification, got %#v", method)
+				}
+			},
+		},
+		{
+			name: "ssh authentication without known_hosts",
+			auth: &AuthConfig{
+				SSH: &SSHAuthConfig{User: "git", PrivateKey: sshKey},
+			},
+			expectErr: true,
+		},
+		{
+			name: "ssh authentication with invalid key",
+			auth: &AuthConfig{
+				SSH: &SSHAuthConfig{User: "git", PrivateKey: []byte("not a key"), KnownHostsFile: knownHosts},
+			},
+			expectErr: true,
+		},
+		{
+			name: "ssh authentication with missing known_hosts file",
+			auth: &AuthConfig{
+				SSH: &SSHAuthConfig{
+					User:           "git",
+					PrivateKey:     sshKey,
+					KnownHostsFile: filepath.Join(t.TempDir(), "missing"),
+				},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			method, err := authMethod(t.Context(), tt.auth)
+			if tt.expectErr {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			tt.expectMethod(t, method)
+		})
+	}
+}
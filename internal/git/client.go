@@ -4,15 +4,20 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"path"
 	"runtime"
+	"sort"
+	"strings"
 
 	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-billy/v5/util"
 	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/cache"
-	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/storage/filesystem"
+	"github.com/go-git/go-git/v5/storage/memory"
 )
 
 // Client defines the interface for Git operations
@@ -20,9 +25,17 @@ type Client interface {
 	// Clone clones a repository with the given configuration
 	Clone(ctx context.Context, config *CloneConfig) (*RepositoryInfo, error)
 
+	// ResolveCommit returns the commit SHA the configured reference points to on the
+	// remote, without cloning the repository
+	ResolveCommit(ctx context.Context, config *CloneConfig) (string, error)
+
 	// GetFileContent retrieves the content of a file from the repository
 	GetFileContent(repoInfo *RepositoryInfo, path string) ([]byte, error)
 
+	// ListFiles returns the paths of all files under a directory of the repository,
+	// recursively. An empty directory lists the whole repository.
+	ListFiles(repoInfo *RepositoryInfo, dir string) ([]string, error)
+
 	// Cleanup removes local repository directory
 	Cleanup(ctx context.Context, repoInfo *RepositoryInfo) error
 }
@@ -42,13 +55,11 @@ func (c *defaultGitClient) Clone(ctx context.Context, config *CloneConfig) (*Rep
 	}
 
 	// Configure authentication if provided
-	if config.Auth != nil && config.Auth.Username != "" {
-		cloneOptions.Auth = &githttp.BasicAuth{
-			Username: config.Auth.Username,
-			Password: config.Auth.Password,
-		}
-		slog.Debug("Using Git HTTP Basic authentication", "username", config.Auth.Username)
+	auth, err := authMethod(ctx, config.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure authentication: %w", err)
 	}
+	cloneOptions.Auth = auth
 
 	// Set reference if specified (but not for commit-based clones)
 	if config.Commit == "" {
@@ -120,28 +131,78 @@ func (c *defaultGitClient) Clone(ctx context.Context, config *CloneConfig) (*Rep
 	return repoInfo, nil
 }
 
-// GetFileContent retrieves the content of a file from the repository
-func (*defaultGitClient) GetFileContent(repoInfo *RepositoryInfo, path string) ([]byte, error) {
-	if repoInfo == nil || repoInfo.Repository == nil {
-		return nil, fmt.Errorf("repository is nil")
+// ResolveCommit returns the commit SHA the configured reference points to on the
+// remote. Only the advertised references are listed (the equivalent of git ls-remote),
+// so no objects are downloaded. A configured commit is returned as is.
+func (*defaultGitClient) ResolveCommit(ctx context.Context, config *CloneConfig) (string, error) {
+	if config.Commit != "" {
+		return config.Commit, nil
 	}
 
-	// Get the HEAD reference
-	ref, err := repoInfo.Repository.Head()
+	auth, err := authMethod(ctx, config.Auth)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get HEAD reference: %w", err)
+		return "", fmt.Errorf("failed to configure authentication: %w", err)
 	}
 
-	// Get the commit object
-	commit, err := repoInfo.Repository.CommitObject(ref.Hash())
+	remote := git.NewRemote(memory.NewStorage(), &gitconfig.RemoteConfig{
+		Name: git.DefaultRemoteName,
+		URLs: []string{config.URL},
+	})
+	refs, err := remote.ListContext(ctx, &git.ListOptions{
+		Auth:          auth,
+		PeelingOption: git.AppendPeeled,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get commit object: %w", err)
+		return "", fmt.Errorf("failed to list remote references: %w", err)
 	}
 
-	// Get the tree
-	tree, err := commit.Tree()
+	refName := plumbing.HEAD
+	if config.Branch != "" {
+		refName = plumbing.NewBranchReferenceName(config.Branch)
+	} else if config.Tag != "" {
+		refName = plumbing.NewTagReferenceName(config.Tag)
+	}
+
+	hash, err := resolveRemoteReference(refs, refName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tree: %w", err)
+		return "", err
+	}
+	return hash.String(), nil
+}
+
+// resolveRemoteReference finds the commit a reference points to in a remote
+// reference listing, following symbolic references (e.g. HEAD) and peeling
+// annotated tags to the commit they point to.
+func resolveRemoteReference(refs []*plumbing.Reference, name plumbing.ReferenceName) (plumbing.Hash, error) {
+	byName := make(map[plumbing.ReferenceName]*plumbing.Reference, len(refs))
+	for _, ref := range refs {
+		byName[ref.Name()] = ref
+	}
+
+	// Symbolic references can only point to other references, so the chain is
+	// bounded by the number of references
+	for range len(refs) + 1 {
+		ref, ok := byName[name]
+		if !ok {
+			return plumbing.ZeroHash, fmt.Errorf("reference %s not found on remote", name)
+		}
+		if ref.Type() == plumbing.SymbolicReference {
+			name = ref.Target()
+			continue
+		}
+		if peeled, ok := byName[plumbing.ReferenceName(name.String()+"^{}")]; ok {
+			return peeled.Hash(), nil
+		}
+		return ref.Hash(), nil
+	}
+	return plumbing.ZeroHash, fmt.Errorf("reference %s is a symbolic reference loop", name)
+}
+
+// GetFileContent retrieves the content of a file from the repository
+func (*defaultGitClient) GetFileContent(repoInfo *RepositoryInfo, path string) ([]byte, error) {
+	tree, err := headTree(repoInfo)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get the file
@@ -159,6 +220,62 @@ func (*defaultGitClient) GetFileContent(repoInfo *RepositoryInfo, path string) (
 	return []byte(content), nil
 }
 
+// ListFiles returns the paths of all files under a directory of the repository, recursively
+func (*defaultGitClient) ListFiles(repoInfo *RepositoryInfo, dir string) ([]string, error) {
+	tree, err := headTree(repoInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	dir = strings.Trim(path.Clean(dir), "/")
+	if dir != "." && dir != "" {
+		tree, err = tree.Tree(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get directory %s: %w", dir, err)
+		}
+	} else {
+		dir = ""
+	}
+
+	var files []string
+	err = tree.Files().ForEach(func(file *object.File) error {
+		files = append(files, path.Join(dir, file.Name))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files of %s: %w", dir, err)
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// headTree returns the tree of the commit HEAD points to
+func headTree(repoInfo *RepositoryInfo) (*object.Tree, error) {
+	if repoInfo == nil || repoInfo.Repository == nil {
+		return nil, fmt.Errorf("repository is nil")
+	}
+
+	// Get the HEAD reference
+	ref, err := repoInfo.Repository.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD reference: %w", err)
+	}
+
+	// Get the commit object
+	commit, err := repoInfo.Repository.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit object: %w", err)
+	}
+
+	// Get the tree
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree: %w", err)
+	}
+	return tree, nil
+}
+
 // Cleanup removes local repository directory
 func (*defaultGitClient) Cleanup(_ context.Context, repoInfo *RepositoryInfo) error {
 	if repoInfo == nil || repoInfo.Repository == nil {
@@ -209,6 +326,7 @@ func (*defaultGitClient) updateRepositoryInfo(repoInfo *RepositoryInfo) error {
 	if ref.Name().IsBranch() {
 		repoInfo.Branch = ref.Name().Short()
 	}
+	repoInfo.CommitSHA = ref.Hash().String()
 
 	return nil
 }
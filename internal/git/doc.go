@@ -61,11 +61,12 @@
 //
 // Supported features:
 //   - Public repository access via HTTPS
+//   - Private repository access via HTTP Basic auth, SSH keys verified against
+//     a known_hosts file, or GitHub App installation tokens
 //   - Branch, tag, and commit checkout
 //   - File content retrieval from any path in the repository
 //
 // Planned features:
-//   - Authentication for private repositories
 //   - Webhook support for immediate sync triggers
 //   - Git LFS support for large files
 package git
@@ -0,0 +1,215 @@
+package git
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/stacklok/toolhive-registry-server/internal/httpclient"
+)
+
+const (
+	// githubAppTokenUsername is the user name GitHub expects along with installation tokens
+	githubAppTokenUsername = "x-access-token"
+
+	// githubAppJWTLifetime is the lifetime of the app JWT used to mint installation
+	// tokens. GitHub rejects JWTs valid for more than 10 minutes.
+	githubAppJWTLifetime = 9 * time.Minute
+
+	// githubAppClockSkew backdates the app JWT to tolerate clock drift with GitHub
+	githubAppClockSkew = time.Minute
+
+	// githubAppTokenRefreshMargin is how long before expiry an installation token is refreshed,
+	// so that a token never expires in the middle of a clone
+	githubAppTokenRefreshMargin = 5 * time.Minute
+
+	// maxGitHubAppResponseSize bounds the size of the access token response
+	maxGitHubAppResponseSize = 1024 * 1024
+)
+
+// GitHubAppConfig contains the settings to mint GitHub App installation tokens
+type GitHubAppConfig struct {
+	// AppID is the ID of the GitHub App
+	AppID int64
+	// InstallationID is the ID of the installation the tokens are minted for
+	InstallationID int64
+	// PrivateKey is the PEM encoded private key of the GitHub App
+	PrivateKey []byte
+	// APIURL is the base URL of the GitHub API (e.g., https://api.github.com)
+	APIURL string
+}
+
+// githubAppTokenSource mints GitHub App installation tokens and caches them until
+// shortly before they expire. Installation tokens are valid for one hour.
+type githubAppTokenSource struct {
+	appID          int64
+	installationID int64
+	privateKey     *rsa.PrivateKey
+	apiURL         string
+	httpClient     *http.Client
+	now            func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+var _ TokenSource = (*githubAppTokenSource)(nil)
+
+// NewGitHubAppTokenSource creates a TokenSource that mints installation tokens for a GitHub App
+func NewGitHubAppTokenSource(cfg *GitHubAppConfig) (TokenSource, error) {
+	return newGitHubAppTokenSource(cfg, &http.Client{Timeout: httpclient.DefaultTimeout})
+}
+
+func newGitHubAppTokenSource(cfg *GitHubAppConfig, httpClient *http.Client) (*githubAppTokenSource, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("github app configuration cannot be nil")
+	}
+	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(cfg.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse github app private key: %w", err)
+	}
+
+	return &githubAppTokenSource{
+		appID:          cfg.AppID,
+		installationID: cfg.InstallationID,
+		privateKey:     privateKey,
+		apiURL:         cfg.APIURL,
+		httpClient:     httpClient,
+		now:            time.Now,
+	}, nil
+}
+
+// githubAppTokenSources caches the token sources by app installation and key, so
+// that installation tokens are reused across clones until they need a refresh
+var (
+	githubAppTokenSourcesMu sync.Mutex
+	githubAppTokenSources   = map[string]TokenSource{}
+)
+
+// GitHubAppTokenSource returns the TokenSource for a GitHub App installation,
+// reusing the one created by a previous call with the same configuration.
+func GitHubAppTokenSource(cfg *GitHubAppConfig) (TokenSource, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("github app configuration cannot be nil")
+	}
+	keyDigest := sha256.Sum256(cfg.PrivateKey)
+	cacheKey := fmt.Sprintf("%s|%d|%d|%x", cfg.APIURL, cfg.AppID, cfg.InstallationID, keyDigest)
+
+	githubAppTokenSourcesMu.Lock()
+	defer githubAppTokenSourcesMu.Unlock()
+
+	if source, ok := githubAppTokenSources[cacheKey]; ok {
+		return source, nil
+	}
+	source, err := NewGitHubAppTokenSource(cfg)
+	if err != nil {
+		return nil, err
+	}
+	githubAppTokenSources[cacheKey] = source
+	return source, nil
+}
+
+// Username returns the user name GitHub expects along with installation tokens
+func (*githubAppTokenSource) Username() string {
+	return githubAppTokenUsername
+}
+
+// Token returns the cached installation token, minting a new one if it is
+// missing or about to expire
+func (s *githubAppTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && s.now().Add(githubAppTokenRefreshMargin).Before(s.expiresAt) {
+		return s.token, nil
+	}
+
+	token, expiresAt, err := s.mintInstallationToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	s.token = token
+	s.expiresAt = expiresAt
+
+	slog.Debug("Minted GitHub App installation token",
+		"app_id", s.appID,
+		"installation_id", s.installationID,
+		"expires_at", expiresAt)
+	return token, nil
+}
+
+// githubAccessTokenResponse is the response of the installation access token endpoint
+type githubAccessTokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// mintInstallationToken exchanges a short-lived app JWT for an installation token
+func (s *githubAppTokenSource) mintInstallationToken(ctx context.Context) (string, time.Time, error) {
+	appJWT, err := s.signAppJWT()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	url := fmt.Sprintf("%s/app/installations/%d/access_tokens", s.apiURL, s.installationID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+appJWT)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", httpclient.UserAgent)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to request github app installation token: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxGitHubAppResponseSize))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to read github app installation token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return "", time.Time{}, fmt.Errorf("failed to mint github app installation token: %w",
+			httpclient.NewHTTPError(resp.StatusCode, url, resp.Status))
+	}
+
+	var tokenResp githubAccessTokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to decode github app installation token response: %w", err)
+	}
+	if tokenResp.Token == "" {
+		return "", time.Time{}, fmt.Errorf("github app installation token response contains no token")
+	}
+
+	return tokenResp.Token, tokenResp.ExpiresAt, nil
+}
+
+// signAppJWT creates the JWT that authenticates as the GitHub App itself
+func (s *githubAppTokenSource) signAppJWT() (string, error) {
+	now := s.now()
+	claims := jwt.RegisteredClaims{
+		Issuer:    strconv.FormatInt(s.appID, 10),
+		IssuedAt:  jwt.NewNumericDate(now.Add(-githubAppClockSkew)),
+		ExpiresAt: jwt.NewNumericDate(now.Add(githubAppJWTLifetime)),
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(s.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign github app JWT: %w", err)
+	}
+	return signed, nil
+}
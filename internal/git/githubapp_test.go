@@ -0,0 +1,178 @@
+package git
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func generateTestRSAKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+	return key, keyPEM
+}
+
+// newTestGitHubAPI returns a fake GitHub API that mints installation tokens
+// for installation 42 of app 1234, and counts the minted tokens
+func newTestGitHubAPI(t *testing.T, key *rsa.PrivateKey, expiresIn time.Duration) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var minted atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/app/installations/42/access_tokens" {
+			http.NotFound(w, r)
+			return
+		}
+
+		// The app JWT must be signed with the app key and issued by the app ID
+		raw := r.Header.Get("Authorization")
+		token, err := jwt.ParseWithClaims(raw[len("Bearer "):], &jwt.RegisteredClaims{},
+			func(*jwt.Token) (any, error) { return &key.PublicKey, nil },
+			jwt.WithValidMethods([]string{"RS256"}))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if issuer, _ := token.Claims.GetIssuer(); issuer != "1234" {
+			http.Error(w, "wrong issuer", http.StatusUnauthorized)
+			return
+		}
+
+		n := minted.Add(1)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"token":      fmt.Sprintf("ghs_token%d", n),
+			"expires_at": time.Now().Add(expiresIn).UTC().Format(time.RFC3339),
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server, &minted
+}
+
+func TestGitHubAppTokenSource_Token(t *testing.T) {
+	t.Parallel()
+
+	key, keyPEM := generateTestRSAKey(t)
+	server, minted := newTestGitHubAPI(t, key, time.Hour)
+
+	source, err := newGitHubAppTokenSource(&GitHubAppConfig{
+		AppID:          1234,
+		InstallationID: 42,
+		PrivateKey:     keyPEM,
+		APIURL:         server.URL,
+	}, server.Client())
+	if err != nil {
+		t.Fatalf("failed to create token source: %v", err)
+	}
+
+	if source.Username() != "x-access-token" {
+		t.Errorf("Expected username x-access-token, got %s", source.Username())
+	}
+
+	first, err := source.Token(t.Context())
+	if err != nil {
+		t.Fatalf("failed to get token: %v", err)
+	}
+	if first != "ghs_token1" {
+		t.Errorf("Expected ghs_token1, got %s", first)
+	}
+
+	// The token is cached while it is valid
+	second, err := source.Token(t.Context())
+	if err != nil {
+		t.Fatalf("failed to get token: %v", err)
+	}
+	if second != first || minted.Load() != 1 {
+		t.Errorf("Expected cached token, got %s after %d mints", second, minted.Load())
+	}
+
+	// The token is refreshed shortly before it expires
+	source.now = func() time.Time { return time.Now().Add(56 * time.Minute) }
+	third, err := source.Token(t.Context())
+	if err != nil {
+		t.Fatalf("failed to refresh token: %v", err)
+	}
+	if third != "ghs_token2" || minted.Load() != 2 {
+		t.Errorf("Expected refreshed token ghs_token2, got %s after %d mints", third, minted.Load())
+	}
+}
+
+func TestGitHubAppTokenSource_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, keyPEM := generateTestRSAKey(t)
+	otherKey, _ := generateTestRSAKey(t)
+
+	// The fake API only accepts JWTs signed with another key
+	server, _ := newTestGitHubAPI(t, otherKey, time.Hour)
+
+	source, err := newGitHubAppTokenSource(&GitHubAppConfig{
+		AppID:          1234,
+		InstallationID: 42,
+		PrivateKey:     keyPEM,
+		APIURL:         server.URL,
+	}, server.Client())
+	if err != nil {
+		t.Fatalf("failed to create token source: %v", err)
+	}
+	if _, err := source.Token(t.Context()); err == nil {
+		t.Error("Expected error for rejected app JWT, got nil")
+	}
+
+	if _, err := NewGitHubAppTokenSource(&GitHubAppConfig{PrivateKey: []byte("not a key")}); err == nil {
+		t.Error("Expected error for invalid private key, got nil")
+	}
+}
+
+func TestGitHubAppTokenSource_Shared(t *testing.T) {
+	t.Parallel()
+
+	_, keyPEM := generateTestRSAKey(t)
+	cfg := &GitHubAppConfig{
+		AppID:          1234,
+		InstallationID: 42,
+		PrivateKey:     keyPEM,
+		APIURL:         "https://api.github.example",
+	}
+
+	first, err := GitHubAppTokenSource(cfg)
+	if err != nil {
+		t.Fatalf("failed to create token source: %v", err)
+	}
+	second, err := GitHubAppTokenSource(cfg)
+	if err != nil {
+		t.Fatalf("failed to create token source: %v", err)
+	}
+	if first != second {
+		t.Error("Expected the token source to be reused for the same configuration")
+	}
+
+	other, err := GitHubAppTokenSource(&GitHubAppConfig{
+		AppID:          1234,
+		InstallationID: 43,
+		PrivateKey:     keyPEM,
+		APIURL:         "https://api.github.example",
+	})
+	if err != nil {
+		t.Fatalf("failed to create token source: %v", err)
+	}
+	if other == first {
+		t.Error("Expected a separate token source for another installation")
+	}
+}
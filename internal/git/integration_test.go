@@ -1,10 +1,12 @@
 package git
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-logr/logr"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -246,3 +248,187 @@ func TestDefaultGitClient_UpdateRepositoryInfo(t *testing.T) {
 		t.Errorf("Expected Branch to be %q, got %s", mainBranchName, repoInfo.Branch)
 	}
 }
+
+// TestDefaultGitClient_ListFiles tests listing the files of a cloned repository
+func TestDefaultGitClient_ListFiles(t *testing.T) {
+	t.Parallel()
+
+	sourceRepoDir, cleanup := CreateTestRepo(t, TestRepoConfig{
+		Files: map[string]string{
+			"registry.json":               "{}",
+			"servers/alpha/server.json":   "{}",
+			"servers/beta/server.json":    "{}",
+			"servers/beta/README.md":      "beta",
+			"skills/gamma/skill.json":     "{}",
+			"skills/gamma/docs/usage.txt": "usage",
+		},
+	})
+	t.Cleanup(cleanup)
+
+	client := NewDefaultGitClient()
+	ctx := log.IntoContext(t.Context(), logr.Discard())
+	repoInfo, err := client.Clone(ctx, &CloneConfig{URL: sourceRepoDir})
+	if err != nil {
+		t.Fatalf("Failed to clone repository: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Cleanup(ctx, repoInfo) })
+
+	tests := []struct {
+		name      string
+		dir       string
+		expected  []string
+		expectErr bool
+	}{
+		{
+			name: "whole repository",
+			dir:  "",
+			expected: []string{
+				"registry.json",
+				"servers/alpha/server.json",
+				"servers/beta/README.md",
+				"servers/beta/server.json",
+				"skills/gamma/docs/usage.txt",
+				"skills/gamma/skill.json",
+			},
+		},
+		{
+			name: "directory with trailing slash",
+			dir:  "servers/",
+			expected: []string{
+				"servers/alpha/server.json",
+				"servers/beta/README.md",
+				"servers/beta/server.json",
+			},
+		},
+		{
+			name:     "nested directory",
+			dir:      "skills/gamma",
+			expected: []string{"skills/gamma/docs/usage.txt", "skills/gamma/skill.json"},
+		},
+		{
+			name:      "missing directory",
+			dir:       "plugins",
+			expectErr: true,
+		},
+	}
+
+	// The cases share the cloned repository, so they run sequentially
+	for _, tt := range tests {
+		files, err := client.ListFiles(repoInfo, tt.dir)
+		if tt.expectErr {
+			if err == nil {
+				t.Errorf("%s: expected error, got files %v", tt.name, files)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: failed to list files: %v", tt.name, err)
+		}
+		if strings.Join(files, ",") != strings.Join(tt.expected, ",") {
+			t.Errorf("%s: expected files %v, got %v", tt.name, tt.expected, files)
+		}
+	}
+}
+
+// TestDefaultGitClient_ResolveCommit tests resolving remote references without cloning
+func TestDefaultGitClient_ResolveCommit(t *testing.T) {
+	t.Parallel()
+
+	commits := []TestRepoConfig{
+		{Files: map[string]string{"file1.txt": "first commit"}},
+		{Files: map[string]string{"file2.txt": "second commit"}},
+	}
+	sourceRepoDir, commitHashes, cleanup := CreateTestRepoWithCommits(t, commits)
+	t.Cleanup(cleanup)
+
+	// Tag the first commit with an annotated tag and the second with a lightweight tag
+	repo, err := git.PlainOpen(sourceRepoDir)
+	if err != nil {
+		t.Fatalf("Failed to open repository: %v", err)
+	}
+	_, err = repo.CreateTag("v1.0.0", commitHashes[0], &git.CreateTagOptions{
+		Tagger:  &object.Signature{Name: "Test Author", Email: "test@example.com"},
+		Message: "Release v1.0.0",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create annotated tag: %v", err)
+	}
+	if _, err := repo.CreateTag("v2.0.0", commitHashes[1], nil); err != nil {
+		t.Fatalf("Failed to create lightweight tag: %v", err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("Failed to get HEAD: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		config    *CloneConfig
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "default branch",
+			config:   &CloneConfig{URL: sourceRepoDir},
+			expected: commitHashes[1].String(),
+		},
+		{
+			name:     "branch",
+			config:   &CloneConfig{URL: sourceRepoDir, Branch: head.Name().Short()},
+			expected: commitHashes[1].String(),
+		},
+		{
+			name:     "annotated tag is peeled to its commit",
+			config:   &CloneConfig{URL: sourceRepoDir, Tag: "v1.0.0"},
+			expected: commitHashes[0].String(),
+		},
+		{
+			name:     "lightweight tag",
+			config:   &CloneConfig{URL: sourceRepoDir, Tag: "v2.0.0"},
+			expected: commitHashes[1].String(),
+		},
+		{
+			name:     "pinned commit is returned as is",
+			config:   &CloneConfig{URL: sourceRepoDir, Commit: "abc123"},
+			expected: "abc123",
+		},
+		{
+			name:      "missing branch",
+			config:    &CloneConfig{URL: sourceRepoDir, Branch: "does-not-exist"},
+			expectErr: true,
+		},
+	}
+
+	client := NewDefaultGitClient()
+	ctx := log.IntoContext(t.Context(), logr.Discard())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			commitSHA, err := client.ResolveCommit(ctx, tt.config)
+			if tt.expectErr {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to resolve commit: %v", err)
+			}
+			if commitSHA != tt.expected {
+				t.Errorf("Expected commit %s, got %s", tt.expected, commitSHA)
+			}
+		})
+	}
+
+	// The commit resolved from the remote matches the commit of a clone
+	repoInfo, err := client.Clone(ctx, &CloneConfig{URL: sourceRepoDir})
+	if err != nil {
+		t.Fatalf("Failed to clone: %v", err)
+	}
+	defer func() {
+		_ = client.Cleanup(ctx, repoInfo)
+	}()
+	if repoInfo.CommitSHA != commitHashes[1].String() {
+		t.Errorf("Expected clone commit %s, got %s", commitHashes[1], repoInfo.CommitSHA)
+	}
+}
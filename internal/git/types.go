@@ -1,6 +1,8 @@
 package git
 
 import (
+	"context"
+
 	billy "github.com/go-git/go-billy/v5"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing/cache"
@@ -24,12 +26,39 @@ type CloneConfig struct {
 	Auth *AuthConfig
 }
 
-// AuthConfig contains authentication credentials for Git operations
+// AuthConfig contains authentication credentials for Git operations.
+// Only one of HTTP Basic (Username and Password), SSH, or TokenSource is used,
+// in that order of precedence: SSH, TokenSource, HTTP Basic.
 type AuthConfig struct {
 	// Username is the Git username for HTTP Basic authentication
 	Username string
 	// Password is the Git password or personal access token
 	Password string
+	// SSH contains the credentials for SSH private key authentication
+	SSH *SSHAuthConfig
+	// TokenSource provides short-lived tokens for HTTP authentication,
+	// such as GitHub App installation tokens
+	TokenSource TokenSource
+}
+
+// SSHAuthConfig contains the credentials for SSH private key authentication
+type SSHAuthConfig struct {
+	// User is the SSH user name (e.g., "git")
+	User string
+	// PrivateKey is the PEM encoded private key
+	PrivateKey []byte
+	// Passphrase decrypts the private key if it is encrypted
+	Passphrase string
+	// KnownHostsFile is the path to the known_hosts file used to verify the server host key
+	KnownHostsFile string
+}
+
+// TokenSource provides tokens for HTTP authentication against Git servers
+type TokenSource interface {
+	// Token returns a valid token, refreshing it if needed
+	Token(ctx context.Context) (string, error)
+	// Username returns the user name to send along with the token
+	Username() string
 }
 
 // RepositoryInfo contains information about a Git repository
@@ -43,6 +72,9 @@ type RepositoryInfo struct {
 	// RemoteURL is the remote repository URL
 	RemoteURL string
 
+	// CommitSHA is the SHA of the checked out commit
+	CommitSHA string
+
 	// storerFilesystem holds the in-memory filesystem containing the Git object database (.git/objects).
 	// This reference is stored during Clone() and must be explicitly cleared in Cleanup() to release
 	// memory, as go-git does not provide automatic cleanup of internal storage structures.
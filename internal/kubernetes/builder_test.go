@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	toolhivetypes "github.com/stacklok/toolhive-core/registry/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -22,6 +23,12 @@ func (*mockSyncWriter) Store(_ context.Context, _ string, _ *toolhivetypes.Upstr
 	return nil
 }
 
+func (*mockSyncWriter) Merge(
+	_ context.Context, _ string, _ *toolhivetypes.UpstreamRegistry, _ []upstreamv0.ServerJSON, _ ...writer.StoreOption,
+) (*writer.MergeResult, error) {
+	return &writer.MergeResult{}, nil
+}
+
 func TestWithNamespaces(t *testing.T) {
 	t.Parallel()
 
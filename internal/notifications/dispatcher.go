@@ -0,0 +1,298 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+)
+
+const (
+	// Headers of webhook deliveries
+	headerEvent     = "X-Toolhive-Event"
+	headerDelivery  = "X-Toolhive-Delivery"
+	headerSignature = "X-Toolhive-Signature-256"
+
+	userAgent = "toolhive-registry-server"
+
+	// pollInterval is how often the dispatcher checks for due deliveries
+	pollInterval = 5 * time.Second
+	// pruneInterval is how often deliveries past the retention period are pruned
+	pruneInterval = time.Hour
+	// batchSize is the maximum number of deliveries sent in parallel
+	batchSize = 10
+	// leaseMargin is added to the longest delivery timeout to get the lease of
+	// claimed deliveries, covering the time needed to record their outcome
+	leaseMargin = time.Minute
+
+	// baseBackoff is the delay before the second attempt of a delivery. It is
+	// doubled after every failed attempt, up to maxBackoff.
+	baseBackoff = 30 * time.Second
+	maxBackoff  = time.Hour
+
+	// maxErrorLength bounds the error message recorded for a failed attempt
+	maxErrorLength = 512
+	// maxResponseBodySize bounds the response body read from the webhook
+	maxResponseBodySize = 64 << 10
+)
+
+// webhook is a configured webhook with its secret resolved.
+type webhook struct {
+	name        string
+	url         string
+	secret      string
+	timeout     time.Duration
+	maxAttempts int
+}
+
+// Dispatcher delivers the events queued by an Outbox to the webhooks. It is
+// safe to run a dispatcher on every instance of the server: deliveries are
+// claimed with a lease, so that each attempt is made by a single instance.
+type Dispatcher struct {
+	querier   sqlc.Querier
+	webhooks  map[string]*webhook
+	client    *http.Client
+	retention time.Duration
+	lease     time.Duration
+
+	// pollInterval overrides the default poll interval (for testing)
+	pollInterval time.Duration
+}
+
+// NewDispatcher creates a dispatcher for the configured webhooks, reading their
+// secrets. querier must not be bound to a transaction.
+func NewDispatcher(querier sqlc.Querier, cfg *config.Config) (*Dispatcher, error) {
+	if querier == nil {
+		return nil, fmt.Errorf("querier is required")
+	}
+
+	configs := cfg.GetWebhooks()
+	webhooks := make(map[string]*webhook, len(configs))
+	var longestTimeout time.Duration
+	for i := range configs {
+		webhookCfg := &configs[i]
+		secret, err := webhookCfg.GetSecret()
+		if err != nil {
+			return nil, fmt.Errorf("webhook %s: %w", webhookCfg.Name, err)
+		}
+		if secret == "" {
+			return nil, fmt.Errorf("webhook %s: secret is empty", webhookCfg.Name)
+		}
+		webhooks[webhookCfg.Name] = &webhook{
+			name:        webhookCfg.Name,
+			url:         webhookCfg.URL,
+			secret:      secret,
+			timeout:     webhookCfg.GetTimeout(),
+			maxAttempts: webhookCfg.GetMaxAttempts(),
+		}
+		longestTimeout = max(longestTimeout, webhookCfg.GetTimeout())
+	}
+
+	return &Dispatcher{
+		querier:      querier,
+		webhooks:     webhooks,
+		client:       &http.Client{},
+		retention:    cfg.GetNotificationDeliveryRetention(),
+		lease:        longestTimeout + leaseMargin,
+		pollInterval: pollInterval,
+	}, nil
+}
+
+// Start delivers due events until the context is cancelled.
+func (d *Dispatcher) Start(ctx context.Context) {
+	slog.Info("Starting webhook dispatcher", "webhooks", len(d.webhooks))
+
+	poll := time.NewTicker(d.pollInterval)
+	defer poll.Stop()
+	prune := time.NewTicker(pruneInterval)
+	defer prune.Stop()
+
+	d.dispatch(ctx)
+	d.prune(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Webhook dispatcher stopped")
+			return
+		case <-poll.C:
+			d.dispatch(ctx)
+		case <-prune.C:
+			d.prune(ctx)
+		}
+	}
+}
+
+// dispatch sends the due deliveries, batch after batch, until none is left.
+func (d *Dispatcher) dispatch(ctx context.Context) {
+	for ctx.Err() == nil {
+		deliveries, err := d.querier.ClaimWebhookDeliveries(ctx, sqlc.ClaimWebhookDeliveriesParams{
+			LeasedUntil: time.Now().Add(d.lease),
+			Size:        batchSize,
+		})
+		if err != nil {
+			slog.Error("Failed to claim webhook deliveries", "error", err)
+			return
+		}
+
+		var wg sync.WaitGroup
+		for i := range deliveries {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				d.deliver(ctx, &deliveries[i])
+			}()
+		}
+		wg.Wait()
+
+		if len(deliveries) < batchSize {
+			return
+		}
+	}
+}
+
+// deliver makes a delivery attempt and records its outcome.
+func (d *Dispatcher) deliver(ctx context.Context, delivery *sqlc.ClaimWebhookDeliveriesRow) {
+	hook, ok := d.webhooks[delivery.Webhook]
+	if !ok {
+		// The webhook was removed from the configuration since the event was queued
+		lastError := "webhook is no longer configured"
+		d.recordAttempt(ctx, sqlc.UpdateWebhookDeliveryAttemptParams{
+			ID:            delivery.ID,
+			Status:        sqlc.WebhookDeliveryStatusFAILED,
+			LastError:     &lastError,
+			NextAttemptAt: time.Now(),
+		})
+		return
+	}
+
+	statusCode, sendErr := hook.send(ctx, d.client, delivery.ID, delivery.EventType, delivery.Payload)
+	if ctx.Err() != nil {
+		// The server is stopping; the delivery is retried when the lease expires
+		return
+	}
+	d.recordAttempt(ctx, attemptOutcome(delivery, hook.maxAttempts, statusCode, sendErr, time.Now()))
+	if sendErr != nil {
+		slog.Warn("Webhook delivery failed",
+			"webhook", hook.name,
+			"event", delivery.EventType,
+			"delivery", delivery.ID,
+			"attempt", delivery.Attempts+1,
+			"error", sendErr)
+	}
+}
+
+// recordAttempt stores the outcome of a delivery attempt. Failures are logged
+// only: the delivery is attempted again when its lease expires.
+func (d *Dispatcher) recordAttempt(ctx context.Context, params sqlc.UpdateWebhookDeliveryAttemptParams) {
+	if err := d.querier.UpdateWebhookDeliveryAttempt(ctx, params); err != nil {
+		slog.Error("Failed to record webhook delivery attempt",
+			"delivery", params.ID,
+			"error", err)
+	}
+}
+
+// prune deletes the deliveries past the retention period.
+func (d *Dispatcher) prune(ctx context.Context) {
+	if err := d.querier.DeleteWebhookDeliveriesBefore(ctx, time.Now().Add(-d.retention)); err != nil {
+		slog.Error("Failed to prune webhook deliveries", "error", err)
+	}
+}
+
+// attemptOutcome returns the outcome of a delivery attempt made at now. Failed
+// deliveries are retried with exponential backoff until maxAttempts is reached.
+func attemptOutcome(
+	delivery *sqlc.ClaimWebhookDeliveriesRow, maxAttempts, statusCode int, sendErr error, now time.Time,
+) sqlc.UpdateWebhookDeliveryAttemptParams {
+	params := sqlc.UpdateWebhookDeliveryAttemptParams{
+		ID:            delivery.ID,
+		Status:        sqlc.WebhookDeliveryStatusDELIVERED,
+		NextAttemptAt: now,
+	}
+	if statusCode != 0 {
+		code := int32(statusCode) //nolint:gosec // HTTP status codes fit in int32
+		params.ResponseStatus = &code
+	}
+	if sendErr == nil {
+		params.DeliveredAt = &now
+		return params
+	}
+
+	lastError := sendErr.Error()
+	if len(lastError) > maxErrorLength {
+		lastError = lastError[:maxErrorLength]
+	}
+	params.LastError = &lastError
+
+	attempts := int(delivery.Attempts) + 1
+	if attempts >= maxAttempts {
+		params.Status = sqlc.WebhookDeliveryStatusFAILED
+		return params
+	}
+	params.Status = sqlc.WebhookDeliveryStatusPENDING
+	params.NextAttemptAt = now.Add(backoff(attempts))
+	return params
+}
+
+// backoff returns the delay before the next attempt of a delivery that failed
+// the given number of times.
+func backoff(failures int) time.Duration {
+	delay := baseBackoff
+	for i := 1; i < failures && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxBackoff)
+}
+
+// send posts a delivery to the webhook. It returns the status code of the
+// response, if any, and an error unless the webhook accepted the delivery
+// with a 2xx status.
+func (w *webhook) send(
+	ctx context.Context, client *http.Client, id uuid.UUID, eventType string, payload []byte,
+) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(headerEvent, eventType)
+	req.Header.Set(headerDelivery, id.String())
+	req.Header.Set(headerSignature, "sha256="+Sign(w.secret, payload))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Drain the body so that the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBodySize))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature of a delivery payload: the hex encoded
+// HMAC-SHA256 of the payload keyed with the webhook secret. Deliveries carry it
+// in the X-Toolhive-Signature-256 header, prefixed with "sha256=".
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
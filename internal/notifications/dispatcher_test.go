@@ -0,0 +1,258 @@
+package notifications
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/database"
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+)
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 30*time.Second, backoff(1))
+	assert.Equal(t, time.Minute, backoff(2))
+	assert.Equal(t, 4*time.Minute, backoff(4))
+	assert.Equal(t, time.Hour, backoff(8))
+	assert.Equal(t, time.Hour, backoff(100))
+}
+
+func TestAttemptOutcome(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 5, 4, 12, 0, 0, 0, time.UTC)
+	id := uuid.New()
+
+	tests := []struct {
+		name           string
+		attempts       int32
+		statusCode     int
+		sendErr        error
+		wantStatus     sqlc.WebhookDeliveryStatus
+		wantNext       time.Time
+		wantDelivered  bool
+		wantLastError  string
+		wantRespStatus int32
+	}{
+		{
+			name:           "delivered",
+			statusCode:     http.StatusNoContent,
+			wantStatus:     sqlc.WebhookDeliveryStatusDELIVERED,
+			wantNext:       now,
+			wantDelivered:  true,
+			wantRespStatus: http.StatusNoContent,
+		},
+		{
+			name:           "rejected delivery is retried",
+			attempts:       1,
+			statusCode:     http.StatusInternalServerError,
+			sendErr:        errors.New("unexpected status 500"),
+			wantStatus:     sqlc.WebhookDeliveryStatusPENDING,
+			wantNext:       now.Add(time.Minute),
+			wantLastError:  "unexpected status 500",
+			wantRespStatus: http.StatusInternalServerError,
+		},
+		{
+			name:          "unreachable webhook is retried",
+			sendErr:       errors.New("connection refused"),
+			wantStatus:    sqlc.WebhookDeliveryStatusPENDING,
+			wantNext:      now.Add(30 * time.Second),
+			wantLastError: "connection refused",
+		},
+		{
+			name:          "delivery fails after the last attempt",
+			attempts:      2,
+			sendErr:       errors.New("connection refused"),
+			wantStatus:    sqlc.WebhookDeliveryStatusFAILED,
+			wantNext:      now,
+			wantLastError: "connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			delivery := &sqlc.ClaimWebhookDeliveriesRow{ID: id, Attempts: tt.attempts}
+			params := attemptOutcome(delivery, 3, tt.statusCode, tt.sendErr, now)
+
+			assert.Equal(t, id, params.ID)
+			assert.Equal(t, tt.wantStatus, params.Status)
+			assert.Equal(t, tt.wantNext, params.NextAttemptAt)
+			assert.Equal(t, tt.wantDelivered, params.DeliveredAt != nil)
+			if tt.wantLastError != "" {
+				require.NotNil(t, params.LastError)
+				assert.Equal(t, tt.wantLastError, *params.LastError)
+			} else {
+				assert.Nil(t, params.LastError)
+			}
+			if tt.wantRespStatus != 0 {
+				require.NotNil(t, params.ResponseStatus)
+				assert.Equal(t, tt.wantRespStatus, *params.ResponseStatus)
+			} else {
+				assert.Nil(t, params.ResponseStatus)
+			}
+		})
+	}
+}
+
+func TestWebhookSend(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New()
+	payload := []byte(`{"type":"entry.publish"}`)
+
+	tests := []struct {
+		name       string
+		statusCode int
+		wantErr    string
+	}{
+		{name: "accepted", statusCode: http.StatusAccepted},
+		{name: "rejected", statusCode: http.StatusBadRequest, wantErr: "unexpected status 400"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, payload, body)
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				assert.Equal(t, "entry.publish", r.Header.Get(headerEvent))
+				assert.Equal(t, id.String(), r.Header.Get(headerDelivery))
+				assert.Equal(t, "sha256="+Sign("s3cret", payload), r.Header.Get(headerSignature))
+				w.WriteHeader(tt.statusCode)
+			}))
+			t.Cleanup(server.Close)
+
+			hook := &webhook{name: "catalog", url: server.URL, secret: "s3cret", timeout: time.Second}
+			statusCode, err := hook.send(t.Context(), server.Client(), id, "entry.publish", payload)
+			assert.Equal(t, tt.statusCode, statusCode)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	// HMAC-SHA256 test vector from RFC 4231, test case 2
+	assert.Equal(t,
+		"5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843",
+		Sign("Jefe", []byte("what do ya want for nothing?")))
+}
+
+func TestNewDispatcher(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "secret")
+	require.NoError(t, os.WriteFile(secretFile, []byte("s3cret\n"), 0600))
+	emptySecretFile := filepath.Join(dir, "empty")
+	require.NoError(t, os.WriteFile(emptySecretFile, nil, 0600))
+
+	newConfig := func(webhooks ...config.WebhookConfig) *config.Config {
+		return &config.Config{Notifications: &config.NotificationsConfig{Webhooks: webhooks}}
+	}
+
+	_, err := NewDispatcher(nil, newConfig())
+	require.EqualError(t, err, "querier is required")
+
+	_, err = NewDispatcher(sqlc.New(nil), newConfig(config.WebhookConfig{Name: "catalog", SecretFile: emptySecretFile}))
+	require.EqualError(t, err, "webhook catalog: secret is empty")
+
+	dispatcher, err := NewDispatcher(sqlc.New(nil), newConfig(
+		config.WebhookConfig{Name: "catalog", URL: "https://hooks.example.com", SecretFile: secretFile},
+		config.WebhookConfig{Name: "slow", URL: "https://slow.example.com", SecretFile: secretFile, Timeout: "2m"},
+	))
+	require.NoError(t, err)
+	assert.Len(t, dispatcher.webhooks, 2)
+	assert.Equal(t, "s3cret", dispatcher.webhooks["catalog"].secret)
+	assert.Equal(t, 3*time.Minute, dispatcher.lease)
+	assert.Equal(t, config.DefaultNotificationDeliveryRetention, dispatcher.retention)
+}
+
+func TestDispatcher_Dispatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		statusCode   int
+		wantStatus   sqlc.WebhookDeliveryStatus
+		wantAttempts int32
+	}{
+		{name: "delivered", statusCode: http.StatusOK, wantStatus: sqlc.WebhookDeliveryStatusDELIVERED, wantAttempts: 1},
+		{name: "retried", statusCode: http.StatusServiceUnavailable, wantStatus: sqlc.WebhookDeliveryStatusPENDING, wantAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			received := make(chan *http.Request, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received <- r
+				w.WriteHeader(tt.statusCode)
+			}))
+			t.Cleanup(server.Close)
+
+			secretFile := filepath.Join(t.TempDir(), "secret")
+			require.NoError(t, os.WriteFile(secretFile, []byte("s3cret"), 0600))
+			cfg := &config.Config{Notifications: &config.NotificationsConfig{
+				Webhooks: []config.WebhookConfig{{Name: "catalog", URL: server.URL, SecretFile: secretFile}},
+			}}
+
+			db, cleanupFunc := database.SetupTestDB(t)
+			t.Cleanup(cleanupFunc)
+			queries := sqlc.New(db)
+
+			outbox, err := NewOutbox(cfg)
+			require.NoError(t, err)
+			require.NoError(t, outbox.Enqueue(t.Context(), queries, NewEntryEvent(EventEntryPublish, EntryData{
+				Source:    "internal",
+				EntryType: "server",
+				Name:      "com.example/weather",
+				Version:   "1.0.0",
+			})))
+
+			dispatcher, err := NewDispatcher(queries, cfg)
+			require.NoError(t, err)
+			dispatcher.dispatch(t.Context())
+
+			select {
+			case r := <-received:
+				assert.Equal(t, EventEntryPublish, r.Header.Get(headerEvent))
+			default:
+				t.Fatal("webhook was not called")
+			}
+
+			deliveries, err := queries.ListWebhookDeliveries(t.Context(), sqlc.ListWebhookDeliveriesParams{Size: 10})
+			require.NoError(t, err)
+			require.Len(t, deliveries, 1)
+			assert.Equal(t, tt.wantStatus, deliveries[0].Status)
+			assert.Equal(t, tt.wantAttempts, deliveries[0].Attempts)
+			require.NotNil(t, deliveries[0].ResponseStatus)
+			assert.Equal(t, int32(tt.statusCode), *deliveries[0].ResponseStatus) //nolint:gosec // HTTP status code
+			if tt.wantStatus == sqlc.WebhookDeliveryStatusPENDING {
+				assert.True(t, deliveries[0].NextAttemptAt.After(time.Now()))
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+// Package notifications delivers catalog and sync events to outbound webhooks.
+//
+// Events are queued in the webhook_delivery table by the transaction of the
+// operation that caused them (see Outbox), so that webhooks are notified if and
+// only if the operation committed. The Dispatcher then delivers the queued
+// events as signed JSON POST requests and retries failed deliveries with
+// exponential backoff. The table doubles as the delivery log of the webhooks.
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	auditmw "github.com/stacklok/toolhive-registry-server/internal/audit"
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/status"
+)
+
+// Event types delivered to webhooks. They are named after the audit events of
+// the operations that cause them.
+const (
+	// EventSourceSync is sent when the sync of a source fails or changes entries
+	EventSourceSync = auditmw.EventSourceSync
+	// EventEntryPublish is sent when an entry version is published
+	EventEntryPublish = auditmw.EventEntryPublish
+	// EventEntryDelete is sent when a published entry version is deleted
+	EventEntryDelete = auditmw.EventEntryDelete
+	// EventEntryStatus is sent when a server version is deprecated, yanked or made active again
+	EventEntryStatus = auditmw.EventEntryStatus
+)
+
+// EventTypes lists the event types that webhooks can subscribe to.
+var EventTypes = []string{EventSourceSync, EventEntryPublish, EventEntryDelete, EventEntryStatus}
+
+// Event is the body of a webhook delivery.
+type Event struct {
+	// Type is one of EventTypes
+	Type string `json:"type"`
+	// OccurredAt is when the operation that caused the event was made
+	OccurredAt time.Time `json:"occurredAt"`
+	// Data is an EntryData for entry events and a SyncData for sync events
+	Data any `json:"data"`
+}
+
+// EntryData describes the entry version of an entry event.
+type EntryData struct {
+	Source    string `json:"source"`              // managed source holding the entry
+	EntryType string `json:"entryType"`           // server, skill or plugin
+	Namespace string `json:"namespace,omitempty"` // skills and plugins only
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	// Status is the new status of the version, for entry.status.update events only
+	Status string `json:"status,omitempty"`
+}
+
+// SyncData describes the sync attempt of a source.sync event.
+type SyncData struct {
+	Source          string                  `json:"source"`
+	Status          string                  `json:"status"` // complete or failed
+	Reason          string                  `json:"reason"`
+	ConditionReason string                  `json:"conditionReason,omitempty"`
+	Message         string                  `json:"message,omitempty"`
+	Changes         service.SyncChangesInfo `json:"changes"`
+}
+
+// NewEntryEvent returns an entry event of the given type that occurred now.
+func NewEntryEvent(eventType string, data EntryData) *Event {
+	return &Event{
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+}
+
+// NewSyncEvent returns the source.sync event of a sync attempt of source, or
+// nil when the attempt is not worth a notification: only failed attempts and
+// attempts that changed entries are.
+func NewSyncEvent(source string, attempt *status.SyncAttempt) *Event {
+	changes := attempt.Changes
+	if attempt.Phase != status.SyncPhaseFailed && !hasChanges(changes) {
+		return nil
+	}
+
+	syncStatus := "complete"
+	if attempt.Phase == status.SyncPhaseFailed {
+		syncStatus = "failed"
+	}
+	return &Event{
+		Type:       EventSourceSync,
+		OccurredAt: attempt.EndedAt.UTC(),
+		Data: SyncData{
+			Source:          source,
+			Status:          syncStatus,
+			Reason:          attempt.Reason,
+			ConditionReason: attempt.ConditionReason,
+			Message:         attempt.Message,
+			Changes: service.SyncChangesInfo{
+				Servers: service.EntryChangesInfo(changes.Servers),
+				Skills:  service.EntryChangesInfo(changes.Skills),
+				Plugins: service.EntryChangesInfo(changes.Plugins),
+			},
+		},
+	}
+}
+
+// hasChanges reports whether a sync changed any entry version.
+func hasChanges(changes status.SyncChanges) bool {
+	var none status.EntryChanges
+	return changes.Servers != none || changes.Skills != none || changes.Plugins != none
+}
+
+// Outbox queues events for the webhooks that subscribed to them. A nil Outbox
+// queues nothing, so that callers need no check when no webhook is configured.
+type Outbox struct {
+	// subscribers holds the names of the webhooks subscribed to each event type
+	subscribers map[string][]string
+}
+
+// NewOutbox creates the outbox of the configured webhooks. It returns nil when
+// no webhook is configured, and an error when a webhook subscribes to an
+// unknown event type.
+func NewOutbox(cfg *config.Config) (*Outbox, error) {
+	webhooks := cfg.GetWebhooks()
+	if len(webhooks) == 0 {
+		return nil, nil
+	}
+
+	subscribers := make(map[string][]string, len(EventTypes))
+	for _, webhook := range webhooks {
+		for _, event := range webhook.Events {
+			if !slices.Contains(EventTypes, event) {
+				return nil, fmt.Errorf("webhook %s: unsupported event type %q, must be one of %v",
+					webhook.Name, event, EventTypes)
+			}
+		}
+		for _, event := range EventTypes {
+			if len(webhook.Events) == 0 || slices.Contains(webhook.Events, event) {
+				subscribers[event] = append(subscribers[event], webhook.Name)
+			}
+		}
+	}
+
+	return &Outbox{subscribers: subscribers}, nil
+}
+
+// Enqueue queues the delivery of an event to the webhooks subscribed to its
+// type. A nil event is ignored. querier must belong to the transaction of the operation that caused
+// the event.
+func (o *Outbox) Enqueue(ctx context.Context, querier sqlc.Querier, event *Event) error {
+	if o == nil || event == nil {
+		return nil
+	}
+	webhooks := o.subscribers[event.Type]
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s event: %w", event.Type, err)
+	}
+	if err := querier.InsertWebhookDeliveries(ctx, sqlc.InsertWebhookDeliveriesParams{
+		EventType: event.Type,
+		Payload:   payload,
+		Webhooks:  webhooks,
+	}); err != nil {
+		return fmt.Errorf("failed to queue %s event: %w", event.Type, err)
+	}
+	return nil
+}
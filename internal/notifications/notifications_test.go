@@ -0,0 +1,167 @@
+package notifications
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/status"
+)
+
+func TestNewOutbox(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		webhooks []config.WebhookConfig
+		want     map[string][]string
+		wantNil  bool
+		errMsg   string
+	}{
+		{
+			name:    "no webhooks",
+			wantNil: true,
+		},
+		{
+			name: "webhooks subscribe to all or some event types",
+			webhooks: []config.WebhookConfig{
+				{Name: "catalog", Events: []string{EventEntryPublish, EventEntryDelete}},
+				{Name: "audit-sink"},
+			},
+			want: map[string][]string{
+				EventSourceSync:   {"audit-sink"},
+				EventEntryPublish: {"catalog", "audit-sink"},
+				EventEntryDelete:  {"catalog", "audit-sink"},
+				EventEntryStatus:  {"audit-sink"},
+			},
+		},
+		{
+			name:     "unknown event type",
+			webhooks: []config.WebhookConfig{{Name: "catalog", Events: []string{"source.create"}}},
+			errMsg:   `webhook catalog: unsupported event type "source.create"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			outbox, err := NewOutbox(&config.Config{
+				Notifications: &config.NotificationsConfig{Webhooks: tt.webhooks},
+			})
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantNil {
+				assert.Nil(t, outbox)
+				return
+			}
+			assert.Equal(t, tt.want, outbox.subscribers)
+		})
+	}
+}
+
+func TestNewSyncEvent(t *testing.T) {
+	t.Parallel()
+
+	endedAt := time.Date(2026, 5, 4, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		attempt *status.SyncAttempt
+		want    string
+	}{
+		{
+			name: "unchanged sync is not notified",
+			attempt: &status.SyncAttempt{
+				Phase:   status.SyncPhaseComplete,
+				Reason:  "up-to-date-no-policy",
+				EndedAt: endedAt,
+			},
+		},
+		{
+			name: "sync with changes",
+			attempt: &status.SyncAttempt{
+				Phase:   status.SyncPhaseComplete,
+				Reason:  "source-data-changed",
+				EndedAt: endedAt,
+				Changes: status.SyncChanges{
+					Servers: status.EntryChanges{Added: 2},
+					Skills:  status.EntryChanges{Removed: 1},
+				},
+			},
+			want: `{
+				"type": "source.sync",
+				"occurredAt": "2026-05-04T12:00:00Z",
+				"data": {
+					"source": "upstream",
+					"status": "complete",
+					"reason": "source-data-changed",
+					"changes": {
+						"servers": {"added": 2, "updated": 0, "removed": 0},
+						"skills": {"added": 0, "updated": 0, "removed": 1},
+						"plugins": {"added": 0, "updated": 0, "removed": 0}
+					}
+				}
+			}`,
+		},
+		{
+			name: "failed sync",
+			attempt: &status.SyncAttempt{
+				Phase:           status.SyncPhaseFailed,
+				Reason:          "source-data-changed",
+				ConditionReason: "FetchFailed",
+				Message:         "connection refused",
+				EndedAt:         endedAt,
+			},
+			want: `{
+				"type": "source.sync",
+				"occurredAt": "2026-05-04T12:00:00Z",
+				"data": {
+					"source": "upstream",
+					"status": "failed",
+					"reason": "source-data-changed",
+					"conditionReason": "FetchFailed",
+					"message": "connection refused",
+					"changes": {
+						"servers": {"added": 0, "updated": 0, "removed": 0},
+						"skills": {"added": 0, "updated": 0, "removed": 0},
+						"plugins": {"added": 0, "updated": 0, "removed": 0}
+					}
+				}
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			event := NewSyncEvent("upstream", tt.attempt)
+			if tt.want == "" {
+				assert.Nil(t, event)
+				return
+			}
+			payload, err := json.Marshal(event)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(payload))
+		})
+	}
+}
+
+func TestOutboxEnqueue_Nil(t *testing.T) {
+	t.Parallel()
+
+	// Neither a nil outbox nor a nil event touches the querier
+	var outbox *Outbox
+	require.NoError(t, outbox.Enqueue(t.Context(), nil, NewEntryEvent(EventEntryPublish, EntryData{})))
+
+	outbox = &Outbox{subscribers: map[string][]string{EventEntryPublish: {"catalog"}}}
+	require.NoError(t, outbox.Enqueue(t.Context(), nil, nil))
+	require.NoError(t, outbox.Enqueue(t.Context(), nil, NewEntryEvent(EventEntryDelete, EntryData{})))
+}
@@ -0,0 +1,198 @@
+// Package pinning pins the OCI packages of the entries of the sources to
+// digests before they are stored, by a sync or a publish.
+//
+// A package is pinned when its reference carries a digest, or when the entry
+// gives the digest of the package. The tags of the other packages are resolved
+// against their OCI registry, with the distribution API, if digest pinning is
+// configured for the source. The sources of the registries requiring digest
+// pinning refuse the entry versions with a package left unpinned.
+package pinning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/distribution/reference"
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	toolhivetypes "github.com/stacklok/toolhive-core/registry/types"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+)
+
+// ErrNotPinned is returned when an entry version of a source requiring digest
+// pinning has an OCI package that is not pinned to a digest
+var ErrNotPinned = errors.New("package is not pinned to a digest")
+
+// Package is an OCI package of an entry version
+type Package struct {
+	// Identifier is the OCI reference of the package
+	Identifier string
+	// Digest is the digest of the package given by the entry, if any
+	Digest string
+}
+
+// PackageDigest is the digest the tag of an OCI package of an entry version
+// resolved to, stored along with the version
+type PackageDigest struct {
+	// Type is the type of the entry: server, skill or plugin
+	Type       string
+	Name       string
+	Version    string
+	Identifier string
+	Digest     string
+}
+
+// Pinner pins the OCI packages of the entries of the sources
+type Pinner struct {
+	resolver *resolver
+	sources  []string
+	required map[string]bool
+}
+
+// NewPinner creates the pinner of a configuration. Returns nil, a pinner
+// applying to no source, when the configuration neither resolves tags nor has
+// a registry requiring digest pinning.
+func NewPinner(cfg *config.Config) *Pinner {
+	required := make(map[string]bool)
+	for _, registry := range cfg.Registries {
+		if !registry.RequireDigestPinning {
+			continue
+		}
+		for _, source := range registry.Sources {
+			required[source] = true
+		}
+	}
+	if cfg.DigestPinning == nil && len(required) == 0 {
+		return nil
+	}
+
+	pinner := &Pinner{required: required}
+	if cfg.DigestPinning != nil {
+		pinner.sources = cfg.DigestPinning.Sources
+		pinner.resolver = &resolver{
+			client: &http.Client{
+				// Registries are only contacted on the allowed hosts: a redirect
+				// could lead the requests anywhere
+				CheckRedirect: func(*http.Request, []*http.Request) error {
+					return http.ErrUseLastResponse
+				},
+			},
+			plainHTTP:    cfg.DigestPinning.PlainHTTPRegistries,
+			allowedHosts: cfg.DigestPinning.GetAllowedHosts(),
+			timeout:      cfg.DigestPinning.GetTimeout(),
+		}
+	}
+	return pinner
+}
+
+// AppliesTo returns whether the packages of the entries of a source are pinned
+func (p *Pinner) AppliesTo(source string) bool {
+	return p.resolves(source) || p.requires(source)
+}
+
+// resolves returns whether the tags of the packages of a source are resolved
+func (p *Pinner) resolves(source string) bool {
+	if p == nil || p.resolver == nil {
+		return false
+	}
+	return len(p.sources) == 0 || slices.Contains(p.sources, source)
+}
+
+// requires returns whether the packages of a source must be pinned
+func (p *Pinner) requires(source string) bool {
+	return p != nil && p.required[source]
+}
+
+// Pin pins the OCI packages of an entry version of a source. Packages pinned by
+// the entry are kept as they are; the tags of the others are resolved when the
+// pinner resolves the tags of the source. Returns the digest of every package,
+// in order, empty for a package left unpinned, along with the resolved digests.
+// Returns ErrNotPinned when the source requires digest pinning and a package is
+// left unpinned.
+func (p *Pinner) Pin(
+	ctx context.Context, source, entryType, name, version string, packages []Package,
+) ([]string, []PackageDigest, error) {
+	digests := make([]string, len(packages))
+	var resolved []PackageDigest
+	for i, pkg := range packages {
+		if pkg.Digest != "" {
+			digests[i] = pkg.Digest
+			continue
+		}
+
+		ref, err := reference.ParseNormalizedNamed(pkg.Identifier)
+		if err != nil {
+			slog.Warn("Invalid OCI package reference", "name", name, "identifier", pkg.Identifier, "error", err)
+		} else if canonical, ok := ref.(reference.Canonical); ok {
+			digests[i] = canonical.Digest().String()
+			continue
+		} else if p.resolves(source) {
+			tagged, _ := reference.TagNameOnly(ref).(reference.NamedTagged)
+			digest, err := p.resolver.resolve(ctx, tagged)
+			if err != nil {
+				slog.Warn("Failed to resolve OCI package tag", "name", name, "identifier", pkg.Identifier, "error", err)
+			} else {
+				digests[i] = digest
+				resolved = append(resolved, PackageDigest{
+					Type:       entryType,
+					Name:       name,
+					Version:    version,
+					Identifier: pkg.Identifier,
+					Digest:     digest,
+				})
+				continue
+			}
+		}
+
+		if p.requires(source) {
+			return nil, nil, fmt.Errorf("%w: %s %s: %s", ErrNotPinned, name, version, pkg.Identifier)
+		}
+	}
+	return digests, resolved, nil
+}
+
+// PinServer pins the OCI packages of a server version of a source, returning
+// the resolved digests. The packages of the server are left as they are.
+func (p *Pinner) PinServer(
+	ctx context.Context, source, entryType string, server *upstreamv0.ServerJSON,
+) ([]PackageDigest, error) {
+	var packages []Package
+	for _, pkg := range server.Packages {
+		if pkg.RegistryType == model.RegistryTypeOCI {
+			packages = append(packages, Package{Identifier: pkg.Identifier})
+		}
+	}
+	_, resolved, err := p.Pin(ctx, source, entryType, server.Name, server.Version, packages)
+	return resolved, err
+}
+
+// PinSkillPackages pins the OCI packages of a skill or plugin version of a
+// source, returning the resolved digests. The digest of the packages pinned
+// by their reference or by the resolution of their tag is set in place.
+func (p *Pinner) PinSkillPackages(
+	ctx context.Context, source, entryType, name, version string, skillPackages []toolhivetypes.SkillPackage,
+) ([]PackageDigest, error) {
+	var indexes []int
+	var packages []Package
+	for i, pkg := range skillPackages {
+		if pkg.RegistryType == model.RegistryTypeOCI {
+			indexes = append(indexes, i)
+			packages = append(packages, Package{Identifier: pkg.Identifier, Digest: pkg.Digest})
+		}
+	}
+	digests, resolved, err := p.Pin(ctx, source, entryType, name, version, packages)
+	if err != nil {
+		return nil, err
+	}
+	for i, digest := range digests {
+		if digest != "" {
+			skillPackages[indexes[i]].Digest = digest
+		}
+	}
+	return resolved, nil
+}
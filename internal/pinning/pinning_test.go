@@ -0,0 +1,286 @@
+package pinning
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	toolhivetypes "github.com/stacklok/toolhive-core/registry/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+)
+
+const testManifest = `{"schemaVersion": 2, "mediaType": "application/vnd.oci.image.index.v1+json", "manifests": []}`
+
+// fakeRegistry is an OCI registry serving the manifests of its tags
+type fakeRegistry struct {
+	// tags maps repository:tag to the manifest of the tag
+	tags map[string]string
+	// digestHeader returns the digest of the manifests in a header
+	digestHeader bool
+	// token requires a bearer token, issued by the /token endpoint
+	token string
+	// tokenRequests counts the requests to the /token endpoint
+	tokenRequests atomic.Int32
+	// realm overrides the realm of the authentication challenge
+	realm string
+	// redirect redirects the manifest requests to another URL
+	redirect string
+	// requests counts the requests to the registry
+	requests atomic.Int32
+}
+
+// start starts the registry and returns its host
+func (f *fakeRegistry) start(t *testing.T) string {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.requests.Add(1)
+		if r.URL.Path == "/token" {
+			f.tokenRequests.Add(1)
+			if r.URL.Query().Get("service") != "fake" || !strings.HasPrefix(r.URL.Query().Get("scope"), "repository:") {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"token": "` + f.token + `"}`))
+			return
+		}
+		if f.redirect != "" {
+			http.Redirect(w, r, f.redirect, http.StatusTemporaryRedirect)
+			return
+		}
+		if f.token != "" && r.Header.Get("Authorization") != "Bearer "+f.token {
+			realm := f.realm
+			if realm == "" {
+				realm = server.URL + "/token"
+			}
+			w.Header().Set("WWW-Authenticate",
+				`Bearer realm="`+realm+`",service="fake",scope="repository:acme/server:pull"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		repository, tag, found := strings.Cut(strings.TrimPrefix(r.URL.Path, "/v2/"), "/manifests/")
+		manifest, ok := f.tags[repository+":"+tag]
+		if !found || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.oci.image.index.v1+json")
+		if f.digestHeader {
+			w.Header().Set("Docker-Content-Digest", digest.FromString(manifest).String())
+		}
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(manifest))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return strings.TrimPrefix(server.URL, "http://")
+}
+
+func newTestPinner(t *testing.T, host string, registries []config.RegistryConfig) *Pinner {
+	t.Helper()
+	pinner := NewPinner(&config.Config{
+		Registries:    registries,
+		DigestPinning: &config.DigestPinningConfig{PlainHTTPRegistries: []string{host}},
+	})
+	require.NotNil(t, pinner)
+	return pinner
+}
+
+func TestPinnerResolvesTags(t *testing.T) {
+	t.Parallel()
+
+	wantDigest := digest.FromString(testManifest).String()
+	tests := []struct {
+		name     string
+		registry *fakeRegistry
+	}{
+		{
+			name:     "digest header",
+			registry: &fakeRegistry{tags: map[string]string{"acme/server:1.0.0": testManifest}, digestHeader: true},
+		},
+		{
+			name:     "digest of the manifest",
+			registry: &fakeRegistry{tags: map[string]string{"acme/server:1.0.0": testManifest}},
+		},
+		{
+			name: "anonymous token",
+			registry: &fakeRegistry{
+				tags:         map[string]string{"acme/server:1.0.0": testManifest},
+				digestHeader: true,
+				token:        "secret",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			host := tt.registry.start(t)
+			pinner := newTestPinner(t, host, nil)
+
+			identifier := host + "/acme/server:1.0.0"
+			digests, resolved, err := pinner.Pin(t.Context(), "upstream", "server", "com.example/server", "1.0.0",
+				[]Package{{Identifier: identifier}})
+			require.NoError(t, err)
+			assert.Equal(t, []string{wantDigest}, digests)
+			assert.Equal(t, []PackageDigest{{
+				Type:       "server",
+				Name:       "com.example/server",
+				Version:    "1.0.0",
+				Identifier: identifier,
+				Digest:     wantDigest,
+			}}, resolved)
+			if tt.registry.token != "" {
+				assert.Equal(t, int32(1), tt.registry.tokenRequests.Load())
+			}
+		})
+	}
+}
+
+func TestPinnerPinnedPackages(t *testing.T) {
+	t.Parallel()
+
+	registry := &fakeRegistry{}
+	host := registry.start(t)
+	pinner := newTestPinner(t, host, nil)
+
+	pinned := "sha256:" + strings.Repeat("a", 64)
+	given := "sha256:" + strings.Repeat("b", 64)
+	digests, resolved, err := pinner.Pin(t.Context(), "upstream", "skill", "calendar", "1.0.0", []Package{
+		{Identifier: host + "/acme/calendar@" + pinned},
+		{Identifier: host + "/acme/calendar:1.0.0", Digest: given},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{pinned, given}, digests)
+	assert.Empty(t, resolved)
+}
+
+func TestPinnerRequiresPinning(t *testing.T) {
+	t.Parallel()
+
+	registry := &fakeRegistry{tags: map[string]string{"acme/server:1.0.0": testManifest}, digestHeader: true}
+	host := registry.start(t)
+	pinner := newTestPinner(t, host, []config.RegistryConfig{
+		{Name: "pinned", Sources: []string{"internal"}, RequireDigestPinning: true},
+	})
+
+	// An unknown tag is left unpinned, which is refused by a requiring source only
+	packages := []Package{{Identifier: host + "/acme/server:2.0.0"}}
+	digests, _, err := pinner.Pin(t.Context(), "upstream", "server", "com.example/server", "2.0.0", packages)
+	require.NoError(t, err)
+	assert.Equal(t, []string{""}, digests)
+
+	_, _, err = pinner.Pin(t.Context(), "internal", "server", "com.example/server", "2.0.0", packages)
+	require.ErrorIs(t, err, ErrNotPinned)
+
+	digests, _, err = pinner.Pin(t.Context(), "internal", "server", "com.example/server", "1.0.0",
+		[]Package{{Identifier: host + "/acme/server:1.0.0"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{digest.FromString(testManifest).String()}, digests)
+}
+
+func TestPinnerPinSkillPackages(t *testing.T) {
+	t.Parallel()
+
+	registry := &fakeRegistry{tags: map[string]string{"acme/calendar:1.0.0": testManifest}, digestHeader: true}
+	host := registry.start(t)
+	pinner := newTestPinner(t, host, nil)
+
+	packages := []toolhivetypes.SkillPackage{
+		{RegistryType: "git", URL: "https://github.com/acme/calendar", Ref: "v1.0.0"},
+		{RegistryType: "oci", Identifier: host + "/acme/calendar:1.0.0"},
+	}
+	resolved, err := pinner.PinSkillPackages(t.Context(), "upstream", "skill", "calendar", "1.0.0", packages)
+	require.NoError(t, err)
+	require.Len(t, resolved, 1)
+	assert.Empty(t, packages[0].Digest)
+	assert.Equal(t, digest.FromString(testManifest).String(), packages[1].Digest)
+	assert.Equal(t, packages[1].Digest, resolved[0].Digest)
+}
+
+func TestPinnerRefusesUntrustedHosts(t *testing.T) {
+	t.Parallel()
+
+	manifests := map[string]string{"acme/server:1.0.0": testManifest}
+
+	t.Run("registry not allowed", func(t *testing.T) {
+		t.Parallel()
+		registry := &fakeRegistry{tags: manifests, digestHeader: true}
+		host := registry.start(t)
+		pinner := NewPinner(&config.Config{DigestPinning: &config.DigestPinningConfig{AllowedHosts: []string{"ghcr.io"}}})
+
+		digests, _, err := pinner.Pin(t.Context(), "upstream", "server", "com.example/server", "1.0.0",
+			[]Package{{Identifier: host + "/acme/server:1.0.0"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{""}, digests)
+		assert.Zero(t, registry.requests.Load())
+	})
+
+	t.Run("authentication realm on another host", func(t *testing.T) {
+		t.Parallel()
+		other := &fakeRegistry{token: "secret"}
+		otherHost := other.start(t)
+		registry := &fakeRegistry{tags: manifests, digestHeader: true, token: "secret", realm: "http://" + otherHost + "/token"}
+		host := registry.start(t)
+		pinner := newTestPinner(t, host, nil)
+
+		digests, _, err := pinner.Pin(t.Context(), "upstream", "server", "com.example/server", "1.0.0",
+			[]Package{{Identifier: host + "/acme/server:1.0.0"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{""}, digests)
+		assert.Zero(t, other.requests.Load())
+	})
+
+	t.Run("redirect", func(t *testing.T) {
+		t.Parallel()
+		other := &fakeRegistry{tags: manifests, digestHeader: true}
+		otherHost := other.start(t)
+		registry := &fakeRegistry{redirect: "http://" + otherHost + "/v2/acme/server/manifests/1.0.0"}
+		host := registry.start(t)
+		pinner := newTestPinner(t, host, nil)
+
+		digests, _, err := pinner.Pin(t.Context(), "upstream", "server", "com.example/server", "1.0.0",
+			[]Package{{Identifier: host + "/acme/server:1.0.0"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{""}, digests)
+		assert.Zero(t, other.requests.Load())
+	})
+}
+
+func TestNewPinner(t *testing.T) {
+	t.Parallel()
+
+	var nilPinner *Pinner
+	assert.False(t, nilPinner.AppliesTo("upstream"))
+	assert.Nil(t, NewPinner(&config.Config{}))
+
+	pinner := NewPinner(&config.Config{DigestPinning: &config.DigestPinningConfig{Sources: []string{"internal"}}})
+	assert.False(t, pinner.AppliesTo("upstream"))
+	assert.True(t, pinner.AppliesTo("internal"))
+
+	// Requiring digest pinning does not resolve tags without a digestPinning section
+	pinner = NewPinner(&config.Config{Registries: []config.RegistryConfig{
+		{Name: "pinned", Sources: []string{"internal"}, RequireDigestPinning: true},
+	}})
+	assert.True(t, pinner.AppliesTo("internal"))
+	_, _, err := pinner.Pin(t.Context(), "internal", "server", "com.example/server", "1.0.0",
+		[]Package{{Identifier: "ghcr.io/acme/server:1.0.0"}})
+	require.ErrorIs(t, err, ErrNotPinned)
+}
+
+func TestParseChallengeParams(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, map[string]string{
+		"realm":   "https://auth.example.com/token",
+		"service": "registry.example.com",
+		"scope":   "repository:a:pull,push",
+	}, parseChallengeParams(`realm="https://auth.example.com/token",service=registry.example.com,scope="repository:a:pull,push"`))
+}
@@ -0,0 +1,241 @@
+package pinning
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/distribution/reference"
+	"github.com/opencontainers/go-digest"
+)
+
+const (
+	userAgent = "toolhive-registry-server"
+
+	// dockerHubRegistry is the host serving the distribution API of Docker Hub,
+	// whose references are normalized to the docker.io domain
+	dockerHubRegistry = "registry-1.docker.io"
+
+	// maxManifestSize is the maximum size of a manifest read to compute its
+	// digest, when the registry does not return it in a header
+	maxManifestSize = 4 * 1024 * 1024
+
+	// maxTokenSize is the maximum size of a token response
+	maxTokenSize = 64 * 1024
+)
+
+// manifestMediaTypes are the media types of the manifests accepted for a tag,
+// image indexes first so that multi-platform images resolve to their index
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// resolver resolves the tags of OCI references to digests with the
+// distribution API. Only anonymous pulls are supported. Only the allowed hosts
+// are contacted, as package identifiers are given by publishers.
+type resolver struct {
+	client       *http.Client
+	plainHTTP    []string
+	allowedHosts []string
+	timeout      time.Duration
+}
+
+// resolve returns the digest of the manifest a tag points to
+func (r *resolver) resolve(ctx context.Context, ref reference.NamedTagged) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	host := reference.Domain(ref)
+	if !slices.Contains(r.allowedHosts, host) {
+		return "", fmt.Errorf("registry %s is not an allowed host", host)
+	}
+	scheme := "https"
+	if slices.Contains(r.plainHTTP, host) {
+		scheme = "http"
+	}
+	if host == "docker.io" {
+		host = dockerHubRegistry
+	}
+	manifestURL := fmt.Sprintf("%s://%s/v2/%s/manifests/%s", scheme, host, reference.Path(ref), ref.Tag())
+
+	// HEAD is enough when the registry returns the digest in a header, and
+	// does not count against the pull rate limits of the registries having any
+	resp, err := r.fetchManifest(ctx, http.MethodHead, manifestURL)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	if dgst := resp.Header.Get("Docker-Content-Digest"); dgst != "" {
+		if _, err := digest.Parse(dgst); err != nil {
+			return "", fmt.Errorf("invalid digest %q returned by the registry: %w", dgst, err)
+		}
+		return dgst, nil
+	}
+
+	resp, err = r.fetchManifest(ctx, http.MethodGet, manifestURL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	manifest, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestSize+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read manifest: %w", err)
+	}
+	if len(manifest) > maxManifestSize {
+		return "", fmt.Errorf("manifest exceeds %d bytes", maxManifestSize)
+	}
+	return digest.FromBytes(manifest).String(), nil
+}
+
+// fetchManifest requests a manifest, authenticating with an anonymous token
+// when the registry requires one. Returns an error for a non-2xx response,
+// redirects included.
+func (r *resolver) fetchManifest(ctx context.Context, method, manifestURL string) (*http.Response, error) {
+	resp, err := r.doManifestRequest(ctx, method, manifestURL, "")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		token, err := r.fetchToken(ctx, manifestURL, challenge)
+		if err != nil {
+			return nil, err
+		}
+		resp, err = r.doManifestRequest(ctx, method, manifestURL, token)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status %d for %s", resp.StatusCode, manifestURL)
+	}
+	return resp, nil
+}
+
+// doManifestRequest sends a manifest request, with a bearer token if not empty
+func (r *resolver) doManifestRequest(ctx context.Context, method, manifestURL, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, manifestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	req.Header.Set("User-Agent", userAgent)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return r.client.Do(req)
+}
+
+// fetchToken fetches an anonymous token from the authorization service of a
+// Bearer challenge of the registry serving manifestURL
+func (r *resolver) fetchToken(ctx context.Context, manifestURL, challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("unsupported authentication challenge %q", challenge)
+	}
+	attributes := parseChallengeParams(params)
+	realm := attributes["realm"]
+	if realm == "" {
+		return "", fmt.Errorf("authentication challenge without realm: %q", challenge)
+	}
+
+	tokenURL, err := url.Parse(realm)
+	if err != nil {
+		return "", fmt.Errorf("invalid authentication realm %q: %w", realm, err)
+	}
+	if err := r.checkRealm(tokenURL, manifestURL); err != nil {
+		return "", err
+	}
+	query := tokenURL.Query()
+	for _, key := range []string{"service", "scope"} {
+		if value := attributes[key]; value != "" {
+			query.Set(key, value)
+		}
+	}
+	tokenURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("unexpected status %d for the token of %s", resp.StatusCode, realm)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxTokenSize)).Decode(&body); err != nil {
+		return "", fmt.Errorf("invalid token response: %w", err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	if body.AccessToken != "" {
+		return body.AccessToken, nil
+	}
+	return "", fmt.Errorf("token response of %s without token", realm)
+}
+
+// checkRealm checks that the authentication realm of a registry is served over
+// HTTPS, unless the registry is a plain HTTP registry, by the registry itself
+// or an allowed host
+func (r *resolver) checkRealm(tokenURL *url.URL, manifestURL string) error {
+	registryURL, err := url.Parse(manifestURL)
+	if err != nil {
+		return fmt.Errorf("invalid manifest URL %q: %w", manifestURL, err)
+	}
+	if tokenURL.Scheme != "https" && (tokenURL.Scheme != "http" || !slices.Contains(r.plainHTTP, registryURL.Host)) {
+		return fmt.Errorf("authentication realm %s of %s must use https", tokenURL, registryURL.Host)
+	}
+	if tokenURL.Host != registryURL.Host && !slices.Contains(r.allowedHosts, tokenURL.Host) {
+		return fmt.Errorf("authentication realm %s of %s is not on an allowed host", tokenURL, registryURL.Host)
+	}
+	return nil
+}
+
+// parseChallengeParams parses the comma-separated key="value" parameters of an
+// authentication challenge. Commas are allowed within quoted values, as in the
+// scopes of several repositories.
+func parseChallengeParams(params string) map[string]string {
+	attributes := make(map[string]string)
+	for params = strings.TrimSpace(params); params != ""; {
+		key, rest, found := strings.Cut(params, "=")
+		if !found {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		attributes[key] = strings.TrimSpace(value)
+		params = strings.TrimLeft(rest, ", ")
+	}
+	return attributes
+}
@@ -0,0 +1,170 @@
+// Package replication carries the entries served by a registry to the bundle
+// sources of other servers, such as edge servers in air-gapped clusters, as
+// signed bundle files.
+//
+// A bundle holds the server, skill and plugin versions a registry serves, with
+// the claims of their entries, as of a position of the change feed of the
+// registry: the ID of the bundle. A full bundle holds every version. A delta
+// bundle, exported since the ID of a previous bundle, its base, holds the
+// versions changed after the base along with the versions no longer served.
+//
+// A bundle file is an envelope holding the JSON encoding of the bundle and its
+// signature, a JWS with detached payload made by the exporting server. It is
+// verified against the public keys trusted by the importing source before the
+// bundle is read.
+package replication
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	toolhivetypes "github.com/stacklok/toolhive-core/registry/types"
+
+	"github.com/stacklok/toolhive-registry-server/internal/signing"
+)
+
+// FormatVersion is the version of the bundle format
+const FormatVersion = "1"
+
+// Entry types of the entries of a bundle
+const (
+	EntryTypeServer = "server"
+	EntryTypeSkill  = "skill"
+	EntryTypePlugin = "plugin"
+)
+
+// ErrInvalidBundle is returned when a bundle file cannot be read or its
+// signature cannot be verified
+var ErrInvalidBundle = errors.New("invalid bundle")
+
+// ErrBundleRollback is returned when the bundles of a source are older than the
+// bundle it last imported
+var ErrBundleRollback = errors.New("bundle older than the last imported bundle")
+
+// Bundle is the signed content of a bundle file
+type Bundle struct {
+	// Version is the version of the bundle format
+	Version string `json:"version"`
+	// ID is the position of the change feed of the registry the bundle was
+	// exported at
+	ID string `json:"id"`
+	// BaseID is the ID of the bundle a delta bundle applies to, empty for a
+	// full bundle
+	BaseID string `json:"baseId,omitempty"`
+	// Registry is the name of the exported registry
+	Registry string `json:"registry"`
+	// CreatedAt is the time the bundle was exported
+	CreatedAt time.Time `json:"createdAt"`
+
+	Servers []upstreamv0.ServerJSON `json:"servers,omitempty"`
+	Skills  []toolhivetypes.Skill   `json:"skills,omitempty"`
+	Plugins []toolhivetypes.Plugin  `json:"plugins,omitempty"`
+
+	// Claims are the claims of the entries of the bundle. Entries without
+	// claims are imported with the claims of the importing source.
+	Claims []EntryClaims `json:"claims,omitempty"`
+
+	// Deleted lists the versions no longer served since the base of a delta bundle
+	Deleted []EntryRef `json:"deleted,omitempty"`
+}
+
+// EntryClaims are the claims of an entry of a bundle, shared by its versions
+type EntryClaims struct {
+	Type   string         `json:"type"`
+	Name   string         `json:"name"`
+	Claims map[string]any `json:"claims"`
+}
+
+// EntryRef identifies an entry version of a bundle. Namespace is only set on
+// the deleted versions of skills and plugins, and is not part of their identity.
+type EntryRef struct {
+	Type      string `json:"type"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+}
+
+// IsDelta reports whether the bundle is a delta bundle
+func (b *Bundle) IsDelta() bool {
+	return b.BaseID != ""
+}
+
+// envelope is the format of a bundle file
+type envelope struct {
+	// Payload is the JSON encoding of the bundle, as signed
+	Payload   json.RawMessage `json:"payload"`
+	Signature signature       `json:"signature"`
+}
+
+// signature is the signature of the payload of a bundle file
+type signature struct {
+	Format string `json:"format"`
+	KeyID  string `json:"keyId,omitempty"`
+	Value  string `json:"value"`
+}
+
+// Seal returns the bundle file of a bundle, signed by signer
+func Seal(bundle *Bundle, signer *signing.Signer) ([]byte, error) {
+	payload, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode bundle: %w", err)
+	}
+	sig, err := signer.Sign(payload)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(envelope{
+		Payload: payload,
+		Signature: signature{
+			Format: sig.Format,
+			KeyID:  signer.KeyID(),
+			Value:  sig.Value,
+		},
+	})
+}
+
+// NewVerifier creates a verifier of bundle files trusting the given PEM
+// encoded public keys
+func NewVerifier(publicKeys []string) (*signing.Verifier, error) {
+	return signing.NewVerifier([]signing.Namespace{
+		{PublicKeys: publicKeys, RequireSignature: true},
+	})
+}
+
+// Open verifies the signature of a bundle file with verifier, created with
+// NewVerifier, and returns its bundle. The payload may have been reformatted:
+// the signature is verified over its compact encoding.
+func Open(data []byte, verifier *signing.Verifier) (*Bundle, error) {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+	}
+	if len(env.Payload) == 0 {
+		return nil, fmt.Errorf("%w: payload is missing", ErrInvalidBundle)
+	}
+
+	var payload bytes.Buffer
+	if err := json.Compact(&payload, env.Payload); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+	}
+	sig := &signing.Signature{Format: env.Signature.Format, Value: env.Signature.Value}
+	if _, err := verifier.Verify("", payload.Bytes(), sig); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+	}
+
+	var bundle Bundle
+	if err := json.Unmarshal(payload.Bytes(), &bundle); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+	}
+	if bundle.Version != FormatVersion {
+		return nil, fmt.Errorf("%w: unsupported format version %q", ErrInvalidBundle, bundle.Version)
+	}
+	if bundle.ID == "" {
+		return nil, fmt.Errorf("%w: id is required", ErrInvalidBundle)
+	}
+	return &bundle, nil
+}
@@ -0,0 +1,134 @@
+package replication
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	toolhivetypes "github.com/stacklok/toolhive-core/registry/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/signing"
+)
+
+// newTestKeys returns a signer and the PEM encoded public key verifying its signatures
+func newTestKeys(t *testing.T) (*signing.Signer, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	signer, err := signing.NewSigner(string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})))
+	require.NoError(t, err)
+
+	der, err = x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	return signer, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func newTestBundle() *Bundle {
+	return &Bundle{
+		Version:   FormatVersion,
+		ID:        "42",
+		Registry:  "central",
+		CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Servers:   []upstreamv0.ServerJSON{{Name: "com.example/server", Version: "1.0.0"}},
+		Skills:    []toolhivetypes.Skill{{Namespace: "io.example", Name: "calendar", Version: "1.0.0"}},
+		Claims: []EntryClaims{
+			{Type: EntryTypeServer, Name: "com.example/server", Claims: map[string]any{"org": "acme"}},
+		},
+	}
+}
+
+func TestSealOpen(t *testing.T) {
+	t.Parallel()
+
+	signer, publicKey := newTestKeys(t)
+	verifier, err := NewVerifier([]string{publicKey})
+	require.NoError(t, err)
+
+	data, err := Seal(newTestBundle(), signer)
+	require.NoError(t, err)
+
+	bundle, err := Open(data, verifier)
+	require.NoError(t, err)
+	assert.Equal(t, newTestBundle(), bundle)
+
+	// Reformatting the file does not break its signature
+	var indented bytes.Buffer
+	require.NoError(t, json.Indent(&indented, data, "", "  "))
+	bundle, err = Open(indented.Bytes(), verifier)
+	require.NoError(t, err)
+	assert.Equal(t, "42", bundle.ID)
+}
+
+func TestOpen_Invalid(t *testing.T) {
+	t.Parallel()
+
+	signer, publicKey := newTestKeys(t)
+	_, otherKey := newTestKeys(t)
+	verifier, err := NewVerifier([]string{publicKey})
+	require.NoError(t, err)
+
+	data, err := Seal(newTestBundle(), signer)
+	require.NoError(t, err)
+
+	unsupported := newTestBundle()
+	unsupported.Version = "0"
+	unsupportedData, err := Seal(unsupported, signer)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		data     []byte
+		verifier func(t *testing.T) *signing.Verifier
+	}{
+		{
+			name: "tampered payload",
+			data: bytes.Replace(data, []byte(`"1.0.0"`), []byte(`"6.6.6"`), 1),
+		},
+		{
+			name: "untrusted key",
+			data: data,
+			verifier: func(t *testing.T) *signing.Verifier {
+				t.Helper()
+				v, err := NewVerifier([]string{otherKey})
+				require.NoError(t, err)
+				return v
+			},
+		},
+		{
+			name: "unsupported format version",
+			data: unsupportedData,
+		},
+		{
+			name: "missing payload",
+			data: []byte(`{"signature": {"format": "jws", "value": "x"}}`),
+		},
+		{
+			name: "not a bundle file",
+			data: []byte(`not json`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			v := verifier
+			if tt.verifier != nil {
+				v = tt.verifier(t)
+			}
+			_, err := Open(tt.data, v)
+			require.ErrorIs(t, err, ErrInvalidBundle)
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package replication
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"time"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	toolhivetypes "github.com/stacklok/toolhive-core/registry/types"
+)
+
+// upstreamSchemaVersion is the schema version of the upstream registry
+// documents built from bundles
+const upstreamSchemaVersion = "1.0.0"
+
+// Resolve returns the full bundle the given bundles resolve to: the most
+// recent full bundle, with the chain of delta bundles based on it applied in
+// order. A delta applies to the bundle whose ID is its base; deltas that do not
+// chain to the full bundle are ignored. The ID of the result is the ID of the
+// last applied bundle.
+func Resolve(bundles []*Bundle) (*Bundle, error) {
+	var full *Bundle
+	for _, bundle := range bundles {
+		if !bundle.IsDelta() && (full == nil || bundle.CreatedAt.After(full.CreatedAt)) {
+			full = bundle
+		}
+	}
+	if full == nil {
+		if len(bundles) == 0 {
+			return nil, fmt.Errorf("no bundle found")
+		}
+		return nil, fmt.Errorf("delta bundle %s requires its base bundle %s", bundles[0].ID, bundles[0].BaseID)
+	}
+
+	resolved := full.clone()
+	applied := map[string]bool{resolved.ID: true}
+	for {
+		delta := nextDelta(bundles, resolved, applied)
+		if delta == nil {
+			break
+		}
+		resolved.apply(delta)
+		applied[delta.ID] = true
+	}
+
+	// Deltas exported before the resolved bundle are superseded by it
+	for _, bundle := range bundles {
+		if bundle.IsDelta() && !applied[bundle.ID] && bundle.CreatedAt.After(resolved.CreatedAt) {
+			slog.Warn("Ignoring delta bundle not based on the resolved bundle",
+				"registry", bundle.Registry,
+				"bundle", bundle.ID,
+				"base", bundle.BaseID,
+				"resolved", resolved.ID)
+		}
+	}
+	return resolved, nil
+}
+
+// ResolveAfter returns the bundle to import from the given bundles into a
+// source that last imported the bundle lastID: a delta bundle based on lastID
+// merging the chain of deltas based on it, to be applied to the stored entries,
+// or the full bundle Resolve returns when it is more recent. When no bundle is
+// more recent than lastID, the result is an empty delta bundle with the ID
+// lastID. Returns ErrBundleRollback when every bundle is older than lastID.
+// Without a lastID that is a bundle ID, it is Resolve.
+func ResolveAfter(bundles []*Bundle, lastID string) (*Bundle, error) {
+	last := bundlePosition(lastID)
+	if last < 0 {
+		return Resolve(bundles)
+	}
+
+	changes := &Bundle{Version: FormatVersion, ID: lastID, BaseID: lastID}
+	applied := map[string]bool{lastID: true}
+	for {
+		delta := nextDelta(bundles, changes, applied)
+		if delta == nil {
+			break
+		}
+		changes.Registry = delta.Registry
+		changes.merge(delta)
+		applied[delta.ID] = true
+	}
+
+	// A full bundle resolving past the deltas supersedes them
+	if slices.ContainsFunc(bundles, func(bundle *Bundle) bool { return !bundle.IsDelta() }) {
+		resolved, err := Resolve(bundles)
+		if err != nil {
+			return nil, err
+		}
+		if bundlePosition(resolved.ID) > bundlePosition(changes.ID) {
+			return resolved, nil
+		}
+	}
+	if changes.ID != lastID {
+		return changes, nil
+	}
+
+	var latest *Bundle
+	for _, bundle := range bundles {
+		if latest == nil || bundlePosition(bundle.ID) > bundlePosition(latest.ID) {
+			latest = bundle
+		}
+	}
+	switch {
+	case latest == nil:
+		return nil, fmt.Errorf("no bundle found")
+	case bundlePosition(latest.ID) < last:
+		return nil, fmt.Errorf("%w: bundle %s is older than the last imported bundle %s",
+			ErrBundleRollback, latest.ID, lastID)
+	case bundlePosition(latest.ID) > last:
+		// Full bundles more recent than lastID resolve past it, so latest is a delta
+		return nil, fmt.Errorf("delta bundle %s requires its base bundle %s", latest.ID, latest.BaseID)
+	}
+	return changes, nil
+}
+
+// bundlePosition returns the position of the change feed a bundle ID stands
+// for, or -1 when the ID is not a position
+func bundlePosition(id string) int64 {
+	position, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || position < 0 {
+		return -1
+	}
+	return position
+}
+
+// nextDelta returns the most recent delta bundle of the registry of resolved
+// based on it, or nil. Any registry is accepted when resolved has none.
+func nextDelta(bundles []*Bundle, resolved *Bundle, applied map[string]bool) *Bundle {
+	var next *Bundle
+	for _, bundle := range bundles {
+		if bundle.BaseID != resolved.ID || applied[bundle.ID] ||
+			(resolved.Registry != "" && bundle.Registry != resolved.Registry) {
+			continue
+		}
+		if next == nil || bundle.CreatedAt.After(next.CreatedAt) {
+			next = bundle
+		}
+	}
+	return next
+}
+
+// clone returns a copy of a full bundle that can be modified by apply
+func (b *Bundle) clone() *Bundle {
+	clone := *b
+	clone.Servers = slices.Clone(b.Servers)
+	clone.Skills = slices.Clone(b.Skills)
+	clone.Plugins = slices.Clone(b.Plugins)
+	clone.Claims = slices.Clone(b.Claims)
+	clone.Deleted = nil
+	return &clone
+}
+
+// apply applies a delta bundle to a full bundle: the deleted versions and the
+// previous content of the changed versions are removed, then the changed
+// versions are added. The claims of the entries of the delta replace theirs.
+// Deleted versions leave the claims of their entry to its remaining versions.
+func (b *Bundle) apply(delta *Bundle) {
+	removed := make(map[EntryRef]bool, len(delta.Deleted)+len(delta.Servers)+len(delta.Skills)+len(delta.Plugins))
+	for _, ref := range delta.Deleted {
+		removed[ref.identity()] = true
+	}
+	for _, server := range delta.Servers {
+		removed[EntryRef{Type: EntryTypeServer, Name: server.Name, Version: server.Version}] = true
+	}
+	for _, skill := range delta.Skills {
+		removed[EntryRef{Type: EntryTypeSkill, Name: skill.Name, Version: skill.Version}] = true
+	}
+	for _, plugin := range delta.Plugins {
+		removed[EntryRef{Type: EntryTypePlugin, Name: plugin.Name, Version: plugin.Version}] = true
+	}
+
+	b.Servers = slices.DeleteFunc(b.Servers, func(server upstreamv0.ServerJSON) bool {
+		return removed[EntryRef{Type: EntryTypeServer, Name: server.Name, Version: server.Version}]
+	})
+	b.Skills = slices.DeleteFunc(b.Skills, func(skill toolhivetypes.Skill) bool {
+		return removed[EntryRef{Type: EntryTypeSkill, Name: skill.Name, Version: skill.Version}]
+	})
+	b.Plugins = slices.DeleteFunc(b.Plugins, func(plugin toolhivetypes.Plugin) bool {
+		return removed[EntryRef{Type: EntryTypePlugin, Name: plugin.Name, Version: plugin.Version}]
+	})
+	b.Servers = append(b.Servers, delta.Servers...)
+	b.Skills = append(b.Skills, delta.Skills...)
+	b.Plugins = append(b.Plugins, delta.Plugins...)
+
+	// The claims of the entries of the delta are replaced, even by none
+	claims := make(map[EntryRef]bool, len(delta.Servers)+len(delta.Skills)+len(delta.Plugins))
+	for _, server := range delta.Servers {
+		claims[EntryRef{Type: EntryTypeServer, Name: server.Name}] = true
+	}
+	for _, skill := range delta.Skills {
+		claims[EntryRef{Type: EntryTypeSkill, Name: skill.Name}] = true
+	}
+	for _, plugin := range delta.Plugins {
+		claims[EntryRef{Type: EntryTypePlugin, Name: plugin.Name}] = true
+	}
+	b.Claims = slices.DeleteFunc(b.Claims, func(entry EntryClaims) bool {
+		return claims[EntryRef{Type: entry.Type, Name: entry.Name}]
+	})
+	b.Claims = append(b.Claims, delta.Claims...)
+
+	b.ID = delta.ID
+	b.CreatedAt = delta.CreatedAt
+}
+
+// merge merges a delta bundle based on the delta bundle b into it, so that b
+// holds the changes of both: versions deleted by one and added by the other
+// are only kept by the most recent one.
+func (b *Bundle) merge(delta *Bundle) {
+	b.apply(delta)
+
+	added := make(map[EntryRef]bool, len(delta.Servers)+len(delta.Skills)+len(delta.Plugins))
+	for _, server := range delta.Servers {
+		added[EntryRef{Type: EntryTypeServer, Name: server.Name, Version: server.Version}] = true
+	}
+	for _, skill := range delta.Skills {
+		added[EntryRef{Type: EntryTypeSkill, Name: skill.Name, Version: skill.Version}] = true
+	}
+	for _, plugin := range delta.Plugins {
+		added[EntryRef{Type: EntryTypePlugin, Name: plugin.Name, Version: plugin.Version}] = true
+	}
+	b.Deleted = slices.DeleteFunc(b.Deleted, func(ref EntryRef) bool {
+		return added[ref.identity()]
+	})
+	b.Deleted = append(b.Deleted, delta.Deleted...)
+}
+
+// identity returns the reference without its namespace
+func (r EntryRef) identity() EntryRef {
+	return EntryRef{Type: r.Type, Name: r.Name, Version: r.Version}
+}
+
+// UpstreamRegistry returns the entries of a full bundle as an upstream
+// registry document
+func (b *Bundle) UpstreamRegistry() *toolhivetypes.UpstreamRegistry {
+	return &toolhivetypes.UpstreamRegistry{
+		Version: upstreamSchemaVersion,
+		Meta:    toolhivetypes.UpstreamMeta{LastUpdated: b.CreatedAt.UTC().Format(time.RFC3339)},
+		Data: toolhivetypes.UpstreamData{
+			Servers: b.Servers,
+			Skills:  b.Skills,
+			Plugins: b.Plugins,
+		},
+	}
+}
+
+// EntryClaims returns the JSON encoded claims of the entries of a type of the
+// bundle, keyed by entry name
+func (b *Bundle) EntryClaims(entryType string) (map[string][]byte, error) {
+	claims := make(map[string][]byte)
+	for _, entry := range b.Claims {
+		if entry.Type != entryType || len(entry.Claims) == 0 {
+			continue
+		}
+		data, err := json.Marshal(entry.Claims)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode claims of %s %s: %w", entry.Type, entry.Name, err)
+		}
+		claims[entry.Name] = data
+	}
+	return claims, nil
+}
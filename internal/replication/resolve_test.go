@@ -0,0 +1,210 @@
+package replication
+
+import (
+	"testing"
+	"time"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	toolhivetypes "github.com/stacklok/toolhive-core/registry/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC) }
+	full := &Bundle{
+		ID:        "10",
+		Registry:  "central",
+		CreatedAt: day(1),
+		Servers: []upstreamv0.ServerJSON{
+			{Name: "com.example/a", Version: "1.0.0", Description: "a"},
+			{Name: "com.example/b", Version: "1.0.0"},
+		},
+		Skills: []toolhivetypes.Skill{{Name: "calendar", Version: "1.0.0"}},
+		Claims: []EntryClaims{
+			{Type: EntryTypeServer, Name: "com.example/a", Claims: map[string]any{"org": "acme"}},
+			{Type: EntryTypeSkill, Name: "calendar", Claims: map[string]any{"org": "acme"}},
+		},
+	}
+	first := &Bundle{
+		ID:        "20",
+		BaseID:    "10",
+		Registry:  "central",
+		CreatedAt: day(2),
+		Servers:   []upstreamv0.ServerJSON{{Name: "com.example/a", Version: "1.0.0", Description: "changed"}},
+		Deleted:   []EntryRef{{Type: EntryTypeServer, Name: "com.example/b", Version: "1.0.0"}},
+	}
+	second := &Bundle{
+		ID:        "30",
+		BaseID:    "20",
+		Registry:  "central",
+		CreatedAt: day(3),
+		Plugins:   []toolhivetypes.Plugin{{Name: "deploy", Version: "1.0.0"}},
+		Claims:    []EntryClaims{{Type: EntryTypePlugin, Name: "deploy", Claims: map[string]any{"team": "ops"}}},
+	}
+	// Based on a bundle that was never imported
+	unrelated := &Bundle{ID: "25", BaseID: "15", Registry: "central", CreatedAt: day(3)}
+
+	t.Run("applies the chain of deltas", func(t *testing.T) {
+		t.Parallel()
+		resolved, err := Resolve([]*Bundle{second, unrelated, first, full})
+		require.NoError(t, err)
+
+		assert.Equal(t, "30", resolved.ID)
+		assert.Equal(t, day(3), resolved.CreatedAt)
+		assert.Equal(t, []upstreamv0.ServerJSON{
+			{Name: "com.example/a", Version: "1.0.0", Description: "changed"},
+		}, resolved.Servers)
+		assert.Len(t, resolved.Skills, 1)
+		assert.Len(t, resolved.Plugins, 1)
+
+		// The claims of a are dropped: the delta re-sent it without claims
+		serverClaims, err := resolved.EntryClaims(EntryTypeServer)
+		require.NoError(t, err)
+		assert.Empty(t, serverClaims)
+		skillClaims, err := resolved.EntryClaims(EntryTypeSkill)
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]byte{"calendar": []byte(`{"org":"acme"}`)}, skillClaims)
+		pluginClaims, err := resolved.EntryClaims(EntryTypePlugin)
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]byte{"deploy": []byte(`{"team":"ops"}`)}, pluginClaims)
+
+		// The bundles resolved from are left unchanged
+		assert.Len(t, full.Servers, 2)
+	})
+
+	t.Run("a later full bundle supersedes the deltas", func(t *testing.T) {
+		t.Parallel()
+		later := &Bundle{ID: "40", Registry: "central", CreatedAt: day(4)}
+		resolved, err := Resolve([]*Bundle{full, first, second, later})
+		require.NoError(t, err)
+		assert.Equal(t, "40", resolved.ID)
+		assert.Empty(t, resolved.Servers)
+	})
+
+	t.Run("a delta requires its base", func(t *testing.T) {
+		t.Parallel()
+		_, err := Resolve([]*Bundle{first})
+		require.ErrorContains(t, err, "delta bundle 20 requires its base bundle 10")
+	})
+
+	t.Run("no bundle", func(t *testing.T) {
+		t.Parallel()
+		_, err := Resolve(nil)
+		require.ErrorContains(t, err, "no bundle found")
+	})
+}
+
+func TestResolveAfter(t *testing.T) {
+	t.Parallel()
+
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC) }
+	full := &Bundle{
+		ID:        "10",
+		Registry:  "central",
+		CreatedAt: day(1),
+		Servers:   []upstreamv0.ServerJSON{{Name: "com.example/a", Version: "1.0.0"}},
+	}
+	first := &Bundle{
+		ID:        "20",
+		BaseID:    "10",
+		Registry:  "central",
+		CreatedAt: day(2),
+		Servers:   []upstreamv0.ServerJSON{{Name: "com.example/b", Version: "1.0.0"}},
+		Skills:    []toolhivetypes.Skill{{Namespace: "io.example", Name: "calendar", Version: "1.0.0"}},
+		Deleted:   []EntryRef{{Type: EntryTypeServer, Name: "com.example/a", Version: "1.0.0"}},
+	}
+	second := &Bundle{
+		ID:        "30",
+		BaseID:    "20",
+		Registry:  "central",
+		CreatedAt: day(3),
+		Servers:   []upstreamv0.ServerJSON{{Name: "com.example/a", Version: "1.0.0"}},
+		Deleted: []EntryRef{
+			{Type: EntryTypeSkill, Namespace: "io.example", Name: "calendar", Version: "1.0.0"},
+		},
+	}
+
+	t.Run("merges the deltas based on the last imported bundle", func(t *testing.T) {
+		t.Parallel()
+		resolved, err := ResolveAfter([]*Bundle{second, first}, "10")
+		require.NoError(t, err)
+
+		assert.True(t, resolved.IsDelta())
+		assert.Equal(t, "30", resolved.ID)
+		assert.Equal(t, "10", resolved.BaseID)
+		assert.Equal(t, "central", resolved.Registry)
+		assert.Equal(t, []upstreamv0.ServerJSON{
+			{Name: "com.example/b", Version: "1.0.0"},
+			{Name: "com.example/a", Version: "1.0.0"},
+		}, resolved.Servers)
+		assert.Empty(t, resolved.Skills)
+		// The server deleted then added again is no longer deleted
+		assert.Equal(t, []EntryRef{
+			{Type: EntryTypeSkill, Namespace: "io.example", Name: "calendar", Version: "1.0.0"},
+		}, resolved.Deleted)
+	})
+
+	t.Run("skips the deltas already imported", func(t *testing.T) {
+		t.Parallel()
+		resolved, err := ResolveAfter([]*Bundle{full, first, second}, "20")
+		require.NoError(t, err)
+		assert.Equal(t, "30", resolved.ID)
+		assert.Equal(t, "20", resolved.BaseID)
+		assert.Len(t, resolved.Servers, 1)
+	})
+
+	t.Run("a more recent full bundle supersedes the deltas", func(t *testing.T) {
+		t.Parallel()
+		later := &Bundle{ID: "40", Registry: "central", CreatedAt: day(4)}
+		resolved, err := ResolveAfter([]*Bundle{first, later}, "10")
+		require.NoError(t, err)
+		assert.False(t, resolved.IsDelta())
+		assert.Equal(t, "40", resolved.ID)
+	})
+
+	t.Run("nothing more recent", func(t *testing.T) {
+		t.Parallel()
+		resolved, err := ResolveAfter([]*Bundle{full, first, second}, "30")
+		require.NoError(t, err)
+		assert.True(t, resolved.IsDelta())
+		assert.Equal(t, "30", resolved.ID)
+		assert.Empty(t, resolved.Servers)
+		assert.Empty(t, resolved.Deleted)
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		t.Parallel()
+		_, err := ResolveAfter([]*Bundle{full, first}, "30")
+		require.ErrorIs(t, err, ErrBundleRollback)
+	})
+
+	t.Run("delta not based on the last imported bundle", func(t *testing.T) {
+		t.Parallel()
+		_, err := ResolveAfter([]*Bundle{second}, "10")
+		require.ErrorContains(t, err, "delta bundle 30 requires its base bundle 20")
+	})
+
+	t.Run("without a last imported bundle", func(t *testing.T) {
+		t.Parallel()
+		resolved, err := ResolveAfter([]*Bundle{full, first}, "")
+		require.NoError(t, err)
+		assert.False(t, resolved.IsDelta())
+		assert.Equal(t, "20", resolved.ID)
+	})
+}
+
+func TestBundle_UpstreamRegistry(t *testing.T) {
+	t.Parallel()
+
+	bundle := &Bundle{
+		ID:        "10",
+		CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Servers:   []upstreamv0.ServerJSON{{Name: "com.example/a", Version: "1.0.0"}},
+	}
+	reg := bundle.UpstreamRegistry()
+	assert.Equal(t, "2026-01-02T03:04:05Z", reg.Meta.LastUpdated)
+	assert.Equal(t, bundle.Servers, reg.Data.Servers)
+}
@@ -0,0 +1,308 @@
+// Package scanning checks the entries of the sources with external scanners
+// before they are stored, by a sync or a publish.
+//
+// Each scanner receives an entry version as a JSON Request and answers with a
+// Result: a verdict and the findings supporting it. Two kinds of scanners are
+// supported:
+//
+//   - command: a local command, run for every entry with the request written to
+//     its standard input and the result read from its standard output
+//   - url: an HTTP endpoint the request is POSTed to, answering with the result
+//     in a 2xx response
+//
+// The verdicts of the scanners of a Pipeline are combined: an entry is blocked
+// if any scanner blocks it, and annotated with the findings of every scanner
+// otherwise.
+package scanning
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+)
+
+// Verdict is the decision of a scanner about an entry version
+type Verdict string
+
+const (
+	// VerdictAllow keeps the entry version without findings
+	VerdictAllow Verdict = "allow"
+	// VerdictAnnotate keeps the entry version and reports the findings
+	VerdictAnnotate Verdict = "annotate"
+	// VerdictBlock rejects the entry version
+	VerdictBlock Verdict = "block"
+)
+
+const (
+	userAgent = "toolhive-registry-server"
+
+	// maxResultSize is the maximum size of the result read from a scanner
+	maxResultSize = 1024 * 1024
+)
+
+// severity orders the verdicts, from the most permissive to the most restrictive
+func (v Verdict) severity() int {
+	switch v {
+	case VerdictAnnotate:
+		return 1
+	case VerdictBlock:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// Types of the scanned entries
+const (
+	EntryTypeServer = "server"
+	EntryTypeSkill  = "skill"
+	EntryTypePlugin = "plugin"
+)
+
+// Request is the document sent to a scanner for an entry version
+type Request struct {
+	// Type is the type of the entry: server, skill or plugin
+	Type string `json:"type"`
+	// Source is the name of the source providing the entry
+	Source  string `json:"source"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	// Entry is the server.json, skill or plugin of the version
+	Entry any `json:"entry"`
+}
+
+// Finding is an issue reported by a scanner about an entry version
+type Finding struct {
+	// Scanner is the name of the scanner reporting the finding. It is set by
+	// the pipeline, not taken from the scanner result.
+	Scanner  string `json:"scanner"`
+	ID       string `json:"id,omitempty"`
+	Severity string `json:"severity,omitempty"`
+	Message  string `json:"message"`
+}
+
+// Result is the outcome of the scan of an entry version
+type Result struct {
+	Verdict  Verdict   `json:"verdict"`
+	Findings []Finding `json:"findings,omitempty"`
+}
+
+// Blocked returns whether the entry version is rejected
+func (r *Result) Blocked() bool {
+	return r != nil && r.Verdict == VerdictBlock
+}
+
+// EntryScan is the result of the scan of an entry version of a sync, stored
+// along with the version
+type EntryScan struct {
+	Type    string
+	Name    string
+	Version string
+	Result  *Result
+}
+
+// Scanner checks an entry version
+type Scanner interface {
+	// Name returns the name of the scanner, reported with its findings
+	Name() string
+	// Scan returns the verdict of the scanner about an entry version
+	Scan(ctx context.Context, req *Request) (*Result, error)
+}
+
+// stage is a scanner of a pipeline with its failure policy
+type stage struct {
+	scanner    Scanner
+	failClosed bool
+}
+
+// Pipeline runs the scanners of an entry policy
+type Pipeline struct {
+	stages  []stage
+	sources []string
+}
+
+// NewPipeline creates the pipeline of an entry policy. Returns nil, a pipeline
+// applying to no source, for a nil configuration.
+func NewPipeline(cfg *config.EntryPolicyConfig) (*Pipeline, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	pipeline := &Pipeline{sources: cfg.Sources}
+	client := &http.Client{}
+	for i := range cfg.Scanners {
+		scannerCfg := &cfg.Scanners[i]
+		var scanner Scanner
+		switch {
+		case len(scannerCfg.Command) > 0:
+			scanner = &commandScanner{
+				name:    scannerCfg.Name,
+				command: scannerCfg.Command,
+				timeout: scannerCfg.GetTimeout(),
+			}
+		case scannerCfg.URL != "":
+			scanner = &httpScanner{
+				name:    scannerCfg.Name,
+				url:     scannerCfg.URL,
+				timeout: scannerCfg.GetTimeout(),
+				client:  client,
+			}
+		default:
+			return nil, fmt.Errorf("scanner %s: either command or url is required", scannerCfg.Name)
+		}
+		pipeline.stages = append(pipeline.stages, stage{scanner: scanner, failClosed: scannerCfg.FailClosed})
+	}
+	return pipeline, nil
+}
+
+// AppliesTo returns whether the entries of a source are scanned
+func (p *Pipeline) AppliesTo(source string) bool {
+	if p == nil || len(p.stages) == 0 {
+		return false
+	}
+	return len(p.sources) == 0 || slices.Contains(p.sources, source)
+}
+
+// Evaluate scans an entry version with every scanner of the pipeline and
+// combines their verdicts. A scanner failing to scan the entry blocks it if it
+// fails closed, and annotates it with the failure otherwise.
+func (p *Pipeline) Evaluate(ctx context.Context, req *Request) *Result {
+	combined := &Result{Verdict: VerdictAllow}
+	for _, stage := range p.stages {
+		name := stage.scanner.Name()
+		result, err := stage.scanner.Scan(ctx, req)
+		if err != nil {
+			verdict := VerdictAnnotate
+			if stage.failClosed {
+				verdict = VerdictBlock
+			}
+			result = &Result{
+				Verdict:  verdict,
+				Findings: []Finding{{Severity: "error", Message: fmt.Sprintf("scan failed: %v", err)}},
+			}
+		}
+
+		if result.Verdict.severity() > combined.Verdict.severity() {
+			combined.Verdict = result.Verdict
+		}
+		for _, finding := range result.Findings {
+			finding.Scanner = name
+			combined.Findings = append(combined.Findings, finding)
+		}
+	}
+	return combined
+}
+
+// parseResult parses and validates the result returned by a scanner
+func parseResult(data []byte) (*Result, error) {
+	var result Result
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("invalid result: %w", err)
+	}
+	switch result.Verdict {
+	case VerdictAllow, VerdictAnnotate, VerdictBlock:
+	default:
+		return nil, fmt.Errorf("invalid result: unknown verdict %q", result.Verdict)
+	}
+	return &result, nil
+}
+
+// commandScanner runs a local command for every entry version
+type commandScanner struct {
+	name    string
+	command []string
+	timeout time.Duration
+}
+
+// Name returns the name of the scanner
+func (s *commandScanner) Name() string {
+	return s.name
+}
+
+// Scan writes the request to the standard input of the command and reads the
+// result from its standard output. The command must exit with status 0.
+func (s *commandScanner) Scan(ctx context.Context, req *Request) (*Result, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	//nolint:gosec // The command is set by the server configuration, not by callers
+	cmd := exec.CommandContext(ctx, s.command[0], s.command[1:]...)
+	cmd.Stdin = bytes.NewReader(payload)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+	if stdout.Len() > maxResultSize {
+		return nil, fmt.Errorf("result exceeds %d bytes", maxResultSize)
+	}
+	return parseResult(stdout.Bytes())
+}
+
+// httpScanner posts every entry version to an HTTP endpoint
+type httpScanner struct {
+	name    string
+	url     string
+	timeout time.Duration
+	client  *http.Client
+}
+
+// Name returns the name of the scanner
+func (s *httpScanner) Name() string {
+	return s.name
+}
+
+// Scan posts the request to the endpoint and reads the result from the body
+// of a 2xx response.
+func (s *httpScanner) Scan(ctx context.Context, req *Request) (*Result, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("User-Agent", userAgent)
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResultSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	if len(body) > maxResultSize {
+		return nil, fmt.Errorf("result exceeds %d bytes", maxResultSize)
+	}
+	return parseResult(body)
+}
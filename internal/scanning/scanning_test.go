@@ -0,0 +1,189 @@
+package scanning
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+)
+
+// newScanServer starts an HTTP scanner answering with status and body, and
+// recording the requests it receives.
+func newScanServer(t *testing.T, status int, body string, received *[]Request) string {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err == nil && received != nil {
+			*received = append(*received, req)
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestPipelineEvaluate(t *testing.T) {
+	t.Parallel()
+
+	req := &Request{
+		Type:    "server",
+		Source:  "upstream",
+		Name:    "com.example/server",
+		Version: "1.0.0",
+		Entry:   map[string]any{"name": "com.example/server"},
+	}
+	allow := `{"verdict": "allow"}`
+	annotate := `{"verdict": "annotate", "findings": [{"id": "CVE-1", "severity": "high", "message": "vulnerable"}]}`
+	block := `{"verdict": "block", "findings": [{"message": "malicious"}]}`
+
+	tests := []struct {
+		name         string
+		scanners     func(t *testing.T) []config.ScannerConfig
+		wantVerdict  Verdict
+		wantFindings []Finding
+	}{
+		{
+			name: "allowed by every scanner",
+			scanners: func(t *testing.T) []config.ScannerConfig {
+				t.Helper()
+				return []config.ScannerConfig{
+					{Name: "osv", URL: newScanServer(t, http.StatusOK, allow, nil)},
+					{Name: "license", Command: []string{"/bin/sh", "-c", "cat >/dev/null; echo '" + allow + "'"}},
+				}
+			},
+			wantVerdict: VerdictAllow,
+		},
+		{
+			name: "annotated by a scanner",
+			scanners: func(t *testing.T) []config.ScannerConfig {
+				t.Helper()
+				return []config.ScannerConfig{
+					{Name: "osv", URL: newScanServer(t, http.StatusOK, annotate, nil)},
+					{Name: "license", URL: newScanServer(t, http.StatusOK, allow, nil)},
+				}
+			},
+			wantVerdict: VerdictAnnotate,
+			wantFindings: []Finding{
+				{Scanner: "osv", ID: "CVE-1", Severity: "high", Message: "vulnerable"},
+			},
+		},
+		{
+			name: "blocked by a scanner",
+			scanners: func(t *testing.T) []config.ScannerConfig {
+				t.Helper()
+				return []config.ScannerConfig{
+					{Name: "osv", URL: newScanServer(t, http.StatusOK, annotate, nil)},
+					{Name: "malware", Command: []string{"/bin/sh", "-c", "cat >/dev/null; echo '" + block + "'"}},
+				}
+			},
+			wantVerdict: VerdictBlock,
+			wantFindings: []Finding{
+				{Scanner: "osv", ID: "CVE-1", Severity: "high", Message: "vulnerable"},
+				{Scanner: "malware", Message: "malicious"},
+			},
+		},
+		{
+			name: "failing scanner annotates",
+			scanners: func(t *testing.T) []config.ScannerConfig {
+				t.Helper()
+				return []config.ScannerConfig{
+					{Name: "osv", URL: newScanServer(t, http.StatusInternalServerError, "", nil)},
+				}
+			},
+			wantVerdict: VerdictAnnotate,
+			wantFindings: []Finding{
+				{Scanner: "osv", Severity: "error", Message: "scan failed: unexpected status 500"},
+			},
+		},
+		{
+			name: "failing scanner blocks when failing closed",
+			scanners: func(t *testing.T) []config.ScannerConfig {
+				t.Helper()
+				return []config.ScannerConfig{
+					{Name: "license", Command: []string{"/bin/sh", "-c", "echo unavailable >&2; exit 3"}, FailClosed: true},
+				}
+			},
+			wantVerdict: VerdictBlock,
+			wantFindings: []Finding{
+				{Scanner: "license", Severity: "error", Message: "scan failed: exit status 3: unavailable"},
+			},
+		},
+		{
+			name: "unknown verdict is a failure",
+			scanners: func(t *testing.T) []config.ScannerConfig {
+				t.Helper()
+				return []config.ScannerConfig{
+					{Name: "osv", URL: newScanServer(t, http.StatusOK, `{"verdict": "maybe"}`, nil)},
+				}
+			},
+			wantVerdict: VerdictAnnotate,
+			wantFindings: []Finding{
+				{Scanner: "osv", Severity: "error", Message: `scan failed: invalid result: unknown verdict "maybe"`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			pipeline, err := NewPipeline(&config.EntryPolicyConfig{Scanners: tt.scanners(t)})
+			require.NoError(t, err)
+
+			result := pipeline.Evaluate(t.Context(), req)
+			assert.Equal(t, tt.wantVerdict, result.Verdict)
+			assert.Equal(t, tt.wantFindings, result.Findings)
+			assert.Equal(t, tt.wantVerdict == VerdictBlock, result.Blocked())
+		})
+	}
+}
+
+func TestPipelineSendsRequest(t *testing.T) {
+	t.Parallel()
+
+	var received []Request
+	pipeline, err := NewPipeline(&config.EntryPolicyConfig{Scanners: []config.ScannerConfig{
+		{Name: "osv", URL: newScanServer(t, http.StatusOK, `{"verdict": "allow"}`, &received)},
+	}})
+	require.NoError(t, err)
+
+	pipeline.Evaluate(t.Context(), &Request{
+		Type:    "skill",
+		Source:  "upstream",
+		Name:    "calendar",
+		Version: "1.0.0",
+		Entry:   map[string]any{"namespace": "io.github.acme"},
+	})
+	require.Len(t, received, 1)
+	assert.Equal(t, "skill", received[0].Type)
+	assert.Equal(t, "upstream", received[0].Source)
+	assert.Equal(t, "calendar", received[0].Name)
+	assert.Equal(t, "1.0.0", received[0].Version)
+	assert.Equal(t, map[string]any{"namespace": "io.github.acme"}, received[0].Entry)
+}
+
+func TestPipelineAppliesTo(t *testing.T) {
+	t.Parallel()
+
+	var nilPipeline *Pipeline
+	assert.False(t, nilPipeline.AppliesTo("upstream"))
+
+	pipeline, err := NewPipeline(nil)
+	require.NoError(t, err)
+	assert.Nil(t, pipeline)
+
+	scanners := []config.ScannerConfig{{Name: "osv", URL: "https://scanner.example.com/scan"}}
+	pipeline, err = NewPipeline(&config.EntryPolicyConfig{Scanners: scanners})
+	require.NoError(t, err)
+	assert.True(t, pipeline.AppliesTo("upstream"))
+
+	pipeline, err = NewPipeline(&config.EntryPolicyConfig{Sources: []string{"internal"}, Scanners: scanners})
+	require.NoError(t, err)
+	assert.False(t, pipeline.AppliesTo("upstream"))
+	assert.True(t, pipeline.AppliesTo("internal"))
+}
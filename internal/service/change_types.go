@@ -0,0 +1,34 @@
+// Package service defines the change feed types returned by the service layer.
+package service
+
+import "time"
+
+// Change types of the entries in a change feed
+const (
+	// ChangeTypeCreated is the type of the changes that add an entry version
+	ChangeTypeCreated = "created"
+	// ChangeTypeUpdated is the type of the changes to the content, status or claims of an entry version
+	ChangeTypeUpdated = "updated"
+	// ChangeTypeDeleted is the type of the changes that remove an entry version
+	ChangeTypeDeleted = "deleted"
+)
+
+// EntryChange is a change of a server, skill or plugin version in the change
+// feed of a registry.
+type EntryChange struct {
+	Token     string    `json:"token"`               // resume the feed after this change
+	Type      string    `json:"type"`                // ChangeTypeCreated, ChangeTypeUpdated, or ChangeTypeDeleted
+	EntryType string    `json:"entryType"`           // EntryTypeServer, EntryTypeSkill, or EntryTypePlugin
+	Namespace string    `json:"namespace,omitempty"` // skills and plugins only
+	Name      string    `json:"name"`
+	Version   string    `json:"version"`
+	ChangedAt time.Time `json:"changedAt"`
+}
+
+// EntryChangesResult contains the result of a ListEntryChanges operation.
+// NextToken resumes the feed after the returned changes, or at the same
+// position when no change was returned.
+type EntryChangesResult struct {
+	Changes   []*EntryChange `json:"changes"`
+	NextToken string         `json:"-"`
+}
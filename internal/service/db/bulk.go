@@ -0,0 +1,314 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/otel"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/validators"
+)
+
+// BulkPublishEntries publishes entries into the managed source, reporting the
+// outcome of each. Each entry is published in its own transaction, unless the
+// operation is atomic. Bulk publishes carry no signatures: the entries of the
+// namespaces requiring them are rejected before anything is published.
+func (s *dbService) BulkPublishEntries(
+	ctx context.Context,
+	entries []service.PortableEntry,
+	opts ...service.Option,
+) (*service.BulkPublishResult, error) {
+	ctx, span := s.startSpan(ctx, "dbService.BulkPublishEntries")
+	defer span.End()
+	start := time.Now()
+
+	options := &service.BulkPublishEntriesOptions{}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			otel.RecordError(span, err)
+			return nil, fmt.Errorf("invalid option: %w", err)
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Bool("bulk.atomic", options.Atomic),
+		attribute.Int("bulk.entry_count", len(entries)),
+	)
+
+	unsigned, err := s.checkBulkSignatures(ctx, entries)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+
+	var result *service.BulkPublishResult
+	if options.Atomic {
+		result, err = s.bulkPublishAtomic(ctx, entries, unsigned, options.JWTClaims)
+		if err != nil {
+			otel.RecordError(span, err)
+			return nil, err
+		}
+	} else {
+		result = s.bulkPublishEach(ctx, entries, unsigned, options.JWTClaims)
+	}
+
+	slog.InfoContext(ctx, "Bulk publish completed",
+		"duration_ms", time.Since(start).Milliseconds(),
+		"atomic", options.Atomic,
+		"committed", result.Committed,
+		"entry_count", len(entries),
+		"request_id", middleware.GetReqID(ctx))
+
+	return result, nil
+}
+
+// bulkPublishEach publishes the entries one by one, each in its own
+// transaction, carrying on past the entries that fail. The entries with an
+// error in unsigned fail without being published.
+func (s *dbService) bulkPublishEach(
+	ctx context.Context, entries []service.PortableEntry, unsigned []error, jwtClaims map[string]any,
+) *service.BulkPublishResult {
+	result := &service.BulkPublishResult{
+		Committed: true,
+		Results:   make([]service.BulkEntryResult, len(entries)),
+	}
+
+	for i := range entries {
+		entry := &entries[i]
+		if unsigned[i] != nil {
+			result.Results[i] = newBulkEntryResult(entry, service.BulkEntryFailed)
+			result.Results[i].Err = unsigned[i]
+			continue
+		}
+		opts := []service.Option{service.WithJWTClaims(jwtClaims)}
+		if entry.Claims != nil {
+			opts = append(opts, service.WithClaims(entry.Claims))
+		}
+
+		var err error
+		switch {
+		case entry.Server != nil:
+			_, err = s.PublishServerVersion(ctx, append(opts, service.WithServerData(entry.Server))...)
+		case entry.Skill != nil:
+			_, err = s.PublishSkill(ctx, entry.Skill, opts...)
+		default:
+			_, err = s.PublishPlugin(ctx, entry.Plugin, opts...)
+		}
+
+		result.Results[i] = newBulkEntryResult(entry, service.BulkEntryPublished)
+		var pending *service.PendingReviewError
+		switch {
+		case errors.As(err, &pending):
+			result.Results[i].Status = service.BulkEntryPendingReview
+			result.Results[i].SubmissionID = pending.Submission.ID
+		case err != nil:
+			result.Results[i].Status = service.BulkEntryFailed
+			result.Results[i].Err = err
+		}
+	}
+
+	return result
+}
+
+// bulkPublishAtomic publishes the entries in a single transaction. When an
+// entry fails, the transaction is rolled back and the other entries are
+// reported as skipped. An error in unsigned fails the entry before the
+// transaction.
+func (s *dbService) bulkPublishAtomic(
+	ctx context.Context, entries []service.PortableEntry, unsigned []error, jwtClaims map[string]any,
+) (*service.BulkPublishResult, error) {
+	gateClaims := jwtClaims
+	if s.skipAuthz {
+		gateClaims = nil
+	}
+
+	result := &service.BulkPublishResult{
+		Atomic:  true,
+		Results: make([]service.BulkEntryResult, len(entries)),
+	}
+	for i := range entries {
+		result.Results[i] = newBulkEntryResult(&entries[i], service.BulkEntrySkipped)
+	}
+	fail := func(i int, err error) (*service.BulkPublishResult, error) {
+		result.Results[i].Status = service.BulkEntryFailed
+		result.Results[i].Err = err
+		for j := range result.Results {
+			if j != i {
+				result.Results[j].Status = service.BulkEntrySkipped
+				result.Results[j].SubmissionID = ""
+			}
+		}
+		return result, nil
+	}
+
+	// Validate every entry before touching the database
+	claimsJSON := make([][]byte, len(entries))
+	for i := range entries {
+		if unsigned[i] != nil {
+			return fail(i, unsigned[i])
+		}
+		var err error
+		claimsJSON[i], err = prepareBulkEntry(ctx, &entries[i], gateClaims)
+		if err != nil {
+			return fail(i, err)
+		}
+	}
+
+	// Pin the OCI packages of every entry and scan it before the transaction
+	checks := make([]*publishChecks, len(entries))
+	for i := range entries {
+		var err error
+		checks[i], err = s.preparePublish(ctx, &entries[i], gateClaims)
+		if err != nil {
+			return fail(i, err)
+		}
+	}
+
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.Serializable,
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		err := tx.Rollback(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.WarnContext(ctx, "Failed to rollback transaction", "error", err)
+		}
+	}()
+
+	querier := sqlc.New(tx)
+
+	source, err := getManagedSource(ctx, querier)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range entries {
+		entry := &entries[i]
+		var submission *service.EntrySubmission
+		switch {
+		case entry.Server != nil:
+			submission, err = s.publishServerInTx(ctx, querier, source, entry.Server, claimsJSON[i], gateClaims, nil, checks[i])
+		case entry.Skill != nil:
+			submission, err = s.publishSkillInTx(ctx, querier, source, entry.Skill, claimsJSON[i], gateClaims, nil, checks[i])
+		default:
+			submission, err = s.publishPluginInTx(ctx, querier, source, entry.Plugin, claimsJSON[i], gateClaims, nil, checks[i])
+		}
+		if err != nil {
+			return fail(i, err)
+		}
+
+		result.Results[i].Status = service.BulkEntryPublished
+		if submission != nil {
+			result.Results[i].Status = service.BulkEntryPendingReview
+			result.Results[i].SubmissionID = submission.ID
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	result.Committed = true
+	return result, nil
+}
+
+// checkBulkSignatures checks the entries of a bulk publish against the signing
+// configuration of the managed source. Bulk publishes carry no signatures, so
+// the entries of the namespaces requiring them cannot be published: their
+// errors are returned by index, nil for the other entries.
+func (s *dbService) checkBulkSignatures(ctx context.Context, entries []service.PortableEntry) ([]error, error) {
+	source, err := getManagedSource(ctx, sqlc.New(s.pool))
+	if err != nil {
+		return nil, err
+	}
+
+	unsigned := make([]error, len(entries))
+	for i := range entries {
+		var name string
+		switch entry := &entries[i]; {
+		case entry.Server != nil:
+			name = entry.Server.Name
+		case entry.Skill != nil:
+			name = signedEntryName(entry.Skill.Namespace, entry.Skill.Name)
+		default:
+			name = signedEntryName(entry.Plugin.Namespace, entry.Plugin.Name)
+		}
+		_, err := verifyEntrySignature(source, name, nil)
+		switch {
+		case errors.Is(err, service.ErrSignatureRequired):
+			unsigned[i] = fmt.Errorf("%w: bulk publishes carry no signatures, publish the entry on its own with its signature", err)
+		case err != nil:
+			return nil, err
+		}
+	}
+	return unsigned, nil
+}
+
+// prepareBulkEntry runs the checks PublishServerVersion, PublishSkill and
+// PublishPlugin make before their transaction, and returns the serialized
+// claims of the entry.
+func prepareBulkEntry(ctx context.Context, entry *service.PortableEntry, gateClaims map[string]any) ([]byte, error) {
+	switch {
+	case entry.Server != nil:
+		if !validators.IsValidServerName(entry.Server.Name) {
+			return nil, fmt.Errorf("%w: %s", service.ErrInvalidServerName, entry.Server.Name)
+		}
+	case entry.Skill != nil:
+		if entry.Skill.Namespace == "" || entry.Skill.Name == "" || entry.Skill.Version == "" {
+			return nil, fmt.Errorf("namespace, name, and version are required")
+		}
+	default:
+		if entry.Plugin.Namespace == "" || entry.Plugin.Name == "" || entry.Plugin.Version == "" {
+			return nil, fmt.Errorf("namespace, name, and version are required")
+		}
+	}
+
+	// Validate published claims are a subset of the publisher's JWT claims
+	if err := validateClaimsSubset(ctx, gateClaims, entry.Claims); err != nil {
+		return nil, err
+	}
+
+	if entry.Claims == nil {
+		return nil, nil
+	}
+	claimsJSON, err := json.Marshal(entry.Claims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize claims: %w", err)
+	}
+	return claimsJSON, nil
+}
+
+// newBulkEntryResult returns the result of a bulk published entry with the
+// given status.
+func newBulkEntryResult(entry *service.PortableEntry, status string) service.BulkEntryResult {
+	result := service.BulkEntryResult{
+		EntryType: entry.EntryType(),
+		Status:    status,
+	}
+	switch {
+	case entry.Server != nil:
+		result.Name = entry.Server.Name
+		result.Version = entry.Server.Version
+	case entry.Skill != nil:
+		result.Namespace = entry.Skill.Namespace
+		result.Name = entry.Skill.Name
+		result.Version = entry.Skill.Version
+	default:
+		result.Namespace = entry.Plugin.Namespace
+		result.Name = entry.Plugin.Name
+		result.Version = entry.Plugin.Version
+	}
+	return result
+}
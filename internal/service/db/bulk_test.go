@@ -0,0 +1,193 @@
+package database
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/auth"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+func TestBulkPublishEntries(t *testing.T) {
+	t.Parallel()
+
+	owner := map[string]any{"org": "acme"}
+	entries := func(names ...string) []service.PortableEntry {
+		result := make([]service.PortableEntry, 0, len(names))
+		for _, name := range names {
+			result = append(result, service.PortableEntry{
+				Server: &upstreamv0.ServerJSON{Name: name, Description: "Server", Version: "1.0.0"},
+				Claims: owner,
+			})
+		}
+		result = append(result, service.PortableEntry{
+			Skill:  &service.Skill{Namespace: "com.acme", Name: "skill", Description: "Skill", Version: "1.0.0"},
+			Claims: owner,
+		})
+		return result
+	}
+	statuses := func(result *service.BulkPublishResult) []string {
+		statuses := make([]string, len(result.Results))
+		for i := range result.Results {
+			statuses[i] = result.Results[i].Status
+		}
+		return statuses
+	}
+
+	t.Run("each entry", func(t *testing.T) {
+		t.Parallel()
+
+		svc, cleanup := setupTestService(t)
+		t.Cleanup(cleanup)
+		createManagedSourceWithRegistry(t, svc, "bulk-source")
+		ctx := context.Background()
+
+		_, err := svc.PublishServerVersion(ctx,
+			service.WithServerData(&upstreamv0.ServerJSON{Name: "com.acme/existing", Version: "1.0.0"}),
+			service.WithClaims(owner),
+		)
+		require.NoError(t, err)
+
+		result, err := svc.BulkPublishEntries(ctx, entries("com.acme/new", "com.acme/existing"),
+			service.WithJWTClaims(owner))
+		require.NoError(t, err)
+		require.True(t, result.Committed)
+		require.Equal(t, []string{
+			service.BulkEntryPublished, service.BulkEntryFailed, service.BulkEntryPublished,
+		}, statuses(result))
+		require.ErrorIs(t, result.Results[1].Err, service.ErrVersionAlreadyExists)
+
+		claims, err := svc.GetEntryClaims(ctx,
+			service.WithEntryType(service.EntryTypeServer), service.WithName("com.acme/new"))
+		require.NoError(t, err)
+		require.Equal(t, owner, claims)
+	})
+
+	t.Run("atomic", func(t *testing.T) {
+		t.Parallel()
+
+		svc, cleanup := setupTestService(t)
+		t.Cleanup(cleanup)
+		createManagedSourceWithRegistry(t, svc, "bulk-source")
+		ctx := context.Background()
+
+		_, err := svc.PublishServerVersion(ctx,
+			service.WithServerData(&upstreamv0.ServerJSON{Name: "com.acme/existing", Version: "1.0.0"}),
+			service.WithClaims(owner),
+		)
+		require.NoError(t, err)
+
+		result, err := svc.BulkPublishEntries(ctx, entries("com.acme/new", "com.acme/existing"),
+			service.WithAtomic(), service.WithJWTClaims(owner))
+		require.NoError(t, err)
+		require.False(t, result.Committed)
+		require.Equal(t, []string{
+			service.BulkEntrySkipped, service.BulkEntryFailed, service.BulkEntrySkipped,
+		}, statuses(result))
+
+		// Nothing was published
+		_, err = svc.GetEntryClaims(ctx,
+			service.WithEntryType(service.EntryTypeServer), service.WithName("com.acme/new"))
+		require.ErrorIs(t, err, service.ErrNotFound)
+
+		result, err = svc.BulkPublishEntries(ctx, entries("com.acme/new", "com.acme/other"),
+			service.WithAtomic(), service.WithJWTClaims(owner))
+		require.NoError(t, err)
+		require.True(t, result.Committed)
+		require.Equal(t, []string{
+			service.BulkEntryPublished, service.BulkEntryPublished, service.BulkEntryPublished,
+		}, statuses(result))
+
+		// Claims the caller does not hold fail before the transaction
+		result, err = svc.BulkPublishEntries(ctx, entries("com.acme/third"),
+			service.WithAtomic(), service.WithJWTClaims(map[string]any{"org": "other"}))
+		require.NoError(t, err)
+		require.False(t, result.Committed)
+		require.ErrorIs(t, result.Results[0].Err, service.ErrClaimsInsufficient)
+	})
+}
+
+func TestExportSource(t *testing.T) {
+	t.Parallel()
+
+	svc, cleanup := setupTestService(t)
+	t.Cleanup(cleanup)
+	createManagedSourceWithRegistry(t, svc, "export-source")
+	ctx := context.Background()
+
+	owner := map[string]any{"org": "acme"}
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		_, err := svc.PublishServerVersion(ctx,
+			service.WithServerData(&upstreamv0.ServerJSON{Name: "com.acme/weather", Description: "Weather", Version: version}),
+			service.WithClaims(owner),
+		)
+		require.NoError(t, err)
+	}
+	_, err := svc.PublishPlugin(ctx,
+		&service.Plugin{Namespace: "com.acme", Name: "radar", Description: "Radar", Version: "1.0.0"},
+		service.WithClaims(owner))
+	require.NoError(t, err)
+	_, err = svc.PublishSkill(ctx,
+		&service.Skill{Namespace: "com.acme", Name: "forecast", Description: "Forecasts", Version: "1.0.0"})
+	require.NoError(t, err)
+
+	var exported []*service.PortableEntry
+	require.NoError(t, svc.ExportSource(ctx, "export-source", func(entry *service.PortableEntry) error {
+		exported = append(exported, entry)
+		return nil
+	}))
+
+	require.Len(t, exported, 4)
+	require.Equal(t, "com.acme/weather", exported[0].Server.Name)
+	require.Equal(t, "1.0.0", exported[0].Server.Version)
+	require.Equal(t, "1.1.0", exported[1].Server.Version)
+	require.Equal(t, owner, exported[1].Claims)
+	require.Equal(t, "forecast", exported[2].Skill.Name)
+	require.Empty(t, exported[2].Claims)
+	require.Equal(t, "radar", exported[3].Plugin.Name)
+	require.Equal(t, owner, exported[3].Claims)
+
+	err = svc.ExportSource(ctx, "unknown", func(*service.PortableEntry) error { return nil })
+	require.ErrorIs(t, err, service.ErrSourceNotFound)
+
+	// Callers not covering the claims of the source do not see it
+	err = svc.ExportSource(auth.ContextWithClaims(ctx, jwt.MapClaims{"org": "other"}), "export-source",
+		func(*service.PortableEntry) error { return nil })
+	require.ErrorIs(t, err, service.ErrSourceNotFound)
+}
+
+func TestBulkPublishEntries_SignatureRequired(t *testing.T) {
+	t.Parallel()
+
+	svc, cleanup := setupTestService(t)
+	t.Cleanup(cleanup)
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	createSigningSourceWithRegistry(t, svc, "bulk-signing-source", pub, false)
+	ctx := context.Background()
+
+	entries := []service.PortableEntry{
+		{Server: &upstreamv0.ServerJSON{Name: "io.other/server", Description: "Server", Version: "1.0.0"}},
+		{Skill: &service.Skill{Namespace: "com.example", Name: "calendar", Description: "Skill", Version: "1.0.0"}},
+	}
+
+	result, err := svc.BulkPublishEntries(ctx, entries)
+	require.NoError(t, err)
+	require.Equal(t, service.BulkEntryPublished, result.Results[0].Status)
+	require.Equal(t, service.BulkEntryFailed, result.Results[1].Status)
+	require.ErrorIs(t, result.Results[1].Err, service.ErrSignatureRequired)
+	require.ErrorContains(t, result.Results[1].Err, "bulk publishes carry no signatures")
+
+	entries[0].Server.Version = "2.0.0"
+	result, err = svc.BulkPublishEntries(ctx, entries, service.WithAtomic())
+	require.NoError(t, err)
+	require.False(t, result.Committed)
+	require.Equal(t, service.BulkEntrySkipped, result.Results[0].Status)
+	require.ErrorIs(t, result.Results[1].Err, service.ErrSignatureRequired)
+}
@@ -0,0 +1,220 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/otel"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// ListEntryChanges returns the changes of the servers, skills and plugins of a
+// registry made after a change token, oldest first. Change tokens are the ids of
+// the changes, which are assigned in commit order.
+func (s *dbService) ListEntryChanges(
+	ctx context.Context,
+	opts ...service.Option,
+) (*service.EntryChangesResult, error) {
+	ctx, span := s.startSpan(ctx, "dbService.ListEntryChanges")
+	defer span.End()
+
+	options := &service.ListEntryChangesOptions{
+		Limit: service.DefaultPageSize,
+	}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			otel.RecordError(span, err)
+			return nil, err
+		}
+	}
+
+	if options.RegistryName == "" {
+		return nil, fmt.Errorf("registry name is required")
+	}
+
+	span.SetAttributes(otel.AttrRegistryName.String(options.RegistryName))
+
+	if options.Limit > service.MaxPageSize {
+		options.Limit = service.MaxPageSize
+	}
+
+	var afterID int64
+	if options.Since != "" {
+		id, err := decodeChangeToken(options.Since)
+		if err != nil {
+			otel.RecordError(span, err)
+			return nil, err
+		}
+		afterID = id
+	}
+
+	gateClaims := options.Claims
+	if s.skipAuthz {
+		gateClaims = nil
+	}
+	registryID, err := lookupRegistryIDWithGate(ctx, s.pool, options.RegistryName, gateClaims)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+
+	querier := sqlc.New(s.pool)
+	bounds, err := querier.GetEntryChangeBounds(ctx)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, fmt.Errorf("failed to get entry change bounds: %w", err)
+	}
+
+	if options.FromLatest {
+		return &service.EntryChangesResult{
+			Changes:   []*service.EntryChange{},
+			NextToken: encodeChangeToken(bounds.NewestID),
+		}, nil
+	}
+
+	// Changes right after the token were pruned when the oldest retained change is more recent
+	if afterID > 0 && bounds.OldestID > afterID+1 {
+		err := fmt.Errorf("%w: changes after %s are no longer retained", service.ErrChangeTokenExpired, options.Since)
+		otel.RecordError(span, err)
+		return nil, err
+	}
+
+	claimsFilter := newClaimsFilterWith(
+		ctx, options.Claims,
+		func(record any) ([]byte, bool) {
+			r, ok := record.(sqlc.ListRegistryEntryChangesRow)
+			return r.Claims, ok
+		},
+	)
+	if s.skipAuthz {
+		claimsFilter = nil
+	}
+	params := sqlc.ListRegistryEntryChangesParams{
+		RegistryID: registryID,
+		AfterID:    afterID,
+		Size:       int64(options.Limit),
+	}
+	rows, lastID, err := streamChangeRows(ctx, querier, params, claimsFilter, options.Limit)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+
+	changes := make([]*service.EntryChange, len(rows))
+	for i, row := range rows {
+		changes[i] = changeRowToEntryChange(row)
+	}
+
+	return &service.EntryChangesResult{
+		Changes:   changes,
+		NextToken: encodeChangeToken(lastID),
+	}, nil
+}
+
+// streamChangeRows fetches changes in batches, applying the auth filter to each row,
+// until limit rows are accumulated or the DB is exhausted. It returns the rows and the
+// id of the last row read, so that changes filtered out are not read again.
+func streamChangeRows(
+	ctx context.Context,
+	querier sqlc.Querier,
+	params sqlc.ListRegistryEntryChangesParams,
+	filter service.RecordFilter,
+	limit int,
+) ([]sqlc.ListRegistryEntryChangesRow, int64, error) {
+	accumulated := []sqlc.ListRegistryEntryChangesRow{}
+	lastID := params.AfterID
+	batchParams := params
+
+	for {
+		batch, err := querier.ListRegistryEntryChanges(ctx, batchParams)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to list entry changes: %w", err)
+		}
+
+		for _, row := range batch {
+			if len(accumulated) == limit {
+				return accumulated, lastID, nil
+			}
+			keep := true
+			if filter != nil {
+				var ferr error
+				keep, ferr = filter(ctx, row)
+				if ferr != nil {
+					return nil, 0, ferr
+				}
+			}
+			if keep {
+				accumulated = append(accumulated, row)
+			}
+			lastID = row.ID
+		}
+
+		if len(accumulated) == limit || int64(len(batch)) < batchParams.Size {
+			return accumulated, lastID, nil
+		}
+		batchParams.AfterID = lastID
+	}
+}
+
+// changeRowToEntryChange maps a sqlc ListRegistryEntryChangesRow to a service EntryChange.
+func changeRowToEntryChange(row sqlc.ListRegistryEntryChangesRow) *service.EntryChange {
+	change := &service.EntryChange{
+		Token:     encodeChangeToken(row.ID),
+		Type:      strings.ToLower(string(row.ChangeType)),
+		EntryType: entryTypeName(row.EntryType),
+		Name:      row.Name,
+		Version:   row.Version,
+		ChangedAt: row.ChangedAt,
+	}
+	if row.Namespace != nil {
+		change.Namespace = *row.Namespace
+	}
+	return change
+}
+
+// encodeChangeToken returns the change token of the change with the given id.
+func encodeChangeToken(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+// decodeChangeToken returns the id of the change of a change token.
+func decodeChangeToken(token string) (int64, error) {
+	id, err := strconv.ParseInt(token, 10, 64)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("%w: %s", service.ErrInvalidChangeToken, token)
+	}
+	return id, nil
+}
+
+// recordEntryChange appends a change of an entry version of a managed source to
+// the change feed. It must run in the transaction that makes the change, before
+// an orphaned entry is removed, so that the change copies the claims of the entry.
+func recordEntryChange(
+	ctx context.Context,
+	querier *sqlc.Queries,
+	sourceID uuid.UUID,
+	entryType sqlc.EntryType,
+	namespace *string,
+	name, version string,
+	changeType sqlc.EntryChangeType,
+) error {
+	if err := querier.LockEntryChanges(ctx); err != nil {
+		return fmt.Errorf("failed to lock entry changes: %w", err)
+	}
+	if err := querier.InsertEntryChange(ctx, sqlc.InsertEntryChangeParams{
+		EntryType:  entryType,
+		Namespace:  namespace,
+		Name:       name,
+		Version:    version,
+		ChangeType: changeType,
+		SourceID:   sourceID,
+	}); err != nil {
+		return fmt.Errorf("failed to record entry change: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+func TestDecodeChangeToken(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		token   string
+		want    int64
+		wantErr bool
+	}{
+		{name: "change id", token: "42", want: 42},
+		{name: "start of the feed", token: "0", want: 0},
+		{name: "negative id", token: "-1", wantErr: true},
+		{name: "not a number", token: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			id, err := decodeChangeToken(tt.token)
+			if tt.wantErr {
+				require.ErrorIs(t, err, service.ErrInvalidChangeToken)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, id)
+			require.Equal(t, tt.token, encodeChangeToken(id))
+		})
+	}
+}
+
+// publishChangeFeedEntries publishes a server, a skill and a plugin, then deletes
+// the server, so that the change feed of the registry holds four changes.
+func publishChangeFeedEntries(t *testing.T, svc *dbService, registryName string) {
+	t.Helper()
+
+	ctx := context.Background()
+	_, err := svc.pool.Exec(ctx, `UPDATE registry SET claims = '{"org": "acme"}' WHERE name = $1`, registryName)
+	require.NoError(t, err)
+
+	acme := map[string]any{"org": "acme"}
+	_, err = svc.PublishServerVersion(ctx,
+		service.WithServerData(&upstreamv0.ServerJSON{
+			Name:        "com.example/weather",
+			Description: "Weather forecasts",
+			Version:     "1.0.0",
+		}),
+		service.WithClaims(acme),
+	)
+	require.NoError(t, err)
+	_, err = svc.PublishSkill(ctx, &service.Skill{
+		Namespace:   "com.example",
+		Name:        "calendar",
+		Description: "Plans meetings",
+		Version:     "1.0.0",
+	}, service.WithClaims(acme))
+	require.NoError(t, err)
+	_, err = svc.PublishPlugin(ctx, &service.Plugin{
+		Namespace:   "com.example",
+		Name:        "alerts",
+		Description: "Alerts on severe weather",
+		Version:     "2.0.0",
+	}, service.WithClaims(map[string]any{"org": "acme", "team": "ops"}))
+	require.NoError(t, err)
+	require.NoError(t, svc.DeleteServerVersion(ctx,
+		service.WithName("com.example/weather"),
+		service.WithVersion("1.0.0"),
+	))
+}
+
+func TestListEntryChanges(t *testing.T) {
+	t.Parallel()
+
+	svc, cleanup := setupTestService(t)
+	t.Cleanup(cleanup)
+
+	const registryName = "changes-registry"
+	createManagedSourceWithRegistry(t, svc, registryName)
+	publishChangeFeedEntries(t, svc, registryName)
+	ctx := context.Background()
+
+	changeKeys := func(changes []*service.EntryChange) []string {
+		keys := make([]string, len(changes))
+		for i, change := range changes {
+			keys[i] = change.Type + ":" + change.EntryType + ":" + change.Name
+		}
+		return keys
+	}
+
+	t.Run("changes are listed oldest first and resume after the token", func(t *testing.T) {
+		t.Parallel()
+
+		first, err := svc.ListEntryChanges(ctx,
+			service.WithRegistryName(registryName),
+			service.WithLimit(2),
+		)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"created:server:com.example/weather",
+			"created:skill:calendar",
+		}, changeKeys(first.Changes))
+		require.Equal(t, first.Changes[1].Token, first.NextToken)
+		require.Equal(t, "com.example", first.Changes[1].Namespace)
+
+		second, err := svc.ListEntryChanges(ctx,
+			service.WithRegistryName(registryName),
+			service.WithSince(first.NextToken),
+		)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"created:plugin:alerts",
+			"deleted:server:com.example/weather",
+		}, changeKeys(second.Changes))
+
+		third, err := svc.ListEntryChanges(ctx,
+			service.WithRegistryName(registryName),
+			service.WithSince(second.NextToken),
+		)
+		require.NoError(t, err)
+		require.Empty(t, third.Changes)
+		require.Equal(t, second.NextToken, third.NextToken)
+	})
+
+	t.Run("changes the caller cannot see are filtered out", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := svc.ListEntryChanges(ctx,
+			service.WithRegistryName(registryName),
+			service.WithClaims(map[string]any{"org": "acme", "team": "eng"}),
+		)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"created:server:com.example/weather",
+			"created:skill:calendar",
+			"deleted:server:com.example/weather",
+		}, changeKeys(result.Changes))
+	})
+
+	t.Run("from latest returns the token of the last change", func(t *testing.T) {
+		t.Parallel()
+
+		all, err := svc.ListEntryChanges(ctx, service.WithRegistryName(registryName))
+		require.NoError(t, err)
+		require.Len(t, all.Changes, 4)
+
+		latest, err := svc.ListEntryChanges(ctx,
+			service.WithRegistryName(registryName),
+			service.WithFromLatest(),
+		)
+		require.NoError(t, err)
+		require.Empty(t, latest.Changes)
+		require.Equal(t, all.NextToken, latest.NextToken)
+	})
+
+	t.Run("registry gate applies", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := svc.ListEntryChanges(ctx,
+			service.WithRegistryName(registryName),
+			service.WithClaims(map[string]any{"org": "contoso"}),
+		)
+		require.ErrorIs(t, err, service.ErrClaimsInsufficient)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := svc.ListEntryChanges(ctx,
+			service.WithRegistryName(registryName),
+			service.WithSince("next"),
+		)
+		require.ErrorIs(t, err, service.ErrInvalidChangeToken)
+	})
+}
+
+func TestListEntryChanges_ExpiredToken(t *testing.T) {
+	t.Parallel()
+
+	svc, cleanup := setupTestService(t)
+	t.Cleanup(cleanup)
+
+	const registryName = "expired-changes-registry"
+	createManagedSourceWithRegistry(t, svc, registryName)
+	publishChangeFeedEntries(t, svc, registryName)
+	ctx := context.Background()
+
+	all, err := svc.ListEntryChanges(ctx, service.WithRegistryName(registryName))
+	require.NoError(t, err)
+	require.Len(t, all.Changes, 4)
+
+	// Prune the first two changes, as the retention would
+	secondID, err := decodeChangeToken(all.Changes[1].Token)
+	require.NoError(t, err)
+	_, err = svc.pool.Exec(ctx, `DELETE FROM entry_change WHERE id <= $1`, secondID)
+	require.NoError(t, err)
+
+	_, err = svc.ListEntryChanges(ctx,
+		service.WithRegistryName(registryName),
+		service.WithSince(all.Changes[0].Token),
+	)
+	require.ErrorIs(t, err, service.ErrChangeTokenExpired)
+
+	// The token of the last pruned change is still valid
+	result, err := svc.ListEntryChanges(ctx,
+		service.WithRegistryName(registryName),
+		service.WithSince(all.Changes[1].Token),
+	)
+	require.NoError(t, err)
+	require.Len(t, result.Changes, 2)
+}
@@ -0,0 +1,135 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	toolhivetypes "github.com/stacklok/toolhive-core/registry/types"
+
+	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/pinning"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// pinPublishedServer pins the OCI packages of a server version published into
+// a managed source to digests. Returns ErrDigestPinningRequired when a registry
+// of the source requires digest pinning and a package is left unpinned.
+func (s *dbService) pinPublishedServer(
+	ctx context.Context, source *sqlc.Source, serverData *upstreamv0.ServerJSON,
+) ([]pinning.PackageDigest, error) {
+	if !s.digestPinning.AppliesTo(source.Name) {
+		return nil, nil
+	}
+	digests, err := s.digestPinning.PinServer(ctx, source.Name, service.EntryTypeServer, serverData)
+	return digests, pinningError(err)
+}
+
+// pinPublishedPackages pins the OCI packages of a skill or plugin version
+// published into a managed source to digests, setting the digest of the pinned
+// packages in place. Returns ErrDigestPinningRequired when a registry of the
+// source requires digest pinning and a package is left unpinned.
+func pinPublishedPackages[P service.SkillPackage | service.PluginPackage](
+	ctx context.Context,
+	pinner *pinning.Pinner,
+	source *sqlc.Source,
+	entryType, name, version string,
+	packages []P,
+) ([]pinning.PackageDigest, error) {
+	if !pinner.AppliesTo(source.Name) {
+		return nil, nil
+	}
+	pinned := make([]toolhivetypes.SkillPackage, len(packages))
+	for i, pkg := range packages {
+		pinned[i] = toolhivetypes.SkillPackage(pkg)
+	}
+	digests, err := pinner.PinSkillPackages(ctx, source.Name, entryType, name, version, pinned)
+	if err != nil {
+		return nil, pinningError(err)
+	}
+	for i := range pinned {
+		packages[i] = P(pinned[i])
+	}
+	return digests, nil
+}
+
+// pinningError converts a pinning failure to a service error
+func pinningError(err error) error {
+	if errors.Is(err, pinning.ErrNotPinned) {
+		return fmt.Errorf("%w: %w", service.ErrDigestPinningRequired, err)
+	}
+	return err
+}
+
+// insertPackageDigests records the resolved digests of the OCI packages of a
+// published entry version of a source.
+func insertPackageDigests(
+	ctx context.Context, querier *sqlc.Queries, sourceID uuid.UUID, digests []pinning.PackageDigest,
+) error {
+	if len(digests) == 0 {
+		return nil
+	}
+
+	params := sqlc.UpsertSourcePackageDigestsParams{SourceID: sourceID}
+	for _, digest := range digests {
+		entryType, err := mapEntryType(digest.Type)
+		if err != nil {
+			return err
+		}
+		params.EntryTypes = append(params.EntryTypes, string(entryType))
+		params.Names = append(params.Names, digest.Name)
+		params.Versions = append(params.Versions, digest.Version)
+		params.Identifiers = append(params.Identifiers, digest.Identifier)
+		params.Digests = append(params.Digests, digest.Digest)
+	}
+	if _, err := querier.UpsertSourcePackageDigests(ctx, params); err != nil {
+		return fmt.Errorf("failed to insert package digests: %w", err)
+	}
+	return nil
+}
+
+// fetchPackageDigests returns the resolved digests of the OCI packages of the
+// given entry versions, keyed by version ID.
+func fetchPackageDigests(
+	ctx context.Context, querier sqlc.Querier, versionIDs []uuid.UUID,
+) (map[uuid.UUID][]sqlc.PackageDigest, error) {
+	rows, err := querier.ListPackageDigests(ctx, versionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list package digests: %w", err)
+	}
+	digests := make(map[uuid.UUID][]sqlc.PackageDigest, len(rows))
+	for _, row := range rows {
+		digests[row.VersionID] = append(digests[row.VersionID], row)
+	}
+	return digests, nil
+}
+
+// withDigestsMeta reports the resolved digests of the OCI packages of an entry
+// version in its _meta, replacing any value supplied by the publisher under the
+// same key.
+func withDigestsMeta(meta map[string]any, rows []sqlc.PackageDigest) map[string]any {
+	if len(rows) == 0 {
+		delete(meta, service.DigestsMetaKey)
+		return meta
+	}
+	if meta == nil {
+		meta = make(map[string]any)
+	}
+	digests := make([]service.PackageDigest, 0, len(rows))
+	for _, row := range rows {
+		digest := service.PackageDigest{
+			Identifier: row.Identifier,
+			Digest:     row.Digest,
+			ResolvedAt: row.ResolvedAt,
+			DriftedAt:  row.DriftedAt,
+		}
+		if row.PreviousDigest != nil {
+			digest.PreviousDigest = *row.PreviousDigest
+		}
+		digests = append(digests, digest)
+	}
+	meta[service.DigestsMetaKey] = digests
+	return meta
+}
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/pinning"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+func TestDigestPinning_Publish(t *testing.T) {
+	t.Parallel()
+
+	svc, cleanup := setupTestService(t)
+	t.Cleanup(cleanup)
+	createManagedSourceWithRegistryClaims(t, svc, "digest-pinning", nil)
+	ctx := context.Background()
+
+	// The OCI registry knows the 1.0.0 tag only
+	const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	ociRegistry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/acme/server/manifests/1.0.0" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Docker-Content-Digest", digest)
+	}))
+	t.Cleanup(ociRegistry.Close)
+	host := strings.TrimPrefix(ociRegistry.URL, "http://")
+
+	svc.digestPinning = pinning.NewPinner(&config.Config{
+		Registries: []config.RegistryConfig{
+			{Name: "digest-pinning", Sources: []string{"digest-pinning"}, RequireDigestPinning: true},
+		},
+		DigestPinning: &config.DigestPinningConfig{PlainHTTPRegistries: []string{host}},
+	})
+	serverWithImage := func(version string) *upstreamv0.ServerJSON {
+		return &upstreamv0.ServerJSON{
+			Name:    "com.example/server",
+			Version: version,
+			Packages: []model.Package{{
+				RegistryType: model.RegistryTypeOCI,
+				Identifier:   host + "/acme/server:" + version,
+				Transport:    model.Transport{Type: "stdio"},
+			}},
+		}
+	}
+
+	_, err := svc.PublishServerVersion(ctx, service.WithServerData(serverWithImage("2.0.0")))
+	require.ErrorIs(t, err, service.ErrDigestPinningRequired)
+
+	_, err = svc.PublishServerVersion(ctx, service.WithServerData(serverWithImage("1.0.0")))
+	require.NoError(t, err)
+
+	server, err := svc.GetServerVersion(ctx,
+		service.WithRegistryName("digest-pinning"),
+		service.WithName("com.example/server"),
+		service.WithVersion("1.0.0"),
+	)
+	require.NoError(t, err)
+	digests, ok := server.Server.Meta.PublisherProvided[service.DigestsMetaKey].([]service.PackageDigest)
+	require.True(t, ok)
+	require.Len(t, digests, 1)
+	require.Equal(t, host+"/acme/server:1.0.0", digests[0].Identifier)
+	require.Equal(t, digest, digests[0].Digest)
+	require.Nil(t, digests[0].DriftedAt)
+}
@@ -12,7 +12,7 @@ import (
 func TestNewDeduplicatingFilterWrongType(t *testing.T) {
 	t.Parallel()
 
-	filter := newDeduplicatingFilter()
+	filter := newDeduplicatingFilter(nil)
 	_, err := filter(t.Context(), "not-a-helper")
 	require.Error(t, err)
 }
@@ -106,7 +106,7 @@ func TestNewDeduplicatingSkillFilter(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			filter := newDeduplicatingSkillFilter()
+			filter := newDeduplicatingSkillFilter(nil)
 			var got []sqlc.ListSkillsRow
 			for _, record := range tt.input {
 				keep, err := filter(t.Context(), record)
@@ -11,6 +11,9 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/notifications"
+	"github.com/stacklok/toolhive-registry-server/internal/pinning"
+	"github.com/stacklok/toolhive-registry-server/internal/scanning"
 	"github.com/stacklok/toolhive-registry-server/internal/service"
 )
 
@@ -35,10 +38,13 @@ type serverCursor struct {
 
 // options holds configuration options for the database service
 type options struct {
-	pool        *pgxpool.Pool
-	tracer      trace.Tracer
-	maxMetaSize int
-	skipAuthz   bool
+	pool          *pgxpool.Pool
+	tracer        trace.Tracer
+	maxMetaSize   int
+	skipAuthz     bool
+	outbox        *notifications.Outbox
+	entryPolicy   *scanning.Pipeline
+	digestPinning *pinning.Pinner
 }
 
 // Option is a functional option for configuring the database service
@@ -87,12 +93,42 @@ func WithSkipAuthz() Option {
 	}
 }
 
+// WithOutbox sets the outbox that queues the publish, delete and status events
+// of entries for the outbound webhooks. If not set, no event is queued.
+func WithOutbox(outbox *notifications.Outbox) Option {
+	return func(o *options) error {
+		o.outbox = outbox
+		return nil
+	}
+}
+
+// WithEntryPolicy sets the entry policy scanning the entries published into the
+// managed sources. If not set, no entry is scanned.
+func WithEntryPolicy(pipeline *scanning.Pipeline) Option {
+	return func(o *options) error {
+		o.entryPolicy = pipeline
+		return nil
+	}
+}
+
+// WithDigestPinning sets the pinner pinning the OCI packages of the entries
+// published into the managed sources to digests. If not set, no package is pinned.
+func WithDigestPinning(pinner *pinning.Pinner) Option {
+	return func(o *options) error {
+		o.digestPinning = pinner
+		return nil
+	}
+}
+
 // dbService implements the RegistryService interface using a database backend
 type dbService struct {
-	pool        *pgxpool.Pool
-	tracer      trace.Tracer
-	maxMetaSize int
-	skipAuthz   bool
+	pool          *pgxpool.Pool
+	tracer        trace.Tracer
+	maxMetaSize   int
+	skipAuthz     bool
+	outbox        *notifications.Outbox
+	entryPolicy   *scanning.Pipeline
+	digestPinning *pinning.Pinner
 }
 
 var _ service.RegistryService = (*dbService)(nil)
@@ -108,10 +144,13 @@ func New(opts ...Option) (service.RegistryService, error) {
 	}
 
 	return &dbService{
-		pool:        o.pool,
-		tracer:      o.tracer,
-		maxMetaSize: o.maxMetaSize,
-		skipAuthz:   o.skipAuthz,
+		pool:          o.pool,
+		tracer:        o.tracer,
+		maxMetaSize:   o.maxMetaSize,
+		skipAuthz:     o.skipAuthz,
+		outbox:        o.outbox,
+		entryPolicy:   o.entryPolicy,
+		digestPinning: o.digestPinning,
 	}, nil
 }
 
@@ -140,6 +179,12 @@ func deserializeSourceConfig(sourceType string, data []byte) any {
 			return nil
 		}
 		return &cfg
+	case config.SourceTypeBundle:
+		var cfg config.BundleConfig
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return nil
+		}
+		return &cfg
 	case config.SourceTypeManaged:
 		var cfg config.ManagedConfig
 		if err := json.Unmarshal(data, &cfg); err != nil {
@@ -31,18 +31,30 @@ func (s *dbService) CheckReadiness(ctx context.Context) error {
 func lookupRegistryIDWithGate(
 	ctx context.Context, pool sqlc.DBTX, registryName string, callerClaims map[string]any,
 ) (uuid.UUID, error) {
+	row, err := lookupRegistryWithGate(ctx, pool, registryName, callerClaims)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return row.ID, nil
+}
+
+// lookupRegistryWithGate is like lookupRegistryIDWithGate but returns the whole
+// registry row, for callers that also need its merge strategies.
+func lookupRegistryWithGate(
+	ctx context.Context, pool sqlc.DBTX, registryName string, callerClaims map[string]any,
+) (sqlc.Registry, error) {
 	querier := sqlc.New(pool)
 	row, err := querier.GetRegistryByName(ctx, registryName)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return uuid.Nil, fmt.Errorf("%w: %s", service.ErrRegistryNotFound, registryName)
+			return sqlc.Registry{}, fmt.Errorf("%w: %s", service.ErrRegistryNotFound, registryName)
 		}
-		return uuid.Nil, err
+		return sqlc.Registry{}, err
 	}
 	if err := validateClaimsVisibleBytes(ctx, callerClaims, row.Claims); err != nil {
-		return uuid.Nil, err
+		return sqlc.Registry{}, err
 	}
-	return row.ID, nil
+	return row, nil
 }
 
 // checkRegistryExistsWithGate validates that a registry exists and the caller's
@@ -53,7 +65,7 @@ func checkRegistryExistsWithGate(ctx context.Context, pool sqlc.DBTX, registryNa
 }
 
 // upsertLatestFunc is a callback used by rePointLatestVersionIfNeeded to update
-// the latest-version pointer for a specific entry type (MCP server or skill).
+// the latest-version pointer for a specific entry type (skill or plugin).
 type upsertLatestFunc func(
 	ctx context.Context,
 	querier *sqlc.Queries,
@@ -68,7 +80,8 @@ type upsertLatestFunc func(
 // if so, re-points it to the next-highest remaining version.
 //
 // The upsertLatest callback is responsible for writing the new pointer using the
-// appropriate SQL query for the entry type (server or skill).
+// appropriate SQL query for the entry type (skill or plugin). MCP servers use
+// refreshLatestServerVersion instead, which skips deprecated and yanked versions.
 func rePointLatestVersionIfNeeded(
 	ctx context.Context,
 	querier *sqlc.Queries,
@@ -118,6 +118,9 @@ func (s *dbService) executeUpdateClaimsTransaction(
 	if err := validateClaimsVisibleBytes(ctx, gateClaims, existing.Claims); err != nil {
 		return err
 	}
+	if err := checkEntryNamespaceOwnership(ctx, querier, gateClaims, entryType, existing.ID, options.Name); err != nil {
+		return err
+	}
 
 	claimsJSON := db.SerializeClaims(options.Claims)
 
@@ -134,6 +137,14 @@ func (s *dbService) executeUpdateClaimsTransaction(
 		return fmt.Errorf("%w: %s", service.ErrNotFound, options.Name)
 	}
 
+	// New claims change who sees the entry, so every version is reported as updated
+	if err := querier.LockEntryChanges(ctx); err != nil {
+		return fmt.Errorf("failed to lock entry changes: %w", err)
+	}
+	if err := querier.InsertEntryVersionChanges(ctx, existing.ID); err != nil {
+		return fmt.Errorf("failed to record entry changes: %w", err)
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
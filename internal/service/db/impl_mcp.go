@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5/middleware"
@@ -17,8 +18,12 @@ import (
 	model "github.com/modelcontextprotocol/registry/pkg/model"
 
 	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/notifications"
 	"github.com/stacklok/toolhive-registry-server/internal/otel"
+	"github.com/stacklok/toolhive-registry-server/internal/pinning"
+	"github.com/stacklok/toolhive-registry-server/internal/scanning"
 	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/signing"
 	"github.com/stacklok/toolhive-registry-server/internal/validators"
 	"github.com/stacklok/toolhive-registry-server/internal/versions"
 )
@@ -62,7 +67,12 @@ func (s *dbService) ListServers(
 	if s.skipAuthz {
 		gateClaims = nil
 	}
-	registryID, err := lookupRegistryIDWithGate(ctx, s.pool, options.RegistryName, gateClaims)
+	registry, err := lookupRegistryWithGate(ctx, s.pool, options.RegistryName, gateClaims)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+	policy, err := loadMergePolicy(ctx, sqlc.New(s.pool), registry, sqlc.EntryTypeMCP, nil)
 	if err != nil {
 		otel.RecordError(span, err)
 		return nil, err
@@ -71,7 +81,7 @@ func (s *dbService) ListServers(
 	// Request one extra record to detect if there are more results
 	params := sqlc.ListServersParams{
 		Size:       int64(options.Limit + 1),
-		RegistryID: registryID,
+		RegistryID: registry.ID,
 	}
 
 	slog.DebugContext(ctx, "ListServers query",
@@ -82,8 +92,9 @@ func (s *dbService) ListServers(
 		"updated_since", options.UpdatedSince,
 		"version", options.Version,
 		"request_id", middleware.GetReqID(ctx))
+	var tsQuery string
 	if options.Search != "" {
-		params.Search = &options.Search
+		tsQuery = toPrefixTSQuery(options.Search)
 	}
 
 	if options.Cursor != "" {
@@ -110,7 +121,7 @@ func (s *dbService) ListServers(
 			p.CursorName = &cursor.Name
 			p.CursorVersion = &cursor.Version
 		}
-		rows, err := querier.ListServers(ctx, p)
+		rows, err := listOrSearchServers(ctx, querier, tsQuery, p)
 		if err != nil {
 			return nil, err
 		}
@@ -131,7 +142,7 @@ func (s *dbService) ListServers(
 	if s.skipAuthz {
 		claimsFilter = nil
 	}
-	results, lastCursor, err := s.sharedListServersWithCursor(ctx, querierFunc, options.Limit, claimsFilter)
+	results, lastCursor, err := s.sharedListServersWithCursor(ctx, querierFunc, options.Limit, claimsFilter, policy)
 	if err != nil {
 		otel.RecordError(span, err)
 		return nil, err
@@ -159,7 +170,7 @@ func (s *dbService) ListServers(
 func (s *dbService) ListServerVersions(
 	ctx context.Context,
 	opts ...service.Option,
-) ([]*upstreamv0.ServerJSON, error) {
+) ([]*upstreamv0.ServerResponse, error) {
 	ctx, span := s.startSpan(ctx, "dbService.ListServerVersions")
 	defer span.End()
 	start := time.Now()
@@ -193,16 +204,22 @@ func (s *dbService) ListServerVersions(
 	if s.skipAuthz {
 		gateClaims = nil
 	}
-	registryIDForVersions, err := lookupRegistryIDWithGate(ctx, s.pool, options.RegistryName, gateClaims)
+	registry, err := lookupRegistryWithGate(ctx, s.pool, options.RegistryName, gateClaims)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+	policy, err := loadMergePolicy(ctx, sqlc.New(s.pool), registry, sqlc.EntryTypeMCP, &options.Name)
 	if err != nil {
 		otel.RecordError(span, err)
 		return nil, err
 	}
 
 	params := sqlc.ListServersParams{
-		Name:       &options.Name,
-		Size:       int64(options.Limit),
-		RegistryID: registryIDForVersions,
+		Name:            &options.Name,
+		Size:            int64(options.Limit),
+		RegistryID:      registry.ID,
+		IncludeInactive: true,
 	}
 
 	// Note: this function fetches a list of server versions. In case no records are
@@ -232,7 +249,7 @@ func (s *dbService) ListServerVersions(
 	if s.skipAuthz {
 		claimsFilter = nil
 	}
-	results, err := s.sharedListServers(ctx, querierFunc, claimsFilter)
+	results, err := s.sharedListServers(ctx, querierFunc, claimsFilter, policy)
 	if err != nil {
 		otel.RecordError(span, err)
 		return nil, err
@@ -253,7 +270,7 @@ func (s *dbService) ListServerVersions(
 func (s *dbService) GetServerVersion(
 	ctx context.Context,
 	opts ...service.Option,
-) (*upstreamv0.ServerJSON, error) {
+) (*upstreamv0.ServerResponse, error) {
 	ctx, span := s.startSpan(ctx, "dbService.GetServerVersion")
 	defer span.End()
 	start := time.Now()
@@ -281,11 +298,23 @@ func (s *dbService) GetServerVersion(
 	if s.skipAuthz {
 		gateClaims = nil
 	}
-	registryID, err := lookupRegistryIDWithGate(ctx, s.pool, options.RegistryName, gateClaims)
+	registry, err := lookupRegistryWithGate(ctx, s.pool, options.RegistryName, gateClaims)
 	if err != nil {
 		otel.RecordError(span, err)
 		return nil, err
 	}
+	registryID := registry.ID
+
+	// An explicit source bypasses the merge strategies of the registry: the
+	// version is served as that source provides it.
+	var policy *mergePolicy
+	if options.SourceName == "" {
+		policy, err = loadMergePolicy(ctx, sqlc.New(s.pool), registry, sqlc.EntryTypeMCP, &options.Name)
+		if err != nil {
+			otel.RecordError(span, err)
+			return nil, err
+		}
+	}
 
 	// Note: this function fetches a single record given name and version.
 	// In case no record is found, the called function maps the underlying
@@ -335,7 +364,7 @@ func (s *dbService) GetServerVersion(
 	if s.skipAuthz {
 		claimsFilter = nil
 	}
-	res, err := s.sharedListServers(ctx, querierFunc, claimsFilter)
+	res, err := s.sharedListServers(ctx, querierFunc, claimsFilter, policy)
 	if err != nil {
 		otel.RecordError(span, err)
 		return nil, err
@@ -591,6 +620,7 @@ func getManagedSource(ctx context.Context, querier *sqlc.Queries) (*sqlc.Source,
 		Name:         row.Name,
 		SourceType:   row.SourceType,
 		CreationType: row.CreationType,
+		SourceConfig: row.SourceConfig,
 		Claims:       row.Claims,
 		CreatedAt:    row.CreatedAt,
 		UpdatedAt:    row.UpdatedAt,
@@ -658,7 +688,7 @@ func (s *dbService) PublishServerVersion(
 	}
 
 	// Execute the publish operation in a transaction
-	sourceName, err := s.executePublishTransaction(ctx, serverData, claimsJSON, gateClaims)
+	sourceName, err := s.executePublishTransaction(ctx, serverData, claimsJSON, gateClaims, options.Signature)
 	if err != nil {
 		otel.RecordError(span, err)
 		return nil, err
@@ -710,8 +740,28 @@ func (s *dbService) fetchServerVersionBySource(
 	if err != nil {
 		return nil, err
 	}
+	icons, err := querier.ListServerIcons(ctx, versionIDs)
+	if err != nil {
+		return nil, err
+	}
+	signatures, err := fetchEntrySignatures(ctx, querier, versionIDs)
+	if err != nil {
+		return nil, err
+	}
+	scans, err := fetchEntryScans(ctx, querier, versionIDs)
+	if err != nil {
+		return nil, err
+	}
+	digests, err := fetchPackageDigests(ctx, querier, versionIDs)
+	if err != nil {
+		return nil, err
+	}
 
-	server, err := helperToServer(h, packages, remotes)
+	server, err := helperToServer(h, packages, remotes, icons, signatures[row.ID], scans[row.ID], digests[row.ID])
+	if err != nil {
+		return nil, err
+	}
+	server, err = withRegistryOverlay(server, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -724,7 +774,15 @@ func (s *dbService) executePublishTransaction(
 	serverData *upstreamv0.ServerJSON,
 	claimsJSON []byte,
 	gateClaims map[string]any,
+	signature *service.EntrySignature,
 ) (string, error) {
+	// Pin the OCI packages of the server and scan it before the transaction,
+	// refusing the publish if a required pin is missing or the server is blocked
+	checks, err := s.preparePublish(ctx, &service.PortableEntry{Server: serverData}, gateClaims)
+	if err != nil {
+		return "", err
+	}
+
 	// Begin transaction
 	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{
 		IsoLevel:   pgx.Serializable,
@@ -748,53 +806,148 @@ func (s *dbService) executePublishTransaction(
 		return "", err
 	}
 
+	submission, err := s.publishServerInTx(ctx, querier, source, serverData, claimsJSON, gateClaims, signature, checks)
+	if err != nil {
+		return "", err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		return "", fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if submission != nil {
+		return "", &service.PendingReviewError{Submission: submission}
+	}
+	return source.Name, nil
+}
+
+// publishServerInTx publishes a server version into the managed source, in the
+// transaction of querier, with the results of the checks run before it. It
+// returns the submission holding the publish when the source requires approval.
+func (s *dbService) publishServerInTx(
+	ctx context.Context,
+	querier *sqlc.Queries,
+	source *sqlc.Source,
+	serverData *upstreamv0.ServerJSON,
+	claimsJSON []byte,
+	gateClaims map[string]any,
+	signature *service.EntrySignature,
+	checks *publishChecks,
+) (*service.EntrySubmission, error) {
 	// Verify the caller may publish into this source: their JWT must cover the
 	// source's claims (visibility / OR — auth.md §3/§5). An untagged managed
 	// source is publishable only by super-admin (default-deny, #845).
 	if err := validateClaimsVisibleBytes(ctx, gateClaims, source.Claims); err != nil {
-		return "", err
+		return nil, err
 	}
 
+	// Verify the caller owns the namespace of the server
+	if err := checkNamespaceOwnership(ctx, querier, gateClaims, serverNamespace(serverData.Name)); err != nil {
+		return nil, err
+	}
+
+	// Verify the signature attached to the publish against the trusted keys
+	verification, err := verifyEntrySignature(source, serverData.Name, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hold the publish for review when the source requires approval
+	approval, err := requiresApproval(source)
+	if err != nil {
+		return nil, err
+	}
+	if approval {
+		signatureJSON, err := marshalVerification(verification)
+		if err != nil {
+			return nil, err
+		}
+		return s.submitEntry(ctx, querier, sqlc.InsertEntrySubmissionParams{
+			SourceID:  source.ID,
+			EntryType: sqlc.EntryTypeMCP,
+			Name:      serverData.Name,
+			Version:   serverData.Version,
+			Claims:    claimsJSON,
+			Signature: signatureJSON,
+		}, serverData)
+	}
+
+	return nil, s.insertPublishedServer(ctx, querier, source, serverData, claimsJSON, verification, checks.scan, checks.digests)
+}
+
+// insertPublishedServer inserts a published server version into the managed
+// source with its verified signature, scan and package digests, if any, and
+// records it in the change feed and the webhook outbox
+func (s *dbService) insertPublishedServer(
+	ctx context.Context,
+	querier *sqlc.Queries,
+	source *sqlc.Source,
+	serverData *upstreamv0.ServerJSON,
+	claimsJSON []byte,
+	verification *signing.Verification,
+	scan *scanning.Result,
+	digests []pinning.PackageDigest,
+) error {
 	// Insert server and related data
-	if err := s.insertServerData(ctx, querier, serverData, source.ID, claimsJSON); err != nil {
-		return "", err
+	serverVersionID, err := s.insertServerData(ctx, querier, serverData, source.ID, claimsJSON)
+	if err != nil {
+		return err
 	}
 
-	// Commit transaction
-	if err := tx.Commit(ctx); err != nil {
-		return "", fmt.Errorf("failed to commit transaction: %w", err)
+	if err := insertEntrySignature(ctx, querier, serverVersionID, verification); err != nil {
+		return err
 	}
 
-	return source.Name, nil
+	if err := insertEntryScan(ctx, querier, serverVersionID, scan); err != nil {
+		return err
+	}
+
+	if err := insertPackageDigests(ctx, querier, source.ID, digests); err != nil {
+		return err
+	}
+
+	if err := recordEntryChange(ctx, querier, source.ID, sqlc.EntryTypeMCP, nil,
+		serverData.Name, serverData.Version, sqlc.EntryChangeTypeCREATED); err != nil {
+		return err
+	}
+
+	return s.notifyEntry(ctx, querier, notifications.EventEntryPublish, notifications.EntryData{
+		Source:    source.Name,
+		EntryType: service.EntryTypeServer,
+		Name:      serverData.Name,
+		Version:   serverData.Version,
+	})
 }
 
-// insertServerData inserts the server version and all related data
+// insertServerData inserts the server version and all related data, and
+// returns the ID of the version
 func (s *dbService) insertServerData(
 	ctx context.Context,
 	querier *sqlc.Queries,
 	serverData *upstreamv0.ServerJSON,
 	registryID uuid.UUID,
 	claimsJSON []byte,
-) error {
+) (uuid.UUID, error) {
 	// Insert the server version
 	serverVersionID, err := insertServerVersionData(ctx, querier, serverData, registryID, s.maxMetaSize, claimsJSON)
 	if err != nil {
-		return err
+		return uuid.Nil, err
 	}
 
 	// Insert packages
 	if err := insertServerPackages(ctx, querier, serverVersionID, serverData.Packages); err != nil {
-		return err
+		return uuid.Nil, err
 	}
 
 	// Insert remotes
 	if err := insertServerRemotes(ctx, querier, serverVersionID, serverData.Remotes); err != nil {
-		return err
+		return uuid.Nil, err
 	}
 
 	// Insert icons
 	if err := insertServerIcons(ctx, querier, serverVersionID, serverData.Icons); err != nil {
-		return err
+		return uuid.Nil, err
 	}
 
 	// Compare with current latest before upserting — avoid regressing the pointer
@@ -806,7 +959,7 @@ func (s *dbService) insertServerData(
 	if err == nil {
 		shouldUpdateLatest = versions.IsNewerVersion(serverData.Version, currentLatest)
 	} else if !errors.Is(err, pgx.ErrNoRows) {
-		return fmt.Errorf("failed to get current latest version: %w", err)
+		return uuid.Nil, fmt.Errorf("failed to get current latest version: %w", err)
 	}
 
 	if shouldUpdateLatest {
@@ -817,11 +970,11 @@ func (s *dbService) insertServerData(
 			VersionID: serverVersionID,
 		})
 		if err != nil {
-			return fmt.Errorf("failed to upsert latest server version: %w", err)
+			return uuid.Nil, fmt.Errorf("failed to upsert latest server version: %w", err)
 		}
 	}
 
-	return nil
+	return serverVersionID, nil
 }
 
 // DeleteServerVersion removes a server version from a managed registry
@@ -906,6 +1059,11 @@ func (s *dbService) executeDeleteTransaction(
 		if err := validateClaimsVisibleBytes(ctx, gateClaims, existing.Claims); err != nil {
 			return err
 		}
+		if err := checkEntryNamespaceOwnership(
+			ctx, querier, gateClaims, sqlc.EntryTypeMCP, existing.ID, options.ServerName,
+		); err != nil {
+			return err
+		}
 	}
 
 	entryID, err := lookupAndDeleteEntryVersion(ctx, querier, source.ID, sqlc.EntryTypeMCP, options.ServerName, options.Version)
@@ -913,16 +1071,21 @@ func (s *dbService) executeDeleteTransaction(
 		return err
 	}
 
-	if err := rePointLatestVersionIfNeeded(ctx, querier, source.ID, options.ServerName, entryID,
-		func(ctx context.Context, querier *sqlc.Queries, sourceID uuid.UUID, name, version string, versionID uuid.UUID) error {
-			_, err := querier.UpsertLatestServerVersion(ctx, sqlc.UpsertLatestServerVersionParams{
-				SourceID:  sourceID,
-				Name:      name,
-				Version:   version,
-				VersionID: versionID,
-			})
-			return err
-		}); err != nil {
+	if err := refreshLatestServerVersion(ctx, querier, source.ID, options.ServerName, entryID); err != nil {
+		return err
+	}
+
+	if err := recordEntryChange(ctx, querier, source.ID, sqlc.EntryTypeMCP, nil,
+		options.ServerName, options.Version, sqlc.EntryChangeTypeDELETED); err != nil {
+		return err
+	}
+
+	if err := s.notifyEntry(ctx, querier, notifications.EventEntryDelete, notifications.EntryData{
+		Source:    source.Name,
+		EntryType: service.EntryTypeServer,
+		Name:      options.ServerName,
+		Version:   options.Version,
+	}); err != nil {
 		return err
 	}
 
@@ -937,6 +1100,222 @@ func (s *dbService) executeDeleteTransaction(
 	return nil
 }
 
+// UpdateServerVersionStatus sets the lifecycle status of a server version in a managed registry
+func (s *dbService) UpdateServerVersionStatus(
+	ctx context.Context,
+	opts ...service.Option,
+) error {
+	ctx, span := s.startSpan(ctx, "dbService.UpdateServerVersionStatus")
+	defer span.End()
+	start := time.Now()
+
+	options := &service.UpdateServerVersionStatusOptions{}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			otel.RecordError(span, err)
+			return fmt.Errorf("invalid option: %w", err)
+		}
+	}
+
+	span.SetAttributes(
+		otel.AttrServerName.String(options.ServerName),
+		otel.AttrServerVersion.String(options.Version),
+	)
+
+	if options.Status == "" {
+		err := fmt.Errorf("%w: status is required", service.ErrInvalidServerStatus)
+		otel.RecordError(span, err)
+		return err
+	}
+	if options.ReplacedBy != "" && options.ReplacedBy == options.Version {
+		err := fmt.Errorf("%w: a version cannot replace itself", service.ErrInvalidServerStatus)
+		otel.RecordError(span, err)
+		return err
+	}
+
+	if err := s.executeStatusUpdateTransaction(ctx, options); err != nil {
+		otel.RecordError(span, err)
+		return err
+	}
+
+	slog.InfoContext(ctx, "Server version status updated",
+		"duration_ms", time.Since(start).Milliseconds(),
+		"server", options.ServerName,
+		"version", options.Version,
+		"status", options.Status,
+		"request_id", middleware.GetReqID(ctx))
+
+	return nil
+}
+
+// executeStatusUpdateTransaction updates the status of a server version and
+// re-points the latest version of the server within a serializable transaction.
+func (s *dbService) executeStatusUpdateTransaction(
+	ctx context.Context,
+	options *service.UpdateServerVersionStatusOptions,
+) error {
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.Serializable,
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		err := tx.Rollback(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.WarnContext(ctx, "Failed to rollback transaction", "error", err)
+		}
+	}()
+
+	querier := sqlc.New(tx)
+
+	source, err := getManagedSource(ctx, querier)
+	if err != nil {
+		return err
+	}
+
+	existing, err := querier.GetRegistryEntryByName(ctx, sqlc.GetRegistryEntryByNameParams{
+		SourceID:  source.ID,
+		EntryType: sqlc.EntryTypeMCP,
+		Name:      options.ServerName,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: %s@%s", service.ErrNotFound, options.ServerName, options.Version)
+		}
+		return fmt.Errorf("failed to look up registry entry: %w", err)
+	}
+
+	// Verify the caller's JWT claims cover the entry's claims before updating
+	if options.JWTClaims != nil {
+		gateClaims := options.JWTClaims
+		if s.skipAuthz {
+			gateClaims = nil
+		}
+		if err := validateClaimsVisibleBytes(ctx, gateClaims, existing.Claims); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	params := sqlc.UpdateServerVersionStatusParams{
+		Status:          sqlc.ServerStatus(strings.ToUpper(options.Status)),
+		StatusChangedAt: &now,
+		EntryID:         existing.ID,
+		Version:         options.Version,
+	}
+	// Active versions carry no reason nor replacement
+	if options.Status != service.ServerStatusActive {
+		if options.Reason != "" {
+			params.StatusReason = &options.Reason
+		}
+		if options.ReplacedBy != "" {
+			if err := checkReplacementVersion(ctx, querier, existing.ID, options.ServerName, options.ReplacedBy); err != nil {
+				return err
+			}
+			params.ReplacedBy = &options.ReplacedBy
+		}
+	}
+
+	rowsAffected, err := querier.UpdateServerVersionStatus(ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to update server version status: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: %s@%s", service.ErrNotFound, options.ServerName, options.Version)
+	}
+
+	if err := refreshLatestServerVersion(ctx, querier, source.ID, options.ServerName, existing.ID); err != nil {
+		return err
+	}
+
+	if err := recordEntryChange(ctx, querier, source.ID, sqlc.EntryTypeMCP, nil,
+		options.ServerName, options.Version, sqlc.EntryChangeTypeUPDATED); err != nil {
+		return err
+	}
+
+	if err := s.notifyEntry(ctx, querier, notifications.EventEntryStatus, notifications.EntryData{
+		Source:    source.Name,
+		EntryType: service.EntryTypeServer,
+		Name:      options.ServerName,
+		Version:   options.Version,
+		Status:    options.Status,
+	}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// checkReplacementVersion verifies that the version replacing a deprecated or
+// yanked server version is another version of the same server.
+func checkReplacementVersion(
+	ctx context.Context,
+	querier *sqlc.Queries,
+	entryID uuid.UUID,
+	name string,
+	replacedBy string,
+) error {
+	existing, err := querier.ListEntryVersions(ctx, entryID)
+	if err != nil {
+		return fmt.Errorf("failed to list versions: %w", err)
+	}
+	for _, row := range existing {
+		if row.Version == replacedBy {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: replacement version %s@%s not found", service.ErrInvalidServerStatus, name, replacedBy)
+}
+
+// refreshLatestServerVersion points the latest version of a server at its highest
+// active version, or removes the pointer when none of its versions is active, so
+// that deprecated and yanked versions are never resolved as the latest one.
+func refreshLatestServerVersion(
+	ctx context.Context,
+	querier *sqlc.Queries,
+	sourceID uuid.UUID,
+	name string,
+	entryID uuid.UUID,
+) error {
+	active, err := querier.ListActiveServerVersions(ctx, entryID)
+	if err != nil {
+		return fmt.Errorf("failed to list active versions: %w", err)
+	}
+	rows := make([]sqlc.ListEntryVersionsRow, len(active))
+	for i, row := range active {
+		rows[i] = sqlc.ListEntryVersionsRow(row)
+	}
+
+	versionID, version := findHighestVersion(rows)
+	if versionID == uuid.Nil {
+		err := querier.DeleteLatestServerVersion(ctx, sqlc.DeleteLatestServerVersionParams{
+			SourceID: sourceID,
+			Name:     name,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete latest server version: %w", err)
+		}
+		return nil
+	}
+
+	_, err = querier.UpsertLatestServerVersion(ctx, sqlc.UpsertLatestServerVersionParams{
+		SourceID:  sourceID,
+		Name:      name,
+		Version:   version,
+		VersionID: versionID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upsert latest server version: %w", err)
+	}
+	return nil
+}
+
 // lookupAndDeleteEntryVersion finds the registry entry by name and entry type,
 // then deletes the specified version. Returns the entry ID for potential
 // cleanup, or an error if the entry or version is not found.
@@ -1027,16 +1406,18 @@ func newDeduplicatingFilterWith(
 }
 
 // streamHelpers fetches helpers in batches, applying the auth filter then the dedup
-// filter to each record, until limit+1 records are accumulated or the DB is exhausted.
+// filter of the merge policy to each record, until limit+1 records are accumulated
+// or the DB is exhausted.
 // It returns the trimmed slice (≤ limit) and the cursor for the next page, if any.
 func streamHelpers(
 	ctx context.Context,
 	querier *sqlc.Queries,
 	querierFunc querierFunction,
 	filter service.RecordFilter,
+	policy *mergePolicy,
 	limit int,
 ) ([]helper, *serverCursor, error) {
-	dedupFilter := newDeduplicatingFilter()
+	dedupFilter := newDeduplicatingFilter(policy)
 	var accumulated []helper
 	var cursor *serverCursor
 
@@ -1099,12 +1480,13 @@ func streamHelpers(
 }
 
 // newDeduplicatingFilter returns a stateful RecordFilter that deduplicates helpers
-// by entry name, keeping only records from the highest-priority source (lowest position).
-func newDeduplicatingFilter() service.RecordFilter {
-	return newDeduplicatingFilterWith(
-		func(record any) (string, int32, bool) {
+// according to the merge policy of the registry. With a nil policy it keeps only
+// records from the highest-priority source (lowest position) of each entry name.
+func newDeduplicatingFilter(policy *mergePolicy) service.RecordFilter {
+	return policy.newFilter(
+		func(record any) (mergeRecord, bool) {
 			h, ok := record.(helper)
-			return h.Name, h.Position, ok
+			return mergeRecord{entryType: sqlc.EntryTypeMCP, name: h.Name, version: h.Version, position: h.Position}, ok
 		},
 	)
 }
@@ -1127,10 +1509,11 @@ func (s *dbService) sharedListServers(
 	ctx context.Context,
 	querierFunc querierFunction,
 	filter service.RecordFilter,
-) ([]*upstreamv0.ServerJSON, error) {
+	policy *mergePolicy,
+) ([]*upstreamv0.ServerResponse, error) {
 	// Delegate to sharedListServersWithCursor with a high limit and discard the cursor.
 	// This avoids duplicating the transaction and fetch logic.
-	result, _, err := s.sharedListServersWithCursor(ctx, querierFunc, service.MaxPageSize, filter)
+	result, _, err := s.sharedListServersWithCursor(ctx, querierFunc, service.MaxPageSize, filter, policy)
 	return result, err
 }
 
@@ -1146,7 +1529,8 @@ func (s *dbService) sharedListServersWithCursor(
 	querierFunc querierFunction,
 	limit int,
 	filter service.RecordFilter,
-) ([]*upstreamv0.ServerJSON, *serverCursor, error) {
+	policy *mergePolicy,
+) ([]*upstreamv0.ServerResponse, *serverCursor, error) {
 	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{
 		IsoLevel:   pgx.ReadCommitted,
 		AccessMode: pgx.ReadOnly,
@@ -1163,12 +1547,22 @@ func (s *dbService) sharedListServersWithCursor(
 
 	querier := sqlc.New(tx)
 
-	accumulated, lastCursor, err := streamHelpers(ctx, querier, querierFunc, filter, limit)
+	accumulated, lastCursor, err := streamHelpers(ctx, querier, querierFunc, filter, policy, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fieldOverrides, err := fetchServerFieldOverrides(ctx, querier, policy, accumulated, filter)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	result, err := fetchAndMapServers(ctx, querier, accumulated)
+	registryOverlays, err := fetchRegistryOverlays(ctx, querier, policy, accumulated)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := fetchAndMapServers(ctx, querier, accumulated, fieldOverrides, registryOverlays)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1176,13 +1570,17 @@ func (s *dbService) sharedListServersWithCursor(
 	return result, lastCursor, nil
 }
 
-// fetchAndMapServers fetches packages and remotes for the given server helpers and
-// maps them to the API schema.
+// fetchAndMapServers fetches packages, remotes, icons and signatures for the given
+// server helpers and maps them to the API schema, along with their registry metadata.
+// Fields overridden by later sources of the registry are taken from fieldOverrides,
+// and the overlays attached to the servers in the registry are applied last.
 func fetchAndMapServers(
 	ctx context.Context,
 	querier *sqlc.Queries,
 	servers []helper,
-) ([]*upstreamv0.ServerJSON, error) {
+	fieldOverrides map[uuid.UUID]*fieldOverride,
+	registryOverlays map[string]*sqlc.RegistryOverlay,
+) ([]*upstreamv0.ServerResponse, error) {
 	ids := make([]uuid.UUID, len(servers))
 	for i, server := range servers {
 		ids[i] = server.ID
@@ -1206,17 +1604,51 @@ func fetchAndMapServers(
 		remotesMap[remote.ServerID] = append(remotesMap[remote.ServerID], remote)
 	}
 
-	result := make([]*upstreamv0.ServerJSON, 0, len(servers))
+	icons, err := querier.ListServerIcons(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	iconsMap := make(map[uuid.UUID][]sqlc.McpServerIcon)
+	for _, icon := range icons {
+		iconsMap[icon.ServerID] = append(iconsMap[icon.ServerID], icon)
+	}
+
+	signatures, err := fetchEntrySignatures(ctx, querier, ids)
+	if err != nil {
+		return nil, err
+	}
+	scans, err := fetchEntryScans(ctx, querier, ids)
+	if err != nil {
+		return nil, err
+	}
+	digests, err := fetchPackageDigests(ctx, querier, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*upstreamv0.ServerResponse, 0, len(servers))
 	for _, dbServer := range servers {
+		merged, mergedIcons := applyServerFieldOverride(dbServer, iconsMap[dbServer.ID], fieldOverrides[dbServer.ID])
 		server, err := helperToServer(
-			dbServer,
+			merged,
 			packagesMap[dbServer.ID],
 			remotesMap[dbServer.ID],
+			mergedIcons,
+			signatures[dbServer.ID],
+			scans[dbServer.ID],
+			digests[dbServer.ID],
 		)
 		if err != nil {
 			return nil, err
 		}
-		result = append(result, &server)
+		server, err = withRegistryOverlay(server, registryOverlays[dbServer.Name])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &upstreamv0.ServerResponse{
+			Server: server,
+			Meta:   helperToResponseMeta(dbServer),
+		})
 	}
 
 	return result, nil
@@ -16,8 +16,12 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 
 	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/notifications"
 	"github.com/stacklok/toolhive-registry-server/internal/otel"
+	"github.com/stacklok/toolhive-registry-server/internal/pinning"
+	"github.com/stacklok/toolhive-registry-server/internal/scanning"
 	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/signing"
 	"github.com/stacklok/toolhive-registry-server/internal/versions"
 )
 
@@ -55,7 +59,7 @@ func (s *dbService) ListPlugins(
 	if s.skipAuthz {
 		gateClaims = nil
 	}
-	registryID, err := lookupRegistryIDWithGate(ctx, s.pool, options.RegistryName, gateClaims)
+	registry, err := lookupRegistryWithGate(ctx, s.pool, options.RegistryName, gateClaims)
 	if err != nil {
 		otel.RecordError(span, err)
 		return nil, err
@@ -63,8 +67,14 @@ func (s *dbService) ListPlugins(
 
 	querier := sqlc.New(s.pool)
 
+	policy, err := loadMergePolicy(ctx, querier, registry, sqlc.EntryTypePLUGIN, options.Name)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+
 	params := sqlc.ListPluginsParams{
-		RegistryID: registryID,
+		RegistryID: registry.ID,
 		Size:       int64(options.Limit + 1),
 	}
 	if options.Namespace != "" {
@@ -73,8 +83,9 @@ func (s *dbService) ListPlugins(
 	if options.Name != nil {
 		params.Name = options.Name
 	}
+	var tsQuery string
 	if options.Search != nil {
-		params.Search = options.Search
+		tsQuery = toPrefixTSQuery(*options.Search)
 	}
 	if options.Cursor != nil {
 		cursorName, cursorVersion, err := service.DecodeCursor(*options.Cursor)
@@ -96,7 +107,7 @@ func (s *dbService) ListPlugins(
 	if s.skipAuthz {
 		claimsFilter = nil
 	}
-	listRows, nextCursor, err := streamPluginRows(ctx, querier, params, claimsFilter, options.Limit)
+	listRows, nextCursor, err := streamPluginRows(ctx, querier, tsQuery, params, claimsFilter, policy, options.Limit)
 	if err != nil {
 		otel.RecordError(span, err)
 		return nil, err
@@ -108,10 +119,33 @@ func (s *dbService) ListPlugins(
 		return nil, err
 	}
 
+	versionIDs := make([]uuid.UUID, len(listRows))
+	for i, row := range listRows {
+		versionIDs[i] = row.VersionID
+	}
+	signatures, err := fetchEntrySignatures(ctx, querier, versionIDs)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+	scans, err := fetchEntryScans(ctx, querier, versionIDs)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+	digests, err := fetchPackageDigests(ctx, querier, versionIDs)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+
 	plugins := make([]*service.Plugin, len(listRows))
 	for i, row := range listRows {
 		plugin := service.ListPluginsRowToPlugin(row)
 		plugin.Packages = packages[row.VersionID]
+		plugin.Meta = withSignatureMeta(plugin.Meta, signatures[row.VersionID])
+		plugin.Meta = withScanMeta(plugin.Meta, scans[row.VersionID])
+		plugin.Meta = withDigestsMeta(plugin.Meta, digests[row.VersionID])
 		plugins[i] = plugin
 	}
 
@@ -187,14 +221,26 @@ func (s *dbService) GetPluginVersion(
 	if s.skipAuthz {
 		gateClaims = nil
 	}
-	registryID, err := lookupRegistryIDWithGate(ctx, s.pool, options.RegistryName, gateClaims)
+	registry, err := lookupRegistryWithGate(ctx, s.pool, options.RegistryName, gateClaims)
 	if err != nil {
 		otel.RecordError(span, err)
 		return nil, err
 	}
+	registryID := registry.ID
 
 	querier := sqlc.New(s.pool)
 
+	// An explicit source bypasses the merge strategies of the registry: the
+	// version is served as that source provides it.
+	var policy *mergePolicy
+	if options.SourceName == "" {
+		policy, err = loadMergePolicy(ctx, querier, registry, sqlc.EntryTypePLUGIN, &options.Name)
+		if err != nil {
+			otel.RecordError(span, err)
+			return nil, err
+		}
+	}
+
 	params := sqlc.GetPluginVersionParams{
 		Name:       options.Name,
 		Version:    options.Version,
@@ -219,7 +265,8 @@ func (s *dbService) GetPluginVersion(
 	// one that passes the claims check, promoting lower-priority sources when
 	// higher-priority ones fail. The filter is nil for skipAuthz, anonymous,
 	// and super-admin callers (uniform bypass — see newClaimsFilterWith), in
-	// which case the highest-priority row wins outright.
+	// which case the highest-priority row wins outright. The rows passing the
+	// claims check are then subject to the merge strategies of the registry.
 	claimsFilter := newClaimsFilterWith(
 		ctx, options.Claims,
 		func(record any) ([]byte, bool) {
@@ -230,18 +277,29 @@ func (s *dbService) GetPluginVersion(
 	if s.skipAuthz {
 		claimsFilter = nil
 	}
+	mergeFilter := policy.newFilter(
+		func(record any) (mergeRecord, bool) {
+			r, ok := record.(sqlc.GetPluginVersionRow)
+			return mergeRecord{entryType: sqlc.EntryTypePLUGIN, name: r.Name, version: r.Version, position: r.Position}, ok
+		},
+	)
 	var row sqlc.GetPluginVersionRow
 	found := false
 	for _, r := range rows {
-		if claimsFilter == nil {
-			row = r
-			found = true
-			break
+		ok := true
+		if claimsFilter != nil {
+			ok, err = claimsFilter(ctx, r)
+			if err != nil {
+				otel.RecordError(span, err)
+				return nil, err
+			}
 		}
-		ok, err := claimsFilter(ctx, r)
-		if err != nil {
-			otel.RecordError(span, err)
-			return nil, err
+		if ok {
+			ok, err = mergeFilter(ctx, r)
+			if err != nil {
+				otel.RecordError(span, err)
+				return nil, err
+			}
 		}
 		if ok {
 			row = r
@@ -270,9 +328,27 @@ func (s *dbService) GetPluginVersion(
 	for _, pkg := range gitPackages {
 		packages = append(packages, toServicePluginGitPackage(pkg))
 	}
+	signatures, err := fetchEntrySignatures(ctx, querier, []uuid.UUID{row.PluginVersionID})
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+	scans, err := fetchEntryScans(ctx, querier, []uuid.UUID{row.PluginVersionID})
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+	digests, err := fetchPackageDigests(ctx, querier, []uuid.UUID{row.PluginVersionID})
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
 
 	res := service.GetPluginVersionRowToPlugin(row)
 	res.Packages = packages
+	res.Meta = withSignatureMeta(res.Meta, signatures[row.PluginVersionID])
+	res.Meta = withScanMeta(res.Meta, scans[row.PluginVersionID])
+	res.Meta = withDigestsMeta(res.Meta, digests[row.PluginVersionID])
 	return res, nil
 }
 
@@ -356,7 +432,7 @@ func (s *dbService) PublishPlugin(
 		}
 	}
 
-	sourceName, err := s.executePublishPluginTransaction(ctx, plugin, claimsJSON, gateClaims)
+	sourceName, err := s.executePublishPluginTransaction(ctx, plugin, claimsJSON, gateClaims, options.Signature)
 	if err != nil {
 		otel.RecordError(span, err)
 		return nil, err
@@ -405,6 +481,18 @@ func (s *dbService) fetchPluginVersionBySource(
 	for _, pkg := range gitPackages {
 		packages = append(packages, toServicePluginGitPackage(pkg))
 	}
+	signatures, err := fetchEntrySignatures(ctx, querier, []uuid.UUID{row.PluginVersionID})
+	if err != nil {
+		return nil, err
+	}
+	scans, err := fetchEntryScans(ctx, querier, []uuid.UUID{row.PluginVersionID})
+	if err != nil {
+		return nil, err
+	}
+	digests, err := fetchPackageDigests(ctx, querier, []uuid.UUID{row.PluginVersionID})
+	if err != nil {
+		return nil, err
+	}
 
 	result := service.GetPluginVersionRowToPlugin(sqlc.GetPluginVersionRow{
 		RegistryType:    row.RegistryType,
@@ -428,19 +516,28 @@ func (s *dbService) fetchPluginVersionBySource(
 		Position:        row.Position,
 	})
 	result.Packages = packages
+	result.Meta = withSignatureMeta(result.Meta, signatures[row.PluginVersionID])
+	result.Meta = withScanMeta(result.Meta, scans[row.PluginVersionID])
+	result.Meta = withDigestsMeta(result.Meta, digests[row.PluginVersionID])
 	return result, nil
 }
 
 // executePublishPluginTransaction executes the plugin publish operation within a transaction.
 // Returns the managed source name for fetch-back, or an error.
-//
-//nolint:gocyclo
 func (s *dbService) executePublishPluginTransaction(
 	ctx context.Context,
 	plugin *service.Plugin,
 	claimsJSON []byte,
 	gateClaims map[string]any,
+	signature *service.EntrySignature,
 ) (string, error) {
+	// Pin the OCI packages of the plugin and scan it before the transaction,
+	// refusing the publish if a required pin is missing or the plugin is blocked
+	checks, err := s.preparePublish(ctx, &service.PortableEntry{Plugin: plugin}, gateClaims)
+	if err != nil {
+		return "", err
+	}
+
 	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{
 		IsoLevel:   pgx.Serializable,
 		AccessMode: pgx.ReadWrite,
@@ -462,14 +559,91 @@ func (s *dbService) executePublishPluginTransaction(
 		return "", err
 	}
 
+	submission, err := s.publishPluginInTx(ctx, querier, managedSource, plugin, claimsJSON, gateClaims, signature, checks)
+	if err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return "", fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if submission != nil {
+		return "", &service.PendingReviewError{Submission: submission}
+	}
+	return managedSource.Name, nil
+}
+
+// publishPluginInTx publishes a plugin version into the managed source, in the
+// transaction of querier, with the results of the checks run before it. It
+// returns the submission holding the publish when the source requires approval.
+func (s *dbService) publishPluginInTx(
+	ctx context.Context,
+	querier *sqlc.Queries,
+	managedSource *sqlc.Source,
+	plugin *service.Plugin,
+	claimsJSON []byte,
+	gateClaims map[string]any,
+	signature *service.EntrySignature,
+	checks *publishChecks,
+) (*service.EntrySubmission, error) {
 	// Verify the caller may publish into this source: their JWT must cover the
 	// source's claims (visibility / OR — auth.md §3/§5). An untagged managed
 	// source is publishable only by super-admin (default-deny, #845).
 	if err := validateClaimsVisibleBytes(ctx, gateClaims, managedSource.Claims); err != nil {
-		return "", err
+		return nil, err
+	}
+
+	// Verify the caller owns the namespace of the plugin
+	if err := checkNamespaceOwnership(ctx, querier, gateClaims, plugin.Namespace); err != nil {
+		return nil, err
+	}
+
+	// Verify the signature attached to the publish against the trusted keys
+	verification, err := verifyEntrySignature(managedSource, signedEntryName(plugin.Namespace, plugin.Name), signature)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hold the publish for review when the source requires approval
+	approval, err := requiresApproval(managedSource)
+	if err != nil {
+		return nil, err
+	}
+	if approval {
+		signatureJSON, err := marshalVerification(verification)
+		if err != nil {
+			return nil, err
+		}
+		return s.submitEntry(ctx, querier, sqlc.InsertEntrySubmissionParams{
+			SourceID:  managedSource.ID,
+			EntryType: sqlc.EntryTypePLUGIN,
+			Namespace: &plugin.Namespace,
+			Name:      plugin.Name,
+			Version:   plugin.Version,
+			Claims:    claimsJSON,
+			Signature: signatureJSON,
+		}, plugin)
 	}
-	sourceName := managedSource.Name
 
+	return nil, s.insertPublishedPlugin(ctx, querier, managedSource, plugin, claimsJSON, verification, checks.scan, checks.digests)
+}
+
+// insertPublishedPlugin inserts a published plugin version into the managed
+// source with its verified signature, scan and package digests, if any, and
+// records it in the change feed and the webhook outbox.
+//
+//nolint:gocyclo
+func (s *dbService) insertPublishedPlugin(
+	ctx context.Context,
+	querier *sqlc.Queries,
+	managedSource *sqlc.Source,
+	plugin *service.Plugin,
+	claimsJSON []byte,
+	verification *signing.Verification,
+	scan *scanning.Result,
+	digests []pinning.PackageDigest,
+) error {
 	now := time.Now().UTC()
 
 	// Get or create the registry entry (one per unique name)
@@ -491,11 +665,11 @@ func (s *dbService) executePublishPluginTransaction(
 	} else if err == nil {
 		entryID = existing.ID
 		if err := checkClaimConsistency(claimsJSON, existing.Claims); err != nil {
-			return "", err
+			return err
 		}
 	}
 	if err != nil {
-		return "", fmt.Errorf("failed to get or create registry entry: %w", err)
+		return fmt.Errorf("failed to get or create registry entry: %w", err)
 	}
 
 	// Insert the entry version (one per name+version)
@@ -517,19 +691,31 @@ func (s *dbService) executePublishPluginTransaction(
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-			return "", fmt.Errorf("%w: %s %s", service.ErrVersionAlreadyExists, plugin.Name, plugin.Version)
+			return fmt.Errorf("%w: %s %s", service.ErrVersionAlreadyExists, plugin.Name, plugin.Version)
 		}
-		return "", err
+		return err
 	}
 
 	pluginParams, err := makeInsertPluginVersionParams(versionID, plugin)
 	if err != nil {
-		return "", err
+		return err
 	}
 
 	_, err = querier.InsertPluginVersion(ctx, *pluginParams)
 	if err != nil {
-		return "", err
+		return err
+	}
+
+	if err := insertEntrySignature(ctx, querier, versionID, verification); err != nil {
+		return err
+	}
+
+	if err := insertEntryScan(ctx, querier, versionID, scan); err != nil {
+		return err
+	}
+
+	if err := insertPackageDigests(ctx, querier, managedSource.ID, digests); err != nil {
+		return err
 	}
 
 	for _, pkg := range plugin.Packages {
@@ -552,7 +738,7 @@ func (s *dbService) executePublishPluginTransaction(
 			})
 		}
 		if err != nil {
-			return "", err
+			return err
 		}
 	}
 
@@ -565,7 +751,7 @@ func (s *dbService) executePublishPluginTransaction(
 	if err == nil {
 		shouldUpdateLatest = versions.IsNewerVersion(plugin.Version, currentLatest)
 	} else if !errors.Is(err, pgx.ErrNoRows) {
-		return "", fmt.Errorf("failed to get current latest version: %w", err)
+		return fmt.Errorf("failed to get current latest version: %w", err)
 	}
 
 	if shouldUpdateLatest {
@@ -576,15 +762,22 @@ func (s *dbService) executePublishPluginTransaction(
 			VersionID: versionID,
 		})
 		if err != nil {
-			return "", fmt.Errorf("failed to upsert latest plugin version: %w", err)
+			return fmt.Errorf("failed to upsert latest plugin version: %w", err)
 		}
 	}
 
-	if err := tx.Commit(ctx); err != nil {
-		return "", fmt.Errorf("failed to commit transaction: %w", err)
+	if err := recordEntryChange(ctx, querier, managedSource.ID, sqlc.EntryTypePLUGIN, &plugin.Namespace,
+		plugin.Name, plugin.Version, sqlc.EntryChangeTypeCREATED); err != nil {
+		return err
 	}
 
-	return sourceName, nil
+	return s.notifyEntry(ctx, querier, notifications.EventEntryPublish, notifications.EntryData{
+		Source:    managedSource.Name,
+		EntryType: service.EntryTypePlugin,
+		Namespace: plugin.Namespace,
+		Name:      plugin.Name,
+		Version:   plugin.Version,
+	})
 }
 
 func makeInsertPluginVersionParams(
@@ -703,6 +896,9 @@ func (s *dbService) executeDeletePluginTransaction(
 		if err := validateClaimsVisibleBytes(ctx, gateClaims, existing.Claims); err != nil {
 			return err
 		}
+		if err := checkEntryNamespaceOwnership(ctx, querier, gateClaims, sqlc.EntryTypePLUGIN, existing.ID, options.Name); err != nil {
+			return err
+		}
 	}
 
 	entryID, err := lookupAndDeleteEntryVersion(
@@ -737,6 +933,21 @@ func (s *dbService) executeDeletePluginTransaction(
 		return err
 	}
 
+	if err := recordEntryChange(ctx, querier, registry.ID, sqlc.EntryTypePLUGIN, &options.Namespace,
+		options.Name, options.Version, sqlc.EntryChangeTypeDELETED); err != nil {
+		return err
+	}
+
+	if err := s.notifyEntry(ctx, querier, notifications.EventEntryDelete, notifications.EntryData{
+		Source:    registry.Name,
+		EntryType: service.EntryTypePlugin,
+		Namespace: options.Namespace,
+		Name:      options.Name,
+		Version:   options.Version,
+	}); err != nil {
+		return err
+	}
+
 	if err := cleanupOrphanedEntry(ctx, querier, entryID); err != nil {
 		return err
 	}
@@ -751,20 +962,22 @@ func (s *dbService) executeDeletePluginTransaction(
 // streamPluginRows fetches plugin rows in batches, applying the auth filter then the
 // dedup filter to each record, until limit+1 rows are accumulated or the DB is
 // exhausted. It returns the trimmed slice (≤ limit) and the encoded cursor for the
-// next page, if any.
+// next page, if any. A non-empty tsQuery runs a full-text search ordered by relevance.
 func streamPluginRows(
 	ctx context.Context,
 	querier *sqlc.Queries,
+	tsQuery string,
 	params sqlc.ListPluginsParams,
 	filter service.RecordFilter,
+	policy *mergePolicy,
 	limit int,
 ) ([]sqlc.ListPluginsRow, string, error) {
-	dedupFilter := newDeduplicatingPluginFilter()
+	dedupFilter := newDeduplicatingPluginFilter(policy)
 	var accumulated []sqlc.ListPluginsRow
 	batchParams := params
 
 	for {
-		batch, err := querier.ListPlugins(ctx, batchParams)
+		batch, err := listOrSearchPlugins(ctx, querier, tsQuery, batchParams)
 		if err != nil {
 			return nil, "", err
 		}
@@ -809,13 +1022,14 @@ func streamPluginRows(
 }
 
 // newDeduplicatingPluginFilter returns a stateful RecordFilter that deduplicates
-// plugin rows by entry name, keeping only records from the highest-priority source
-// (lowest position). SQL must return records in position-ascending order per name.
-func newDeduplicatingPluginFilter() service.RecordFilter {
-	return newDeduplicatingFilterWith(
-		func(record any) (string, int32, bool) {
+// plugin rows according to the merge policy of the registry. With a nil policy it
+// keeps only records from the highest-priority source (lowest position) of each
+// entry name. SQL must return records in position-ascending order per name.
+func newDeduplicatingPluginFilter(policy *mergePolicy) service.RecordFilter {
+	return policy.newFilter(
+		func(record any) (mergeRecord, bool) {
 			r, ok := record.(sqlc.ListPluginsRow)
-			return r.Name, r.Position, ok
+			return mergeRecord{entryType: sqlc.EntryTypePLUGIN, name: r.Name, version: r.Version, position: r.Position}, ok
 		},
 	)
 }
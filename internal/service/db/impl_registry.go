@@ -199,6 +199,7 @@ func (s *dbService) CreateRegistry(
 	inserted, err := querier.UpsertRegistry(ctx, sqlc.UpsertRegistryParams{
 		Name:         name,
 		Claims:       db.SerializeClaims(req.Claims),
+		Merge:        serializeMerge(req.Merge),
 		CreationType: sqlc.CreationTypeAPI,
 		CreatedAt:    &now,
 		UpdatedAt:    &now,
@@ -298,6 +299,7 @@ func (s *dbService) UpdateRegistry(
 	upserted, err := querier.UpsertRegistry(ctx, sqlc.UpsertRegistryParams{
 		Name:         name,
 		Claims:       db.SerializeClaims(req.Claims),
+		Merge:        serializeMerge(req.Merge),
 		CreationType: sqlc.CreationTypeAPI,
 		CreatedAt:    existing.CreatedAt,
 		UpdatedAt:    &now,
@@ -444,31 +446,16 @@ func (s *dbService) ListRegistryEntries(ctx context.Context, registryName string
 		return nil, fmt.Errorf("failed to list entries by registry: %w", err)
 	}
 
-	// Map each row directly to RegistryEntryInfo (flat, no grouping)
-	result := make([]service.RegistryEntryInfo, 0, len(rows))
-	for _, row := range rows {
-		info := service.RegistryEntryInfo{
-			EntryType:  string(row.EntryType),
-			Name:       row.Name,
-			Version:    row.Version,
-			SourceName: row.SourceName,
-			Position:   row.Position,
-		}
-		if row.Title != nil {
-			info.Title = *row.Title
-		}
-		if row.Description != nil {
-			info.Description = *row.Description
-		}
-		if row.CreatedAt != nil {
-			info.CreatedAt = *row.CreatedAt
-		}
-		if row.UpdatedAt != nil {
-			info.UpdatedAt = *row.UpdatedAt
-		}
-		result = append(result, info)
+	policy, err := loadMergePolicy(ctx, querier, registry, "", nil)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
 	}
 
+	// Map each row to RegistryEntryInfo (flat, no grouping), with the provenance
+	// of the fields of the versions the registry serves
+	result := mergeRegistryEntries(rows, policy)
+
 	span.SetAttributes(otel.AttrResultCount.Int(len(result)))
 	slog.DebugContext(ctx, "ListRegistryEntries completed",
 		"duration_ms", time.Since(start).Milliseconds(),
@@ -528,6 +515,7 @@ func newRegistryInfo(reg sqlc.Registry, sourceNames []string) *service.RegistryI
 	info := &service.RegistryInfo{
 		Name:         reg.Name,
 		Claims:       db.DeserializeClaims(reg.Claims),
+		Merge:        deserializeMerge(reg.Merge),
 		CreationType: service.CreationType(reg.CreationType),
 		Sources:      sourceNames,
 	}
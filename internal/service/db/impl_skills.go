@@ -15,8 +15,12 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 
 	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/notifications"
 	"github.com/stacklok/toolhive-registry-server/internal/otel"
+	"github.com/stacklok/toolhive-registry-server/internal/pinning"
+	"github.com/stacklok/toolhive-registry-server/internal/scanning"
 	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/signing"
 	"github.com/stacklok/toolhive-registry-server/internal/versions"
 )
 
@@ -54,7 +58,7 @@ func (s *dbService) ListSkills(
 	if s.skipAuthz {
 		gateClaims = nil
 	}
-	registryID, err := lookupRegistryIDWithGate(ctx, s.pool, options.RegistryName, gateClaims)
+	registry, err := lookupRegistryWithGate(ctx, s.pool, options.RegistryName, gateClaims)
 	if err != nil {
 		otel.RecordError(span, err)
 		return nil, err
@@ -62,8 +66,14 @@ func (s *dbService) ListSkills(
 
 	querier := sqlc.New(s.pool)
 
+	policy, err := loadMergePolicy(ctx, querier, registry, sqlc.EntryTypeSKILL, options.Name)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+
 	params := sqlc.ListSkillsParams{
-		RegistryID: registryID,
+		RegistryID: registry.ID,
 		Size:       int64(options.Limit + 1),
 	}
 	if options.Namespace != "" {
@@ -72,8 +82,9 @@ func (s *dbService) ListSkills(
 	if options.Name != nil {
 		params.Name = options.Name
 	}
+	var tsQuery string
 	if options.Search != nil {
-		params.Search = options.Search
+		tsQuery = toPrefixTSQuery(*options.Search)
 	}
 	if options.Cursor != nil {
 		cursorName, cursorVersion, err := service.DecodeCursor(*options.Cursor)
@@ -95,7 +106,7 @@ func (s *dbService) ListSkills(
 	if s.skipAuthz {
 		claimsFilter = nil
 	}
-	listRows, nextCursor, err := streamSkillRows(ctx, querier, params, claimsFilter, options.Limit)
+	listRows, nextCursor, err := streamSkillRows(ctx, querier, tsQuery, params, claimsFilter, policy, options.Limit)
 	if err != nil {
 		otel.RecordError(span, err)
 		return nil, err
@@ -107,10 +118,33 @@ func (s *dbService) ListSkills(
 		return nil, err
 	}
 
+	versionIDs := make([]uuid.UUID, len(listRows))
+	for i, row := range listRows {
+		versionIDs[i] = row.VersionID
+	}
+	signatures, err := fetchEntrySignatures(ctx, querier, versionIDs)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+	scans, err := fetchEntryScans(ctx, querier, versionIDs)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+	digests, err := fetchPackageDigests(ctx, querier, versionIDs)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+
 	skills := make([]*service.Skill, len(listRows))
 	for i, row := range listRows {
 		skill := service.ListSkillsRowToSkill(row)
 		skill.Packages = packages[row.VersionID]
+		skill.Meta = withSignatureMeta(skill.Meta, signatures[row.VersionID])
+		skill.Meta = withScanMeta(skill.Meta, scans[row.VersionID])
+		skill.Meta = withDigestsMeta(skill.Meta, digests[row.VersionID])
 		skills[i] = skill
 	}
 
@@ -186,14 +220,26 @@ func (s *dbService) GetSkillVersion(
 	if s.skipAuthz {
 		gateClaims = nil
 	}
-	registryID, err := lookupRegistryIDWithGate(ctx, s.pool, options.RegistryName, gateClaims)
+	registry, err := lookupRegistryWithGate(ctx, s.pool, options.RegistryName, gateClaims)
 	if err != nil {
 		otel.RecordError(span, err)
 		return nil, err
 	}
+	registryID := registry.ID
 
 	querier := sqlc.New(s.pool)
 
+	// An explicit source bypasses the merge strategies of the registry: the
+	// version is served as that source provides it.
+	var policy *mergePolicy
+	if options.SourceName == "" {
+		policy, err = loadMergePolicy(ctx, querier, registry, sqlc.EntryTypeSKILL, &options.Name)
+		if err != nil {
+			otel.RecordError(span, err)
+			return nil, err
+		}
+	}
+
 	params := sqlc.GetSkillVersionParams{
 		Name:       options.Name,
 		Version:    options.Version,
@@ -218,7 +264,8 @@ func (s *dbService) GetSkillVersion(
 	// one that passes the claims check, promoting lower-priority sources when
 	// higher-priority ones fail. The filter is nil for skipAuthz, anonymous,
 	// and super-admin callers (uniform bypass — see newClaimsFilterWith), in
-	// which case the highest-priority row wins outright.
+	// which case the highest-priority row wins outright. The rows passing the
+	// claims check are then subject to the merge strategies of the registry.
 	claimsFilter := newClaimsFilterWith(
 		ctx, options.Claims,
 		func(record any) ([]byte, bool) {
@@ -229,18 +276,29 @@ func (s *dbService) GetSkillVersion(
 	if s.skipAuthz {
 		claimsFilter = nil
 	}
+	mergeFilter := policy.newFilter(
+		func(record any) (mergeRecord, bool) {
+			r, ok := record.(sqlc.GetSkillVersionRow)
+			return mergeRecord{entryType: sqlc.EntryTypeSKILL, name: r.Name, version: r.Version, position: r.Position}, ok
+		},
+	)
 	var row sqlc.GetSkillVersionRow
 	found := false
 	for _, r := range rows {
-		if claimsFilter == nil {
-			row = r
-			found = true
-			break
+		ok := true
+		if claimsFilter != nil {
+			ok, err = claimsFilter(ctx, r)
+			if err != nil {
+				otel.RecordError(span, err)
+				return nil, err
+			}
 		}
-		ok, err := claimsFilter(ctx, r)
-		if err != nil {
-			otel.RecordError(span, err)
-			return nil, err
+		if ok {
+			ok, err = mergeFilter(ctx, r)
+			if err != nil {
+				otel.RecordError(span, err)
+				return nil, err
+			}
 		}
 		if ok {
 			row = r
@@ -269,9 +327,27 @@ func (s *dbService) GetSkillVersion(
 	for _, pkg := range gitPackages {
 		packages = append(packages, toServiceSkillGitPackage(pkg))
 	}
+	signatures, err := fetchEntrySignatures(ctx, querier, []uuid.UUID{row.SkillVersionID})
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+	scans, err := fetchEntryScans(ctx, querier, []uuid.UUID{row.SkillVersionID})
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+	digests, err := fetchPackageDigests(ctx, querier, []uuid.UUID{row.SkillVersionID})
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
 
 	res := service.GetSkillVersionRowToSkill(row)
 	res.Packages = packages
+	res.Meta = withSignatureMeta(res.Meta, signatures[row.SkillVersionID])
+	res.Meta = withScanMeta(res.Meta, scans[row.SkillVersionID])
+	res.Meta = withDigestsMeta(res.Meta, digests[row.SkillVersionID])
 	return res, nil
 }
 
@@ -355,7 +431,7 @@ func (s *dbService) PublishSkill(
 		}
 	}
 
-	sourceName, err := s.executePublishSkillTransaction(ctx, skill, claimsJSON, gateClaims)
+	sourceName, err := s.executePublishSkillTransaction(ctx, skill, claimsJSON, gateClaims, options.Signature)
 	if err != nil {
 		otel.RecordError(span, err)
 		return nil, err
@@ -404,6 +480,18 @@ func (s *dbService) fetchSkillVersionBySource(
 	for _, pkg := range gitPackages {
 		packages = append(packages, toServiceSkillGitPackage(pkg))
 	}
+	signatures, err := fetchEntrySignatures(ctx, querier, []uuid.UUID{row.SkillVersionID})
+	if err != nil {
+		return nil, err
+	}
+	scans, err := fetchEntryScans(ctx, querier, []uuid.UUID{row.SkillVersionID})
+	if err != nil {
+		return nil, err
+	}
+	digests, err := fetchPackageDigests(ctx, querier, []uuid.UUID{row.SkillVersionID})
+	if err != nil {
+		return nil, err
+	}
 
 	result := service.GetSkillVersionRowToSkill(sqlc.GetSkillVersionRow{
 		RegistryType:   row.RegistryType,
@@ -429,19 +517,28 @@ func (s *dbService) fetchSkillVersionBySource(
 		Position:       row.Position,
 	})
 	result.Packages = packages
+	result.Meta = withSignatureMeta(result.Meta, signatures[row.SkillVersionID])
+	result.Meta = withScanMeta(result.Meta, scans[row.SkillVersionID])
+	result.Meta = withDigestsMeta(result.Meta, digests[row.SkillVersionID])
 	return result, nil
 }
 
 // executePublishSkillTransaction executes the skill publish operation within a transaction.
 // Returns the managed source name for fetch-back, or an error.
-//
-//nolint:gocyclo
 func (s *dbService) executePublishSkillTransaction(
 	ctx context.Context,
 	skill *service.Skill,
 	claimsJSON []byte,
 	gateClaims map[string]any,
+	signature *service.EntrySignature,
 ) (string, error) {
+	// Pin the OCI packages of the skill and scan it before the transaction,
+	// refusing the publish if a required pin is missing or the skill is blocked
+	checks, err := s.preparePublish(ctx, &service.PortableEntry{Skill: skill}, gateClaims)
+	if err != nil {
+		return "", err
+	}
+
 	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{
 		IsoLevel:   pgx.Serializable,
 		AccessMode: pgx.ReadWrite,
@@ -463,14 +560,91 @@ func (s *dbService) executePublishSkillTransaction(
 		return "", err
 	}
 
+	submission, err := s.publishSkillInTx(ctx, querier, managedSource, skill, claimsJSON, gateClaims, signature, checks)
+	if err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return "", fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if submission != nil {
+		return "", &service.PendingReviewError{Submission: submission}
+	}
+	return managedSource.Name, nil
+}
+
+// publishSkillInTx publishes a skill version into the managed source, in the
+// transaction of querier, with the results of the checks run before it. It
+// returns the submission holding the publish when the source requires approval.
+func (s *dbService) publishSkillInTx(
+	ctx context.Context,
+	querier *sqlc.Queries,
+	managedSource *sqlc.Source,
+	skill *service.Skill,
+	claimsJSON []byte,
+	gateClaims map[string]any,
+	signature *service.EntrySignature,
+	checks *publishChecks,
+) (*service.EntrySubmission, error) {
 	// Verify the caller may publish into this source: their JWT must cover the
 	// source's claims (visibility / OR — auth.md §3/§5). An untagged managed
 	// source is publishable only by super-admin (default-deny, #845).
 	if err := validateClaimsVisibleBytes(ctx, gateClaims, managedSource.Claims); err != nil {
-		return "", err
+		return nil, err
+	}
+
+	// Verify the caller owns the namespace of the skill
+	if err := checkNamespaceOwnership(ctx, querier, gateClaims, skill.Namespace); err != nil {
+		return nil, err
+	}
+
+	// Verify the signature attached to the publish against the trusted keys
+	verification, err := verifyEntrySignature(managedSource, signedEntryName(skill.Namespace, skill.Name), signature)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hold the publish for review when the source requires approval
+	approval, err := requiresApproval(managedSource)
+	if err != nil {
+		return nil, err
+	}
+	if approval {
+		signatureJSON, err := marshalVerification(verification)
+		if err != nil {
+			return nil, err
+		}
+		return s.submitEntry(ctx, querier, sqlc.InsertEntrySubmissionParams{
+			SourceID:  managedSource.ID,
+			EntryType: sqlc.EntryTypeSKILL,
+			Namespace: &skill.Namespace,
+			Name:      skill.Name,
+			Version:   skill.Version,
+			Claims:    claimsJSON,
+			Signature: signatureJSON,
+		}, skill)
 	}
-	sourceName := managedSource.Name
 
+	return nil, s.insertPublishedSkill(ctx, querier, managedSource, skill, claimsJSON, verification, checks.scan, checks.digests)
+}
+
+// insertPublishedSkill inserts a published skill version into the managed
+// source with its verified signature, scan and package digests, if any, and
+// records it in the change feed and the webhook outbox.
+//
+//nolint:gocyclo
+func (s *dbService) insertPublishedSkill(
+	ctx context.Context,
+	querier *sqlc.Queries,
+	managedSource *sqlc.Source,
+	skill *service.Skill,
+	claimsJSON []byte,
+	verification *signing.Verification,
+	scan *scanning.Result,
+	digests []pinning.PackageDigest,
+) error {
 	now := time.Now().UTC()
 
 	// Get or create the registry entry (one per unique name)
@@ -492,11 +666,11 @@ func (s *dbService) executePublishSkillTransaction(
 	} else if err == nil {
 		entryID = existing.ID
 		if err := checkClaimConsistency(claimsJSON, existing.Claims); err != nil {
-			return "", err
+			return err
 		}
 	}
 	if err != nil {
-		return "", fmt.Errorf("failed to get or create registry entry: %w", err)
+		return fmt.Errorf("failed to get or create registry entry: %w", err)
 	}
 
 	// Insert the entry version (one per name+version)
@@ -518,19 +692,31 @@ func (s *dbService) executePublishSkillTransaction(
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-			return "", fmt.Errorf("%w: %s %s", service.ErrVersionAlreadyExists, skill.Name, skill.Version)
+			return fmt.Errorf("%w: %s %s", service.ErrVersionAlreadyExists, skill.Name, skill.Version)
 		}
-		return "", err
+		return err
 	}
 
 	skillParams, err := makeInsertSkillVersionParams(versionID, skill)
 	if err != nil {
-		return "", err
+		return err
 	}
 
 	_, err = querier.InsertSkillVersion(ctx, *skillParams)
 	if err != nil {
-		return "", err
+		return err
+	}
+
+	if err := insertEntrySignature(ctx, querier, versionID, verification); err != nil {
+		return err
+	}
+
+	if err := insertEntryScan(ctx, querier, versionID, scan); err != nil {
+		return err
+	}
+
+	if err := insertPackageDigests(ctx, querier, managedSource.ID, digests); err != nil {
+		return err
 	}
 
 	for _, pkg := range skill.Packages {
@@ -553,7 +739,7 @@ func (s *dbService) executePublishSkillTransaction(
 			})
 		}
 		if err != nil {
-			return "", err
+			return err
 		}
 	}
 
@@ -566,7 +752,7 @@ func (s *dbService) executePublishSkillTransaction(
 	if err == nil {
 		shouldUpdateLatest = versions.IsNewerVersion(skill.Version, currentLatest)
 	} else if !errors.Is(err, pgx.ErrNoRows) {
-		return "", fmt.Errorf("failed to get current latest version: %w", err)
+		return fmt.Errorf("failed to get current latest version: %w", err)
 	}
 
 	if shouldUpdateLatest {
@@ -577,15 +763,22 @@ func (s *dbService) executePublishSkillTransaction(
 			VersionID: versionID,
 		})
 		if err != nil {
-			return "", fmt.Errorf("failed to upsert latest skill version: %w", err)
+			return fmt.Errorf("failed to upsert latest skill version: %w", err)
 		}
 	}
 
-	if err := tx.Commit(ctx); err != nil {
-		return "", fmt.Errorf("failed to commit transaction: %w", err)
+	if err := recordEntryChange(ctx, querier, managedSource.ID, sqlc.EntryTypeSKILL, &skill.Namespace,
+		skill.Name, skill.Version, sqlc.EntryChangeTypeCREATED); err != nil {
+		return err
 	}
 
-	return sourceName, nil
+	return s.notifyEntry(ctx, querier, notifications.EventEntryPublish, notifications.EntryData{
+		Source:    managedSource.Name,
+		EntryType: service.EntryTypeSkill,
+		Namespace: skill.Namespace,
+		Name:      skill.Name,
+		Version:   skill.Version,
+	})
 }
 
 func makeInsertSkillVersionParams(
@@ -708,6 +901,9 @@ func (s *dbService) executeDeleteSkillTransaction(
 		if err := validateClaimsVisibleBytes(ctx, gateClaims, existing.Claims); err != nil {
 			return err
 		}
+		if err := checkEntryNamespaceOwnership(ctx, querier, gateClaims, sqlc.EntryTypeSKILL, existing.ID, options.Name); err != nil {
+			return err
+		}
 	}
 
 	entryID, err := lookupAndDeleteEntryVersion(
@@ -742,6 +938,21 @@ func (s *dbService) executeDeleteSkillTransaction(
 		return err
 	}
 
+	if err := recordEntryChange(ctx, querier, registry.ID, sqlc.EntryTypeSKILL, &options.Namespace,
+		options.Name, options.Version, sqlc.EntryChangeTypeDELETED); err != nil {
+		return err
+	}
+
+	if err := s.notifyEntry(ctx, querier, notifications.EventEntryDelete, notifications.EntryData{
+		Source:    registry.Name,
+		EntryType: service.EntryTypeSkill,
+		Namespace: options.Namespace,
+		Name:      options.Name,
+		Version:   options.Version,
+	}); err != nil {
+		return err
+	}
+
 	if err := cleanupOrphanedEntry(ctx, querier, entryID); err != nil {
 		return err
 	}
@@ -756,20 +967,22 @@ func (s *dbService) executeDeleteSkillTransaction(
 // streamSkillRows fetches skill rows in batches, applying the auth filter then the
 // dedup filter to each record, until limit+1 rows are accumulated or the DB is
 // exhausted. It returns the trimmed slice (≤ limit) and the encoded cursor for the
-// next page, if any.
+// next page, if any. A non-empty tsQuery runs a full-text search ordered by relevance.
 func streamSkillRows(
 	ctx context.Context,
 	querier *sqlc.Queries,
+	tsQuery string,
 	params sqlc.ListSkillsParams,
 	filter service.RecordFilter,
+	policy *mergePolicy,
 	limit int,
 ) ([]sqlc.ListSkillsRow, string, error) {
-	dedupFilter := newDeduplicatingSkillFilter()
+	dedupFilter := newDeduplicatingSkillFilter(policy)
 	var accumulated []sqlc.ListSkillsRow
 	batchParams := params
 
 	for {
-		batch, err := querier.ListSkills(ctx, batchParams)
+		batch, err := listOrSearchSkills(ctx, querier, tsQuery, batchParams)
 		if err != nil {
 			return nil, "", err
 		}
@@ -814,13 +1027,14 @@ func streamSkillRows(
 }
 
 // newDeduplicatingSkillFilter returns a stateful RecordFilter that deduplicates
-// skill rows by entry name, keeping only records from the highest-priority source
-// (lowest position). SQL must return records in position-ascending order per name.
-func newDeduplicatingSkillFilter() service.RecordFilter {
-	return newDeduplicatingFilterWith(
-		func(record any) (string, int32, bool) {
+// skill rows according to the merge policy of the registry. With a nil policy it
+// keeps only records from the highest-priority source (lowest position) of each
+// entry name. SQL must return records in position-ascending order per name.
+func newDeduplicatingSkillFilter(policy *mergePolicy) service.RecordFilter {
+	return policy.newFilter(
+		func(record any) (mergeRecord, bool) {
 			r, ok := record.(sqlc.ListSkillsRow)
-			return r.Name, r.Position, ok
+			return mergeRecord{entryType: sqlc.EntryTypeSKILL, name: r.Name, version: r.Version, position: r.Position}, ok
 		},
 	)
 }
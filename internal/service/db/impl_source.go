@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/aws/smithy-go/ptr"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -326,6 +327,13 @@ func (s *dbService) UpdateSource(
 		return nil, updateErr
 	}
 
+	// The repository, ref or path may have changed, so the next sync fetches the
+	// data instead of only checking the remote commit
+	if err := querier.ClearSourceSyncCommits(ctx, []uuid.UUID{source.ID}); err != nil {
+		otel.RecordError(span, err)
+		return nil, fmt.Errorf("failed to reset source sync commit: %w", err)
+	}
+
 	// Commit transaction
 	if err := tx.Commit(ctx); err != nil {
 		otel.RecordError(span, err)
@@ -706,6 +714,102 @@ func (s *dbService) ListSourceEntries(ctx context.Context, sourceName string) ([
 	return result, nil
 }
 
+// ExportSource passes every entry version of a source, with its claims, to emit:
+// servers first, then skills, then plugins.
+func (s *dbService) ExportSource(
+	ctx context.Context, sourceName string, emit func(*service.PortableEntry) error,
+) error {
+	ctx, span := s.startSpan(ctx, "dbService.ExportSource")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(otel.AttrRegistryName.String(sourceName))
+
+	querier := sqlc.New(s.pool)
+
+	source, err := querier.GetSourceByName(ctx, sourceName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = fmt.Errorf("%w: %s", service.ErrSourceNotFound, sourceName)
+			otel.RecordError(span, err)
+			return err
+		}
+		otel.RecordError(span, err)
+		return fmt.Errorf("failed to get source: %w", err)
+	}
+
+	// Validate caller's JWT covers the source's claims (hide existence on failure)
+	callerClaims := claimsFromCtx(ctx)
+	if s.skipAuthz {
+		callerClaims = nil
+	}
+	if err := validateClaimsVisibleBytes(ctx, callerClaims, source.Claims); err != nil {
+		err = fmt.Errorf("%w: %s", service.ErrSourceNotFound, sourceName)
+		otel.RecordError(span, err)
+		return err
+	}
+
+	rows, err := querier.ListEntriesBySource(ctx, source.ID)
+	if err != nil {
+		otel.RecordError(span, err)
+		return fmt.Errorf("failed to list entries by source: %w", err)
+	}
+
+	count := 0
+	for _, entryType := range []sqlc.EntryType{sqlc.EntryTypeMCP, sqlc.EntryTypeSKILL, sqlc.EntryTypePLUGIN} {
+		for _, row := range rows {
+			if row.EntryType != entryType {
+				continue
+			}
+			entry, err := s.fetchPortableEntry(ctx, row.EntryType, row.Name, row.Version, source.Name)
+			if err != nil {
+				otel.RecordError(span, err)
+				return fmt.Errorf("failed to fetch %s %s %s: %w", entryTypeName(row.EntryType), row.Name, row.Version, err)
+			}
+			entry.Claims = db.DeserializeClaims(row.Claims)
+			if err := emit(entry); err != nil {
+				otel.RecordError(span, err)
+				return err
+			}
+			count++
+		}
+	}
+
+	span.SetAttributes(otel.AttrResultCount.Int(count))
+	slog.DebugContext(ctx, "ExportSource completed",
+		"duration_ms", time.Since(start).Milliseconds(),
+		"source", sourceName,
+		"entry_count", count,
+		"request_id", middleware.GetReqID(ctx))
+	return nil
+}
+
+// fetchPortableEntry retrieves an entry version of a source with its data.
+func (s *dbService) fetchPortableEntry(
+	ctx context.Context, entryType sqlc.EntryType, name, version, sourceName string,
+) (*service.PortableEntry, error) {
+	switch entryType {
+	case sqlc.EntryTypeMCP:
+		server, err := s.fetchServerVersionBySource(ctx, name, version, sourceName)
+		if err != nil {
+			return nil, err
+		}
+		return &service.PortableEntry{Server: server}, nil
+	case sqlc.EntryTypeSKILL:
+		skill, err := s.fetchSkillVersionBySource(ctx, name, version, sourceName)
+		if err != nil {
+			return nil, err
+		}
+		return &service.PortableEntry{Skill: skill}, nil
+	default:
+		plugin, err := s.fetchPluginVersionBySource(ctx, name, version, sourceName)
+		if err != nil {
+			return nil, err
+		}
+		return &service.PortableEntry{Plugin: plugin}, nil
+	}
+}
+
 // =============================================================================
 // Helper functions for source CRUD operations
 // =============================================================================
@@ -927,6 +1031,7 @@ func fetchSyncStatus(ctx context.Context, querier *sqlc.Queries, sourceName stri
 		SkillCount:   int(syncRecord.SkillCount),
 		PluginCount:  int(syncRecord.PluginCount),
 		Message:      getStatusMessage(syncRecord.ErrorMsg),
+		CommitSHA:    ptr.ToString(syncRecord.LastSyncCommit),
 	}
 }
 
@@ -0,0 +1,331 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/otel"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// RequestSourceSync queues a manual sync for a source.
+// The sync coordinator picks up sources with a pending request on its next
+// poll, regardless of their sync schedule.
+func (s *dbService) RequestSourceSync(ctx context.Context, name string) (*service.SyncRequestInfo, error) {
+	ctx, span := s.startSpan(ctx, "dbService.RequestSourceSync")
+	defer span.End()
+	start := time.Now()
+
+	// Add tracing attributes
+	span.SetAttributes(otel.AttrRegistryName.String(name))
+
+	// Begin transaction
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.ReadCommitted,
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		err := tx.Rollback(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.WarnContext(ctx, "Failed to rollback transaction", "error", err)
+		}
+	}()
+
+	querier := sqlc.New(tx)
+
+	if err := s.checkSourceVisible(ctx, querier, name); err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+
+	// Non-syncable sources (managed, kubernetes, inline data) produce no rows
+	request, err := querier.InsertSyncRequest(ctx, name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = fmt.Errorf("%w: %s", service.ErrSourceNotSyncable, name)
+			otel.RecordError(span, err)
+			return nil, err
+		}
+		otel.RecordError(span, err)
+		return nil, fmt.Errorf("failed to queue sync request: %w", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		otel.RecordError(span, err)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Source sync requested",
+		"duration_ms", time.Since(start).Milliseconds(),
+		"source", name,
+		"sync_request_id", request.ID.String(),
+		"request_id", middleware.GetReqID(ctx))
+
+	return buildSyncRequestInfo(name, &request), nil
+}
+
+// GetSourceSyncRequest returns a manual sync request of a source by ID
+func (s *dbService) GetSourceSyncRequest(
+	ctx context.Context, name string, id string,
+) (*service.SyncRequestInfo, error) {
+	ctx, span := s.startSpan(ctx, "dbService.GetSourceSyncRequest")
+	defer span.End()
+
+	// Add tracing attributes
+	span.SetAttributes(otel.AttrRegistryName.String(name))
+
+	requestID, err := uuid.Parse(id)
+	if err != nil {
+		err = fmt.Errorf("%w: %s", service.ErrSyncRequestNotFound, id)
+		otel.RecordError(span, err)
+		return nil, err
+	}
+
+	// Begin a read-only transaction
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.ReadCommitted,
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		err := tx.Rollback(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.WarnContext(ctx, "Failed to rollback transaction", "error", err)
+		}
+	}()
+
+	querier := sqlc.New(tx)
+
+	if err := s.checkSourceVisible(ctx, querier, name); err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+
+	request, err := querier.GetSyncRequest(ctx, sqlc.GetSyncRequestParams{
+		ID:   requestID,
+		Name: name,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = fmt.Errorf("%w: %s", service.ErrSyncRequestNotFound, id)
+			otel.RecordError(span, err)
+			return nil, err
+		}
+		otel.RecordError(span, err)
+		return nil, fmt.Errorf("failed to get sync request: %w", err)
+	}
+
+	return buildSyncRequestInfo(name, &request), nil
+}
+
+// ListSourceSyncs returns the sync history of a source, newest first.
+// Pagination uses an opaque cursor encoding the start time and ID of the last
+// returned sync attempt.
+func (s *dbService) ListSourceSyncs(
+	ctx context.Context, name string, opts ...service.Option,
+) (*service.SourceSyncListResponse, error) {
+	ctx, span := s.startSpan(ctx, "dbService.ListSourceSyncs")
+	defer span.End()
+
+	// Add tracing attributes
+	span.SetAttributes(otel.AttrRegistryName.String(name))
+
+	options := &service.ListSourceSyncsOptions{
+		Limit: service.DefaultPageSize,
+	}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			otel.RecordError(span, err)
+			return nil, err
+		}
+	}
+	if options.Limit > service.MaxPageSize {
+		options.Limit = service.MaxPageSize
+	}
+
+	params := sqlc.ListSyncHistoryParams{
+		Name: name,
+		Size: int64(options.Limit + 1),
+	}
+	if options.Cursor != "" {
+		startedAt, id, err := decodeSyncHistoryCursor(options.Cursor)
+		if err != nil {
+			otel.RecordError(span, err)
+			return nil, err
+		}
+		params.CursorStartedAt = &startedAt
+		params.CursorID = &id
+	}
+
+	// Begin a read-only transaction
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.ReadCommitted,
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		err := tx.Rollback(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.WarnContext(ctx, "Failed to rollback transaction", "error", err)
+		}
+	}()
+
+	querier := sqlc.New(tx)
+
+	if err := s.checkSourceVisible(ctx, querier, name); err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+
+	rows, err := querier.ListSyncHistory(ctx, params)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, fmt.Errorf("failed to list sync history: %w", err)
+	}
+
+	// One extra row was requested to know whether there is a next page
+	var nextCursor string
+	if len(rows) > options.Limit {
+		rows = rows[:options.Limit]
+		last := rows[len(rows)-1]
+		nextCursor = encodeSyncHistoryCursor(last.StartedAt, last.ID)
+	}
+
+	syncs := make([]service.SourceSyncInfo, len(rows))
+	for i := range rows {
+		syncs[i] = buildSourceSyncInfo(&rows[i])
+	}
+
+	return &service.SourceSyncListResponse{
+		Syncs:      syncs,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// encodeSyncHistoryCursor encodes the position of a sync attempt into a pagination cursor
+func encodeSyncHistoryCursor(startedAt time.Time, id uuid.UUID) string {
+	return service.EncodeCursor(startedAt.UTC().Format(time.RFC3339Nano), id.String())
+}
+
+// decodeSyncHistoryCursor decodes a pagination cursor created by encodeSyncHistoryCursor
+func decodeSyncHistoryCursor(cursor string) (time.Time, uuid.UUID, error) {
+	rawStartedAt, rawID, err := service.DecodeCursor(cursor)
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("%w: %w", service.ErrInvalidCursor, err)
+	}
+	startedAt, err := time.Parse(time.RFC3339Nano, rawStartedAt)
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("%w: %w", service.ErrInvalidCursor, err)
+	}
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("%w: %w", service.ErrInvalidCursor, err)
+	}
+	return startedAt, id, nil
+}
+
+// buildSourceSyncInfo converts a database sync history row to its service representation
+func buildSourceSyncInfo(row *sqlc.SyncHistory) service.SourceSyncInfo {
+	return service.SourceSyncInfo{
+		ID:              row.ID.String(),
+		Status:          convertSyncPhase(row.SyncStatus),
+		Reason:          row.Reason,
+		ConditionReason: ptr.ToString(row.ConditionReason),
+		Message:         getStatusMessage(row.ErrorMsg),
+		Hash:            ptr.ToString(row.SyncHash),
+		Incremental:     row.Incremental,
+		ServerCount:     int(row.ServerCount),
+		SkillCount:      int(row.SkillCount),
+		PluginCount:     int(row.PluginCount),
+		Changes: service.SyncChangesInfo{
+			Servers: service.EntryChangesInfo{
+				Added:   int(row.ServersAdded),
+				Updated: int(row.ServersUpdated),
+				Removed: int(row.ServersRemoved),
+			},
+			Skills: service.EntryChangesInfo{
+				Added:   int(row.SkillsAdded),
+				Updated: int(row.SkillsUpdated),
+				Removed: int(row.SkillsRemoved),
+			},
+			Plugins: service.EntryChangesInfo{
+				Added:   int(row.PluginsAdded),
+				Updated: int(row.PluginsUpdated),
+				Removed: int(row.PluginsRemoved),
+			},
+		},
+		StartedAt: row.StartedAt,
+		EndedAt:   row.EndedAt,
+	}
+}
+
+// checkSourceVisible verifies that the source exists and that the caller's JWT
+// covers its claims. Both cases return ErrSourceNotFound to hide existence.
+func (s *dbService) checkSourceVisible(ctx context.Context, querier *sqlc.Queries, name string) error {
+	source, err := querier.GetSourceByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: %s", service.ErrSourceNotFound, name)
+		}
+		return fmt.Errorf("failed to get source: %w", err)
+	}
+
+	callerClaims := claimsFromCtx(ctx)
+	if s.skipAuthz {
+		callerClaims = nil
+	}
+	if err := validateClaimsVisibleBytes(ctx, callerClaims, source.Claims); err != nil {
+		return fmt.Errorf("%w: %s", service.ErrSourceNotFound, name)
+	}
+	return nil
+}
+
+// buildSyncRequestInfo converts a database sync request to its service representation
+func buildSyncRequestInfo(sourceName string, request *sqlc.SyncRequest) *service.SyncRequestInfo {
+	return &service.SyncRequestInfo{
+		ID:          request.ID.String(),
+		SourceName:  sourceName,
+		Status:      convertSyncRequestStatus(request.Status),
+		Message:     getStatusMessage(request.ErrorMsg),
+		RequestedAt: request.RequestedAt,
+		StartedAt:   request.StartedAt,
+		EndedAt:     request.EndedAt,
+	}
+}
+
+// convertSyncRequestStatus converts database SyncRequestStatus enum to service status string.
+// Values match the phases reported by convertSyncPhase.
+func convertSyncRequestStatus(status sqlc.SyncRequestStatus) string {
+	switch status {
+	case sqlc.SyncRequestStatusPENDING:
+		return "pending"
+	case sqlc.SyncRequestStatusINPROGRESS:
+		return "syncing"
+	case sqlc.SyncRequestStatusCOMPLETED:
+		return "complete"
+	case sqlc.SyncRequestStatusFAILED:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
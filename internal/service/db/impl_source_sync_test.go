@@ -0,0 +1,178 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+func TestRequestSourceSync(t *testing.T) {
+	t.Parallel()
+
+	svc, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	queries := sqlc.New(svc.pool)
+
+	_, err := queries.UpsertSource(ctx, sqlc.UpsertSourceParams{
+		Name:         "git-source",
+		CreationType: sqlc.CreationTypeCONFIG,
+		SourceType:   "git",
+		Syncable:     true,
+	})
+	require.NoError(t, err)
+	_, err = queries.UpsertSource(ctx, sqlc.UpsertSourceParams{
+		Name:         "managed-source",
+		CreationType: sqlc.CreationTypeCONFIG,
+		SourceType:   "managed",
+		Syncable:     false,
+	})
+	require.NoError(t, err)
+
+	t.Run("queues a pending request", func(t *testing.T) {
+		first, err := svc.RequestSourceSync(ctx, "git-source")
+		require.NoError(t, err)
+		assert.Equal(t, "git-source", first.SourceName)
+		assert.Equal(t, "pending", first.Status)
+		assert.NotEmpty(t, first.ID)
+
+		// A second request while the first is pending reuses it
+		second, err := svc.RequestSourceSync(ctx, "git-source")
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, second.ID)
+
+		got, err := svc.GetSourceSyncRequest(ctx, "git-source", first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, got.ID)
+		assert.Equal(t, "pending", got.Status)
+	})
+
+	t.Run("reports the outcome once finished", func(t *testing.T) {
+		req, err := svc.RequestSourceSync(ctx, "git-source")
+		require.NoError(t, err)
+
+		now := req.RequestedAt
+		require.NoError(t, queries.StartSyncRequests(ctx, sqlc.StartSyncRequestsParams{
+			Name:      "git-source",
+			StartedAt: &now,
+		}))
+		msg := "fetch failed"
+		require.NoError(t, queries.FinishSyncRequests(ctx, sqlc.FinishSyncRequestsParams{
+			Name:     "git-source",
+			Status:   sqlc.SyncRequestStatusFAILED,
+			ErrorMsg: &msg,
+			EndedAt:  &now,
+		}))
+
+		got, err := svc.GetSourceSyncRequest(ctx, "git-source", req.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "failed", got.Status)
+		assert.Equal(t, msg, got.Message)
+		require.NotNil(t, got.EndedAt)
+
+		// A new request can be queued after the previous one finished
+		next, err := svc.RequestSourceSync(ctx, "git-source")
+		require.NoError(t, err)
+		assert.NotEqual(t, req.ID, next.ID)
+	})
+
+	t.Run("non-syncable source", func(t *testing.T) {
+		_, err := svc.RequestSourceSync(ctx, "managed-source")
+		require.ErrorIs(t, err, service.ErrSourceNotSyncable)
+	})
+
+	t.Run("missing source", func(t *testing.T) {
+		_, err := svc.RequestSourceSync(ctx, "missing")
+		require.ErrorIs(t, err, service.ErrSourceNotFound)
+	})
+
+	t.Run("unknown request ID", func(t *testing.T) {
+		_, err := svc.GetSourceSyncRequest(ctx, "git-source", uuid.NewString())
+		require.ErrorIs(t, err, service.ErrSyncRequestNotFound)
+
+		_, err = svc.GetSourceSyncRequest(ctx, "git-source", "not-a-uuid")
+		require.ErrorIs(t, err, service.ErrSyncRequestNotFound)
+	})
+
+	t.Run("request ID of another source", func(t *testing.T) {
+		req, err := svc.RequestSourceSync(ctx, "git-source")
+		require.NoError(t, err)
+
+		_, err = svc.GetSourceSyncRequest(ctx, "managed-source", req.ID)
+		require.ErrorIs(t, err, service.ErrSyncRequestNotFound)
+	})
+}
+
+func TestListSourceSyncs(t *testing.T) {
+	t.Parallel()
+
+	svc, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	queries := sqlc.New(svc.pool)
+
+	_, err := queries.UpsertSource(ctx, sqlc.UpsertSourceParams{
+		Name:         "git-source",
+		CreationType: sqlc.CreationTypeCONFIG,
+		SourceType:   "git",
+		Syncable:     true,
+	})
+	require.NoError(t, err)
+
+	base := time.Now().UTC().Truncate(time.Second)
+	errMsg := "fetch failed"
+	for i := range 3 {
+		params := sqlc.InsertSyncHistoryParams{
+			Name:         "git-source",
+			SyncStatus:   sqlc.SyncStatusCOMPLETED,
+			Reason:       "source-data-changed",
+			ServerCount:  int64(i + 1),
+			ServersAdded: 1,
+			StartedAt:    base.Add(time.Duration(i) * time.Minute),
+			EndedAt:      base.Add(time.Duration(i)*time.Minute + time.Second),
+		}
+		if i == 2 {
+			params.SyncStatus = sqlc.SyncStatusFAILED
+			params.ErrorMsg = &errMsg
+		}
+		require.NoError(t, queries.InsertSyncHistory(ctx, params))
+	}
+
+	t.Run("paginates newest first", func(t *testing.T) {
+		first, err := svc.ListSourceSyncs(ctx, "git-source", service.WithLimit(2))
+		require.NoError(t, err)
+		require.Len(t, first.Syncs, 2)
+		require.NotEmpty(t, first.NextCursor)
+		assert.Equal(t, "failed", first.Syncs[0].Status)
+		assert.Equal(t, errMsg, first.Syncs[0].Message)
+		assert.Equal(t, "complete", first.Syncs[1].Status)
+		assert.Equal(t, 2, first.Syncs[1].ServerCount)
+		assert.Equal(t, 1, first.Syncs[1].Changes.Servers.Added)
+
+		second, err := svc.ListSourceSyncs(ctx, "git-source",
+			service.WithLimit(2), service.WithCursor(first.NextCursor))
+		require.NoError(t, err)
+		require.Len(t, second.Syncs, 1)
+		assert.Empty(t, second.NextCursor)
+		assert.Equal(t, 1, second.Syncs[0].ServerCount)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		_, err := svc.ListSourceSyncs(ctx, "git-source", service.WithCursor("not-a-cursor"))
+		require.ErrorIs(t, err, service.ErrInvalidCursor)
+	})
+
+	t.Run("missing source", func(t *testing.T) {
+		_, err := svc.ListSourceSyncs(ctx, "missing")
+		require.ErrorIs(t, err, service.ErrSourceNotFound)
+	})
+}
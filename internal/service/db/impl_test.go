@@ -213,8 +213,8 @@ func TestListServers(t *testing.T) {
 				require.Len(t, result.Servers, 4)
 				// Verify server structure
 				for _, server := range result.Servers {
-					require.NotEmpty(t, server.Name)
-					require.NotEmpty(t, server.Version)
+					require.NotEmpty(t, server.Server.Name)
+					require.NotEmpty(t, server.Server.Version)
 				}
 			},
 		},
@@ -296,8 +296,8 @@ func TestListServers(t *testing.T) {
 				require.Len(t, result.Servers, 4)
 				// Verify server structure
 				for _, server := range result.Servers {
-					require.NotEmpty(t, server.Name)
-					require.NotEmpty(t, server.Version)
+					require.NotEmpty(t, server.Server.Name)
+					require.NotEmpty(t, server.Server.Version)
 				}
 			},
 		},
@@ -327,7 +327,7 @@ func TestListServers(t *testing.T) {
 			validateFunc: func(t *testing.T, result *service.ListServersResult) {
 				require.Len(t, result.Servers, 3) // Should find all 3 versions of com.example/test-server-1
 				for _, server := range result.Servers {
-					require.Equal(t, "com.example/test-server-1", server.Name)
+					require.Equal(t, "com.example/test-server-1", server.Server.Name)
 				}
 			},
 		},
@@ -345,7 +345,7 @@ func TestListServers(t *testing.T) {
 			//nolint:thelper // We want to see these lines in the test output
 			validateFunc: func(t *testing.T, result *service.ListServersResult) {
 				require.Len(t, result.Servers, 1) // Should find only com.example/test-server-2
-				require.Equal(t, "com.example/test-server-2", result.Servers[0].Name)
+				require.Equal(t, "com.example/test-server-2", result.Servers[0].Server.Name)
 			},
 		},
 		{
@@ -362,7 +362,7 @@ func TestListServers(t *testing.T) {
 			//nolint:thelper // We want to see these lines in the test output
 			validateFunc: func(t *testing.T, result *service.ListServersResult) {
 				require.Len(t, result.Servers, 1) // Should find only com.example/test-server-2
-				require.Equal(t, "com.example/test-server-2", result.Servers[0].Name)
+				require.Equal(t, "com.example/test-server-2", result.Servers[0].Server.Name)
 			},
 		},
 		{
@@ -380,7 +380,7 @@ func TestListServers(t *testing.T) {
 			validateFunc: func(t *testing.T, result *service.ListServersResult) {
 				require.Len(t, result.Servers, 3) // Should still find com.example/test-server-1 versions
 				for _, server := range result.Servers {
-					require.Equal(t, "com.example/test-server-1", server.Name)
+					require.Equal(t, "com.example/test-server-1", server.Server.Name)
 				}
 			},
 		},
@@ -431,7 +431,7 @@ func TestListServers(t *testing.T) {
 			validateFunc: func(t *testing.T, result *service.ListServersResult) {
 				require.Len(t, result.Servers, 3) // Should find com.example/test-server-1 versions in test-registry
 				for _, server := range result.Servers {
-					require.Equal(t, "com.example/test-server-1", server.Name)
+					require.Equal(t, "com.example/test-server-1", server.Server.Name)
 				}
 			},
 		},
@@ -450,8 +450,8 @@ func TestListServers(t *testing.T) {
 			validateFunc: func(t *testing.T, result *service.ListServersResult) {
 				// Only com.example/test-server-1 v2.0.0 has a latest_entry_version record
 				require.Len(t, result.Servers, 1)
-				require.Equal(t, "com.example/test-server-1", result.Servers[0].Name)
-				require.Equal(t, "2.0.0", result.Servers[0].Version)
+				require.Equal(t, "com.example/test-server-1", result.Servers[0].Server.Name)
+				require.Equal(t, "2.0.0", result.Servers[0].Server.Version)
 			},
 		},
 	}
@@ -486,7 +486,7 @@ func TestListServerVersions(t *testing.T) {
 		name         string
 		setupFunc    func(*testing.T, *pgxpool.Pool)
 		options      []service.Option
-		validateFunc func(*testing.T, []*upstreamv0.ServerJSON)
+		validateFunc func(*testing.T, []*upstreamv0.ServerResponse)
 	}{
 		{
 			name: "list versions for existing server",
@@ -500,16 +500,16 @@ func TestListServerVersions(t *testing.T) {
 				service.WithLimit(10),
 			},
 			//nolint:thelper // We want to see these lines in the test output
-			validateFunc: func(t *testing.T, servers []*upstreamv0.ServerJSON) {
+			validateFunc: func(t *testing.T, servers []*upstreamv0.ServerResponse) {
 				require.Len(t, servers, 3)
 				// Verify all are the same server name
 				for _, server := range servers {
-					require.Equal(t, "com.example/test-server-1", server.Name)
+					require.Equal(t, "com.example/test-server-1", server.Server.Name)
 				}
 				// Verify versions are present
 				versions := make([]string, len(servers))
 				for i, s := range servers {
-					versions[i] = s.Version
+					versions[i] = s.Server.Version
 				}
 				require.Contains(t, versions, "1.0.0")
 				require.Contains(t, versions, "1.1.0")
@@ -528,7 +528,7 @@ func TestListServerVersions(t *testing.T) {
 				service.WithLimit(2),
 			},
 			//nolint:thelper // We want to see these lines in the test output
-			validateFunc: func(t *testing.T, servers []*upstreamv0.ServerJSON) {
+			validateFunc: func(t *testing.T, servers []*upstreamv0.ServerResponse) {
 				require.Len(t, servers, 2)
 			},
 		},
@@ -544,7 +544,7 @@ func TestListServerVersions(t *testing.T) {
 				service.WithLimit(10),
 			},
 			//nolint:thelper // We want to see these lines in the test output
-			validateFunc: func(t *testing.T, servers []*upstreamv0.ServerJSON) {
+			validateFunc: func(t *testing.T, servers []*upstreamv0.ServerResponse) {
 				require.Len(t, servers, 0)
 			},
 		},
@@ -571,16 +571,16 @@ func TestListServerVersions(t *testing.T) {
 				service.WithLimit(10),
 			},
 			//nolint:thelper // We want to see these lines in the test output
-			validateFunc: func(t *testing.T, servers []*upstreamv0.ServerJSON) {
+			validateFunc: func(t *testing.T, servers []*upstreamv0.ServerResponse) {
 				require.Len(t, servers, 3)
 				// Verify all are the same server name
 				for _, server := range servers {
-					require.Equal(t, "com.example/test-server-1", server.Name)
+					require.Equal(t, "com.example/test-server-1", server.Server.Name)
 				}
 				// Verify versions are present
 				versions := make([]string, len(servers))
 				for i, s := range servers {
-					versions[i] = s.Version
+					versions[i] = s.Server.Version
 				}
 				require.Contains(t, versions, "1.0.0")
 				require.Contains(t, versions, "1.1.0")
@@ -631,7 +631,7 @@ func TestGetServerVersion(t *testing.T) {
 		name         string
 		setupFunc    func(*testing.T, *pgxpool.Pool)
 		options      []service.Option
-		validateFunc func(*testing.T, *upstreamv0.ServerJSON)
+		validateFunc func(*testing.T, *upstreamv0.ServerResponse)
 	}{
 		{
 			name: "get existing server version",
@@ -645,14 +645,14 @@ func TestGetServerVersion(t *testing.T) {
 				service.WithVersion("1.0.0"),
 			},
 			//nolint:thelper // We want to see these lines in the test output
-			validateFunc: func(t *testing.T, server *upstreamv0.ServerJSON) {
+			validateFunc: func(t *testing.T, server *upstreamv0.ServerResponse) {
 				require.NotNil(t, server)
-				require.Equal(t, "com.example/test-server-1", server.Name)
-				require.Equal(t, "1.0.0", server.Version)
-				require.Equal(t, "Test server 1 description", server.Description)
-				require.Equal(t, "Test Server 1", server.Title)
-				require.NotNil(t, server.Repository)
-				require.Equal(t, "https://github.com/test/server1", server.Repository.URL)
+				require.Equal(t, "com.example/test-server-1", server.Server.Name)
+				require.Equal(t, "1.0.0", server.Server.Version)
+				require.Equal(t, "Test server 1 description", server.Server.Description)
+				require.Equal(t, "Test Server 1", server.Server.Title)
+				require.NotNil(t, server.Server.Repository)
+				require.Equal(t, "https://github.com/test/server1", server.Server.Repository.URL)
 			},
 		},
 		{
@@ -667,10 +667,10 @@ func TestGetServerVersion(t *testing.T) {
 				service.WithVersion("2.0.0"),
 			},
 			//nolint:thelper // We want to see these lines in the test output
-			validateFunc: func(t *testing.T, server *upstreamv0.ServerJSON) {
+			validateFunc: func(t *testing.T, server *upstreamv0.ServerResponse) {
 				require.NotNil(t, server)
-				require.Equal(t, "com.example/test-server-1", server.Name)
-				require.Equal(t, "2.0.0", server.Version)
+				require.Equal(t, "com.example/test-server-1", server.Server.Name)
+				require.Equal(t, "2.0.0", server.Server.Version)
 			},
 		},
 		{
@@ -729,11 +729,11 @@ func TestGetServerVersion(t *testing.T) {
 				service.WithVersion("1.0.0"),
 			},
 			//nolint:thelper // We want to see these lines in the test output
-			validateFunc: func(t *testing.T, server *upstreamv0.ServerJSON) {
+			validateFunc: func(t *testing.T, server *upstreamv0.ServerResponse) {
 				require.NotNil(t, server)
-				require.Equal(t, "com.example/test-server-2", server.Name)
-				require.Equal(t, "1.0.0", server.Version)
-				require.Equal(t, "Test server 2 description", server.Description)
+				require.Equal(t, "com.example/test-server-2", server.Server.Name)
+				require.Equal(t, "1.0.0", server.Server.Version)
+				require.Equal(t, "Test server 2 description", server.Server.Description)
 			},
 		},
 		{
@@ -854,29 +854,29 @@ func TestGetServerVersion(t *testing.T) {
 				service.WithVersion("1.0.0"),
 			},
 			//nolint:thelper // We want to see these lines in the test output
-			validateFunc: func(t *testing.T, server *upstreamv0.ServerJSON) {
+			validateFunc: func(t *testing.T, server *upstreamv0.ServerResponse) {
 				require.NotNil(t, server)
-				require.Equal(t, "com.test/server-with-packages", server.Name)
-				require.Equal(t, "1.0.0", server.Version)
-				require.Equal(t, "Test server with packages and remotes", server.Description)
-				require.Equal(t, "Test Server With Packages", server.Title)
-				require.NotNil(t, server.Repository)
-				require.Equal(t, "https://github.com/test/server-with-packages", server.Repository.URL)
+				require.Equal(t, "com.test/server-with-packages", server.Server.Name)
+				require.Equal(t, "1.0.0", server.Server.Version)
+				require.Equal(t, "Test server with packages and remotes", server.Server.Description)
+				require.Equal(t, "Test Server With Packages", server.Server.Title)
+				require.NotNil(t, server.Server.Repository)
+				require.Equal(t, "https://github.com/test/server-with-packages", server.Server.Repository.URL)
 
 				// Validate packages
-				require.Len(t, server.Packages, 1)
-				require.Equal(t, "npm", server.Packages[0].RegistryType)
-				require.Equal(t, "https://registry.npmjs.org", server.Packages[0].RegistryBaseURL)
-				require.Equal(t, "@test/package", server.Packages[0].Identifier)
-				require.Equal(t, "1.0.0", server.Packages[0].Version)
-				require.Equal(t, "abc123def456", server.Packages[0].FileSHA256)
-				require.Equal(t, "npx", server.Packages[0].RunTimeHint)
-				require.Equal(t, "stdio", server.Packages[0].Transport.Type)
+				require.Len(t, server.Server.Packages, 1)
+				require.Equal(t, "npm", server.Server.Packages[0].RegistryType)
+				require.Equal(t, "https://registry.npmjs.org", server.Server.Packages[0].RegistryBaseURL)
+				require.Equal(t, "@test/package", server.Server.Packages[0].Identifier)
+				require.Equal(t, "1.0.0", server.Server.Packages[0].Version)
+				require.Equal(t, "abc123def456", server.Server.Packages[0].FileSHA256)
+				require.Equal(t, "npx", server.Server.Packages[0].RunTimeHint)
+				require.Equal(t, "stdio", server.Server.Packages[0].Transport.Type)
 
 				// Validate remotes
-				require.Len(t, server.Remotes, 1)
-				require.Equal(t, "sse", server.Remotes[0].Type)
-				require.Equal(t, "https://example.com/sse", server.Remotes[0].URL)
+				require.Len(t, server.Server.Remotes, 1)
+				require.Equal(t, "sse", server.Server.Remotes[0].Type)
+				require.Equal(t, "https://example.com/sse", server.Server.Remotes[0].URL)
 			},
 		},
 		{
@@ -891,12 +891,12 @@ func TestGetServerVersion(t *testing.T) {
 				service.WithRegistryName("test-registry"),
 			},
 			//nolint:thelper // We want to see these lines in the test output
-			validateFunc: func(t *testing.T, server *upstreamv0.ServerJSON) {
+			validateFunc: func(t *testing.T, server *upstreamv0.ServerResponse) {
 				require.NotNil(t, server)
-				require.Equal(t, "com.example/test-server-1", server.Name)
-				require.Equal(t, "1.0.0", server.Version)
-				require.Equal(t, "Test server 1 description", server.Description)
-				require.Equal(t, "Test Server 1", server.Title)
+				require.Equal(t, "com.example/test-server-1", server.Server.Name)
+				require.Equal(t, "1.0.0", server.Server.Version)
+				require.Equal(t, "Test server 1 description", server.Server.Description)
+				require.Equal(t, "Test Server 1", server.Server.Title)
 			},
 		},
 		{
@@ -2924,7 +2924,190 @@ func TestDeleteServerVersion(t *testing.T) {
 
 			require.NoError(t, err)
 			require.NotNil(t, result)
-			require.Equal(t, tt.expectLatestVersion, result.Version)
+			require.Equal(t, tt.expectLatestVersion, result.Server.Version)
+		})
+	}
+}
+
+func TestUpdateServerVersionStatus(t *testing.T) {
+	t.Parallel()
+
+	const serverName = "com.example/test-server"
+
+	type statusUpdate struct {
+		version    string
+		status     string
+		reason     string
+		replacedBy string
+	}
+
+	tests := []struct {
+		name            string
+		registryName    string
+		publishVersions []string
+		// status updates applied in order; only the last one may fail
+		updates []statusUpdate
+		// expected error from the last update (wrapped with ErrorIs)
+		expectErrIs error
+		// if non-empty, the version we expect "latest" to resolve to after the updates
+		expectLatestVersion string
+		// if true, GET latest should return ErrNotFound after the updates
+		expectLatestNotFound bool
+		// versions expected from ListServers
+		expectListed []string
+		// registry status and message expected on the last updated version
+		expectStatus  model.Status
+		expectMessage string
+	}{
+		{
+			name:            "deprecating the latest version re-points latest",
+			registryName:    "status-srv-deprecate",
+			publishVersions: []string{"1.0.0", "1.1.0", "2.0.0"},
+			updates: []statusUpdate{
+				{version: "2.0.0", status: service.ServerStatusDeprecated, reason: "CVE-2026-0001", replacedBy: "1.1.0"},
+			},
+			expectLatestVersion: "1.1.0",
+			expectListed:        []string{"1.0.0", "1.1.0"},
+			expectStatus:        model.StatusDeprecated,
+			expectMessage:       "CVE-2026-0001 (replaced by version 1.1.0)",
+		},
+		{
+			name:            "yanking every version removes latest",
+			registryName:    "status-srv-yank",
+			publishVersions: []string{"1.0.0"},
+			updates: []statusUpdate{
+				{version: "1.0.0", status: service.ServerStatusYanked},
+			},
+			expectLatestNotFound: true,
+			expectListed:         []string{},
+			expectStatus:         model.StatusDeleted,
+		},
+		{
+			name:            "reactivating a version restores latest",
+			registryName:    "status-srv-reactivate",
+			publishVersions: []string{"1.0.0", "2.0.0"},
+			updates: []statusUpdate{
+				{version: "2.0.0", status: service.ServerStatusYanked, reason: "broken"},
+				{version: "2.0.0", status: service.ServerStatusActive, reason: "ignored"},
+			},
+			expectLatestVersion: "2.0.0",
+			expectListed:        []string{"1.0.0", "2.0.0"},
+			expectStatus:        model.StatusActive,
+		},
+		{
+			name:            "non-existent version returns error",
+			registryName:    "status-srv-noexist",
+			publishVersions: []string{"1.0.0"},
+			updates: []statusUpdate{
+				{version: "9.9.9", status: service.ServerStatusDeprecated},
+			},
+			expectErrIs: service.ErrNotFound,
+		},
+		{
+			name:            "non-existent replacement returns error",
+			registryName:    "status-srv-noreplacement",
+			publishVersions: []string{"1.0.0"},
+			updates: []statusUpdate{
+				{version: "1.0.0", status: service.ServerStatusDeprecated, replacedBy: "9.9.9"},
+			},
+			expectErrIs: service.ErrInvalidServerStatus,
+		},
+		{
+			name:            "unknown status returns error",
+			registryName:    "status-srv-badstatus",
+			publishVersions: []string{"1.0.0"},
+			updates: []statusUpdate{
+				{version: "1.0.0", status: "archived"},
+			},
+			expectErrIs: service.ErrInvalidServerStatus,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc, cleanup := setupTestService(t)
+			t.Cleanup(cleanup)
+
+			ctx := context.Background()
+			createManagedSourceWithRegistry(t, svc, tt.registryName)
+
+			for _, ver := range tt.publishVersions {
+				_, err := svc.PublishServerVersion(
+					ctx,
+					service.WithServerData(&upstreamv0.ServerJSON{
+						Name:    serverName,
+						Version: ver,
+					}),
+				)
+				require.NoError(t, err)
+			}
+
+			var updateErr error
+			for _, update := range tt.updates {
+				updateErr = svc.UpdateServerVersionStatus(
+					ctx,
+					service.WithName(serverName),
+					service.WithVersion(update.version),
+					service.WithServerStatus(update.status),
+					service.WithStatusReason(update.reason),
+					service.WithReplacedBy(update.replacedBy),
+				)
+			}
+			if tt.expectErrIs != nil {
+				require.ErrorIs(t, updateErr, tt.expectErrIs)
+				return
+			}
+			require.NoError(t, updateErr)
+
+			latest, err := svc.GetServerVersion(
+				ctx,
+				service.WithRegistryName(tt.registryName),
+				service.WithName(serverName),
+				service.WithVersion("latest"),
+			)
+			if tt.expectLatestNotFound {
+				require.ErrorIs(t, err, service.ErrNotFound)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectLatestVersion, latest.Server.Version)
+				require.True(t, latest.Meta.Official.IsLatest)
+			}
+
+			listed, err := svc.ListServers(ctx, service.WithRegistryName(tt.registryName))
+			require.NoError(t, err)
+			listedVersions := make([]string, 0, len(listed.Servers))
+			for _, server := range listed.Servers {
+				listedVersions = append(listedVersions, server.Server.Version)
+			}
+			require.Equal(t, tt.expectListed, listedVersions)
+
+			// Every version stays resolvable by its exact version and listed among the versions
+			versions, err := svc.ListServerVersions(
+				ctx,
+				service.WithRegistryName(tt.registryName),
+				service.WithName(serverName),
+			)
+			require.NoError(t, err)
+			require.Len(t, versions, len(tt.publishVersions))
+
+			last := tt.updates[len(tt.updates)-1]
+			exact, err := svc.GetServerVersion(
+				ctx,
+				service.WithRegistryName(tt.registryName),
+				service.WithName(serverName),
+				service.WithVersion(last.version),
+			)
+			require.NoError(t, err)
+			require.NotNil(t, exact.Meta.Official)
+			require.Equal(t, tt.expectStatus, exact.Meta.Official.Status)
+			if tt.expectMessage == "" {
+				require.Nil(t, exact.Meta.Official.StatusMessage)
+			} else {
+				require.NotNil(t, exact.Meta.Official.StatusMessage)
+				require.Equal(t, tt.expectMessage, *exact.Meta.Official.StatusMessage)
+			}
 		})
 	}
 }
@@ -0,0 +1,384 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/google/uuid"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/versions"
+)
+
+// mergeFieldEntry is the provenance key of the fields of an entry version that
+// are not overlaid: the version itself, its packages, remotes and the rest.
+const mergeFieldEntry = "entry"
+
+// serializeMerge serializes the merge strategies of a registry to JSON bytes for storage.
+// Returns nil when no strategy is set, meaning first-wins for every field.
+func serializeMerge(merge *config.MergeConfig) []byte {
+	if merge == nil || *merge == (config.MergeConfig{}) {
+		return nil
+	}
+	data, err := json.Marshal(merge)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// deserializeMerge deserializes the merge strategies of a registry.
+// Returns nil for empty data or on unmarshal error.
+func deserializeMerge(data []byte) *config.MergeConfig {
+	if len(data) == 0 {
+		return nil
+	}
+	var merge config.MergeConfig
+	if err := json.Unmarshal(data, &merge); err != nil {
+		return nil
+	}
+	return &merge
+}
+
+// mergePolicy selects the rows of the sources of a registry that the registry
+// serves, according to its merge strategies. A nil policy is first-wins for every field.
+type mergePolicy struct {
+	registryID uuid.UUID
+	merge      *config.MergeConfig
+
+	// winners maps each entry to the position of the source providing its
+	// highest version. It is only set for the highest-version strategy.
+	winners map[string]int32
+}
+
+// mergeRecord identifies a row provided by a source of a registry
+type mergeRecord struct {
+	entryType sqlc.EntryType
+	name      string
+	version   string
+	position  int32
+}
+
+// loadMergePolicy returns the merge policy of a registry. For the highest-version
+// strategy, it selects the winning source of the entries of the given type (of every
+// type when empty), or of the single entry when name is set.
+func loadMergePolicy(
+	ctx context.Context,
+	querier sqlc.Querier,
+	registry sqlc.Registry,
+	entryType sqlc.EntryType,
+	name *string,
+) (*mergePolicy, error) {
+	policy := &mergePolicy{
+		registryID: registry.ID,
+		merge:      deserializeMerge(registry.Merge),
+	}
+	if policy.merge.GetVersions() != config.MergeHighestVersion {
+		return policy, nil
+	}
+
+	rows, err := querier.ListRegistryEntryVersions(ctx, sqlc.ListRegistryEntryVersionsParams{
+		RegistryID: registry.ID,
+		EntryType:  sqlc.NullEntryType{EntryType: entryType, Valid: entryType != ""},
+		Name:       name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list registry entry versions: %w", err)
+	}
+	policy.winners = highestVersionWinners(rows)
+	return policy, nil
+}
+
+// highestVersionWinners maps each entry to the position of the source providing
+// its highest version. Rows must be in position-ascending order, so that the first
+// source in the list wins when several provide the same highest version.
+func highestVersionWinners(rows []sqlc.ListRegistryEntryVersionsRow) map[string]int32 {
+	winners := make(map[string]int32)
+	highest := make(map[string]string)
+	for _, row := range rows {
+		key := mergeKey(row.EntryType, row.Name)
+		best, exists := highest[key]
+		if !exists || versions.IsNewerVersion(row.Version, best) {
+			highest[key] = row.Version
+			winners[key] = row.Position
+		}
+	}
+	return winners
+}
+
+// mergeKey returns the key identifying an entry across the sources of a registry
+func mergeKey(entryType sqlc.EntryType, name string) string {
+	return string(entryType) + "/" + name
+}
+
+// versions returns the strategy selecting the versions served for an entry
+func (p *mergePolicy) versions() config.MergeStrategy {
+	if p == nil {
+		return config.MergeFirstWins
+	}
+	return p.merge.GetVersions()
+}
+
+// overriddenFields returns the fields a later source may override
+func (p *mergePolicy) overriddenFields() []string {
+	if p == nil {
+		return nil
+	}
+	return p.merge.Overlays()
+}
+
+// newFilter returns a stateful RecordFilter keeping the rows the registry serves:
+//   - first-wins keeps the rows of the first source seen for an entry
+//   - union keeps the first row seen for each version of an entry
+//   - highest-version keeps the rows of the source providing the highest version
+//     of an entry, falling back to first-wins for entries without a winner
+//
+// SQL must return rows in position-ascending order per name and version.
+// extract retrieves the entry of a record; returning ok=false causes the filter
+// to reject the record with a type error.
+func (p *mergePolicy) newFilter(extract func(record any) (mergeRecord, bool)) service.RecordFilter {
+	strategy := p.versions()
+	dedupFilter := newDeduplicatingFilterWith(
+		func(record any) (string, int32, bool) {
+			r, ok := extract(record)
+			key := mergeKey(r.entryType, r.name)
+			if strategy == config.MergeUnion {
+				key += "@" + r.version
+			}
+			return key, r.position, ok
+		},
+	)
+	if strategy != config.MergeHighestVersion {
+		return dedupFilter
+	}
+
+	return func(ctx context.Context, record any) (bool, error) {
+		r, ok := extract(record)
+		if !ok {
+			return false, fmt.Errorf("unexpected record type: %T", record)
+		}
+		if winPos, found := p.winners[mergeKey(r.entryType, r.name)]; found {
+			return r.position == winPos, nil
+		}
+		return dedupFilter(ctx, record)
+	}
+}
+
+// fieldOverride holds the fields of a served server version overridden by later sources
+type fieldOverride struct {
+	description *string
+	meta        []byte
+	icons       []sqlc.McpServerIcon
+}
+
+// fetchServerFieldOverrides returns the fields of the served server versions that
+// later sources of the registry override, keyed by the ID of the served version. For
+// each overridden field, the last source providing the same version with a value for
+// the field wins. Versions of sources the caller cannot see are skipped by filter.
+func fetchServerFieldOverrides(
+	ctx context.Context,
+	querier *sqlc.Queries,
+	policy *mergePolicy,
+	servers []helper,
+	filter service.RecordFilter,
+) (map[uuid.UUID]*fieldOverride, error) {
+	fields := policy.overriddenFields()
+	if len(fields) == 0 || len(servers) == 0 {
+		return nil, nil
+	}
+
+	served := make(map[string]helper, len(servers))
+	seenNames := make(map[string]struct{}, len(servers))
+	names := make([]string, 0, len(servers))
+	for _, server := range servers {
+		served[server.Name+"@"+server.Version] = server
+		if _, exists := seenNames[server.Name]; !exists {
+			seenNames[server.Name] = struct{}{}
+			names = append(names, server.Name)
+		}
+	}
+
+	rows, err := querier.ListServerFieldOverrides(ctx, sqlc.ListServerFieldOverridesParams{
+		RegistryID: policy.registryID,
+		Names:      names,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list server field overrides: %w", err)
+	}
+
+	overrides := make(map[uuid.UUID]*fieldOverride)
+	iconCandidates := make(map[uuid.UUID][]uuid.UUID)
+	for _, row := range rows {
+		server, ok := served[row.Name+"@"+row.Version]
+		if !ok || row.Position <= server.Position {
+			continue
+		}
+		if filter != nil {
+			keep, err := filter(ctx, helper{Name: row.Name, Version: row.Version, Claims: row.Claims})
+			if err != nil {
+				return nil, err
+			}
+			if !keep {
+				continue
+			}
+		}
+
+		override := overrides[server.ID]
+		if override == nil {
+			override = &fieldOverride{}
+			overrides[server.ID] = override
+		}
+		for _, field := range fields {
+			switch field {
+			case config.MergeFieldDescription:
+				if ptr.ToString(row.Description) != "" {
+					override.description = row.Description
+				}
+			case config.MergeFieldMeta:
+				if len(row.ServerMeta) > 0 {
+					override.meta = row.ServerMeta
+				}
+			case config.MergeFieldIcons:
+				iconCandidates[server.ID] = append(iconCandidates[server.ID], row.ID)
+			}
+		}
+	}
+
+	if err := selectOverrideIcons(ctx, querier, overrides, iconCandidates); err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+// selectOverrideIcons sets the icons of each override to those of the last candidate
+// version having icons. Candidates are the versions of later sources, in position order.
+func selectOverrideIcons(
+	ctx context.Context,
+	querier *sqlc.Queries,
+	overrides map[uuid.UUID]*fieldOverride,
+	candidates map[uuid.UUID][]uuid.UUID,
+) error {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	var ids []uuid.UUID
+	for _, versionIDs := range candidates {
+		ids = append(ids, versionIDs...)
+	}
+	icons, err := querier.ListServerIcons(ctx, ids)
+	if err != nil {
+		return err
+	}
+	iconsMap := make(map[uuid.UUID][]sqlc.McpServerIcon)
+	for _, icon := range icons {
+		iconsMap[icon.ServerID] = append(iconsMap[icon.ServerID], icon)
+	}
+
+	for servedID, versionIDs := range candidates {
+		for _, versionID := range versionIDs {
+			if len(iconsMap[versionID]) > 0 {
+				overrides[servedID].icons = iconsMap[versionID]
+			}
+		}
+	}
+	return nil
+}
+
+// applyServerFieldOverride overrides the fields of a served server version with those of later sources
+func applyServerFieldOverride(server helper, icons []sqlc.McpServerIcon, override *fieldOverride) (helper, []sqlc.McpServerIcon) {
+	if override == nil {
+		return server, icons
+	}
+	if override.description != nil {
+		server.Description = override.description
+	}
+	if override.meta != nil {
+		server.ServerMeta = override.meta
+	}
+	if override.icons != nil {
+		icons = override.icons
+	}
+	return server, icons
+}
+
+// mergeRegistryEntries sets the provenance of the entry versions a registry serves,
+// mapping each field to the source contributing it under the merge strategies of the
+// registry. Rows must be in the (name, version, position) order of the read paths.
+// Claims are not considered: the provenance is that of a caller seeing every source.
+func mergeRegistryEntries(rows []sqlc.ListEntriesByRegistryRow, policy *mergePolicy) []service.RegistryEntryInfo {
+	filter := policy.newFilter(func(record any) (mergeRecord, bool) {
+		r, ok := record.(sqlc.ListEntriesByRegistryRow)
+		return mergeRecord{entryType: r.EntryType, name: r.Name, version: r.Version, position: r.Position}, ok
+	})
+
+	result := make([]service.RegistryEntryInfo, 0, len(rows))
+	servedIndex := make(map[string]int) // type/name@version → index of the served row in result
+	for _, row := range rows {
+		info := registryEntryRowToInfo(row)
+		versionKey := mergeKey(row.EntryType, row.Name) + "@" + row.Version
+
+		// The filter only fails on records of another type
+		if keep, _ := filter(context.Background(), row); keep {
+			info.FieldSources = map[string]string{mergeFieldEntry: row.SourceName}
+			for _, field := range policy.overriddenFields() {
+				info.FieldSources[field] = row.SourceName
+			}
+			servedIndex[versionKey] = len(result)
+		} else if i, exists := servedIndex[versionKey]; exists && row.EntryType == sqlc.EntryTypeMCP {
+			// A later source providing the served version overrides the fields it has a value for
+			for _, field := range policy.overriddenFields() {
+				if registryEntryRowHasField(row, field) {
+					result[i].FieldSources[field] = row.SourceName
+				}
+			}
+		}
+		result = append(result, info)
+	}
+	return result
+}
+
+// registryEntryRowHasField reports whether a server version has a value for an overlaid field
+func registryEntryRowHasField(row sqlc.ListEntriesByRegistryRow, field string) bool {
+	switch field {
+	case config.MergeFieldDescription:
+		return ptr.ToString(row.Description) != ""
+	case config.MergeFieldMeta:
+		return row.HasMeta
+	case config.MergeFieldIcons:
+		return row.HasIcons
+	default:
+		return false
+	}
+}
+
+// registryEntryRowToInfo converts a row of the entries of a registry to a RegistryEntryInfo
+func registryEntryRowToInfo(row sqlc.ListEntriesByRegistryRow) service.RegistryEntryInfo {
+	info := service.RegistryEntryInfo{
+		EntryType:  string(row.EntryType),
+		Name:       row.Name,
+		Version:    row.Version,
+		SourceName: row.SourceName,
+		Position:   row.Position,
+	}
+	if row.Title != nil {
+		info.Title = *row.Title
+	}
+	if row.Description != nil {
+		info.Description = *row.Description
+	}
+	if row.CreatedAt != nil {
+		info.CreatedAt = *row.CreatedAt
+	}
+	if row.UpdatedAt != nil {
+		info.UpdatedAt = *row.UpdatedAt
+	}
+	if row.ScanVerdict != nil && row.ScannedAt != nil {
+		info.Scan = entryScanResult(*row.ScanVerdict, row.ScanFindings, *row.ScannedAt)
+	}
+	return info
+}
@@ -0,0 +1,168 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+)
+
+func TestSerializeMerge(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, serializeMerge(nil))
+	assert.Nil(t, serializeMerge(&config.MergeConfig{}))
+	assert.Nil(t, deserializeMerge(nil))
+	assert.Nil(t, deserializeMerge([]byte("not-json")))
+
+	merge := &config.MergeConfig{Versions: config.MergeUnion, Icons: config.MergeOverlay}
+	data := serializeMerge(merge)
+	require.NotNil(t, data)
+	assert.Equal(t, merge, deserializeMerge(data))
+}
+
+func TestHighestVersionWinners(t *testing.T) {
+	t.Parallel()
+
+	rows := []sqlc.ListRegistryEntryVersionsRow{
+		{EntryType: sqlc.EntryTypeMCP, Name: "server-a", Version: "1.0.0", Position: 0},
+		{EntryType: sqlc.EntryTypeMCP, Name: "server-b", Version: "2.0.0", Position: 0},
+		{EntryType: sqlc.EntryTypeMCP, Name: "server-a", Version: "1.2.0", Position: 1},
+		{EntryType: sqlc.EntryTypeMCP, Name: "server-b", Version: "2.0.0", Position: 1},
+		{EntryType: sqlc.EntryTypeSKILL, Name: "server-a", Version: "0.1.0", Position: 2},
+	}
+
+	assert.Equal(t, map[string]int32{
+		"MCP/server-a":   1,
+		"MCP/server-b":   0, // ties go to the first source
+		"SKILL/server-a": 2,
+	}, highestVersionWinners(rows))
+}
+
+func TestMergePolicyNewFilter(t *testing.T) {
+	t.Parallel()
+
+	// Rows in the (name, version, position) order of the read paths
+	rows := []helper{
+		{Name: "server-a", Version: "1.0.0", Position: 0},
+		{Name: "server-a", Version: "1.0.0", Position: 1},
+		{Name: "server-a", Version: "1.2.0", Position: 1},
+		{Name: "server-b", Version: "1.0.0", Position: 1},
+	}
+
+	tests := []struct {
+		name   string
+		policy *mergePolicy
+		expect []helper
+	}{
+		{
+			name:   "nil policy is first-wins",
+			policy: nil,
+			expect: []helper{rows[0], rows[3]},
+		},
+		{
+			name:   "first-wins keeps the versions of the first source",
+			policy: &mergePolicy{merge: &config.MergeConfig{Versions: config.MergeFirstWins}},
+			expect: []helper{rows[0], rows[3]},
+		},
+		{
+			name:   "union keeps the first source of each version",
+			policy: &mergePolicy{merge: &config.MergeConfig{Versions: config.MergeUnion}},
+			expect: []helper{rows[0], rows[2], rows[3]},
+		},
+		{
+			name: "highest-version keeps the versions of the winning source",
+			policy: &mergePolicy{
+				merge:   &config.MergeConfig{Versions: config.MergeHighestVersion},
+				winners: map[string]int32{"MCP/server-a": 1},
+			},
+			expect: []helper{rows[1], rows[2], rows[3]},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			filter := newDeduplicatingFilter(tt.policy)
+			var got []helper
+			for _, row := range rows {
+				keep, err := filter(t.Context(), row)
+				require.NoError(t, err)
+				if keep {
+					got = append(got, row)
+				}
+			}
+			assert.Equal(t, tt.expect, got)
+		})
+	}
+}
+
+func TestApplyServerFieldOverride(t *testing.T) {
+	t.Parallel()
+
+	server := helper{ID: uuid.New(), Description: ptr.String("base"), ServerMeta: []byte(`{"a":1}`)}
+	icons := []sqlc.McpServerIcon{{SourceUri: "https://example.com/base.png"}}
+
+	got, gotIcons := applyServerFieldOverride(server, icons, nil)
+	assert.Equal(t, server, got)
+	assert.Equal(t, icons, gotIcons)
+
+	override := &fieldOverride{
+		meta:  []byte(`{"b":2}`),
+		icons: []sqlc.McpServerIcon{{SourceUri: "https://example.com/override.png"}},
+	}
+	got, gotIcons = applyServerFieldOverride(server, icons, override)
+	assert.Equal(t, "base", *got.Description)
+	assert.Equal(t, []byte(`{"b":2}`), got.ServerMeta)
+	assert.Equal(t, override.icons, gotIcons)
+}
+
+func TestMergeRegistryEntries(t *testing.T) {
+	t.Parallel()
+
+	rows := []sqlc.ListEntriesByRegistryRow{
+		{EntryType: sqlc.EntryTypeMCP, Name: "server-a", Version: "1.0.0", SourceName: "upstream", Position: 0},
+		{
+			EntryType: sqlc.EntryTypeMCP, Name: "server-a", Version: "1.0.0", SourceName: "curated", Position: 1,
+			Description: ptr.String("curated"), HasIcons: true,
+		},
+		{EntryType: sqlc.EntryTypeMCP, Name: "server-a", Version: "1.1.0", SourceName: "curated", Position: 1},
+	}
+
+	t.Run("first-wins", func(t *testing.T) {
+		t.Parallel()
+
+		got := mergeRegistryEntries(rows, nil)
+		require.Len(t, got, 3)
+		assert.Equal(t, map[string]string{mergeFieldEntry: "upstream"}, got[0].FieldSources)
+		assert.Nil(t, got[1].FieldSources)
+		assert.Nil(t, got[2].FieldSources)
+	})
+
+	t.Run("union with overlays", func(t *testing.T) {
+		t.Parallel()
+
+		policy := &mergePolicy{merge: &config.MergeConfig{
+			Versions:    config.MergeUnion,
+			Meta:        config.MergeOverlay,
+			Icons:       config.MergeOverlay,
+			Description: config.MergeOverlay,
+		}}
+		got := mergeRegistryEntries(rows, policy)
+		require.Len(t, got, 3)
+		assert.Equal(t, map[string]string{
+			mergeFieldEntry:              "upstream",
+			config.MergeFieldMeta:        "upstream",
+			config.MergeFieldIcons:       "curated",
+			config.MergeFieldDescription: "curated",
+		}, got[0].FieldSources)
+		assert.Nil(t, got[1].FieldSources)
+		assert.Equal(t, "curated", got[2].FieldSources[mergeFieldEntry])
+	})
+}
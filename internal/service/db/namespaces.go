@@ -0,0 +1,244 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/stacklok/toolhive-registry-server/internal/db"
+	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/otel"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/validators"
+)
+
+// ListNamespaces returns all reserved namespaces, ordered by name.
+func (s *dbService) ListNamespaces(ctx context.Context) ([]service.NamespaceInfo, error) {
+	ctx, span := s.startSpan(ctx, "dbService.ListNamespaces")
+	defer span.End()
+
+	rows, err := sqlc.New(s.pool).ListNamespaces(ctx)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	namespaces := make([]service.NamespaceInfo, len(rows))
+	for i := range rows {
+		namespaces[i] = *namespaceToInfo(&rows[i])
+	}
+	return namespaces, nil
+}
+
+// GetNamespace returns a reserved namespace by name.
+func (s *dbService) GetNamespace(ctx context.Context, name string) (*service.NamespaceInfo, error) {
+	ctx, span := s.startSpan(ctx, "dbService.GetNamespace")
+	defer span.End()
+	span.SetAttributes(attribute.String("namespace.name", name))
+
+	row, err := sqlc.New(s.pool).GetNamespace(ctx, name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = fmt.Errorf("%w: %s", service.ErrNamespaceNotFound, name)
+		} else {
+			err = fmt.Errorf("failed to get namespace: %w", err)
+		}
+		otel.RecordError(span, err)
+		return nil, err
+	}
+	return namespaceToInfo(&row), nil
+}
+
+// CreateNamespace reserves a namespace for the callers covering its claims.
+func (s *dbService) CreateNamespace(
+	ctx context.Context, name string, req *service.NamespaceRequest,
+) (*service.NamespaceInfo, error) {
+	ctx, span := s.startSpan(ctx, "dbService.CreateNamespace")
+	defer span.End()
+	span.SetAttributes(attribute.String("namespace.name", name))
+
+	if err := validateNamespaceRequest(name, req); err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+
+	querier := sqlc.New(s.pool)
+	if _, err := querier.GetNamespace(ctx, name); err == nil {
+		err = fmt.Errorf("%w: %s", service.ErrNamespaceAlreadyExists, name)
+		otel.RecordError(span, err)
+		return nil, err
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		otel.RecordError(span, err)
+		return nil, fmt.Errorf("failed to check namespace existence: %w", err)
+	}
+
+	row, err := querier.InsertNamespace(ctx, sqlc.InsertNamespaceParams{
+		Name:   name,
+		Claims: db.SerializeClaims(req.Claims),
+	})
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, fmt.Errorf("failed to insert namespace: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Namespace reserved",
+		"namespace", name,
+		"request_id", middleware.GetReqID(ctx))
+
+	return namespaceToInfo(&row), nil
+}
+
+// UpdateNamespace updates the claims of a reserved namespace.
+func (s *dbService) UpdateNamespace(
+	ctx context.Context, name string, req *service.NamespaceRequest,
+) (*service.NamespaceInfo, error) {
+	ctx, span := s.startSpan(ctx, "dbService.UpdateNamespace")
+	defer span.End()
+	span.SetAttributes(attribute.String("namespace.name", name))
+
+	if err := validateNamespaceRequest(name, req); err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+
+	row, err := sqlc.New(s.pool).UpdateNamespace(ctx, sqlc.UpdateNamespaceParams{
+		Claims: db.SerializeClaims(req.Claims),
+		Name:   name,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = fmt.Errorf("%w: %s", service.ErrNamespaceNotFound, name)
+		} else {
+			err = fmt.Errorf("failed to update namespace: %w", err)
+		}
+		otel.RecordError(span, err)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Namespace updated",
+		"namespace", name,
+		"request_id", middleware.GetReqID(ctx))
+
+	return namespaceToInfo(&row), nil
+}
+
+// DeleteNamespace releases a reserved namespace. Its entries are kept.
+func (s *dbService) DeleteNamespace(ctx context.Context, name string) error {
+	ctx, span := s.startSpan(ctx, "dbService.DeleteNamespace")
+	defer span.End()
+	span.SetAttributes(attribute.String("namespace.name", name))
+
+	rowsAffected, err := sqlc.New(s.pool).DeleteNamespace(ctx, name)
+	if err != nil {
+		otel.RecordError(span, err)
+		return fmt.Errorf("failed to delete namespace: %w", err)
+	}
+	if rowsAffected == 0 {
+		err := fmt.Errorf("%w: %s", service.ErrNamespaceNotFound, name)
+		otel.RecordError(span, err)
+		return err
+	}
+
+	slog.InfoContext(ctx, "Namespace released",
+		"namespace", name,
+		"request_id", middleware.GetReqID(ctx))
+
+	return nil
+}
+
+// validateNamespaceRequest validates the name and claims of a namespace.
+func validateNamespaceRequest(name string, req *service.NamespaceRequest) error {
+	if req == nil {
+		return fmt.Errorf("%w: request is required", service.ErrInvalidNamespace)
+	}
+	if err := validators.ValidateNamespace(name); err != nil {
+		return fmt.Errorf("%w: %v", service.ErrInvalidNamespace, err)
+	}
+	if err := db.ValidateClaimValues(req.Claims); err != nil {
+		return fmt.Errorf("%w: invalid claim values: %v", service.ErrInvalidNamespace, err)
+	}
+	return nil
+}
+
+// namespaceToInfo converts a namespace row to its service representation.
+func namespaceToInfo(row *sqlc.Namespace) *service.NamespaceInfo {
+	return &service.NamespaceInfo{
+		Name:      row.Name,
+		Claims:    db.DeserializeClaims(row.Claims),
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+}
+
+// serverNamespace returns the namespace of a server name: the part before the
+// '/' separator.
+func serverNamespace(name string) string {
+	namespace, _, _ := strings.Cut(name, "/")
+	return namespace
+}
+
+// checkNamespaceOwnership verifies that the caller owns the namespace of an
+// entry: that their claims cover the claims of the most specific reserved
+// namespace covering it. Entries outside any reserved namespace are open to
+// every caller. A nil callerClaims (authz off, anonymous) skips the check, as
+// does the super-admin role.
+func checkNamespaceOwnership(
+	ctx context.Context, querier sqlc.Querier, callerClaims map[string]any, namespace string,
+) error {
+	if callerClaims == nil {
+		return nil
+	}
+
+	owner, err := querier.GetOwningNamespace(ctx, namespace)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to look up namespace owner: %w", err)
+	}
+
+	if err := validateClaimsVisibleBytes(ctx, callerClaims, owner.Claims); err != nil {
+		if errors.Is(err, service.ErrClaimsInsufficient) {
+			return fmt.Errorf("%w: %s is reserved by %s", service.ErrNamespaceNotOwned, namespace, owner.Name)
+		}
+		return err
+	}
+	return nil
+}
+
+// checkEntryNamespaceOwnership verifies that the caller owns the namespace of
+// an existing entry of the managed source. A skill or plugin is checked against
+// the namespaces of all its versions.
+func checkEntryNamespaceOwnership(
+	ctx context.Context,
+	querier sqlc.Querier,
+	callerClaims map[string]any,
+	entryType sqlc.EntryType,
+	entryID uuid.UUID,
+	name string,
+) error {
+	if callerClaims == nil {
+		return nil
+	}
+	if entryType == sqlc.EntryTypeMCP {
+		return checkNamespaceOwnership(ctx, querier, callerClaims, serverNamespace(name))
+	}
+
+	namespaces, err := querier.ListEntryNamespaces(ctx, entryID)
+	if err != nil {
+		return fmt.Errorf("failed to list entry namespaces: %w", err)
+	}
+	for _, namespace := range namespaces {
+		if err := checkNamespaceOwnership(ctx, querier, callerClaims, namespace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/auth"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+func TestNamespaces_CRUD(t *testing.T) {
+	t.Parallel()
+
+	svc, cleanup := setupTestService(t)
+	t.Cleanup(cleanup)
+	ctx := context.Background()
+
+	created, err := svc.CreateNamespace(ctx, "com.example", &service.NamespaceRequest{
+		Claims: map[string]any{"org": "example"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "com.example", created.Name)
+	require.Equal(t, map[string]any{"org": "example"}, created.Claims)
+
+	_, err = svc.CreateNamespace(ctx, "com.example", &service.NamespaceRequest{})
+	require.ErrorIs(t, err, service.ErrNamespaceAlreadyExists)
+
+	_, err = svc.CreateNamespace(ctx, "com.example/server", &service.NamespaceRequest{})
+	require.ErrorIs(t, err, service.ErrInvalidNamespace)
+
+	_, err = svc.CreateNamespace(ctx, "org.reserved", &service.NamespaceRequest{})
+	require.NoError(t, err)
+
+	updated, err := svc.UpdateNamespace(ctx, "com.example", &service.NamespaceRequest{
+		Claims: map[string]any{"org": "example", "team": "eng"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"org": "example", "team": "eng"}, updated.Claims)
+
+	_, err = svc.UpdateNamespace(ctx, "com.unknown", &service.NamespaceRequest{})
+	require.ErrorIs(t, err, service.ErrNamespaceNotFound)
+
+	got, err := svc.GetNamespace(ctx, "com.example")
+	require.NoError(t, err)
+	require.Equal(t, updated.Claims, got.Claims)
+
+	list, err := svc.ListNamespaces(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, "com.example", list[0].Name)
+	require.Equal(t, "org.reserved", list[1].Name)
+	require.Nil(t, list[1].Claims)
+
+	require.NoError(t, svc.DeleteNamespace(ctx, "com.example"))
+	_, err = svc.GetNamespace(ctx, "com.example")
+	require.ErrorIs(t, err, service.ErrNamespaceNotFound)
+	require.ErrorIs(t, svc.DeleteNamespace(ctx, "com.example"), service.ErrNamespaceNotFound)
+}
+
+func TestNamespaces_Ownership(t *testing.T) {
+	t.Parallel()
+
+	svc, cleanup := setupTestService(t)
+	t.Cleanup(cleanup)
+	createManagedSourceWithRegistry(t, svc, "ownership-source")
+	ctx := context.Background()
+
+	for name, claims := range map[string]map[string]any{
+		"com.bigcorp":     {"team": "bigcorp"},
+		"com.bigcorp.eng": {"team": "eng"},
+		"org.reserved":    nil,
+	} {
+		_, err := svc.CreateNamespace(ctx, name, &service.NamespaceRequest{Claims: claims})
+		require.NoError(t, err)
+	}
+
+	owner := map[string]any{"org": "acme", "team": "bigcorp"}
+	other := map[string]any{"org": "acme", "team": "eng"}
+	entryClaims := map[string]any{"org": "acme"}
+	publishServer := func(ctx context.Context, name string, jwtClaims map[string]any) error {
+		_, err := svc.PublishServerVersion(ctx,
+			service.WithServerData(&upstreamv0.ServerJSON{Name: name, Version: "1.0.0"}),
+			service.WithClaims(entryClaims),
+			service.WithJWTClaims(jwtClaims),
+		)
+		return err
+	}
+
+	t.Run("publishes", func(t *testing.T) {
+		t.Parallel()
+
+		require.ErrorIs(t, publishServer(ctx, "com.bigcorp/squatted", other), service.ErrNamespaceNotOwned)
+		require.ErrorIs(t, publishServer(ctx, "com.bigcorp.tools/squatted", other), service.ErrNamespaceNotOwned)
+		require.NoError(t, publishServer(ctx, "com.bigcorpx/lookalike", other))
+		require.NoError(t, publishServer(ctx, "com.bigcorp.tools/owned", owner))
+
+		// The most specific namespace decides
+		require.NoError(t, publishServer(ctx, "com.bigcorp.eng/delegated", other))
+		require.ErrorIs(t, publishServer(ctx, "com.bigcorp.eng/delegated-away", owner), service.ErrNamespaceNotOwned)
+
+		// A namespace without claims is reserved for super-admins
+		require.ErrorIs(t, publishServer(ctx, "org.reserved/server", owner), service.ErrNamespaceNotOwned)
+		superAdmin := auth.ContextWithRoles(ctx, []auth.Role{auth.RoleSuperAdmin})
+		require.NoError(t, publishServer(superAdmin, "org.reserved/server", owner))
+
+		_, err := svc.PublishSkill(ctx, &service.Skill{Namespace: "com.bigcorp", Name: "squatted", Version: "1.0.0"},
+			service.WithClaims(entryClaims), service.WithJWTClaims(other))
+		require.ErrorIs(t, err, service.ErrNamespaceNotOwned)
+		_, err = svc.PublishSkill(ctx, &service.Skill{Namespace: "com.bigcorp", Name: "owned", Version: "1.0.0"},
+			service.WithClaims(entryClaims), service.WithJWTClaims(owner))
+		require.NoError(t, err)
+	})
+
+	t.Run("claims updates and deletes", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, publishServer(ctx, "com.bigcorp/weather", owner))
+
+		err := svc.UpdateEntryClaims(ctx,
+			service.WithEntryType(service.EntryTypeServer),
+			service.WithName("com.bigcorp/weather"),
+			service.WithClaims(entryClaims),
+			service.WithJWTClaims(other),
+		)
+		require.ErrorIs(t, err, service.ErrNamespaceNotOwned)
+
+		err = svc.DeleteServerVersion(ctx,
+			service.WithName("com.bigcorp/weather"),
+			service.WithVersion("1.0.0"),
+			service.WithJWTClaims(other),
+		)
+		require.ErrorIs(t, err, service.ErrNamespaceNotOwned)
+
+		_, err = svc.PublishPlugin(ctx, &service.Plugin{Namespace: "com.bigcorp", Name: "linter", Version: "1.0.0"},
+			service.WithClaims(entryClaims), service.WithJWTClaims(owner))
+		require.NoError(t, err)
+		err = svc.DeletePluginVersion(ctx,
+			service.WithName("linter"),
+			service.WithVersion("1.0.0"),
+			service.WithJWTClaims(other),
+		)
+		require.ErrorIs(t, err, service.ErrNamespaceNotOwned)
+		err = svc.DeletePluginVersion(ctx,
+			service.WithName("linter"),
+			service.WithVersion("1.0.0"),
+			service.WithJWTClaims(owner),
+		)
+		require.NoError(t, err)
+	})
+}
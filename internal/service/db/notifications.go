@@ -0,0 +1,139 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/notifications"
+	"github.com/stacklok/toolhive-registry-server/internal/otel"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// ListWebhookDeliveries returns the delivery log of the outbound webhooks, newest
+// first. Pagination uses an opaque cursor encoding the creation time and ID of
+// the last returned delivery.
+func (s *dbService) ListWebhookDeliveries(
+	ctx context.Context, opts ...service.Option,
+) (*service.WebhookDeliveryListResponse, error) {
+	ctx, span := s.startSpan(ctx, "dbService.ListWebhookDeliveries")
+	defer span.End()
+
+	options := &service.ListWebhookDeliveriesOptions{
+		Limit: service.DefaultPageSize,
+	}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			otel.RecordError(span, err)
+			return nil, err
+		}
+	}
+	if options.Limit > service.MaxPageSize {
+		options.Limit = service.MaxPageSize
+	}
+
+	params := sqlc.ListWebhookDeliveriesParams{
+		Size: int64(options.Limit + 1),
+	}
+	if options.Webhook != "" {
+		params.Webhook = &options.Webhook
+	}
+	if options.Status != "" {
+		params.Status = sqlc.NullWebhookDeliveryStatus{
+			WebhookDeliveryStatus: sqlc.WebhookDeliveryStatus(strings.ToUpper(options.Status)),
+			Valid:                 true,
+		}
+	}
+	if options.Cursor != "" {
+		createdAt, id, err := decodeWebhookDeliveryCursor(options.Cursor)
+		if err != nil {
+			otel.RecordError(span, err)
+			return nil, err
+		}
+		params.CursorCreatedAt = &createdAt
+		params.CursorID = &id
+	}
+
+	rows, err := sqlc.New(s.pool).ListWebhookDeliveries(ctx, params)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	// One extra row was requested to know whether there is a next page
+	var nextCursor string
+	if len(rows) > options.Limit {
+		rows = rows[:options.Limit]
+		last := rows[len(rows)-1]
+		nextCursor = encodeWebhookDeliveryCursor(last.CreatedAt, last.ID)
+	}
+
+	deliveries := make([]service.WebhookDeliveryInfo, len(rows))
+	for i := range rows {
+		deliveries[i] = buildWebhookDeliveryInfo(&rows[i])
+	}
+
+	return &service.WebhookDeliveryListResponse{
+		Deliveries: deliveries,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// encodeWebhookDeliveryCursor encodes the position of a delivery into a pagination cursor
+func encodeWebhookDeliveryCursor(createdAt time.Time, id uuid.UUID) string {
+	return service.EncodeCursor(createdAt.UTC().Format(time.RFC3339Nano), id.String())
+}
+
+// decodeWebhookDeliveryCursor decodes a pagination cursor created by encodeWebhookDeliveryCursor
+func decodeWebhookDeliveryCursor(cursor string) (time.Time, uuid.UUID, error) {
+	rawCreatedAt, rawID, err := service.DecodeCursor(cursor)
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("%w: %w", service.ErrInvalidCursor, err)
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, rawCreatedAt)
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("%w: %w", service.ErrInvalidCursor, err)
+	}
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("%w: %w", service.ErrInvalidCursor, err)
+	}
+	return createdAt, id, nil
+}
+
+// buildWebhookDeliveryInfo converts a database webhook delivery to its service representation
+func buildWebhookDeliveryInfo(row *sqlc.WebhookDelivery) service.WebhookDeliveryInfo {
+	info := service.WebhookDeliveryInfo{
+		ID:          row.ID.String(),
+		Webhook:     row.Webhook,
+		EventType:   row.EventType,
+		Status:      strings.ToLower(string(row.Status)),
+		Attempts:    int(row.Attempts),
+		CreatedAt:   row.CreatedAt,
+		DeliveredAt: row.DeliveredAt,
+		Payload:     row.Payload,
+	}
+	if row.ResponseStatus != nil {
+		info.ResponseStatus = int(*row.ResponseStatus)
+	}
+	if row.LastError != nil {
+		info.LastError = *row.LastError
+	}
+	if row.Status == sqlc.WebhookDeliveryStatusPENDING {
+		nextAttemptAt := row.NextAttemptAt
+		info.NextAttemptAt = &nextAttemptAt
+	}
+	return info
+}
+
+// notifyEntry queues an entry event for the webhooks subscribed to it, in the
+// transaction of querier.
+func (s *dbService) notifyEntry(
+	ctx context.Context, querier sqlc.Querier, eventType string, data notifications.EntryData,
+) error {
+	return s.outbox.Enqueue(ctx, querier, notifications.NewEntryEvent(eventType, data))
+}
@@ -0,0 +1,100 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/notifications"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+func TestListWebhookDeliveries(t *testing.T) {
+	t.Parallel()
+
+	svc, cleanup := setupTestService(t)
+	t.Cleanup(cleanup)
+
+	outbox, err := notifications.NewOutbox(&config.Config{
+		Notifications: &config.NotificationsConfig{
+			Webhooks: []config.WebhookConfig{
+				{Name: "catalog"},
+				{Name: "publishes", Events: []string{notifications.EventEntryPublish}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	svc.outbox = outbox
+
+	const registryName = "notifications-registry"
+	createManagedSourceWithRegistry(t, svc, registryName)
+	publishChangeFeedEntries(t, svc, registryName)
+	ctx := context.Background()
+
+	eventTypes := func(deliveries []service.WebhookDeliveryInfo) []string {
+		types := make([]string, len(deliveries))
+		for i, delivery := range deliveries {
+			types[i] = delivery.EventType
+		}
+		return types
+	}
+
+	t.Run("deliveries are listed newest first and resume after the cursor", func(t *testing.T) {
+		t.Parallel()
+
+		first, err := svc.ListWebhookDeliveries(ctx,
+			service.WithWebhook("catalog"),
+			service.WithLimit(3),
+		)
+		require.NoError(t, err)
+		require.Equal(t, []string{"entry.delete", "entry.publish", "entry.publish"}, eventTypes(first.Deliveries))
+		require.NotEmpty(t, first.NextCursor)
+
+		deleted := first.Deliveries[0]
+		require.Equal(t, service.DeliveryStatusPending, deleted.Status)
+		require.NotNil(t, deleted.NextAttemptAt)
+		require.Zero(t, deleted.Attempts)
+		var payload map[string]any
+		require.NoError(t, json.Unmarshal(deleted.Payload, &payload))
+		require.Equal(t, map[string]any{
+			"source":    registryName,
+			"entryType": "server",
+			"name":      "com.example/weather",
+			"version":   "1.0.0",
+		}, payload["data"])
+
+		second, err := svc.ListWebhookDeliveries(ctx,
+			service.WithWebhook("catalog"),
+			service.WithCursor(first.NextCursor),
+		)
+		require.NoError(t, err)
+		require.Equal(t, []string{"entry.publish"}, eventTypes(second.Deliveries))
+		require.Empty(t, second.NextCursor)
+	})
+
+	t.Run("webhooks only receive the events they subscribed to", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := svc.ListWebhookDeliveries(ctx, service.WithWebhook("publishes"))
+		require.NoError(t, err)
+		require.Equal(t, []string{"entry.publish", "entry.publish", "entry.publish"}, eventTypes(result.Deliveries))
+	})
+
+	t.Run("status filter", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := svc.ListWebhookDeliveries(ctx, service.WithDeliveryStatus(service.DeliveryStatusDelivered))
+		require.NoError(t, err)
+		require.Empty(t, result.Deliveries)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := svc.ListWebhookDeliveries(ctx, service.WithCursor("bogus"))
+		require.ErrorIs(t, err, service.ErrInvalidCursor)
+	})
+}
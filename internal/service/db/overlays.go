@@ -0,0 +1,399 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	model "github.com/modelcontextprotocol/registry/pkg/model"
+
+	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/otel"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/validators"
+)
+
+// maxOverlayTitleLength is the maximum length of the display name set by an
+// overlay, that of the title of a server.json.
+const maxOverlayTitleLength = 100
+
+// overlayTagsKey is the key of the tags of an overlay in its _meta
+const overlayTagsKey = "tags"
+
+// ListServerOverlays returns the overlays of the servers of a registry, ordered by server name.
+func (s *dbService) ListServerOverlays(ctx context.Context, registryName string) ([]service.ServerOverlayInfo, error) {
+	ctx, span := s.startSpan(ctx, "dbService.ListServerOverlays")
+	defer span.End()
+	span.SetAttributes(otel.AttrRegistryName.String(registryName))
+
+	registryID, err := s.lookupOverlayRegistryID(ctx, registryName)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+
+	rows, err := sqlc.New(s.pool).ListRegistryOverlays(ctx, registryID)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, fmt.Errorf("failed to list overlays: %w", err)
+	}
+
+	overlays := make([]service.ServerOverlayInfo, len(rows))
+	for i := range rows {
+		overlays[i] = *overlayToInfo(&rows[i])
+	}
+	return overlays, nil
+}
+
+// GetServerOverlay returns the overlay of a server of a registry.
+func (s *dbService) GetServerOverlay(
+	ctx context.Context, registryName, serverName string,
+) (*service.ServerOverlayInfo, error) {
+	ctx, span := s.startSpan(ctx, "dbService.GetServerOverlay")
+	defer span.End()
+	span.SetAttributes(
+		otel.AttrRegistryName.String(registryName),
+		otel.AttrServerName.String(serverName),
+	)
+
+	registryID, err := s.lookupOverlayRegistryID(ctx, registryName)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+
+	row, err := sqlc.New(s.pool).GetRegistryOverlay(ctx, sqlc.GetRegistryOverlayParams{
+		RegistryID: registryID,
+		Name:       serverName,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = fmt.Errorf("%w: %s", service.ErrOverlayNotFound, serverName)
+		} else {
+			err = fmt.Errorf("failed to get overlay: %w", err)
+		}
+		otel.RecordError(span, err)
+		return nil, err
+	}
+	return overlayToInfo(&row), nil
+}
+
+// CreateServerOverlay attaches an overlay to a server of a registry. The server
+// does not have to be provided by a source of the registry yet.
+func (s *dbService) CreateServerOverlay(
+	ctx context.Context, registryName, serverName string, req *service.ServerOverlayRequest,
+) (*service.ServerOverlayInfo, error) {
+	ctx, span := s.startSpan(ctx, "dbService.CreateServerOverlay")
+	defer span.End()
+	span.SetAttributes(
+		otel.AttrRegistryName.String(registryName),
+		otel.AttrServerName.String(serverName),
+	)
+
+	params, err := s.newOverlayParams(serverName, req)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+
+	registryID, err := s.lookupOverlayRegistryID(ctx, registryName)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+	params.RegistryID = registryID
+
+	querier := sqlc.New(s.pool)
+	_, err = querier.GetRegistryOverlay(ctx, sqlc.GetRegistryOverlayParams{RegistryID: registryID, Name: serverName})
+	if err == nil {
+		err = fmt.Errorf("%w: %s", service.ErrOverlayAlreadyExists, serverName)
+		otel.RecordError(span, err)
+		return nil, err
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		otel.RecordError(span, err)
+		return nil, fmt.Errorf("failed to check overlay existence: %w", err)
+	}
+
+	row, err := querier.InsertRegistryOverlay(ctx, params)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, fmt.Errorf("failed to insert overlay: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Overlay created",
+		"registry", registryName,
+		"server", serverName,
+		"request_id", middleware.GetReqID(ctx))
+
+	return overlayToInfo(&row), nil
+}
+
+// UpdateServerOverlay replaces the overlay of a server of a registry.
+func (s *dbService) UpdateServerOverlay(
+	ctx context.Context, registryName, serverName string, req *service.ServerOverlayRequest,
+) (*service.ServerOverlayInfo, error) {
+	ctx, span := s.startSpan(ctx, "dbService.UpdateServerOverlay")
+	defer span.End()
+	span.SetAttributes(
+		otel.AttrRegistryName.String(registryName),
+		otel.AttrServerName.String(serverName),
+	)
+
+	params, err := s.newOverlayParams(serverName, req)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+
+	registryID, err := s.lookupOverlayRegistryID(ctx, registryName)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+
+	row, err := sqlc.New(s.pool).UpdateRegistryOverlay(ctx, sqlc.UpdateRegistryOverlayParams{
+		Title:      params.Title,
+		Tags:       params.Tags,
+		Icons:      params.Icons,
+		Meta:       params.Meta,
+		RegistryID: registryID,
+		Name:       serverName,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = fmt.Errorf("%w: %s", service.ErrOverlayNotFound, serverName)
+		} else {
+			err = fmt.Errorf("failed to update overlay: %w", err)
+		}
+		otel.RecordError(span, err)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Overlay updated",
+		"registry", registryName,
+		"server", serverName,
+		"request_id", middleware.GetReqID(ctx))
+
+	return overlayToInfo(&row), nil
+}
+
+// DeleteServerOverlay removes the overlay of a server of a registry.
+func (s *dbService) DeleteServerOverlay(ctx context.Context, registryName, serverName string) error {
+	ctx, span := s.startSpan(ctx, "dbService.DeleteServerOverlay")
+	defer span.End()
+	span.SetAttributes(
+		otel.AttrRegistryName.String(registryName),
+		otel.AttrServerName.String(serverName),
+	)
+
+	registryID, err := s.lookupOverlayRegistryID(ctx, registryName)
+	if err != nil {
+		otel.RecordError(span, err)
+		return err
+	}
+
+	rowsAffected, err := sqlc.New(s.pool).DeleteRegistryOverlay(ctx, sqlc.DeleteRegistryOverlayParams{
+		RegistryID: registryID,
+		Name:       serverName,
+	})
+	if err != nil {
+		otel.RecordError(span, err)
+		return fmt.Errorf("failed to delete overlay: %w", err)
+	}
+	if rowsAffected == 0 {
+		err := fmt.Errorf("%w: %s", service.ErrOverlayNotFound, serverName)
+		otel.RecordError(span, err)
+		return err
+	}
+
+	slog.InfoContext(ctx, "Overlay deleted",
+		"registry", registryName,
+		"server", serverName,
+		"request_id", middleware.GetReqID(ctx))
+
+	return nil
+}
+
+// lookupOverlayRegistryID returns the ID of the registry of an overlay after
+// verifying the caller's claims satisfy the registry's access gate.
+func (s *dbService) lookupOverlayRegistryID(ctx context.Context, registryName string) (uuid.UUID, error) {
+	callerClaims := claimsFromCtx(ctx)
+	if s.skipAuthz {
+		callerClaims = nil
+	}
+	return lookupRegistryIDWithGate(ctx, s.pool, registryName, callerClaims)
+}
+
+// newOverlayParams validates an overlay request and serializes it for storage.
+// The registry ID of the returned parameters is left unset.
+func (s *dbService) newOverlayParams(
+	serverName string, req *service.ServerOverlayRequest,
+) (sqlc.InsertRegistryOverlayParams, error) {
+	if req == nil {
+		return sqlc.InsertRegistryOverlayParams{}, fmt.Errorf("%w: request is required", service.ErrInvalidOverlay)
+	}
+	if err := validateServerOverlayRequest(serverName, req); err != nil {
+		return sqlc.InsertRegistryOverlayParams{}, fmt.Errorf("%w: %v", service.ErrInvalidOverlay, err)
+	}
+
+	params := sqlc.InsertRegistryOverlayParams{
+		Name: serverName,
+		Tags: req.Tags,
+	}
+	if req.Title != "" {
+		params.Title = &req.Title
+	}
+	if len(req.Icons) > 0 {
+		icons, err := json.Marshal(req.Icons)
+		if err != nil {
+			return sqlc.InsertRegistryOverlayParams{}, fmt.Errorf("failed to serialize icons: %w", err)
+		}
+		params.Icons = icons
+	}
+	meta, err := serializePublisherProvidedMeta(&upstreamv0.ServerMeta{PublisherProvided: req.Meta}, s.maxMetaSize)
+	if err != nil {
+		return sqlc.InsertRegistryOverlayParams{}, fmt.Errorf("%w: meta: %v", service.ErrInvalidOverlay, err)
+	}
+	params.Meta = meta
+	return params, nil
+}
+
+// validateServerOverlayRequest validates the server name and fields of an overlay.
+func validateServerOverlayRequest(serverName string, req *service.ServerOverlayRequest) error {
+	if _, err := validators.ValidateServerName(serverName); err != nil {
+		return err
+	}
+	if len(req.Title) > maxOverlayTitleLength {
+		return fmt.Errorf("title must be at most %d characters", maxOverlayTitleLength)
+	}
+	for i, tag := range req.Tags {
+		if strings.TrimSpace(tag) == "" {
+			return fmt.Errorf("tags[%d] cannot be empty", i)
+		}
+	}
+	for i, icon := range req.Icons {
+		if icon.Src == "" {
+			return fmt.Errorf("icons[%d]: src is required", i)
+		}
+	}
+	if _, ok := req.Meta[overlayTagsKey]; ok {
+		return fmt.Errorf("meta cannot set %s, use the tags of the overlay", overlayTagsKey)
+	}
+	return nil
+}
+
+// overlayToInfo converts an overlay row to its service representation.
+// Fields that cannot be deserialized are left empty.
+func overlayToInfo(row *sqlc.RegistryOverlay) *service.ServerOverlayInfo {
+	info := &service.ServerOverlayInfo{
+		Name:      row.Name,
+		Title:     ptr.ToString(row.Title),
+		Tags:      row.Tags,
+		Icons:     deserializeOverlayIcons(row.Icons),
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+	if len(row.Meta) > 0 {
+		if err := json.Unmarshal(row.Meta, &info.Meta); err != nil {
+			info.Meta = nil
+		}
+	}
+	return info
+}
+
+// deserializeOverlayIcons deserializes the icons of an overlay.
+// Returns nil for empty data or on unmarshal error.
+func deserializeOverlayIcons(data []byte) []model.Icon {
+	if len(data) == 0 {
+		return nil
+	}
+	var icons []model.Icon
+	if err := json.Unmarshal(data, &icons); err != nil {
+		return nil
+	}
+	return icons
+}
+
+// fetchRegistryOverlays returns the overlays of the given servers in the registry
+// of the merge policy, keyed by server name. A nil policy, used when a version is
+// read from an explicit source rather than through a registry, has no overlays.
+func fetchRegistryOverlays(
+	ctx context.Context,
+	querier *sqlc.Queries,
+	policy *mergePolicy,
+	servers []helper,
+) (map[string]*sqlc.RegistryOverlay, error) {
+	if policy == nil || len(servers) == 0 {
+		return nil, nil
+	}
+
+	seen := make(map[string]struct{}, len(servers))
+	names := make([]string, 0, len(servers))
+	for _, server := range servers {
+		if _, exists := seen[server.Name]; !exists {
+			seen[server.Name] = struct{}{}
+			names = append(names, server.Name)
+		}
+	}
+
+	rows, err := querier.ListRegistryOverlaysByNames(ctx, sqlc.ListRegistryOverlaysByNamesParams{
+		RegistryID: policy.registryID,
+		Names:      names,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list overlays: %w", err)
+	}
+
+	overlays := make(map[string]*sqlc.RegistryOverlay, len(rows))
+	for i := range rows {
+		overlays[rows[i].Name] = &rows[i]
+	}
+	return overlays, nil
+}
+
+// withRegistryOverlay merges the overlay of a registry into a version of a server: the
+// display name and icons are overridden, and the tags and _meta of the overlay are
+// reported under service.OverlayMetaKey, replacing any value supplied by the publisher.
+func withRegistryOverlay(server upstreamv0.ServerJSON, overlay *sqlc.RegistryOverlay) (upstreamv0.ServerJSON, error) {
+	if server.Meta != nil {
+		delete(server.Meta.PublisherProvided, service.OverlayMetaKey)
+	}
+	if overlay == nil {
+		return server, nil
+	}
+
+	if overlay.Title != nil {
+		server.Title = *overlay.Title
+	}
+	if icons := deserializeOverlayIcons(overlay.Icons); len(icons) > 0 {
+		server.Icons = icons
+	}
+
+	meta := make(map[string]any)
+	if len(overlay.Meta) > 0 {
+		if err := json.Unmarshal(overlay.Meta, &meta); err != nil {
+			return upstreamv0.ServerJSON{}, fmt.Errorf("failed to unmarshal overlay meta: %w", err)
+		}
+	}
+	if len(overlay.Tags) > 0 {
+		meta[overlayTagsKey] = overlay.Tags
+	}
+	if len(meta) > 0 {
+		if server.Meta == nil {
+			server.Meta = &upstreamv0.ServerMeta{}
+		}
+		if server.Meta.PublisherProvided == nil {
+			server.Meta.PublisherProvided = make(map[string]any)
+		}
+		server.Meta.PublisherProvided[service.OverlayMetaKey] = meta
+	}
+	return server, nil
+}
@@ -0,0 +1,180 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	model "github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+func TestServerOverlays_CRUD(t *testing.T) {
+	t.Parallel()
+
+	svc, cleanup := setupTestService(t)
+	t.Cleanup(cleanup)
+	createManagedSourceWithRegistryClaims(t, svc, "overlay-crud", nil)
+	ctx := context.Background()
+
+	created, err := svc.CreateServerOverlay(ctx, "overlay-crud", "com.example/server", &service.ServerOverlayRequest{
+		Title: "Example Server",
+		Tags:  []string{"approved"},
+		Icons: []model.Icon{{Src: "https://example.com/icon.png"}},
+		Meta:  map[string]any{"owner": "platform"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "com.example/server", created.Name)
+	require.Equal(t, "Example Server", created.Title)
+	require.Equal(t, []string{"approved"}, created.Tags)
+	require.Equal(t, "https://example.com/icon.png", created.Icons[0].Src)
+	require.Equal(t, map[string]any{"owner": "platform"}, created.Meta)
+
+	_, err = svc.CreateServerOverlay(ctx, "overlay-crud", "com.example/server", &service.ServerOverlayRequest{})
+	require.ErrorIs(t, err, service.ErrOverlayAlreadyExists)
+
+	_, err = svc.CreateServerOverlay(ctx, "unknown", "com.example/server", &service.ServerOverlayRequest{})
+	require.ErrorIs(t, err, service.ErrRegistryNotFound)
+
+	for _, req := range []*service.ServerOverlayRequest{
+		nil,
+		{Title: strings.Repeat("a", maxOverlayTitleLength+1)},
+		{Tags: []string{" "}},
+		{Icons: []model.Icon{{}}},
+		{Meta: map[string]any{"tags": []string{"approved"}}},
+	} {
+		_, err = svc.CreateServerOverlay(ctx, "overlay-crud", "com.example/other", req)
+		require.ErrorIs(t, err, service.ErrInvalidOverlay)
+	}
+
+	updated, err := svc.UpdateServerOverlay(ctx, "overlay-crud", "com.example/server", &service.ServerOverlayRequest{
+		Tags: []string{"approved", "internal"},
+	})
+	require.NoError(t, err)
+	require.Empty(t, updated.Title)
+	require.Empty(t, updated.Icons)
+	require.Nil(t, updated.Meta)
+	require.Equal(t, []string{"approved", "internal"}, updated.Tags)
+
+	_, err = svc.UpdateServerOverlay(ctx, "overlay-crud", "com.example/unknown", &service.ServerOverlayRequest{})
+	require.ErrorIs(t, err, service.ErrOverlayNotFound)
+
+	got, err := svc.GetServerOverlay(ctx, "overlay-crud", "com.example/server")
+	require.NoError(t, err)
+	require.Equal(t, updated.Tags, got.Tags)
+
+	_, err = svc.CreateServerOverlay(ctx, "overlay-crud", "com.example/another", &service.ServerOverlayRequest{})
+	require.NoError(t, err)
+
+	list, err := svc.ListServerOverlays(ctx, "overlay-crud")
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, "com.example/another", list[0].Name)
+	require.Equal(t, "com.example/server", list[1].Name)
+
+	require.NoError(t, svc.DeleteServerOverlay(ctx, "overlay-crud", "com.example/server"))
+	_, err = svc.GetServerOverlay(ctx, "overlay-crud", "com.example/server")
+	require.ErrorIs(t, err, service.ErrOverlayNotFound)
+	require.ErrorIs(t, svc.DeleteServerOverlay(ctx, "overlay-crud", "com.example/server"), service.ErrOverlayNotFound)
+}
+
+func TestServerOverlays_AppliedOnRead(t *testing.T) {
+	t.Parallel()
+
+	svc, cleanup := setupTestService(t)
+	t.Cleanup(cleanup)
+	createManagedSourceWithRegistryClaims(t, svc, "overlay-read", nil)
+	ctx := context.Background()
+
+	for _, name := range []string{"com.example/annotated", "com.example/plain"} {
+		_, err := svc.PublishServerVersion(ctx, service.WithServerData(&upstreamv0.ServerJSON{
+			Name:    name,
+			Title:   "Upstream Title",
+			Version: "1.0.0",
+			Meta: &upstreamv0.ServerMeta{PublisherProvided: map[string]any{
+				service.OverlayMetaKey: map[string]any{"forged": true},
+				"vendor":               "upstream",
+			}},
+		}))
+		require.NoError(t, err)
+	}
+
+	_, err := svc.CreateServerOverlay(ctx, "overlay-read", "com.example/annotated", &service.ServerOverlayRequest{
+		Title: "Curated Title",
+		Tags:  []string{"approved"},
+		Meta:  map[string]any{"owner": "platform"},
+	})
+	require.NoError(t, err)
+
+	annotated, err := svc.GetServerVersion(ctx,
+		service.WithRegistryName("overlay-read"),
+		service.WithName("com.example/annotated"),
+		service.WithVersion("latest"),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "Curated Title", annotated.Server.Title)
+	require.Equal(t, "upstream", annotated.Server.Meta.PublisherProvided["vendor"])
+	require.Equal(t, map[string]any{"owner": "platform", "tags": []string{"approved"}},
+		annotated.Server.Meta.PublisherProvided[service.OverlayMetaKey])
+
+	result, err := svc.ListServers(ctx, service.WithRegistryName("overlay-read"), service.WithLimit(10))
+	require.NoError(t, err)
+	require.Len(t, result.Servers, 2)
+	for _, server := range result.Servers {
+		if server.Server.Name == "com.example/plain" {
+			require.Equal(t, "Upstream Title", server.Server.Title)
+			require.NotContains(t, server.Server.Meta.PublisherProvided, service.OverlayMetaKey)
+		} else {
+			require.Equal(t, "Curated Title", server.Server.Title)
+		}
+	}
+}
+
+func TestWithRegistryOverlay(t *testing.T) {
+	t.Parallel()
+
+	title := "Curated Title"
+	server := upstreamv0.ServerJSON{
+		Name:  "com.example/server",
+		Title: "Upstream Title",
+		Icons: []model.Icon{{Src: "https://example.com/upstream.png"}},
+		Meta: &upstreamv0.ServerMeta{PublisherProvided: map[string]any{
+			service.OverlayMetaKey: "forged",
+		}},
+	}
+
+	got, err := withRegistryOverlay(server, &sqlc.RegistryOverlay{
+		Name:  "com.example/server",
+		Title: &title,
+		Tags:  []string{"approved"},
+		Icons: []byte(`[{"src":"https://example.com/curated.png"}]`),
+		Meta:  []byte(`{"owner":"platform"}`),
+	})
+	require.NoError(t, err)
+	require.Equal(t, title, got.Title)
+	require.Equal(t, "https://example.com/curated.png", got.Icons[0].Src)
+	require.Equal(t, map[string]any{"owner": "platform", "tags": []string{"approved"}},
+		got.Meta.PublisherProvided[service.OverlayMetaKey])
+
+	got, err = withRegistryOverlay(upstreamv0.ServerJSON{
+		Name:  "com.example/server",
+		Title: "Upstream Title",
+		Meta: &upstreamv0.ServerMeta{PublisherProvided: map[string]any{
+			service.OverlayMetaKey: "forged",
+		}},
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "Upstream Title", got.Title)
+	require.NotContains(t, got.Meta.PublisherProvided, service.OverlayMetaKey)
+
+	got, err = withRegistryOverlay(upstreamv0.ServerJSON{Name: "com.example/server"}, &sqlc.RegistryOverlay{
+		Name: "com.example/server",
+		Tags: []string{"approved"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"tags": []string{"approved"}}, got.Meta.PublisherProvided[service.OverlayMetaKey])
+}
@@ -0,0 +1,102 @@
+package database
+
+import (
+	"context"
+
+	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/pinning"
+	"github.com/stacklok/toolhive-registry-server/internal/scanning"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// publishChecks holds the results of the checks of an entry version published
+// into the managed source that run before the transaction of the publish:
+// resolving the tags of its packages and running the scanners of the entry
+// policy go over the network, and must not hold the serializable transaction
+// open.
+type publishChecks struct {
+	// digests are the digests the OCI packages of the version are pinned to
+	digests []pinning.PackageDigest
+	// scan is the scan of the version, nil when the entry policy does not apply
+	scan *scanning.Result
+}
+
+// preparePublish runs the checks of a publish into the managed source that are
+// kept out of its transaction, setting the digest of the pinned packages of
+// skills and plugins in place. The caller must be allowed to publish the entry,
+// as verified again in the transaction, so that other callers cannot have tags
+// resolved or scanners run.
+func (s *dbService) preparePublish(
+	ctx context.Context, entry *service.PortableEntry, gateClaims map[string]any,
+) (*publishChecks, error) {
+	if s.digestPinning == nil && s.entryPolicy == nil {
+		return &publishChecks{}, nil
+	}
+
+	querier := sqlc.New(s.pool)
+	source, err := getManagedSource(ctx, querier)
+	if err != nil {
+		return nil, err
+	}
+	if !s.digestPinning.AppliesTo(source.Name) && !s.entryPolicy.AppliesTo(source.Name) {
+		return &publishChecks{}, nil
+	}
+	if err := validateClaimsVisibleBytes(ctx, gateClaims, source.Claims); err != nil {
+		return nil, err
+	}
+	if err := checkNamespaceOwnership(ctx, querier, gateClaims, entryNamespace(entry)); err != nil {
+		return nil, err
+	}
+	return s.runPublishChecks(ctx, source, entry)
+}
+
+// runPublishChecks pins the OCI packages of an entry version published into a
+// managed source to digests, then scans the version with the entry policy.
+// Returns ErrEntryBlocked when the policy blocks the version.
+func (s *dbService) runPublishChecks(
+	ctx context.Context, source *sqlc.Source, entry *service.PortableEntry,
+) (*publishChecks, error) {
+	checks := &publishChecks{}
+	var err error
+	switch {
+	case entry.Server != nil:
+		checks.digests, err = s.pinPublishedServer(ctx, source, entry.Server)
+	case entry.Skill != nil:
+		checks.digests, err = pinPublishedPackages(ctx, s.digestPinning, source, service.EntryTypeSkill,
+			entry.Skill.Name, entry.Skill.Version, entry.Skill.Packages)
+	default:
+		checks.digests, err = pinPublishedPackages(ctx, s.digestPinning, source, service.EntryTypePlugin,
+			entry.Plugin.Name, entry.Plugin.Version, entry.Plugin.Packages)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var name, version string
+	var published any
+	switch {
+	case entry.Server != nil:
+		name, version, published = entry.Server.Name, entry.Server.Version, entry.Server
+	case entry.Skill != nil:
+		name, version, published = entry.Skill.Name, entry.Skill.Version, entry.Skill
+	default:
+		name, version, published = entry.Plugin.Name, entry.Plugin.Version, entry.Plugin
+	}
+	checks.scan, err = s.scanPublishedEntry(ctx, source, entry.EntryType(), name, version, published)
+	if err != nil {
+		return nil, err
+	}
+	return checks, nil
+}
+
+// entryNamespace returns the namespace reserved by the owner of an entry
+func entryNamespace(entry *service.PortableEntry) string {
+	switch {
+	case entry.Server != nil:
+		return serverNamespace(entry.Server.Name)
+	case entry.Skill != nil:
+		return entry.Skill.Namespace
+	default:
+		return entry.Plugin.Namespace
+	}
+}
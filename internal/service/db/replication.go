@@ -0,0 +1,257 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/stacklok/toolhive-registry-server/internal/db"
+	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/otel"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// servedVersion is an entry version served by a registry, with the claims of
+// its entry in the source serving it
+type servedVersion struct {
+	entryType  sqlc.EntryType
+	name       string
+	version    string
+	sourceName string
+	claims     []byte
+}
+
+// ExportRegistry returns the entry versions a registry serves, under its merge
+// strategies, with the claims of their entries. The change token is read before
+// the entries, so that the changes made during the export are exported again
+// since it. Entries whose claims the caller cannot see are left out.
+func (s *dbService) ExportRegistry(
+	ctx context.Context, registryName, since string,
+) (*service.RegistryExport, error) {
+	ctx, span := s.startSpan(ctx, "dbService.ExportRegistry")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(otel.AttrRegistryName.String(registryName))
+
+	callerClaims := claimsFromCtx(ctx)
+	if s.skipAuthz {
+		callerClaims = nil
+	}
+
+	// Reading the change token also gates the registry on the claims of the caller
+	latest, err := s.ListEntryChanges(ctx,
+		service.WithRegistryName(registryName), service.WithClaims(callerClaims), service.WithFromLatest())
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+	export := &service.RegistryExport{ChangeToken: latest.NextToken}
+
+	// changed maps the versions changed since the token to their last change
+	var changed map[string]*service.EntryChange
+	var changes []*service.EntryChange
+	if since != "" {
+		changes, err = s.listChangesUntil(ctx, registryName, callerClaims, since, latest.NextToken)
+		if err != nil {
+			otel.RecordError(span, err)
+			return nil, err
+		}
+		changed = make(map[string]*service.EntryChange, len(changes))
+		for _, change := range changes {
+			changed[change.EntryType+"/"+change.Name+"@"+change.Version] = change
+		}
+	}
+
+	served, err := s.listServedVersions(ctx, registryName)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+
+	for _, version := range served {
+		key := entryTypeName(version.entryType) + "/" + version.name + "@" + version.version
+		if changed != nil && changed[key] == nil {
+			continue
+		}
+		if err := validateClaimsVisibleBytes(ctx, callerClaims, version.claims); err != nil {
+			continue
+		}
+		delete(changed, key)
+
+		entry, err := s.fetchServedEntry(ctx, registryName, callerClaims, version)
+		if err != nil {
+			otel.RecordError(span, err)
+			return nil, fmt.Errorf("failed to fetch %s %s %s: %w",
+				entryTypeName(version.entryType), version.name, version.version, err)
+		}
+		entry.Claims = db.DeserializeClaims(version.claims)
+		export.Entries = append(export.Entries, *entry)
+	}
+
+	// The changed versions left are no longer served, reported by their last change
+	for _, change := range changes {
+		key := change.EntryType + "/" + change.Name + "@" + change.Version
+		if changed[key] == change {
+			export.Deleted = append(export.Deleted, change)
+		}
+	}
+
+	span.SetAttributes(otel.AttrResultCount.Int(len(export.Entries)))
+	slog.DebugContext(ctx, "ExportRegistry completed",
+		"duration_ms", time.Since(start).Milliseconds(),
+		"registry", registryName,
+		"entry_count", len(export.Entries),
+		"deleted_count", len(export.Deleted),
+		"request_id", middleware.GetReqID(ctx))
+	return export, nil
+}
+
+// listChangesUntil returns the changes of a registry made after a change token
+// and up to another, oldest first.
+func (s *dbService) listChangesUntil(
+	ctx context.Context, registryName string, callerClaims map[string]any, since, until string,
+) ([]*service.EntryChange, error) {
+	untilID, err := decodeChangeToken(until)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []*service.EntryChange
+	for {
+		result, err := s.ListEntryChanges(ctx,
+			service.WithRegistryName(registryName),
+			service.WithClaims(callerClaims),
+			service.WithSince(since),
+			service.WithLimit(service.MaxPageSize))
+		if err != nil {
+			return nil, err
+		}
+		for _, change := range result.Changes {
+			id, err := decodeChangeToken(change.Token)
+			if err != nil {
+				return nil, err
+			}
+			if id > untilID {
+				return changes, nil
+			}
+			changes = append(changes, change)
+		}
+		// A short page means that the feed is exhausted
+		if len(result.Changes) < service.MaxPageSize {
+			return changes, nil
+		}
+		since = result.NextToken
+	}
+}
+
+// listServedVersions returns the entry versions a registry serves under its merge
+// strategies, servers first, then skills, then plugins.
+func (s *dbService) listServedVersions(ctx context.Context, registryName string) ([]servedVersion, error) {
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.RepeatableRead,
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		err := tx.Rollback(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.WarnContext(ctx, "Failed to rollback transaction", "error", err)
+		}
+	}()
+
+	querier := sqlc.New(tx)
+
+	registry, err := querier.GetRegistryByName(ctx, registryName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", service.ErrRegistryNotFound, registryName)
+		}
+		return nil, fmt.Errorf("failed to get registry: %w", err)
+	}
+
+	rows, err := querier.ListEntriesByRegistry(ctx, registry.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list entries by registry: %w", err)
+	}
+	policy, err := loadMergePolicy(ctx, querier, registry, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// The entries are merged row by row: the provenance of rows[i] is in infos[i]
+	infos := mergeRegistryEntries(rows, policy)
+
+	// The claims of the entries are those of the source serving them
+	sourceClaims := make(map[string]map[string][]byte)
+	var served []servedVersion
+	for _, entryType := range []sqlc.EntryType{sqlc.EntryTypeMCP, sqlc.EntryTypeSKILL, sqlc.EntryTypePLUGIN} {
+		for i, row := range rows {
+			if row.EntryType != entryType || infos[i].FieldSources == nil {
+				continue
+			}
+			claims, ok := sourceClaims[row.SourceName]
+			if !ok {
+				claims, err = listSourceEntryClaims(ctx, querier, row.SourceName)
+				if err != nil {
+					return nil, err
+				}
+				sourceClaims[row.SourceName] = claims
+			}
+			served = append(served, servedVersion{
+				entryType:  row.EntryType,
+				name:       row.Name,
+				version:    row.Version,
+				sourceName: row.SourceName,
+				claims:     claims[mergeKey(row.EntryType, row.Name)],
+			})
+		}
+	}
+	return served, nil
+}
+
+// listSourceEntryClaims returns the claims of the entries of a source, keyed by
+// their merge key.
+func listSourceEntryClaims(ctx context.Context, querier *sqlc.Queries, sourceName string) (map[string][]byte, error) {
+	source, err := querier.GetSourceByName(ctx, sourceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source %s: %w", sourceName, err)
+	}
+	rows, err := querier.ListEntriesBySource(ctx, source.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list entries by source: %w", err)
+	}
+	claims := make(map[string][]byte, len(rows))
+	for _, row := range rows {
+		claims[mergeKey(row.EntryType, row.Name)] = row.Claims
+	}
+	return claims, nil
+}
+
+// fetchServedEntry retrieves an entry version as the registry serves it. Servers
+// are read through the registry, with the fields overlaid by other sources and
+// the overlay of the registry applied.
+func (s *dbService) fetchServedEntry(
+	ctx context.Context, registryName string, callerClaims map[string]any, version servedVersion,
+) (*service.PortableEntry, error) {
+	if version.entryType != sqlc.EntryTypeMCP {
+		return s.fetchPortableEntry(ctx, version.entryType, version.name, version.version, version.sourceName)
+	}
+	resp, err := s.GetServerVersion(ctx,
+		service.WithRegistryName(registryName),
+		service.WithName(version.name),
+		service.WithVersion(version.version),
+		service.WithClaims(callerClaims))
+	if err != nil {
+		return nil, err
+	}
+	server := resp.Server
+	return &service.PortableEntry{Server: &server}, nil
+}
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/scanning"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// scanPublishedEntry scans an entry version published into a managed source
+// with the scanners of the entry policy. Returns a nil result when the policy
+// does not apply to the source, and ErrEntryBlocked when it blocks the version.
+func (s *dbService) scanPublishedEntry(
+	ctx context.Context, source *sqlc.Source, entryType, name, version string, entry any,
+) (*scanning.Result, error) {
+	if !s.entryPolicy.AppliesTo(source.Name) {
+		return nil, nil
+	}
+
+	result := s.entryPolicy.Evaluate(ctx, &scanning.Request{
+		Type:    entryType,
+		Source:  source.Name,
+		Name:    name,
+		Version: version,
+		Entry:   entry,
+	})
+	if result.Blocked() {
+		messages := make([]string, 0, len(result.Findings))
+		for _, finding := range result.Findings {
+			messages = append(messages, finding.Scanner+": "+finding.Message)
+		}
+		return nil, fmt.Errorf("%w: %s %s: %s", service.ErrEntryBlocked, name, version, strings.Join(messages, "; "))
+	}
+	return result, nil
+}
+
+// insertEntryScan records the scan of a published entry version. Does nothing
+// when the entry policy does not apply to the source.
+func insertEntryScan(ctx context.Context, querier sqlc.Querier, versionID uuid.UUID, scan *scanning.Result) error {
+	if scan == nil {
+		return nil
+	}
+	var findings []byte
+	if len(scan.Findings) > 0 {
+		var err error
+		findings, err = json.Marshal(scan.Findings)
+		if err != nil {
+			return fmt.Errorf("failed to serialize scan findings: %w", err)
+		}
+	}
+	if err := querier.UpsertEntryScan(ctx, sqlc.UpsertEntryScanParams{
+		VersionID: versionID,
+		Verdict:   string(scan.Verdict),
+		Findings:  findings,
+	}); err != nil {
+		return fmt.Errorf("failed to insert entry scan: %w", err)
+	}
+	return nil
+}
+
+// fetchEntryScans returns the scans of the given entry versions, keyed by
+// version ID.
+func fetchEntryScans(
+	ctx context.Context, querier sqlc.Querier, versionIDs []uuid.UUID,
+) (map[uuid.UUID]*sqlc.EntryScan, error) {
+	rows, err := querier.ListEntryScans(ctx, versionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list entry scans: %w", err)
+	}
+	scans := make(map[uuid.UUID]*sqlc.EntryScan, len(rows))
+	for i := range rows {
+		scans[rows[i].VersionID] = &rows[i]
+	}
+	return scans, nil
+}
+
+// withScanMeta reports the scan of an entry version in its _meta, replacing
+// any value supplied by the publisher under the same key.
+func withScanMeta(meta map[string]any, scan *sqlc.EntryScan) map[string]any {
+	if scan == nil {
+		delete(meta, service.ScanMetaKey)
+		return meta
+	}
+	if meta == nil {
+		meta = make(map[string]any)
+	}
+	meta[service.ScanMetaKey] = entryScanResult(scan.Verdict, scan.Findings, scan.ScannedAt)
+	return meta
+}
+
+// entryScanResult converts a stored scan to an EntryScanResult. Findings that
+// cannot be parsed are dropped: the verdict is still reported.
+func entryScanResult(verdict string, findings []byte, scannedAt time.Time) *service.EntryScanResult {
+	result := &service.EntryScanResult{Verdict: verdict, ScannedAt: scannedAt}
+	if len(findings) > 0 {
+		if err := json.Unmarshal(findings, &result.Findings); err != nil {
+			slog.Warn("Failed to parse stored scan findings", "error", err)
+		}
+	}
+	return result
+}
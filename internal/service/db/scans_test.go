@@ -0,0 +1,64 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/scanning"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+func TestEntryPolicy_Publish(t *testing.T) {
+	t.Parallel()
+
+	svc, cleanup := setupTestService(t)
+	t.Cleanup(cleanup)
+	createManagedSourceWithRegistryClaims(t, svc, "entry-policy", nil)
+	ctx := context.Background()
+
+	// The scanner blocks the malicious server and annotates the others
+	script := `if grep -q malicious; then echo '{"verdict": "block", "findings": [{"message": "malware"}]}'; ` +
+		`else echo '{"verdict": "annotate", "findings": [{"id": "CVE-1", "message": "vulnerable"}]}'; fi`
+	entryPolicy, err := scanning.NewPipeline(&config.EntryPolicyConfig{
+		Scanners: []config.ScannerConfig{{Name: "test", Command: []string{"/bin/sh", "-c", script}}},
+	})
+	require.NoError(t, err)
+	svc.entryPolicy = entryPolicy
+
+	_, err = svc.PublishServerVersion(ctx, service.WithServerData(&upstreamv0.ServerJSON{
+		Name:    "com.example/malicious",
+		Version: "1.0.0",
+	}))
+	require.ErrorIs(t, err, service.ErrEntryBlocked)
+	require.ErrorContains(t, err, "test: malware")
+
+	_, err = svc.PublishServerVersion(ctx, service.WithServerData(&upstreamv0.ServerJSON{
+		Name:    "com.example/server",
+		Version: "1.0.0",
+		Meta: &upstreamv0.ServerMeta{PublisherProvided: map[string]any{
+			service.ScanMetaKey: map[string]any{"verdict": "allow"},
+		}},
+	}))
+	require.NoError(t, err)
+
+	server, err := svc.GetServerVersion(ctx,
+		service.WithRegistryName("entry-policy"),
+		service.WithName("com.example/server"),
+		service.WithVersion("1.0.0"),
+	)
+	require.NoError(t, err)
+	scan, ok := server.Server.Meta.PublisherProvided[service.ScanMetaKey].(*service.EntryScanResult)
+	require.True(t, ok)
+	require.Equal(t, "annotate", scan.Verdict)
+	require.Equal(t, []service.ScanFinding{{Scanner: "test", ID: "CVE-1", Message: "vulnerable"}}, scan.Findings)
+
+	entries, err := svc.ListRegistryEntries(ctx, "entry-policy")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.NotNil(t, entries[0].Scan)
+	require.Equal(t, "annotate", entries[0].Scan.Verdict)
+}
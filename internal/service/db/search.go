@@ -0,0 +1,355 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/otel"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// SearchEntries searches the latest versions of the servers, skills and plugins of a
+// registry, ordered by relevance, with cursor-based pagination.
+func (s *dbService) SearchEntries(
+	ctx context.Context,
+	opts ...service.Option,
+) (*service.SearchEntriesResult, error) {
+	ctx, span := s.startSpan(ctx, "dbService.SearchEntries")
+	defer span.End()
+
+	options := &service.SearchEntriesOptions{
+		Limit: service.DefaultPageSize,
+	}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			otel.RecordError(span, err)
+			return nil, err
+		}
+	}
+
+	if options.RegistryName == "" {
+		return nil, fmt.Errorf("registry name is required")
+	}
+
+	span.SetAttributes(otel.AttrRegistryName.String(options.RegistryName))
+
+	if options.Limit > service.MaxPageSize {
+		options.Limit = service.MaxPageSize
+	}
+
+	gateClaims := options.Claims
+	if s.skipAuthz {
+		gateClaims = nil
+	}
+	registry, err := lookupRegistryWithGate(ctx, s.pool, options.RegistryName, gateClaims)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+
+	// A search without words matches nothing
+	tsQuery := toPrefixTSQuery(options.Search)
+	if tsQuery == "" {
+		return &service.SearchEntriesResult{Hits: []*service.SearchHit{}}, nil
+	}
+
+	params := sqlc.SearchEntriesParams{
+		Search:     tsQuery,
+		RegistryID: registry.ID,
+		Size:       int64(options.Limit + 1),
+	}
+	if options.Cursor != nil {
+		relevance, entryType, name, err := decodeSearchCursor(*options.Cursor)
+		if err != nil {
+			otel.RecordError(span, err)
+			return nil, err
+		}
+		setSearchCursor(&params, relevance, entryType, name)
+	}
+
+	claimsFilter := newClaimsFilterWith(
+		ctx, options.Claims,
+		func(record any) ([]byte, bool) {
+			r, ok := record.(sqlc.SearchEntriesRow)
+			return r.Claims, ok
+		},
+	)
+	if s.skipAuthz {
+		claimsFilter = nil
+	}
+	querier := sqlc.New(s.pool)
+	policy, err := loadMergePolicy(ctx, querier, registry, "", nil)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+	rows, nextCursor, err := streamSearchRows(ctx, querier, params, claimsFilter, policy, options.Limit)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+
+	hits := make([]*service.SearchHit, len(rows))
+	for i, row := range rows {
+		hits[i] = searchRowToHit(row)
+	}
+
+	return &service.SearchEntriesResult{
+		Hits:       hits,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// streamSearchRows fetches search results in batches, applying the auth filter then the
+// dedup filter to each row, until limit+1 rows are accumulated or the DB is exhausted.
+// It returns the trimmed slice (≤ limit) and the cursor for the next page, if any.
+func streamSearchRows(
+	ctx context.Context,
+	querier sqlc.Querier,
+	params sqlc.SearchEntriesParams,
+	filter service.RecordFilter,
+	policy *mergePolicy,
+	limit int,
+) ([]sqlc.SearchEntriesRow, string, error) {
+	dedupFilter := newDeduplicatingSearchFilter(policy)
+	var accumulated []sqlc.SearchEntriesRow
+	batchParams := params
+
+	for {
+		batch, err := querier.SearchEntries(ctx, batchParams)
+		if err != nil {
+			return nil, "", err
+		}
+
+		for _, row := range batch {
+			keep := true
+			var ferr error
+			if filter != nil {
+				keep, ferr = filter(ctx, row)
+				if ferr != nil {
+					return nil, "", ferr
+				}
+			}
+			if keep {
+				keep, ferr = dedupFilter(ctx, row)
+				if ferr != nil {
+					return nil, "", ferr
+				}
+			}
+			if keep {
+				accumulated = append(accumulated, row)
+			}
+		}
+
+		if len(accumulated) >= limit+1 || int64(len(batch)) < batchParams.Size {
+			break
+		}
+
+		lastRow := batch[len(batch)-1]
+		setSearchCursor(&batchParams, lastRow.Relevance, lastRow.EntryType, lastRow.Name)
+	}
+
+	nextCursor := ""
+	if len(accumulated) > limit {
+		last := accumulated[limit-1]
+		nextCursor = encodeSearchCursor(last.Relevance, last.EntryType, last.Name)
+		accumulated = accumulated[:limit]
+	}
+
+	return accumulated, nextCursor, nil
+}
+
+// setSearchCursor sets the position of the search results to start after
+func setSearchCursor(params *sqlc.SearchEntriesParams, relevance float32, entryType sqlc.EntryType, name string) {
+	params.CursorRelevance = pgtype.Float4{Float32: relevance, Valid: true}
+	params.CursorType = sqlc.NullEntryType{EntryType: entryType, Valid: true}
+	params.CursorName = &name
+}
+
+// encodeSearchCursor encodes the position of a search hit into a pagination
+// cursor. The relevance of the hit is part of the position, so that the next
+// page is found even if the entry of the hit changed or was removed since.
+func encodeSearchCursor(relevance float32, entryType sqlc.EntryType, name string) string {
+	return service.EncodeCursor(strconv.FormatFloat(float64(relevance), 'g', -1, 32), entryTypeName(entryType)+"/"+name)
+}
+
+// decodeSearchCursor decodes a pagination cursor created by encodeSearchCursor
+func decodeSearchCursor(cursor string) (float32, sqlc.EntryType, string, error) {
+	rawRelevance, rawEntry, err := service.DecodeCursor(cursor)
+	if err != nil {
+		return 0, "", "", fmt.Errorf("%w: %w", service.ErrInvalidCursor, err)
+	}
+	relevance, err := strconv.ParseFloat(rawRelevance, 32)
+	if err != nil {
+		return 0, "", "", fmt.Errorf("%w: %w", service.ErrInvalidCursor, err)
+	}
+	rawType, name, ok := strings.Cut(rawEntry, "/")
+	if !ok {
+		return 0, "", "", fmt.Errorf("%w: missing entry type", service.ErrInvalidCursor)
+	}
+	entryType, err := mapEntryType(rawType)
+	if err != nil {
+		return 0, "", "", fmt.Errorf("%w: %w", service.ErrInvalidCursor, err)
+	}
+	return float32(relevance), entryType, name, nil
+}
+
+// newDeduplicatingSearchFilter returns a stateful RecordFilter that deduplicates
+// search rows by entry type and name according to the merge policy of the registry.
+// With a nil policy it keeps only rows from the highest-priority source (lowest
+// position). SQL must return rows in position-ascending order per entry.
+// A hit is an entry rather than a version, so the union strategy still returns a
+// single hit per entry.
+func newDeduplicatingSearchFilter(policy *mergePolicy) service.RecordFilter {
+	return policy.newFilter(
+		func(record any) (mergeRecord, bool) {
+			r, ok := record.(sqlc.SearchEntriesRow)
+			return mergeRecord{entryType: r.EntryType, name: r.Name, position: r.Position}, ok
+		},
+	)
+}
+
+// entryTypeName converts a sqlc.EntryType to the entry type name used by the service layer.
+// It is the inverse of mapEntryType.
+func entryTypeName(entryType sqlc.EntryType) string {
+	switch entryType {
+	case sqlc.EntryTypeMCP:
+		return service.EntryTypeServer
+	case sqlc.EntryTypeSKILL:
+		return service.EntryTypeSkill
+	case sqlc.EntryTypePLUGIN:
+		return service.EntryTypePlugin
+	default:
+		return strings.ToLower(string(entryType))
+	}
+}
+
+// searchRowToHit maps a sqlc SearchEntriesRow to a service SearchHit.
+func searchRowToHit(row sqlc.SearchEntriesRow) *service.SearchHit {
+	hit := &service.SearchHit{
+		EntryType: entryTypeName(row.EntryType),
+		Name:      row.Name,
+		Version:   row.Version,
+	}
+	if row.Namespace != nil {
+		hit.Namespace = *row.Namespace
+	}
+	if row.Title != nil {
+		hit.Title = *row.Title
+	}
+	if row.Description != nil {
+		hit.Description = *row.Description
+	}
+	if row.UpdatedAt != nil {
+		hit.UpdatedAt = *row.UpdatedAt
+	}
+	return hit
+}
+
+// toPrefixTSQuery converts free text search terms to a tsquery matching the entries that
+// contain every word, or a word starting with it. Any character other than a letter or
+// digit separates words, the same way entry names are split into words when indexed.
+// It returns an empty string when the search has no words.
+func toPrefixTSQuery(search string) string {
+	words := strings.FieldsFunc(strings.ToLower(search), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+// listOrSearchServers lists servers, or runs a full-text search ordered by relevance when
+// a tsquery is given. Search results have the columns of ListServers rows.
+func listOrSearchServers(
+	ctx context.Context, querier sqlc.Querier, tsQuery string, params sqlc.ListServersParams,
+) ([]sqlc.ListServersRow, error) {
+	if tsQuery == "" {
+		return querier.ListServers(ctx, params)
+	}
+
+	rows, err := querier.SearchServers(ctx, sqlc.SearchServersParams{
+		Search:          tsQuery,
+		RegistryID:      params.RegistryID,
+		Name:            params.Name,
+		UpdatedSince:    params.UpdatedSince,
+		Version:         params.Version,
+		IncludeInactive: params.IncludeInactive,
+		CursorName:      params.CursorName,
+		CursorVersion:   params.CursorVersion,
+		Size:            params.Size,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]sqlc.ListServersRow, len(rows))
+	for i, row := range rows {
+		result[i] = sqlc.ListServersRow(row)
+	}
+	return result, nil
+}
+
+// listOrSearchSkills lists skills, or runs a full-text search ordered by relevance when
+// a tsquery is given. Search results have the columns of ListSkills rows.
+func listOrSearchSkills(
+	ctx context.Context, querier sqlc.Querier, tsQuery string, params sqlc.ListSkillsParams,
+) ([]sqlc.ListSkillsRow, error) {
+	if tsQuery == "" {
+		return querier.ListSkills(ctx, params)
+	}
+
+	rows, err := querier.SearchSkills(ctx, sqlc.SearchSkillsParams{
+		Search:        tsQuery,
+		RegistryID:    params.RegistryID,
+		Namespace:     params.Namespace,
+		Name:          params.Name,
+		UpdatedSince:  params.UpdatedSince,
+		CursorName:    params.CursorName,
+		CursorVersion: params.CursorVersion,
+		Size:          params.Size,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]sqlc.ListSkillsRow, len(rows))
+	for i, row := range rows {
+		result[i] = sqlc.ListSkillsRow(row)
+	}
+	return result, nil
+}
+
+// listOrSearchPlugins lists plugins, or runs a full-text search ordered by relevance when
+// a tsquery is given. Search results have the columns of ListPlugins rows.
+func listOrSearchPlugins(
+	ctx context.Context, querier sqlc.Querier, tsQuery string, params sqlc.ListPluginsParams,
+) ([]sqlc.ListPluginsRow, error) {
+	if tsQuery == "" {
+		return querier.ListPlugins(ctx, params)
+	}
+
+	rows, err := querier.SearchPlugins(ctx, sqlc.SearchPluginsParams{
+		Search:        tsQuery,
+		RegistryID:    params.RegistryID,
+		Namespace:     params.Namespace,
+		Name:          params.Name,
+		UpdatedSince:  params.UpdatedSince,
+		CursorName:    params.CursorName,
+		CursorVersion: params.CursorVersion,
+		Size:          params.Size,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]sqlc.ListPluginsRow, len(rows))
+	for i, row := range rows {
+		result[i] = sqlc.ListPluginsRow(row)
+	}
+	return result, nil
+}
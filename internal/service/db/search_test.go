@@ -0,0 +1,214 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+func TestToPrefixTSQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		search   string
+		expected string
+	}{
+		{
+			name:     "single word",
+			search:   "github",
+			expected: "github:*",
+		},
+		{
+			name:     "words are lowercased and all required",
+			search:   "Code Review",
+			expected: "code:* & review:*",
+		},
+		{
+			name:     "name separators split words",
+			search:   "io.github.stacklok/fetch-server_v2",
+			expected: "io:* & github:* & stacklok:* & fetch:* & server:* & v2:*",
+		},
+		{
+			name:     "tsquery operators are not passed through",
+			search:   "a & !b | (c:*)",
+			expected: "a:* & b:* & c:*",
+		},
+		{
+			name:     "no words",
+			search:   "  -- ",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, toPrefixTSQuery(tt.search))
+		})
+	}
+}
+
+func TestSearchEntries(t *testing.T) {
+	t.Parallel()
+
+	svc, cleanup := setupTestService(t)
+	t.Cleanup(cleanup)
+
+	const registryName = "search-registry"
+	createManagedSourceWithRegistry(t, svc, registryName)
+	ctx := context.Background()
+	_, err := svc.pool.Exec(ctx, `UPDATE registry SET claims = '{"org": "acme"}' WHERE name = $1`, registryName)
+	require.NoError(t, err)
+
+	acme := map[string]any{"org": "acme"}
+	_, err = svc.PublishServerVersion(ctx,
+		service.WithServerData(&upstreamv0.ServerJSON{
+			Name:        "com.example/weather-forecast",
+			Description: "Forecasts for any city",
+			Version:     "1.0.0",
+		}),
+		service.WithClaims(acme),
+	)
+	require.NoError(t, err)
+	_, err = svc.PublishSkill(ctx, &service.Skill{
+		Namespace:   "com.example",
+		Name:        "weather-report",
+		Description: "Writes a weather report",
+		Version:     "1.0.0",
+	}, service.WithClaims(acme))
+	require.NoError(t, err)
+	_, err = svc.PublishSkill(ctx, &service.Skill{
+		Namespace:   "com.example",
+		Name:        "calendar",
+		Description: "Plans meetings",
+		Version:     "1.0.0",
+	}, service.WithClaims(acme))
+	require.NoError(t, err)
+	_, err = svc.PublishPlugin(ctx, &service.Plugin{
+		Namespace:   "com.example",
+		Name:        "weather-alerts",
+		Description: "Alerts on severe weather",
+		Version:     "2.0.0",
+	}, service.WithClaims(map[string]any{"org": "acme", "team": "ops"}))
+	require.NoError(t, err)
+
+	hitKeys := func(hits []*service.SearchHit) []string {
+		keys := make([]string, len(hits))
+		for i, hit := range hits {
+			keys[i] = hit.EntryType + ":" + hit.Name
+		}
+		return keys
+	}
+
+	t.Run("hits of every type are paginated together", func(t *testing.T) {
+		t.Parallel()
+
+		first, err := svc.SearchEntries(ctx,
+			service.WithRegistryName(registryName),
+			service.WithSearch("weather"),
+			service.WithLimit(2),
+		)
+		require.NoError(t, err)
+		require.Len(t, first.Hits, 2)
+		require.NotEmpty(t, first.NextCursor)
+
+		second, err := svc.SearchEntries(ctx,
+			service.WithRegistryName(registryName),
+			service.WithSearch("weather"),
+			service.WithLimit(2),
+			service.WithCursor(first.NextCursor),
+		)
+		require.NoError(t, err)
+		require.Len(t, second.Hits, 1)
+		require.Empty(t, second.NextCursor)
+
+		require.ElementsMatch(t, []string{
+			"server:com.example/weather-forecast",
+			"skill:weather-report",
+			"plugin:weather-alerts",
+		}, append(hitKeys(first.Hits), hitKeys(second.Hits)...))
+	})
+
+	t.Run("pagination goes on past a removed cursor entry", func(t *testing.T) {
+		t.Parallel()
+
+		first, err := svc.SearchEntries(ctx,
+			service.WithRegistryName(registryName),
+			service.WithSearch("weather"),
+			service.WithLimit(2),
+		)
+		require.NoError(t, err)
+		relevance, entryType, _, err := decodeSearchCursor(first.NextCursor)
+		require.NoError(t, err)
+
+		// The cursor names an entry that does not exist, at the position of the last hit
+		second, err := svc.SearchEntries(ctx,
+			service.WithRegistryName(registryName),
+			service.WithSearch("weather"),
+			service.WithLimit(2),
+			service.WithCursor(encodeSearchCursor(relevance, entryType, first.Hits[1].Name+"-removed")),
+		)
+		require.NoError(t, err)
+		require.Len(t, second.Hits, 1)
+		require.NotContains(t, hitKeys(first.Hits), hitKeys(second.Hits)[0])
+	})
+
+	t.Run("entries the caller cannot see are filtered out", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := svc.SearchEntries(ctx,
+			service.WithRegistryName(registryName),
+			service.WithSearch("weather"),
+			service.WithClaims(map[string]any{"org": "acme", "team": "eng"}),
+		)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{
+			"server:com.example/weather-forecast",
+			"skill:weather-report",
+		}, hitKeys(result.Hits))
+	})
+
+	t.Run("registry gate applies", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := svc.SearchEntries(ctx,
+			service.WithRegistryName(registryName),
+			service.WithSearch("weather"),
+			service.WithClaims(map[string]any{"org": "contoso"}),
+		)
+		require.ErrorIs(t, err, service.ErrClaimsInsufficient)
+	})
+
+	t.Run("hits describe the latest version", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := svc.SearchEntries(ctx,
+			service.WithRegistryName(registryName),
+			service.WithSearch("meetings"),
+		)
+		require.NoError(t, err)
+		require.Len(t, result.Hits, 1)
+		hit := result.Hits[0]
+		require.Equal(t, service.EntryTypeSkill, hit.EntryType)
+		require.Equal(t, "com.example", hit.Namespace)
+		require.Equal(t, "calendar", hit.Name)
+		require.Equal(t, "1.0.0", hit.Version)
+		require.Equal(t, "Plans meetings", hit.Description)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := svc.SearchEntries(ctx,
+			service.WithRegistryName(registryName),
+			service.WithSearch("weather"),
+			service.WithCursor(service.EncodeCursor("widget", "weather-report")),
+		)
+		require.ErrorIs(t, err, service.ErrInvalidCursor)
+	})
+}
@@ -186,8 +186,8 @@ func TestGetServerVersionClaimsVisibility(t *testing.T) {
 			}
 
 			require.NoError(t, err)
-			require.Equal(t, entryName, result.Name)
-			require.Equal(t, tt.expectDesc, result.Description)
+			require.Equal(t, entryName, result.Server.Name)
+			require.Equal(t, tt.expectDesc, result.Server.Description)
 		})
 	}
 }
@@ -194,8 +194,8 @@ func TestListServerVersionsClaimsVisibility(t *testing.T) {
 			}
 
 			require.Len(t, result, 1)
-			require.Equal(t, entryName, result[0].Name)
-			require.Equal(t, tt.expectDesc, result[0].Description)
+			require.Equal(t, entryName, result[0].Server.Name)
+			require.Equal(t, tt.expectDesc, result[0].Server.Description)
 		})
 	}
 }
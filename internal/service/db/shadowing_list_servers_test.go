@@ -251,8 +251,8 @@ func TestListServersClaimsVisibility(t *testing.T) {
 			}
 
 			require.Len(t, result.Servers, 1)
-			require.Equal(t, entryName, result.Servers[0].Name)
-			require.Equal(t, tt.expectDesc, result.Servers[0].Description)
+			require.Equal(t, entryName, result.Servers[0].Server.Name)
+			require.Equal(t, tt.expectDesc, result.Servers[0].Server.Description)
 		})
 	}
 }
@@ -0,0 +1,144 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/signing"
+)
+
+// verifyEntrySignature verifies the signature attached to the publish of the
+// entry named name into a managed source, against the keys trusted by the
+// signing configuration of the source. Returns nil for an unsigned publish that
+// the source accepts.
+func verifyEntrySignature(
+	source *sqlc.Source, name string, signature *service.EntrySignature,
+) (*signing.Verification, error) {
+	managedCfg, err := parseManagedConfig(source)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := make([]signing.Namespace, 0, len(managedCfg.Signing))
+	for _, cfg := range managedCfg.Signing {
+		namespaces = append(namespaces, signing.Namespace{
+			Prefix:           cfg.Namespace,
+			PublicKeys:       cfg.PublicKeys,
+			RequireSignature: cfg.RequireSignature,
+		})
+	}
+	verifier, err := signing.NewVerifier(namespaces)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing configuration: %w", err)
+	}
+
+	var sig *signing.Signature
+	var payload []byte
+	if signature != nil {
+		if len(signature.Payload) == 0 {
+			return nil, fmt.Errorf("%w: the signed payload is missing", service.ErrInvalidSignature)
+		}
+		sig = &signing.Signature{Format: signature.Format, Value: signature.Value}
+		payload = signature.Payload
+	}
+
+	verification, err := verifier.Verify(name, payload, sig)
+	if errors.Is(err, signing.ErrSignatureRequired) {
+		return nil, fmt.Errorf("%w: %s", service.ErrSignatureRequired, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", service.ErrInvalidSignature, name, err)
+	}
+	return verification, nil
+}
+
+// signedEntryName returns the name of a skill or plugin matched against the
+// namespace prefixes of the signing configuration.
+func signedEntryName(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// marshalVerification serializes a signature verification for storage with a
+// submission. Returns nil for an unsigned publish.
+func marshalVerification(verification *signing.Verification) ([]byte, error) {
+	if verification == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(verification)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize signature verification: %w", err)
+	}
+	return data, nil
+}
+
+// unmarshalVerification deserializes the signature verification stored with a
+// submission. Returns nil for an unsigned publish.
+func unmarshalVerification(data []byte) (*signing.Verification, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var verification signing.Verification
+	if err := json.Unmarshal(data, &verification); err != nil {
+		return nil, fmt.Errorf("failed to parse signature verification: %w", err)
+	}
+	return &verification, nil
+}
+
+// insertEntrySignature records the verified signature of a published entry
+// version. Does nothing for an unsigned publish.
+func insertEntrySignature(
+	ctx context.Context, querier sqlc.Querier, versionID uuid.UUID, verification *signing.Verification,
+) error {
+	if verification == nil {
+		return nil
+	}
+	if err := querier.InsertEntrySignature(ctx, sqlc.InsertEntrySignatureParams{
+		VersionID: versionID,
+		Format:    verification.Format,
+		KeyID:     verification.KeyID,
+		Signature: verification.Signature,
+	}); err != nil {
+		return fmt.Errorf("failed to insert entry signature: %w", err)
+	}
+	return nil
+}
+
+// fetchEntrySignatures returns the verified signatures of the given entry
+// versions, keyed by version ID.
+func fetchEntrySignatures(
+	ctx context.Context, querier sqlc.Querier, versionIDs []uuid.UUID,
+) (map[uuid.UUID]*sqlc.EntrySignature, error) {
+	rows, err := querier.ListEntrySignatures(ctx, versionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list entry signatures: %w", err)
+	}
+	signatures := make(map[uuid.UUID]*sqlc.EntrySignature, len(rows))
+	for i := range rows {
+		signatures[rows[i].VersionID] = &rows[i]
+	}
+	return signatures, nil
+}
+
+// withSignatureMeta reports the verified signature of an entry version in its
+// _meta, replacing any value supplied by the publisher under the same key.
+func withSignatureMeta(meta map[string]any, signature *sqlc.EntrySignature) map[string]any {
+	if signature == nil {
+		delete(meta, service.SignatureMetaKey)
+		return meta
+	}
+	if meta == nil {
+		meta = make(map[string]any)
+	}
+	meta[service.SignatureMetaKey] = service.SignatureVerification{
+		Verified:   true,
+		Format:     signature.Format,
+		KeyID:      signature.KeyID,
+		VerifiedAt: signature.VerifiedAt,
+	}
+	return meta
+}
@@ -0,0 +1,131 @@
+package database
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// createSigningSourceWithRegistry creates a managed source trusting key to sign
+// the entries of the com.example namespace, and a registry linked to it.
+func createSigningSourceWithRegistry(
+	t *testing.T, svc *dbService, name string, key ed25519.PublicKey, requireApproval bool,
+) {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	managedCfg, err := json.Marshal(config.ManagedConfig{
+		RequireApproval: requireApproval,
+		Signing: []config.SigningConfig{
+			{
+				Namespace:        "com.example/",
+				PublicKeys:       []string{string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))},
+				RequireSignature: true,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	createManagedSourceWithRegistry(t, svc, name)
+	_, err = svc.pool.Exec(context.Background(),
+		`UPDATE source SET source_config = $1 WHERE name = $2`, managedCfg, name)
+	require.NoError(t, err)
+}
+
+// signSkill returns the cosign signature of the JSON encoding of skill.
+func signSkill(t *testing.T, key ed25519.PrivateKey, skill *service.Skill) *service.EntrySignature {
+	t.Helper()
+
+	payload, err := json.Marshal(skill)
+	require.NoError(t, err)
+	return &service.EntrySignature{
+		Format:  "cosign",
+		Value:   base64.StdEncoding.EncodeToString(ed25519.Sign(key, payload)),
+		Payload: payload,
+	}
+}
+
+func TestPublishSkill_Signature(t *testing.T) {
+	t.Parallel()
+
+	svc, cleanup := setupTestService(t)
+	t.Cleanup(cleanup)
+
+	const sourceName = "signing-source"
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	createSigningSourceWithRegistry(t, svc, sourceName, pub, false)
+	ctx := context.Background()
+
+	skill := &service.Skill{
+		Namespace: "com.example",
+		Name:      "calendar",
+		Version:   "1.0.0",
+		// Publishers cannot claim a verified signature themselves
+		Meta: map[string]any{service.SignatureMetaKey: map[string]any{"verified": true}},
+	}
+
+	_, err = svc.PublishSkill(ctx, skill)
+	require.ErrorIs(t, err, service.ErrSignatureRequired)
+
+	_, err = svc.PublishSkill(ctx, skill, service.WithSignature(signSkill(t, otherKey, skill)))
+	require.ErrorIs(t, err, service.ErrInvalidSignature)
+
+	tampered := signSkill(t, key, skill)
+	tampered.Payload = []byte(`{"namespace":"com.example","name":"calendar","version":"2.0.0"}`)
+	_, err = svc.PublishSkill(ctx, skill, service.WithSignature(tampered))
+	require.ErrorIs(t, err, service.ErrInvalidSignature)
+
+	_, err = svc.PublishSkill(ctx, skill, service.WithSignature(signSkill(t, key, skill)))
+	require.NoError(t, err)
+
+	published, err := svc.fetchSkillVersionBySource(ctx, "calendar", "1.0.0", sourceName)
+	require.NoError(t, err)
+	verification, ok := published.Meta[service.SignatureMetaKey].(service.SignatureVerification)
+	require.True(t, ok)
+	require.True(t, verification.Verified)
+	require.Equal(t, "cosign", verification.Format)
+	require.Contains(t, verification.KeyID, "sha256:")
+}
+
+func TestEntrySubmissions_ApproveSigned(t *testing.T) {
+	t.Parallel()
+
+	svc, cleanup := setupTestService(t)
+	t.Cleanup(cleanup)
+
+	const sourceName = "signed-approval-source"
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	createSigningSourceWithRegistry(t, svc, sourceName, pub, true)
+
+	skill := &service.Skill{Namespace: "com.example", Name: "calendar", Version: "1.0.0"}
+	_, err = svc.PublishSkill(contextWithSubject("alice"), skill,
+		service.WithSignature(signSkill(t, key, skill)),
+		service.WithClaims(map[string]any{"org": "acme"}),
+	)
+	var pending *service.PendingReviewError
+	require.ErrorAs(t, err, &pending)
+	require.NotNil(t, pending.Submission.Signature)
+	require.True(t, pending.Submission.Signature.Verified)
+
+	_, err = svc.ApproveEntrySubmission(contextWithSubject("bob"), pending.Submission.ID)
+	require.NoError(t, err)
+
+	published, err := svc.fetchSkillVersionBySource(context.Background(), "calendar", "1.0.0", sourceName)
+	require.NoError(t, err)
+	require.Contains(t, published.Meta, service.SignatureMetaKey)
+}
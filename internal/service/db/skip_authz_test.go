@@ -164,7 +164,7 @@ func TestListServers_SkipAuthz(t *testing.T) {
 
 			if tt.expectVisible {
 				require.Len(t, result.Servers, 1)
-				require.Equal(t, entryName, result.Servers[0].Name)
+				require.Equal(t, entryName, result.Servers[0].Server.Name)
 			} else {
 				require.Empty(t, result.Servers)
 			}
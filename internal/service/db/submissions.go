@@ -0,0 +1,529 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+
+	"github.com/stacklok/toolhive-registry-server/internal/auth"
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/db"
+	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/otel"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// ListEntrySubmissions returns the publish submissions held for review, oldest
+// first. Submissions whose claims are not visible to the caller are filtered
+// out. Pagination uses an opaque cursor encoding the submission time and ID of
+// the last returned submission.
+func (s *dbService) ListEntrySubmissions(
+	ctx context.Context, opts ...service.Option,
+) (*service.EntrySubmissionListResponse, error) {
+	ctx, span := s.startSpan(ctx, "dbService.ListEntrySubmissions")
+	defer span.End()
+
+	options := &service.ListEntrySubmissionsOptions{
+		Limit: service.DefaultPageSize,
+	}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			otel.RecordError(span, err)
+			return nil, err
+		}
+	}
+	if options.Limit > service.MaxPageSize {
+		options.Limit = service.MaxPageSize
+	}
+
+	params := sqlc.ListEntrySubmissionsParams{
+		Size: int64(options.Limit + 1),
+	}
+	if options.Status != "" {
+		params.Status = sqlc.NullSubmissionStatus{
+			SubmissionStatus: sqlc.SubmissionStatus(strings.ToUpper(options.Status)),
+			Valid:            true,
+		}
+	}
+	if options.EntryType != "" {
+		entryType, err := mapEntryType(options.EntryType)
+		if err != nil {
+			otel.RecordError(span, err)
+			return nil, err
+		}
+		params.EntryType = sqlc.NullEntryType{EntryType: entryType, Valid: true}
+	}
+	if options.Cursor != "" {
+		submittedAt, id, err := decodeSubmissionCursor(options.Cursor)
+		if err != nil {
+			otel.RecordError(span, err)
+			return nil, err
+		}
+		params.CursorSubmittedAt = &submittedAt
+		params.CursorID = &id
+	}
+
+	claimsFilter := newClaimsFilterWith(
+		ctx, options.JWTClaims,
+		func(record any) ([]byte, bool) {
+			r, ok := record.(*sqlc.EntrySubmission)
+			return r.Claims, ok
+		},
+	)
+	if s.skipAuthz {
+		claimsFilter = nil
+	}
+
+	submissions := []service.EntrySubmission{}
+	var nextCursor string
+	querier := sqlc.New(s.pool)
+	for {
+		rows, err := querier.ListEntrySubmissions(ctx, params)
+		if err != nil {
+			otel.RecordError(span, err)
+			return nil, fmt.Errorf("failed to list entry submissions: %w", err)
+		}
+
+		for i := range rows {
+			row := &rows[i]
+			if claimsFilter != nil {
+				keep, err := claimsFilter(ctx, row)
+				if err != nil {
+					otel.RecordError(span, err)
+					return nil, err
+				}
+				if !keep {
+					continue
+				}
+			}
+			// A visible submission past the page means that there is a next page
+			if len(submissions) == options.Limit {
+				last := submissions[len(submissions)-1]
+				nextCursor = encodeSubmissionCursor(last.SubmittedAt, last.ID)
+				break
+			}
+			submissions = append(submissions, buildEntrySubmission(row))
+		}
+
+		if nextCursor != "" || int64(len(rows)) < params.Size {
+			break
+		}
+		last := rows[len(rows)-1]
+		params.CursorSubmittedAt = &last.SubmittedAt
+		params.CursorID = &last.ID
+	}
+
+	return &service.EntrySubmissionListResponse{
+		Submissions: submissions,
+		NextCursor:  nextCursor,
+	}, nil
+}
+
+// ApproveEntrySubmission publishes the entry of a pending submission into the
+// managed source, in the transaction that records the approval.
+func (s *dbService) ApproveEntrySubmission(
+	ctx context.Context, id string, opts ...service.Option,
+) (*service.EntrySubmission, error) {
+	ctx, span := s.startSpan(ctx, "dbService.ApproveEntrySubmission")
+	defer span.End()
+
+	submission, err := s.reviewEntrySubmission(ctx, id, sqlc.SubmissionStatusAPPROVED, opts)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+	return submission, nil
+}
+
+// RejectEntrySubmission rejects a pending submission. Its entry is never published.
+func (s *dbService) RejectEntrySubmission(
+	ctx context.Context, id string, opts ...service.Option,
+) (*service.EntrySubmission, error) {
+	ctx, span := s.startSpan(ctx, "dbService.RejectEntrySubmission")
+	defer span.End()
+
+	submission, err := s.reviewEntrySubmission(ctx, id, sqlc.SubmissionStatusREJECTED, opts)
+	if err != nil {
+		otel.RecordError(span, err)
+		return nil, err
+	}
+	return submission, nil
+}
+
+// reviewEntrySubmission records the decision of the caller on a pending
+// submission, publishing its entry when the decision is an approval.
+//
+//nolint:gocyclo
+func (s *dbService) reviewEntrySubmission(
+	ctx context.Context, id string, decision sqlc.SubmissionStatus, opts []service.Option,
+) (*service.EntrySubmission, error) {
+	start := time.Now()
+
+	options := &service.ReviewEntrySubmissionOptions{}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return nil, fmt.Errorf("invalid option: %w", err)
+		}
+	}
+
+	submissionID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", service.ErrSubmissionNotFound, id)
+	}
+
+	gateClaims := options.JWTClaims
+	if s.skipAuthz {
+		gateClaims = nil
+	}
+
+	// Pin the OCI packages of the entry of an approved submission and scan it before the transaction
+	var entry *service.PortableEntry
+	var checks *publishChecks
+	if decision == sqlc.SubmissionStatusAPPROVED {
+		entry, checks, err = s.prepareSubmittedEntry(ctx, submissionID, gateClaims)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.Serializable,
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		err := tx.Rollback(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.WarnContext(ctx, "Failed to rollback transaction", "error", err)
+		}
+	}()
+
+	querier := sqlc.New(tx)
+
+	row, err := querier.GetEntrySubmissionForUpdate(ctx, submissionID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", service.ErrSubmissionNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get entry submission: %w", err)
+	}
+
+	if err := checkReviewable(ctx, gateClaims, &row); err != nil {
+		return nil, err
+	}
+
+	if decision == sqlc.SubmissionStatusAPPROVED {
+		source, err := getManagedSource(ctx, querier)
+		if err != nil {
+			return nil, err
+		}
+		// Approving publishes into the source, which requires the same access as publishing
+		if err := validateClaimsVisibleBytes(ctx, gateClaims, source.Claims); err != nil {
+			return nil, err
+		}
+		if err := s.insertSubmittedEntry(ctx, querier, source, &row, entry, checks); err != nil {
+			return nil, err
+		}
+	}
+
+	params := sqlc.UpdateEntrySubmissionReviewParams{
+		ID:         submissionID,
+		Status:     decision,
+		ReviewedBy: submissionIdentity(ctx),
+	}
+	if options.Comment != "" {
+		params.ReviewComment = &options.Comment
+	}
+	reviewed, err := querier.UpdateEntrySubmissionReview(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update entry submission: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Entry submission reviewed",
+		"duration_ms", time.Since(start).Milliseconds(),
+		"submission", id,
+		"decision", strings.ToLower(string(decision)),
+		"entry_type", entryTypeName(reviewed.EntryType),
+		"name", reviewed.Name,
+		"version", reviewed.Version,
+		"request_id", middleware.GetReqID(ctx))
+
+	submission := buildEntrySubmission(&reviewed)
+	return &submission, nil
+}
+
+// checkReviewable verifies that the caller may decide on a submission: that
+// they can see it, that it is pending and that they did not submit it.
+func checkReviewable(ctx context.Context, gateClaims map[string]any, row *sqlc.EntrySubmission) error {
+	// Reviewers may only decide on the submissions they can see
+	if err := validateClaimsVisibleBytes(ctx, gateClaims, row.Claims); err != nil {
+		return err
+	}
+	if row.Status != sqlc.SubmissionStatusPENDING {
+		return fmt.Errorf("%w: %s is %s", service.ErrSubmissionNotPending, row.ID, strings.ToLower(string(row.Status)))
+	}
+
+	reviewer := submissionIdentity(ctx)
+	if reviewer != nil && row.SubmittedBy != nil && *reviewer == *row.SubmittedBy {
+		return service.ErrSubmissionSelfReview
+	}
+	return nil
+}
+
+// prepareSubmittedEntry decodes the entry of a submission approved by the
+// caller and runs the checks of its publish that are kept out of the
+// transaction of the review. The entry is pinned and scanned again, as the
+// tags of its packages and the entry policy may have changed since it was
+// submitted.
+func (s *dbService) prepareSubmittedEntry(
+	ctx context.Context, submissionID uuid.UUID, gateClaims map[string]any,
+) (*service.PortableEntry, *publishChecks, error) {
+	querier := sqlc.New(s.pool)
+
+	row, err := querier.GetEntrySubmission(ctx, submissionID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, fmt.Errorf("%w: %s", service.ErrSubmissionNotFound, submissionID)
+		}
+		return nil, nil, fmt.Errorf("failed to get entry submission: %w", err)
+	}
+	if err := checkReviewable(ctx, gateClaims, &row); err != nil {
+		return nil, nil, err
+	}
+	entry, err := decodeSubmittedEntry(&row)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s.digestPinning == nil && s.entryPolicy == nil {
+		return entry, &publishChecks{}, nil
+	}
+
+	source, err := getManagedSource(ctx, querier)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := validateClaimsVisibleBytes(ctx, gateClaims, source.Claims); err != nil {
+		return nil, nil, err
+	}
+	checks, err := s.runPublishChecks(ctx, source, entry)
+	if err != nil {
+		return nil, nil, err
+	}
+	return entry, checks, nil
+}
+
+// decodeSubmittedEntry decodes the entry version held by a submission
+func decodeSubmittedEntry(row *sqlc.EntrySubmission) (*service.PortableEntry, error) {
+	entry := &service.PortableEntry{}
+	var err error
+	switch row.EntryType {
+	case sqlc.EntryTypeMCP:
+		entry.Server = &upstreamv0.ServerJSON{}
+		err = json.Unmarshal(row.Payload, entry.Server)
+	case sqlc.EntryTypeSKILL:
+		entry.Skill = &service.Skill{}
+		err = json.Unmarshal(row.Payload, entry.Skill)
+	case sqlc.EntryTypePLUGIN:
+		entry.Plugin = &service.Plugin{}
+		err = json.Unmarshal(row.Payload, entry.Plugin)
+	default:
+		return nil, fmt.Errorf("%w: %s", service.ErrInvalidEntryType, row.EntryType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse submitted %s: %w", entryTypeName(row.EntryType), err)
+	}
+	return entry, nil
+}
+
+// insertSubmittedEntry publishes the entry of an approved submission into the
+// managed source, with the claims and the verified signature it was submitted
+// with, and the results of the checks run before the transaction.
+func (s *dbService) insertSubmittedEntry(
+	ctx context.Context,
+	querier *sqlc.Queries,
+	source *sqlc.Source,
+	row *sqlc.EntrySubmission,
+	entry *service.PortableEntry,
+	checks *publishChecks,
+) error {
+	verification, err := unmarshalVerification(row.Signature)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case entry.Server != nil:
+		return s.insertPublishedServer(ctx, querier, source, entry.Server, row.Claims, verification, checks.scan, checks.digests)
+	case entry.Skill != nil:
+		return s.insertPublishedSkill(ctx, querier, source, entry.Skill, row.Claims, verification, checks.scan, checks.digests)
+	default:
+		return s.insertPublishedPlugin(ctx, querier, source, entry.Plugin, row.Claims, verification, checks.scan, checks.digests)
+	}
+}
+
+// submitEntry holds a publish into the managed source for review, in the
+// transaction of querier. Publishes that could not be approved, because the
+// version already exists or the claims do not match those of the entry, are
+// refused right away.
+func (*dbService) submitEntry(
+	ctx context.Context, querier *sqlc.Queries, params sqlc.InsertEntrySubmissionParams, entry any,
+) (*service.EntrySubmission, error) {
+	existing, err := querier.GetRegistryEntryByName(ctx, sqlc.GetRegistryEntryByNameParams{
+		SourceID:  params.SourceID,
+		EntryType: params.EntryType,
+		Name:      params.Name,
+	})
+	if err == nil {
+		if err := checkClaimConsistency(params.Claims, existing.Claims); err != nil {
+			return nil, err
+		}
+		entryVersions, err := querier.ListEntryVersions(ctx, existing.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list entry versions: %w", err)
+		}
+		for _, v := range entryVersions {
+			if v.Version == params.Version {
+				return nil, fmt.Errorf("%w: %s %s", service.ErrVersionAlreadyExists, params.Name, params.Version)
+			}
+		}
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get registry entry: %w", err)
+	}
+
+	params.Payload, err = json.Marshal(entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize submitted entry: %w", err)
+	}
+	params.SubmittedBy = submissionIdentity(ctx)
+
+	row, err := querier.InsertEntrySubmission(ctx, params)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, fmt.Errorf("%w: %s %s is already pending review",
+				service.ErrVersionAlreadyExists, params.Name, params.Version)
+		}
+		return nil, fmt.Errorf("failed to insert entry submission: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Entry submitted for review",
+		"submission", row.ID,
+		"entry_type", entryTypeName(row.EntryType),
+		"name", row.Name,
+		"version", row.Version,
+		"request_id", middleware.GetReqID(ctx))
+
+	submission := buildEntrySubmission(&row)
+	return &submission, nil
+}
+
+// requiresApproval reports whether the publishes into a managed source are held
+// for review, as set by the requireApproval field of its configuration.
+func requiresApproval(source *sqlc.Source) (bool, error) {
+	managedCfg, err := parseManagedConfig(source)
+	if err != nil {
+		return false, err
+	}
+	return managedCfg.RequireApproval, nil
+}
+
+// parseManagedConfig parses the configuration of a managed source.
+func parseManagedConfig(source *sqlc.Source) (*config.ManagedConfig, error) {
+	var managedCfg config.ManagedConfig
+	if len(source.SourceConfig) == 0 {
+		return &managedCfg, nil
+	}
+	if err := json.Unmarshal(source.SourceConfig, &managedCfg); err != nil {
+		return nil, fmt.Errorf("failed to parse managed source config: %w", err)
+	}
+	return &managedCfg, nil
+}
+
+// submissionIdentity returns the identity of the caller recorded as the
+// submitter or reviewer of a submission: the subject of their token, or their
+// display name when the token has no subject. Returns nil for anonymous callers.
+func submissionIdentity(ctx context.Context) *string {
+	sub, user := auth.IdentityFromContext(ctx)
+	if sub == "" {
+		sub = user
+	}
+	if sub == "" {
+		return nil
+	}
+	return &sub
+}
+
+// encodeSubmissionCursor encodes the position of a submission into a pagination cursor
+func encodeSubmissionCursor(submittedAt time.Time, id string) string {
+	return service.EncodeCursor(submittedAt.UTC().Format(time.RFC3339Nano), id)
+}
+
+// decodeSubmissionCursor decodes a pagination cursor created by encodeSubmissionCursor
+func decodeSubmissionCursor(cursor string) (time.Time, uuid.UUID, error) {
+	rawSubmittedAt, rawID, err := service.DecodeCursor(cursor)
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("%w: %w", service.ErrInvalidCursor, err)
+	}
+	submittedAt, err := time.Parse(time.RFC3339Nano, rawSubmittedAt)
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("%w: %w", service.ErrInvalidCursor, err)
+	}
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("%w: %w", service.ErrInvalidCursor, err)
+	}
+	return submittedAt, id, nil
+}
+
+// buildEntrySubmission converts a database entry submission to its service representation
+func buildEntrySubmission(row *sqlc.EntrySubmission) service.EntrySubmission {
+	submission := service.EntrySubmission{
+		ID:          row.ID.String(),
+		EntryType:   entryTypeName(row.EntryType),
+		Name:        row.Name,
+		Version:     row.Version,
+		Status:      strings.ToLower(string(row.Status)),
+		Claims:      db.DeserializeClaims(row.Claims),
+		Entry:       row.Payload,
+		SubmittedAt: row.SubmittedAt,
+		ReviewedAt:  row.ReviewedAt,
+	}
+	if row.Namespace != nil {
+		submission.Namespace = *row.Namespace
+	}
+	if row.SubmittedBy != nil {
+		submission.SubmittedBy = *row.SubmittedBy
+	}
+	if row.ReviewedBy != nil {
+		submission.ReviewedBy = *row.ReviewedBy
+	}
+	if row.ReviewComment != nil {
+		submission.ReviewComment = *row.ReviewComment
+	}
+	// The signature was verified when the entry was submitted
+	if verification, err := unmarshalVerification(row.Signature); err == nil && verification != nil {
+		submission.Signature = &service.SignatureVerification{
+			Verified:   true,
+			Format:     verification.Format,
+			KeyID:      verification.KeyID,
+			VerifiedAt: row.SubmittedAt,
+		}
+	}
+	return submission
+}
@@ -0,0 +1,252 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/auth"
+	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+func TestRequiresApproval(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		sourceConfig []byte
+		want         bool
+		wantErr      bool
+	}{
+		{name: "no config", sourceConfig: nil, want: false},
+		{name: "empty config", sourceConfig: []byte(`{}`), want: false},
+		{name: "approval required", sourceConfig: []byte(`{"requireApproval": true}`), want: true},
+		{name: "approval not required", sourceConfig: []byte(`{"requireApproval": false}`), want: false},
+		{name: "malformed config", sourceConfig: []byte(`{`), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := requiresApproval(&sqlc.Source{SourceConfig: tt.sourceConfig})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// createApprovalSourceWithRegistry creates a managed source requiring approval
+// and a registry linked to it.
+func createApprovalSourceWithRegistry(t *testing.T, svc *dbService, name string) {
+	t.Helper()
+
+	createManagedSourceWithRegistry(t, svc, name)
+	_, err := svc.pool.Exec(context.Background(),
+		`UPDATE source SET source_config = '{"requireApproval": true}' WHERE name = $1`, name)
+	require.NoError(t, err)
+}
+
+// contextWithSubject returns a context carrying the JWT claims of a caller
+// identified by sub.
+func contextWithSubject(sub string) context.Context {
+	return auth.ContextWithClaims(context.Background(), jwt.MapClaims{"sub": sub})
+}
+
+func TestEntrySubmissions_Approve(t *testing.T) {
+	t.Parallel()
+
+	svc, cleanup := setupTestService(t)
+	t.Cleanup(cleanup)
+
+	const sourceName = "approval-source"
+	createApprovalSourceWithRegistry(t, svc, sourceName)
+	alice := contextWithSubject("alice")
+	bob := contextWithSubject("bob")
+
+	server := &upstreamv0.ServerJSON{
+		Name:        "com.example/weather",
+		Description: "Weather forecasts",
+		Version:     "1.0.0",
+	}
+	_, err := svc.PublishServerVersion(alice,
+		service.WithServerData(server),
+		service.WithClaims(map[string]any{"org": "acme"}),
+	)
+	var pending *service.PendingReviewError
+	require.ErrorAs(t, err, &pending)
+	require.ErrorIs(t, err, service.ErrPublishPendingReview)
+	require.Equal(t, service.SubmissionStatusPending, pending.Submission.Status)
+	require.Equal(t, service.EntryTypeServer, pending.Submission.EntryType)
+	require.Equal(t, "alice", pending.Submission.SubmittedBy)
+
+	// The entry is not published until approved
+	_, err = svc.fetchServerVersionBySource(context.Background(), server.Name, server.Version, sourceName)
+	require.ErrorIs(t, err, service.ErrNotFound)
+
+	// The same version cannot be submitted twice
+	_, err = svc.PublishServerVersion(alice,
+		service.WithServerData(server),
+		service.WithClaims(map[string]any{"org": "acme"}),
+	)
+	require.ErrorIs(t, err, service.ErrVersionAlreadyExists)
+
+	list, err := svc.ListEntrySubmissions(context.Background(),
+		service.WithSubmissionStatus(service.SubmissionStatusPending))
+	require.NoError(t, err)
+	require.Len(t, list.Submissions, 1)
+	require.Equal(t, pending.Submission.ID, list.Submissions[0].ID)
+	require.Equal(t, map[string]any{"org": "acme"}, list.Submissions[0].Claims)
+
+	// Submitters cannot review their own submissions
+	_, err = svc.ApproveEntrySubmission(alice, pending.Submission.ID)
+	require.ErrorIs(t, err, service.ErrSubmissionSelfReview)
+
+	approved, err := svc.ApproveEntrySubmission(bob, pending.Submission.ID, service.WithReviewComment("LGTM"))
+	require.NoError(t, err)
+	require.Equal(t, service.SubmissionStatusApproved, approved.Status)
+	require.Equal(t, "bob", approved.ReviewedBy)
+	require.Equal(t, "LGTM", approved.ReviewComment)
+	require.NotNil(t, approved.ReviewedAt)
+
+	published, err := svc.fetchServerVersionBySource(context.Background(), server.Name, server.Version, sourceName)
+	require.NoError(t, err)
+	require.Equal(t, "Weather forecasts", published.Description)
+
+	_, err = svc.RejectEntrySubmission(bob, pending.Submission.ID, service.WithReviewComment("too late"))
+	require.ErrorIs(t, err, service.ErrSubmissionNotPending)
+
+	// A published version cannot be submitted again
+	_, err = svc.PublishServerVersion(alice,
+		service.WithServerData(server),
+		service.WithClaims(map[string]any{"org": "acme"}),
+	)
+	require.ErrorIs(t, err, service.ErrVersionAlreadyExists)
+}
+
+func TestEntrySubmissions_Reject(t *testing.T) {
+	t.Parallel()
+
+	svc, cleanup := setupTestService(t)
+	t.Cleanup(cleanup)
+
+	const sourceName = "reject-source"
+	createApprovalSourceWithRegistry(t, svc, sourceName)
+	alice := contextWithSubject("alice")
+	bob := contextWithSubject("bob")
+
+	_, err := svc.PublishSkill(alice, &service.Skill{
+		Namespace:   "com.example",
+		Name:        "calendar",
+		Description: "Plans meetings",
+		Version:     "1.0.0",
+	}, service.WithClaims(map[string]any{"org": "acme"}))
+	var pending *service.PendingReviewError
+	require.ErrorAs(t, err, &pending)
+	require.Equal(t, "com.example", pending.Submission.Namespace)
+
+	rejected, err := svc.RejectEntrySubmission(bob, pending.Submission.ID, service.WithReviewComment("missing license"))
+	require.NoError(t, err)
+	require.Equal(t, service.SubmissionStatusRejected, rejected.Status)
+	require.Equal(t, "missing license", rejected.ReviewComment)
+
+	_, err = svc.fetchSkillVersionBySource(context.Background(), "calendar", "1.0.0", sourceName)
+	require.ErrorIs(t, err, service.ErrNotFound)
+
+	// A rejected version can be submitted again
+	_, err = svc.PublishSkill(alice, &service.Skill{
+		Namespace: "com.example",
+		Name:      "calendar",
+		Version:   "1.0.0",
+	}, service.WithClaims(map[string]any{"org": "acme"}))
+	require.ErrorAs(t, err, &pending)
+
+	pendingList, err := svc.ListEntrySubmissions(context.Background(),
+		service.WithSubmissionStatus(service.SubmissionStatusPending),
+		service.WithEntryType(service.EntryTypeSkill),
+	)
+	require.NoError(t, err)
+	require.Len(t, pendingList.Submissions, 1)
+
+	rejectedList, err := svc.ListEntrySubmissions(context.Background(),
+		service.WithSubmissionStatus(service.SubmissionStatusRejected),
+	)
+	require.NoError(t, err)
+	require.Len(t, rejectedList.Submissions, 1)
+	require.Equal(t, rejected.ID, rejectedList.Submissions[0].ID)
+}
+
+func TestEntrySubmissions_Visibility(t *testing.T) {
+	t.Parallel()
+
+	svc, cleanup := setupTestService(t)
+	t.Cleanup(cleanup)
+
+	createApprovalSourceWithRegistry(t, svc, "visibility-source")
+	ctx := context.Background()
+
+	for _, name := range []string{"com.example/a", "com.example/b", "com.example/c"} {
+		_, err := svc.PublishServerVersion(ctx,
+			service.WithServerData(&upstreamv0.ServerJSON{Name: name, Version: "1.0.0"}),
+			service.WithClaims(map[string]any{"org": "acme"}),
+		)
+		require.ErrorIs(t, err, service.ErrPublishPendingReview)
+	}
+	_, err := svc.PublishServerVersion(ctx,
+		service.WithServerData(&upstreamv0.ServerJSON{Name: "com.example/ops", Version: "1.0.0"}),
+		service.WithClaims(map[string]any{"org": "acme", "team": "ops"}),
+	)
+	var opsPending *service.PendingReviewError
+	require.ErrorAs(t, err, &opsPending)
+
+	t.Run("pages skip the submissions the caller cannot see", func(t *testing.T) {
+		t.Parallel()
+
+		eng := map[string]any{"org": "acme", "team": "eng"}
+		first, err := svc.ListEntrySubmissions(ctx, service.WithLimit(2), service.WithJWTClaims(eng))
+		require.NoError(t, err)
+		require.Len(t, first.Submissions, 2)
+		require.NotEmpty(t, first.NextCursor)
+
+		second, err := svc.ListEntrySubmissions(ctx,
+			service.WithLimit(2),
+			service.WithCursor(first.NextCursor),
+			service.WithJWTClaims(eng),
+		)
+		require.NoError(t, err)
+		require.Len(t, second.Submissions, 1)
+		require.Equal(t, "com.example/c", second.Submissions[0].Name)
+		require.Empty(t, second.NextCursor)
+	})
+
+	t.Run("reviewers cannot decide on submissions they cannot see", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := svc.ApproveEntrySubmission(ctx, opsPending.Submission.ID,
+			service.WithJWTClaims(map[string]any{"org": "acme", "team": "eng"}))
+		require.ErrorIs(t, err, service.ErrClaimsInsufficient)
+	})
+
+	t.Run("unknown submission", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := svc.RejectEntrySubmission(ctx, "not-a-uuid")
+		require.ErrorIs(t, err, service.ErrSubmissionNotFound)
+		_, err = svc.RejectEntrySubmission(ctx, "0b6f1a52-6a4e-4a53-9d0c-5c1e2f9f7a10")
+		require.ErrorIs(t, err, service.ErrSubmissionNotFound)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := svc.ListEntrySubmissions(ctx, service.WithCursor("next"))
+		require.ErrorIs(t, err, service.ErrInvalidCursor)
+	})
+}
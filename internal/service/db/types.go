@@ -3,6 +3,7 @@ package database
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/aws/smithy-go/ptr"
@@ -37,6 +38,10 @@ type helper struct {
 	RepositoryID        *string
 	RepositorySubfolder *string
 	RepositoryType      *string
+	Status              sqlc.ServerStatus
+	StatusReason        *string
+	ReplacedBy          *string
+	StatusChangedAt     *time.Time
 	Claims              []byte
 	SourceID            uuid.UUID
 	Position            int32
@@ -61,6 +66,10 @@ func listServersRowToHelper(
 		RepositoryID:        dbServer.RepositoryID,
 		RepositorySubfolder: dbServer.RepositorySubfolder,
 		RepositoryType:      dbServer.RepositoryType,
+		Status:              dbServer.Status,
+		StatusReason:        dbServer.StatusReason,
+		ReplacedBy:          dbServer.ReplacedBy,
+		StatusChangedAt:     dbServer.StatusChangedAt,
 		Claims:              dbServer.Claims,
 		Position:            dbServer.Position,
 	}
@@ -85,6 +94,10 @@ func getServerVersionRowToHelper(
 		RepositoryID:        dbServer.RepositoryID,
 		RepositorySubfolder: dbServer.RepositorySubfolder,
 		RepositoryType:      dbServer.RepositoryType,
+		Status:              dbServer.Status,
+		StatusReason:        dbServer.StatusReason,
+		ReplacedBy:          dbServer.ReplacedBy,
+		StatusChangedAt:     dbServer.StatusChangedAt,
 		Claims:              dbServer.Claims,
 		SourceID:            dbServer.SourceID,
 		Position:            dbServer.Position,
@@ -110,6 +123,10 @@ func getServerVersionBySourceNameRowToHelper(
 		RepositoryID:        dbServer.RepositoryID,
 		RepositorySubfolder: dbServer.RepositorySubfolder,
 		RepositoryType:      dbServer.RepositoryType,
+		Status:              dbServer.Status,
+		StatusReason:        dbServer.StatusReason,
+		ReplacedBy:          dbServer.ReplacedBy,
+		StatusChangedAt:     dbServer.StatusChangedAt,
 		Claims:              dbServer.Claims,
 		Position:            dbServer.Position,
 	}
@@ -119,6 +136,10 @@ func helperToServer(
 	dbServer helper,
 	packages []sqlc.ListServerPackagesRow,
 	remotes []sqlc.McpServerRemote,
+	icons []sqlc.McpServerIcon,
+	signature *sqlc.EntrySignature,
+	scan *sqlc.EntryScan,
+	digests []sqlc.PackageDigest,
 ) (upstreamv0.ServerJSON, error) {
 	server := upstreamv0.ServerJSON{
 		Schema:      "https://static.modelcontextprotocol.io/schemas/2025-12-11/server.schema.json",
@@ -129,6 +150,7 @@ func helperToServer(
 		WebsiteURL:  ptr.ToString(dbServer.Website),
 		Packages:    toPackages(packages),
 		Remotes:     toRemotes(remotes),
+		Icons:       toIcons(icons),
 	}
 
 	if dbServer.RepositoryUrl != nil {
@@ -153,10 +175,55 @@ func helperToServer(
 			return upstreamv0.ServerJSON{}, fmt.Errorf("failed to unmarshal server meta: %w", err)
 		}
 	}
+	server.Meta.PublisherProvided = withSignatureMeta(server.Meta.PublisherProvided, signature)
+	server.Meta.PublisherProvided = withScanMeta(server.Meta.PublisherProvided, scan)
+	server.Meta.PublisherProvided = withDigestsMeta(server.Meta.PublisherProvided, digests)
 
 	return server, nil
 }
 
+// helperToResponseMeta builds the registry metadata of a server version. Yanked
+// versions are reported as deleted, the closest upstream status, so that
+// registries syncing from this one drop them.
+func helperToResponseMeta(dbServer helper) upstreamv0.ResponseMeta {
+	official := &upstreamv0.RegistryExtensions{
+		Status:   model.StatusActive,
+		IsLatest: dbServer.IsLatest,
+	}
+	if dbServer.CreatedAt != nil {
+		official.PublishedAt = *dbServer.CreatedAt
+		official.StatusChangedAt = *dbServer.CreatedAt
+	}
+	if dbServer.UpdatedAt != nil {
+		official.UpdatedAt = *dbServer.UpdatedAt
+	}
+	if dbServer.StatusChangedAt != nil {
+		official.StatusChangedAt = *dbServer.StatusChangedAt
+	}
+
+	switch dbServer.Status {
+	case sqlc.ServerStatusDEPRECATED:
+		official.Status = model.StatusDeprecated
+	case sqlc.ServerStatusYANKED:
+		official.Status = model.StatusDeleted
+	default:
+		return upstreamv0.ResponseMeta{Official: official}
+	}
+
+	message := ptr.ToString(dbServer.StatusReason)
+	if replacedBy := ptr.ToString(dbServer.ReplacedBy); replacedBy != "" {
+		if message != "" {
+			message += " "
+		}
+		message += fmt.Sprintf("(replaced by version %s)", replacedBy)
+	}
+	if message != "" {
+		official.StatusMessage = &message
+	}
+
+	return upstreamv0.ResponseMeta{Official: official}
+}
+
 func toPackages(
 	packages []sqlc.ListServerPackagesRow,
 ) []model.Package {
@@ -196,6 +263,27 @@ func toRemotes(
 	return result
 }
 
+// toIcons converts the stored icons of a server version; a version without
+// icons has none so that the field is omitted.
+func toIcons(
+	icons []sqlc.McpServerIcon,
+) []model.Icon {
+	if len(icons) == 0 {
+		return nil
+	}
+	result := make([]model.Icon, len(icons))
+	for i, icon := range icons {
+		result[i] = model.Icon{
+			Src:   icon.SourceUri,
+			Theme: ptr.String(strings.ToLower(string(icon.Theme))),
+		}
+		if icon.MimeType != "" {
+			result[i].MimeType = ptr.String(icon.MimeType)
+		}
+	}
+	return result
+}
+
 func toKeyValueInputs(
 	jsonData []byte,
 ) []model.KeyValueInput {
@@ -2,14 +2,17 @@ package database
 
 import (
 	"testing"
+	"time"
 
 	"github.com/aws/smithy-go/ptr"
 	"github.com/google/uuid"
 	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
 )
 
 func TestHelperToServer(t *testing.T) {
@@ -20,6 +23,11 @@ func TestHelperToServer(t *testing.T) {
 		dbServer    helper
 		packages    []sqlc.ListServerPackagesRow
 		remotes     []sqlc.McpServerRemote
+		icons       []sqlc.McpServerIcon
+		signature   *sqlc.EntrySignature
+		scan        *sqlc.EntryScan
+		digests     []sqlc.PackageDigest
+		wantIcons   []model.Icon
 		wantName    string
 		wantVersion string
 		wantDesc    string
@@ -78,6 +86,119 @@ func TestHelperToServer(t *testing.T) {
 			wantVersion: "1.0.0",
 			wantMetaVal: map[string]any{"category": "tools"},
 		},
+		{
+			name: "server with verified signature",
+			dbServer: helper{
+				ID:         uuid.New(),
+				Name:       "signed-server",
+				Version:    "1.0.0",
+				ServerMeta: []byte(`{"category":"tools"}`),
+			},
+			signature: &sqlc.EntrySignature{
+				Format:     "jws",
+				KeyID:      "sha256:abc",
+				VerifiedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			wantName:    "signed-server",
+			wantVersion: "1.0.0",
+			wantMetaVal: map[string]any{
+				"category": "tools",
+				service.SignatureMetaKey: service.SignatureVerification{
+					Verified:   true,
+					Format:     "jws",
+					KeyID:      "sha256:abc",
+					VerifiedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			name: "publisher cannot claim a verified signature",
+			dbServer: helper{
+				ID:         uuid.New(),
+				Name:       "forged-server",
+				Version:    "1.0.0",
+				ServerMeta: []byte(`{"category":"tools","io.github.stacklok/signature":{"verified":true}}`),
+			},
+			wantName:    "forged-server",
+			wantVersion: "1.0.0",
+			wantMetaVal: map[string]any{"category": "tools"},
+		},
+		{
+			name: "server with scan findings",
+			dbServer: helper{
+				ID:         uuid.New(),
+				Name:       "scanned-server",
+				Version:    "1.0.0",
+				ServerMeta: []byte(`{"category":"tools","io.github.stacklok/scan":{"verdict":"allow"}}`),
+			},
+			scan: &sqlc.EntryScan{
+				Verdict:   "annotate",
+				Findings:  []byte(`[{"scanner":"osv","id":"CVE-1","severity":"high","message":"vulnerable"}]`),
+				ScannedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			wantName:    "scanned-server",
+			wantVersion: "1.0.0",
+			wantMetaVal: map[string]any{
+				"category": "tools",
+				service.ScanMetaKey: &service.EntryScanResult{
+					Verdict:   "annotate",
+					Findings:  []service.ScanFinding{{Scanner: "osv", ID: "CVE-1", Severity: "high", Message: "vulnerable"}},
+					ScannedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			name: "publisher cannot claim a scan",
+			dbServer: helper{
+				ID:         uuid.New(),
+				Name:       "forged-scan-server",
+				Version:    "1.0.0",
+				ServerMeta: []byte(`{"category":"tools","io.github.stacklok/scan":{"verdict":"allow"}}`),
+			},
+			wantName:    "forged-scan-server",
+			wantVersion: "1.0.0",
+			wantMetaVal: map[string]any{"category": "tools"},
+		},
+		{
+			name: "server with drifted package digest",
+			dbServer: helper{
+				ID:         uuid.New(),
+				Name:       "pinned-server",
+				Version:    "1.0.0",
+				ServerMeta: []byte(`{"category":"tools","io.github.stacklok/digests":[]}`),
+			},
+			digests: []sqlc.PackageDigest{{
+				Identifier:     "ghcr.io/example/server:1.0.0",
+				Digest:         "sha256:bbbb",
+				PreviousDigest: ptr.String("sha256:aaaa"),
+				ResolvedAt:     time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+				DriftedAt:      ptr.Time(time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)),
+			}},
+			wantName:    "pinned-server",
+			wantVersion: "1.0.0",
+			wantMetaVal: map[string]any{
+				"category": "tools",
+				service.DigestsMetaKey: []service.PackageDigest{{
+					Identifier:     "ghcr.io/example/server:1.0.0",
+					Digest:         "sha256:bbbb",
+					PreviousDigest: "sha256:aaaa",
+					ResolvedAt:     time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+					DriftedAt:      ptr.Time(time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)),
+				}},
+			},
+		},
+		{
+			name: "publisher cannot claim package digests",
+			dbServer: helper{
+				ID:         uuid.New(),
+				Name:       "forged-digests-server",
+				Version:    "1.0.0",
+				ServerMeta: []byte(`{"category":"tools","io.github.stacklok/digests":[]}`),
+			},
+			wantName:    "forged-digests-server",
+			wantVersion: "1.0.0",
+			wantMetaVal: map[string]any{"category": "tools"},
+		},
 		{
 			name: "server with invalid meta JSON returns error",
 			dbServer: helper{
@@ -115,13 +236,31 @@ func TestHelperToServer(t *testing.T) {
 			wantName:    "pkg-server",
 			wantVersion: "1.0.0",
 		},
+		{
+			name: "server with icons",
+			dbServer: helper{
+				ID:      uuid.New(),
+				Name:    "icon-server",
+				Version: "1.0.0",
+			},
+			icons: []sqlc.McpServerIcon{
+				{SourceUri: "https://example.com/dark.svg", MimeType: "image/svg+xml", Theme: sqlc.IconThemeDARK},
+				{SourceUri: "https://example.com/light.png", Theme: sqlc.IconThemeLIGHT},
+			},
+			wantIcons: []model.Icon{
+				{Src: "https://example.com/dark.svg", MimeType: ptr.String("image/svg+xml"), Theme: ptr.String("dark")},
+				{Src: "https://example.com/light.png", Theme: ptr.String("light")},
+			},
+			wantName:    "icon-server",
+			wantVersion: "1.0.0",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			got, err := helperToServer(tt.dbServer, tt.packages, tt.remotes)
+			got, err := helperToServer(tt.dbServer, tt.packages, tt.remotes, tt.icons, tt.signature, tt.scan, tt.digests)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -156,6 +295,72 @@ func TestHelperToServer(t *testing.T) {
 			if tt.remotes != nil {
 				assert.Len(t, got.Remotes, len(tt.remotes))
 			}
+			assert.Equal(t, tt.wantIcons, got.Icons)
+		})
+	}
+}
+
+func TestHelperToResponseMeta(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	changedAt := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		dbServer          helper
+		wantStatus        model.Status
+		wantMessage       *string
+		wantStatusChanged time.Time
+	}{
+		{
+			name: "active version",
+			dbServer: helper{
+				Status:    sqlc.ServerStatusACTIVE,
+				CreatedAt: &createdAt,
+				IsLatest:  true,
+			},
+			wantStatus:        model.StatusActive,
+			wantStatusChanged: createdAt,
+		},
+		{
+			name: "deprecated version with reason and replacement",
+			dbServer: helper{
+				Status:          sqlc.ServerStatusDEPRECATED,
+				StatusReason:    ptr.String("CVE-2026-0001"),
+				ReplacedBy:      ptr.String("1.0.1"),
+				StatusChangedAt: &changedAt,
+				CreatedAt:       &createdAt,
+			},
+			wantStatus:        model.StatusDeprecated,
+			wantMessage:       ptr.String("CVE-2026-0001 (replaced by version 1.0.1)"),
+			wantStatusChanged: changedAt,
+		},
+		{
+			name: "yanked version is reported as deleted",
+			dbServer: helper{
+				Status:          sqlc.ServerStatusYANKED,
+				ReplacedBy:      ptr.String("1.0.1"),
+				StatusChangedAt: &changedAt,
+				CreatedAt:       &createdAt,
+			},
+			wantStatus:        model.StatusDeleted,
+			wantMessage:       ptr.String("(replaced by version 1.0.1)"),
+			wantStatusChanged: changedAt,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := helperToResponseMeta(tt.dbServer)
+			require.NotNil(t, got.Official)
+			assert.Equal(t, tt.wantStatus, got.Official.Status)
+			assert.Equal(t, tt.wantMessage, got.Official.StatusMessage)
+			assert.Equal(t, tt.wantStatusChanged, got.Official.StatusChangedAt)
+			assert.Equal(t, createdAt, got.Official.PublishedAt)
+			assert.Equal(t, tt.dbServer.IsLatest, got.Official.IsLatest)
 		})
 	}
 }
@@ -341,7 +546,7 @@ func TestNewDeduplicatingFilter(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			filter := newDeduplicatingFilter()
+			filter := newDeduplicatingFilter(nil)
 			var got []helper
 			for _, h := range tt.input {
 				keep, err := filter(t.Context(), h)
@@ -0,0 +1,23 @@
+// Package service defines the package digest types of the service layer.
+package service
+
+import "time"
+
+// DigestsMetaKey is the _meta key under which the digests the tags of the OCI
+// packages of an entry version resolved to are reported. For servers, it is a
+// key of the publisher-provided metadata. Versions stored without a resolved
+// digest have no such key: it is never taken from the metadata supplied by
+// publishers.
+const DigestsMetaKey = "io.github.stacklok/digests"
+
+// PackageDigest is the digest the tag of an OCI package of an entry version
+// resolved to, reported in its _meta under DigestsMetaKey. A tag resolving to
+// another digest than at a previous sync is reported as drifted, with the
+// digest it resolved to before.
+type PackageDigest struct {
+	Identifier     string     `json:"identifier"`
+	Digest         string     `json:"digest"`
+	ResolvedAt     time.Time  `json:"resolvedAt"`
+	PreviousDigest string     `json:"previousDigest,omitempty"`
+	DriftedAt      *time.Time `json:"driftedAt,omitempty"`
+}
@@ -42,6 +42,46 @@ func (m *MockRegistryService) EXPECT() *MockRegistryServiceMockRecorder {
 	return m.recorder
 }
 
+// ApproveEntrySubmission mocks base method.
+func (m *MockRegistryService) ApproveEntrySubmission(ctx context.Context, id string, opts ...service.Option) (*service.EntrySubmission, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, id}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ApproveEntrySubmission", varargs...)
+	ret0, _ := ret[0].(*service.EntrySubmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveEntrySubmission indicates an expected call of ApproveEntrySubmission.
+func (mr *MockRegistryServiceMockRecorder) ApproveEntrySubmission(ctx, id any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, id}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveEntrySubmission", reflect.TypeOf((*MockRegistryService)(nil).ApproveEntrySubmission), varargs...)
+}
+
+// BulkPublishEntries mocks base method.
+func (m *MockRegistryService) BulkPublishEntries(ctx context.Context, entries []service.PortableEntry, opts ...service.Option) (*service.BulkPublishResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, entries}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BulkPublishEntries", varargs...)
+	ret0, _ := ret[0].(*service.BulkPublishResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkPublishEntries indicates an expected call of BulkPublishEntries.
+func (mr *MockRegistryServiceMockRecorder) BulkPublishEntries(ctx, entries any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, entries}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkPublishEntries", reflect.TypeOf((*MockRegistryService)(nil).BulkPublishEntries), varargs...)
+}
+
 // CheckReadiness mocks base method.
 func (m *MockRegistryService) CheckReadiness(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -56,6 +96,21 @@ func (mr *MockRegistryServiceMockRecorder) CheckReadiness(ctx any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckReadiness", reflect.TypeOf((*MockRegistryService)(nil).CheckReadiness), ctx)
 }
 
+// CreateNamespace mocks base method.
+func (m *MockRegistryService) CreateNamespace(ctx context.Context, name string, req *service.NamespaceRequest) (*service.NamespaceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNamespace", ctx, name, req)
+	ret0, _ := ret[0].(*service.NamespaceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNamespace indicates an expected call of CreateNamespace.
+func (mr *MockRegistryServiceMockRecorder) CreateNamespace(ctx, name, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNamespace", reflect.TypeOf((*MockRegistryService)(nil).CreateNamespace), ctx, name, req)
+}
+
 // CreateRegistry mocks base method.
 func (m *MockRegistryService) CreateRegistry(ctx context.Context, name string, req *service.RegistryCreateRequest) (*service.RegistryInfo, error) {
 	m.ctrl.T.Helper()
@@ -71,6 +126,21 @@ func (mr *MockRegistryServiceMockRecorder) CreateRegistry(ctx, name, req any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRegistry", reflect.TypeOf((*MockRegistryService)(nil).CreateRegistry), ctx, name, req)
 }
 
+// CreateServerOverlay mocks base method.
+func (m *MockRegistryService) CreateServerOverlay(ctx context.Context, registryName, serverName string, req *service.ServerOverlayRequest) (*service.ServerOverlayInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateServerOverlay", ctx, registryName, serverName, req)
+	ret0, _ := ret[0].(*service.ServerOverlayInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateServerOverlay indicates an expected call of CreateServerOverlay.
+func (mr *MockRegistryServiceMockRecorder) CreateServerOverlay(ctx, registryName, serverName, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateServerOverlay", reflect.TypeOf((*MockRegistryService)(nil).CreateServerOverlay), ctx, registryName, serverName, req)
+}
+
 // CreateSource mocks base method.
 func (m *MockRegistryService) CreateSource(ctx context.Context, name string, req *service.SourceCreateRequest) (*service.SourceInfo, error) {
 	m.ctrl.T.Helper()
@@ -86,6 +156,20 @@ func (mr *MockRegistryServiceMockRecorder) CreateSource(ctx, name, req any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSource", reflect.TypeOf((*MockRegistryService)(nil).CreateSource), ctx, name, req)
 }
 
+// DeleteNamespace mocks base method.
+func (m *MockRegistryService) DeleteNamespace(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNamespace", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNamespace indicates an expected call of DeleteNamespace.
+func (mr *MockRegistryServiceMockRecorder) DeleteNamespace(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNamespace", reflect.TypeOf((*MockRegistryService)(nil).DeleteNamespace), ctx, name)
+}
+
 // DeletePluginVersion mocks base method.
 func (m *MockRegistryService) DeletePluginVersion(ctx context.Context, opts ...service.Option) error {
 	m.ctrl.T.Helper()
@@ -119,6 +203,20 @@ func (mr *MockRegistryServiceMockRecorder) DeleteRegistry(ctx, name any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRegistry", reflect.TypeOf((*MockRegistryService)(nil).DeleteRegistry), ctx, name)
 }
 
+// DeleteServerOverlay mocks base method.
+func (m *MockRegistryService) DeleteServerOverlay(ctx context.Context, registryName, serverName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteServerOverlay", ctx, registryName, serverName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteServerOverlay indicates an expected call of DeleteServerOverlay.
+func (mr *MockRegistryServiceMockRecorder) DeleteServerOverlay(ctx, registryName, serverName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteServerOverlay", reflect.TypeOf((*MockRegistryService)(nil).DeleteServerOverlay), ctx, registryName, serverName)
+}
+
 // DeleteServerVersion mocks base method.
 func (m *MockRegistryService) DeleteServerVersion(ctx context.Context, opts ...service.Option) error {
 	m.ctrl.T.Helper()
@@ -171,6 +269,35 @@ func (mr *MockRegistryServiceMockRecorder) DeleteSource(ctx, name any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSource", reflect.TypeOf((*MockRegistryService)(nil).DeleteSource), ctx, name)
 }
 
+// ExportRegistry mocks base method.
+func (m *MockRegistryService) ExportRegistry(ctx context.Context, registryName, since string) (*service.RegistryExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportRegistry", ctx, registryName, since)
+	ret0, _ := ret[0].(*service.RegistryExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportRegistry indicates an expected call of ExportRegistry.
+func (mr *MockRegistryServiceMockRecorder) ExportRegistry(ctx, registryName, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportRegistry", reflect.TypeOf((*MockRegistryService)(nil).ExportRegistry), ctx, registryName, since)
+}
+
+// ExportSource mocks base method.
+func (m *MockRegistryService) ExportSource(ctx context.Context, sourceName string, emit func(*service.PortableEntry) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportSource", ctx, sourceName, emit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportSource indicates an expected call of ExportSource.
+func (mr *MockRegistryServiceMockRecorder) ExportSource(ctx, sourceName, emit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportSource", reflect.TypeOf((*MockRegistryService)(nil).ExportSource), ctx, sourceName, emit)
+}
+
 // GetEntryClaims mocks base method.
 func (m *MockRegistryService) GetEntryClaims(ctx context.Context, opts ...service.Option) (map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -191,6 +318,21 @@ func (mr *MockRegistryServiceMockRecorder) GetEntryClaims(ctx any, opts ...any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntryClaims", reflect.TypeOf((*MockRegistryService)(nil).GetEntryClaims), varargs...)
 }
 
+// GetNamespace mocks base method.
+func (m *MockRegistryService) GetNamespace(ctx context.Context, name string) (*service.NamespaceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNamespace", ctx, name)
+	ret0, _ := ret[0].(*service.NamespaceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNamespace indicates an expected call of GetNamespace.
+func (mr *MockRegistryServiceMockRecorder) GetNamespace(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNamespace", reflect.TypeOf((*MockRegistryService)(nil).GetNamespace), ctx, name)
+}
+
 // GetPluginVersion mocks base method.
 func (m *MockRegistryService) GetPluginVersion(ctx context.Context, opts ...service.Option) (*service.Plugin, error) {
 	m.ctrl.T.Helper()
@@ -226,15 +368,30 @@ func (mr *MockRegistryServiceMockRecorder) GetRegistryByName(ctx, name any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegistryByName", reflect.TypeOf((*MockRegistryService)(nil).GetRegistryByName), ctx, name)
 }
 
+// GetServerOverlay mocks base method.
+func (m *MockRegistryService) GetServerOverlay(ctx context.Context, registryName, serverName string) (*service.ServerOverlayInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServerOverlay", ctx, registryName, serverName)
+	ret0, _ := ret[0].(*service.ServerOverlayInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServerOverlay indicates an expected call of GetServerOverlay.
+func (mr *MockRegistryServiceMockRecorder) GetServerOverlay(ctx, registryName, serverName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServerOverlay", reflect.TypeOf((*MockRegistryService)(nil).GetServerOverlay), ctx, registryName, serverName)
+}
+
 // GetServerVersion mocks base method.
-func (m *MockRegistryService) GetServerVersion(ctx context.Context, opts ...service.Option) (*v0.ServerJSON, error) {
+func (m *MockRegistryService) GetServerVersion(ctx context.Context, opts ...service.Option) (*v0.ServerResponse, error) {
 	m.ctrl.T.Helper()
 	varargs := []any{ctx}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
 	ret := m.ctrl.Call(m, "GetServerVersion", varargs...)
-	ret0, _ := ret[0].(*v0.ServerJSON)
+	ret0, _ := ret[0].(*v0.ServerResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -281,6 +438,76 @@ func (mr *MockRegistryServiceMockRecorder) GetSourceByName(ctx, name any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSourceByName", reflect.TypeOf((*MockRegistryService)(nil).GetSourceByName), ctx, name)
 }
 
+// GetSourceSyncRequest mocks base method.
+func (m *MockRegistryService) GetSourceSyncRequest(ctx context.Context, name, id string) (*service.SyncRequestInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSourceSyncRequest", ctx, name, id)
+	ret0, _ := ret[0].(*service.SyncRequestInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSourceSyncRequest indicates an expected call of GetSourceSyncRequest.
+func (mr *MockRegistryServiceMockRecorder) GetSourceSyncRequest(ctx, name, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSourceSyncRequest", reflect.TypeOf((*MockRegistryService)(nil).GetSourceSyncRequest), ctx, name, id)
+}
+
+// ListEntryChanges mocks base method.
+func (m *MockRegistryService) ListEntryChanges(ctx context.Context, opts ...service.Option) (*service.EntryChangesResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListEntryChanges", varargs...)
+	ret0, _ := ret[0].(*service.EntryChangesResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntryChanges indicates an expected call of ListEntryChanges.
+func (mr *MockRegistryServiceMockRecorder) ListEntryChanges(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntryChanges", reflect.TypeOf((*MockRegistryService)(nil).ListEntryChanges), varargs...)
+}
+
+// ListEntrySubmissions mocks base method.
+func (m *MockRegistryService) ListEntrySubmissions(ctx context.Context, opts ...service.Option) (*service.EntrySubmissionListResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListEntrySubmissions", varargs...)
+	ret0, _ := ret[0].(*service.EntrySubmissionListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntrySubmissions indicates an expected call of ListEntrySubmissions.
+func (mr *MockRegistryServiceMockRecorder) ListEntrySubmissions(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntrySubmissions", reflect.TypeOf((*MockRegistryService)(nil).ListEntrySubmissions), varargs...)
+}
+
+// ListNamespaces mocks base method.
+func (m *MockRegistryService) ListNamespaces(ctx context.Context) ([]service.NamespaceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNamespaces", ctx)
+	ret0, _ := ret[0].([]service.NamespaceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNamespaces indicates an expected call of ListNamespaces.
+func (mr *MockRegistryServiceMockRecorder) ListNamespaces(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNamespaces", reflect.TypeOf((*MockRegistryService)(nil).ListNamespaces), ctx)
+}
+
 // ListPlugins mocks base method.
 func (m *MockRegistryService) ListPlugins(ctx context.Context, opts ...service.Option) (*service.ListPluginsResult, error) {
 	m.ctrl.T.Helper()
@@ -331,15 +558,30 @@ func (mr *MockRegistryServiceMockRecorder) ListRegistryEntries(ctx, registryName
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRegistryEntries", reflect.TypeOf((*MockRegistryService)(nil).ListRegistryEntries), ctx, registryName)
 }
 
+// ListServerOverlays mocks base method.
+func (m *MockRegistryService) ListServerOverlays(ctx context.Context, registryName string) ([]service.ServerOverlayInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListServerOverlays", ctx, registryName)
+	ret0, _ := ret[0].([]service.ServerOverlayInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListServerOverlays indicates an expected call of ListServerOverlays.
+func (mr *MockRegistryServiceMockRecorder) ListServerOverlays(ctx, registryName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServerOverlays", reflect.TypeOf((*MockRegistryService)(nil).ListServerOverlays), ctx, registryName)
+}
+
 // ListServerVersions mocks base method.
-func (m *MockRegistryService) ListServerVersions(ctx context.Context, opts ...service.Option) ([]*v0.ServerJSON, error) {
+func (m *MockRegistryService) ListServerVersions(ctx context.Context, opts ...service.Option) ([]*v0.ServerResponse, error) {
 	m.ctrl.T.Helper()
 	varargs := []any{ctx}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
 	ret := m.ctrl.Call(m, "ListServerVersions", varargs...)
-	ret0, _ := ret[0].([]*v0.ServerJSON)
+	ret0, _ := ret[0].([]*v0.ServerResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -406,6 +648,26 @@ func (mr *MockRegistryServiceMockRecorder) ListSourceEntries(ctx, sourceName any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSourceEntries", reflect.TypeOf((*MockRegistryService)(nil).ListSourceEntries), ctx, sourceName)
 }
 
+// ListSourceSyncs mocks base method.
+func (m *MockRegistryService) ListSourceSyncs(ctx context.Context, name string, opts ...service.Option) (*service.SourceSyncListResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, name}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListSourceSyncs", varargs...)
+	ret0, _ := ret[0].(*service.SourceSyncListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSourceSyncs indicates an expected call of ListSourceSyncs.
+func (mr *MockRegistryServiceMockRecorder) ListSourceSyncs(ctx, name any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, name}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSourceSyncs", reflect.TypeOf((*MockRegistryService)(nil).ListSourceSyncs), varargs...)
+}
+
 // ListSources mocks base method.
 func (m *MockRegistryService) ListSources(ctx context.Context) ([]service.SourceInfo, error) {
 	m.ctrl.T.Helper()
@@ -421,6 +683,26 @@ func (mr *MockRegistryServiceMockRecorder) ListSources(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSources", reflect.TypeOf((*MockRegistryService)(nil).ListSources), ctx)
 }
 
+// ListWebhookDeliveries mocks base method.
+func (m *MockRegistryService) ListWebhookDeliveries(ctx context.Context, opts ...service.Option) (*service.WebhookDeliveryListResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListWebhookDeliveries", varargs...)
+	ret0, _ := ret[0].(*service.WebhookDeliveryListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeliveries indicates an expected call of ListWebhookDeliveries.
+func (mr *MockRegistryServiceMockRecorder) ListWebhookDeliveries(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveries", reflect.TypeOf((*MockRegistryService)(nil).ListWebhookDeliveries), varargs...)
+}
+
 // ProcessInlineSourceData mocks base method.
 func (m *MockRegistryService) ProcessInlineSourceData(ctx context.Context, name, data string) error {
 	m.ctrl.T.Helper()
@@ -495,6 +777,61 @@ func (mr *MockRegistryServiceMockRecorder) PublishSkill(ctx, skill any, opts ...
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishSkill", reflect.TypeOf((*MockRegistryService)(nil).PublishSkill), varargs...)
 }
 
+// RejectEntrySubmission mocks base method.
+func (m *MockRegistryService) RejectEntrySubmission(ctx context.Context, id string, opts ...service.Option) (*service.EntrySubmission, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, id}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RejectEntrySubmission", varargs...)
+	ret0, _ := ret[0].(*service.EntrySubmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectEntrySubmission indicates an expected call of RejectEntrySubmission.
+func (mr *MockRegistryServiceMockRecorder) RejectEntrySubmission(ctx, id any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, id}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectEntrySubmission", reflect.TypeOf((*MockRegistryService)(nil).RejectEntrySubmission), varargs...)
+}
+
+// RequestSourceSync mocks base method.
+func (m *MockRegistryService) RequestSourceSync(ctx context.Context, name string) (*service.SyncRequestInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestSourceSync", ctx, name)
+	ret0, _ := ret[0].(*service.SyncRequestInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestSourceSync indicates an expected call of RequestSourceSync.
+func (mr *MockRegistryServiceMockRecorder) RequestSourceSync(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestSourceSync", reflect.TypeOf((*MockRegistryService)(nil).RequestSourceSync), ctx, name)
+}
+
+// SearchEntries mocks base method.
+func (m *MockRegistryService) SearchEntries(ctx context.Context, opts ...service.Option) (*service.SearchEntriesResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SearchEntries", varargs...)
+	ret0, _ := ret[0].(*service.SearchEntriesResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchEntries indicates an expected call of SearchEntries.
+func (mr *MockRegistryServiceMockRecorder) SearchEntries(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchEntries", reflect.TypeOf((*MockRegistryService)(nil).SearchEntries), varargs...)
+}
+
 // UpdateEntryClaims mocks base method.
 func (m *MockRegistryService) UpdateEntryClaims(ctx context.Context, opts ...service.Option) error {
 	m.ctrl.T.Helper()
@@ -514,6 +851,21 @@ func (mr *MockRegistryServiceMockRecorder) UpdateEntryClaims(ctx any, opts ...an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEntryClaims", reflect.TypeOf((*MockRegistryService)(nil).UpdateEntryClaims), varargs...)
 }
 
+// UpdateNamespace mocks base method.
+func (m *MockRegistryService) UpdateNamespace(ctx context.Context, name string, req *service.NamespaceRequest) (*service.NamespaceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNamespace", ctx, name, req)
+	ret0, _ := ret[0].(*service.NamespaceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNamespace indicates an expected call of UpdateNamespace.
+func (mr *MockRegistryServiceMockRecorder) UpdateNamespace(ctx, name, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNamespace", reflect.TypeOf((*MockRegistryService)(nil).UpdateNamespace), ctx, name, req)
+}
+
 // UpdateRegistry mocks base method.
 func (m *MockRegistryService) UpdateRegistry(ctx context.Context, name string, req *service.RegistryCreateRequest) (*service.RegistryInfo, error) {
 	m.ctrl.T.Helper()
@@ -529,6 +881,40 @@ func (mr *MockRegistryServiceMockRecorder) UpdateRegistry(ctx, name, req any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRegistry", reflect.TypeOf((*MockRegistryService)(nil).UpdateRegistry), ctx, name, req)
 }
 
+// UpdateServerOverlay mocks base method.
+func (m *MockRegistryService) UpdateServerOverlay(ctx context.Context, registryName, serverName string, req *service.ServerOverlayRequest) (*service.ServerOverlayInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateServerOverlay", ctx, registryName, serverName, req)
+	ret0, _ := ret[0].(*service.ServerOverlayInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateServerOverlay indicates an expected call of UpdateServerOverlay.
+func (mr *MockRegistryServiceMockRecorder) UpdateServerOverlay(ctx, registryName, serverName, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateServerOverlay", reflect.TypeOf((*MockRegistryService)(nil).UpdateServerOverlay), ctx, registryName, serverName, req)
+}
+
+// UpdateServerVersionStatus mocks base method.
+func (m *MockRegistryService) UpdateServerVersionStatus(ctx context.Context, opts ...service.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateServerVersionStatus", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateServerVersionStatus indicates an expected call of UpdateServerVersionStatus.
+func (mr *MockRegistryServiceMockRecorder) UpdateServerVersionStatus(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateServerVersionStatus", reflect.TypeOf((*MockRegistryService)(nil).UpdateServerVersionStatus), varargs...)
+}
+
 // UpdateSource mocks base method.
 func (m *MockRegistryService) UpdateSource(ctx context.Context, name string, req *service.SourceCreateRequest) (*service.SourceInfo, error) {
 	m.ctrl.T.Helper()
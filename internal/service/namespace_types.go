@@ -0,0 +1,22 @@
+// Package service defines the namespace types returned by the service layer.
+package service
+
+import "time"
+
+// NamespaceInfo is a reverse-DNS namespace reserved for its owners. It covers
+// the entries of the namespace and of its sub-namespaces, unless a more
+// specific namespace is reserved too: com.example covers com.example/weather
+// and the skills of the com.example.tools namespace.
+type NamespaceInfo struct {
+	Name string `json:"name"`
+	// Claims a caller must cover to publish, delete or update the claims of the
+	// entries of the namespace. Without claims, only super-admins may.
+	Claims    map[string]any `json:"claims,omitempty"`
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+}
+
+// NamespaceRequest is the request body for reserving a namespace
+type NamespaceRequest struct {
+	Claims map[string]any `json:"claims,omitempty"`
+}
@@ -244,3 +244,197 @@ func WithServerData(serverData *upstreamv0.ServerJSON) Option {
 		}
 	}
 }
+
+type serverStatusOption interface {
+	setServerStatus(status string) error
+	setStatusReason(reason string) error
+	setReplacedBy(version string) error
+}
+
+// WithServerStatus sets the status for the UpdateServerVersionStatus operation.
+// Valid values are defined in server_status.go. Invalid values produce an error
+// wrapping ErrInvalidServerStatus.
+func WithServerStatus(status string) Option {
+	return func(o any) error {
+		switch o := o.(type) {
+		case serverStatusOption:
+			return o.setServerStatus(status)
+		default:
+			return fmt.Errorf("invalid option type: %T", o)
+		}
+	}
+}
+
+// WithStatusReason sets the reason for deprecating or yanking a server version
+func WithStatusReason(reason string) Option {
+	return func(o any) error {
+		switch o := o.(type) {
+		case serverStatusOption:
+			return o.setStatusReason(reason)
+		default:
+			return fmt.Errorf("invalid option type: %T", o)
+		}
+	}
+}
+
+// WithReplacedBy sets the version replacing a deprecated or yanked server version
+func WithReplacedBy(version string) Option {
+	return func(o any) error {
+		switch o := o.(type) {
+		case serverStatusOption:
+			return o.setReplacedBy(version)
+		default:
+			return fmt.Errorf("invalid option type: %T", o)
+		}
+	}
+}
+
+type changeTokenOption interface {
+	setSince(token string) error
+	setFromLatest() error
+}
+
+// WithSince sets the change token after which the ListEntryChanges operation
+// returns changes. Without it, changes are returned from the oldest retained one.
+func WithSince(token string) Option {
+	return func(o any) error {
+		if token == "" {
+			return fmt.Errorf("%w: empty token", ErrInvalidChangeToken)
+		}
+
+		switch o := o.(type) {
+		case changeTokenOption:
+			return o.setSince(token)
+		default:
+			return fmt.Errorf("invalid option type: %T", o)
+		}
+	}
+}
+
+// WithFromLatest makes the ListEntryChanges operation return no change and the
+// token of the latest one, to follow the changes made from now on.
+func WithFromLatest() Option {
+	return func(o any) error {
+		switch o := o.(type) {
+		case changeTokenOption:
+			return o.setFromLatest()
+		default:
+			return fmt.Errorf("invalid option type: %T", o)
+		}
+	}
+}
+
+type webhookDeliveryOption interface {
+	setWebhook(webhook string) error
+	setDeliveryStatus(status string) error
+}
+
+// WithWebhook restricts the ListWebhookDeliveries operation to the deliveries of a webhook
+func WithWebhook(webhook string) Option {
+	return func(o any) error {
+		if webhook == "" {
+			return fmt.Errorf("invalid webhook: %s", webhook)
+		}
+
+		switch o := o.(type) {
+		case webhookDeliveryOption:
+			return o.setWebhook(webhook)
+		default:
+			return fmt.Errorf("invalid option type: %T", o)
+		}
+	}
+}
+
+// WithDeliveryStatus restricts the ListWebhookDeliveries operation to the
+// deliveries with the given status: pending, delivered or failed
+func WithDeliveryStatus(status string) Option {
+	return func(o any) error {
+		switch status {
+		case DeliveryStatusPending, DeliveryStatusDelivered, DeliveryStatusFailed:
+		default:
+			return fmt.Errorf("%w: %s", ErrInvalidDeliveryStatus, status)
+		}
+
+		switch o := o.(type) {
+		case webhookDeliveryOption:
+			return o.setDeliveryStatus(status)
+		default:
+			return fmt.Errorf("invalid option type: %T", o)
+		}
+	}
+}
+
+type submissionStatusOption interface {
+	setSubmissionStatus(status string) error
+}
+
+// WithSubmissionStatus restricts the ListEntrySubmissions operation to the
+// submissions with the given status: pending, approved or rejected
+func WithSubmissionStatus(status string) Option {
+	return func(o any) error {
+		switch status {
+		case SubmissionStatusPending, SubmissionStatusApproved, SubmissionStatusRejected:
+		default:
+			return fmt.Errorf("%w: %s", ErrInvalidSubmissionStatus, status)
+		}
+
+		switch o := o.(type) {
+		case submissionStatusOption:
+			return o.setSubmissionStatus(status)
+		default:
+			return fmt.Errorf("invalid option type: %T", o)
+		}
+	}
+}
+
+type reviewCommentOption interface {
+	setReviewComment(comment string) error
+}
+
+// WithReviewComment sets the comment recorded with the decision of the
+// ApproveEntrySubmission or RejectEntrySubmission operation
+func WithReviewComment(comment string) Option {
+	return func(o any) error {
+		switch o := o.(type) {
+		case reviewCommentOption:
+			return o.setReviewComment(comment)
+		default:
+			return fmt.Errorf("invalid option type: %T", o)
+		}
+	}
+}
+
+type signatureOption interface {
+	setSignature(signature *EntrySignature) error
+}
+
+// WithSignature attaches a signature to the PublishServerVersion, PublishSkill
+// or PublishPlugin operation. Its Payload must be the signed JSON encoding of
+// the published entry.
+func WithSignature(signature *EntrySignature) Option {
+	return func(o any) error {
+		switch o := o.(type) {
+		case signatureOption:
+			return o.setSignature(signature)
+		default:
+			return fmt.Errorf("invalid option type: %T", o)
+		}
+	}
+}
+
+type atomicOption interface {
+	setAtomic(atomic bool) error
+}
+
+// WithAtomic makes the BulkPublishEntries operation all-or-nothing: the entries
+// are published in a single transaction, rolled back if any of them fails.
+func WithAtomic() Option {
+	return func(o any) error {
+		switch o := o.(type) {
+		case atomicOption:
+			return o.setAtomic(true)
+		default:
+			return fmt.Errorf("invalid option type: %T", o)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package service
+
+// ListEntryChangesOptions is the options for the ListEntryChanges operation.
+type ListEntryChangesOptions struct {
+	RegistryName string
+	Since        string
+	FromLatest   bool
+	Limit        int
+	Claims       map[string]any
+}
+
+//nolint:unparam
+func (o *ListEntryChangesOptions) setRegistryName(registryName string) error {
+	o.RegistryName = registryName
+	return nil
+}
+
+//nolint:unparam
+func (o *ListEntryChangesOptions) setSince(token string) error {
+	o.Since = token
+	return nil
+}
+
+//nolint:unparam
+func (o *ListEntryChangesOptions) setFromLatest() error {
+	o.FromLatest = true
+	return nil
+}
+
+//nolint:unparam
+func (o *ListEntryChangesOptions) setLimit(limit int) error {
+	o.Limit = limit
+	return nil
+}
+
+//nolint:unparam
+func (o *ListEntryChangesOptions) setClaims(claims map[string]any) error {
+	o.Claims = claims
+	return nil
+}
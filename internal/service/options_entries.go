@@ -66,3 +66,21 @@ func (o *GetEntryClaimsOptions) setJWTClaims(claims map[string]any) error {
 	o.JWTClaims = claims
 	return nil
 }
+
+// BulkPublishEntriesOptions is the options for the BulkPublishEntries operation.
+type BulkPublishEntriesOptions struct {
+	Atomic    bool
+	JWTClaims map[string]any
+}
+
+//nolint:unparam
+func (o *BulkPublishEntriesOptions) setAtomic(atomic bool) error {
+	o.Atomic = atomic
+	return nil
+}
+
+//nolint:unparam
+func (o *BulkPublishEntriesOptions) setJWTClaims(claims map[string]any) error {
+	o.JWTClaims = claims
+	return nil
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"fmt"
 	"time"
 
 	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
@@ -129,6 +130,7 @@ type PublishServerVersionOptions struct {
 	ServerData *upstreamv0.ServerJSON
 	Claims     map[string]any
 	JWTClaims  map[string]any
+	Signature  *EntrySignature
 }
 
 //nolint:unparam
@@ -149,6 +151,12 @@ func (o *PublishServerVersionOptions) setJWTClaims(claims map[string]any) error
 	return nil
 }
 
+//nolint:unparam
+func (o *PublishServerVersionOptions) setSignature(signature *EntrySignature) error {
+	o.Signature = signature
+	return nil
+}
+
 // DeleteServerVersionOptions is the options for the DeleteServerVersion operation
 type DeleteServerVersionOptions struct {
 	ServerName string
@@ -173,3 +181,54 @@ func (o *DeleteServerVersionOptions) setJWTClaims(claims map[string]any) error {
 	o.JWTClaims = claims
 	return nil
 }
+
+// UpdateServerVersionStatusOptions is the options for the UpdateServerVersionStatus operation
+type UpdateServerVersionStatusOptions struct {
+	ServerName string
+	Version    string
+	Status     string // ServerStatusActive, ServerStatusDeprecated, or ServerStatusYanked
+	Reason     string
+	ReplacedBy string
+	JWTClaims  map[string]any
+}
+
+//nolint:unparam
+func (o *UpdateServerVersionStatusOptions) setName(serverName string) error {
+	o.ServerName = serverName
+	return nil
+}
+
+//nolint:unparam
+func (o *UpdateServerVersionStatusOptions) setVersion(version string) error {
+	o.Version = version
+	return nil
+}
+
+func (o *UpdateServerVersionStatusOptions) setServerStatus(status string) error {
+	switch status {
+	case ServerStatusActive, ServerStatusDeprecated, ServerStatusYanked:
+		o.Status = status
+		return nil
+	default:
+		return fmt.Errorf("%w: must be %q, %q, or %q",
+			ErrInvalidServerStatus, ServerStatusActive, ServerStatusDeprecated, ServerStatusYanked)
+	}
+}
+
+//nolint:unparam
+func (o *UpdateServerVersionStatusOptions) setStatusReason(reason string) error {
+	o.Reason = reason
+	return nil
+}
+
+//nolint:unparam
+func (o *UpdateServerVersionStatusOptions) setReplacedBy(version string) error {
+	o.ReplacedBy = version
+	return nil
+}
+
+//nolint:unparam
+func (o *UpdateServerVersionStatusOptions) setJWTClaims(claims map[string]any) error {
+	o.JWTClaims = claims
+	return nil
+}
@@ -0,0 +1,33 @@
+package service
+
+// ListWebhookDeliveriesOptions is the options for the ListWebhookDeliveries operation.
+type ListWebhookDeliveriesOptions struct {
+	Webhook string
+	Status  string
+	Cursor  string
+	Limit   int
+}
+
+//nolint:unparam
+func (o *ListWebhookDeliveriesOptions) setWebhook(webhook string) error {
+	o.Webhook = webhook
+	return nil
+}
+
+//nolint:unparam
+func (o *ListWebhookDeliveriesOptions) setDeliveryStatus(status string) error {
+	o.Status = status
+	return nil
+}
+
+//nolint:unparam
+func (o *ListWebhookDeliveriesOptions) setCursor(cursor string) error {
+	o.Cursor = cursor
+	return nil
+}
+
+//nolint:unparam
+func (o *ListWebhookDeliveriesOptions) setLimit(limit int) error {
+	o.Limit = limit
+	return nil
+}
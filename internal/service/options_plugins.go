@@ -4,6 +4,7 @@ package service
 type PublishPluginOptions struct {
 	Claims    map[string]any
 	JWTClaims map[string]any
+	Signature *EntrySignature
 }
 
 //nolint:unparam
@@ -18,6 +19,12 @@ func (o *PublishPluginOptions) setJWTClaims(claims map[string]any) error {
 	return nil
 }
 
+//nolint:unparam
+func (o *PublishPluginOptions) setSignature(signature *EntrySignature) error {
+	o.Signature = signature
+	return nil
+}
+
 // ListPluginsOptions is the options for the ListPlugins and ListPluginVersions
 // operations.
 type ListPluginsOptions struct {
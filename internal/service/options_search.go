@@ -0,0 +1,40 @@
+package service
+
+// SearchEntriesOptions is the options for the SearchEntries operation.
+type SearchEntriesOptions struct {
+	RegistryName string
+	Search       string
+	Limit        int
+	Cursor       *string
+	Claims       map[string]any
+}
+
+//nolint:unparam
+func (o *SearchEntriesOptions) setRegistryName(registryName string) error {
+	o.RegistryName = registryName
+	return nil
+}
+
+//nolint:unparam
+func (o *SearchEntriesOptions) setSearch(search string) error {
+	o.Search = search
+	return nil
+}
+
+//nolint:unparam
+func (o *SearchEntriesOptions) setLimit(limit int) error {
+	o.Limit = limit
+	return nil
+}
+
+//nolint:unparam
+func (o *SearchEntriesOptions) setCursor(cursor string) error {
+	o.Cursor = &cursor
+	return nil
+}
+
+//nolint:unparam
+func (o *SearchEntriesOptions) setClaims(claims map[string]any) error {
+	o.Claims = claims
+	return nil
+}
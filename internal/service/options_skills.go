@@ -4,6 +4,7 @@ package service
 type PublishSkillOptions struct {
 	Claims    map[string]any
 	JWTClaims map[string]any
+	Signature *EntrySignature
 }
 
 //nolint:unparam
@@ -18,6 +19,12 @@ func (o *PublishSkillOptions) setJWTClaims(claims map[string]any) error {
 	return nil
 }
 
+//nolint:unparam
+func (o *PublishSkillOptions) setSignature(signature *EntrySignature) error {
+	o.Signature = signature
+	return nil
+}
+
 // ListSkillsOptions is the options for the ListSkills and ListSkillVersions
 // operations.
 type ListSkillsOptions struct {
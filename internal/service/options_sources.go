@@ -0,0 +1,19 @@
+package service
+
+// ListSourceSyncsOptions is the options for the ListSourceSyncs operation
+type ListSourceSyncsOptions struct {
+	Cursor string
+	Limit  int
+}
+
+//nolint:unparam
+func (o *ListSourceSyncsOptions) setCursor(cursor string) error {
+	o.Cursor = cursor
+	return nil
+}
+
+//nolint:unparam
+func (o *ListSourceSyncsOptions) setLimit(limit int) error {
+	o.Limit = limit
+	return nil
+}
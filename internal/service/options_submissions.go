@@ -0,0 +1,65 @@
+package service
+
+import "fmt"
+
+// ListEntrySubmissionsOptions is the options for the ListEntrySubmissions operation.
+type ListEntrySubmissionsOptions struct {
+	Status    string
+	EntryType string // EntryTypeServer, EntryTypeSkill, or EntryTypePlugin
+	Cursor    string
+	Limit     int
+	JWTClaims map[string]any
+}
+
+//nolint:unparam
+func (o *ListEntrySubmissionsOptions) setSubmissionStatus(status string) error {
+	o.Status = status
+	return nil
+}
+
+func (o *ListEntrySubmissionsOptions) setEntryType(entryType string) error {
+	switch entryType {
+	case EntryTypeServer, EntryTypeSkill, EntryTypePlugin:
+		o.EntryType = entryType
+		return nil
+	default:
+		return fmt.Errorf("%w: must be %q, %q, or %q", ErrInvalidEntryType, EntryTypeServer, EntryTypeSkill, EntryTypePlugin)
+	}
+}
+
+//nolint:unparam
+func (o *ListEntrySubmissionsOptions) setCursor(cursor string) error {
+	o.Cursor = cursor
+	return nil
+}
+
+//nolint:unparam
+func (o *ListEntrySubmissionsOptions) setLimit(limit int) error {
+	o.Limit = limit
+	return nil
+}
+
+//nolint:unparam
+func (o *ListEntrySubmissionsOptions) setJWTClaims(claims map[string]any) error {
+	o.JWTClaims = claims
+	return nil
+}
+
+// ReviewEntrySubmissionOptions is the options for the ApproveEntrySubmission and
+// RejectEntrySubmission operations.
+type ReviewEntrySubmissionOptions struct {
+	Comment   string
+	JWTClaims map[string]any
+}
+
+//nolint:unparam
+func (o *ReviewEntrySubmissionOptions) setReviewComment(comment string) error {
+	o.Comment = comment
+	return nil
+}
+
+//nolint:unparam
+func (o *ReviewEntrySubmissionOptions) setJWTClaims(claims map[string]any) error {
+	o.JWTClaims = claims
+	return nil
+}
@@ -0,0 +1,39 @@
+// Package service defines the registry overlay types of the service layer.
+package service
+
+import (
+	"time"
+
+	model "github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// OverlayMetaKey is the _meta key under which the overlay of a server is
+// reported, with its tags, in every version of the server a registry serves.
+// It is a key of the publisher-provided metadata, and is never taken from the
+// metadata supplied by publishers.
+const OverlayMetaKey = "dev.toolhive/overlay"
+
+// ServerOverlayInfo annotates a server of the sources of a registry without
+// owning it. It is attached to the server name, so it applies to every version
+// the registry serves and is kept across the syncs of the underlying sources.
+type ServerOverlayInfo struct {
+	Name string `json:"name"`
+	// Title overrides the display name of the server
+	Title string `json:"title,omitempty"`
+	// Tags are reported under OverlayMetaKey
+	Tags []string `json:"tags,omitempty"`
+	// Icons replace the icons of the server
+	Icons []model.Icon `json:"icons,omitempty"`
+	// Meta is reported under OverlayMetaKey
+	Meta      map[string]any `json:"meta,omitempty"`
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+}
+
+// ServerOverlayRequest is the request body for creating or replacing the overlay of a server
+type ServerOverlayRequest struct {
+	Title string         `json:"title,omitempty"`
+	Tags  []string       `json:"tags,omitempty"`
+	Icons []model.Icon   `json:"icons,omitempty"`
+	Meta  map[string]any `json:"meta,omitempty"`
+}
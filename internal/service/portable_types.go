@@ -0,0 +1,289 @@
+// Package service defines the types carrying entries in and out of the
+// service layer as upstream registry documents.
+package service
+
+import (
+	"fmt"
+	"maps"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	toolhivetypes "github.com/stacklok/toolhive-core/registry/types"
+)
+
+// ClaimsMetaKey is the _meta key under which the claims of an entry are carried
+// in upstream registry documents, on bulk imports and source exports. For
+// servers, it is a key of the publisher-provided metadata. It is never stored
+// with the metadata of the entry.
+const ClaimsMetaKey = "io.github.stacklok/claims"
+
+// Bulk publish entry statuses
+const (
+	// BulkEntryPublished is the status of an entry published into the managed source
+	BulkEntryPublished = "published"
+	// BulkEntryPendingReview is the status of an entry held for review
+	BulkEntryPendingReview = "pending_review"
+	// BulkEntryFailed is the status of an entry that could not be published
+	BulkEntryFailed = "failed"
+	// BulkEntrySkipped is the status of the entries of an atomic bulk publish
+	// that was rolled back because another entry failed
+	BulkEntrySkipped = "skipped"
+)
+
+// PortableEntry is a server, skill or plugin version with its claims, as
+// carried by an upstream registry document. Exactly one of Server, Skill or
+// Plugin is set.
+type PortableEntry struct {
+	Server *upstreamv0.ServerJSON
+	Skill  *Skill
+	Plugin *Plugin
+	Claims map[string]any
+}
+
+// EntryType returns the type of the entry: EntryTypeServer, EntryTypeSkill or
+// EntryTypePlugin.
+func (e *PortableEntry) EntryType() string {
+	switch {
+	case e.Server != nil:
+		return EntryTypeServer
+	case e.Skill != nil:
+		return EntryTypeSkill
+	default:
+		return EntryTypePlugin
+	}
+}
+
+// BulkEntryResult is the outcome of publishing one entry of a bulk publish.
+type BulkEntryResult struct {
+	EntryType    string `json:"entryType"`
+	Namespace    string `json:"namespace,omitempty"`
+	Name         string `json:"name"`
+	Version      string `json:"version"`
+	Status       string `json:"status" enums:"published,pending_review,failed,skipped"`
+	SubmissionID string `json:"submissionId,omitempty"`
+	Error        string `json:"error,omitempty"`
+	// Err is the error the entry failed with
+	Err error `json:"-"`
+}
+
+// BulkPublishResult is the outcome of a bulk publish, with one result per
+// entry in the order of the published document.
+type BulkPublishResult struct {
+	Atomic bool `json:"atomic"`
+	// Committed is false when an atomic bulk publish was rolled back
+	Committed bool              `json:"committed"`
+	Results   []BulkEntryResult `json:"results"`
+}
+
+// RegistryExport is the content of a registry exported for replication: the
+// entry versions it serves, with their claims, as of a change token.
+type RegistryExport struct {
+	// ChangeToken is the position of the change feed of the registry the
+	// export was read at
+	ChangeToken string
+	Entries     []PortableEntry
+	// Deleted lists the versions changed after the token an export was made
+	// since that the registry no longer serves
+	Deleted []*EntryChange
+}
+
+// PortableEntriesFromUpstream returns the entries of an upstream registry
+// document, servers first, then skills, then plugins, with the claims carried
+// in their _meta.
+func PortableEntriesFromUpstream(reg *toolhivetypes.UpstreamRegistry) ([]PortableEntry, error) {
+	entries := make([]PortableEntry, 0, len(reg.Data.Servers)+len(reg.Data.Skills)+len(reg.Data.Plugins))
+
+	for i := range reg.Data.Servers {
+		server := reg.Data.Servers[i]
+		entry := PortableEntry{Server: &server}
+		if server.Meta != nil && server.Meta.PublisherProvided != nil {
+			meta := *server.Meta
+			claims, publisherProvided, err := takeClaimsMeta(server.Meta.PublisherProvided)
+			if err != nil {
+				return nil, fmt.Errorf("server %s: %w", server.Name, err)
+			}
+			meta.PublisherProvided = publisherProvided
+			server.Meta = &meta
+			entry.Claims = claims
+		}
+		entries = append(entries, entry)
+	}
+
+	for i := range reg.Data.Skills {
+		skill := skillFromUpstream(&reg.Data.Skills[i])
+		claims, meta, err := takeClaimsMeta(skill.Meta)
+		if err != nil {
+			return nil, fmt.Errorf("skill %s/%s: %w", skill.Namespace, skill.Name, err)
+		}
+		skill.Meta = meta
+		entries = append(entries, PortableEntry{Skill: skill, Claims: claims})
+	}
+
+	for i := range reg.Data.Plugins {
+		plugin := pluginFromUpstream(&reg.Data.Plugins[i])
+		claims, meta, err := takeClaimsMeta(plugin.Meta)
+		if err != nil {
+			return nil, fmt.Errorf("plugin %s/%s: %w", plugin.Namespace, plugin.Name, err)
+		}
+		plugin.Meta = meta
+		entries = append(entries, PortableEntry{Plugin: plugin, Claims: claims})
+	}
+
+	return entries, nil
+}
+
+// UpstreamServer returns the server of the entry with its claims in its
+// publisher-provided _meta.
+func (e *PortableEntry) UpstreamServer() *upstreamv0.ServerJSON {
+	server := *e.Server
+	if len(e.Claims) == 0 {
+		return &server
+	}
+	meta := upstreamv0.ServerMeta{}
+	if server.Meta != nil {
+		meta = *server.Meta
+	}
+	meta.PublisherProvided = withClaimsMeta(meta.PublisherProvided, e.Claims)
+	server.Meta = &meta
+	return &server
+}
+
+// UpstreamSkill returns the skill of the entry, in upstream format, with its
+// claims in its _meta.
+func (e *PortableEntry) UpstreamSkill() *toolhivetypes.Skill {
+	s := e.Skill
+	skill := &toolhivetypes.Skill{
+		Namespace:     s.Namespace,
+		Name:          s.Name,
+		Description:   s.Description,
+		Version:       s.Version,
+		Status:        s.Status,
+		Title:         s.Title,
+		License:       s.License,
+		Compatibility: s.Compatibility,
+		AllowedTools:  s.AllowedTools,
+		Metadata:      s.Metadata,
+		Meta:          withClaimsMeta(s.Meta, e.Claims),
+	}
+	if s.Repository != nil {
+		skill.Repository = &toolhivetypes.SkillRepository{URL: s.Repository.URL, Type: s.Repository.Type}
+	}
+	for _, icon := range s.Icons {
+		skill.Icons = append(skill.Icons, toolhivetypes.SkillIcon(icon))
+	}
+	for _, pkg := range s.Packages {
+		skill.Packages = append(skill.Packages, toolhivetypes.SkillPackage(pkg))
+	}
+	return skill
+}
+
+// UpstreamPlugin returns the plugin of the entry, in upstream format, with its
+// claims in its _meta.
+func (e *PortableEntry) UpstreamPlugin() *toolhivetypes.Plugin {
+	p := e.Plugin
+	plugin := &toolhivetypes.Plugin{
+		Namespace:   p.Namespace,
+		Name:        p.Name,
+		Description: p.Description,
+		Version:     p.Version,
+		Status:      p.Status,
+		Title:       p.Title,
+		License:     p.License,
+		Metadata:    p.Metadata,
+		Meta:        withClaimsMeta(p.Meta, e.Claims),
+	}
+	if p.Repository != nil {
+		plugin.Repository = &toolhivetypes.SkillRepository{URL: p.Repository.URL, Type: p.Repository.Type}
+	}
+	for _, icon := range p.Icons {
+		plugin.Icons = append(plugin.Icons, toolhivetypes.SkillIcon(icon))
+	}
+	for _, pkg := range p.Packages {
+		plugin.Packages = append(plugin.Packages, toolhivetypes.SkillPackage(pkg))
+	}
+	return plugin
+}
+
+// skillFromUpstream converts an upstream skill to its service representation.
+func skillFromUpstream(s *toolhivetypes.Skill) *Skill {
+	skill := &Skill{
+		Namespace:     s.Namespace,
+		Name:          s.Name,
+		Description:   s.Description,
+		Version:       s.Version,
+		Status:        s.Status,
+		Title:         s.Title,
+		License:       s.License,
+		Compatibility: s.Compatibility,
+		AllowedTools:  s.AllowedTools,
+		Metadata:      s.Metadata,
+		Meta:          s.Meta,
+	}
+	if s.Repository != nil {
+		skill.Repository = &SkillRepository{URL: s.Repository.URL, Type: s.Repository.Type}
+	}
+	for _, icon := range s.Icons {
+		skill.Icons = append(skill.Icons, SkillIcon(icon))
+	}
+	for _, pkg := range s.Packages {
+		skill.Packages = append(skill.Packages, SkillPackage(pkg))
+	}
+	return skill
+}
+
+// pluginFromUpstream converts an upstream plugin to its service representation.
+func pluginFromUpstream(p *toolhivetypes.Plugin) *Plugin {
+	plugin := &Plugin{
+		Namespace:   p.Namespace,
+		Name:        p.Name,
+		Description: p.Description,
+		Version:     p.Version,
+		Status:      p.Status,
+		Title:       p.Title,
+		License:     p.License,
+		Metadata:    p.Metadata,
+		Meta:        p.Meta,
+	}
+	if p.Repository != nil {
+		plugin.Repository = &PluginRepository{URL: p.Repository.URL, Type: p.Repository.Type}
+	}
+	for _, icon := range p.Icons {
+		plugin.Icons = append(plugin.Icons, PluginIcon(icon))
+	}
+	for _, pkg := range p.Packages {
+		plugin.Packages = append(plugin.Packages, PluginPackage(pkg))
+	}
+	return plugin
+}
+
+// takeClaimsMeta returns the claims carried in a _meta map, and a copy of the
+// map without them.
+func takeClaimsMeta(meta map[string]any) (map[string]any, map[string]any, error) {
+	value, ok := meta[ClaimsMetaKey]
+	if !ok {
+		return nil, meta, nil
+	}
+	claims, ok := value.(map[string]any)
+	if !ok {
+		return nil, nil, fmt.Errorf("%s must be an object", ClaimsMetaKey)
+	}
+
+	rest := maps.Clone(meta)
+	delete(rest, ClaimsMetaKey)
+	if len(rest) == 0 {
+		rest = nil
+	}
+	return claims, rest, nil
+}
+
+// withClaimsMeta returns a copy of a _meta map carrying the given claims.
+func withClaimsMeta(meta map[string]any, claims map[string]any) map[string]any {
+	if len(claims) == 0 {
+		return meta
+	}
+	result := maps.Clone(meta)
+	if result == nil {
+		result = make(map[string]any, 1)
+	}
+	result[ClaimsMetaKey] = claims
+	return result
+}
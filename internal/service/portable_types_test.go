@@ -0,0 +1,75 @@
+package service
+
+import (
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	toolhivetypes "github.com/stacklok/toolhive-core/registry/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortableEntriesFromUpstream(t *testing.T) {
+	t.Parallel()
+
+	claims := map[string]any{"org": "example"}
+	reg := &toolhivetypes.UpstreamRegistry{
+		Data: toolhivetypes.UpstreamData{
+			Servers: []upstreamv0.ServerJSON{{
+				Name:    "com.example/weather",
+				Version: "1.0.0",
+				Meta: &upstreamv0.ServerMeta{PublisherProvided: map[string]any{
+					ClaimsMetaKey: claims,
+					"provider":    map[string]any{"team": "weather"},
+				}},
+			}},
+			Skills: []toolhivetypes.Skill{{
+				Namespace: "com.example",
+				Name:      "forecast",
+				Version:   "1.0.0",
+				Icons:     []toolhivetypes.SkillIcon{{Src: "https://example.com/icon.png"}},
+				Meta:      map[string]any{ClaimsMetaKey: claims},
+			}},
+			Plugins: []toolhivetypes.Plugin{{
+				Namespace: "com.example",
+				Name:      "radar",
+				Version:   "1.0.0",
+				Packages:  []toolhivetypes.SkillPackage{{RegistryType: "oci", Identifier: "ghcr.io/example/radar"}},
+			}},
+		},
+	}
+
+	entries, err := PortableEntriesFromUpstream(reg)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, EntryTypeServer, entries[0].EntryType())
+	assert.Equal(t, claims, entries[0].Claims)
+	assert.Equal(t, map[string]any{"provider": map[string]any{"team": "weather"}},
+		entries[0].Server.Meta.PublisherProvided)
+	// The document is left untouched
+	assert.Contains(t, reg.Data.Servers[0].Meta.PublisherProvided, ClaimsMetaKey)
+
+	assert.Equal(t, EntryTypeSkill, entries[1].EntryType())
+	assert.Equal(t, claims, entries[1].Claims)
+	assert.Nil(t, entries[1].Skill.Meta)
+	assert.Equal(t, []SkillIcon{{Src: "https://example.com/icon.png"}}, entries[1].Skill.Icons)
+
+	assert.Equal(t, EntryTypePlugin, entries[2].EntryType())
+	assert.Nil(t, entries[2].Claims)
+	assert.Equal(t, "ghcr.io/example/radar", entries[2].Plugin.Packages[0].Identifier)
+
+	// Converting back restores the claims in _meta
+	assert.Equal(t, reg.Data.Servers[0].Meta.PublisherProvided, entries[0].UpstreamServer().Meta.PublisherProvided)
+	assert.Equal(t, reg.Data.Skills[0], *entries[1].UpstreamSkill())
+	assert.Equal(t, reg.Data.Plugins[0], *entries[2].UpstreamPlugin())
+
+	_, err = PortableEntriesFromUpstream(&toolhivetypes.UpstreamRegistry{
+		Data: toolhivetypes.UpstreamData{Skills: []toolhivetypes.Skill{{
+			Namespace: "com.example",
+			Name:      "forecast",
+			Meta:      map[string]any{ClaimsMetaKey: "org"},
+		}}},
+	})
+	require.ErrorContains(t, err, "skill com.example/forecast")
+}
@@ -0,0 +1,28 @@
+// Package service defines the entry scan types of the service layer.
+package service
+
+import "time"
+
+// ScanMetaKey is the _meta key under which the scan of an entry version by the
+// entry policy is reported. For servers, it is a key of the publisher-provided
+// metadata. Versions stored without a scan have no such key: it is never taken
+// from the metadata supplied by publishers.
+const ScanMetaKey = "io.github.stacklok/scan"
+
+// EntryScanResult is the scan of an entry version by the scanners of the entry
+// policy, reported in its _meta under ScanMetaKey. Blocked versions are never
+// stored, so the verdict is either allow or annotate.
+type EntryScanResult struct {
+	Verdict   string        `json:"verdict" enums:"allow,annotate"`
+	Findings  []ScanFinding `json:"findings,omitempty"`
+	ScannedAt time.Time     `json:"scannedAt"`
+}
+
+// ScanFinding is an issue reported by a scanner about an entry version
+type ScanFinding struct {
+	// Scanner is the name of the scanner, as configured in the entry policy
+	Scanner  string `json:"scanner"`
+	ID       string `json:"id,omitempty"`
+	Severity string `json:"severity,omitempty"`
+	Message  string `json:"message"`
+}
@@ -0,0 +1,22 @@
+// Package service defines the search types returned by the service layer.
+package service
+
+import "time"
+
+// SearchHit is a single entry matching a SearchEntries query. It describes the
+// latest version of the entry.
+type SearchHit struct {
+	EntryType   string    `json:"entryType"` // EntryTypeServer, EntryTypeSkill, or EntryTypePlugin
+	Namespace   string    `json:"namespace,omitempty"`
+	Name        string    `json:"name"`
+	Version     string    `json:"version"`
+	Title       string    `json:"title,omitempty"`
+	Description string    `json:"description,omitempty"`
+	UpdatedAt   time.Time `json:"updatedAt,omitempty"`
+}
+
+// SearchEntriesResult contains the result of a SearchEntries operation with pagination.
+type SearchEntriesResult struct {
+	Hits       []*SearchHit `json:"hits"`
+	NextCursor string       `json:"-"`
+}
@@ -0,0 +1,10 @@
+package service
+
+// Lifecycle statuses of MCP server versions. Deprecated and yanked versions stay
+// resolvable by their exact version, but are left out of server listings and are
+// never the latest version of their server.
+const (
+	ServerStatusActive     = "active"
+	ServerStatusDeprecated = "deprecated"
+	ServerStatusYanked     = "yanked"
+)
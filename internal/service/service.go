@@ -3,6 +3,7 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -68,8 +69,55 @@ var (
 	ErrClaimsInsufficient = errors.New("insufficient claims")
 	// ErrInvalidEntryType is returned when an unsupported entry type string is supplied to an option
 	ErrInvalidEntryType = errors.New("invalid entry type")
+	// ErrSourceNotSyncable is returned when requesting a sync for a source that does not sync
+	ErrSourceNotSyncable = errors.New("source is not syncable")
+	// ErrSyncRequestNotFound is returned when a sync request is not found
+	ErrSyncRequestNotFound = errors.New("sync request not found")
+	// ErrInvalidCursor is returned when a pagination cursor cannot be decoded
+	ErrInvalidCursor = errors.New("invalid cursor")
 	// ErrInvalidServerName is returned when a server name fails format validation
 	ErrInvalidServerName = errors.New("invalid server name")
+	// ErrInvalidServerStatus is returned when a server version status is not supported
+	ErrInvalidServerStatus = errors.New("invalid server status")
+	// ErrInvalidChangeToken is returned when a change feed token cannot be decoded
+	ErrInvalidChangeToken = errors.New("invalid change token")
+	// ErrChangeTokenExpired is returned when changes after a change feed token were pruned
+	ErrChangeTokenExpired = errors.New("change token expired")
+	// ErrInvalidDeliveryStatus is returned when a webhook delivery status is not supported
+	ErrInvalidDeliveryStatus = errors.New("invalid delivery status")
+	// ErrPublishPendingReview is returned when a publish was held for review instead of being published
+	ErrPublishPendingReview = errors.New("publish is pending review")
+	// ErrSubmissionNotFound is returned when a publish submission is not found
+	ErrSubmissionNotFound = errors.New("submission not found")
+	// ErrSubmissionNotPending is returned when reviewing a submission that was already reviewed
+	ErrSubmissionNotPending = errors.New("submission is not pending review")
+	// ErrSubmissionSelfReview is returned when the submitter of a submission attempts to review it
+	ErrSubmissionSelfReview = errors.New("submissions cannot be reviewed by their submitter")
+	// ErrInvalidSubmissionStatus is returned when a submission status is not supported
+	ErrInvalidSubmissionStatus = errors.New("invalid submission status")
+	// ErrSignatureRequired is returned when publishing an unsigned entry into a namespace requiring signatures
+	ErrSignatureRequired = errors.New("signature is required")
+	// ErrInvalidSignature is returned when the signature attached to a publish cannot be verified
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrNamespaceNotFound is returned when a namespace is not found
+	ErrNamespaceNotFound = errors.New("namespace not found")
+	// ErrNamespaceAlreadyExists is returned when attempting to reserve a namespace that already exists
+	ErrNamespaceAlreadyExists = errors.New("namespace already exists")
+	// ErrInvalidNamespace is returned when a namespace name or its claims are invalid
+	ErrInvalidNamespace = errors.New("invalid namespace")
+	// ErrNamespaceNotOwned is returned when the caller does not own the namespace of an entry
+	ErrNamespaceNotOwned = errors.New("namespace is not owned by the caller")
+	// ErrOverlayNotFound is returned when a registry has no overlay for a server
+	ErrOverlayNotFound = errors.New("overlay not found")
+	// ErrOverlayAlreadyExists is returned when attempting to create an overlay that already exists
+	ErrOverlayAlreadyExists = errors.New("overlay already exists")
+	// ErrInvalidOverlay is returned when an overlay is invalid
+	ErrInvalidOverlay = errors.New("invalid overlay")
+	// ErrEntryBlocked is returned when the entry policy blocks a published entry version
+	ErrEntryBlocked = errors.New("entry blocked by the entry policy")
+	// ErrDigestPinningRequired is returned when a published entry version has an
+	// OCI package that is not pinned to a digest, as required by a registry
+	ErrDigestPinningRequired = errors.New("digest pinning required")
 )
 
 //go:generate mockgen -destination=mocks/mock_service.go -package=mocks -source=service.go Service
@@ -87,11 +135,12 @@ type RegistryService interface {
 	// ListServers returns all servers in the registry with pagination info
 	ListServers(ctx context.Context, opts ...Option) (*ListServersResult, error)
 
-	// ListServerVersions returns all versions of a specific server
-	ListServerVersions(ctx context.Context, opts ...Option) ([]*upstreamv0.ServerJSON, error)
+	// ListServerVersions returns all versions of a specific server, including
+	// deprecated and yanked ones
+	ListServerVersions(ctx context.Context, opts ...Option) ([]*upstreamv0.ServerResponse, error)
 
 	// GetServerVersion returns a specific server version by name
-	GetServerVersion(ctx context.Context, opts ...Option) (*upstreamv0.ServerJSON, error)
+	GetServerVersion(ctx context.Context, opts ...Option) (*upstreamv0.ServerResponse, error)
 
 	// PublishServerVersion publishes a server version to a managed registry
 	PublishServerVersion(ctx context.Context, opts ...Option) (*upstreamv0.ServerJSON, error)
@@ -99,6 +148,10 @@ type RegistryService interface {
 	// DeleteServerVersion removes a server version from a managed registry
 	DeleteServerVersion(ctx context.Context, opts ...Option) error
 
+	// UpdateServerVersionStatus deprecates or yanks a server version in a managed
+	// registry, or makes it active again
+	UpdateServerVersionStatus(ctx context.Context, opts ...Option) error
+
 	// ********** SOURCE OPERATIONS **********
 
 	// ListSources returns all configured sources
@@ -119,6 +172,20 @@ type RegistryService interface {
 	// ListSourceEntries returns all entries for a source (unshadowed, all types)
 	ListSourceEntries(ctx context.Context, sourceName string) ([]SourceEntryInfo, error)
 
+	// ExportSource passes every entry version of a source, with its claims, to
+	// emit: servers first, then skills, then plugins
+	ExportSource(ctx context.Context, sourceName string, emit func(*PortableEntry) error) error
+
+	// RequestSourceSync queues a manual sync for a source. A request that is still
+	// pending is returned instead of queueing a new one.
+	RequestSourceSync(ctx context.Context, name string) (*SyncRequestInfo, error)
+
+	// GetSourceSyncRequest returns a manual sync request of a source by ID
+	GetSourceSyncRequest(ctx context.Context, name string, id string) (*SyncRequestInfo, error)
+
+	// ListSourceSyncs returns the sync history of a source, newest first, with pagination info
+	ListSourceSyncs(ctx context.Context, name string, opts ...Option) (*SourceSyncListResponse, error)
+
 	// ********** REGISTRY OPERATIONS **********
 
 	// ListRegistries returns all configured registries
@@ -139,6 +206,30 @@ type RegistryService interface {
 	// ListRegistryEntries returns all entries across a registry's linked sources (unshadowed, lightweight)
 	ListRegistryEntries(ctx context.Context, registryName string) ([]RegistryEntryInfo, error)
 
+	// ExportRegistry returns the entry versions a registry serves with their claims, for replication.
+	// With a change token, only the versions changed after it are returned, along with the versions
+	// no longer served.
+	ExportRegistry(ctx context.Context, registryName, since string) (*RegistryExport, error)
+
+	// ListServerOverlays returns the overlays of the servers of a registry
+	ListServerOverlays(ctx context.Context, registryName string) ([]ServerOverlayInfo, error)
+
+	// GetServerOverlay returns the overlay of a server of a registry
+	GetServerOverlay(ctx context.Context, registryName, serverName string) (*ServerOverlayInfo, error)
+
+	// CreateServerOverlay attaches an overlay to a server of a registry
+	CreateServerOverlay(
+		ctx context.Context, registryName, serverName string, req *ServerOverlayRequest,
+	) (*ServerOverlayInfo, error)
+
+	// UpdateServerOverlay replaces the overlay of a server of a registry
+	UpdateServerOverlay(
+		ctx context.Context, registryName, serverName string, req *ServerOverlayRequest,
+	) (*ServerOverlayInfo, error)
+
+	// DeleteServerOverlay removes the overlay of a server of a registry
+	DeleteServerOverlay(ctx context.Context, registryName, serverName string) error
+
 	// ProcessInlineSourceData processes inline data for a managed/file registry
 	ProcessInlineSourceData(ctx context.Context, name string, data string) error
 
@@ -172,6 +263,18 @@ type RegistryService interface {
 	// DeletePluginVersion deletes a plugin version
 	DeletePluginVersion(ctx context.Context, opts ...Option) error
 
+	// ********** SEARCH OPERATIONS **********
+
+	// SearchEntries searches the servers, skills and plugins of a registry,
+	// ordered by relevance, with cursor-based pagination
+	SearchEntries(ctx context.Context, opts ...Option) (*SearchEntriesResult, error)
+
+	// ********** CHANGE FEED OPERATIONS **********
+
+	// ListEntryChanges returns the changes of the servers, skills and plugins of a
+	// registry made after a change token, oldest first
+	ListEntryChanges(ctx context.Context, opts ...Option) (*EntryChangesResult, error)
+
 	// UpdateEntryClaims updates the claims on a published entry within the managed source.
 	UpdateEntryClaims(ctx context.Context, opts ...Option) error
 
@@ -180,6 +283,46 @@ type RegistryService interface {
 	// Returns ErrInvalidEntryType for unknown entry types, ErrNotFound when the entry
 	// does not exist, and ErrNoManagedSource when no managed source is configured.
 	GetEntryClaims(ctx context.Context, opts ...Option) (map[string]any, error)
+
+	// BulkPublishEntries publishes entries into the managed source, reporting the
+	// outcome of each. With WithAtomic, either all of them are published or none.
+	BulkPublishEntries(ctx context.Context, entries []PortableEntry, opts ...Option) (*BulkPublishResult, error)
+
+	// ********** SUBMISSION OPERATIONS **********
+
+	// ListEntrySubmissions returns the publish submissions held for review by the
+	// managed source, oldest first, with pagination info
+	ListEntrySubmissions(ctx context.Context, opts ...Option) (*EntrySubmissionListResponse, error)
+
+	// ApproveEntrySubmission publishes the entry of a pending submission into the
+	// managed source
+	ApproveEntrySubmission(ctx context.Context, id string, opts ...Option) (*EntrySubmission, error)
+
+	// RejectEntrySubmission rejects a pending submission, discarding its entry
+	RejectEntrySubmission(ctx context.Context, id string, opts ...Option) (*EntrySubmission, error)
+
+	// ********** NAMESPACE OPERATIONS **********
+
+	// ListNamespaces returns all reserved namespaces
+	ListNamespaces(ctx context.Context) ([]NamespaceInfo, error)
+
+	// GetNamespace returns a reserved namespace by name
+	GetNamespace(ctx context.Context, name string) (*NamespaceInfo, error)
+
+	// CreateNamespace reserves a namespace for the callers covering its claims
+	CreateNamespace(ctx context.Context, name string, req *NamespaceRequest) (*NamespaceInfo, error)
+
+	// UpdateNamespace updates the claims of a reserved namespace
+	UpdateNamespace(ctx context.Context, name string, req *NamespaceRequest) (*NamespaceInfo, error)
+
+	// DeleteNamespace releases a reserved namespace
+	DeleteNamespace(ctx context.Context, name string) error
+
+	// ********** NOTIFICATION OPERATIONS **********
+
+	// ListWebhookDeliveries returns the delivery log of the outbound webhooks,
+	// newest first, with pagination info
+	ListWebhookDeliveries(ctx context.Context, opts ...Option) (*WebhookDeliveryListResponse, error)
 }
 
 // SourceInfo represents detailed information about a source
@@ -187,7 +330,7 @@ type SourceInfo struct {
 	Name         string               `json:"name"`
 	Type         string               `json:"type"`                   // MANAGED, FILE, REMOTE, KUBERNETES
 	CreationType CreationType         `json:"creationType,omitempty"` // API or CONFIG
-	SourceType   config.SourceType    `json:"sourceType,omitempty"`   // git, api, file, managed, kubernetes
+	SourceType   config.SourceType    `json:"sourceType,omitempty"`   // git, api, file, bundle, managed, kubernetes
 	SourceConfig any                  `json:"sourceConfig,omitempty"` // Type-specific source configuration
 	FilterConfig *config.FilterConfig `json:"filterConfig,omitempty"` // Filtering rules
 	SyncSchedule string               `json:"syncSchedule,omitempty"` // Sync interval string
@@ -199,12 +342,13 @@ type SourceInfo struct {
 
 // RegistryInfo represents detailed information about a registry
 type RegistryInfo struct {
-	Name         string         `json:"name"`
-	Claims       map[string]any `json:"claims,omitempty"`
-	CreationType CreationType   `json:"creationType,omitempty"`
-	Sources      []string       `json:"sources"`
-	CreatedAt    time.Time      `json:"createdAt"`
-	UpdatedAt    time.Time      `json:"updatedAt"`
+	Name         string              `json:"name"`
+	Claims       map[string]any      `json:"claims,omitempty"`
+	CreationType CreationType        `json:"creationType,omitempty"`
+	Sources      []string            `json:"sources"`
+	Merge        *config.MergeConfig `json:"merge,omitempty"` // Merge strategies, first-wins when unset
+	CreatedAt    time.Time           `json:"createdAt"`
+	UpdatedAt    time.Time           `json:"updatedAt"`
 }
 
 // SourceSyncStatus represents the sync status of a registry
@@ -217,6 +361,87 @@ type SourceSyncStatus struct {
 	SkillCount   int        `json:"skillCount"`             // Number of skills in registry
 	PluginCount  int        `json:"pluginCount"`            // Number of plugins in registry
 	Message      string     `json:"message,omitempty"`      // Status or error message
+	CommitSHA    string     `json:"commitSha,omitempty"`    // Git commit of the last successful sync
+}
+
+// SyncRequestInfo represents a manual sync request for a source
+type SyncRequestInfo struct {
+	ID          string     `json:"id"`
+	SourceName  string     `json:"sourceName"`
+	Status      string     `json:"status"`              // pending, syncing, complete, failed
+	Message     string     `json:"message,omitempty"`   // Error message of a failed sync
+	RequestedAt time.Time  `json:"requestedAt"`         // When the sync was requested
+	StartedAt   *time.Time `json:"startedAt,omitempty"` // When the coordinator picked up the request
+	EndedAt     *time.Time `json:"endedAt,omitempty"`   // When the sync finished
+}
+
+// SourceSyncInfo represents a single sync attempt from the sync history of a source
+type SourceSyncInfo struct {
+	ID              string          `json:"id"`
+	Status          string          `json:"status"`                    // complete, failed
+	Reason          string          `json:"reason"`                    // Why the sync was triggered
+	ConditionReason string          `json:"conditionReason,omitempty"` // Failure classification
+	Message         string          `json:"message,omitempty"`         // Error message of a failed sync
+	Hash            string          `json:"hash,omitempty"`            // Hash of the synced data
+	Incremental     bool            `json:"incremental"`               // Only changed entries were fetched
+	ServerCount     int             `json:"serverCount"`               // Number of servers after the sync
+	SkillCount      int             `json:"skillCount"`                // Number of skills after the sync
+	PluginCount     int             `json:"pluginCount"`               // Number of plugins after the sync
+	Changes         SyncChangesInfo `json:"changes"`                   // Entry versions changed by the sync
+	StartedAt       time.Time       `json:"startedAt"`
+	EndedAt         time.Time       `json:"endedAt"`
+}
+
+// SyncChangesInfo counts the entry versions changed by a sync, per entry type
+type SyncChangesInfo struct {
+	Servers EntryChangesInfo `json:"servers"`
+	Skills  EntryChangesInfo `json:"skills"`
+	Plugins EntryChangesInfo `json:"plugins"`
+}
+
+// EntryChangesInfo counts the entry versions of one type changed by a sync
+type EntryChangesInfo struct {
+	Added   int `json:"added"`
+	Updated int `json:"updated"`
+	Removed int `json:"removed"`
+}
+
+// SourceSyncListResponse represents the response for listing the sync history of a source
+type SourceSyncListResponse struct {
+	Syncs []SourceSyncInfo `json:"syncs"`
+	// NextCursor is the cursor to use for fetching the next page of results.
+	// Empty string indicates no more results are available.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// Statuses of webhook deliveries
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusFailed    = "failed"
+)
+
+// WebhookDeliveryInfo represents the delivery of an event to an outbound webhook
+type WebhookDeliveryInfo struct {
+	ID             string          `json:"id"`
+	Webhook        string          `json:"webhook"`
+	EventType      string          `json:"eventType"`
+	Status         string          `json:"status"`                   // pending, delivered, failed
+	Attempts       int             `json:"attempts"`                 // Number of attempts made so far
+	ResponseStatus int             `json:"responseStatus,omitempty"` // HTTP status of the last attempt
+	LastError      string          `json:"lastError,omitempty"`      // Error of the last failed attempt
+	NextAttemptAt  *time.Time      `json:"nextAttemptAt,omitempty"`  // When a pending delivery is attempted next
+	CreatedAt      time.Time       `json:"createdAt"`                // When the event was queued
+	DeliveredAt    *time.Time      `json:"deliveredAt,omitempty"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"` // Body of the delivery
+}
+
+// WebhookDeliveryListResponse represents the response for listing webhook deliveries
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDeliveryInfo `json:"deliveries"`
+	// NextCursor is the cursor to use for fetching the next page of results.
+	// Empty string indicates no more results are available.
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 // SourceListResponse represents the response for listing sources
@@ -228,7 +453,7 @@ type SourceListResponse struct {
 // It wraps the server list with cursor-based pagination metadata.
 type ListServersResult struct {
 	// Servers is the list of servers matching the query
-	Servers []*upstreamv0.ServerJSON
+	Servers []*upstreamv0.ServerResponse
 	// NextCursor is the cursor to use for fetching the next page of results.
 	// Empty string indicates no more results are available.
 	NextCursor string
@@ -257,16 +482,22 @@ type SourceEntriesResponse struct {
 }
 
 // RegistryEntryInfo represents a lightweight entry in a registry listing.
+// FieldSources is set on the entry versions the registry serves and maps each
+// field (entry, meta, icons, description) to the name of the source contributing it
+// under the merge strategies of the registry. It is empty for shadowed versions.
+// Scan is the scan of the version by the entry policy, if any.
 type RegistryEntryInfo struct {
-	EntryType   string    `json:"entryType"`
-	Name        string    `json:"name"`
-	Version     string    `json:"version"`
-	Title       string    `json:"title,omitempty"`
-	Description string    `json:"description,omitempty"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
-	SourceName  string    `json:"sourceName"`
-	Position    int32     `json:"position"`
+	EntryType    string            `json:"entryType"`
+	Name         string            `json:"name"`
+	Version      string            `json:"version"`
+	Title        string            `json:"title,omitempty"`
+	Description  string            `json:"description,omitempty"`
+	CreatedAt    time.Time         `json:"createdAt"`
+	UpdatedAt    time.Time         `json:"updatedAt"`
+	SourceName   string            `json:"sourceName"`
+	Position     int32             `json:"position"`
+	FieldSources map[string]string `json:"fieldSources,omitempty"`
+	Scan         *EntryScanResult  `json:"scan,omitempty"`
 }
 
 // RegistryEntriesResponse is the JSON envelope for listing registry entries.
@@ -0,0 +1,32 @@
+// Package service defines the entry signature types of the service layer.
+package service
+
+import "time"
+
+// SignatureMetaKey is the _meta key under which the verified signature of an
+// entry version is reported. For servers, it is a key of the publisher-provided
+// metadata. Versions published without a verified signature have no such key:
+// it is never taken from the metadata supplied by publishers.
+const SignatureMetaKey = "io.github.stacklok/signature"
+
+// EntrySignature is a signature attached to a publish. It is made over the
+// JSON encoding of the published server, skill or plugin, as sent by the
+// publisher.
+type EntrySignature struct {
+	// Format is jws, for a JWS with detached payload in compact serialization,
+	// or cosign, for the base64 encoded output of cosign sign-blob
+	Format string `json:"format" enums:"jws,cosign"`
+	// Value is the signature
+	Value string `json:"value"`
+	// Payload is the signed JSON encoding of the entry
+	Payload []byte `json:"-"`
+}
+
+// SignatureVerification is the verified signature of an entry version,
+// reported in its _meta under SignatureMetaKey.
+type SignatureVerification struct {
+	Verified   bool      `json:"verified"`
+	Format     string    `json:"format"`
+	KeyID      string    `json:"keyId"` // sha256 digest of the DER encoded public key
+	VerifiedAt time.Time `json:"verifiedAt"`
+}
@@ -20,6 +20,7 @@ type SourceCreateRequest struct {
 	Git        *config.GitConfig        `json:"git,omitempty"`        // Git repository source
 	API        *config.APIConfig        `json:"api,omitempty"`        // API endpoint source
 	File       *config.FileConfig       `json:"file,omitempty"`       // Local file or URL source
+	Bundle     *config.BundleConfig     `json:"bundle,omitempty"`     // Signed bundle import source
 	Managed    *config.ManagedConfig    `json:"managed,omitempty"`    // Managed registry (no sync)
 	Kubernetes *config.KubernetesConfig `json:"kubernetes,omitempty"` // Kubernetes discovery source
 	SyncPolicy *config.SyncPolicyConfig `json:"syncPolicy,omitempty"` // Sync schedule configuration
@@ -36,6 +37,8 @@ func (r *SourceCreateRequest) GetSourceType() config.SourceType {
 		return config.SourceTypeAPI
 	case r.File != nil:
 		return config.SourceTypeFile
+	case r.Bundle != nil:
+		return config.SourceTypeBundle
 	case r.Managed != nil:
 		return config.SourceTypeManaged
 	case r.Kubernetes != nil:
@@ -57,6 +60,9 @@ func (r *SourceCreateRequest) CountSourceTypes() int {
 	if r.File != nil {
 		count++
 	}
+	if r.Bundle != nil {
+		count++
+	}
 	if r.Managed != nil {
 		count++
 	}
@@ -94,6 +100,8 @@ func (r *SourceCreateRequest) GetSourceConfig() any {
 		return r.API
 	case r.File != nil:
 		return r.File
+	case r.Bundle != nil:
+		return r.Bundle
 	case r.Managed != nil:
 		return r.Managed
 	case r.Kubernetes != nil:
@@ -116,6 +124,7 @@ type DeployedServer struct {
 
 // RegistryCreateRequest represents the request body for creating or updating a registry
 type RegistryCreateRequest struct {
-	Sources []string       `json:"sources"`          // ordered list of source names
-	Claims  map[string]any `json:"claims,omitempty"` // Authorization claims
+	Sources []string            `json:"sources"`          // ordered list of source names
+	Claims  map[string]any      `json:"claims,omitempty"` // Authorization claims
+	Merge   *config.MergeConfig `json:"merge,omitempty"`  // Merge strategies, first-wins when unset
 }
@@ -0,0 +1,61 @@
+// Package service defines the publish submission types returned by the service layer.
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Statuses of publish submissions
+const (
+	SubmissionStatusPending  = "pending"
+	SubmissionStatusApproved = "approved"
+	SubmissionStatusRejected = "rejected"
+)
+
+// EntrySubmission is a publish into the managed source held for review, because
+// the source requires approval. The entry is only published once approved.
+type EntrySubmission struct {
+	ID            string          `json:"id"`
+	EntryType     string          `json:"entryType"`           // EntryTypeServer, EntryTypeSkill, or EntryTypePlugin
+	Namespace     string          `json:"namespace,omitempty"` // skills and plugins only
+	Name          string          `json:"name"`
+	Version       string          `json:"version"`
+	Status        string          `json:"status"` // pending, approved, rejected
+	Claims        map[string]any  `json:"claims,omitempty"`
+	Entry         json.RawMessage `json:"entry" swaggertype:"object"` // Published server, skill or plugin
+	SubmittedBy   string          `json:"submittedBy,omitempty"`
+	SubmittedAt   time.Time       `json:"submittedAt"`
+	ReviewedBy    string          `json:"reviewedBy,omitempty"`
+	ReviewComment string          `json:"reviewComment,omitempty"`
+	ReviewedAt    *time.Time      `json:"reviewedAt,omitempty"`
+	// Signature is the verified signature attached to the publish, if any
+	Signature *SignatureVerification `json:"signature,omitempty"`
+}
+
+// EntrySubmissionListResponse represents the response for listing publish submissions
+type EntrySubmissionListResponse struct {
+	Submissions []EntrySubmission `json:"submissions"`
+	// NextCursor is the cursor to use for fetching the next page of results.
+	// Empty string indicates no more results are available.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// PendingReviewError is returned by the publish operations when the managed
+// source requires approval: the entry was held for review as Submission
+// instead of being published. It wraps ErrPublishPendingReview.
+type PendingReviewError struct {
+	Submission *EntrySubmission
+}
+
+// Error implements the error interface.
+func (e *PendingReviewError) Error() string {
+	return fmt.Sprintf("%s %s %s: %s", e.Submission.EntryType, e.Submission.Name, e.Submission.Version,
+		ErrPublishPendingReview)
+}
+
+// Unwrap returns ErrPublishPendingReview.
+func (*PendingReviewError) Unwrap() error {
+	return ErrPublishPendingReview
+}
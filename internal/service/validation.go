@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/filtering"
 	"github.com/stacklok/toolhive-registry-server/internal/sources"
 )
 
@@ -24,6 +25,9 @@ func ValidateRegistryConfig(req *RegistryCreateRequest) error {
 		}
 		seen[s] = struct{}{}
 	}
+	if err := req.Merge.Validate(); err != nil {
+		return fmt.Errorf("invalid merge: %w", err)
+	}
 	return nil
 }
 
@@ -44,7 +48,7 @@ func ValidateSourceConfig(req *SourceCreateRequest) error {
 	// Exactly one source type must be set
 	sourceCount := req.CountSourceTypes()
 	if sourceCount == 0 {
-		return fmt.Errorf("one of git, api, file, managed, or kubernetes must be specified")
+		return fmt.Errorf("one of git, api, file, bundle, managed, or kubernetes must be specified")
 	}
 	if sourceCount > 1 {
 		return fmt.Errorf("only one source type may be specified")
@@ -58,6 +62,9 @@ func ValidateSourceConfig(req *SourceCreateRequest) error {
 		if _, err := time.ParseDuration(req.SyncPolicy.Interval); err != nil {
 			return fmt.Errorf("invalid sync interval: %w", err)
 		}
+		if err := filtering.ValidateFilterConfig(req.Filter); err != nil {
+			return fmt.Errorf("invalid filter: %w", err)
+		}
 	}
 
 	// Source-specific validation
@@ -73,9 +80,11 @@ func validateSourceSpecific(req *SourceCreateRequest) error {
 		return validateAPIConfig(req.API)
 	case config.SourceTypeFile:
 		return validateFileConfig(req.File)
+	case config.SourceTypeBundle:
+		return req.Bundle.Validate()
 	case config.SourceTypeManaged:
 		// Managed registries have no required fields
-		return nil
+		return req.Managed.Validate()
 	case config.SourceTypeKubernetes:
 		// Kubernetes registries have no required fields
 		return nil
@@ -114,6 +123,13 @@ func validateGitConfig(cfg *config.GitConfig) error {
 		if err := cfg.Auth.Validate(); err != nil {
 			return err
 		}
+		if err := cfg.Auth.ValidateRepository(cfg.Repository); err != nil {
+			return err
+		}
+	}
+
+	if err := cfg.Webhook.Validate(); err != nil {
+		return err
 	}
 
 	return nil
@@ -129,7 +145,11 @@ func validateAPIConfig(cfg *config.APIConfig) error {
 		return fmt.Errorf("api.endpoint is required")
 	}
 
-	return config.ValidateAPITimeout(cfg.Timeout)
+	if err := config.ValidateAPITimeout(cfg.Timeout); err != nil {
+		return err
+	}
+
+	return config.ValidateAPIIncremental(cfg.Incremental)
 }
 
 // validateFileConfig validates File source configuration
@@ -31,7 +31,7 @@ func TestValidateSourceConfig(t *testing.T) {
 			name:    "no_source_type_returns_error",
 			req:     &SourceCreateRequest{},
 			wantErr: true,
-			errMsg:  "one of git, api, file, managed, or kubernetes must be specified",
+			errMsg:  "one of git, api, file, bundle, managed, or kubernetes must be specified",
 		},
 
 		// Multiple source types specified
@@ -300,6 +300,24 @@ func TestValidateSourceConfig(t *testing.T) {
 			},
 			wantErr: false,
 		},
+
+		// Filter expressions must compile
+		{
+			name: "invalid_filter_expression",
+			req: &SourceCreateRequest{
+				API: &config.APIConfig{
+					Endpoint: "https://api.example.com",
+				},
+				SyncPolicy: &config.SyncPolicyConfig{
+					Interval: "30m",
+				},
+				Filter: &config.FilterConfig{
+					Expressions: &config.ExpressionFilterConfig{Include: []string{`entry.type ==`}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid filter",
+		},
 	}
 
 	for _, tt := range tests {
@@ -319,6 +337,59 @@ func TestValidateSourceConfig(t *testing.T) {
 	}
 }
 
+func TestValidateRegistryConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		req     *RegistryCreateRequest
+		wantErr string
+	}{
+		{
+			name:    "nil request",
+			wantErr: "config is required",
+		},
+		{
+			name:    "no sources",
+			req:     &RegistryCreateRequest{},
+			wantErr: "at least one source is required",
+		},
+		{
+			name:    "duplicate source",
+			req:     &RegistryCreateRequest{Sources: []string{"a", "a"}},
+			wantErr: "duplicate source: a",
+		},
+		{
+			name: "valid merge strategies",
+			req: &RegistryCreateRequest{
+				Sources: []string{"a", "b"},
+				Merge:   &config.MergeConfig{Versions: config.MergeHighestVersion, Icons: config.MergeOverlay},
+			},
+		},
+		{
+			name: "unsupported merge strategy",
+			req: &RegistryCreateRequest{
+				Sources: []string{"a", "b"},
+				Merge:   &config.MergeConfig{Description: config.MergeHighestVersion},
+			},
+			wantErr: "invalid merge: description: unsupported strategy 'highest-version'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateRegistryConfig(tt.req)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
 // TestValidateSourceConfig_IgnoresName proves this validator is name-agnostic.
 // The DNS-subdomain gate lives in config.ValidateSourceName and is applied only
 // where a name is chosen — config load, and the insert path in CreateSource.
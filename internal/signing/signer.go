@@ -0,0 +1,111 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Signer signs payloads with a private key, as JWS with detached payload that
+// a Verifier trusting the matching public key accepts.
+type Signer struct {
+	method jwt.SigningMethod
+	key    crypto.Signer
+	keyID  string
+}
+
+// NewSigner creates a signer from a PEM encoded ECDSA, Ed25519 or RSA private
+// key, in PKCS #8, SEC 1 or PKCS #1 form. ECDSA keys sign with the algorithm
+// matching their curve, RSA keys with RS256 and Ed25519 keys with EdDSA.
+func NewSigner(rawKey string) (*Signer, error) {
+	block, _ := pem.Decode([]byte(rawKey))
+	if block == nil {
+		return nil, fmt.Errorf("not a PEM encoded key")
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	var method jwt.SigningMethod
+	switch key := key.(type) {
+	case *ecdsa.PrivateKey:
+		switch key.Curve {
+		case elliptic.P256():
+			method = jwt.SigningMethodES256
+		case elliptic.P384():
+			method = jwt.SigningMethodES384
+		case elliptic.P521():
+			method = jwt.SigningMethodES512
+		default:
+			return nil, fmt.Errorf("unsupported ECDSA curve %s", key.Curve.Params().Name)
+		}
+	case ed25519.PrivateKey:
+		method = jwt.SigningMethodEdDSA
+	case *rsa.PrivateKey:
+		method = jwt.SigningMethodRS256
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+
+	signer := key.(crypto.Signer)
+	der, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	digest := sha256.Sum256(der)
+	return &Signer{
+		method: method,
+		key:    signer,
+		keyID:  "sha256:" + hex.EncodeToString(digest[:]),
+	}, nil
+}
+
+// KeyID identifies the key of the signer, as reported by the verification of
+// its signatures
+func (s *Signer) KeyID() string {
+	return s.keyID
+}
+
+// Sign signs payload, returning a JWS with detached payload in compact
+// serialization. The ID of the key is carried in the kid header parameter.
+func (s *Signer) Sign(payload []byte) (*Signature, error) {
+	header, err := json.Marshal(struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}{Alg: s.method.Alg(), Kid: s.keyID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode JWS header: %w", err)
+	}
+
+	encodedHeader := base64.RawURLEncoding.EncodeToString(header)
+	sig, err := s.method.Sign(encodedHeader+"."+base64.RawURLEncoding.EncodeToString(payload), s.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign payload: %w", err)
+	}
+	return &Signature{
+		Format: FormatJWS,
+		Value:  encodedHeader + ".." + base64.RawURLEncoding.EncodeToString(sig),
+	}, nil
+}
@@ -0,0 +1,317 @@
+// Package signing verifies the signatures attached to the entries published
+// into a managed source, and signs the bundles exported for replication.
+//
+// A signature is made over the payload of the entry: its JSON encoding, as sent
+// by the publisher. It is verified against the public keys trusted for the
+// longest namespace prefix matching the name of the entry at a "." or "/"
+// boundary. Two formats are supported:
+//
+//   - jws: a JWS with detached payload (RFC 7515, Appendix F) in compact
+//     serialization, signed with an asymmetric algorithm (ES256, ES384, ES512,
+//     EdDSA, RS256, RS384, RS512, PS256, PS384 or PS512)
+//   - cosign: the base64 encoded signature produced by
+//     `cosign sign-blob --key`, with an ECDSA, Ed25519 or RSA key
+//
+// Keyless cosign signatures, backed by Fulcio certificates and the Rekor
+// transparency log, are not supported.
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Signature formats
+const (
+	// FormatJWS is a JWS with detached payload in compact serialization
+	FormatJWS = "jws"
+	// FormatCosign is a signature produced by cosign sign-blob
+	FormatCosign = "cosign"
+)
+
+var (
+	// ErrSignatureRequired is returned when an unsigned entry is published
+	// into a namespace requiring signatures
+	ErrSignatureRequired = errors.New("signature is required")
+	// ErrUntrustedNamespace is returned when a signed entry is published into a
+	// namespace without trusted keys
+	ErrUntrustedNamespace = errors.New("no key is trusted to sign entries of this namespace")
+	// ErrUnsupportedFormat is returned for a signature of an unknown format
+	ErrUnsupportedFormat = errors.New("unsupported signature format")
+	// ErrSignatureMismatch is returned when a signature was not made over the
+	// payload with any of the trusted keys
+	ErrSignatureMismatch = errors.New("signature does not match any trusted key")
+)
+
+// namespacePattern matches the namespace prefixes keys can be trusted for:
+// segments separated by "." or "/", optionally ending with either
+var namespacePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+([./][A-Za-z0-9_-]+)*[./]?$`)
+
+// jwsAlgorithms are the JWS algorithms accepted for signatures. Symmetric and
+// unsigned algorithms are excluded: the verifier only holds public keys.
+var jwsAlgorithms = []string{
+	"ES256", "ES384", "ES512",
+	"EdDSA",
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+}
+
+// Signature is a signature attached to a published entry
+type Signature struct {
+	// Format is the format of the signature: jws or cosign
+	Format string
+	// Value is the signature, encoded according to its format
+	Value string
+}
+
+// Verification is the outcome of the successful verification of a signature
+type Verification struct {
+	// Format is the format of the signature
+	Format string `json:"format"`
+	// KeyID identifies the trusted key that made the signature: the hex
+	// encoded SHA-256 digest of its DER encoding, prefixed with "sha256:"
+	KeyID string `json:"keyId"`
+	// Signature is the verified signature
+	Signature string `json:"signature"`
+}
+
+// Namespace lists the public keys trusted to sign the entries of a namespace
+// prefix
+type Namespace struct {
+	// Prefix is the namespace prefix of the entry names
+	Prefix string
+	// PublicKeys are the PEM encoded ECDSA, Ed25519 or RSA public keys
+	PublicKeys []string
+	// RequireSignature rejects the unsigned entries of the namespace
+	RequireSignature bool
+}
+
+// trustedKey is a public key trusted to sign the entries of a namespace
+type trustedKey struct {
+	id  string
+	key crypto.PublicKey
+}
+
+// namespace holds the trusted keys of a namespace prefix
+type namespace struct {
+	prefix           string
+	keys             []trustedKey
+	requireSignature bool
+}
+
+// Verifier verifies the signatures of published entries against the keys
+// trusted for their namespace.
+type Verifier struct {
+	namespaces []namespace
+}
+
+// NewVerifier creates a verifier trusting the keys of the given namespaces.
+func NewVerifier(namespaces []Namespace) (*Verifier, error) {
+	trusted := make([]namespace, 0, len(namespaces))
+	for _, cfg := range namespaces {
+		ns := namespace{
+			prefix:           cfg.Prefix,
+			requireSignature: cfg.RequireSignature,
+		}
+		for i, rawKey := range cfg.PublicKeys {
+			key, err := parsePublicKey(rawKey)
+			if err != nil {
+				return nil, fmt.Errorf("namespace %s: key %d: %w", cfg.Prefix, i, err)
+			}
+			ns.keys = append(ns.keys, key)
+		}
+		trusted = append(trusted, ns)
+	}
+
+	// Sort by decreasing prefix length, so that the first match is the longest
+	slices.SortStableFunc(trusted, func(a, b namespace) int {
+		return len(b.prefix) - len(a.prefix)
+	})
+	return &Verifier{namespaces: trusted}, nil
+}
+
+// ValidateNamespace checks that prefix is a namespace prefix: segments of
+// letters, digits, "_" and "-" separated by "." or "/". A prefix covers the
+// names it is followed in by "." or "/", so "com.example" covers
+// "com.example/weather" and "com.example.tools/weather" but not
+// "com.examplecorp/weather".
+func ValidateNamespace(prefix string) error {
+	if !namespacePattern.MatchString(prefix) {
+		return fmt.Errorf("invalid namespace %q: expected segments separated by '.' or '/'", prefix)
+	}
+	return nil
+}
+
+// ValidatePublicKey checks that key is a PEM encoded public key of a type
+// supported for signature verification.
+func ValidatePublicKey(key string) error {
+	_, err := parsePublicKey(key)
+	return err
+}
+
+// Verify verifies the signature of the entry named name over payload. It
+// returns nil without error for an unsigned entry, unless its namespace
+// requires signatures.
+func (v *Verifier) Verify(name string, payload []byte, sig *Signature) (*Verification, error) {
+	ns := v.match(name)
+	if sig == nil {
+		if ns != nil && ns.requireSignature {
+			return nil, ErrSignatureRequired
+		}
+		return nil, nil
+	}
+	if ns == nil {
+		return nil, ErrUntrustedNamespace
+	}
+
+	var verify func(payload []byte, value string, key crypto.PublicKey) error
+	switch sig.Format {
+	case FormatJWS:
+		verify = verifyJWS
+	case FormatCosign:
+		verify = verifyCosign
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, sig.Format)
+	}
+
+	for _, key := range ns.keys {
+		if err := verify(payload, sig.Value, key.key); err == nil {
+			return &Verification{
+				Format:    sig.Format,
+				KeyID:     key.id,
+				Signature: sig.Value,
+			}, nil
+		}
+	}
+	return nil, ErrSignatureMismatch
+}
+
+// match returns the namespace with the longest prefix of name, or nil. A
+// prefix only matches at a "." or "/" boundary of name.
+func (v *Verifier) match(name string) *namespace {
+	for i := range v.namespaces {
+		if coversName(v.namespaces[i].prefix, name) {
+			return &v.namespaces[i]
+		}
+	}
+	return nil
+}
+
+// coversName reports whether the namespace prefix covers name: name is the
+// prefix, or continues it after a "." or "/" ending the prefix or following it
+func coversName(prefix, name string) bool {
+	if name == prefix {
+		return true
+	}
+	if prefix == "" || !strings.HasPrefix(name, prefix) {
+		return false
+	}
+	return isNamespaceSeparator(prefix[len(prefix)-1]) || isNamespaceSeparator(name[len(prefix)])
+}
+
+// isNamespaceSeparator reports whether c separates the segments of a namespace
+func isNamespaceSeparator(c byte) bool {
+	return c == '.' || c == '/'
+}
+
+// parsePublicKey parses a PEM encoded ECDSA, Ed25519 or RSA public key.
+func parsePublicKey(rawKey string) (trustedKey, error) {
+	block, _ := pem.Decode([]byte(rawKey))
+	if block == nil {
+		return trustedKey{}, fmt.Errorf("not a PEM encoded key")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return trustedKey{}, fmt.Errorf("invalid public key: %w", err)
+	}
+	switch key.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey, *rsa.PublicKey:
+	default:
+		return trustedKey{}, fmt.Errorf("unsupported public key type %T", key)
+	}
+
+	digest := sha256.Sum256(block.Bytes)
+	return trustedKey{
+		id:  "sha256:" + hex.EncodeToString(digest[:]),
+		key: key,
+	}, nil
+}
+
+// verifyJWS verifies a JWS with detached payload: its signing input is the
+// protected header and the base64url encoded payload, joined by a dot.
+func verifyJWS(payload []byte, value string, key crypto.PublicKey) error {
+	parts := strings.Split(value, ".")
+	if len(parts) != 3 || parts[1] != "" {
+		return fmt.Errorf("signature is not a JWS with detached payload")
+	}
+
+	rawHeader, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return fmt.Errorf("invalid JWS header: %w", err)
+	}
+	var header struct {
+		Alg  string   `json:"alg"`
+		Crit []string `json:"crit"`
+	}
+	if err := json.Unmarshal(rawHeader, &header); err != nil {
+		return fmt.Errorf("invalid JWS header: %w", err)
+	}
+	// Extensions such as unencoded payloads (RFC 7797) are not understood
+	if len(header.Crit) > 0 {
+		return fmt.Errorf("unsupported critical JWS header parameters: %v", header.Crit)
+	}
+	if !slices.Contains(jwsAlgorithms, header.Alg) {
+		return fmt.Errorf("unsupported JWS algorithm %q", header.Alg)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return fmt.Errorf("invalid JWS signature: %w", err)
+	}
+	signingInput := parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return jwt.GetSigningMethod(header.Alg).Verify(signingInput, sig, key)
+}
+
+// verifyCosign verifies a signature produced by cosign sign-blob. ECDSA keys
+// sign the SHA-256 digest of the payload whatever their curve, RSA keys sign
+// it with PKCS #1 v1.5, and Ed25519 keys sign the payload.
+func verifyCosign(payload []byte, value string, key crypto.PublicKey) error {
+	sig, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %w", err)
+	}
+
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(payload)
+		if !ecdsa.VerifyASN1(key, digest[:], sig) {
+			return ErrSignatureMismatch
+		}
+		return nil
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, payload, sig) {
+			return ErrSignatureMismatch
+		}
+		return nil
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(payload)
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig)
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+}
@@ -0,0 +1,333 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// encodePublicKey returns the PEM encoding of a public key
+func encodePublicKey(t *testing.T, key crypto.PublicKey) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+// signJWS returns a JWS with detached payload
+func signJWS(t *testing.T, method jwt.SigningMethod, key crypto.PrivateKey, payload []byte) string {
+	t.Helper()
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"` + method.Alg() + `"}`))
+	sig, err := method.Sign(header+"."+base64.RawURLEncoding.EncodeToString(payload), key)
+	require.NoError(t, err)
+	return header + ".." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	t.Parallel()
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	payload := []byte(`{"name":"com.example/weather","version":"1.0.0"}`)
+	digest := sha256.Sum256(payload)
+	ecCosign, err := ecdsa.SignASN1(rand.Reader, ecKey, digest[:])
+	require.NoError(t, err)
+	// cosign signs the SHA-256 digest of the payload whatever the curve of the key
+	p384Cosign, err := ecdsa.SignASN1(rand.Reader, p384Key, digest[:])
+	require.NoError(t, err)
+	rsaCosign, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	verifier, err := NewVerifier([]Namespace{
+		{
+			Prefix: "com.example/",
+			PublicKeys: []string{
+				encodePublicKey(t, &ecKey.PublicKey),
+				encodePublicKey(t, edPub),
+				encodePublicKey(t, &rsaKey.PublicKey),
+				encodePublicKey(t, &p384Key.PublicKey),
+			},
+		},
+		{
+			Prefix:           "com.example/secure",
+			PublicKeys:       []string{encodePublicKey(t, &otherKey.PublicKey)},
+			RequireSignature: true,
+		},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		entry   string
+		payload []byte
+		sig     *Signature
+		wantErr error
+		wantKey crypto.PublicKey
+	}{
+		{
+			name:  "unsigned entry",
+			entry: "com.example/weather",
+		},
+		{
+			name:  "unsigned entry outside any namespace",
+			entry: "org.other/weather",
+		},
+		{
+			name:  "unsigned entry sharing the prefix of a namespace past its boundary",
+			entry: "com.example/secureweather",
+		},
+		{
+			name:    "unsigned entry in a namespace requiring signatures",
+			entry:   "com.example/secure.weather",
+			wantErr: ErrSignatureRequired,
+		},
+		{
+			name:    "signed entry outside any namespace",
+			entry:   "org.other/weather",
+			payload: payload,
+			sig:     &Signature{Format: FormatJWS, Value: signJWS(t, jwt.SigningMethodES256, ecKey, payload)},
+			wantErr: ErrUntrustedNamespace,
+		},
+		{
+			name:    "jws signed with ES256",
+			entry:   "com.example/weather",
+			payload: payload,
+			sig:     &Signature{Format: FormatJWS, Value: signJWS(t, jwt.SigningMethodES256, ecKey, payload)},
+			wantKey: &ecKey.PublicKey,
+		},
+		{
+			name:    "jws signed with ES384",
+			entry:   "com.example/weather",
+			payload: payload,
+			sig:     &Signature{Format: FormatJWS, Value: signJWS(t, jwt.SigningMethodES384, p384Key, payload)},
+			wantKey: &p384Key.PublicKey,
+		},
+		{
+			name:    "jws signed with EdDSA",
+			entry:   "com.example/weather",
+			payload: payload,
+			sig:     &Signature{Format: FormatJWS, Value: signJWS(t, jwt.SigningMethodEdDSA, edKey, payload)},
+			wantKey: edPub,
+		},
+		{
+			name:    "jws signed with PS256",
+			entry:   "com.example/weather",
+			payload: payload,
+			sig:     &Signature{Format: FormatJWS, Value: signJWS(t, jwt.SigningMethodPS256, rsaKey, payload)},
+			wantKey: &rsaKey.PublicKey,
+		},
+		{
+			name:    "cosign signature with an ECDSA key",
+			entry:   "com.example/weather",
+			payload: payload,
+			sig:     &Signature{Format: FormatCosign, Value: base64.StdEncoding.EncodeToString(ecCosign)},
+			wantKey: &ecKey.PublicKey,
+		},
+		{
+			name:    "cosign signature with a P-384 ECDSA key",
+			entry:   "com.example/weather",
+			payload: payload,
+			sig:     &Signature{Format: FormatCosign, Value: base64.StdEncoding.EncodeToString(p384Cosign)},
+			wantKey: &p384Key.PublicKey,
+		},
+		{
+			name:    "cosign signature with an Ed25519 key",
+			entry:   "com.example/weather",
+			payload: payload,
+			sig: &Signature{
+				Format: FormatCosign,
+				Value:  base64.StdEncoding.EncodeToString(ed25519.Sign(edKey, payload)),
+			},
+			wantKey: edPub,
+		},
+		{
+			name:    "cosign signature with an RSA key",
+			entry:   "com.example/weather",
+			payload: payload,
+			sig:     &Signature{Format: FormatCosign, Value: base64.StdEncoding.EncodeToString(rsaCosign)},
+			wantKey: &rsaKey.PublicKey,
+		},
+		{
+			name:    "signature over another payload",
+			entry:   "com.example/weather",
+			payload: []byte(`{"name":"com.example/weather","version":"2.0.0"}`),
+			sig:     &Signature{Format: FormatJWS, Value: signJWS(t, jwt.SigningMethodES256, ecKey, payload)},
+			wantErr: ErrSignatureMismatch,
+		},
+		{
+			name:    "signature by an untrusted key",
+			entry:   "com.example/weather",
+			payload: payload,
+			sig:     &Signature{Format: FormatJWS, Value: signJWS(t, jwt.SigningMethodES256, otherKey, payload)},
+			wantErr: ErrSignatureMismatch,
+		},
+		{
+			name:    "keys of a shorter prefix are not trusted by the longest one",
+			entry:   "com.example/secure.weather",
+			payload: payload,
+			sig:     &Signature{Format: FormatJWS, Value: signJWS(t, jwt.SigningMethodES256, ecKey, payload)},
+			wantErr: ErrSignatureMismatch,
+		},
+		{
+			name:    "signature by the key of the longest prefix",
+			entry:   "com.example/secure.weather",
+			payload: payload,
+			sig:     &Signature{Format: FormatJWS, Value: signJWS(t, jwt.SigningMethodES256, otherKey, payload)},
+			wantKey: &otherKey.PublicKey,
+		},
+		{
+			name:    "jws with attached payload",
+			entry:   "com.example/weather",
+			payload: payload,
+			sig: &Signature{
+				Format: FormatJWS,
+				Value:  "eyJhbGciOiJFUzI1NiJ9." + base64.RawURLEncoding.EncodeToString(payload) + ".c2ln",
+			},
+			wantErr: ErrSignatureMismatch,
+		},
+		{
+			name:    "jws with a symmetric algorithm",
+			entry:   "com.example/weather",
+			payload: payload,
+			sig:     &Signature{Format: FormatJWS, Value: signJWS(t, jwt.SigningMethodHS256, []byte("secret"), payload)},
+			wantErr: ErrSignatureMismatch,
+		},
+		{
+			name:    "unsupported format",
+			entry:   "com.example/weather",
+			payload: payload,
+			sig:     &Signature{Format: "pgp", Value: "c2ln"},
+			wantErr: ErrUnsupportedFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			verification, err := verifier.Verify(tt.entry, tt.payload, tt.sig)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, verification)
+				return
+			}
+			require.NoError(t, err)
+			if tt.sig == nil {
+				assert.Nil(t, verification)
+				return
+			}
+
+			wantKey, err := parsePublicKey(encodePublicKey(t, tt.wantKey))
+			require.NoError(t, err)
+			require.NotNil(t, verification)
+			assert.Equal(t, tt.sig.Format, verification.Format)
+			assert.Equal(t, wantKey.id, verification.KeyID)
+			assert.Equal(t, tt.sig.Value, verification.Signature)
+		})
+	}
+}
+
+func TestNewVerifier_InvalidKey(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewVerifier([]Namespace{
+		{Prefix: "com.example/", PublicKeys: []string{"not a key"}},
+	})
+	require.ErrorContains(t, err, "namespace com.example/: key 0: not a PEM encoded key")
+}
+
+func TestValidateNamespace(t *testing.T) {
+	t.Parallel()
+
+	for _, prefix := range []string{"com.example", "com.example/", "com.example.", "io.github.acme/tools"} {
+		require.NoError(t, ValidateNamespace(prefix), prefix)
+	}
+	for _, prefix := range []string{"", "com.example/secure-*", "/com.example", "com..example", "com example"} {
+		require.Error(t, ValidateNamespace(prefix), prefix)
+	}
+}
+
+func TestSigner_Sign(t *testing.T) {
+	t.Parallel()
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	require.NoError(t, err)
+	edDER, err := x509.MarshalPKCS8PrivateKey(edKey)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		block     *pem.Block
+		publicKey crypto.PublicKey
+	}{
+		{
+			name:      "ecdsa sec1",
+			block:     &pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER},
+			publicKey: &ecKey.PublicKey,
+		},
+		{
+			name:      "ed25519 pkcs8",
+			block:     &pem.Block{Type: "PRIVATE KEY", Bytes: edDER},
+			publicKey: edKey.Public(),
+		},
+		{
+			name:      "rsa pkcs1",
+			block:     &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)},
+			publicKey: &rsaKey.PublicKey,
+		},
+	}
+
+	payload := []byte(`{"id":"1","registry":"central"}`)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			signer, err := NewSigner(string(pem.EncodeToMemory(tt.block)))
+			require.NoError(t, err)
+			sig, err := signer.Sign(payload)
+			require.NoError(t, err)
+
+			verifier, err := NewVerifier([]Namespace{
+				{PublicKeys: []string{encodePublicKey(t, tt.publicKey)}},
+			})
+			require.NoError(t, err)
+			verification, err := verifier.Verify("", payload, sig)
+			require.NoError(t, err)
+			assert.Equal(t, signer.KeyID(), verification.KeyID)
+
+			_, err = verifier.Verify("", []byte(`{"id":"2","registry":"central"}`), sig)
+			require.ErrorIs(t, err, ErrSignatureMismatch)
+		})
+	}
+
+	_, err = NewSigner(encodePublicKey(t, &ecKey.PublicKey))
+	require.ErrorContains(t, err, "invalid private key")
+}
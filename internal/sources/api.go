@@ -16,6 +16,8 @@ type apiRegistryHandler struct {
 	validator RegistryDataValidator
 }
 
+var _ IncrementalRegistryHandler = (*apiRegistryHandler)(nil)
+
 // NewAPIRegistryHandler creates a new API registry handler
 func NewAPIRegistryHandler() RegistryHandler {
 	return &apiRegistryHandler{
@@ -66,6 +68,29 @@ func (h *apiRegistryHandler) FetchRegistry(ctx context.Context, regCfg *config.S
 	return handler.FetchRegistry(ctx, regCfg)
 }
 
+// FetchRegistrySince retrieves only the servers updated after the given time from the API
+// endpoint. It performs the same validation as FetchRegistry before delegating.
+func (h *apiRegistryHandler) FetchRegistrySince(
+	ctx context.Context,
+	regCfg *config.SourceConfig,
+	since time.Time,
+) (*FetchResult, error) {
+	if err := h.Validate(regCfg); err != nil {
+		return nil, fmt.Errorf("registry validation failed: %w", err)
+	}
+
+	handler, err := h.newUpstreamHandler(regCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.validateUpstreamFormat(ctx, handler, regCfg); err != nil {
+		return nil, fmt.Errorf("upstream format validation failed: %w", err)
+	}
+
+	return handler.FetchRegistrySince(ctx, regCfg, since)
+}
+
 // newUpstreamHandler builds an upstream API handler backed by an HTTP client whose
 // timeout honors the optional per-source override, defaulting to httpclient.DefaultTimeout.
 // Bounds on the override are enforced at config load (see config.validateAPIConfig).
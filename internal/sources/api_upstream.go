@@ -1,24 +1,17 @@
 package sources
 
-// TODO: Future optimization - Incremental sync support
-// Currently this implementation fetches all servers on every sync (full replacement).
-// Future enhancement should:
-// - Add updatedSince parameter to FetchRegistry (use /v0.1/servers?updated_since={timestamp})
-// - Modify storage layer to support UPSERT/merge instead of full replacement
-// - Optimize change detection to avoid full fetch (use ETag/Last-Modified headers)
-// This would significantly reduce bandwidth and processing for large registries.
-// See: https://github.com/stacklok/toolhive-registry-server/issues/XXX (create issue)
-
 import (
 	"context"
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
 	"time"
 
 	v0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
 	toolhivetypes "github.com/stacklok/toolhive-core/registry/types"
 	"gopkg.in/yaml.v3"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -104,7 +97,7 @@ func (h *upstreamAPIHandler) FetchRegistry(ctx context.Context, regCfg *config.S
 	baseURL := getBaseURL(regCfg)
 
 	// Fetch all servers via pagination
-	servers, err := h.fetchAllServers(ctx, baseURL)
+	servers, _, err := h.fetchAllServers(ctx, baseURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch servers: %w", err)
 	}
@@ -124,10 +117,52 @@ func (h *upstreamAPIHandler) FetchRegistry(ctx context.Context, regCfg *config.S
 	return NewFetchResult(upstreamReg, hash), nil
 }
 
-// fetchAllServers performs paginated fetching and returns all ServerJSON objects
-func (h *upstreamAPIHandler) fetchAllServers(ctx context.Context, baseURL string) ([]v0.ServerJSON, error) {
+// FetchRegistrySince retrieves only the servers updated after the given time using the
+// upstream updated_since filter. Servers reported as deleted are returned separately in
+// FetchResult.DeletedServers so the caller can remove them from storage.
+func (h *upstreamAPIHandler) FetchRegistrySince(
+	ctx context.Context,
+	regCfg *config.SourceConfig,
+	since time.Time,
+) (*FetchResult, error) {
+	logger := log.FromContext(ctx)
+	baseURL := getBaseURL(regCfg)
+
+	servers, deleted, err := h.fetchAllServers(ctx, baseURL, &since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch updated servers: %w", err)
+	}
+
+	logger.Info("Fetched updated servers from upstream API",
+		"count", len(servers), "deleted", len(deleted), "since", since.Format(time.RFC3339))
+
+	upstreamReg := h.buildUpstreamRegistry(servers)
+
+	// Hash both sides of the delta so a deletion-only delta still yields a distinct hash
+	hash, err := h.calculateHash(&toolhivetypes.UpstreamRegistry{
+		Data: toolhivetypes.UpstreamData{Servers: append(slices.Clone(servers), deleted...)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate hash: %w", err)
+	}
+
+	result := NewFetchResult(upstreamReg, hash)
+	result.Incremental = true
+	result.DeletedServers = deleted
+	return result, nil
+}
+
+// fetchAllServers performs paginated fetching and returns all ServerJSON objects.
+// When since is set, only servers updated after that time are requested. Servers whose
+// official status is deleted are returned in the second slice rather than the first.
+func (h *upstreamAPIHandler) fetchAllServers(
+	ctx context.Context,
+	baseURL string,
+	since *time.Time,
+) ([]v0.ServerJSON, []v0.ServerJSON, error) {
 	logger := log.FromContext(ctx)
 	allServers := []v0.ServerJSON{}
+	var deletedServers []v0.ServerJSON
 	cursor := ""
 	pageCount := 0
 
@@ -136,7 +171,7 @@ func (h *upstreamAPIHandler) fetchAllServers(ctx context.Context, baseURL string
 
 		// Security: Prevent infinite pagination loops
 		if pageCount > maxPaginationPages {
-			return nil, fmt.Errorf(
+			return nil, nil, fmt.Errorf(
 				"pagination exceeded maximum pages (%d), possible infinite loop or malicious upstream",
 				maxPaginationPages,
 			)
@@ -144,6 +179,9 @@ func (h *upstreamAPIHandler) fetchAllServers(ctx context.Context, baseURL string
 
 		// Build URL with pagination
 		requestURL := fmt.Sprintf("%s/v0.1/servers?limit=100", baseURL)
+		if since != nil {
+			requestURL = fmt.Sprintf("%s&updated_since=%s", requestURL, url.QueryEscape(since.UTC().Format(time.RFC3339)))
+		}
 		if cursor != "" {
 			// Security: URL-encode cursor to prevent injection attacks
 			requestURL = fmt.Sprintf("%s&cursor=%s", requestURL, url.QueryEscape(cursor))
@@ -154,25 +192,30 @@ func (h *upstreamAPIHandler) fetchAllServers(ctx context.Context, baseURL string
 		// Fetch page
 		data, err := h.httpClient.Get(ctx, requestURL)
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch page %d: %w", pageCount, err)
+			return nil, nil, fmt.Errorf("failed to fetch page %d: %w", pageCount, err)
 		}
 
 		// Parse response
 		var response v0.ServerListResponse
 		if err := json.Unmarshal(data, &response); err != nil {
-			return nil, fmt.Errorf("failed to parse response page %d: %w", pageCount, err)
+			return nil, nil, fmt.Errorf("failed to parse response page %d: %w", pageCount, err)
 		}
 
 		logger.V(1).Info("Parsed page", "page", pageCount, "serversInPage", len(response.Servers))
 
 		// Security: Prevent memory exhaustion from too many servers
-		if len(allServers)+len(response.Servers) > maxServers {
-			return nil, fmt.Errorf("total servers (%d) would exceed maximum (%d), could cause out of service",
-				len(allServers)+len(response.Servers), maxServers)
+		total := len(allServers) + len(deletedServers) + len(response.Servers)
+		if total > maxServers {
+			return nil, nil, fmt.Errorf("total servers (%d) would exceed maximum (%d), could cause out of service",
+				total, maxServers)
 		}
 
 		// Extract ServerJSON from each ServerResponse
 		for _, serverResp := range response.Servers {
+			if isDeletedServer(serverResp) {
+				deletedServers = append(deletedServers, serverResp.Server)
+				continue
+			}
 			allServers = append(allServers, serverResp.Server)
 		}
 
@@ -185,7 +228,13 @@ func (h *upstreamAPIHandler) fetchAllServers(ctx context.Context, baseURL string
 		cursor = response.Metadata.NextCursor
 	}
 
-	return allServers, nil
+	return allServers, deletedServers, nil
+}
+
+// isDeletedServer reports whether the upstream registry marked the server as deleted.
+// Deleted servers are only returned by upstream when filtering with updated_since.
+func isDeletedServer(resp v0.ServerResponse) bool {
+	return resp.Meta.Official != nil && resp.Meta.Official.Status == model.StatusDeleted
 }
 
 // buildUpstreamRegistry converts []ServerJSON to ToolHive's UpstreamRegistry format
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -568,3 +569,71 @@ func TestUpstreamAPIHandler_HTTPErrorCodes(t *testing.T) {
 		})
 	}
 }
+
+func TestUpstreamAPIHandler_FetchRegistrySince(t *testing.T) {
+	t.Parallel()
+
+	var receivedUpdatedSince string
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != serversAPIPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		receivedUpdatedSince = r.URL.Query().Get("updated_since")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"servers": [
+				{
+					"server": {
+						"name": "io.test/updated",
+						"description": "Updated server",
+						"version": "1.1.0"
+					},
+					"_meta": {
+						"io.modelcontextprotocol.registry/official": {"status": "active"}
+					}
+				},
+				{
+					"server": {
+						"name": "io.test/removed",
+						"description": "Removed server",
+						"version": "1.0.0"
+					},
+					"_meta": {
+						"io.modelcontextprotocol.registry/official": {"status": "deleted"}
+					}
+				}
+			],
+			"metadata": {
+				"nextCursor": "",
+				"count": 2
+			}
+		}`))
+	}))
+	defer mockServer.Close()
+
+	handler := NewUpstreamAPIHandler(httpclient.NewDefaultClient(0))
+	registryConfig := &config.SourceConfig{
+		Name: "test-registry",
+		API: &config.APIConfig{
+			Endpoint: mockServer.URL,
+		},
+	}
+	since := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	result, err := handler.FetchRegistrySince(context.Background(), registryConfig, since)
+
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, "2026-01-02T03:04:05Z", receivedUpdatedSince)
+	assert.True(t, result.Incremental)
+	assert.Equal(t, 1, result.ServerCount)
+	require.Len(t, result.Registry.Data.Servers, 1)
+	assert.Equal(t, "io.test/updated", result.Registry.Data.Servers[0].Name)
+	require.Len(t, result.DeletedServers, 1)
+	assert.Equal(t, "io.test/removed", result.DeletedServers[0].Name)
+	assert.NotEmpty(t, result.Hash)
+}
@@ -0,0 +1,192 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	toolhivetypes "github.com/stacklok/toolhive-core/registry/types"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/httpclient"
+	"github.com/stacklok/toolhive-registry-server/internal/replication"
+	"github.com/stacklok/toolhive-registry-server/internal/signing"
+)
+
+// bundleFileExtension is the extension of the bundle files read from a directory
+const bundleFileExtension = ".json"
+
+// bundleRegistryHandler handles registry data imported from signed bundles,
+// read from a local file, a directory of bundle files, or a URL
+type bundleRegistryHandler struct {
+	httpClient httpclient.Client
+}
+
+var _ DeltaRegistryHandler = (*bundleRegistryHandler)(nil)
+
+// NewBundleRegistryHandler creates a new bundle registry handler
+func NewBundleRegistryHandler() RegistryHandler {
+	return &bundleRegistryHandler{
+		httpClient: httpclient.NewDefaultClient(DefaultURLTimeout),
+	}
+}
+
+// NewBundleRegistryHandlerWithClient creates a new bundle registry handler with a custom HTTP client
+// This is useful for testing
+func NewBundleRegistryHandlerWithClient(client httpclient.Client) RegistryHandler {
+	return &bundleRegistryHandler{
+		httpClient: client,
+	}
+}
+
+// Validate validates the bundle registry configuration
+func (*bundleRegistryHandler) Validate(regCfg *config.SourceConfig) error {
+	if regCfg == nil {
+		return fmt.Errorf("registry configuration cannot be nil")
+	}
+
+	if regCfg.Bundle == nil {
+		return fmt.Errorf("bundle configuration is required")
+	}
+
+	return regCfg.Bundle.Validate()
+}
+
+// FetchRegistry reads the bundles of the source, verifies their signature and
+// returns the entries of the full bundle they resolve to, with the claims of
+// their entries. The hash of the result is the ID of the last applied bundle.
+func (h *bundleRegistryHandler) FetchRegistry(ctx context.Context, regCfg *config.SourceConfig) (*FetchResult, error) {
+	return h.FetchRegistryAfter(ctx, regCfg, "")
+}
+
+// FetchRegistryAfter reads the bundles of the source, verifies their signature
+// and returns the changes they make to the bundle last imported, whose ID is
+// lastHash, as an incremental result, or the entries of a more recent full
+// bundle. Deltas are applied to the stored entries, so the source does not need
+// to serve their base bundle. Bundles older than lastHash are rejected with
+// replication.ErrBundleRollback.
+func (h *bundleRegistryHandler) FetchRegistryAfter(
+	ctx context.Context, regCfg *config.SourceConfig, lastHash string,
+) (*FetchResult, error) {
+	if err := h.Validate(regCfg); err != nil {
+		return nil, fmt.Errorf("registry validation failed: %w", err)
+	}
+
+	verifier, err := replication.NewVerifier(regCfg.Bundle.PublicKeys)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bundle public keys: %w", err)
+	}
+
+	var bundles []*replication.Bundle
+	if regCfg.Bundle.URL != "" {
+		bundles, err = h.fetchURLBundle(ctx, regCfg.Bundle, verifier)
+	} else {
+		bundles, err = readLocalBundles(regCfg.Bundle.Path, verifier)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bundles: %w", err)
+	}
+
+	bundle, err := replication.ResolveAfter(bundles, lastHash)
+	if err != nil {
+		return nil, err
+	}
+
+	result := NewFetchResult(bundle.UpstreamRegistry(), bundle.ID)
+	if result.ServerClaims, err = bundle.EntryClaims(replication.EntryTypeServer); err != nil {
+		return nil, err
+	}
+	if result.SkillClaims, err = bundle.EntryClaims(replication.EntryTypeSkill); err != nil {
+		return nil, err
+	}
+	if result.PluginClaims, err = bundle.EntryClaims(replication.EntryTypePlugin); err != nil {
+		return nil, err
+	}
+
+	if bundle.IsDelta() {
+		result.Incremental = true
+		for _, ref := range bundle.Deleted {
+			switch ref.Type {
+			case replication.EntryTypeServer:
+				result.DeletedServers = append(result.DeletedServers,
+					upstreamv0.ServerJSON{Name: ref.Name, Version: ref.Version})
+			case replication.EntryTypeSkill:
+				result.DeletedSkills = append(result.DeletedSkills,
+					toolhivetypes.Skill{Namespace: ref.Namespace, Name: ref.Name, Version: ref.Version})
+			case replication.EntryTypePlugin:
+				result.DeletedPlugins = append(result.DeletedPlugins,
+					toolhivetypes.Plugin{Namespace: ref.Namespace, Name: ref.Name, Version: ref.Version})
+			}
+		}
+	}
+	return result, nil
+}
+
+// fetchURLBundle fetches the bundle file served at the configured URL
+func (h *bundleRegistryHandler) fetchURLBundle(
+	ctx context.Context, bundleCfg *config.BundleConfig, verifier *signing.Verifier,
+) ([]*replication.Bundle, error) {
+	client := h.httpClient
+	if bundleCfg.Timeout != "" {
+		timeout, err := time.ParseDuration(bundleCfg.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout: %w", err)
+		}
+		client = httpclient.NewDefaultClient(timeout)
+	}
+
+	data, err := client.Get(ctx, bundleCfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch URL %s: %w", bundleCfg.URL, err)
+	}
+	bundle, err := replication.Open(data, verifier)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", bundleCfg.URL, err)
+	}
+	return []*replication.Bundle{bundle}, nil
+}
+
+// readLocalBundles reads the bundle file at path or, when path is a directory,
+// every bundle file in it
+func readLocalBundles(path string, verifier *signing.Verifier) ([]*replication.Bundle, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("path not found: %s", path)
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read directory %s: %w", path, err)
+		}
+		files = files[:0]
+		for _, entry := range entries {
+			if entry.Type().IsRegular() && strings.HasSuffix(entry.Name(), bundleFileExtension) {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+
+	bundles := make([]*replication.Bundle, 0, len(files))
+	for _, file := range files {
+		//nolint:gosec // File path comes from user configuration, this is expected behavior
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %s: %w", file, err)
+		}
+		bundle, err := replication.Open(data, verifier)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		bundles = append(bundles, bundle)
+	}
+	return bundles, nil
+}
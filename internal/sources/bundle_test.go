@@ -0,0 +1,200 @@
+package sources
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	toolhivetypes "github.com/stacklok/toolhive-core/registry/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/replication"
+	"github.com/stacklok/toolhive-registry-server/internal/signing"
+)
+
+// newBundleSigner returns a bundle signer and the PEM encoded public key verifying its signatures
+func newBundleSigner(t *testing.T) (*signing.Signer, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	signer, err := signing.NewSigner(string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})))
+	require.NoError(t, err)
+
+	der, err = x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	return signer, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+// writeBundle seals a bundle into a file of dir
+func writeBundle(t *testing.T, dir, name string, bundle *replication.Bundle, signer *signing.Signer) string {
+	t.Helper()
+	data, err := replication.Seal(bundle, signer)
+	require.NoError(t, err)
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	return path
+}
+
+func TestBundleRegistryHandler_FetchRegistry(t *testing.T) {
+	t.Parallel()
+
+	signer, publicKey := newBundleSigner(t)
+	otherSigner, _ := newBundleSigner(t)
+
+	full := &replication.Bundle{
+		Version:   replication.FormatVersion,
+		ID:        "10",
+		Registry:  "central",
+		CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Servers: []upstreamv0.ServerJSON{
+			{Name: "com.example/a", Version: "1.0.0"},
+			{Name: "com.example/b", Version: "1.0.0"},
+		},
+		Claims: []replication.EntryClaims{
+			{Type: replication.EntryTypeServer, Name: "com.example/a", Claims: map[string]any{"org": "acme"}},
+		},
+	}
+	delta := &replication.Bundle{
+		Version:   replication.FormatVersion,
+		ID:        "20",
+		BaseID:    "10",
+		Registry:  "central",
+		CreatedAt: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+		Skills:    []toolhivetypes.Skill{{Namespace: "io.example", Name: "calendar", Version: "1.0.0"}},
+		Deleted: []replication.EntryRef{
+			{Type: replication.EntryTypeServer, Name: "com.example/b", Version: "1.0.0"},
+		},
+	}
+
+	t.Run("file", func(t *testing.T) {
+		t.Parallel()
+		path := writeBundle(t, t.TempDir(), "full.json", full, signer)
+
+		result, err := NewBundleRegistryHandler().FetchRegistry(t.Context(), &config.SourceConfig{
+			Name:   "edge",
+			Bundle: &config.BundleConfig{Path: path, PublicKeys: []string{publicKey}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "10", result.Hash)
+		assert.Equal(t, 2, result.ServerCount)
+		assert.Equal(t, map[string][]byte{"com.example/a": []byte(`{"org":"acme"}`)}, result.ServerClaims)
+	})
+
+	t.Run("directory of a full bundle and its deltas", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		writeBundle(t, dir, "full.json", full, signer)
+		writeBundle(t, dir, "delta.json", delta, signer)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "README.txt"), []byte("not a bundle"), 0600))
+
+		result, err := NewBundleRegistryHandler().FetchRegistry(t.Context(), &config.SourceConfig{
+			Name:   "edge",
+			Bundle: &config.BundleConfig{Path: dir, PublicKeys: []string{publicKey}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "20", result.Hash)
+		assert.Equal(t, 1, result.ServerCount)
+		assert.Equal(t, 1, result.SkillCount)
+		assert.Equal(t, "com.example/a", result.Registry.Data.Servers[0].Name)
+	})
+
+	t.Run("url", func(t *testing.T) {
+		t.Parallel()
+		data, err := replication.Seal(full, signer)
+		require.NoError(t, err)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write(data)
+		}))
+		t.Cleanup(server.Close)
+
+		result, err := NewBundleRegistryHandler().FetchRegistry(t.Context(), &config.SourceConfig{
+			Name:   "edge",
+			Bundle: &config.BundleConfig{URL: server.URL, Timeout: "5s", PublicKeys: []string{publicKey}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "10", result.Hash)
+	})
+
+	t.Run("bundle signed by an untrusted key", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		writeBundle(t, dir, "full.json", full, signer)
+		writeBundle(t, dir, "delta.json", delta, otherSigner)
+
+		_, err := NewBundleRegistryHandler().FetchRegistry(t.Context(), &config.SourceConfig{
+			Name:   "edge",
+			Bundle: &config.BundleConfig{Path: dir, PublicKeys: []string{publicKey}},
+		})
+		require.ErrorIs(t, err, replication.ErrInvalidBundle)
+	})
+
+	t.Run("delta without its base", func(t *testing.T) {
+		t.Parallel()
+		path := writeBundle(t, t.TempDir(), "delta.json", delta, signer)
+
+		_, err := NewBundleRegistryHandler().FetchRegistry(t.Context(), &config.SourceConfig{
+			Name:   "edge",
+			Bundle: &config.BundleConfig{Path: path, PublicKeys: []string{publicKey}},
+		})
+		require.ErrorContains(t, err, "requires its base bundle 10")
+	})
+
+	t.Run("url serving a delta based on the last imported bundle", func(t *testing.T) {
+		t.Parallel()
+		data, err := replication.Seal(delta, signer)
+		require.NoError(t, err)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write(data)
+		}))
+		t.Cleanup(server.Close)
+
+		handler, ok := NewBundleRegistryHandler().(DeltaRegistryHandler)
+		require.True(t, ok)
+		result, err := handler.FetchRegistryAfter(t.Context(), &config.SourceConfig{
+			Name:   "edge",
+			Bundle: &config.BundleConfig{URL: server.URL, Timeout: "5s", PublicKeys: []string{publicKey}},
+		}, "10")
+		require.NoError(t, err)
+		assert.True(t, result.Incremental)
+		assert.Equal(t, "20", result.Hash)
+		assert.Equal(t, 0, result.ServerCount)
+		assert.Equal(t, 1, result.SkillCount)
+		assert.Equal(t, []upstreamv0.ServerJSON{{Name: "com.example/b", Version: "1.0.0"}}, result.DeletedServers)
+	})
+
+	t.Run("bundle older than the last imported bundle", func(t *testing.T) {
+		t.Parallel()
+		path := writeBundle(t, t.TempDir(), "full.json", full, signer)
+
+		handler, ok := NewBundleRegistryHandler().(DeltaRegistryHandler)
+		require.True(t, ok)
+		_, err := handler.FetchRegistryAfter(t.Context(), &config.SourceConfig{
+			Name:   "edge",
+			Bundle: &config.BundleConfig{Path: path, PublicKeys: []string{publicKey}},
+		}, "20")
+		require.ErrorIs(t, err, replication.ErrBundleRollback)
+	})
+
+	t.Run("missing path", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewBundleRegistryHandler().FetchRegistry(t.Context(), &config.SourceConfig{
+			Name:   "edge",
+			Bundle: &config.BundleConfig{Path: filepath.Join(t.TempDir(), "missing"), PublicKeys: []string{publicKey}},
+		})
+		require.ErrorContains(t, err, "path not found")
+	})
+}
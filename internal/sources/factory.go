@@ -35,6 +35,8 @@ func (*defaultRegistryHandlerFactory) CreateHandler(regCfg *config.SourceConfig)
 		return NewAPIRegistryHandler(), nil
 	case config.SourceTypeFile:
 		return NewFileRegistryHandler(), nil
+	case config.SourceTypeBundle:
+		return NewBundleRegistryHandler(), nil
 	case config.SourceTypeKubernetes:
 		return nil, fmt.Errorf("kubernetes source type is not yet implemented")
 	case config.SourceTypeManaged:
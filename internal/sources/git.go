@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/stacklok/toolhive-registry-server/internal/config"
@@ -23,6 +24,8 @@ type gitRegistryHandler struct {
 	validator RegistryDataValidator
 }
 
+var _ CommitRegistryHandler = (*gitRegistryHandler)(nil)
+
 // NewGitRegistryHandler creates a new Git registry handler
 func NewGitRegistryHandler() RegistryHandler {
 	return &gitRegistryHandler{
@@ -71,8 +74,36 @@ func (*gitRegistryHandler) Validate(regCfg *config.SourceConfig) error {
 	return nil
 }
 
+// newCloneConfig builds the clone configuration of a Git source, including its credentials
+func newCloneConfig(gitSource *config.GitConfig) (*git2.CloneConfig, error) {
+	cloneConfig := &git2.CloneConfig{
+		URL:    gitSource.Repository,
+		Branch: gitSource.Branch,
+		Tag:    gitSource.Tag,
+		Commit: gitSource.Commit,
+	}
+
+	// Configure authentication if provided
+	auth, err := buildGitAuth(gitSource.Auth)
+	if err != nil {
+		return nil, err
+	}
+	cloneConfig.Auth = auth
+	return cloneConfig, nil
+}
+
+// gitRegistryData is the registry data read from a Git commit
+type gitRegistryData struct {
+	// files holds the files matching the configured path, in path order
+	files []registryFile
+	// multiFile is set when the configured path is a glob or a directory
+	multiFile bool
+	// commitSHA is the SHA of the commit the files were read from
+	commitSHA string
+}
+
 // fetchRegistryData retrieves registry data from the Git repository
-func (h *gitRegistryHandler) fetchRegistryData(ctx context.Context, regCfg *config.SourceConfig) ([]byte, error) {
+func (h *gitRegistryHandler) fetchRegistryData(ctx context.Context, regCfg *config.SourceConfig) (*gitRegistryData, error) {
 
 	// Validate registry configuration
 	if err := h.Validate(regCfg); err != nil {
@@ -81,23 +112,9 @@ func (h *gitRegistryHandler) fetchRegistryData(ctx context.Context, regCfg *conf
 
 	gitSource := regCfg.Git
 	// Prepare clone configuration
-	cloneConfig := &git2.CloneConfig{
-		URL:    gitSource.Repository,
-		Branch: gitSource.Branch,
-		Tag:    gitSource.Tag,
-		Commit: gitSource.Commit,
-	}
-
-	// Configure authentication if provided
-	if gitSource.Auth != nil && gitSource.Auth.Username != "" {
-		password, err := gitSource.Auth.GetPassword()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get git password: %w", err)
-		}
-		cloneConfig.Auth = &git2.AuthConfig{
-			Username: gitSource.Auth.Username,
-			Password: password,
-		}
+	cloneConfig, err := newCloneConfig(gitSource)
+	if err != nil {
+		return nil, err
 	}
 
 	// Clone the repository with timing and metrics
@@ -127,10 +144,8 @@ func (h *gitRegistryHandler) fetchRegistryData(ctx context.Context, regCfg *conf
 		"duration", cloneDuration.String(),
 		"branch", repoInfo.Branch,
 	}
-	if repoInfo.Repository != nil {
-		if ref, err := repoInfo.Repository.Head(); err == nil {
-			cloneAttrs = append(cloneAttrs, "commit_sha", ref.Hash().String())
-		}
+	if repoInfo.CommitSHA != "" {
+		cloneAttrs = append(cloneAttrs, "commit_sha", repoInfo.CommitSHA)
 	}
 	slog.Info("Git clone completed", cloneAttrs...)
 
@@ -149,12 +164,116 @@ func (h *gitRegistryHandler) fetchRegistryData(ctx context.Context, regCfg *conf
 		filePath = DefaultRegistryDataFile
 	}
 
-	registryData, err := h.gitClient.GetFileContent(repoInfo, filePath)
+	files, multiFile, err := h.readRegistryFiles(repoInfo, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gitRegistryData{
+		files:     files,
+		multiFile: multiFile,
+		commitSHA: repoInfo.CommitSHA,
+	}, nil
+}
+
+// readRegistryFiles reads the files matching the configured path. The path names a single
+// registry file, a directory whose JSON files are all read, or a glob pattern.
+// It also reports whether the files form a multi-file catalog.
+func (h *gitRegistryHandler) readRegistryFiles(
+	repoInfo *git2.RepositoryInfo, filePath string,
+) ([]registryFile, bool, error) {
+	dir, match := strings.TrimSuffix(filePath, "/"), isJSONFile
+	switch {
+	case isGlobPath(filePath):
+		dir = globBaseDir(filePath)
+		match = func(name string) bool {
+			return matchGlob(filePath, name)
+		}
+	case !strings.HasSuffix(filePath, "/"):
+		data, err := h.gitClient.GetFileContent(repoInfo, filePath)
+		if err == nil {
+			return []registryFile{{path: filePath, data: data}}, false, nil
+		}
+		// The path may name a directory rather than a file
+		if _, dirErr := h.gitClient.ListFiles(repoInfo, dir); dirErr != nil {
+			return nil, false, fmt.Errorf("failed to get file %s from repository: %w", filePath, err)
+		}
+	}
+
+	paths, err := h.gitClient.ListFiles(repoInfo, dir)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get file %s from repository: %w", filePath, err)
+		return nil, false, fmt.Errorf("failed to list files of %s in repository: %w", dir, err)
+	}
+
+	var files []registryFile
+	for _, p := range paths {
+		if !match(p) {
+			continue
+		}
+		data, err := h.gitClient.GetFileContent(repoInfo, p)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get file %s from repository: %w", p, err)
+		}
+		files = append(files, registryFile{path: p, data: data})
+	}
+	if len(files) == 0 {
+		return nil, false, fmt.Errorf("no registry files found matching %s in repository", filePath)
 	}
 
-	return registryData, nil
+	return files, true, nil
+}
+
+// buildGitAuth reads the credentials of the configured authentication method.
+// It returns nil if no authentication is configured.
+func buildGitAuth(auth *config.GitAuthConfig) (*git2.AuthConfig, error) {
+	switch {
+	case auth == nil:
+		return nil, nil
+	case auth.SSH != nil:
+		privateKey, err := auth.SSH.GetPrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		passphrase, err := auth.SSH.GetPassphrase()
+		if err != nil {
+			return nil, err
+		}
+		return &git2.AuthConfig{
+			SSH: &git2.SSHAuthConfig{
+				User:           auth.SSH.GetUser(),
+				PrivateKey:     privateKey,
+				Passphrase:     passphrase,
+				KnownHostsFile: auth.SSH.KnownHostsFile,
+			},
+		}, nil
+	case auth.GitHubApp != nil:
+		privateKey, err := auth.GitHubApp.GetPrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		// Token sources are shared across fetches so installation tokens are reused until they expire
+		tokenSource, err := git2.GitHubAppTokenSource(&git2.GitHubAppConfig{
+			AppID:          auth.GitHubApp.AppID,
+			InstallationID: auth.GitHubApp.InstallationID,
+			PrivateKey:     privateKey,
+			APIURL:         auth.GitHubApp.GetAPIURL(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure github app authentication: %w", err)
+		}
+		return &git2.AuthConfig{TokenSource: tokenSource}, nil
+	case auth.Username != "":
+		password, err := auth.GetPassword()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get git password: %w", err)
+		}
+		return &git2.AuthConfig{
+			Username: auth.Username,
+			Password: password,
+		}, nil
+	default:
+		return nil, nil
+	}
 }
 
 // FetchRegistry retrieves registry data from the Git repository
@@ -165,17 +284,49 @@ func (h *gitRegistryHandler) FetchRegistry(ctx context.Context, regCfg *config.S
 		return nil, fmt.Errorf("failed to fetch registry data: %w", err)
 	}
 
-	// Validate and parse registry data
-	reg, err := h.validator.ValidateData(registryData)
-	if err != nil {
-		return nil, fmt.Errorf("registry data validation failed: %w", err)
+	var result *FetchResult
+	if registryData.multiFile {
+		// Merge the entries of every matching file into a single registry
+		reg, hash, err := mergeRegistryFiles(registryData.files)
+		if err != nil {
+			return nil, fmt.Errorf("registry data validation failed: %w", err)
+		}
+		result = NewFetchResult(reg, hash)
+	} else {
+		data := registryData.files[0].data
+
+		// Validate and parse registry data
+		reg, err := h.validator.ValidateData(data)
+		if err != nil {
+			return nil, fmt.Errorf("registry data validation failed: %w", err)
+		}
+
+		// Calculate hash using the SHA256 hash of the registry data
+		hash := fmt.Sprintf("%x", sha256.Sum256(data))
+		result = NewFetchResult(reg, hash)
 	}
 
-	// Calculate hash using the SHA256 hash of the registry data
-	hash := fmt.Sprintf("%x", sha256.Sum256(registryData))
+	result.CommitSHA = registryData.commitSHA
+	return result, nil
+}
 
-	// Create and return fetch result with pre-calculated hash
-	return NewFetchResult(reg, hash), nil
+// ResolveCommit returns the SHA of the commit the configured branch, tag or default
+// branch currently points to, by listing the remote references instead of cloning
+func (h *gitRegistryHandler) ResolveCommit(ctx context.Context, regCfg *config.SourceConfig) (string, error) {
+	if err := h.Validate(regCfg); err != nil {
+		return "", fmt.Errorf("registry validation failed: %w", err)
+	}
+
+	cloneConfig, err := newCloneConfig(regCfg.Git)
+	if err != nil {
+		return "", err
+	}
+
+	commitSHA, err := h.gitClient.ResolveCommit(ctx, cloneConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve remote commit: %w", err)
+	}
+	return commitSHA, nil
 }
 
 // logMemoryStatsAfterOperation logs the memory stats after an operation
@@ -0,0 +1,233 @@
+package sources
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	v0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	toolhivetypes "github.com/stacklok/toolhive-core/registry/types"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+)
+
+const (
+	// skillFileName is the name of the files holding a single skill in multi-file catalogs
+	skillFileName = "skill.json"
+	// pluginFileName is the name of the files holding a single plugin in multi-file catalogs
+	pluginFileName = "plugin.json"
+)
+
+// registryFile is a file read from a Git repository
+type registryFile struct {
+	path string
+	data []byte
+}
+
+// isGlobPath reports whether a Git source path is a glob pattern rather than a file or directory
+func isGlobPath(p string) bool {
+	return strings.ContainsAny(p, "*?[")
+}
+
+// globBaseDir returns the leading directories of a glob pattern that contain no wildcards,
+// which is where the matching files are searched
+func globBaseDir(pattern string) string {
+	segments := strings.Split(pattern, "/")
+	for i, segment := range segments {
+		if isGlobPath(segment) {
+			return strings.Join(segments[:i], "/")
+		}
+	}
+	return path.Dir(pattern)
+}
+
+// matchGlob reports whether a slash separated file path matches a glob pattern.
+// A "**" segment matches any number of directories, other segments follow path.Match.
+func matchGlob(pattern, name string) bool {
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchGlobSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchGlobSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], name[0]); err != nil || !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// isJSONFile reports whether a file is read when a Git source path names a directory
+func isJSONFile(name string) bool {
+	return strings.HasSuffix(name, ".json")
+}
+
+// mergeRegistryFiles validates the files of a multi-file catalog and merges their servers,
+// skills and plugins into a single registry. Each file is one of:
+//   - a full registry document, recognized by its top-level "data" property
+//   - a single skill, when the file is named skill.json
+//   - a single plugin, when the file is named plugin.json
+//   - a single server in upstream ServerJSON format otherwise
+//
+// Validation errors are prefixed with the path of the offending file.
+func mergeRegistryFiles(files []registryFile) (*toolhivetypes.UpstreamRegistry, string, error) {
+	merged := &toolhivetypes.UpstreamRegistry{
+		Schema:  registry.UpstreamRegistrySchemaURL,
+		Version: registry.UpstreamRegistryVersion,
+		Meta: toolhivetypes.UpstreamMeta{
+			LastUpdated: time.Now().UTC().Format(time.RFC3339),
+		},
+		Data: toolhivetypes.UpstreamData{
+			Servers: []v0.ServerJSON{},
+		},
+	}
+
+	// Entries are keyed by kind, name and version to report the file defining them first
+	definedIn := make(map[string]string)
+	for _, file := range files {
+		if err := mergeRegistryFile(merged, file, definedIn); err != nil {
+			return nil, "", fmt.Errorf("%s: %w", file.path, err)
+		}
+	}
+
+	if len(merged.Data.Servers) == 0 && len(merged.Data.Skills) == 0 && len(merged.Data.Plugins) == 0 {
+		return nil, "", fmt.Errorf("no servers, skills or plugins found in %d files", len(files))
+	}
+
+	// Hash only the merged data, as the file contents are not kept around
+	data, err := json.Marshal(merged.Data)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to marshal registry data: %w", err)
+	}
+	return merged, fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
+// mergeRegistryFile validates a single catalog file and appends its entries to the merged registry
+func mergeRegistryFile(merged *toolhivetypes.UpstreamRegistry, file registryFile, definedIn map[string]string) error {
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(file.data, &properties); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	var servers []v0.ServerJSON
+	var skills []toolhivetypes.Skill
+	var plugins []toolhivetypes.Plugin
+
+	_, isRegistry := properties["data"]
+	switch {
+	case isRegistry:
+		reg, err := validateUpstreamFormatAndParse(file.data)
+		if err != nil {
+			return err
+		}
+		servers, skills, plugins = reg.Data.Servers, reg.Data.Skills, reg.Data.Plugins
+	case path.Base(file.path) == skillFileName:
+		skill, err := parseSkillFile(file.data)
+		if err != nil {
+			return err
+		}
+		skills = append(skills, *skill)
+	case path.Base(file.path) == pluginFileName:
+		plugin, err := parsePluginFile(file.data)
+		if err != nil {
+			return err
+		}
+		plugins = append(plugins, *plugin)
+	default:
+		server, err := parseServerFile(file.data)
+		if err != nil {
+			return err
+		}
+		servers = append(servers, *server)
+	}
+
+	for _, server := range servers {
+		if err := claimEntry(definedIn, "server", server.Name, server.Version, file.path); err != nil {
+			return err
+		}
+	}
+	for _, skill := range skills {
+		if err := claimEntry(definedIn, "skill", skill.Namespace+"/"+skill.Name, skill.Version, file.path); err != nil {
+			return err
+		}
+	}
+	for _, plugin := range plugins {
+		if err := claimEntry(definedIn, "plugin", plugin.Namespace+"/"+plugin.Name, plugin.Version, file.path); err != nil {
+			return err
+		}
+	}
+
+	merged.Data.Servers = append(merged.Data.Servers, servers...)
+	merged.Data.Skills = append(merged.Data.Skills, skills...)
+	merged.Data.Plugins = append(merged.Data.Plugins, plugins...)
+	return nil
+}
+
+// claimEntry records the file defining an entry, failing if another file already defines it
+func claimEntry(definedIn map[string]string, kind, name, version, filePath string) error {
+	key := kind + "\x00" + name + "\x00" + version
+	if other, ok := definedIn[key]; ok {
+		return fmt.Errorf("%s %s version %s is already defined in %s", kind, name, version, other)
+	}
+	definedIn[key] = filePath
+	return nil
+}
+
+// parseServerFile validates and parses a single server in upstream ServerJSON format
+func parseServerFile(data []byte) (*v0.ServerJSON, error) {
+	if err := toolhivetypes.ValidateServerJSON(data, true); err != nil {
+		return nil, err
+	}
+
+	var server v0.ServerJSON
+	if err := json.Unmarshal(data, &server); err != nil {
+		return nil, fmt.Errorf("failed to parse server: %w", err)
+	}
+	if server.Name == "" {
+		return nil, fmt.Errorf("server name is required")
+	}
+	if server.Description == "" {
+		return nil, fmt.Errorf("server %s: description is required", server.Name)
+	}
+	return &server, nil
+}
+
+// parseSkillFile validates and parses a single skill
+func parseSkillFile(data []byte) (*toolhivetypes.Skill, error) {
+	if err := toolhivetypes.ValidateSkillBytes(data); err != nil {
+		return nil, err
+	}
+
+	var skill toolhivetypes.Skill
+	if err := json.Unmarshal(data, &skill); err != nil {
+		return nil, fmt.Errorf("failed to parse skill: %w", err)
+	}
+	return &skill, nil
+}
+
+// parsePluginFile validates and parses a single plugin
+func parsePluginFile(data []byte) (*toolhivetypes.Plugin, error) {
+	if err := toolhivetypes.ValidatePluginBytes(data); err != nil {
+		return nil, err
+	}
+
+	var plugin toolhivetypes.Plugin
+	if err := json.Unmarshal(data, &plugin); err != nil {
+		return nil, fmt.Errorf("failed to parse plugin: %w", err)
+	}
+	return &plugin, nil
+}
@@ -0,0 +1,146 @@
+package sources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchGlob(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern string
+		name    string
+		match   bool
+	}{
+		{pattern: "servers/*/server.json", name: "servers/alpha/server.json", match: true},
+		{pattern: "servers/*/server.json", name: "servers/alpha/beta/server.json", match: false},
+		{pattern: "servers/*.json", name: "servers/alpha.json", match: true},
+		{pattern: "servers/*.json", name: "servers/alpha.yaml", match: false},
+		{pattern: "servers/**/server.json", name: "servers/server.json", match: true},
+		{pattern: "servers/**/server.json", name: "servers/a/b/c/server.json", match: true},
+		{pattern: "**/skill.json", name: "skills/review/skill.json", match: true},
+		{pattern: "**", name: "skills/review/skill.json", match: true},
+		{pattern: "skills/[ab]*/skill.json", name: "skills/review/skill.json", match: false},
+		{pattern: "skills/[", name: "skills/[", match: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.match, matchGlob(tt.pattern, tt.name))
+		})
+	}
+}
+
+func TestGlobBaseDir(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "servers", globBaseDir("servers/*/server.json"))
+	assert.Equal(t, "catalog/servers", globBaseDir("catalog/servers/**/server.json"))
+	assert.Equal(t, "", globBaseDir("*.json"))
+}
+
+func TestMergeRegistryFiles(t *testing.T) {
+	t.Parallel()
+
+	server := registryFile{
+		path: "servers/alpha/server.json",
+		data: []byte(`{"name": "io.github.example/alpha", "description": "Alpha server", "version": "1.0.0"}`),
+	}
+	skill := registryFile{
+		path: "skills/review/skill.json",
+		data: []byte(`{"namespace": "io.github.example", "name": "review", "description": "Reviews code", "version": "1.0.0"}`),
+	}
+	plugin := registryFile{
+		path: "plugins/lint/plugin.json",
+		data: []byte(`{"namespace": "io.github.example", "name": "lint", "description": "Lints code", "version": "2.0.0"}`),
+	}
+	document := registryFile{
+		path: "registry.json",
+		data: []byte(testUpstreamRegistryData),
+	}
+
+	tests := []struct {
+		name          string
+		files         []registryFile
+		expectServers int
+		expectSkills  int
+		expectPlugins int
+		errorContains string
+	}{
+		{
+			name:          "servers, skills, plugins and registry documents are merged",
+			files:         []registryFile{document, plugin, server, skill},
+			expectServers: 2,
+			expectSkills:  1,
+			expectPlugins: 1,
+		},
+		{
+			name:          "skills only",
+			files:         []registryFile{skill},
+			expectSkills:  1,
+			expectServers: 0,
+		},
+		{
+			name: "duplicate entries name both files",
+			files: []registryFile{server, {
+				path: "servers/alpha-copy/server.json",
+				data: server.data,
+			}},
+			errorContains: "servers/alpha-copy/server.json: server io.github.example/alpha version 1.0.0 " +
+				"is already defined in servers/alpha/server.json",
+		},
+		{
+			name:          "invalid JSON",
+			files:         []registryFile{{path: "servers/broken.json", data: []byte(`{`)}},
+			errorContains: "servers/broken.json: invalid JSON",
+		},
+		{
+			name: "invalid skill",
+			files: []registryFile{{
+				path: "skills/review/skill.json",
+				data: []byte(`{"namespace": "io.github.example", "name": "Review!"}`),
+			}},
+			errorContains: "skills/review/skill.json: skill schema validation failed",
+		},
+		{
+			name: "invalid registry document",
+			files: []registryFile{{
+				path: "registry.json",
+				data: []byte(`{"version": "1.0.0", "meta": {"last_updated": "2025-01-15T10:30:00Z"}, "data": {"servers": []}}`),
+			}},
+			errorContains: "registry.json: ",
+		},
+		{
+			name:          "no files",
+			errorContains: "no servers, skills or plugins found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reg, hash, err := mergeRegistryFiles(tt.files)
+			if tt.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, reg.Data.Servers, tt.expectServers)
+			assert.Len(t, reg.Data.Skills, tt.expectSkills)
+			assert.Len(t, reg.Data.Plugins, tt.expectPlugins)
+			assert.NotEmpty(t, hash)
+
+			// The hash only depends on the merged entries
+			_, again, err := mergeRegistryFiles(tt.files)
+			require.NoError(t, err)
+			assert.Equal(t, hash, again)
+		})
+	}
+}
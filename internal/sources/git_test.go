@@ -37,6 +37,11 @@ func (m *MockGitClient) Clone(ctx context.Context, cfg *git.CloneConfig) (*git.R
 	return args.Get(0).(*git.RepositoryInfo), args.Error(1)
 }
 
+func (m *MockGitClient) ResolveCommit(ctx context.Context, cfg *git.CloneConfig) (string, error) {
+	args := m.Called(ctx, cfg)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockGitClient) Pull(ctx context.Context, repoInfo *git.RepositoryInfo) error {
 	args := m.Called(ctx, repoInfo)
 	return args.Error(0)
@@ -50,6 +55,14 @@ func (m *MockGitClient) GetFileContent(repoInfo *git.RepositoryInfo, path string
 	return args.Get(0).([]byte), args.Error(1)
 }
 
+func (m *MockGitClient) ListFiles(repoInfo *git.RepositoryInfo, dir string) ([]string, error) {
+	args := m.Called(repoInfo, dir)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
 func (m *MockGitClient) GetCommitHash(repoInfo *git.RepositoryInfo) (string, error) {
 	args := m.Called(repoInfo)
 	return args.String(0), args.Error(1)
@@ -370,6 +383,7 @@ func TestGitRegistryHandler_FetchRegistry(t *testing.T) {
 
 				gitClient.On("Clone", mock.Anything, mock.Anything).Return(repoInfo, nil)
 				gitClient.On("GetFileContent", repoInfo, DefaultRegistryDataFile).Return(nil, errors.New("file not found"))
+				gitClient.On("ListFiles", repoInfo, DefaultRegistryDataFile).Return(nil, errors.New("directory not found"))
 				gitClient.On("Cleanup", repoInfo).Return(nil)
 			},
 			expectError:   true,
@@ -438,6 +452,122 @@ func TestGitRegistryHandler_FetchRegistry(t *testing.T) {
 	}
 }
 
+func TestGitRegistryHandler_FetchRegistryMultiFile(t *testing.T) {
+	t.Parallel()
+
+	serverFile := func(name string) []byte {
+		return []byte(`{"name": "` + name + `", "description": "Test server", "version": "1.0.0"}`)
+	}
+	skillFile := []byte(`{"namespace": "io.github.example", "name": "review", "description": "Reviews code", "version": "1.0.0"}`)
+
+	tests := []struct {
+		name          string
+		path          string
+		setupMocks    func(*MockGitClient, *git.RepositoryInfo)
+		expectServers int
+		expectSkills  int
+		errorContains string
+	}{
+		{
+			name: "glob pattern",
+			path: "servers/*/server.json",
+			setupMocks: func(gitClient *MockGitClient, repoInfo *git.RepositoryInfo) {
+				gitClient.On("ListFiles", repoInfo, "servers").Return([]string{
+					"servers/alpha/README.md",
+					"servers/alpha/server.json",
+					"servers/beta/server.json",
+				}, nil)
+				gitClient.On("GetFileContent", repoInfo, "servers/alpha/server.json").
+					Return(serverFile("io.github.example/alpha"), nil)
+				gitClient.On("GetFileContent", repoInfo, "servers/beta/server.json").
+					Return(serverFile("io.github.example/beta"), nil)
+			},
+			expectServers: 2,
+		},
+		{
+			name: "directory",
+			path: "catalog",
+			setupMocks: func(gitClient *MockGitClient, repoInfo *git.RepositoryInfo) {
+				gitClient.On("GetFileContent", repoInfo, "catalog").Return(nil, errors.New("file not found"))
+				gitClient.On("ListFiles", repoInfo, "catalog").Return([]string{
+					"catalog/servers/alpha.json",
+					"catalog/skills/review/SKILL.md",
+					"catalog/skills/review/skill.json",
+				}, nil)
+				gitClient.On("GetFileContent", repoInfo, "catalog/servers/alpha.json").
+					Return(serverFile("io.github.example/alpha"), nil)
+				gitClient.On("GetFileContent", repoInfo, "catalog/skills/review/skill.json").Return(skillFile, nil)
+			},
+			expectServers: 1,
+			expectSkills:  1,
+		},
+		{
+			name: "invalid file is named in the error",
+			path: "servers/",
+			setupMocks: func(gitClient *MockGitClient, repoInfo *git.RepositoryInfo) {
+				gitClient.On("ListFiles", repoInfo, "servers").Return([]string{
+					"servers/alpha.json",
+					"servers/beta.json",
+				}, nil)
+				gitClient.On("GetFileContent", repoInfo, "servers/alpha.json").
+					Return(serverFile("io.github.example/alpha"), nil)
+				gitClient.On("GetFileContent", repoInfo, "servers/beta.json").
+					Return([]byte(`{"name": "io.github.example/beta", "version": "1.0.0"}`), nil)
+			},
+			errorContains: "servers/beta.json: server io.github.example/beta: description is required",
+		},
+		{
+			name: "no matching files",
+			path: "servers/**/server.json",
+			setupMocks: func(gitClient *MockGitClient, repoInfo *git.RepositoryInfo) {
+				gitClient.On("ListFiles", repoInfo, "servers").Return([]string{"servers/README.md"}, nil)
+			},
+			errorContains: "no registry files found matching servers/**/server.json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repoInfo := &git.RepositoryInfo{RemoteURL: testGitRepoURL, CommitSHA: "abc123"}
+			mockGitClient := new(MockGitClient)
+			mockGitClient.On("Clone", mock.Anything, mock.Anything).Return(repoInfo, nil)
+			mockGitClient.On("Cleanup", repoInfo).Return(nil)
+			tt.setupMocks(mockGitClient, repoInfo)
+
+			// The validator is only used for single registry files
+			mockValidator := new(MockRegistryDataValidator)
+			handler := &gitRegistryHandler{
+				gitClient: mockGitClient,
+				validator: mockValidator,
+			}
+
+			result, err := handler.FetchRegistry(context.Background(), &config.SourceConfig{
+				Name: "test-git",
+				Git: &config.GitConfig{
+					Repository: testGitRepoURL,
+					Path:       tt.path,
+				},
+			})
+
+			if tt.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectServers, result.ServerCount)
+				assert.Equal(t, tt.expectSkills, result.SkillCount)
+				assert.Equal(t, "abc123", result.CommitSHA)
+				assert.NotEmpty(t, result.Hash)
+			}
+
+			mockGitClient.AssertExpectations(t)
+			mockValidator.AssertExpectations(t)
+		})
+	}
+}
+
 func TestGitRegistryHandler_DefaultPath(t *testing.T) {
 	t.Parallel()
 
@@ -457,6 +587,42 @@ func TestGitRegistryHandler_DefaultPath(t *testing.T) {
 	assert.Equal(t, DefaultRegistryDataFile, registryConfig.Git.Path, "Default path should be set during validation")
 }
 
+func TestGitRegistryHandler_ResolveCommit(t *testing.T) {
+	t.Parallel()
+
+	registryConfig := &config.SourceConfig{
+		Name: "test-git",
+		Git: &config.GitConfig{
+			Repository: testGitRepoURL,
+			Tag:        "v1.0.0",
+		},
+	}
+
+	mockGitClient := new(MockGitClient)
+	mockGitClient.On("ResolveCommit", mock.Anything, mock.MatchedBy(func(cfg *git.CloneConfig) bool {
+		return cfg.URL == testGitRepoURL && cfg.Tag == "v1.0.0" && cfg.Auth == nil
+	})).Return("abc123", nil)
+
+	handler := &gitRegistryHandler{
+		gitClient: mockGitClient,
+		validator: new(MockRegistryDataValidator),
+	}
+
+	commitSHA, err := handler.ResolveCommit(context.Background(), registryConfig)
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", commitSHA)
+	mockGitClient.AssertExpectations(t)
+
+	// Failures to list the remote references are reported as errors
+	failingClient := new(MockGitClient)
+	failingClient.On("ResolveCommit", mock.Anything, mock.Anything).Return("", errors.New("unreachable"))
+	handler.gitClient = failingClient
+
+	_, err = handler.ResolveCommit(context.Background(), registryConfig)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to resolve remote commit")
+}
+
 func TestGitRegistryHandler_CleanupFailure(t *testing.T) {
 	t.Parallel()
 
@@ -592,6 +758,86 @@ func TestGitRegistryHandler_FetchRegistryWithAuth(t *testing.T) {
 			expectError:   true,
 			errorContains: "failed to get git password",
 		},
+		{
+			name: "successful fetch with ssh authentication",
+			setupAuth: func(t *testing.T) *config.GitAuthConfig {
+				t.Helper()
+				tmpDir := t.TempDir()
+				keyFile := tmpDir + "/deploy-key"
+				err := os.WriteFile(keyFile, []byte("test-key"), 0600)
+				require.NoError(t, err)
+
+				return &config.GitAuthConfig{
+					SSH: &config.GitSSHAuthConfig{
+						PrivateKeyFile: keyFile,
+						KnownHostsFile: tmpDir + "/known_hosts",
+					},
+				}
+			},
+			registryConfig: func(auth *config.GitAuthConfig) *config.SourceConfig {
+				return &config.SourceConfig{
+					Name: "test-git-ssh",
+					Git: &config.GitConfig{
+						Repository: "git@github.com:example/repo.git",
+						Branch:     testBranch,
+						Auth:       auth,
+					},
+				}
+			},
+			setupMocks: func(gitClient *MockGitClient, validator *MockRegistryDataValidator) {
+				repoInfo := &git.RepositoryInfo{
+					RemoteURL: "git@github.com:example/repo.git",
+				}
+				testData := []byte(`{"version": "1.0.0"}`)
+
+				upstreamRegistry := registry.NewTestUpstreamRegistry(
+					registry.WithVersion("1.0.0"),
+				)
+
+				// Verify that the key is read and the default SSH user is used
+				gitClient.On("Clone", mock.Anything, mock.MatchedBy(func(cfg *git.CloneConfig) bool {
+					return cfg.Auth != nil &&
+						cfg.Auth.SSH != nil &&
+						cfg.Auth.SSH.User == config.DefaultGitSSHUser &&
+						string(cfg.Auth.SSH.PrivateKey) == "test-key" &&
+						cfg.Auth.SSH.KnownHostsFile != ""
+				})).Return(repoInfo, nil)
+
+				gitClient.On("GetFileContent", repoInfo, DefaultRegistryDataFile).Return(testData, nil)
+				gitClient.On("Cleanup", repoInfo).Return(nil)
+
+				validator.On("ValidateData", testData).Return(upstreamRegistry, nil)
+			},
+			expectError: false,
+		},
+		{
+			name: "fetch fails when github app private key not readable",
+			setupAuth: func(t *testing.T) *config.GitAuthConfig {
+				t.Helper()
+				return &config.GitAuthConfig{
+					GitHubApp: &config.GitHubAppAuthConfig{
+						AppID:          1234,
+						InstallationID: 42,
+						PrivateKeyFile: t.TempDir() + "/nonexistent-key.pem",
+					},
+				}
+			},
+			registryConfig: func(auth *config.GitAuthConfig) *config.SourceConfig {
+				return &config.SourceConfig{
+					Name: "test-git-app-fail",
+					Git: &config.GitConfig{
+						Repository: testGitRepoURL,
+						Branch:     testBranch,
+						Auth:       auth,
+					},
+				}
+			},
+			setupMocks: func(_ *MockGitClient, _ *MockRegistryDataValidator) {
+				// No mocks needed as the fetch should fail before git operations
+			},
+			expectError:   true,
+			errorContains: "failed to read github app private key",
+		},
 	}
 
 	for _, tt := range tests {
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	registry "github.com/stacklok/toolhive-core/registry/types"
 	config "github.com/stacklok/toolhive-registry-server/internal/config"
@@ -111,6 +112,210 @@ func (mr *MockRegistryHandlerMockRecorder) Validate(regCfg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockRegistryHandler)(nil).Validate), regCfg)
 }
 
+// MockIncrementalRegistryHandler is a mock of IncrementalRegistryHandler interface.
+type MockIncrementalRegistryHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIncrementalRegistryHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIncrementalRegistryHandlerMockRecorder is the mock recorder for MockIncrementalRegistryHandler.
+type MockIncrementalRegistryHandlerMockRecorder struct {
+	mock *MockIncrementalRegistryHandler
+}
+
+// NewMockIncrementalRegistryHandler creates a new mock instance.
+func NewMockIncrementalRegistryHandler(ctrl *gomock.Controller) *MockIncrementalRegistryHandler {
+	mock := &MockIncrementalRegistryHandler{ctrl: ctrl}
+	mock.recorder = &MockIncrementalRegistryHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIncrementalRegistryHandler) EXPECT() *MockIncrementalRegistryHandlerMockRecorder {
+	return m.recorder
+}
+
+// FetchRegistry mocks base method.
+func (m *MockIncrementalRegistryHandler) FetchRegistry(ctx context.Context, regCfg *config.SourceConfig) (*sources.FetchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchRegistry", ctx, regCfg)
+	ret0, _ := ret[0].(*sources.FetchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchRegistry indicates an expected call of FetchRegistry.
+func (mr *MockIncrementalRegistryHandlerMockRecorder) FetchRegistry(ctx, regCfg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRegistry", reflect.TypeOf((*MockIncrementalRegistryHandler)(nil).FetchRegistry), ctx, regCfg)
+}
+
+// FetchRegistrySince mocks base method.
+func (m *MockIncrementalRegistryHandler) FetchRegistrySince(ctx context.Context, regCfg *config.SourceConfig, since time.Time) (*sources.FetchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchRegistrySince", ctx, regCfg, since)
+	ret0, _ := ret[0].(*sources.FetchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchRegistrySince indicates an expected call of FetchRegistrySince.
+func (mr *MockIncrementalRegistryHandlerMockRecorder) FetchRegistrySince(ctx, regCfg, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRegistrySince", reflect.TypeOf((*MockIncrementalRegistryHandler)(nil).FetchRegistrySince), ctx, regCfg, since)
+}
+
+// Validate mocks base method.
+func (m *MockIncrementalRegistryHandler) Validate(regCfg *config.SourceConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", regCfg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockIncrementalRegistryHandlerMockRecorder) Validate(regCfg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockIncrementalRegistryHandler)(nil).Validate), regCfg)
+}
+
+// MockDeltaRegistryHandler is a mock of DeltaRegistryHandler interface.
+type MockDeltaRegistryHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeltaRegistryHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockDeltaRegistryHandlerMockRecorder is the mock recorder for MockDeltaRegistryHandler.
+type MockDeltaRegistryHandlerMockRecorder struct {
+	mock *MockDeltaRegistryHandler
+}
+
+// NewMockDeltaRegistryHandler creates a new mock instance.
+func NewMockDeltaRegistryHandler(ctrl *gomock.Controller) *MockDeltaRegistryHandler {
+	mock := &MockDeltaRegistryHandler{ctrl: ctrl}
+	mock.recorder = &MockDeltaRegistryHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeltaRegistryHandler) EXPECT() *MockDeltaRegistryHandlerMockRecorder {
+	return m.recorder
+}
+
+// FetchRegistry mocks base method.
+func (m *MockDeltaRegistryHandler) FetchRegistry(ctx context.Context, regCfg *config.SourceConfig) (*sources.FetchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchRegistry", ctx, regCfg)
+	ret0, _ := ret[0].(*sources.FetchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchRegistry indicates an expected call of FetchRegistry.
+func (mr *MockDeltaRegistryHandlerMockRecorder) FetchRegistry(ctx, regCfg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRegistry", reflect.TypeOf((*MockDeltaRegistryHandler)(nil).FetchRegistry), ctx, regCfg)
+}
+
+// FetchRegistryAfter mocks base method.
+func (m *MockDeltaRegistryHandler) FetchRegistryAfter(ctx context.Context, regCfg *config.SourceConfig, lastHash string) (*sources.FetchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchRegistryAfter", ctx, regCfg, lastHash)
+	ret0, _ := ret[0].(*sources.FetchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchRegistryAfter indicates an expected call of FetchRegistryAfter.
+func (mr *MockDeltaRegistryHandlerMockRecorder) FetchRegistryAfter(ctx, regCfg, lastHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRegistryAfter", reflect.TypeOf((*MockDeltaRegistryHandler)(nil).FetchRegistryAfter), ctx, regCfg, lastHash)
+}
+
+// Validate mocks base method.
+func (m *MockDeltaRegistryHandler) Validate(regCfg *config.SourceConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", regCfg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockDeltaRegistryHandlerMockRecorder) Validate(regCfg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockDeltaRegistryHandler)(nil).Validate), regCfg)
+}
+
+// MockCommitRegistryHandler is a mock of CommitRegistryHandler interface.
+type MockCommitRegistryHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommitRegistryHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockCommitRegistryHandlerMockRecorder is the mock recorder for MockCommitRegistryHandler.
+type MockCommitRegistryHandlerMockRecorder struct {
+	mock *MockCommitRegistryHandler
+}
+
+// NewMockCommitRegistryHandler creates a new mock instance.
+func NewMockCommitRegistryHandler(ctrl *gomock.Controller) *MockCommitRegistryHandler {
+	mock := &MockCommitRegistryHandler{ctrl: ctrl}
+	mock.recorder = &MockCommitRegistryHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommitRegistryHandler) EXPECT() *MockCommitRegistryHandlerMockRecorder {
+	return m.recorder
+}
+
+// FetchRegistry mocks base method.
+func (m *MockCommitRegistryHandler) FetchRegistry(ctx context.Context, regCfg *config.SourceConfig) (*sources.FetchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchRegistry", ctx, regCfg)
+	ret0, _ := ret[0].(*sources.FetchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchRegistry indicates an expected call of FetchRegistry.
+func (mr *MockCommitRegistryHandlerMockRecorder) FetchRegistry(ctx, regCfg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRegistry", reflect.TypeOf((*MockCommitRegistryHandler)(nil).FetchRegistry), ctx, regCfg)
+}
+
+// ResolveCommit mocks base method.
+func (m *MockCommitRegistryHandler) ResolveCommit(ctx context.Context, regCfg *config.SourceConfig) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveCommit", ctx, regCfg)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveCommit indicates an expected call of ResolveCommit.
+func (mr *MockCommitRegistryHandlerMockRecorder) ResolveCommit(ctx, regCfg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveCommit", reflect.TypeOf((*MockCommitRegistryHandler)(nil).ResolveCommit), ctx, regCfg)
+}
+
+// Validate mocks base method.
+func (m *MockCommitRegistryHandler) Validate(regCfg *config.SourceConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", regCfg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockCommitRegistryHandlerMockRecorder) Validate(regCfg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockCommitRegistryHandler)(nil).Validate), regCfg)
+}
+
 // MockRegistryHandlerFactory is a mock of RegistryHandlerFactory interface.
 type MockRegistryHandlerFactory struct {
 	ctrl     *gomock.Controller
@@ -4,10 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	toolhivetypes "github.com/stacklok/toolhive-core/registry/types"
 
 	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/pinning"
+	"github.com/stacklok/toolhive-registry-server/internal/scanning"
 )
 
 // RegistryDataValidator is an interface for validating registry source configurations
@@ -27,12 +31,46 @@ type RegistryHandler interface {
 	Validate(regCfg *config.SourceConfig) error
 }
 
+// IncrementalRegistryHandler is implemented by handlers whose source can return only the
+// entries changed since a point in time
+type IncrementalRegistryHandler interface {
+	RegistryHandler
+
+	// FetchRegistrySince retrieves the entries updated after since. The returned result
+	// has Incremental set and lists removed servers in DeletedServers.
+	FetchRegistrySince(ctx context.Context, regCfg *config.SourceConfig, since time.Time) (*FetchResult, error)
+}
+
+// DeltaRegistryHandler is implemented by handlers whose source can return the changes
+// made to the data after the data of a previous sync, identified by its hash
+type DeltaRegistryHandler interface {
+	RegistryHandler
+
+	// FetchRegistryAfter retrieves the changes made after the data whose hash is
+	// lastHash. The returned result has Incremental set when it only holds the
+	// changes, and lists the removed entry versions in DeletedServers,
+	// DeletedSkills and DeletedPlugins.
+	FetchRegistryAfter(ctx context.Context, regCfg *config.SourceConfig, lastHash string) (*FetchResult, error)
+}
+
+// CommitRegistryHandler is implemented by handlers whose source data is read from a
+// version control commit, so that changes can be detected by resolving the current
+// commit before fetching the data
+type CommitRegistryHandler interface {
+	RegistryHandler
+
+	// ResolveCommit returns the SHA of the commit the source currently points to,
+	// without fetching the data
+	ResolveCommit(ctx context.Context, regCfg *config.SourceConfig) (string, error)
+}
+
 // FetchResult contains the result of a fetch operation
 type FetchResult struct {
 	// Registry is the parsed registry data in unified UpstreamRegistry format
 	Registry *toolhivetypes.UpstreamRegistry
 
-	// Hash is the SHA256 hash of the serialized data for change detection
+	// Hash is the SHA256 hash of the serialized data for change detection,
+	// or the ID of the last applied bundle for bundle sources
 	Hash string
 
 	// ServerCount is the number of servers found in the registry data
@@ -43,6 +81,45 @@ type FetchResult struct {
 
 	// PluginCount is the number of plugins found in the registry data
 	PluginCount int
+
+	// Incremental indicates the result only holds entries changed since the last sync
+	// and must be merged into the stored data instead of replacing it
+	Incremental bool
+
+	// DeletedServers lists the server versions removed upstream since the last sync.
+	// Only populated for incremental results.
+	DeletedServers []upstreamv0.ServerJSON
+
+	// DeletedSkills and DeletedPlugins list the skill and plugin versions removed
+	// since the last sync, like DeletedServers. Only populated for bundle sources.
+	DeletedSkills  []toolhivetypes.Skill
+	DeletedPlugins []toolhivetypes.Plugin
+
+	// CommitSHA is the SHA of the commit the data was read from.
+	// Only populated for Git sources.
+	CommitSHA string
+
+	// BlockedCount is the number of entry versions removed by the entry policy
+	BlockedCount int
+
+	// EntryScans holds the scans of the entry versions kept by the entry policy.
+	// Only populated when the entry policy applies to the source.
+	EntryScans []scanning.EntryScan
+
+	// UnpinnedCount is the number of entry versions removed because their OCI
+	// packages are not pinned to a digest, as required by a registry
+	UnpinnedCount int
+
+	// PackageDigests holds the digests the tags of the OCI packages of the entry
+	// versions resolved to. Only populated when digest pinning applies to the source.
+	PackageDigests []pinning.PackageDigest
+
+	// ServerClaims, SkillClaims and PluginClaims map entry names to the JSON
+	// encoded claims of the entries, overriding the claims of the source.
+	// Only populated for bundle sources.
+	ServerClaims map[string][]byte
+	SkillClaims  map[string][]byte
+	PluginClaims map[string][]byte
 }
 
 // NewFetchResult creates a new FetchResult from a UpstreamRegistry instance and pre-calculated hash
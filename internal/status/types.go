@@ -49,10 +49,24 @@ type SyncStatus struct {
 	// distinguish a successful sync from a failed attempt.
 	LastSyncTime *time.Time `yaml:"lastSyncTime,omitempty"`
 
+	// LastSuccessfulSyncTime is the timestamp at which the most recent successful
+	// sync started. Incremental API syncs use it as the updated_since watermark,
+	// so it is taken at the start of the sync to avoid missing concurrent updates.
+	LastSuccessfulSyncTime *time.Time `yaml:"lastSuccessfulSyncTime,omitempty"`
+
+	// LastFullSyncTime is the timestamp at which the most recent successful full
+	// (non-incremental) sync started. It schedules periodic full reconciliation
+	// for sources with incremental sync enabled.
+	LastFullSyncTime *time.Time `yaml:"lastFullSyncTime,omitempty"`
+
 	// LastSyncHash is the hash of the last successfully synced data
 	// Used to detect changes in source data
 	LastSyncHash string `yaml:"lastSyncHash,omitempty"`
 
+	// LastSyncCommit is the commit SHA the last successful sync of a Git source
+	// was read from. Used to skip the clone when the remote ref has not moved
+	LastSyncCommit string `yaml:"lastSyncCommit,omitempty"`
+
 	// LastAppliedFilterHash is the hash of the last applied filter
 	LastAppliedFilterHash string `yaml:"lastAppliedFilterHash,omitempty"`
 
@@ -72,4 +86,100 @@ type SyncStatus struct {
 	// SyncSchedule is the sync interval from configuration (e.g., "30m", "1h")
 	// This field is nullable - non-synced registries (managed, kubernetes) will have an empty value
 	SyncSchedule string `yaml:"syncSchedule,omitempty"`
+
+	// ManualSyncRequested is true when a manual sync was requested via the API
+	// and has not been picked up yet. It is derived from pending sync requests
+	// and is not persisted as part of the status.
+	ManualSyncRequested bool `yaml:"-"`
+}
+
+// EntryDigests maps the key of every entry version stored by a sync
+// ("<type>/<name>@<version>") to a digest of its content. Comparing the
+// digests of consecutive syncs yields the entries they added, updated and removed.
+type EntryDigests map[string]string
+
+// EntryChanges counts the entry versions of one type changed by a sync
+type EntryChanges struct {
+	// Added is the number of entry versions that were not stored before the sync
+	Added int `yaml:"added,omitempty"`
+
+	// Updated is the number of stored entry versions whose content changed
+	Updated int `yaml:"updated,omitempty"`
+
+	// Removed is the number of stored entry versions that the sync deleted
+	Removed int `yaml:"removed,omitempty"`
+}
+
+// SyncChanges counts the entry versions changed by a sync, per entry type
+type SyncChanges struct {
+	Servers EntryChanges `yaml:"servers,omitempty"`
+	Skills  EntryChanges `yaml:"skills,omitempty"`
+	Plugins EntryChanges `yaml:"plugins,omitempty"`
+
+	// Entries lists the changed entry versions for the change feed. Only the
+	// counts are kept in the sync history.
+	Entries []EntryChange `yaml:"-"`
+}
+
+// EntryChangeType is the type of change made to an entry version
+type EntryChangeType string
+
+const (
+	// EntryCreated means that the entry version was not stored before
+	EntryCreated EntryChangeType = "created"
+	// EntryUpdated means that the content of a stored entry version changed
+	EntryUpdated EntryChangeType = "updated"
+	// EntryDeleted means that a stored entry version was deleted
+	EntryDeleted EntryChangeType = "deleted"
+)
+
+// EntryChange is an entry version changed by a sync
+type EntryChange struct {
+	// Type is the type of change
+	Type EntryChangeType
+
+	// EntryType is server, skill or plugin
+	EntryType string
+
+	// Namespace is the namespace of skills and plugins, empty for servers
+	Namespace string
+
+	// Name and Version identify the entry version
+	Name    string
+	Version string
+}
+
+// SyncAttempt records the outcome of a single sync attempt for the sync history
+type SyncAttempt struct {
+	// StartedAt is the timestamp at which the attempt started
+	StartedAt time.Time `yaml:"startedAt"`
+
+	// EndedAt is the timestamp at which the attempt ended
+	EndedAt time.Time `yaml:"endedAt"`
+
+	// Reason is the reason the sync was triggered (see sync.Reason)
+	Reason string `yaml:"reason"`
+
+	// Phase is either SyncPhaseComplete or SyncPhaseFailed
+	Phase SyncPhase `yaml:"phase"`
+
+	// Message provides additional information about the outcome
+	Message string `yaml:"message,omitempty"`
+
+	// ConditionReason classifies the failure of a failed attempt
+	ConditionReason string `yaml:"conditionReason,omitempty"`
+
+	// Hash is the hash of the synced data
+	Hash string `yaml:"hash,omitempty"`
+
+	// Incremental is true when only the entries changed since the last sync were applied
+	Incremental bool `yaml:"incremental,omitempty"`
+
+	// ServerCount, SkillCount and PluginCount are the totals stored after the attempt
+	ServerCount int `yaml:"serverCount,omitempty"`
+	SkillCount  int `yaml:"skillCount,omitempty"`
+	PluginCount int `yaml:"pluginCount,omitempty"`
+
+	// Changes counts the entry versions changed by the attempt
+	Changes SyncChanges `yaml:"changes,omitempty"`
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"math/rand/v2"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -37,6 +38,10 @@ type Coordinator interface {
 
 	// Stop gracefully stops the coordinator and all registry sync loops
 	Stop() error
+
+	// Wake checks for due sources right away instead of waiting for the next
+	// polling tick, e.g. after a sync was requested. It never blocks.
+	Wake()
 }
 
 // defaultCoordinator is the default implementation of Coordinator
@@ -47,6 +52,8 @@ type defaultCoordinator struct {
 	// Lifecycle management
 	cancelFunc context.CancelFunc
 	done       chan struct{}
+	// wake starts a new polling round before the next tick
+	wake chan struct{}
 
 	statusSvc state.RegistryStateService
 
@@ -57,6 +64,17 @@ type defaultCoordinator struct {
 	// Tracing
 	tracer trace.Tracer
 
+	// concurrency is the number of workers syncing sources in parallel
+	concurrency int
+
+	// roundMu guards round and claimedRounds
+	roundMu sync.Mutex
+	// round is the current polling round, incremented on every polling tick
+	round uint64
+	// claimedRounds holds the last round in which each source was claimed,
+	// so that a source is synced at most once per polling round
+	claimedRounds map[string]uint64
+
 	// pollingIntervalOverride overrides the default polling interval (for testing)
 	pollingIntervalOverride time.Duration
 }
@@ -104,10 +122,14 @@ func New(
 	opts ...Option,
 ) Coordinator {
 	c := &defaultCoordinator{
-		manager:   manager,
-		statusSvc: statusSvc,
-		config:    cfg,
-		done:      make(chan struct{}),
+		manager:       manager,
+		statusSvc:     statusSvc,
+		config:        cfg,
+		done:          make(chan struct{}),
+		wake:          make(chan struct{}, 1),
+		concurrency:   cfg.GetSyncConcurrency(),
+		round:         1,
+		claimedRounds: make(map[string]uint64),
 	}
 
 	for _, opt := range opts {
@@ -152,20 +174,35 @@ func (c *defaultCoordinator) Start(ctx context.Context) error {
 	}
 	slog.Info("Configured coordinator sync interval",
 		"base_interval", basePollingInterval,
-		"actual_interval", pollingInterval)
+		"actual_interval", pollingInterval,
+		"workers", c.concurrency)
 
 	// Create ticker for periodic sync checks
 	ticker := time.NewTicker(pollingInterval)
 	defer ticker.Stop()
 
-	// Perform initial sync check
-	c.processNextSyncJob(coordCtx)
+	// Start the sync workers. Each one has a wakeup channel that is signalled
+	// on every tick, and starts with a pending wakeup for the initial sync check.
+	wakeups := make([]chan struct{}, c.concurrency)
+	var workers sync.WaitGroup
+	for i := range wakeups {
+		wakeups[i] = make(chan struct{}, 1)
+		wakeups[i] <- struct{}{}
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			c.runWorker(coordCtx, i, wakeups[i])
+		}()
+	}
+	// Wait for in-flight syncs to finish before reporting the coordinator as done
+	defer workers.Wait()
 
 	// Run the coordinator loop
 	for {
 		select {
 		case <-ticker.C:
-			c.processNextSyncJob(coordCtx)
+			c.nextRound()
+			wakeWorkers(wakeups)
 
 			// Recalculate interval with new jitter for next iteration
 			if c.pollingIntervalOverride > 0 {
@@ -173,6 +210,10 @@ func (c *defaultCoordinator) Start(ctx context.Context) error {
 			} else {
 				ticker.Reset(calculatePollingInterval())
 			}
+		case <-c.wake:
+			// The ticker is left alone, so waking up does not delay the next tick
+			c.nextRound()
+			wakeWorkers(wakeups)
 		case <-coordCtx.Done():
 			slog.Info("Sync coordinator stopping")
 			return nil
@@ -194,14 +235,88 @@ func (c *defaultCoordinator) Stop() error {
 	return nil
 }
 
-// processNextSyncJob gets the next job and processes it if available
-func (c *defaultCoordinator) processNextSyncJob(ctx context.Context) {
+// Wake starts a new polling round right away, so that a source with a pending
+// sync request is picked up without waiting for the next tick
+func (c *defaultCoordinator) Wake() {
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+}
+
+// wakeWorkers signals every sync worker to check for due sources
+func wakeWorkers(wakeups []chan struct{}) {
+	for _, wakeup := range wakeups {
+		// A wakeup that is still pending belongs to a busy worker, which
+		// checks for due sources again once its current sync is done
+		select {
+		case wakeup <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// runWorker runs a sync worker until the context is cancelled. On every wakeup
+// the worker claims and syncs due sources one at a time until none is left.
+// Workers claim their own source rows, so a slow sync only occupies the worker
+// running it.
+func (c *defaultCoordinator) runWorker(ctx context.Context, worker int, wakeup <-chan struct{}) {
+	for {
+		select {
+		case <-wakeup:
+			for ctx.Err() == nil && c.processNextSyncJob(ctx, worker) {
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// nextRound starts a new polling round, making all sources claimable again
+func (c *defaultCoordinator) nextRound() {
+	c.roundMu.Lock()
+	defer c.roundMu.Unlock()
+	c.round++
+}
+
+// claimedInRound reports whether a source was already claimed in the current polling round
+func (c *defaultCoordinator) claimedInRound(registryName string) bool {
+	c.roundMu.Lock()
+	defer c.roundMu.Unlock()
+	return c.claimedRounds[registryName] == c.round
+}
+
+// markClaimed records that a source was claimed in the current polling round
+func (c *defaultCoordinator) markClaimed(registryName string) {
+	c.roundMu.Lock()
+	defer c.roundMu.Unlock()
+	c.claimedRounds[registryName] = c.round
+}
+
+// processNextSyncJob gets the next job and processes it on behalf of the given
+// worker. It returns true if a job was processed, and false if no source needs
+// to be synced or the next job could not be claimed.
+func (c *defaultCoordinator) processNextSyncJob(ctx context.Context, worker int) bool {
 	var prefetched *sources.FetchResult
+	var unchanged *status.SyncStatus
+	var syncReason pkgsync.Reason
 	// Get the next sync job using the predicate to check if sync is needed
 	regCfg, err := c.statusSvc.GetNextSyncJob(
 		ctx,
 		func(regCfg *config.SourceConfig, syncStatus *status.SyncStatus) bool {
-			reason, fetchResult := c.manager.ShouldSync(ctx, regCfg, syncStatus, false)
+			// Sources are synced at most once per polling round, so that a
+			// source that is immediately due again cannot keep a worker busy
+			if c.claimedInRound(regCfg.Name) {
+				return false
+			}
+			reason, fetchResult := c.manager.ShouldSync(ctx, regCfg, syncStatus, syncStatus.ManualSyncRequested)
+			if reason == pkgsync.ReasonManualNoChanges {
+				// Claim the source anyway so the pending manual sync request is
+				// resolved, even though there is nothing to sync.
+				unchanged = syncStatus
+				c.markClaimed(regCfg.Name)
+				return true
+			}
 			if !reason.ShouldSync() {
 				slog.Debug("Registry does not need sync",
 					"registry", regCfg.Name,
@@ -209,27 +324,83 @@ func (c *defaultCoordinator) processNextSyncJob(ctx context.Context) {
 				return false
 			}
 			prefetched = fetchResult
+			syncReason = reason
+			c.markClaimed(regCfg.Name)
 			return true
 		},
 	)
 	if err != nil {
-		slog.Error("Error getting next sync job", "error", err)
-		return
+		slog.Error("Error getting next sync job", "worker", worker, "error", err)
+		return false
 	}
 
 	// No job available
 	if regCfg == nil {
+		return false
+	}
+
+	slog.Debug("Sync worker claimed source", "worker", worker, "registry", regCfg.Name)
+	if c.syncMetrics != nil {
+		c.syncMetrics.RecordWorkerBusy(ctx, worker, true)
+		defer c.syncMetrics.RecordWorkerBusy(ctx, worker, false)
+	}
+
+	success := true
+	if unchanged != nil {
+		c.completeUnchangedSync(ctx, regCfg.Name, unchanged)
+	} else {
+		// Perform the sync
+		success = c.performRegistrySync(ctx, regCfg, syncReason, prefetched)
+	}
+
+	if c.syncMetrics != nil {
+		c.syncMetrics.RecordWorkerSync(ctx, worker, success)
+	}
+	return true
+}
+
+// completeUnchangedSync finishes a manually requested sync whose source data has
+// not changed. The previous sync results are kept and only the timestamps, phase
+// and message are updated.
+func (c *defaultCoordinator) completeUnchangedSync(
+	ctx context.Context, registryName string, previous *status.SyncStatus,
+) {
+	now := time.Now()
+	syncStatus := *previous
+	syncStatus.Phase = status.SyncPhaseComplete
+	syncStatus.Message = "Manual sync completed, no data changes"
+	syncStatus.LastAttempt = &now
+	syncStatus.LastSyncTime = &now
+	syncStatus.AttemptCount = 0
+	syncStatus.ManualSyncRequested = false
+
+	if err := c.statusSvc.UpdateSyncStatus(ctx, registryName, &syncStatus); err != nil {
+		slog.Error("Error updating sync status",
+			"registry", registryName,
+			"error", err)
 		return
 	}
 
-	// Perform the sync
-	c.performRegistrySync(ctx, regCfg, prefetched)
+	// The stored entries are untouched, so the entry digests are kept as well
+	c.recordSyncAttempt(ctx, registryName, &status.SyncAttempt{
+		StartedAt:   now,
+		EndedAt:     now,
+		Reason:      pkgsync.ReasonManualNoChanges.String(),
+		Phase:       status.SyncPhaseComplete,
+		Message:     syncStatus.Message,
+		Hash:        syncStatus.LastSyncHash,
+		ServerCount: syncStatus.ServerCount,
+		SkillCount:  syncStatus.SkillCount,
+		PluginCount: syncStatus.PluginCount,
+	}, nil)
+
+	slog.Info("Manual sync requested but source data is unchanged", "registry", registryName)
 }
 
-// performRegistrySync executes the sync operation for a registry
+// performRegistrySync executes the sync operation for a registry and reports whether it succeeded
 func (c *defaultCoordinator) performRegistrySync(
-	ctx context.Context, regCfg *config.SourceConfig, prefetched *sources.FetchResult,
-) {
+	ctx context.Context, regCfg *config.SourceConfig, reason pkgsync.Reason, prefetched *sources.FetchResult,
+) bool {
 	registryName := regCfg.Name
 	startTime := time.Now()
 
@@ -249,12 +420,26 @@ func (c *defaultCoordinator) performRegistrySync(
 		Phase:   status.SyncPhaseFailed,
 		Message: fmt.Sprintf("Unexpected failure while syncing registry %s", registryName),
 	}
+	// Every attempt is also appended to the sync history of the source.
+	// entryDigests stays nil unless the attempt stored new entries.
+	attempt := &status.SyncAttempt{
+		StartedAt: startTime,
+		Reason:    reason.String(),
+	}
+	var entryDigests status.EntryDigests
 	defer func() {
 		if err := c.statusSvc.UpdateSyncStatus(ctx, registryName, syncStatus); err != nil {
 			slog.Error("Error updating sync status",
 				"registry", registryName,
 				"error", err)
 		}
+
+		attempt.Phase = syncStatus.Phase
+		attempt.Message = syncStatus.Message
+		if attempt.EndedAt.IsZero() {
+			attempt.EndedAt = time.Now()
+		}
+		c.recordSyncAttempt(ctx, registryName, attempt, entryDigests)
 	}()
 
 	slog.Info("Starting sync operation", "registry", registryName)
@@ -280,9 +465,11 @@ func (c *defaultCoordinator) performRegistrySync(
 	// `ended_at` NULL and starve every other source by sorting first forever.
 	now := time.Now()
 	syncStatus.LastSyncTime = &now
+	attempt.EndedAt = now
 	if syncErr != nil {
 		syncStatus.Phase = status.SyncPhaseFailed
 		syncStatus.Message = syncErr.Message
+		attempt.ConditionReason = syncErr.ConditionReason
 		slog.Error("Sync failed",
 			"registry", registryName,
 			"error", syncErr.Message)
@@ -301,10 +488,23 @@ func (c *defaultCoordinator) performRegistrySync(
 		syncStatus.Phase = status.SyncPhaseComplete
 		syncStatus.Message = "Sync completed successfully"
 		syncStatus.LastSyncHash = result.Hash
+		syncStatus.LastSyncCommit = result.CommitSHA
 		syncStatus.ServerCount = result.ServerCount
 		syncStatus.SkillCount = result.SkillCount
 		syncStatus.PluginCount = result.PluginCount
 		syncStatus.AttemptCount = 0
+		// Incremental syncs only fetch entries updated since this watermark, so it is
+		// taken from the start of the sync rather than its end.
+		syncStatus.LastSuccessfulSyncTime = &startTime
+		if !result.Incremental {
+			syncStatus.LastFullSyncTime = &startTime
+		}
+		attempt.Hash = result.Hash
+		attempt.Incremental = result.Incremental
+		attempt.ServerCount = result.ServerCount
+		attempt.SkillCount = result.SkillCount
+		attempt.PluginCount = result.PluginCount
+		entryDigests, attempt.Changes = c.diffEntryDigests(ctx, registryName, result)
 		hashPreview := result.Hash
 		if len(hashPreview) > 8 {
 			hashPreview = hashPreview[:8]
@@ -314,6 +514,7 @@ func (c *defaultCoordinator) performRegistrySync(
 			"server_count", result.ServerCount,
 			"skill_count", result.SkillCount,
 			"plugin_count", result.PluginCount,
+			"incremental", result.Incremental,
 			"hash", hashPreview)
 
 		// Add counts to span on success
@@ -329,4 +530,39 @@ func (c *defaultCoordinator) performRegistrySync(
 		}
 
 	}
+
+	return syncErr == nil
+}
+
+// diffEntryDigests compares the entry digests of a successful sync with the ones
+// stored by the previous sync. It returns the digests to store and the changes,
+// or nil digests and no changes if the comparison is not possible.
+func (c *defaultCoordinator) diffEntryDigests(
+	ctx context.Context, registryName string, result *pkgsync.Result,
+) (status.EntryDigests, status.SyncChanges) {
+	if result.EntryDigests == nil {
+		return nil, status.SyncChanges{}
+	}
+
+	previous, err := c.statusSvc.GetEntryDigests(ctx, registryName)
+	if err != nil {
+		slog.Warn("Failed to get entry digests of the previous sync",
+			"registry", registryName,
+			"error", err)
+		return nil, status.SyncChanges{}
+	}
+
+	return pkgsync.ApplyEntryDigests(previous, result)
+}
+
+// recordSyncAttempt appends a sync attempt to the sync history. Failures are
+// logged only, as the history must never affect the sync itself.
+func (c *defaultCoordinator) recordSyncAttempt(
+	ctx context.Context, registryName string, attempt *status.SyncAttempt, digests status.EntryDigests,
+) {
+	if err := c.statusSvc.RecordSyncAttempt(ctx, registryName, attempt, digests); err != nil {
+		slog.Error("Error recording sync attempt",
+			"registry", registryName,
+			"error", err)
+	}
 }
@@ -5,6 +5,7 @@ import (
 	"sort"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -92,9 +93,12 @@ func TestPerformRegistrySync_FailureAdvancesEndedAt(t *testing.T) {
 			captured = s
 			return nil
 		})
+	mockStateSvc.EXPECT().
+		RecordSyncAttempt(gomock.Any(), "broken-source", gomock.Any(), gomock.Nil()).
+		Return(nil)
 
 	c := New(mockManager, mockStateSvc, cfg).(*defaultCoordinator)
-	c.performRegistrySync(context.Background(), regCfg, nil)
+	c.performRegistrySync(context.Background(), regCfg, pkgsync.ReasonSourceDataChanged, nil)
 
 	require.NotNil(t, captured, "UpdateSyncStatus must be called even on failure")
 	assert.Equal(t, status.SyncPhaseFailed, captured.Phase)
@@ -127,6 +131,9 @@ func TestPerformRegistrySync_FailureRecordsSyncError(t *testing.T) {
 	mockStateSvc.EXPECT().
 		UpdateSyncStatus(gomock.Any(), "broken-source", gomock.Any()).
 		Return(nil)
+	mockStateSvc.EXPECT().
+		RecordSyncAttempt(gomock.Any(), "broken-source", gomock.Any(), gomock.Nil()).
+		Return(nil)
 
 	reader := sdkmetric.NewManualReader()
 	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
@@ -137,7 +144,7 @@ func TestPerformRegistrySync_FailureRecordsSyncError(t *testing.T) {
 	require.NotNil(t, syncMetrics)
 
 	c := New(mockManager, mockStateSvc, cfg, WithSyncMetrics(syncMetrics)).(*defaultCoordinator)
-	c.performRegistrySync(context.Background(), regCfg, nil)
+	c.performRegistrySync(context.Background(), regCfg, pkgsync.ReasonSourceDataChanged, nil)
 
 	var rm metricdata.ResourceMetrics
 	require.NoError(t, reader.Collect(context.Background(), &rm))
@@ -186,6 +193,9 @@ func TestPerformRegistrySync_SuccessDoesNotRecordSyncError(t *testing.T) {
 	mockStateSvc.EXPECT().
 		UpdateSyncStatus(gomock.Any(), "healthy-source", gomock.Any()).
 		Return(nil)
+	mockStateSvc.EXPECT().
+		RecordSyncAttempt(gomock.Any(), "healthy-source", gomock.Any(), gomock.Nil()).
+		Return(nil)
 
 	reader := sdkmetric.NewManualReader()
 	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
@@ -196,7 +206,7 @@ func TestPerformRegistrySync_SuccessDoesNotRecordSyncError(t *testing.T) {
 	require.NotNil(t, syncMetrics)
 
 	c := New(mockManager, mockStateSvc, cfg, WithSyncMetrics(syncMetrics)).(*defaultCoordinator)
-	c.performRegistrySync(context.Background(), regCfg, nil)
+	c.performRegistrySync(context.Background(), regCfg, pkgsync.ReasonSourceDataChanged, nil)
 
 	var rm metricdata.ResourceMetrics
 	require.NoError(t, reader.Collect(context.Background(), &rm))
@@ -256,7 +266,9 @@ func TestProcessNextSyncJob_FailingSourceDoesNotStarveOthers(t *testing.T) {
 	const maxTicks = 5
 	var betaPickedTick int
 	for tick := 1; tick <= maxTicks; tick++ {
-		c.processNextSyncJob(context.Background())
+		// Every tick starts a new polling round, as in Start
+		c.nextRound()
+		c.processNextSyncJob(context.Background(), 0)
 		if fakeState.lastPicked() == "beta" {
 			betaPickedTick = tick
 			break
@@ -269,6 +281,288 @@ func TestProcessNextSyncJob_FailingSourceDoesNotStarveOthers(t *testing.T) {
 		"beta should be selected by the second tick once alpha's ended_at is advanced")
 }
 
+func TestProcessNextSyncJob_ManualSyncWithoutChanges(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockManager := syncmocks.NewMockManager(ctrl)
+
+	src := &config.SourceConfig{Name: "alpha", Git: &config.GitConfig{Repository: "https://example.invalid/repo.git"}}
+	fakeState := newFakeStateService(src)
+	fakeState.statuses["alpha"] = &status.SyncStatus{
+		Phase:               status.SyncPhaseComplete,
+		LastSyncHash:        "previous-hash",
+		ServerCount:         4,
+		ManualSyncRequested: true,
+	}
+
+	cfg := &config.Config{Sources: []config.SourceConfig{*src}}
+
+	// The manual request flag is passed through, and no sync is performed when
+	// the source data is unchanged.
+	mockManager.EXPECT().
+		ShouldSync(gomock.Any(), src, gomock.Any(), true).
+		Return(pkgsync.ReasonManualNoChanges, (*sources.FetchResult)(nil))
+
+	c := New(mockManager, fakeState, cfg).(*defaultCoordinator)
+	c.processNextSyncJob(context.Background(), 0)
+
+	assert.Equal(t, "alpha", fakeState.lastPicked())
+	got, err := fakeState.GetSyncStatus(context.Background(), "alpha")
+	require.NoError(t, err)
+	assert.Equal(t, status.SyncPhaseComplete, got.Phase)
+	assert.Equal(t, "previous-hash", got.LastSyncHash)
+	assert.Equal(t, 4, got.ServerCount)
+	assert.False(t, got.ManualSyncRequested)
+	require.NotNil(t, got.LastSyncTime)
+
+	require.Len(t, fakeState.history["alpha"], 1)
+	assert.Equal(t, "manual-sync-no-data-changes", fakeState.history["alpha"][0].Reason)
+	assert.Equal(t, status.SyncPhaseComplete, fakeState.history["alpha"][0].Phase)
+}
+
+func TestPerformRegistrySync_RecordsSyncHistory(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockManager := syncmocks.NewMockManager(ctrl)
+
+	src := &config.SourceConfig{Name: "alpha", Git: &config.GitConfig{Repository: "https://example.invalid/repo.git"}}
+	fakeState := newFakeStateService(src)
+	fakeState.digests["alpha"] = status.EntryDigests{
+		"server/io.test/kept@1.0.0":    "same",
+		"server/io.test/changed@1.0.0": "old",
+		"server/io.test/removed@1.0.0": "gone",
+	}
+
+	cfg := &config.Config{Sources: []config.SourceConfig{*src}}
+
+	mockManager.EXPECT().
+		PerformSync(gomock.Any(), src, gomock.Nil()).
+		Return(&pkgsync.Result{
+			Hash:        "new-hash",
+			ServerCount: 3,
+			EntryDigests: status.EntryDigests{
+				"server/io.test/kept@1.0.0":    "same",
+				"server/io.test/changed@1.0.0": "new",
+				"server/io.test/added@1.0.0":   "fresh",
+			},
+		}, nil)
+	mockManager.EXPECT().
+		PerformSync(gomock.Any(), src, gomock.Nil()).
+		Return(nil, &pkgsync.Error{Message: "fetch failed", ConditionReason: "FetchFailed"})
+
+	c := New(mockManager, fakeState, cfg).(*defaultCoordinator)
+	c.performRegistrySync(context.Background(), src, pkgsync.ReasonSourceDataChanged, nil)
+	c.performRegistrySync(context.Background(), src, pkgsync.ReasonRegistryNotReady, nil)
+
+	history := fakeState.history["alpha"]
+	require.Len(t, history, 2)
+
+	success := history[0]
+	assert.Equal(t, status.SyncPhaseComplete, success.Phase)
+	assert.Equal(t, "source-data-changed", success.Reason)
+	assert.Equal(t, "new-hash", success.Hash)
+	assert.Equal(t, 3, success.ServerCount)
+	assert.Equal(t, status.EntryChanges{Added: 1, Updated: 1, Removed: 1}, success.Changes.Servers)
+	assert.False(t, success.StartedAt.After(success.EndedAt))
+
+	failure := history[1]
+	assert.Equal(t, status.SyncPhaseFailed, failure.Phase)
+	assert.Equal(t, "registry-not-ready", failure.Reason)
+	assert.Equal(t, "fetch failed", failure.Message)
+	assert.Equal(t, "FetchFailed", failure.ConditionReason)
+
+	// Only the successful sync replaces the stored digests
+	assert.Equal(t, status.EntryDigests{
+		"server/io.test/kept@1.0.0":    "same",
+		"server/io.test/changed@1.0.0": "new",
+		"server/io.test/added@1.0.0":   "fresh",
+	}, fakeState.digests["alpha"])
+}
+
+func TestProcessNextSyncJob_ClaimsEachSourceOncePerRound(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockManager := syncmocks.NewMockManager(ctrl)
+
+	alpha := &config.SourceConfig{Name: "alpha", Git: &config.GitConfig{Repository: "https://example.invalid/broken.git"}}
+	beta := &config.SourceConfig{Name: "beta", Git: &config.GitConfig{Repository: "https://example.invalid/good.git"}}
+	fakeState := newFakeStateService(alpha, beta)
+	cfg := &config.Config{Sources: []config.SourceConfig{*alpha, *beta}}
+
+	// Both sources always need syncing, so only the round bounds the work of a worker
+	mockManager.EXPECT().
+		ShouldSync(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(pkgsync.ReasonRegistryNotReady, (*sources.FetchResult)(nil)).
+		AnyTimes()
+	mockManager.EXPECT().
+		PerformSync(gomock.Any(), alpha, gomock.Any()).
+		Return(nil, &pkgsync.Error{Message: "broken upstream"}).
+		Times(2)
+	mockManager.EXPECT().
+		PerformSync(gomock.Any(), beta, gomock.Any()).
+		Return(&pkgsync.Result{Hash: "h", ServerCount: 1}, nil).
+		Times(2)
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { _ = mp.Shutdown(context.Background()) })
+
+	syncMetrics, err := telemetry.NewSyncMetrics(mp)
+	require.NoError(t, err)
+
+	c := New(mockManager, fakeState, cfg, WithSyncMetrics(syncMetrics)).(*defaultCoordinator)
+
+	// A worker drains the due sources, then runs out of work for the round
+	assert.True(t, c.processNextSyncJob(context.Background(), 1))
+	assert.True(t, c.processNextSyncJob(context.Background(), 1))
+	assert.False(t, c.processNextSyncJob(context.Background(), 1))
+
+	// The next round makes both sources claimable again
+	c.nextRound()
+	assert.True(t, c.processNextSyncJob(context.Background(), 0))
+	assert.True(t, c.processNextSyncJob(context.Background(), 0))
+	assert.False(t, c.processNextSyncJob(context.Background(), 0))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	var syncs metricdata.Sum[int64]
+	var found bool
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name == "stacklok.registry.sync.worker.syncs" {
+				syncs, found = m.Data.(metricdata.Sum[int64])
+				require.True(t, found, "expected int64 sum data type")
+			}
+		}
+	}
+	require.True(t, found, "workers must record stacklok.registry.sync.worker.syncs")
+
+	// Each worker synced alpha (failure) and beta (success) once
+	require.Len(t, syncs.DataPoints, 4)
+	for _, dp := range syncs.DataPoints {
+		assert.Equal(t, int64(1), dp.Value, "unexpected count for %v", dp.Attributes)
+	}
+}
+
+func TestStart_SyncsSourcesInParallel(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockManager := syncmocks.NewMockManager(ctrl)
+
+	slow := &config.SourceConfig{Name: "alpha-slow", Git: &config.GitConfig{Repository: "https://example.invalid/slow.git"}}
+	fast := &config.SourceConfig{Name: "beta-fast", File: &config.FileConfig{Path: "/tmp/registry.json"}}
+	fakeState := newFakeStateService(slow, fast)
+	cfg := &config.Config{
+		Sources: []config.SourceConfig{*slow, *fast},
+		Sync:    &config.SyncConfig{Concurrency: 2},
+	}
+
+	mockManager.EXPECT().
+		ShouldSync(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(pkgsync.ReasonRegistryNotReady, (*sources.FetchResult)(nil)).
+		AnyTimes()
+
+	// The slow source only finishes once the fast one has been synced, which
+	// can only happen if the two syncs run in parallel
+	fastSynced := make(chan struct{})
+	mockManager.EXPECT().
+		PerformSync(gomock.Any(), slow, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ *config.SourceConfig, _ *sources.FetchResult) (*pkgsync.Result, *pkgsync.Error) {
+			select {
+			case <-fastSynced:
+				return &pkgsync.Result{Hash: "slow"}, nil
+			case <-ctx.Done():
+				return nil, &pkgsync.Error{Message: "cancelled"}
+			}
+		})
+	mockManager.EXPECT().
+		PerformSync(gomock.Any(), fast, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *config.SourceConfig, _ *sources.FetchResult) (*pkgsync.Result, *pkgsync.Error) {
+			close(fastSynced)
+			return &pkgsync.Result{Hash: "fast"}, nil
+		})
+
+	c := New(mockManager, fakeState, cfg).(*defaultCoordinator)
+	require.Equal(t, 2, c.concurrency)
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- c.Start(context.Background()) }()
+
+	require.Eventually(t, func() bool {
+		statuses, err := fakeState.ListSyncStatuses(context.Background())
+		if err != nil {
+			return false
+		}
+		return statuses[slow.Name].LastSyncHash == "slow" && statuses[fast.Name].LastSyncHash == "fast"
+	}, 5*time.Second, 10*time.Millisecond, "both sources should be synced in parallel")
+
+	require.NoError(t, c.Stop())
+	require.NoError(t, <-errCh)
+}
+
+func TestStart_WakeSyncsBeforeNextTick(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockManager := syncmocks.NewMockManager(ctrl)
+
+	src := &config.SourceConfig{Name: "catalog", Git: &config.GitConfig{Repository: "https://example.invalid/catalog.git"}}
+	fakeState := newFakeStateService(src)
+	cfg := &config.Config{Sources: []config.SourceConfig{*src}}
+
+	// The source is always due, so only the polling round bounds its syncs
+	mockManager.EXPECT().
+		ShouldSync(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(pkgsync.ReasonRegistryNotReady, (*sources.FetchResult)(nil)).
+		AnyTimes()
+	synced := make(chan struct{}, 2)
+	mockManager.EXPECT().
+		PerformSync(gomock.Any(), src, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *config.SourceConfig, _ *sources.FetchResult) (*pkgsync.Result, *pkgsync.Error) {
+			synced <- struct{}{}
+			return &pkgsync.Result{Hash: "h"}, nil
+		}).
+		Times(2)
+
+	c := New(mockManager, fakeState, cfg).(*defaultCoordinator)
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- c.Start(context.Background()) }()
+
+	// The initial check syncs the source once
+	select {
+	case <-synced:
+	case <-time.After(5 * time.Second):
+		t.Fatal("initial sync did not run")
+	}
+
+	// Waking up syncs it again long before the polling tick
+	c.Wake()
+	select {
+	case <-synced:
+	case <-time.After(5 * time.Second):
+		t.Fatal("wake did not trigger a sync")
+	}
+
+	require.NoError(t, c.Stop())
+	require.NoError(t, <-errCh)
+}
+
 // Compile-time assertion that fakeStateService satisfies the interface.
 var _ state.RegistryStateService = (*fakeStateService)(nil)
 
@@ -281,6 +575,8 @@ type fakeStateService struct {
 	mu       sync.Mutex
 	configs  map[string]*config.SourceConfig
 	statuses map[string]*status.SyncStatus
+	history  map[string][]status.SyncAttempt
+	digests  map[string]status.EntryDigests
 	picked   string
 }
 
@@ -288,6 +584,8 @@ func newFakeStateService(srcs ...*config.SourceConfig) *fakeStateService {
 	f := &fakeStateService{
 		configs:  make(map[string]*config.SourceConfig, len(srcs)),
 		statuses: make(map[string]*status.SyncStatus, len(srcs)),
+		history:  make(map[string][]status.SyncAttempt, len(srcs)),
+		digests:  make(map[string]status.EntryDigests, len(srcs)),
 	}
 	for _, s := range srcs {
 		f.configs[s.Name] = s
@@ -337,6 +635,24 @@ func (f *fakeStateService) UpdateSyncStatus(_ context.Context, name string, s *s
 	return nil
 }
 
+func (f *fakeStateService) GetEntryDigests(_ context.Context, name string) (status.EntryDigests, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.digests[name], nil
+}
+
+func (f *fakeStateService) RecordSyncAttempt(
+	_ context.Context, name string, attempt *status.SyncAttempt, digests status.EntryDigests,
+) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.history[name] = append(f.history[name], *attempt)
+	if digests != nil {
+		f.digests[name] = digests
+	}
+	return nil
+}
+
 func (f *fakeStateService) GetNextSyncJob(
 	_ context.Context,
 	predicate func(*config.SourceConfig, *status.SyncStatus) bool,
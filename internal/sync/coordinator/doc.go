@@ -4,6 +4,7 @@
 // sync operations. It sits on top of pkg/sync.Manager and handles:
 //
 //   - Background sync scheduling using time.Ticker
+//   - Parallel sync execution with a bounded pool of workers
 //   - Initial sync on startup
 //   - Status persistence and thread-safe access
 //   - Graceful shutdown
@@ -23,6 +24,7 @@
 //	type Coordinator interface {
 //	    Start(ctx context.Context) error  // Begin background sync loop
 //	    Stop() error                       // Graceful shutdown
+//	    Wake()                             // Check for due sources before the next tick
 //	    GetStatus() *status.SyncStatus     // Thread-safe status access
 //	}
 //
@@ -60,11 +62,19 @@
 //
 // # Sync Decision Flow
 //
-// 1. Ticker fires (based on configured interval)
-// 2. Coordinator calls checkSync()
-// 3. checkSync() calls Manager.ShouldSync() to decide
-// 4. If needed, performSync() executes the sync
+// 1. Ticker fires (based on configured interval) and wakes up every worker
+// 2. Each worker claims its own due source row through GetNextSyncJob()
+// 3. The claim predicate calls Manager.ShouldSync() to decide
+// 4. If needed, performRegistrySync() executes the sync
 // 5. Status is updated and persisted at each phase transition
+// 6. The worker claims the next due source until none is left for this round
+//
+// # Worker Pool
+//
+// The number of workers is set by sync.concurrency in the configuration. Each
+// worker claims and syncs one source at a time, so a slow Git clone only keeps
+// its own worker busy. A source is synced at most once per polling round, even
+// if it is due again by the time a worker looks for more work.
 //
 // # Error Handling
 //
@@ -2,6 +2,8 @@ package sync
 
 import (
 	"context"
+	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/stacklok/toolhive-registry-server/internal/config"
@@ -14,8 +16,25 @@ type defaultDataChangeDetector struct {
 	registryHandlerFactory sources.RegistryHandlerFactory
 }
 
+// incrementalSyncOverlap is subtracted from the last successful sync time when requesting
+// an incremental delta, so updates that landed while the previous sync was running are not
+// missed. Re-applying an unchanged entry is harmless.
+const incrementalSyncOverlap = time.Minute
+
 // IsDataChanged checks if source data has changed by comparing hashes for a specific registry.
 // Returns the fetched data so PerformSync can reuse it without a second fetch.
+//
+// For Git sources that were synced before, the commit the configured ref points to is
+// resolved first and the data is only fetched when it differs from the synced commit.
+// The returned FetchResult is nil when the fetch was skipped.
+//
+// For bundle sources that were synced before, the changes made after the last imported
+// bundle are fetched, and they count as a change when a more recent bundle was applied.
+//
+// For API sources with incremental sync enabled, only the servers updated since the last
+// successful sync are fetched and any non-empty delta counts as a change. Once the full sync
+// interval has elapsed a full fetch is performed and always reported as changed, so the
+// periodic reconciliation is stored even when the upstream data is identical.
 func (d *defaultDataChangeDetector) IsDataChanged(
 	ctx context.Context, regCfg *config.SourceConfig, syncStatus *status.SyncStatus,
 ) (bool, *sources.FetchResult, error) {
@@ -31,6 +50,43 @@ func (d *defaultDataChangeDetector) IsDataChanged(
 		return true, nil, err
 	}
 
+	if regCfg.API.IsIncrementalEnabled() {
+		if incrementalHandler, ok := registryHandler.(sources.IncrementalRegistryHandler); ok {
+			if since, ok := incrementalSyncSince(regCfg, syncStatus); ok {
+				fetchResult, err := incrementalHandler.FetchRegistrySince(ctx, regCfg, since)
+				if err != nil {
+					return true, nil, err
+				}
+				changed := fetchResult.ServerCount > 0 || len(fetchResult.DeletedServers) > 0
+				return changed, fetchResult, nil
+			}
+		}
+	}
+
+	// Bundle sources apply the deltas after the last imported bundle to the stored entries
+	if deltaHandler, ok := registryHandler.(sources.DeltaRegistryHandler); ok && lastSyncHash != "" {
+		fetchResult, err := deltaHandler.FetchRegistryAfter(ctx, regCfg, lastSyncHash)
+		if err != nil {
+			return true, nil, err
+		}
+		return fetchResult.Hash != lastSyncHash, fetchResult, nil
+	}
+
+	// Git sources resolve the remote commit first and are only cloned when it moved
+	if commitHandler, ok := registryHandler.(sources.CommitRegistryHandler); ok &&
+		lastSyncHash != "" && syncStatus.LastSyncCommit != "" {
+		commitSHA, err := commitHandler.ResolveCommit(ctx, regCfg)
+		if err != nil {
+			return true, nil, err
+		}
+		if isSameCommit(syncStatus.LastSyncCommit, commitSHA) {
+			slog.Debug("Source commit unchanged, skipping fetch",
+				"registry", regCfg.Name,
+				"commit_sha", commitSHA)
+			return false, nil, nil
+		}
+	}
+
 	// Fetch current data from source — the result is returned so PerformSync can reuse it
 	fetchResult, err := registryHandler.FetchRegistry(ctx, regCfg)
 	if err != nil {
@@ -42,10 +98,37 @@ func (d *defaultDataChangeDetector) IsDataChanged(
 		return true, fetchResult, nil
 	}
 
+	// A due full reconciliation is always stored so that LastFullSyncTime advances
+	if regCfg.API.IsIncrementalEnabled() {
+		return true, fetchResult, nil
+	}
+
 	// Compare hashes - data changed if different
 	return fetchResult.Hash != lastSyncHash, fetchResult, nil
 }
 
+// isSameCommit reports whether the synced commit SHA matches the resolved one.
+// Commits pinned in the configuration may be abbreviated, so a prefix match is enough.
+func isSameCommit(syncedSHA, resolvedSHA string) bool {
+	return resolvedSHA != "" && strings.HasPrefix(syncedSHA, resolvedSHA)
+}
+
+// incrementalSyncSince returns the updated_since watermark to use for an incremental fetch.
+// It returns false when a full sync is required instead: when the source has never
+// completed a sync, has never completed a full sync, or its full sync interval has elapsed.
+func incrementalSyncSince(regCfg *config.SourceConfig, syncStatus *status.SyncStatus) (time.Time, bool) {
+	if syncStatus == nil || syncStatus.LastSyncHash == "" ||
+		syncStatus.LastSuccessfulSyncTime == nil || syncStatus.LastFullSyncTime == nil {
+		return time.Time{}, false
+	}
+
+	if time.Since(*syncStatus.LastFullSyncTime) >= regCfg.API.Incremental.GetFullSyncInterval() {
+		return time.Time{}, false
+	}
+
+	return syncStatus.LastSuccessfulSyncTime.Add(-incrementalSyncOverlap), true
+}
+
 // defaultAutomaticSyncChecker implements AutomaticSyncChecker
 type defaultAutomaticSyncChecker struct{}
 
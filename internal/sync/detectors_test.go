@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -12,10 +13,12 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 
 	"github.com/stacklok/toolhive-registry-server/internal/config"
 	"github.com/stacklok/toolhive-registry-server/internal/registry"
 	"github.com/stacklok/toolhive-registry-server/internal/sources"
+	"github.com/stacklok/toolhive-registry-server/internal/sources/mocks"
 	"github.com/stacklok/toolhive-registry-server/internal/status"
 )
 
@@ -283,3 +286,223 @@ func TestDefaultAutomaticSyncChecker_IsIntervalSyncNeeded(t *testing.T) {
 		})
 	}
 }
+
+func TestIncrementalSyncSince(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	lastSuccess := now.Add(-10 * time.Minute)
+	recentFull := now.Add(-time.Hour)
+	staleFull := now.Add(-48 * time.Hour)
+
+	regCfg := &config.SourceConfig{
+		Name: "test-registry",
+		API: &config.APIConfig{
+			Endpoint:    "https://registry.example.com",
+			Incremental: &config.APIIncrementalConfig{Enabled: true},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		status     *status.SyncStatus
+		expectedOK bool
+	}{
+		{
+			name:       "full sync when status is missing",
+			status:     nil,
+			expectedOK: false,
+		},
+		{
+			name: "full sync when never fully synced",
+			status: &status.SyncStatus{
+				LastSyncHash:           "hash",
+				LastSuccessfulSyncTime: &lastSuccess,
+			},
+			expectedOK: false,
+		},
+		{
+			name: "full sync when full sync interval elapsed",
+			status: &status.SyncStatus{
+				LastSyncHash:           "hash",
+				LastSuccessfulSyncTime: &lastSuccess,
+				LastFullSyncTime:       &staleFull,
+			},
+			expectedOK: false,
+		},
+		{
+			name: "incremental sync within full sync interval",
+			status: &status.SyncStatus{
+				LastSyncHash:           "hash",
+				LastSuccessfulSyncTime: &lastSuccess,
+				LastFullSyncTime:       &recentFull,
+			},
+			expectedOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			since, ok := incrementalSyncSince(regCfg, tt.status)
+
+			assert.Equal(t, tt.expectedOK, ok)
+			if tt.expectedOK {
+				assert.Equal(t, lastSuccess.Add(-incrementalSyncOverlap), since)
+			}
+		})
+	}
+}
+
+func TestDefaultDataChangeDetector_IsDataChanged_GitCommit(t *testing.T) {
+	t.Parallel()
+
+	const syncedCommit = "0123456789abcdef0123456789abcdef01234567"
+	regCfg := &config.SourceConfig{
+		Name: "test-registry",
+		Git: &config.GitConfig{
+			Repository: "https://github.com/example/repo.git",
+		},
+	}
+
+	tests := []struct {
+		name            string
+		status          *status.SyncStatus
+		setupMocks      func(handler *mocks.MockCommitRegistryHandler)
+		expectedChanged bool
+		expectFetch     bool
+		expectError     bool
+	}{
+		{
+			name:   "fetch skipped when the commit has not moved",
+			status: &status.SyncStatus{LastSyncHash: "hash", LastSyncCommit: syncedCommit},
+			setupMocks: func(handler *mocks.MockCommitRegistryHandler) {
+				handler.EXPECT().ResolveCommit(gomock.Any(), regCfg).Return(syncedCommit, nil)
+			},
+			expectedChanged: false,
+		},
+		{
+			name:   "fetch skipped when the pinned commit is abbreviated",
+			status: &status.SyncStatus{LastSyncHash: "hash", LastSyncCommit: syncedCommit},
+			setupMocks: func(handler *mocks.MockCommitRegistryHandler) {
+				handler.EXPECT().ResolveCommit(gomock.Any(), regCfg).Return(syncedCommit[:7], nil)
+			},
+			expectedChanged: false,
+		},
+		{
+			name:   "data fetched when the commit moved",
+			status: &status.SyncStatus{LastSyncHash: "hash", LastSyncCommit: syncedCommit},
+			setupMocks: func(handler *mocks.MockCommitRegistryHandler) {
+				handler.EXPECT().ResolveCommit(gomock.Any(), regCfg).Return("fedcba", nil)
+				handler.EXPECT().FetchRegistry(gomock.Any(), regCfg).
+					Return(&sources.FetchResult{Hash: "new-hash", CommitSHA: "fedcba"}, nil)
+			},
+			expectedChanged: true,
+			expectFetch:     true,
+		},
+		{
+			name:   "data fetched when no commit was synced",
+			status: &status.SyncStatus{LastSyncHash: "hash"},
+			setupMocks: func(handler *mocks.MockCommitRegistryHandler) {
+				handler.EXPECT().FetchRegistry(gomock.Any(), regCfg).
+					Return(&sources.FetchResult{Hash: "hash", CommitSHA: syncedCommit}, nil)
+			},
+			expectedChanged: false,
+			expectFetch:     true,
+		},
+		{
+			name:   "error when the commit cannot be resolved",
+			status: &status.SyncStatus{LastSyncHash: "hash", LastSyncCommit: syncedCommit},
+			setupMocks: func(handler *mocks.MockCommitRegistryHandler) {
+				handler.EXPECT().ResolveCommit(gomock.Any(), regCfg).Return("", errors.New("unreachable"))
+			},
+			expectedChanged: true,
+			expectError:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			handler := mocks.NewMockCommitRegistryHandler(ctrl)
+			factory := mocks.NewMockRegistryHandlerFactory(ctrl)
+			factory.EXPECT().CreateHandler(regCfg).Return(handler, nil)
+			tt.setupMocks(handler)
+
+			detector := &defaultDataChangeDetector{registryHandlerFactory: factory}
+			changed, fetchResult, err := detector.IsDataChanged(t.Context(), regCfg, tt.status)
+
+			assert.Equal(t, tt.expectedChanged, changed)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectFetch, fetchResult != nil)
+		})
+	}
+}
+
+func TestDefaultDataChangeDetector_IsDataChanged_BundleDelta(t *testing.T) {
+	t.Parallel()
+
+	regCfg := &config.SourceConfig{
+		Name:   "test-registry",
+		Bundle: &config.BundleConfig{URL: "https://replica.example.com/bundle.json"},
+	}
+
+	tests := []struct {
+		name            string
+		status          *status.SyncStatus
+		setupMocks      func(handler *mocks.MockDeltaRegistryHandler)
+		expectedChanged bool
+	}{
+		{
+			name:   "deltas after the last imported bundle",
+			status: &status.SyncStatus{LastSyncHash: "10"},
+			setupMocks: func(handler *mocks.MockDeltaRegistryHandler) {
+				handler.EXPECT().FetchRegistryAfter(gomock.Any(), regCfg, "10").
+					Return(&sources.FetchResult{Hash: "20", Incremental: true}, nil)
+			},
+			expectedChanged: true,
+		},
+		{
+			name:   "no bundle after the last imported bundle",
+			status: &status.SyncStatus{LastSyncHash: "10"},
+			setupMocks: func(handler *mocks.MockDeltaRegistryHandler) {
+				handler.EXPECT().FetchRegistryAfter(gomock.Any(), regCfg, "10").
+					Return(&sources.FetchResult{Hash: "10", Incremental: true}, nil)
+			},
+			expectedChanged: false,
+		},
+		{
+			name:   "full fetch when no bundle was imported",
+			status: &status.SyncStatus{},
+			setupMocks: func(handler *mocks.MockDeltaRegistryHandler) {
+				handler.EXPECT().FetchRegistry(gomock.Any(), regCfg).Return(&sources.FetchResult{Hash: "10"}, nil)
+			},
+			expectedChanged: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			handler := mocks.NewMockDeltaRegistryHandler(ctrl)
+			factory := mocks.NewMockRegistryHandlerFactory(ctrl)
+			factory.EXPECT().CreateHandler(regCfg).Return(handler, nil)
+			tt.setupMocks(handler)
+
+			detector := &defaultDataChangeDetector{registryHandlerFactory: factory}
+			changed, fetchResult, err := detector.IsDataChanged(t.Context(), regCfg, tt.status)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedChanged, changed)
+			assert.NotNil(t, fetchResult)
+		})
+	}
+}
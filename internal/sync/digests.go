@@ -0,0 +1,201 @@
+package sync
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	toolhivetypes "github.com/stacklok/toolhive-core/registry/types"
+
+	"github.com/stacklok/toolhive-registry-server/internal/sources"
+	"github.com/stacklok/toolhive-registry-server/internal/status"
+)
+
+// Entry type prefixes of the EntryDigests keys
+const (
+	digestPrefixServer = "server/"
+	digestPrefixSkill  = "skill/"
+	digestPrefixPlugin = "plugin/"
+)
+
+// serverDigestKey returns the EntryDigests key of a server version
+func serverDigestKey(name, version string) string {
+	return digestPrefixServer + name + "@" + version
+}
+
+// computeEntryDigests returns the digest of every server, skill and plugin version in reg
+func computeEntryDigests(reg *toolhivetypes.UpstreamRegistry) (status.EntryDigests, error) {
+	digests := status.EntryDigests{}
+	if reg == nil {
+		return digests, nil
+	}
+
+	for i := range reg.Data.Servers {
+		server := &reg.Data.Servers[i]
+		if err := addEntryDigest(digests, serverDigestKey(server.Name, server.Version), server); err != nil {
+			return nil, err
+		}
+	}
+	for i := range reg.Data.Skills {
+		skill := &reg.Data.Skills[i]
+		key := digestPrefixSkill + skill.Namespace + "/" + skill.Name + "@" + skill.Version
+		if err := addEntryDigest(digests, key, skill); err != nil {
+			return nil, err
+		}
+	}
+	for i := range reg.Data.Plugins {
+		plugin := &reg.Data.Plugins[i]
+		key := digestPrefixPlugin + plugin.Namespace + "/" + plugin.Name + "@" + plugin.Version
+		if err := addEntryDigest(digests, key, plugin); err != nil {
+			return nil, err
+		}
+	}
+
+	return digests, nil
+}
+
+// addEntryDigest stores the SHA256 digest of the JSON encoding of entry under key
+func addEntryDigest(digests status.EntryDigests, key string, entry any) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal entry %s: %w", key, err)
+	}
+	sum := sha256.Sum256(data)
+	digests[key] = hex.EncodeToString(sum[:])
+	return nil
+}
+
+// deletedEntryDigestKeys returns the EntryDigests keys of the entry versions an
+// incremental fetch result deletes
+func deletedEntryDigestKeys(result *sources.FetchResult) []string {
+	var keys []string
+	for i := range result.DeletedServers {
+		keys = append(keys, serverDigestKey(result.DeletedServers[i].Name, result.DeletedServers[i].Version))
+	}
+	for i := range result.DeletedSkills {
+		skill := &result.DeletedSkills[i]
+		keys = append(keys, digestPrefixSkill+skill.Namespace+"/"+skill.Name+"@"+skill.Version)
+	}
+	for i := range result.DeletedPlugins {
+		plugin := &result.DeletedPlugins[i]
+		keys = append(keys, digestPrefixPlugin+plugin.Namespace+"/"+plugin.Name+"@"+plugin.Version)
+	}
+	return keys
+}
+
+// ApplyEntryDigests computes the entry digests stored after the sync described by
+// result and counts the entry versions it added, updated and removed compared to
+// the digests of the previous sync.
+//
+// A full sync replaces the previous digests, so entries missing from result are
+// counted as removed. An incremental sync only adds or updates the entries in
+// result and removes the deleted ones, keeping all other previous digests.
+func ApplyEntryDigests(previous status.EntryDigests, result *Result) (status.EntryDigests, status.SyncChanges) {
+	var changes status.SyncChanges
+
+	current := make(status.EntryDigests, len(previous)+len(result.EntryDigests))
+	if result.Incremental {
+		maps.Copy(current, previous)
+	}
+
+	for key, digest := range result.EntryDigests {
+		previousDigest, existed := previous[key]
+		switch {
+		case !existed:
+			addEntryChange(&changes, key, status.EntryCreated)
+		case previousDigest != digest:
+			addEntryChange(&changes, key, status.EntryUpdated)
+		}
+		current[key] = digest
+	}
+
+	if result.Incremental {
+		for _, key := range result.DeletedEntries {
+			if _, ok := current[key]; ok {
+				addEntryChange(&changes, key, status.EntryDeleted)
+				delete(current, key)
+			}
+		}
+	} else {
+		for key := range previous {
+			if _, ok := current[key]; !ok {
+				addEntryChange(&changes, key, status.EntryDeleted)
+			}
+		}
+	}
+
+	slices.SortFunc(changes.Entries, compareEntryChanges)
+	return current, changes
+}
+
+// addEntryChange counts the change of the entry version of an EntryDigests key
+// and adds it to the changed entries
+func addEntryChange(changes *status.SyncChanges, key string, changeType status.EntryChangeType) {
+	counts := entryChangesForKey(changes, key)
+	switch changeType {
+	case status.EntryCreated:
+		counts.Added++
+	case status.EntryUpdated:
+		counts.Updated++
+	case status.EntryDeleted:
+		counts.Removed++
+	}
+	changes.Entries = append(changes.Entries, parseEntryDigestKey(key, changeType))
+}
+
+// parseEntryDigestKey splits an EntryDigests key into the entry type, namespace,
+// name and version of the entry version. Versions never contain "@", while
+// server names may contain "/".
+func parseEntryDigestKey(key string, changeType status.EntryChangeType) status.EntryChange {
+	change := status.EntryChange{Type: changeType}
+
+	var prefix string
+	switch {
+	case strings.HasPrefix(key, digestPrefixSkill):
+		prefix = digestPrefixSkill
+	case strings.HasPrefix(key, digestPrefixPlugin):
+		prefix = digestPrefixPlugin
+	default:
+		prefix = digestPrefixServer
+	}
+	change.EntryType = strings.TrimSuffix(prefix, "/")
+
+	rest := strings.TrimPrefix(key, prefix)
+	if i := strings.LastIndex(rest, "@"); i >= 0 {
+		rest, change.Version = rest[:i], rest[i+1:]
+	}
+	if prefix != digestPrefixServer {
+		if i := strings.LastIndex(rest, "/"); i >= 0 {
+			change.Namespace, rest = rest[:i], rest[i+1:]
+		}
+	}
+	change.Name = rest
+	return change
+}
+
+// compareEntryChanges orders entry changes by entry type, namespace, name and version
+func compareEntryChanges(a, b status.EntryChange) int {
+	return cmp.Or(
+		cmp.Compare(a.EntryType, b.EntryType),
+		cmp.Compare(a.Namespace, b.Namespace),
+		cmp.Compare(a.Name, b.Name),
+		cmp.Compare(a.Version, b.Version),
+	)
+}
+
+// entryChangesForKey returns the counters of the entry type of an EntryDigests key
+func entryChangesForKey(changes *status.SyncChanges, key string) *status.EntryChanges {
+	switch {
+	case strings.HasPrefix(key, digestPrefixSkill):
+		return &changes.Skills
+	case strings.HasPrefix(key, digestPrefixPlugin):
+		return &changes.Plugins
+	default:
+		return &changes.Servers
+	}
+}
@@ -0,0 +1,156 @@
+package sync
+
+import (
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	toolhivetypes "github.com/stacklok/toolhive-core/registry/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/status"
+)
+
+func TestComputeEntryDigests(t *testing.T) {
+	t.Parallel()
+
+	reg := &toolhivetypes.UpstreamRegistry{
+		Data: toolhivetypes.UpstreamData{
+			Servers: []upstreamv0.ServerJSON{
+				{Name: "io.test/server", Version: "1.0.0", Description: "first"},
+				{Name: "io.test/server", Version: "2.0.0", Description: "second"},
+			},
+			Skills: []toolhivetypes.Skill{
+				{Namespace: "io.test", Name: "skill", Version: "1.0.0"},
+			},
+			Plugins: []toolhivetypes.Plugin{
+				{Namespace: "io.test", Name: "plugin", Version: "1.0.0"},
+			},
+		},
+	}
+
+	digests, err := computeEntryDigests(reg)
+	require.NoError(t, err)
+	require.Len(t, digests, 4)
+	assert.Contains(t, digests, "server/io.test/server@1.0.0")
+	assert.Contains(t, digests, "server/io.test/server@2.0.0")
+	assert.Contains(t, digests, "skill/io.test/skill@1.0.0")
+	assert.Contains(t, digests, "plugin/io.test/plugin@1.0.0")
+	assert.NotEqual(t, digests["server/io.test/server@1.0.0"], digests["server/io.test/server@2.0.0"])
+
+	// Digests are stable for the same content
+	again, err := computeEntryDigests(reg)
+	require.NoError(t, err)
+	assert.Equal(t, digests, again)
+
+	empty, err := computeEntryDigests(nil)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestApplyEntryDigests(t *testing.T) {
+	t.Parallel()
+
+	previous := status.EntryDigests{
+		"server/io.test/kept@1.0.0":    "a",
+		"server/io.test/changed@1.0.0": "b",
+		"server/io.test/removed@1.0.0": "c",
+		"skill/io.test/skill@1.0.0":    "d",
+	}
+
+	tests := []struct {
+		name            string
+		previous        status.EntryDigests
+		result          *Result
+		expectedDigests status.EntryDigests
+		expectedChanges status.SyncChanges
+	}{
+		{
+			name:     "full sync replaces the previous digests",
+			previous: previous,
+			result: &Result{
+				EntryDigests: status.EntryDigests{
+					"server/io.test/kept@1.0.0":    "a",
+					"server/io.test/changed@1.0.0": "b2",
+					"server/io.test/new@1.0.0":     "e",
+					"plugin/io.test/plugin@1.0.0":  "f",
+				},
+			},
+			expectedDigests: status.EntryDigests{
+				"server/io.test/kept@1.0.0":    "a",
+				"server/io.test/changed@1.0.0": "b2",
+				"server/io.test/new@1.0.0":     "e",
+				"plugin/io.test/plugin@1.0.0":  "f",
+			},
+			expectedChanges: status.SyncChanges{
+				Servers: status.EntryChanges{Added: 1, Updated: 1, Removed: 1},
+				Skills:  status.EntryChanges{Removed: 1},
+				Plugins: status.EntryChanges{Added: 1},
+				Entries: []status.EntryChange{
+					{Type: status.EntryCreated, EntryType: "plugin", Namespace: "io.test", Name: "plugin", Version: "1.0.0"},
+					{Type: status.EntryUpdated, EntryType: "server", Name: "io.test/changed", Version: "1.0.0"},
+					{Type: status.EntryCreated, EntryType: "server", Name: "io.test/new", Version: "1.0.0"},
+					{Type: status.EntryDeleted, EntryType: "server", Name: "io.test/removed", Version: "1.0.0"},
+					{Type: status.EntryDeleted, EntryType: "skill", Namespace: "io.test", Name: "skill", Version: "1.0.0"},
+				},
+			},
+		},
+		{
+			name:     "incremental sync keeps untouched digests",
+			previous: previous,
+			result: &Result{
+				Incremental: true,
+				EntryDigests: status.EntryDigests{
+					"server/io.test/changed@1.0.0": "b2",
+					"server/io.test/new@1.0.0":     "e",
+				},
+				DeletedEntries: []string{"server/io.test/removed@1.0.0", "server/io.test/unknown@1.0.0"},
+			},
+			expectedDigests: status.EntryDigests{
+				"server/io.test/kept@1.0.0":    "a",
+				"server/io.test/changed@1.0.0": "b2",
+				"server/io.test/new@1.0.0":     "e",
+				"skill/io.test/skill@1.0.0":    "d",
+			},
+			expectedChanges: status.SyncChanges{
+				Servers: status.EntryChanges{Added: 1, Updated: 1, Removed: 1},
+				Entries: []status.EntryChange{
+					{Type: status.EntryUpdated, EntryType: "server", Name: "io.test/changed", Version: "1.0.0"},
+					{Type: status.EntryCreated, EntryType: "server", Name: "io.test/new", Version: "1.0.0"},
+					{Type: status.EntryDeleted, EntryType: "server", Name: "io.test/removed", Version: "1.0.0"},
+				},
+			},
+		},
+		{
+			name:     "first sync adds everything",
+			previous: nil,
+			result: &Result{
+				EntryDigests: status.EntryDigests{
+					"server/io.test/new@1.0.0":  "e",
+					"skill/io.test/skill@1.0.0": "d",
+				},
+			},
+			expectedDigests: status.EntryDigests{
+				"server/io.test/new@1.0.0":  "e",
+				"skill/io.test/skill@1.0.0": "d",
+			},
+			expectedChanges: status.SyncChanges{
+				Servers: status.EntryChanges{Added: 1},
+				Skills:  status.EntryChanges{Added: 1},
+				Entries: []status.EntryChange{
+					{Type: status.EntryCreated, EntryType: "server", Name: "io.test/new", Version: "1.0.0"},
+					{Type: status.EntryCreated, EntryType: "skill", Namespace: "io.test", Name: "skill", Version: "1.0.0"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			digests, changes := ApplyEntryDigests(tt.previous, tt.result)
+			assert.Equal(t, tt.expectedDigests, digests)
+			assert.Equal(t, tt.expectedChanges, changes)
+		})
+	}
+}
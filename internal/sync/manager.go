@@ -7,10 +7,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"slices"
 	"time"
 
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	toolhivetypes "github.com/stacklok/toolhive-core/registry/types"
+
 	"github.com/stacklok/toolhive-registry-server/internal/config"
 	"github.com/stacklok/toolhive-registry-server/internal/filtering"
+	"github.com/stacklok/toolhive-registry-server/internal/pinning"
+	"github.com/stacklok/toolhive-registry-server/internal/scanning"
 	"github.com/stacklok/toolhive-registry-server/internal/sources"
 	"github.com/stacklok/toolhive-registry-server/internal/status"
 	"github.com/stacklok/toolhive-registry-server/internal/sync/writer"
@@ -22,6 +28,20 @@ type Result struct {
 	ServerCount int
 	SkillCount  int
 	PluginCount int
+
+	// Incremental is true when only the entries changed since the last sync were applied
+	Incremental bool
+
+	// EntryDigests holds the digest of every entry version applied by the sync.
+	// For incremental syncs it only covers the updated entries. It is nil if the
+	// digests could not be computed.
+	EntryDigests status.EntryDigests
+
+	// DeletedEntries lists the EntryDigests keys removed by an incremental sync
+	DeletedEntries []string
+
+	// CommitSHA is the SHA of the commit the data was read from, for Git sources
+	CommitSHA string
 }
 
 // Reason represents the decision and reason for whether a sync should occur
@@ -178,20 +198,46 @@ type defaultSyncManager struct {
 	filterService          filtering.FilterService
 	dataChangeDetector     DataChangeDetector
 	automaticSyncChecker   AutomaticSyncChecker
+	entryPolicy            *scanning.Pipeline
+	digestPinning          *pinning.Pinner
+}
+
+// ManagerOption is a functional option for configuring the default sync manager
+type ManagerOption func(*defaultSyncManager)
+
+// WithEntryPolicy sets the entry policy scanning the entries of the sources
+// after filtering. If not set, no entry is scanned.
+func WithEntryPolicy(pipeline *scanning.Pipeline) ManagerOption {
+	return func(s *defaultSyncManager) {
+		s.entryPolicy = pipeline
+	}
+}
+
+// WithDigestPinning sets the pinner pinning the OCI packages of the entries of
+// the sources to digests after filtering. If not set, no package is pinned.
+func WithDigestPinning(pinner *pinning.Pinner) ManagerOption {
+	return func(s *defaultSyncManager) {
+		s.digestPinning = pinner
+	}
 }
 
 // NewDefaultSyncManager creates a new defaultSyncManager
 func NewDefaultSyncManager(
 	registryHandlerFactory sources.RegistryHandlerFactory,
 	syncWriter writer.SyncWriter,
+	opts ...ManagerOption,
 ) Manager {
-	return &defaultSyncManager{
+	manager := &defaultSyncManager{
 		registryHandlerFactory: registryHandlerFactory,
 		writer:                 syncWriter,
 		filterService:          filtering.NewDefaultFilterService(),
 		dataChangeDetector:     &defaultDataChangeDetector{registryHandlerFactory: registryHandlerFactory},
 		automaticSyncChecker:   &defaultAutomaticSyncChecker{},
 	}
+	for _, opt := range opts {
+		opt(manager)
+	}
+	return manager
 }
 
 // ShouldSync determines if a sync operation is needed for a specific registry.
@@ -251,6 +297,10 @@ func (s *defaultSyncManager) ShouldSync(
 				} else {
 					reason = ReasonSourceDataChanged
 				}
+			} else if syncNeededForState {
+				// The stored data is not known to be complete, so sync it again
+				prefetched = fetchResult
+				reason = ReasonRegistryNotReady
 			} else {
 				if manualSyncRequested {
 					reason = ReasonManualNoChanges
@@ -350,6 +400,30 @@ func (s *defaultSyncManager) PerformSync(
 		return nil, err
 	}
 
+	// Digests of the synced entries are compared with the previous sync to
+	// report what changed. They are informational, so failures are not fatal.
+	entryDigests, digestErr := computeEntryDigests(fetchResult.Registry)
+	if digestErr != nil {
+		slog.Warn("Failed to compute entry digests", "registry", regCfg.Name, "error", digestErr)
+	}
+
+	// Incremental results are merged into the stored data rather than replacing it
+	if fetchResult.Incremental {
+		merged, err := s.mergeRegistryData(ctx, regCfg, fetchResult)
+		if err != nil {
+			return nil, err
+		}
+		return &Result{
+			Hash:           fetchResult.Hash,
+			ServerCount:    merged.ServerCount,
+			SkillCount:     merged.SkillCount,
+			PluginCount:    merged.PluginCount,
+			Incremental:    true,
+			EntryDigests:   entryDigests,
+			DeletedEntries: deletedEntryDigestKeys(fetchResult),
+		}, nil
+	}
+
 	// Store the processed registry data
 	if err := s.storeRegistryData(ctx, regCfg, fetchResult); err != nil {
 		return nil, err
@@ -357,10 +431,12 @@ func (s *defaultSyncManager) PerformSync(
 
 	// Return sync result with data for status collector
 	syncResult := &Result{
-		Hash:        fetchResult.Hash,
-		ServerCount: fetchResult.ServerCount,
-		SkillCount:  fetchResult.SkillCount,
-		PluginCount: fetchResult.PluginCount,
+		Hash:         fetchResult.Hash,
+		ServerCount:  fetchResult.ServerCount,
+		SkillCount:   fetchResult.SkillCount,
+		PluginCount:  fetchResult.PluginCount,
+		EntryDigests: entryDigests,
+		CommitSHA:    fetchResult.CommitSHA,
 	}
 
 	return syncResult, nil
@@ -422,14 +498,70 @@ func (s *defaultSyncManager) fetchAndProcessRegistryData(
 		fetchResult = result
 	}
 
+	// Keep the fetched entry versions of an incremental result, as filtering,
+	// digest pinning and the entry policy remove entries in place
+	var fetched *toolhivetypes.UpstreamData
+	if fetchResult.Incremental && fetchResult.Registry != nil {
+		fetched = &toolhivetypes.UpstreamData{
+			Servers: slices.Clone(fetchResult.Registry.Data.Servers),
+			Skills:  slices.Clone(fetchResult.Registry.Data.Skills),
+			Plugins: slices.Clone(fetchResult.Registry.Data.Plugins),
+		}
+	}
+
 	// Apply filtering if configured
 	if err := s.applyFilteringIfConfigured(ctx, regCfg, fetchResult); err != nil {
 		return nil, err
 	}
 
+	// Pin the OCI packages of the remaining entries if digest pinning applies to the source
+	s.applyDigestPinningIfConfigured(ctx, regCfg, fetchResult)
+
+	// Scan the remaining entries if the entry policy applies to the source
+	s.applyEntryPolicyIfConfigured(ctx, regCfg, fetchResult)
+
+	if fetched != nil {
+		deleteDroppedEntries(fetchResult, fetched)
+	}
+
 	return fetchResult, nil
 }
 
+// deleteDroppedEntries adds the entry versions of an incremental fetch result
+// removed by filtering, digest pinning or the entry policy to its deleted
+// entries. A full sync would not store them, so the versions stored by an
+// earlier sync are removed, such as an entry updated to no longer match the
+// filter.
+func deleteDroppedEntries(fetchResult *sources.FetchResult, fetched *toolhivetypes.UpstreamData) {
+	data := &fetchResult.Registry.Data
+	kept := make(map[string]struct{}, len(data.Servers)+len(data.Skills)+len(data.Plugins))
+	for i := range data.Servers {
+		kept[serverDigestKey(data.Servers[i].Name, data.Servers[i].Version)] = struct{}{}
+	}
+	for i := range data.Skills {
+		kept[digestPrefixSkill+data.Skills[i].Namespace+"/"+data.Skills[i].Name+"@"+data.Skills[i].Version] = struct{}{}
+	}
+	for i := range data.Plugins {
+		kept[digestPrefixPlugin+data.Plugins[i].Namespace+"/"+data.Plugins[i].Name+"@"+data.Plugins[i].Version] = struct{}{}
+	}
+
+	for _, server := range fetched.Servers {
+		if _, ok := kept[serverDigestKey(server.Name, server.Version)]; !ok {
+			fetchResult.DeletedServers = append(fetchResult.DeletedServers, server)
+		}
+	}
+	for _, skill := range fetched.Skills {
+		if _, ok := kept[digestPrefixSkill+skill.Namespace+"/"+skill.Name+"@"+skill.Version]; !ok {
+			fetchResult.DeletedSkills = append(fetchResult.DeletedSkills, skill)
+		}
+	}
+	for _, plugin := range fetched.Plugins {
+		if _, ok := kept[digestPrefixPlugin+plugin.Namespace+"/"+plugin.Name+"@"+plugin.Version]; !ok {
+			fetchResult.DeletedPlugins = append(fetchResult.DeletedPlugins, plugin)
+		}
+	}
+}
+
 // applyFilteringIfConfigured applies filtering to fetch result if registry has filter configuration
 func (s *defaultSyncManager) applyFilteringIfConfigured(
 	ctx context.Context,
@@ -438,7 +570,8 @@ func (s *defaultSyncManager) applyFilteringIfConfigured(
 	if regCfg.Filter != nil {
 		slog.Info("Applying registry filters",
 			"hasNameFilters", regCfg.Filter.Names != nil,
-			"hasTagFilters", regCfg.Filter.Tags != nil)
+			"hasTagFilters", regCfg.Filter.Tags != nil,
+			"hasExpressionFilters", regCfg.Filter.Expressions != nil)
 
 		// Apply filtering to UpstreamRegistry
 		filteredServerReg, err := s.filterService.ApplyFilters(ctx, fetchResult.Registry, regCfg.Filter)
@@ -471,12 +604,148 @@ func (s *defaultSyncManager) applyFilteringIfConfigured(
 	return nil
 }
 
+// applyDigestPinningIfConfigured pins the OCI packages of the entries of the
+// fetch result to digests, if digest pinning applies to the source. Entry
+// versions left unpinned while the source requires digest pinning are removed
+// and counted; the resolved digests are kept to be stored with the others.
+func (s *defaultSyncManager) applyDigestPinningIfConfigured(
+	ctx context.Context,
+	regCfg *config.SourceConfig,
+	fetchResult *sources.FetchResult) {
+	if !s.digestPinning.AppliesTo(regCfg.Name) || fetchResult.Registry == nil {
+		return
+	}
+
+	data := &fetchResult.Registry.Data
+	var digests []pinning.PackageDigest
+	unpinned := 0
+
+	// keep records the outcome of the pinning of an entry version, returning
+	// whether it is kept
+	keep := func(entryType, name, version string, resolved []pinning.PackageDigest, err error) bool {
+		if err != nil {
+			slog.Warn("Entry removed by digest pinning",
+				"registry", regCfg.Name,
+				"type", entryType,
+				"name", name,
+				"version", version,
+				"error", err)
+			unpinned++
+			return false
+		}
+		digests = append(digests, resolved...)
+		return true
+	}
+
+	data.Servers = slices.DeleteFunc(data.Servers, func(server upstreamv0.ServerJSON) bool {
+		resolved, err := s.digestPinning.PinServer(ctx, regCfg.Name, scanning.EntryTypeServer, &server)
+		return !keep(scanning.EntryTypeServer, server.Name, server.Version, resolved, err)
+	})
+	data.Skills = slices.DeleteFunc(data.Skills, func(skill toolhivetypes.Skill) bool {
+		resolved, err := s.digestPinning.PinSkillPackages(
+			ctx, regCfg.Name, scanning.EntryTypeSkill, skill.Name, skill.Version, skill.Packages)
+		return !keep(scanning.EntryTypeSkill, skill.Name, skill.Version, resolved, err)
+	})
+	data.Plugins = slices.DeleteFunc(data.Plugins, func(plugin toolhivetypes.Plugin) bool {
+		resolved, err := s.digestPinning.PinSkillPackages(
+			ctx, regCfg.Name, scanning.EntryTypePlugin, plugin.Name, plugin.Version, plugin.Packages)
+		return !keep(scanning.EntryTypePlugin, plugin.Name, plugin.Version, resolved, err)
+	})
+
+	fetchResult.ServerCount = len(data.Servers)
+	fetchResult.SkillCount = len(data.Skills)
+	fetchResult.PluginCount = len(data.Plugins)
+	fetchResult.UnpinnedCount = unpinned
+	fetchResult.PackageDigests = digests
+
+	slog.Info("Digest pinning completed",
+		"registry", regCfg.Name,
+		"resolvedPackages", len(digests),
+		"unpinnedEntries", unpinned)
+}
+
+// applyEntryPolicyIfConfigured scans the entries of the fetch result with the
+// entry policy, if it applies to the source. Blocked entry versions are removed
+// and counted; the scans of the others are kept to be stored with them.
+func (s *defaultSyncManager) applyEntryPolicyIfConfigured(
+	ctx context.Context,
+	regCfg *config.SourceConfig,
+	fetchResult *sources.FetchResult) {
+	if !s.entryPolicy.AppliesTo(regCfg.Name) || fetchResult.Registry == nil {
+		return
+	}
+
+	data := &fetchResult.Registry.Data
+	var scans []scanning.EntryScan
+	blocked := 0
+
+	// evaluate scans an entry version, returning whether it is kept
+	evaluate := func(entryType, name, version string, entry any) bool {
+		result := s.entryPolicy.Evaluate(ctx, &scanning.Request{
+			Type:    entryType,
+			Source:  regCfg.Name,
+			Name:    name,
+			Version: version,
+			Entry:   entry,
+		})
+		if result.Blocked() {
+			slog.Warn("Entry blocked by entry policy",
+				"registry", regCfg.Name,
+				"type", entryType,
+				"name", name,
+				"version", version,
+				"findings", len(result.Findings))
+			blocked++
+			return false
+		}
+		scans = append(scans, scanning.EntryScan{Type: entryType, Name: name, Version: version, Result: result})
+		return true
+	}
+
+	data.Servers = slices.DeleteFunc(data.Servers, func(server upstreamv0.ServerJSON) bool {
+		return !evaluate(scanning.EntryTypeServer, server.Name, server.Version, server)
+	})
+	data.Skills = slices.DeleteFunc(data.Skills, func(skill toolhivetypes.Skill) bool {
+		return !evaluate(scanning.EntryTypeSkill, skill.Name, skill.Version, skill)
+	})
+	data.Plugins = slices.DeleteFunc(data.Plugins, func(plugin toolhivetypes.Plugin) bool {
+		return !evaluate(scanning.EntryTypePlugin, plugin.Name, plugin.Version, plugin)
+	})
+
+	fetchResult.ServerCount = len(data.Servers)
+	fetchResult.SkillCount = len(data.Skills)
+	fetchResult.PluginCount = len(data.Plugins)
+	fetchResult.BlockedCount = blocked
+	fetchResult.EntryScans = scans
+
+	slog.Info("Entry policy completed",
+		"registry", regCfg.Name,
+		"scannedEntries", len(scans)+blocked,
+		"blockedEntries", blocked)
+}
+
 // storeRegistryData stores the registry data using the storage manager
 func (s *defaultSyncManager) storeRegistryData(
 	ctx context.Context,
 	regCfg *config.SourceConfig,
 	fetchResult *sources.FetchResult) *Error {
-	if err := s.writer.Store(ctx, regCfg.Name, fetchResult.Registry); err != nil {
+	var opts []writer.StoreOption
+	if len(fetchResult.EntryScans) > 0 {
+		opts = append(opts, writer.WithEntryScans(fetchResult.EntryScans))
+	}
+	if len(fetchResult.PackageDigests) > 0 {
+		opts = append(opts, writer.WithPackageDigests(fetchResult.PackageDigests))
+	}
+	if len(fetchResult.ServerClaims) > 0 {
+		opts = append(opts, writer.WithPerEntryClaims(fetchResult.ServerClaims))
+	}
+	if len(fetchResult.SkillClaims) > 0 {
+		opts = append(opts, writer.WithPerSkillClaims(fetchResult.SkillClaims))
+	}
+	if len(fetchResult.PluginClaims) > 0 {
+		opts = append(opts, writer.WithPerPluginClaims(fetchResult.PluginClaims))
+	}
+	if err := s.writer.Store(ctx, regCfg.Name, fetchResult.Registry, opts...); err != nil {
 		slog.Error("Failed to store registry data", "error", err)
 		return &Error{
 			Err:             err,
@@ -490,3 +759,57 @@ func (s *defaultSyncManager) storeRegistryData(
 
 	return nil
 }
+
+// mergeRegistryData merges an incremental fetch result into the stored registry data.
+// Returns the number of entry versions stored for the registry after the merge.
+func (s *defaultSyncManager) mergeRegistryData(
+	ctx context.Context,
+	regCfg *config.SourceConfig,
+	fetchResult *sources.FetchResult) (*writer.MergeResult, *Error) {
+	var opts []writer.StoreOption
+	if len(fetchResult.EntryScans) > 0 {
+		opts = append(opts, writer.WithEntryScans(fetchResult.EntryScans))
+	}
+	if len(fetchResult.PackageDigests) > 0 {
+		opts = append(opts, writer.WithPackageDigests(fetchResult.PackageDigests))
+	}
+	if len(fetchResult.ServerClaims) > 0 {
+		opts = append(opts, writer.WithPerEntryClaims(fetchResult.ServerClaims))
+	}
+	if len(fetchResult.SkillClaims) > 0 {
+		opts = append(opts, writer.WithPerSkillClaims(fetchResult.SkillClaims))
+	}
+	if len(fetchResult.PluginClaims) > 0 {
+		opts = append(opts, writer.WithPerPluginClaims(fetchResult.PluginClaims))
+	}
+	if len(fetchResult.DeletedSkills) > 0 {
+		opts = append(opts, writer.WithDeletedSkills(fetchResult.DeletedSkills))
+	}
+	if len(fetchResult.DeletedPlugins) > 0 {
+		opts = append(opts, writer.WithDeletedPlugins(fetchResult.DeletedPlugins))
+	}
+	merged, err := s.writer.Merge(ctx, regCfg.Name, fetchResult.Registry, fetchResult.DeletedServers, opts...)
+	if err != nil {
+		slog.Error("Failed to merge registry data", "error", err)
+		return nil, &Error{
+			Err:             err,
+			Message:         fmt.Sprintf("Storage failed: %v", err),
+			ConditionType:   ConditionSyncSuccessful,
+			ConditionReason: conditionReasonStorageFailed,
+		}
+	}
+
+	slog.Info("Incremental registry data merged successfully",
+		"registryName", regCfg.Name,
+		"updatedServers", fetchResult.ServerCount,
+		"deletedServers", len(fetchResult.DeletedServers),
+		"updatedSkills", fetchResult.SkillCount,
+		"deletedSkills", len(fetchResult.DeletedSkills),
+		"updatedPlugins", fetchResult.PluginCount,
+		"deletedPlugins", len(fetchResult.DeletedPlugins),
+		"totalServers", merged.ServerCount,
+		"totalSkills", merged.SkillCount,
+		"totalPlugins", merged.PluginCount)
+
+	return merged, nil
+}
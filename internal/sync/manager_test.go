@@ -5,19 +5,27 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	toolhivetypes "github.com/stacklok/toolhive-core/registry/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/pinning"
 	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/scanning"
 	"github.com/stacklok/toolhive-registry-server/internal/sources"
 	"github.com/stacklok/toolhive-registry-server/internal/status"
+	"github.com/stacklok/toolhive-registry-server/internal/sync/writer"
 	writermocks "github.com/stacklok/toolhive-registry-server/internal/sync/writer/mocks"
 )
 
@@ -142,6 +150,21 @@ func TestDefaultSyncManager_ShouldSync(t *testing.T) {
 			},
 			expectedReason: ReasonRegistryNotReady,
 		},
+		{
+			name:                "sync needed when registry is in failed state with unchanged data",
+			manualSyncRequested: false,
+			config: &config.SourceConfig{
+				Name: "test-registry",
+				File: &config.FileConfig{
+					Path: testFilePath,
+				},
+			},
+			syncStatus: &status.SyncStatus{
+				Phase:        status.SyncPhaseFailed,
+				LastSyncHash: testHash,
+			},
+			expectedReason: ReasonRegistryNotReady,
+		},
 		{
 			name:                "manual sync not needed with new trigger value and same hash",
 			manualSyncRequested: true,
@@ -394,6 +417,160 @@ func TestDefaultSyncManager_PerformSync_WithPrefetched(t *testing.T) {
 	assert.Equal(t, 1, result.ServerCount)
 }
 
+func TestDefaultSyncManager_PerformSync_Incremental(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prefetched := sources.NewFetchResult(registry.NewTestUpstreamRegistry(
+		registry.WithServers(
+			registry.NewTestServer("io.test/kept-server", registry.WithOCIPackage("test/kept:latest")),
+			registry.NewTestServer("io.other/filtered-server", registry.WithOCIPackage("test/filtered:latest")),
+		),
+	), "hash")
+	prefetched.Incremental = true
+
+	// The server updated to no longer match the filter is deleted
+	mockWriter := writermocks.NewMockSyncWriter(ctrl)
+	mockWriter.EXPECT().
+		Merge(gomock.Any(), "test-registry", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context, _ string, reg *toolhivetypes.UpstreamRegistry, deleted []upstreamv0.ServerJSON,
+			_ ...writer.StoreOption,
+		) (*writer.MergeResult, error) {
+			require.Len(t, reg.Data.Servers, 1)
+			assert.Equal(t, "io.test/kept-server", reg.Data.Servers[0].Name)
+			require.Len(t, deleted, 1)
+			assert.Equal(t, "io.other/filtered-server", deleted[0].Name)
+			return &writer.MergeResult{ServerCount: 5, SkillCount: 2, PluginCount: 1}, nil
+		}).
+		Times(1)
+
+	regCfg := &config.SourceConfig{
+		Name:   "test-registry",
+		File:   &config.FileConfig{Path: "/nonexistent/path/registry.json"},
+		Filter: &config.FilterConfig{Names: &config.NameFilterConfig{Include: []string{"io.test/*"}}},
+	}
+	syncManager := NewDefaultSyncManager(sources.NewRegistryHandlerFactory(), mockWriter)
+
+	result, syncErr := syncManager.PerformSync(t.Context(), regCfg, prefetched)
+	require.Nil(t, syncErr)
+	assert.True(t, result.Incremental)
+	assert.Equal(t, 5, result.ServerCount)
+	assert.Equal(t, 2, result.SkillCount)
+	assert.Equal(t, 1, result.PluginCount)
+	assert.Contains(t, result.DeletedEntries, serverDigestKey("io.other/filtered-server", "1.0.0"))
+}
+
+func TestDefaultSyncManager_PerformSync_WithEntryPolicy(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prefetched := sources.NewFetchResult(registry.NewTestUpstreamRegistry(
+		registry.WithServers(
+			registry.NewTestServer("io.test/clean-server", registry.WithOCIPackage("test/clean:latest")),
+			registry.NewTestServer("io.test/malicious-server", registry.WithOCIPackage("test/malicious:latest")),
+		),
+	), "hash")
+
+	// The scanner blocks the malicious server and annotates the others
+	script := `if grep -q malicious-server; then echo '{"verdict": "block"}'; ` +
+		`else echo '{"verdict": "annotate", "findings": [{"message": "unsigned"}]}'; fi`
+	entryPolicy, err := scanning.NewPipeline(&config.EntryPolicyConfig{
+		Scanners: []config.ScannerConfig{{Name: "test", Command: []string{"/bin/sh", "-c", script}}},
+	})
+	require.NoError(t, err)
+
+	mockWriter := writermocks.NewMockSyncWriter(ctrl)
+	mockWriter.EXPECT().
+		Store(gomock.Any(), "test-registry", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, reg *toolhivetypes.UpstreamRegistry, _ ...writer.StoreOption) error {
+			require.Len(t, reg.Data.Servers, 1)
+			assert.Equal(t, "io.test/clean-server", reg.Data.Servers[0].Name)
+			return nil
+		}).
+		Times(1)
+
+	regCfg := &config.SourceConfig{
+		Name: "test-registry",
+		File: &config.FileConfig{Path: "/nonexistent/path/registry.json"},
+	}
+	syncManager := NewDefaultSyncManager(sources.NewRegistryHandlerFactory(), mockWriter, WithEntryPolicy(entryPolicy))
+
+	result, syncErr := syncManager.PerformSync(t.Context(), regCfg, prefetched)
+	require.Nil(t, syncErr)
+	assert.Equal(t, 1, result.ServerCount)
+	assert.Equal(t, 1, prefetched.BlockedCount)
+	require.Len(t, prefetched.EntryScans, 1)
+	assert.Equal(t, "io.test/clean-server", prefetched.EntryScans[0].Name)
+	assert.Equal(t, scanning.VerdictAnnotate, prefetched.EntryScans[0].Result.Verdict)
+	assert.Equal(t, []scanning.Finding{{Scanner: "test", Message: "unsigned"}}, prefetched.EntryScans[0].Result.Findings)
+}
+
+func TestDefaultSyncManager_PerformSync_WithDigestPinning(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The OCI registry knows the tag of the pinned server only
+	const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	ociRegistry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/test/pinned/manifests/1.0.0" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Docker-Content-Digest", digest)
+	}))
+	defer ociRegistry.Close()
+	host := strings.TrimPrefix(ociRegistry.URL, "http://")
+
+	prefetched := sources.NewFetchResult(registry.NewTestUpstreamRegistry(
+		registry.WithServers(
+			registry.NewTestServer("io.test/pinned-server", registry.WithOCIPackage(host+"/test/pinned:1.0.0")),
+			registry.NewTestServer("io.test/unpinned-server", registry.WithOCIPackage(host+"/test/unpinned:1.0.0")),
+		),
+	), "hash")
+
+	pinner := pinning.NewPinner(&config.Config{
+		Registries: []config.RegistryConfig{
+			{Name: "pinned", Sources: []string{"test-registry"}, RequireDigestPinning: true},
+		},
+		DigestPinning: &config.DigestPinningConfig{PlainHTTPRegistries: []string{host}},
+	})
+
+	mockWriter := writermocks.NewMockSyncWriter(ctrl)
+	mockWriter.EXPECT().
+		Store(gomock.Any(), "test-registry", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, reg *toolhivetypes.UpstreamRegistry, _ ...writer.StoreOption) error {
+			require.Len(t, reg.Data.Servers, 1)
+			assert.Equal(t, "io.test/pinned-server", reg.Data.Servers[0].Name)
+			return nil
+		}).
+		Times(1)
+
+	regCfg := &config.SourceConfig{
+		Name: "test-registry",
+		File: &config.FileConfig{Path: "/nonexistent/path/registry.json"},
+	}
+	syncManager := NewDefaultSyncManager(sources.NewRegistryHandlerFactory(), mockWriter, WithDigestPinning(pinner))
+
+	result, syncErr := syncManager.PerformSync(t.Context(), regCfg, prefetched)
+	require.Nil(t, syncErr)
+	assert.Equal(t, 1, result.ServerCount)
+	assert.Equal(t, 1, prefetched.UnpinnedCount)
+	assert.Equal(t, []pinning.PackageDigest{{
+		Type:       "server",
+		Name:       "io.test/pinned-server",
+		Version:    "1.0.0",
+		Identifier: host + "/test/pinned:1.0.0",
+		Digest:     digest,
+	}}, prefetched.PackageDigests)
+}
+
 func TestIsManualSync(t *testing.T) {
 	t.Parallel()
 
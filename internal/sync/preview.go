@@ -0,0 +1,77 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/filtering"
+	"github.com/stacklok/toolhive-registry-server/internal/sources"
+	"github.com/stacklok/toolhive-registry-server/internal/status"
+)
+
+// Preview is the outcome of a sync that was run without storing its data
+type Preview struct {
+	// Result is the result the sync would have
+	Result *Result
+
+	// Digests are the entry digests the sync would store
+	Digests status.EntryDigests
+
+	// Changes counts and lists the entry versions the sync would add, update
+	// and remove compared to the digests of the previous sync
+	Changes status.SyncChanges
+}
+
+// PreviewSync runs the fetch, validation and filtering steps of a full sync of
+// a specific registry, as PerformSync does, without storing the data. The
+// entries of the sync are compared to the digests stored by the previous sync,
+// so that a nil previous reports every entry version as added.
+func PreviewSync(
+	ctx context.Context,
+	registryHandlerFactory sources.RegistryHandlerFactory,
+	regCfg *config.SourceConfig,
+	previous status.EntryDigests,
+) (*Preview, *Error) {
+	if regCfg.IsNonSyncedSource() {
+		err := fmt.Errorf("source %s is not synced", regCfg.Name)
+		return nil, &Error{
+			Err:             err,
+			Message:         err.Error(),
+			ConditionType:   ConditionSourceAvailable,
+			ConditionReason: conditionReasonValidationFailed,
+		}
+	}
+
+	manager := &defaultSyncManager{
+		registryHandlerFactory: registryHandlerFactory,
+		filterService:          filtering.NewDefaultFilterService(),
+	}
+	fetchResult, syncErr := manager.fetchAndProcessRegistryData(ctx, regCfg, nil)
+	if syncErr != nil {
+		return nil, syncErr
+	}
+
+	// Unlike PerformSync, the digests are the point of a preview
+	entryDigests, err := computeEntryDigests(fetchResult.Registry)
+	if err != nil {
+		return nil, &Error{
+			Err:             err,
+			Message:         fmt.Sprintf("Failed to compute entry digests: %v", err),
+			ConditionType:   ConditionDataValid,
+			ConditionReason: conditionReasonValidationFailed,
+		}
+	}
+
+	result := &Result{
+		Hash:         fetchResult.Hash,
+		ServerCount:  fetchResult.ServerCount,
+		SkillCount:   fetchResult.SkillCount,
+		PluginCount:  fetchResult.PluginCount,
+		EntryDigests: entryDigests,
+		CommitSHA:    fetchResult.CommitSHA,
+	}
+	digests, changes := ApplyEntryDigests(previous, result)
+
+	return &Preview{Result: result, Digests: digests, Changes: changes}, nil
+}
@@ -0,0 +1,79 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/sources"
+	"github.com/stacklok/toolhive-registry-server/internal/status"
+)
+
+func TestPreviewSync(t *testing.T) {
+	t.Parallel()
+
+	testReg := registry.NewTestUpstreamRegistry(
+		registry.WithServers(
+			registry.NewTestServer("io.test/kept", registry.WithOCIPackage("test/kept:latest")),
+			registry.NewTestServer("io.test/new", registry.WithOCIPackage("test/new:latest")),
+			registry.NewTestServer("io.test/excluded", registry.WithOCIPackage("test/excluded:latest")),
+		),
+	)
+	testData, err := json.Marshal(testReg)
+	require.NoError(t, err)
+	testFilePath := filepath.Join(t.TempDir(), "registry.json")
+	require.NoError(t, os.WriteFile(testFilePath, testData, 0644))
+
+	regCfg := &config.SourceConfig{
+		Name: "test-registry",
+		File: &config.FileConfig{Path: testFilePath},
+		Filter: &config.FilterConfig{
+			Names: &config.NameFilterConfig{Exclude: []string{"io.test/excluded"}},
+		},
+	}
+
+	current, err := computeEntryDigests(testReg)
+	require.NoError(t, err)
+	keptKey := serverDigestKey("io.test/kept", testReg.Data.Servers[0].Version)
+	previous := status.EntryDigests{
+		keptKey:                             current[keptKey],
+		"server/io.test/removed@1.0.0":      "a",
+		"skill/io.test/skill-removed@1.0.0": "b",
+	}
+
+	preview, syncErr := PreviewSync(context.Background(), sources.NewRegistryHandlerFactory(), regCfg, previous)
+	require.Nil(t, syncErr)
+
+	assert.Equal(t, 2, preview.Result.ServerCount)
+	assert.Len(t, preview.Digests, 2)
+	assert.Equal(t, status.EntryChanges{Added: 1, Removed: 1}, preview.Changes.Servers)
+	assert.Equal(t, status.EntryChanges{Removed: 1}, preview.Changes.Skills)
+	require.Len(t, preview.Changes.Entries, 3)
+	assert.Equal(t, "io.test/new", preview.Changes.Entries[0].Name)
+	assert.Equal(t, status.EntryCreated, preview.Changes.Entries[0].Type)
+
+	// Without previous digests, every entry is added
+	preview, syncErr = PreviewSync(context.Background(), sources.NewRegistryHandlerFactory(), regCfg, nil)
+	require.Nil(t, syncErr)
+	assert.Equal(t, status.EntryChanges{Added: 2}, preview.Changes.Servers)
+
+	// Non-synced sources cannot be previewed
+	_, syncErr = PreviewSync(context.Background(), sources.NewRegistryHandlerFactory(),
+		&config.SourceConfig{Name: "managed", Managed: &config.ManagedConfig{}}, nil)
+	require.NotNil(t, syncErr)
+	assert.Contains(t, syncErr.Message, "is not synced")
+
+	// Fetch failures are reported
+	_, syncErr = PreviewSync(context.Background(), sources.NewRegistryHandlerFactory(),
+		&config.SourceConfig{Name: "missing", File: &config.FileConfig{Path: filepath.Join(t.TempDir(), "missing.json")}},
+		nil)
+	require.NotNil(t, syncErr)
+	assert.Equal(t, conditionReasonFetchFailed, syncErr.ConditionReason)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,6 +16,7 @@ import (
 	"github.com/stacklok/toolhive-registry-server/internal/db"
 	"github.com/stacklok/toolhive-registry-server/internal/db/pgtypes"
 	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/notifications"
 	"github.com/stacklok/toolhive-registry-server/internal/status"
 )
 
@@ -22,6 +24,10 @@ type dbStatusService struct {
 	pool *pgxpool.Pool
 	// sourceConfigsMap caches the source configs by name from the last Initialize call
 	sourceConfigsMap map[string]*config.SourceConfig
+	// historyRetention is how long sync attempts are kept, from the last Initialize call
+	historyRetention time.Duration
+	// outbox queues the source.sync events of the webhooks, from the last Initialize call
+	outbox *notifications.Outbox
 }
 
 // ErrRegistryNotFound is returned when a registry can't be found.
@@ -30,12 +36,20 @@ var ErrRegistryNotFound = errors.New("registry not found")
 // NewDBStateService creates a new database-backed registry state service
 func NewDBStateService(pool *pgxpool.Pool) RegistryStateService {
 	return &dbStatusService{
-		pool: pool,
+		pool:             pool,
+		historyRetention: config.DefaultSyncHistoryRetention,
 	}
 }
 
 func (d *dbStatusService) Initialize(ctx context.Context, cfg *config.Config) error {
 	sourceConfigs := cfg.Sources
+	d.historyRetention = cfg.GetSyncHistoryRetention()
+
+	outbox, err := notifications.NewOutbox(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to configure webhook notifications: %w", err)
+	}
+	d.outbox = outbox
 
 	// Build source configs map for caching
 	d.sourceConfigsMap = make(map[string]*config.SourceConfig, len(sourceConfigs))
@@ -96,6 +110,12 @@ func (d *dbStatusService) Initialize(ctx context.Context, cfg *config.Config) er
 		upsertedIDs[i] = src.ID
 	}
 
+	// The configuration of Git sources may have changed while the server was down, so
+	// their next sync fetches the data instead of only checking the remote commit
+	if err := queries.ClearSourceSyncCommits(ctx, upsertedIDs); err != nil {
+		return err
+	}
+
 	// Propagate source claims to existing entries (drift correction for claims-only changes)
 	if err := propagateSourceClaimsToEntries(ctx, queries, sourceConfigs, sourceNameToID); err != nil {
 		return err
@@ -264,6 +284,7 @@ func upsertRegistryRowsAndLinks(
 		registryRow, err := queries.UpsertRegistry(ctx, sqlc.UpsertRegistryParams{
 			Name:         reg.Name,
 			Claims:       claims,
+			Merge:        serializeMergeConfig(reg.Merge),
 			CreationType: sqlc.CreationTypeCONFIG,
 			CreatedAt:    now,
 			UpdatedAt:    now,
@@ -350,7 +371,13 @@ func (d *dbStatusService) GetSyncStatus(ctx context.Context, registryName string
 }
 
 func (d *dbStatusService) UpdateSyncStatus(ctx context.Context, registryName string, syncStatus *status.SyncStatus) error {
-	queries := sqlc.New(d.pool)
+	tx, err := d.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	queries := sqlc.New(d.pool).WithTx(tx)
 
 	// Prepare nullable string fields
 	var errorMsg *string
@@ -368,8 +395,13 @@ func (d *dbStatusService) UpdateSyncStatus(ctx context.Context, registryName str
 		lastAppliedFilterHash = &syncStatus.LastAppliedFilterHash
 	}
 
+	var lastSyncCommit *string
+	if syncStatus.LastSyncCommit != "" {
+		lastSyncCommit = &syncStatus.LastSyncCommit
+	}
+
 	// Upsert the sync status
-	err := queries.UpsertSourceSyncByName(ctx, sqlc.UpsertSourceSyncByNameParams{
+	err = queries.UpsertSourceSyncByName(ctx, sqlc.UpsertSourceSyncByNameParams{
 		Name:                  registryName,
 		SyncStatus:            syncPhaseToDBStatus(syncStatus.Phase),
 		ErrorMsg:              errorMsg,
@@ -381,21 +413,192 @@ func (d *dbStatusService) UpdateSyncStatus(ctx context.Context, registryName str
 		ServerCount:           int64(syncStatus.ServerCount),
 		SkillCount:            int64(syncStatus.SkillCount),
 		PluginCount:           int64(syncStatus.PluginCount),
+		LastSuccessAt:         syncStatus.LastSuccessfulSyncTime,
+		LastFullSyncAt:        syncStatus.LastFullSyncTime,
+		LastSyncCommit:        lastSyncCommit,
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	// Record the outcome on any manual sync requests this sync picked up
+	if requestStatus, ok := syncPhaseToRequestStatus(syncStatus.Phase); ok {
+		endedAt := syncStatus.LastSyncTime
+		if endedAt == nil {
+			now := time.Now()
+			endedAt = &now
+		}
+		var requestErrorMsg *string
+		if requestStatus == sqlc.SyncRequestStatusFAILED {
+			requestErrorMsg = errorMsg
+		}
+		if err := queries.FinishSyncRequests(ctx, sqlc.FinishSyncRequestsParams{
+			Name:     registryName,
+			Status:   requestStatus,
+			ErrorMsg: requestErrorMsg,
+			EndedAt:  endedAt,
+		}); err != nil {
+			return fmt.Errorf("failed to finish sync requests: %w", err)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (d *dbStatusService) GetEntryDigests(ctx context.Context, registryName string) (status.EntryDigests, error) {
+	queries := sqlc.New(d.pool)
+
+	data, err := queries.GetSourceEntryDigests(ctx, registryName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get entry digests: %w", err)
+	}
+
+	digests := status.EntryDigests{}
+	if err := json.Unmarshal(data, &digests); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal entry digests: %w", err)
+	}
+	return digests, nil
+}
+
+func (d *dbStatusService) RecordSyncAttempt(
+	ctx context.Context, registryName string, attempt *status.SyncAttempt, digests status.EntryDigests,
+) error {
+	tx, err := d.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	queries := sqlc.New(d.pool).WithTx(tx)
+
+	if err := queries.InsertSyncHistory(ctx, syncAttemptToInsertParams(registryName, attempt)); err != nil {
+		return fmt.Errorf("failed to insert sync history: %w", err)
+	}
+
+	if digests != nil {
+		data, err := json.Marshal(digests)
+		if err != nil {
+			return fmt.Errorf("failed to marshal entry digests: %w", err)
+		}
+		if err := queries.UpsertSourceEntryDigests(ctx, sqlc.UpsertSourceEntryDigestsParams{
+			Name:      registryName,
+			Digests:   data,
+			UpdatedAt: attempt.EndedAt,
+		}); err != nil {
+			return fmt.Errorf("failed to store entry digests: %w", err)
+		}
+	}
+
+	if len(attempt.Changes.Entries) > 0 {
+		if err := queries.LockEntryChanges(ctx); err != nil {
+			return fmt.Errorf("failed to lock entry changes: %w", err)
+		}
+		if err := queries.InsertSyncEntryChanges(ctx, entryChangesToInsertParams(registryName, attempt.Changes.Entries)); err != nil {
+			return fmt.Errorf("failed to record entry changes: %w", err)
+		}
+	}
+
+	if err := d.outbox.Enqueue(ctx, queries, notifications.NewSyncEvent(registryName, attempt)); err != nil {
+		return err
+	}
+
+	// Prune history and entry changes past the retention period
+	before := time.Now().Add(-d.historyRetention)
+	if err := queries.DeleteSyncHistoryBefore(ctx, sqlc.DeleteSyncHistoryBeforeParams{
+		Name:   registryName,
+		Before: before,
+	}); err != nil {
+		return fmt.Errorf("failed to prune sync history: %w", err)
+	}
+	if err := queries.DeleteEntryChangesBefore(ctx, before); err != nil {
+		return fmt.Errorf("failed to prune entry changes: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// syncAttemptToInsertParams converts a status.SyncAttempt to sync history insert parameters
+func syncAttemptToInsertParams(registryName string, attempt *status.SyncAttempt) sqlc.InsertSyncHistoryParams {
+	var conditionReason, errorMsg, syncHash *string
+	if attempt.ConditionReason != "" {
+		conditionReason = &attempt.ConditionReason
+	}
+	if attempt.Phase == status.SyncPhaseFailed && attempt.Message != "" {
+		errorMsg = &attempt.Message
+	}
+	if attempt.Hash != "" {
+		syncHash = &attempt.Hash
+	}
+
+	changes := attempt.Changes
+	return sqlc.InsertSyncHistoryParams{
+		Name:            registryName,
+		SyncStatus:      syncPhaseToDBStatus(attempt.Phase),
+		Reason:          attempt.Reason,
+		ConditionReason: conditionReason,
+		ErrorMsg:        errorMsg,
+		SyncHash:        syncHash,
+		Incremental:     attempt.Incremental,
+		ServerCount:     int64(attempt.ServerCount),
+		SkillCount:      int64(attempt.SkillCount),
+		PluginCount:     int64(attempt.PluginCount),
+		ServersAdded:    int64(changes.Servers.Added),
+		ServersUpdated:  int64(changes.Servers.Updated),
+		ServersRemoved:  int64(changes.Servers.Removed),
+		SkillsAdded:     int64(changes.Skills.Added),
+		SkillsUpdated:   int64(changes.Skills.Updated),
+		SkillsRemoved:   int64(changes.Skills.Removed),
+		PluginsAdded:    int64(changes.Plugins.Added),
+		PluginsUpdated:  int64(changes.Plugins.Updated),
+		PluginsRemoved:  int64(changes.Plugins.Removed),
+		StartedAt:       attempt.StartedAt,
+		EndedAt:         attempt.EndedAt,
+	}
+}
+
+// entryChangesToInsertParams converts the entry versions changed by a sync to
+// entry change insert parameters
+func entryChangesToInsertParams(registryName string, changes []status.EntryChange) sqlc.InsertSyncEntryChangesParams {
+	params := sqlc.InsertSyncEntryChangesParams{
+		EntryTypes:  make([]sqlc.EntryType, len(changes)),
+		Namespaces:  make([]string, len(changes)),
+		Names:       make([]string, len(changes)),
+		Versions:    make([]string, len(changes)),
+		ChangeTypes: make([]sqlc.EntryChangeType, len(changes)),
+		SourceName:  registryName,
+	}
+	for i, change := range changes {
+		switch change.EntryType {
+		case "skill":
+			params.EntryTypes[i] = sqlc.EntryTypeSKILL
+		case "plugin":
+			params.EntryTypes[i] = sqlc.EntryTypePLUGIN
+		default:
+			params.EntryTypes[i] = sqlc.EntryTypeMCP
+		}
+		params.Namespaces[i] = change.Namespace
+		params.Names[i] = change.Name
+		params.Versions[i] = change.Version
+		params.ChangeTypes[i] = sqlc.EntryChangeType(strings.ToUpper(string(change.Type)))
+	}
+	return params
 }
 
 // dbSyncToStatus converts a database RegistrySync to a status.SyncStatus
 func dbSyncToStatus(dbSync sqlc.RegistrySync) *status.SyncStatus {
 	syncStatus := &status.SyncStatus{
-		Phase:        dbSyncStatusToPhase(dbSync.SyncStatus),
-		LastAttempt:  dbSync.StartedAt,
-		LastSyncTime: dbSync.EndedAt,
-		AttemptCount: int(dbSync.AttemptCount),
-		ServerCount:  int(dbSync.ServerCount),
-		SkillCount:   int(dbSync.SkillCount),
-		PluginCount:  int(dbSync.PluginCount),
+		Phase:                  dbSyncStatusToPhase(dbSync.SyncStatus),
+		LastAttempt:            dbSync.StartedAt,
+		LastSyncTime:           dbSync.EndedAt,
+		AttemptCount:           int(dbSync.AttemptCount),
+		ServerCount:            int(dbSync.ServerCount),
+		SkillCount:             int(dbSync.SkillCount),
+		PluginCount:            int(dbSync.PluginCount),
+		LastSuccessfulSyncTime: dbSync.LastSuccessAt,
+		LastFullSyncTime:       dbSync.LastFullSyncAt,
 	}
 
 	// Set message from error_msg if present
@@ -410,6 +613,9 @@ func dbSyncToStatus(dbSync sqlc.RegistrySync) *status.SyncStatus {
 	if dbSync.LastAppliedFilterHash != nil {
 		syncStatus.LastAppliedFilterHash = *dbSync.LastAppliedFilterHash
 	}
+	if dbSync.LastSyncCommit != nil {
+		syncStatus.LastSyncCommit = *dbSync.LastSyncCommit
+	}
 
 	return syncStatus
 }
@@ -417,14 +623,16 @@ func dbSyncToStatus(dbSync sqlc.RegistrySync) *status.SyncStatus {
 // dbSyncRowToStatus converts a ListSourceSyncsRow to a status.SyncStatus
 func dbSyncRowToStatus(row sqlc.ListSourceSyncsRow) *status.SyncStatus {
 	syncStatus := &status.SyncStatus{
-		Phase:        dbSyncStatusToPhase(row.SyncStatus),
-		LastAttempt:  row.StartedAt,
-		LastSyncTime: row.EndedAt,
-		AttemptCount: int(row.AttemptCount),
-		ServerCount:  int(row.ServerCount),
-		SkillCount:   int(row.SkillCount),
-		PluginCount:  int(row.PluginCount),
-		SyncSchedule: intervalToString(row.SyncSchedule),
+		Phase:                  dbSyncStatusToPhase(row.SyncStatus),
+		LastAttempt:            row.StartedAt,
+		LastSyncTime:           row.EndedAt,
+		AttemptCount:           int(row.AttemptCount),
+		ServerCount:            int(row.ServerCount),
+		SkillCount:             int(row.SkillCount),
+		PluginCount:            int(row.PluginCount),
+		LastSuccessfulSyncTime: row.LastSuccessAt,
+		LastFullSyncTime:       row.LastFullSyncAt,
+		SyncSchedule:           intervalToString(row.SyncSchedule),
 	}
 
 	// Set message from error_msg if present
@@ -439,6 +647,9 @@ func dbSyncRowToStatus(row sqlc.ListSourceSyncsRow) *status.SyncStatus {
 	if row.LastAppliedFilterHash != nil {
 		syncStatus.LastAppliedFilterHash = *row.LastAppliedFilterHash
 	}
+	if row.LastSyncCommit != nil {
+		syncStatus.LastSyncCommit = *row.LastSyncCommit
+	}
 
 	return syncStatus
 }
@@ -446,14 +657,17 @@ func dbSyncRowToStatus(row sqlc.ListSourceSyncsRow) *status.SyncStatus {
 // dbSyncRowByLastUpdateToStatus converts a ListSourceSyncsByLastUpdateRow to a status.SyncStatus
 func dbSyncRowByLastUpdateToStatus(row sqlc.ListSourceSyncsByLastUpdateRow) *status.SyncStatus {
 	syncStatus := &status.SyncStatus{
-		Phase:        dbSyncStatusToPhase(row.SyncStatus),
-		LastAttempt:  row.StartedAt,
-		LastSyncTime: row.EndedAt,
-		AttemptCount: int(row.AttemptCount),
-		ServerCount:  int(row.ServerCount),
-		SkillCount:   int(row.SkillCount),
-		PluginCount:  int(row.PluginCount),
-		SyncSchedule: intervalToString(row.SyncSchedule),
+		Phase:                  dbSyncStatusToPhase(row.SyncStatus),
+		LastAttempt:            row.StartedAt,
+		LastSyncTime:           row.EndedAt,
+		AttemptCount:           int(row.AttemptCount),
+		ServerCount:            int(row.ServerCount),
+		SkillCount:             int(row.SkillCount),
+		PluginCount:            int(row.PluginCount),
+		LastSuccessfulSyncTime: row.LastSuccessAt,
+		LastFullSyncTime:       row.LastFullSyncAt,
+		SyncSchedule:           intervalToString(row.SyncSchedule),
+		ManualSyncRequested:    row.ManualSyncRequested,
 	}
 
 	// Set message from error_msg if present
@@ -468,6 +682,9 @@ func dbSyncRowByLastUpdateToStatus(row sqlc.ListSourceSyncsByLastUpdateRow) *sta
 	if row.LastAppliedFilterHash != nil {
 		syncStatus.LastAppliedFilterHash = *row.LastAppliedFilterHash
 	}
+	if row.LastSyncCommit != nil {
+		syncStatus.LastSyncCommit = *row.LastSyncCommit
+	}
 
 	return syncStatus
 }
@@ -500,6 +717,19 @@ func syncPhaseToDBStatus(phase status.SyncPhase) sqlc.SyncStatus {
 	}
 }
 
+// syncPhaseToRequestStatus converts a terminal status.SyncPhase to the database
+// sync_request_status enum. Returns false for phases that do not end a sync.
+func syncPhaseToRequestStatus(phase status.SyncPhase) (sqlc.SyncRequestStatus, bool) {
+	switch phase {
+	case status.SyncPhaseComplete:
+		return sqlc.SyncRequestStatusCOMPLETED, true
+	case status.SyncPhaseFailed:
+		return sqlc.SyncRequestStatusFAILED, true
+	default:
+		return "", false
+	}
+}
+
 // getInitialSyncStatus returns the initial sync status and error message for a source.
 // Non-synced sources (managed and kubernetes) start with COMPLETED status since they don't
 // sync from external sources. Synced sources start with FAILED to trigger initial sync.
@@ -552,15 +782,22 @@ func (d *dbStatusService) GetNextSyncJob(
 	// Create queries with transaction
 	queries := sqlc.New(d.pool).WithTx(tx)
 
-	// List all sources ordered by last update (ended_at) in ascending order
-	// Using FOR UPDATE SKIP LOCKED to prevent race conditions
-	sources, err := queries.ListSourceSyncsByLastUpdate(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list sources: %w", err)
-	}
+	// Take the due sources one at a time, ordered by last update (ended_at) in
+	// ascending order, passing over those the predicate rejects so that they do
+	// not hide the sources due after them. Using FOR UPDATE SKIP LOCKED to
+	// prevent race conditions
+	skipped := []string{}
+	for {
+		sources, err := queries.ListSourceSyncsByLastUpdate(ctx, skipped)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list sources: %w", err)
+		}
+		if len(sources) == 0 {
+			break
+		}
+		src := sources[0]
+		skipped = append(skipped, src.Name)
 
-	// Iterate through sources and find one that matches the predicate
-	for _, src := range sources {
 		syncStatus := dbSyncRowByLastUpdateToStatus(src)
 
 		// Find the matching source configuration from cached configs (CONFIG sources)
@@ -595,6 +832,17 @@ func (d *dbStatusService) GetNextSyncJob(
 				return nil, fmt.Errorf("failed to update source status: %w", err)
 			}
 
+			// Mark pending manual sync requests as picked up by this sync
+			if syncStatus.ManualSyncRequested {
+				err = queries.StartSyncRequests(ctx, sqlc.StartSyncRequestsParams{
+					Name:      src.Name,
+					StartedAt: &now,
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to start sync requests: %w", err)
+				}
+			}
+
 			// Commit the transaction
 			if err := tx.Commit(ctx); err != nil {
 				return nil, fmt.Errorf("failed to commit transaction: %w", err)
@@ -624,6 +872,8 @@ func serializeSourceConfig(src *config.SourceConfig) []byte {
 		sourceConfig = src.API
 	case src.File != nil:
 		sourceConfig = src.File
+	case src.Bundle != nil:
+		sourceConfig = src.Bundle
 	case src.Managed != nil:
 		sourceConfig = src.Managed
 	case src.Kubernetes != nil:
@@ -653,6 +903,20 @@ func serializeFilterConfig(filter *config.FilterConfig) []byte {
 	return data
 }
 
+// serializeMergeConfig serializes the merge strategies of a registry to JSON bytes.
+// Returns nil when no strategy is set, meaning first-wins for every field.
+func serializeMergeConfig(merge *config.MergeConfig) []byte {
+	if merge == nil || *merge == (config.MergeConfig{}) {
+		return nil
+	}
+
+	data, err := json.Marshal(merge)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
 // loadSourceConfigFromDB loads a source configuration from the database.
 // This is used for API-created sources that are not in the config file cache.
 func loadSourceConfigFromDB(ctx context.Context, queries *sqlc.Queries, name string) (*config.SourceConfig, error) {
@@ -713,6 +977,12 @@ func parseSourceConfig(srcCfg *config.SourceConfig, sourceType config.SourceType
 			return err
 		}
 		srcCfg.File = &fileConfig
+	case config.SourceTypeBundle:
+		var bundleConfig config.BundleConfig
+		if err := json.Unmarshal(sourceConfig, &bundleConfig); err != nil {
+			return err
+		}
+		srcCfg.Bundle = &bundleConfig
 	case config.SourceTypeManaged:
 		var managedConfig config.ManagedConfig
 		if err := json.Unmarshal(sourceConfig, &managedConfig); err != nil {
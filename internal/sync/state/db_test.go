@@ -122,6 +122,50 @@ func TestSyncPhaseToDBStatus(t *testing.T) {
 	}
 }
 
+func TestSyncPhaseToRequestStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		phase  status.SyncPhase
+		want   sqlc.SyncRequestStatus
+		wantOK bool
+	}{
+		{
+			name:   "converts Complete to COMPLETED",
+			phase:  status.SyncPhaseComplete,
+			want:   sqlc.SyncRequestStatusCOMPLETED,
+			wantOK: true,
+		},
+		{
+			name:   "converts Failed to FAILED",
+			phase:  status.SyncPhaseFailed,
+			want:   sqlc.SyncRequestStatusFAILED,
+			wantOK: true,
+		},
+		{
+			name:   "Syncing does not finish requests",
+			phase:  status.SyncPhaseSyncing,
+			wantOK: false,
+		},
+		{
+			name:   "unknown does not finish requests",
+			phase:  status.SyncPhase("unknown"),
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := syncPhaseToRequestStatus(tt.phase)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestDBSyncStatusToPhase(t *testing.T) {
 	t.Parallel()
 
@@ -553,3 +597,110 @@ func TestDBSyncRowToStatus_PreservesAllFields(t *testing.T) {
 	require.NotNil(t, result.LastSyncTime)
 	assert.True(t, syncTime.Equal(*result.LastSyncTime))
 }
+
+func TestDBSyncRowByLastUpdateToStatus_ManualSyncRequested(t *testing.T) {
+	t.Parallel()
+
+	row := sqlc.ListSourceSyncsByLastUpdateRow{
+		Name:                "test-registry",
+		ID:                  uuid.New(),
+		SyncStatus:          sqlc.SyncStatusCOMPLETED,
+		ServerCount:         3,
+		ManualSyncRequested: true,
+	}
+
+	result := dbSyncRowByLastUpdateToStatus(row)
+
+	assert.Equal(t, status.SyncPhaseComplete, result.Phase)
+	assert.Equal(t, 3, result.ServerCount)
+	assert.True(t, result.ManualSyncRequested)
+}
+
+func TestSyncAttemptToInsertParams(t *testing.T) {
+	t.Parallel()
+
+	startedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endedAt := startedAt.Add(time.Minute)
+
+	t.Run("successful attempt", func(t *testing.T) {
+		t.Parallel()
+
+		params := syncAttemptToInsertParams("git-source", &status.SyncAttempt{
+			StartedAt:   startedAt,
+			EndedAt:     endedAt,
+			Reason:      "source-data-changed",
+			Phase:       status.SyncPhaseComplete,
+			Message:     "Sync completed successfully",
+			Hash:        "abc",
+			Incremental: true,
+			ServerCount: 10,
+			SkillCount:  2,
+			PluginCount: 1,
+			Changes: status.SyncChanges{
+				Servers: status.EntryChanges{Added: 1, Updated: 2, Removed: 3},
+				Skills:  status.EntryChanges{Added: 4},
+				Plugins: status.EntryChanges{Removed: 5},
+			},
+		})
+
+		assert.Equal(t, "git-source", params.Name)
+		assert.Equal(t, sqlc.SyncStatusCOMPLETED, params.SyncStatus)
+		assert.Equal(t, "source-data-changed", params.Reason)
+		assert.Nil(t, params.ConditionReason)
+		assert.Nil(t, params.ErrorMsg, "success messages are not stored as errors")
+		require.NotNil(t, params.SyncHash)
+		assert.Equal(t, "abc", *params.SyncHash)
+		assert.True(t, params.Incremental)
+		assert.Equal(t, int64(10), params.ServerCount)
+		assert.Equal(t, int64(2), params.SkillCount)
+		assert.Equal(t, int64(1), params.PluginCount)
+		assert.Equal(t, int64(1), params.ServersAdded)
+		assert.Equal(t, int64(2), params.ServersUpdated)
+		assert.Equal(t, int64(3), params.ServersRemoved)
+		assert.Equal(t, int64(4), params.SkillsAdded)
+		assert.Equal(t, int64(5), params.PluginsRemoved)
+		assert.Equal(t, startedAt, params.StartedAt)
+		assert.Equal(t, endedAt, params.EndedAt)
+	})
+
+	t.Run("failed attempt", func(t *testing.T) {
+		t.Parallel()
+
+		params := syncAttemptToInsertParams("git-source", &status.SyncAttempt{
+			StartedAt:       startedAt,
+			EndedAt:         endedAt,
+			Reason:          "registry-not-ready",
+			Phase:           status.SyncPhaseFailed,
+			Message:         "Fetch failed: boom",
+			ConditionReason: "FetchFailed",
+		})
+
+		assert.Equal(t, sqlc.SyncStatusFAILED, params.SyncStatus)
+		require.NotNil(t, params.ConditionReason)
+		assert.Equal(t, "FetchFailed", *params.ConditionReason)
+		require.NotNil(t, params.ErrorMsg)
+		assert.Equal(t, "Fetch failed: boom", *params.ErrorMsg)
+		assert.Nil(t, params.SyncHash)
+	})
+}
+
+func TestEntryChangesToInsertParams(t *testing.T) {
+	t.Parallel()
+
+	params := entryChangesToInsertParams("git-source", []status.EntryChange{
+		{Type: status.EntryCreated, EntryType: "server", Name: "io.test/server", Version: "1.0.0"},
+		{Type: status.EntryUpdated, EntryType: "skill", Namespace: "io.test", Name: "skill", Version: "1.0.0"},
+		{Type: status.EntryDeleted, EntryType: "plugin", Namespace: "io.test", Name: "plugin", Version: "2.0.0"},
+	})
+
+	assert.Equal(t, "git-source", params.SourceName)
+	assert.Equal(t, []sqlc.EntryType{sqlc.EntryTypeMCP, sqlc.EntryTypeSKILL, sqlc.EntryTypePLUGIN}, params.EntryTypes)
+	assert.Equal(t, []string{"", "io.test", "io.test"}, params.Namespaces)
+	assert.Equal(t, []string{"io.test/server", "skill", "plugin"}, params.Names)
+	assert.Equal(t, []string{"1.0.0", "1.0.0", "2.0.0"}, params.Versions)
+	assert.Equal(t, []sqlc.EntryChangeType{
+		sqlc.EntryChangeTypeCREATED,
+		sqlc.EntryChangeTypeUPDATED,
+		sqlc.EntryChangeTypeDELETED,
+	}, params.ChangeTypes)
+}
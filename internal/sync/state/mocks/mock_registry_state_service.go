@@ -42,6 +42,21 @@ func (m *MockRegistryStateService) EXPECT() *MockRegistryStateServiceMockRecorde
 	return m.recorder
 }
 
+// GetEntryDigests mocks base method.
+func (m *MockRegistryStateService) GetEntryDigests(ctx context.Context, registryName string) (status.EntryDigests, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntryDigests", ctx, registryName)
+	ret0, _ := ret[0].(status.EntryDigests)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntryDigests indicates an expected call of GetEntryDigests.
+func (mr *MockRegistryStateServiceMockRecorder) GetEntryDigests(ctx, registryName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntryDigests", reflect.TypeOf((*MockRegistryStateService)(nil).GetEntryDigests), ctx, registryName)
+}
+
 // GetNextSyncJob mocks base method.
 func (m *MockRegistryStateService) GetNextSyncJob(ctx context.Context, predicate func(*config.SourceConfig, *status.SyncStatus) bool) (*config.SourceConfig, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +116,20 @@ func (mr *MockRegistryStateServiceMockRecorder) ListSyncStatuses(ctx any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSyncStatuses", reflect.TypeOf((*MockRegistryStateService)(nil).ListSyncStatuses), ctx)
 }
 
+// RecordSyncAttempt mocks base method.
+func (m *MockRegistryStateService) RecordSyncAttempt(ctx context.Context, registryName string, attempt *status.SyncAttempt, digests status.EntryDigests) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordSyncAttempt", ctx, registryName, attempt, digests)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordSyncAttempt indicates an expected call of RecordSyncAttempt.
+func (mr *MockRegistryStateServiceMockRecorder) RecordSyncAttempt(ctx, registryName, attempt, digests any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordSyncAttempt", reflect.TypeOf((*MockRegistryStateService)(nil).RecordSyncAttempt), ctx, registryName, attempt, digests)
+}
+
 // UpdateSyncStatus mocks base method.
 func (m *MockRegistryStateService) UpdateSyncStatus(ctx context.Context, registryName string, syncStatus *status.SyncStatus) error {
 	m.ctrl.T.Helper()
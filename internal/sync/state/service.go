@@ -22,6 +22,16 @@ type RegistryStateService interface {
 	GetSyncStatus(ctx context.Context, registryName string) (*status.SyncStatus, error)
 	// UpdateSyncStatus overrides the value of the named source with the syncStatus parameter.
 	UpdateSyncStatus(ctx context.Context, registryName string, syncStatus *status.SyncStatus) error
+	// GetEntryDigests returns the entry digests stored by the last successful sync
+	// of the named source, or nil if none were stored yet.
+	GetEntryDigests(ctx context.Context, registryName string) (status.EntryDigests, error)
+	// RecordSyncAttempt appends a sync attempt to the history of the named source and
+	// prunes its history past the configured retention. A non-nil digests parameter
+	// replaces the stored entry digests of the source. The entry versions changed by
+	// the attempt are appended to the entry change feed, pruned with the same retention.
+	RecordSyncAttempt(
+		ctx context.Context, registryName string, attempt *status.SyncAttempt, digests status.EntryDigests,
+	) error
 	// GetNextSyncJob returns the next source configuration that needs syncing.
 	// The predicate function is used to filter sources based on their config and sync status.
 	// The source configs are cached from the Initialize call.
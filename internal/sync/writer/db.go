@@ -19,6 +19,8 @@ import (
 	toolhivetypes "github.com/stacklok/toolhive-core/registry/types"
 
 	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/pinning"
+	"github.com/stacklok/toolhive-registry-server/internal/scanning"
 	"github.com/stacklok/toolhive-registry-server/internal/validators"
 	"github.com/stacklok/toolhive-registry-server/internal/versions"
 )
@@ -137,15 +139,28 @@ func (d *dbSyncWriter) Store(
 	}
 
 	// Step 6: Store skills
-	if err := d.storeSkills(ctx, tx, registry.ID, reg.Data.Skills, registry.Claims); err != nil {
+	if err := d.storeSkills(ctx, tx, registry.ID, reg.Data.Skills, registry.Claims, storeOpts.PerSkillClaims, true); err != nil {
 		return fmt.Errorf("failed to store skills: %w", err)
 	}
 
 	// Step 7: Store plugins
-	if err := d.storePlugins(ctx, tx, registry.ID, reg.Data.Plugins, registry.Claims); err != nil {
+	if err := d.storePlugins(ctx, tx, registry.ID, reg.Data.Plugins, registry.Claims, storeOpts.PerPluginClaims, true); err != nil {
 		return fmt.Errorf("failed to store plugins: %w", err)
 	}
 
+	// Step 8: Replace the scans of the entry versions
+	if err := querier.DeleteSourceEntryScans(ctx, registry.ID); err != nil {
+		return fmt.Errorf("failed to delete entry scans: %w", err)
+	}
+	if err := storeEntryScans(ctx, querier, registry.ID, storeOpts.EntryScans); err != nil {
+		return err
+	}
+
+	// Step 9: Record the resolved digests of the OCI packages
+	if err := storePackageDigests(ctx, querier, registry.ID, storeOpts.PackageDigests); err != nil {
+		return err
+	}
+
 	// Commit transaction
 	if err := tx.Commit(ctx); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
@@ -154,6 +169,360 @@ func (d *dbSyncWriter) Store(
 	return nil
 }
 
+// Merge applies an incremental delta to the database storage for a specific registry.
+//
+// Unlike Store, entries missing from reg are kept:
+//  1. Validates the registry exists
+//  2. Upserts the servers in reg and their packages, remotes, and icons
+//  3. Upserts the skills and plugins in reg
+//  4. Deletes the server versions listed in deleted and the skill and plugin versions
+//     of the WithDeletedSkills and WithDeletedPlugins options, removing entries left
+//     without versions
+//  5. Recomputes the latest version of every entry name touched by the delta
+//
+// Returns the number of entry versions of each type stored for the registry after the merge.
+//
+//nolint:gocyclo
+func (d *dbSyncWriter) Merge(
+	ctx context.Context,
+	registryName string,
+	reg *toolhivetypes.UpstreamRegistry,
+	deleted []upstreamv0.ServerJSON,
+	opts ...StoreOption,
+) (*MergeResult, error) {
+	if reg == nil {
+		return nil, fmt.Errorf("registry data is required")
+	}
+
+	tx, err := d.pool.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.Serializable,
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil && !errors.Is(rollbackErr, pgx.ErrTxClosed) {
+			_ = rollbackErr
+		}
+	}()
+
+	querier := sqlc.New(tx)
+
+	registry, err := querier.GetSourceByName(ctx, registryName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("registry not found: %s", registryName)
+		}
+		return nil, fmt.Errorf("failed to get registry: %w", err)
+	}
+
+	storeOpts, err := parseStoreOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	serverIDMap, err := d.storeSyncInTempTables(ctx, tx, registry.ID, reg.Data.Servers, registry.Claims, storeOpts.PerEntryClaims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert servers: %w", err)
+	}
+
+	if err := dropEntryTempTables(ctx, querier); err != nil {
+		return nil, err
+	}
+
+	if err := d.insertRelatedData(ctx, tx, serverIDMap, reg.Data.Servers); err != nil {
+		return nil, fmt.Errorf("failed to insert related data: %w", err)
+	}
+
+	if err := d.storeSkills(ctx, tx, registry.ID, reg.Data.Skills, registry.Claims, storeOpts.PerSkillClaims, false); err != nil {
+		return nil, fmt.Errorf("failed to store skills: %w", err)
+	}
+
+	// Drop skill temp tables so they can be reused for plugins
+	if err := dropEntryTempTables(ctx, querier); err != nil {
+		return nil, err
+	}
+
+	if err := d.storePlugins(ctx, tx, registry.ID, reg.Data.Plugins, registry.Claims, storeOpts.PerPluginClaims, false); err != nil {
+		return nil, fmt.Errorf("failed to store plugins: %w", err)
+	}
+
+	removed := map[sqlc.EntryType][]entryVersion{}
+	for _, server := range deleted {
+		removed[sqlc.EntryTypeMCP] = append(removed[sqlc.EntryTypeMCP], entryVersion{server.Name, server.Version})
+	}
+	for _, skill := range storeOpts.DeletedSkills {
+		removed[sqlc.EntryTypeSKILL] = append(removed[sqlc.EntryTypeSKILL], entryVersion{skill.Name, skill.Version})
+	}
+	for _, plugin := range storeOpts.DeletedPlugins {
+		removed[sqlc.EntryTypePLUGIN] = append(removed[sqlc.EntryTypePLUGIN], entryVersion{plugin.Name, plugin.Version})
+	}
+	for entryType, refs := range removed {
+		if err := deleteEntryVersions(ctx, querier, registry.ID, entryType, refs); err != nil {
+			return nil, fmt.Errorf("failed to delete removed entries: %w", err)
+		}
+	}
+
+	if err := storeEntryScans(ctx, querier, registry.ID, storeOpts.EntryScans); err != nil {
+		return nil, err
+	}
+
+	if err := storePackageDigests(ctx, querier, registry.ID, storeOpts.PackageDigests); err != nil {
+		return nil, err
+	}
+
+	touched := map[sqlc.EntryType]map[string]struct{}{
+		sqlc.EntryTypeMCP:    {},
+		sqlc.EntryTypeSKILL:  {},
+		sqlc.EntryTypePLUGIN: {},
+	}
+	for _, server := range reg.Data.Servers {
+		touched[sqlc.EntryTypeMCP][server.Name] = struct{}{}
+	}
+	for _, skill := range reg.Data.Skills {
+		touched[sqlc.EntryTypeSKILL][skill.Name] = struct{}{}
+	}
+	for _, plugin := range reg.Data.Plugins {
+		touched[sqlc.EntryTypePLUGIN][plugin.Name] = struct{}{}
+	}
+	for entryType, refs := range removed {
+		for _, ref := range refs {
+			touched[entryType][ref.name] = struct{}{}
+		}
+	}
+	for entryType, names := range touched {
+		if err := recomputeLatestVersions(ctx, querier, registry.ID, entryType, names); err != nil {
+			return nil, fmt.Errorf("failed to update latest versions: %w", err)
+		}
+	}
+
+	stored, err := querier.ListEntriesBySource(ctx, registry.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count entries: %w", err)
+	}
+	result := &MergeResult{}
+	for _, row := range stored {
+		switch row.EntryType {
+		case sqlc.EntryTypeMCP:
+			result.ServerCount++
+		case sqlc.EntryTypeSKILL:
+			result.SkillCount++
+		case sqlc.EntryTypePLUGIN:
+			result.PluginCount++
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return result, nil
+}
+
+// storeEntryScans records the scans of the given entry versions of a source.
+// Scans of versions that are not stored are ignored.
+func storeEntryScans(ctx context.Context, querier *sqlc.Queries, sourceID uuid.UUID, scans []scanning.EntryScan) error {
+	if len(scans) == 0 {
+		return nil
+	}
+
+	params := sqlc.UpsertSourceEntryScansParams{
+		EntryTypes: make([]string, 0, len(scans)),
+		Names:      make([]string, 0, len(scans)),
+		Versions:   make([]string, 0, len(scans)),
+		Verdicts:   make([]string, 0, len(scans)),
+		Findings:   make([]string, 0, len(scans)),
+		SourceID:   sourceID,
+	}
+	for _, scan := range scans {
+		entryType, err := scanEntryType(scan.Type)
+		if err != nil {
+			return err
+		}
+		var findings []byte
+		if len(scan.Result.Findings) > 0 {
+			findings, err = json.Marshal(scan.Result.Findings)
+			if err != nil {
+				return fmt.Errorf("failed to marshal findings for %s: %w", scan.Name, err)
+			}
+		}
+		params.EntryTypes = append(params.EntryTypes, string(entryType))
+		params.Names = append(params.Names, scan.Name)
+		params.Versions = append(params.Versions, scan.Version)
+		params.Verdicts = append(params.Verdicts, string(scan.Result.Verdict))
+		params.Findings = append(params.Findings, string(findings))
+	}
+
+	if err := querier.UpsertSourceEntryScans(ctx, params); err != nil {
+		return fmt.Errorf("failed to store entry scans: %w", err)
+	}
+	return nil
+}
+
+// storePackageDigests records the resolved digests of the OCI packages of the
+// given entry versions of a source, and logs the packages whose tag drifted to
+// another digest. Digests of versions that are not stored are ignored.
+func storePackageDigests(
+	ctx context.Context, querier *sqlc.Queries, sourceID uuid.UUID, digests []pinning.PackageDigest,
+) error {
+	if len(digests) == 0 {
+		return nil
+	}
+
+	params := sqlc.UpsertSourcePackageDigestsParams{
+		EntryTypes:  make([]string, 0, len(digests)),
+		Names:       make([]string, 0, len(digests)),
+		Versions:    make([]string, 0, len(digests)),
+		Identifiers: make([]string, 0, len(digests)),
+		Digests:     make([]string, 0, len(digests)),
+		SourceID:    sourceID,
+	}
+	for _, digest := range digests {
+		entryType, err := scanEntryType(digest.Type)
+		if err != nil {
+			return err
+		}
+		params.EntryTypes = append(params.EntryTypes, string(entryType))
+		params.Names = append(params.Names, digest.Name)
+		params.Versions = append(params.Versions, digest.Version)
+		params.Identifiers = append(params.Identifiers, digest.Identifier)
+		params.Digests = append(params.Digests, digest.Digest)
+	}
+
+	drifted, err := querier.UpsertSourcePackageDigests(ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to store package digests: %w", err)
+	}
+	for _, row := range drifted {
+		var previous string
+		if row.PreviousDigest != nil {
+			previous = *row.PreviousDigest
+		}
+		slog.Warn("OCI package tag drifted to another digest",
+			"identifier", row.Identifier,
+			"previous_digest", previous,
+			"digest", row.Digest)
+	}
+	return nil
+}
+
+// scanEntryType returns the database entry type of a scanned entry
+func scanEntryType(entryType string) (sqlc.EntryType, error) {
+	switch entryType {
+	case scanning.EntryTypeServer:
+		return sqlc.EntryTypeMCP, nil
+	case scanning.EntryTypeSkill:
+		return sqlc.EntryTypeSKILL, nil
+	case scanning.EntryTypePlugin:
+		return sqlc.EntryTypePLUGIN, nil
+	default:
+		return "", fmt.Errorf("unknown entry type of scan: %s", entryType)
+	}
+}
+
+// entryVersion identifies a version of an entry of a registry
+type entryVersion struct {
+	name    string
+	version string
+}
+
+// deleteEntryVersions removes the given versions of entries of a type from a registry.
+// Versions that are not stored are ignored, and entries left without any version are
+// deleted as well. CASCADE constraints clean up the related packages, remotes, icons,
+// and latest pointers.
+func deleteEntryVersions(
+	ctx context.Context,
+	querier *sqlc.Queries,
+	registryID uuid.UUID,
+	entryType sqlc.EntryType,
+	versions []entryVersion,
+) error {
+	for _, v := range versions {
+		entry, err := querier.GetRegistryEntryByName(ctx, sqlc.GetRegistryEntryByNameParams{
+			SourceID:  registryID,
+			EntryType: entryType,
+			Name:      v.name,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			return fmt.Errorf("failed to get entry for %s %s: %w", entryType, v.name, err)
+		}
+
+		if _, err := querier.DeleteEntryVersion(ctx, sqlc.DeleteEntryVersionParams{
+			EntryID: entry.ID,
+			Version: v.version,
+		}); err != nil {
+			return fmt.Errorf("failed to delete %s %s@%s: %w", entryType, v.name, v.version, err)
+		}
+
+		remaining, err := querier.CountEntryVersions(ctx, entry.ID)
+		if err != nil {
+			return fmt.Errorf("failed to count versions for %s %s: %w", entryType, v.name, err)
+		}
+		if remaining == 0 {
+			if _, err := querier.DeleteRegistryEntryByID(ctx, entry.ID); err != nil {
+				return fmt.Errorf("failed to delete entry for %s %s: %w", entryType, v.name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// recomputeLatestVersions points the latest_entry_version row of each given entry name
+// of a type at its highest stored version. Names without any stored version are skipped.
+func recomputeLatestVersions(
+	ctx context.Context,
+	querier *sqlc.Queries,
+	registryID uuid.UUID,
+	entryType sqlc.EntryType,
+	names map[string]struct{},
+) error {
+	for name := range names {
+		entry, err := querier.GetRegistryEntryByName(ctx, sqlc.GetRegistryEntryByNameParams{
+			SourceID:  registryID,
+			EntryType: entryType,
+			Name:      name,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			return fmt.Errorf("failed to get entry for %s %s: %w", entryType, name, err)
+		}
+
+		rows, err := querier.ListEntryVersions(ctx, entry.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list versions for %s %s: %w", entryType, name, err)
+		}
+		if len(rows) == 0 {
+			continue
+		}
+
+		latest := rows[0]
+		for _, row := range rows[1:] {
+			if versions.IsNewerVersion(row.Version, latest.Version) {
+				latest = row
+			}
+		}
+
+		// latest_entry_version is shared by every entry type, so is the server query
+		if _, err := querier.UpsertLatestServerVersion(ctx, sqlc.UpsertLatestServerVersionParams{
+			SourceID:  registryID,
+			Name:      name,
+			Version:   latest.Version,
+			VersionID: latest.ID,
+		}); err != nil {
+			return fmt.Errorf("failed to upsert latest version for %s %s: %w", entryType, name, err)
+		}
+	}
+
+	return nil
+}
+
 // serverKey creates a unique key for a server based on name and version
 func serverKey(name, version string) string {
 	return name + "@" + version
@@ -909,18 +1278,24 @@ func skillKey(namespace, name, version string) string {
 // storeSkills persists skills from an upstream registry into the database.
 // It follows the same bulk-sync pattern as server storage: temp tables with COPY,
 // followed by upserts and orphan cleanup. Reuses the shared copyAndUpsertEntries
-// and copyAndUpsertEntryVersions functions.
+// and copyAndUpsertEntryVersions functions. Without prune, the skills missing
+// from skills are kept and the latest versions are left to the caller.
 func (d *dbSyncWriter) storeSkills(
 	ctx context.Context,
 	tx pgx.Tx,
 	registryID uuid.UUID,
 	skills []toolhivetypes.Skill,
 	claims []byte,
+	perEntryClaims map[string][]byte,
+	prune bool,
 ) error {
 	querier := sqlc.New(tx)
 
 	// If no skills, clean up any previously synced skills and return
 	if len(skills) == 0 {
+		if !prune {
+			return nil
+		}
 		return querier.DeleteSkillsByRegistry(ctx, registryID)
 	}
 
@@ -940,12 +1315,18 @@ func (d *dbSyncWriter) storeSkills(
 			return fmt.Errorf("failed to generate entry ID: %w", err)
 		}
 
+		// Use per-entry claims if available, otherwise fall back to source-level claims
+		entryClaims := claims
+		if ec, ok := perEntryClaims[skill.Name]; ok {
+			entryClaims = ec
+		}
+
 		entryRows = append(entryRows, []any{
 			entryID,
 			registryID,
 			sqlc.EntryTypeSKILL,
 			skill.Name,
-			claims,
+			entryClaims,
 			&now,
 			&now,
 		})
@@ -1018,6 +1399,11 @@ func (d *dbSyncWriter) storeSkills(
 		return err
 	}
 
+	// Merges keep the other skills and recompute the latest versions themselves
+	if !prune {
+		return nil
+	}
+
 	// 4. Delete orphaned skills that no longer exist in upstream
 	if err := d.deleteOrphanedEntries(ctx, tx, registryID, sqlc.EntryTypeSKILL, keepIDs); err != nil {
 		return fmt.Errorf("failed to delete orphaned skills: %w", err)
@@ -1211,18 +1597,24 @@ func pluginKey(namespace, name, version string) string {
 // storePlugins persists plugins from an upstream registry into the database.
 // It follows the same bulk-sync pattern as skill storage: temp tables with COPY,
 // followed by upserts and orphan cleanup. Reuses the shared copyAndUpsertEntries
-// and copyAndUpsertEntryVersions functions.
+// and copyAndUpsertEntryVersions functions. Without prune, the plugins missing
+// from plugins are kept and the latest versions are left to the caller.
 func (d *dbSyncWriter) storePlugins(
 	ctx context.Context,
 	tx pgx.Tx,
 	registryID uuid.UUID,
 	plugins []toolhivetypes.Plugin,
 	claims []byte,
+	perEntryClaims map[string][]byte,
+	prune bool,
 ) error {
 	querier := sqlc.New(tx)
 
 	// If no plugins, clean up any previously synced plugins and return
 	if len(plugins) == 0 {
+		if !prune {
+			return nil
+		}
 		return querier.DeletePluginsByRegistry(ctx, registryID)
 	}
 
@@ -1242,12 +1634,18 @@ func (d *dbSyncWriter) storePlugins(
 			return fmt.Errorf("failed to generate entry ID: %w", err)
 		}
 
+		// Use per-entry claims if available, otherwise fall back to source-level claims
+		entryClaims := claims
+		if ec, ok := perEntryClaims[plugin.Name]; ok {
+			entryClaims = ec
+		}
+
 		entryRows = append(entryRows, []any{
 			entryID,
 			registryID,
 			sqlc.EntryTypePLUGIN,
 			plugin.Name,
-			claims,
+			entryClaims,
 			&now,
 			&now,
 		})
@@ -1320,6 +1718,11 @@ func (d *dbSyncWriter) storePlugins(
 		return err
 	}
 
+	// Merges keep the other plugins and recompute the latest versions themselves
+	if !prune {
+		return nil
+	}
+
 	// 4. Delete orphaned plugins that no longer exist in upstream
 	if err := d.deleteOrphanedEntries(ctx, tx, registryID, sqlc.EntryTypePLUGIN, keepIDs); err != nil {
 		return fmt.Errorf("failed to delete orphaned plugins: %w", err)
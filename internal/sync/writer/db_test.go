@@ -4,6 +4,7 @@ package writer
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"testing"
 	"time"
 
@@ -18,6 +19,8 @@ import (
 
 	"github.com/stacklok/toolhive-registry-server/database"
 	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/pinning"
+	"github.com/stacklok/toolhive-registry-server/internal/scanning"
 )
 
 // Test constants for icon themes and MIME types
@@ -3405,6 +3408,54 @@ func TestDbSyncWriter_Store_PerEntryClaims(t *testing.T) {
 	}
 }
 
+// TestDbSyncWriter_Store_PerSkillAndPluginClaims tests that WithPerSkillClaims and
+// WithPerPluginClaims override source-level claims for specific skills and plugins.
+func TestDbSyncWriter_Store_PerSkillAndPluginClaims(t *testing.T) {
+	t.Parallel()
+
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	ids := createTestRegistry(t, pool, "per-entry-skills-plugins")
+
+	_, err := pool.Exec(ctx, "UPDATE source SET claims = $1 WHERE id = $2", []byte(`{"org":"acme"}`), ids.sourceID)
+	require.NoError(t, err)
+
+	writer, err := NewDBSyncWriter(pool, testMaxMetaSize)
+	require.NoError(t, err)
+
+	reg := createTestUpstreamRegistryWithSkills(nil, []toolhivetypes.Skill{
+		createTestSkill("io.test", "skill1", "1.0.0"),
+		createTestSkill("io.test", "skill2", "1.0.0"),
+	})
+	reg.Data.Plugins = []toolhivetypes.Plugin{
+		createTestPlugin("io.test", "plugin1", "1.0.0"),
+		createTestPlugin("io.test", "plugin2", "1.0.0"),
+	}
+
+	err = writer.Store(ctx, "per-entry-skills-plugins", reg,
+		WithPerSkillClaims(map[string][]byte{"skill1": []byte(`{"team":"data"}`)}),
+		WithPerPluginClaims(map[string][]byte{"plugin2": []byte(`{"team":"platform"}`)}))
+	require.NoError(t, err)
+
+	entries, err := sqlc.New(pool).ListEntriesBySource(ctx, ids.sourceID)
+	require.NoError(t, err)
+
+	got := make(map[string]map[string]string, len(entries))
+	for _, entry := range entries {
+		var claims map[string]string
+		require.NoError(t, json.Unmarshal(entry.Claims, &claims))
+		got[entry.Name] = claims
+	}
+	assert.Equal(t, map[string]map[string]string{
+		"skill1":  {"team": "data"},
+		"skill2":  {"org": "acme"},
+		"plugin1": {"org": "acme"},
+		"plugin2": {"team": "platform"},
+	}, got)
+}
+
 // --- Plugin sync test helpers ---
 
 // createTestPlugin creates a test Plugin (no Compatibility/AllowedTools — plugin-only fields).
@@ -3972,3 +4023,149 @@ func TestDbSyncWriter_Store_PluginMultiVersionLatest(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "3.0.0", latestVersion, "latest_entry_version should point to v3.0.0")
 }
+
+func TestDbSyncWriter_Store_EntryScans(t *testing.T) {
+	t.Parallel()
+
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ids := createTestRegistry(t, pool, "test-registry")
+	writer, err := NewDBSyncWriter(pool, testMaxMetaSize)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	const scansQuery = `
+	SELECT e.name, sc.verdict, COALESCE(sc.findings::text, '')
+	  FROM entry_scan sc
+	  JOIN entry_version v ON sc.version_id = v.id
+	  JOIN registry_entry e ON v.entry_id = e.id
+	 WHERE e.source_id = $1
+	 ORDER BY e.name
+	`
+	listScans := func() []string {
+		rows, err := pool.Query(ctx, scansQuery, ids.sourceID)
+		require.NoError(t, err)
+		defer rows.Close()
+		var scans []string
+		for rows.Next() {
+			var name, verdict, findings string
+			require.NoError(t, rows.Scan(&name, &verdict, &findings))
+			scans = append(scans, name+" "+verdict+" "+findings)
+		}
+		require.NoError(t, rows.Err())
+		return scans
+	}
+
+	reg := createTestUpstreamRegistry([]upstreamv0.ServerJSON{
+		createTestServer("test.org/server-1", "1.0.0"),
+		createTestServer("test.org/server-2", "1.0.0"),
+	})
+	scans := []scanning.EntryScan{
+		{
+			Type:    scanning.EntryTypeServer,
+			Name:    "test.org/server-1",
+			Version: "1.0.0",
+			Result: &scanning.Result{
+				Verdict:  scanning.VerdictAnnotate,
+				Findings: []scanning.Finding{{Scanner: "osv", Message: "vulnerable"}},
+			},
+		},
+		{
+			Type:    scanning.EntryTypeServer,
+			Name:    "test.org/server-2",
+			Version: "1.0.0",
+			Result:  &scanning.Result{Verdict: scanning.VerdictAllow},
+		},
+		// Scans of versions that are not stored are ignored
+		{
+			Type:    scanning.EntryTypeServer,
+			Name:    "test.org/server-3",
+			Version: "1.0.0",
+			Result:  &scanning.Result{Verdict: scanning.VerdictAllow},
+		},
+	}
+	require.NoError(t, writer.Store(ctx, "test-registry", reg, WithEntryScans(scans)))
+	assert.Equal(t, []string{
+		`test.org/server-1 annotate [{"message": "vulnerable", "scanner": "osv"}]`,
+		"test.org/server-2 allow ",
+	}, listScans())
+
+	// A full sync without scans removes those of the previous sync
+	require.NoError(t, writer.Store(ctx, "test-registry", reg))
+	assert.Empty(t, listScans())
+}
+
+func TestDbSyncWriter_Store_PackageDigests(t *testing.T) {
+	t.Parallel()
+
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ids := createTestRegistry(t, pool, "test-registry")
+	writer, err := NewDBSyncWriter(pool, testMaxMetaSize)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	const digestsQuery = `
+	SELECT e.name, pd.digest, COALESCE(pd.previous_digest, ''), pd.drifted_at IS NOT NULL
+	  FROM package_digest pd
+	  JOIN entry_version v ON pd.version_id = v.id
+	  JOIN registry_entry e ON v.entry_id = e.id
+	 WHERE e.source_id = $1
+	 ORDER BY e.name
+	`
+	listDigests := func() []string {
+		rows, err := pool.Query(ctx, digestsQuery, ids.sourceID)
+		require.NoError(t, err)
+		defer rows.Close()
+		var digests []string
+		for rows.Next() {
+			var name, digest, previous string
+			var drifted bool
+			require.NoError(t, rows.Scan(&name, &digest, &previous, &drifted))
+			digests = append(digests, fmt.Sprintf("%s %s %s %t", name, digest, previous, drifted))
+		}
+		require.NoError(t, rows.Err())
+		return digests
+	}
+
+	reg := createTestUpstreamRegistry([]upstreamv0.ServerJSON{
+		createTestServer("test.org/server-1", "1.0.0"),
+		createTestServer("test.org/server-2", "1.0.0"),
+	})
+	packageDigest := func(name, digest string) pinning.PackageDigest {
+		return pinning.PackageDigest{
+			Type:       scanning.EntryTypeServer,
+			Name:       name,
+			Version:    "1.0.0",
+			Identifier: "ghcr.io/" + name + ":1.0.0",
+			Digest:     digest,
+		}
+	}
+
+	require.NoError(t, writer.Store(ctx, "test-registry", reg, WithPackageDigests([]pinning.PackageDigest{
+		packageDigest("test.org/server-1", "sha256:aaaa"),
+		packageDigest("test.org/server-2", "sha256:bbbb"),
+		// Digests of versions that are not stored are ignored
+		packageDigest("test.org/server-3", "sha256:cccc"),
+	})))
+	assert.Equal(t, []string{
+		"test.org/server-1 sha256:aaaa  false",
+		"test.org/server-2 sha256:bbbb  false",
+	}, listDigests())
+
+	// The tag of server-1 moved to another digest; server-2 resolved to the same one
+	require.NoError(t, writer.Store(ctx, "test-registry", reg, WithPackageDigests([]pinning.PackageDigest{
+		packageDigest("test.org/server-1", "sha256:dddd"),
+		packageDigest("test.org/server-2", "sha256:bbbb"),
+	})))
+	assert.Equal(t, []string{
+		"test.org/server-1 sha256:dddd sha256:aaaa true",
+		"test.org/server-2 sha256:bbbb  false",
+	}, listDigests())
+
+	// A sync that does not resolve the tags keeps the stored digests
+	require.NoError(t, writer.Store(ctx, "test-registry", reg))
+	assert.Len(t, listDigests(), 2)
+}
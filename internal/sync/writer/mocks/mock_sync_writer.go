@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	v0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	registry "github.com/stacklok/toolhive-core/registry/types"
 	writer "github.com/stacklok/toolhive-registry-server/internal/sync/writer"
 	gomock "go.uber.org/mock/gomock"
@@ -42,6 +43,26 @@ func (m *MockSyncWriter) EXPECT() *MockSyncWriterMockRecorder {
 	return m.recorder
 }
 
+// Merge mocks base method.
+func (m *MockSyncWriter) Merge(ctx context.Context, registryName string, reg *registry.UpstreamRegistry, deleted []v0.ServerJSON, opts ...writer.StoreOption) (*writer.MergeResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, registryName, reg, deleted}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Merge", varargs...)
+	ret0, _ := ret[0].(*writer.MergeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockSyncWriterMockRecorder) Merge(ctx, registryName, reg, deleted any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, registryName, reg, deleted}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockSyncWriter)(nil).Merge), varargs...)
+}
+
 // Store mocks base method.
 func (m *MockSyncWriter) Store(ctx context.Context, registryName string, reg *registry.UpstreamRegistry, opts ...writer.StoreOption) error {
 	m.ctrl.T.Helper()
@@ -5,7 +5,11 @@ import (
 	"context"
 	"fmt"
 
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	toolhivetypes "github.com/stacklok/toolhive-core/registry/types"
+
+	"github.com/stacklok/toolhive-registry-server/internal/pinning"
+	"github.com/stacklok/toolhive-registry-server/internal/scanning"
 )
 
 //go:generate mockgen -destination=mocks/mock_sync_writer.go -package=mocks -source=writer.go SyncWriter
@@ -14,6 +18,26 @@ import (
 type SyncWriter interface {
 	// Store saves a UpstreamRegistry instance to persistent storage for a specific registry
 	Store(ctx context.Context, registryName string, reg *toolhivetypes.UpstreamRegistry, opts ...StoreOption) error
+
+	// Merge applies an incremental delta to persistent storage for a specific registry.
+	// Entries in reg are upserted, the deleted server versions and those of the
+	// WithDeletedSkills and WithDeletedPlugins options are removed, and all other stored
+	// entries are kept. Returns the number of entry versions stored after the merge.
+	Merge(
+		ctx context.Context,
+		registryName string,
+		reg *toolhivetypes.UpstreamRegistry,
+		deleted []upstreamv0.ServerJSON,
+		opts ...StoreOption,
+	) (*MergeResult, error)
+}
+
+// MergeResult holds the number of entry versions of each type stored for a
+// registry after a Merge.
+type MergeResult struct {
+	ServerCount int
+	SkillCount  int
+	PluginCount int
 }
 
 // storeOptions holds optional parameters for a Store call.
@@ -22,6 +46,27 @@ type storeOptions struct {
 	// When set, entries use these claims instead of the source-level claims.
 	// Entries not present in the map fall back to source-level claims.
 	PerEntryClaims map[string][]byte
+
+	// PerSkillClaims and PerPluginClaims map skill and plugin names to their
+	// individual claims JSON, like PerEntryClaims for servers.
+	PerSkillClaims  map[string][]byte
+	PerPluginClaims map[string][]byte
+
+	// EntryScans are the results of the scan of the stored entry versions by the
+	// entry policy. Store replaces the scans of the source with them; Merge only
+	// updates the scans of the given versions.
+	EntryScans []scanning.EntryScan
+
+	// PackageDigests are the digests the tags of the OCI packages of the stored
+	// entry versions resolved to. Digests of packages that are not given keep
+	// their stored value.
+	PackageDigests []pinning.PackageDigest
+
+	// DeletedSkills and DeletedPlugins are the skill and plugin versions Merge
+	// removes, like its deleted server versions. Only the name and version of
+	// each are used.
+	DeletedSkills  []toolhivetypes.Skill
+	DeletedPlugins []toolhivetypes.Plugin
 }
 
 // StoreOption is a function that configures storeOptions.
@@ -35,6 +80,55 @@ func WithPerEntryClaims(claims map[string][]byte) StoreOption {
 	}
 }
 
+// WithPerSkillClaims provides per-entry claims of skills that override source-level claims.
+func WithPerSkillClaims(claims map[string][]byte) StoreOption {
+	return func(o *storeOptions) error {
+		o.PerSkillClaims = claims
+		return nil
+	}
+}
+
+// WithPerPluginClaims provides per-entry claims of plugins that override source-level claims.
+func WithPerPluginClaims(claims map[string][]byte) StoreOption {
+	return func(o *storeOptions) error {
+		o.PerPluginClaims = claims
+		return nil
+	}
+}
+
+// WithEntryScans provides the results of the scan of the stored entry versions.
+func WithEntryScans(scans []scanning.EntryScan) StoreOption {
+	return func(o *storeOptions) error {
+		o.EntryScans = scans
+		return nil
+	}
+}
+
+// WithPackageDigests provides the digests the OCI packages of the stored entry
+// versions resolved to.
+func WithPackageDigests(digests []pinning.PackageDigest) StoreOption {
+	return func(o *storeOptions) error {
+		o.PackageDigests = digests
+		return nil
+	}
+}
+
+// WithDeletedSkills provides the skill versions removed by a Merge.
+func WithDeletedSkills(skills []toolhivetypes.Skill) StoreOption {
+	return func(o *storeOptions) error {
+		o.DeletedSkills = skills
+		return nil
+	}
+}
+
+// WithDeletedPlugins provides the plugin versions removed by a Merge.
+func WithDeletedPlugins(plugins []toolhivetypes.Plugin) StoreOption {
+	return func(o *storeOptions) error {
+		o.DeletedPlugins = plugins
+		return nil
+	}
+}
+
 // parseStoreOptions applies all options and returns the resulting config.
 func parseStoreOptions(opts []StoreOption) (*storeOptions, error) {
 	o := &storeOptions{}
@@ -4,6 +4,7 @@ package telemetry
 import (
 	"context"
 	"log/slog"
+	"strconv"
 	"sync"
 	"time"
 
@@ -327,6 +328,10 @@ type SyncMetrics struct {
 	// the fixed "sync" value. Orthogonal to the outcome label on
 	// syncDuration, which only distinguishes success from failure.
 	errorsTotal metric.Int64Counter
+	// workerSyncs counts the syncs run by each coordinator worker, by outcome.
+	workerSyncs metric.Int64Counter
+	// workersBusy is 1 while a coordinator worker is running a sync and 0 otherwise.
+	workersBusy metric.Int64UpDownCounter
 }
 
 // NewSyncMetrics creates a new SyncMetrics instance with the given meter provider.
@@ -353,9 +358,29 @@ func NewSyncMetrics(provider metric.MeterProvider) (*SyncMetrics, error) {
 		return nil, err
 	}
 
+	workerSyncs, err := meter.Int64Counter(
+		"stacklok.registry.sync.worker.syncs",
+		metric.WithDescription("Number of syncs run by each sync worker"),
+		metric.WithUnit("{sync}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	workersBusy, err := meter.Int64UpDownCounter(
+		"stacklok.registry.sync.workers.busy",
+		metric.WithDescription("Whether each sync worker is currently running a sync"),
+		metric.WithUnit("{worker}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return &SyncMetrics{
 		syncDuration: syncDuration,
 		errorsTotal:  errorsTotal,
+		workerSyncs:  workerSyncs,
+		workersBusy:  workersBusy,
 	}, nil
 }
 
@@ -398,3 +423,39 @@ func (m *SyncMetrics) RecordSyncDuration(ctx context.Context, sourceName string,
 
 	m.syncDuration.Record(ctx, duration.Seconds(), metric.WithAttributes(attrs...))
 }
+
+// RecordWorkerSync increments the sync count of a coordinator worker. The
+// worker label is the index of the worker in the coordinator's pool, so its
+// cardinality is bounded by the configured sync concurrency.
+func (m *SyncMetrics) RecordWorkerSync(ctx context.Context, worker int, success bool) {
+	if m == nil || m.workerSyncs == nil {
+		return
+	}
+
+	outcome := coremetrics.OutcomeSuccess
+	if !success {
+		outcome = coremetrics.OutcomeError
+	}
+
+	m.workerSyncs.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("worker", strconv.Itoa(worker)),
+		attribute.String(coremetrics.LabelOutcome, outcome),
+	))
+}
+
+// RecordWorkerBusy marks a coordinator worker as busy when it starts a sync
+// and as idle when the sync ends.
+func (m *SyncMetrics) RecordWorkerBusy(ctx context.Context, worker int, busy bool) {
+	if m == nil || m.workersBusy == nil {
+		return
+	}
+
+	delta := int64(-1)
+	if busy {
+		delta = 1
+	}
+
+	m.workersBusy.Add(ctx, delta, metric.WithAttributes(
+		attribute.String("worker", strconv.Itoa(worker)),
+	))
+}
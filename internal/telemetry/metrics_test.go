@@ -601,3 +601,85 @@ func TestSyncMetrics_RecordSyncError(t *testing.T) {
 		assert.True(t, sum.DataPoints[0].Attributes.Equals(&expectedAttrs))
 	})
 }
+
+func TestSyncMetrics_RecordWorkerSync(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no-op when metrics is nil", func(t *testing.T) {
+		t.Parallel()
+
+		var metrics *SyncMetrics
+		// Should not panic
+		metrics.RecordWorkerSync(context.Background(), 0, true)
+		metrics.RecordWorkerBusy(context.Background(), 0, true)
+	})
+
+	t.Run("records syncs per worker and outcome", func(t *testing.T) {
+		t.Parallel()
+
+		reader := sdkmetric.NewManualReader()
+		mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+		defer func() { _ = mp.Shutdown(context.Background()) }()
+
+		metrics, err := NewSyncMetrics(mp)
+		require.NoError(t, err)
+		require.NotNil(t, metrics)
+
+		metrics.RecordWorkerSync(context.Background(), 0, true)
+		metrics.RecordWorkerSync(context.Background(), 0, true)
+		metrics.RecordWorkerSync(context.Background(), 1, false)
+
+		var rm metricdata.ResourceMetrics
+		err = reader.Collect(context.Background(), &rm)
+		require.NoError(t, err)
+
+		sum := findInt64Sum(t, rm, "stacklok.registry.sync.worker.syncs")
+		require.Len(t, sum.DataPoints, 2)
+
+		successAttrs := attribute.NewSet(attribute.String("worker", "0"), attribute.String("outcome", "success"))
+		errorAttrs := attribute.NewSet(attribute.String("worker", "1"), attribute.String("outcome", "error"))
+		want := map[attribute.Distinct]int64{
+			successAttrs.Equivalent(): 2,
+			errorAttrs.Equivalent():   1,
+		}
+		for _, dp := range sum.DataPoints {
+			expected, ok := want[dp.Attributes.Equivalent()]
+			require.True(t, ok, "unexpected data point %v", dp.Attributes)
+			assert.Equal(t, expected, dp.Value)
+		}
+	})
+
+	t.Run("tracks busy workers", func(t *testing.T) {
+		t.Parallel()
+
+		reader := sdkmetric.NewManualReader()
+		mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+		defer func() { _ = mp.Shutdown(context.Background()) }()
+
+		metrics, err := NewSyncMetrics(mp)
+		require.NoError(t, err)
+		require.NotNil(t, metrics)
+
+		metrics.RecordWorkerBusy(context.Background(), 0, true)
+		metrics.RecordWorkerBusy(context.Background(), 1, true)
+		metrics.RecordWorkerBusy(context.Background(), 1, false)
+
+		var rm metricdata.ResourceMetrics
+		err = reader.Collect(context.Background(), &rm)
+		require.NoError(t, err)
+
+		sum := findInt64Sum(t, rm, "stacklok.registry.sync.workers.busy")
+		require.Len(t, sum.DataPoints, 2)
+		for _, dp := range sum.DataPoints {
+			worker, _ := dp.Attributes.Value(attribute.Key("worker"))
+			switch worker.AsString() {
+			case "0":
+				assert.Equal(t, int64(1), dp.Value)
+			case "1":
+				assert.Equal(t, int64(0), dp.Value)
+			default:
+				t.Fatalf("unexpected worker %q", worker.AsString())
+			}
+		}
+	})
+}
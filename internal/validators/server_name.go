@@ -101,6 +101,25 @@ func ValidateServerName(name string) (string, error) {
 	return name, nil
 }
 
+// ValidateNamespace validates a reverse-DNS namespace: the part of a server
+// name before the '/' separator, or the namespace of a skill or plugin.
+func ValidateNamespace(namespace string) error {
+	if namespace == "" {
+		return fmt.Errorf("namespace cannot be empty")
+	}
+	if len(namespace) > maxServerNameLength {
+		return fmt.Errorf("namespace exceeds maximum length of %d characters", maxServerNameLength)
+	}
+	if !namespacePattern.MatchString(namespace) {
+		return fmt.Errorf(
+			"namespace '%s' is invalid. Namespace must start and end with alphanumeric characters, "+
+				"and may contain dots and hyphens in the middle",
+			namespace,
+		)
+	}
+	return nil
+}
+
 // IsValidServerName checks if a server name is valid according to the MCP Registry specification.
 // Returns true if valid, false otherwise.
 // This is a convenience wrapper around ValidateServerName for boolean checks.
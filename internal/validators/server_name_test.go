@@ -301,3 +301,36 @@ func TestIsValidServerName(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateNamespace(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		namespace   string
+		expectError string
+	}{
+		{name: "reverse-DNS namespace", namespace: "com.example"},
+		{name: "nested namespace", namespace: "com.example.tools"},
+		{name: "single label", namespace: "example"},
+		{name: "empty", namespace: "", expectError: "cannot be empty"},
+		{name: "server name", namespace: "com.example/server", expectError: "is invalid"},
+		{name: "trailing dot", namespace: "com.example.", expectError: "is invalid"},
+		{name: "too long", namespace: strings.Repeat("a", 201), expectError: "exceeds maximum length"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateNamespace(tt.namespace)
+			if tt.expectError == "" {
+				if err != nil {
+					t.Errorf("Expected valid, got error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+				t.Errorf("Expected error containing %q, got %v", tt.expectError, err)
+			}
+		})
+	}
+}